@@ -67,6 +67,10 @@ type Orderer interface {
 	// ConsensusType returns the configured consensus type
 	ConsensusType() string
 
+	// ConsensusState returns whether the chain is in normal operation or in maintenance mode,
+	// in which only config transactions are ordered and the consensus type may be changed
+	ConsensusState() ab.ConsensusType_State
+
 	// BatchSize returns the maximum number of messages to include in a block
 	BatchSize() *ab.BatchSize
 
@@ -84,6 +88,21 @@ type Orderer interface {
 	// Kafka brokers, i.e. this is not necessarily the entire set of Kafka brokers
 	// used for ordering
 	KafkaBrokers() []string
+
+	// KafkaTopic returns the checks the Kafka topic of the chain must pass
+	KafkaTopic() *ab.KafkaTopic
+
+	// RaftConsenters returns the orderers which replicate the raft log of the chain
+	RaftConsenters() []*ab.RaftConsenter
+
+	// SbftMembership returns the replicas which order the transactions of the chain
+	SbftMembership() *ab.SbftMembership
+
+	// RateLimits returns the limits on the rate at which broadcast messages are admitted
+	RateLimits() *ab.RateLimits
+
+	// Sealed returns whether the chain rejects new transactions, while its blocks are still delivered
+	Sealed() bool
 }
 
 type ValueProposer interface {
@@ -17,13 +17,16 @@ limitations under the License.
 package config
 
 import (
+	"encoding/pem"
 	"fmt"
 	"regexp"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/config/msp"
+	fabricmsp "github.com/hyperledger/fabric/msp"
 	ab "github.com/hyperledger/fabric/protos/orderer"
 )
 
@@ -50,6 +53,21 @@ const (
 
 	// KafkaBrokersKey is the cb.ConfigItem type key name for the KafkaBrokers message
 	KafkaBrokersKey = "KafkaBrokers"
+
+	// KafkaTopicKey is the cb.ConfigItem type key name for the KafkaTopic message
+	KafkaTopicKey = "KafkaTopic"
+
+	// RaftConsentersKey is the cb.ConfigItem type key name for the RaftConsenters message
+	RaftConsentersKey = "RaftConsenters"
+
+	// SbftMembershipKey is the cb.ConfigItem type key name for the SbftMembership message
+	SbftMembershipKey = "SbftMembership"
+
+	// RateLimitsKey is the cb.ConfigItem type key name for the RateLimits message
+	RateLimitsKey = "RateLimits"
+
+	// ChannelStateKey is the cb.ConfigItem type key name for the ChannelState message
+	ChannelStateKey = "ChannelState"
 )
 
 // OrdererProtos is used as the source of the OrdererConfig
@@ -59,8 +77,13 @@ type OrdererProtos struct {
 	BatchTimeout             *ab.BatchTimeout
 	ChainCreationPolicyNames *ab.ChainCreationPolicyNames
 	KafkaBrokers             *ab.KafkaBrokers
+	KafkaTopic               *ab.KafkaTopic
+	RaftConsenters           *ab.RaftConsenters
+	SbftMembership           *ab.SbftMembership
 	CreationPolicy           *ab.CreationPolicy
 	ChannelRestrictions      *ab.ChannelRestrictions
+	RateLimits               *ab.RateLimits
+	ChannelState             *ab.ChannelState
 }
 
 // Config is stores the orderer component configuration
@@ -123,6 +146,11 @@ func (oc *OrdererConfig) ConsensusType() string {
 	return oc.protos.ConsensusType.Type
 }
 
+// ConsensusState returns whether the chain is in normal operation or in maintenance mode
+func (oc *OrdererConfig) ConsensusState() ab.ConsensusType_State {
+	return oc.protos.ConsensusType.State
+}
+
 // BatchSize returns the maximum number of messages to include in a block
 func (oc *OrdererConfig) BatchSize() *ab.BatchSize {
 	return oc.protos.BatchSize
@@ -146,6 +174,31 @@ func (oc *OrdererConfig) KafkaBrokers() []string {
 	return oc.protos.KafkaBrokers.Brokers
 }
 
+// KafkaTopic returns the checks the Kafka topic of the chain must pass
+func (oc *OrdererConfig) KafkaTopic() *ab.KafkaTopic {
+	return oc.protos.KafkaTopic
+}
+
+// RaftConsenters returns the orderers which replicate the raft log of the chain
+func (oc *OrdererConfig) RaftConsenters() []*ab.RaftConsenter {
+	return oc.protos.RaftConsenters.Consenters
+}
+
+// SbftMembership returns the replicas which order the transactions of the chain
+func (oc *OrdererConfig) SbftMembership() *ab.SbftMembership {
+	return oc.protos.SbftMembership
+}
+
+// RateLimits returns the limits on the rate at which broadcast messages are admitted
+func (oc *OrdererConfig) RateLimits() *ab.RateLimits {
+	return oc.protos.RateLimits
+}
+
+// Sealed returns whether the chain rejects new transactions
+func (oc *OrdererConfig) Sealed() bool {
+	return oc.protos.ChannelState.Sealed
+}
+
 // MaxChannelsCount returns the maximum count of channels this orderer supports
 func (oc *OrdererConfig) MaxChannelsCount() uint64 {
 	return oc.protos.ChannelRestrictions.MaxCount
@@ -157,6 +210,9 @@ func (oc *OrdererConfig) Validate(tx interface{}, groups map[string]ValuePropose
 		oc.validateBatchSize,
 		oc.validateBatchTimeout,
 		oc.validateKafkaBrokers,
+		oc.validateRaftConsenters,
+		oc.validateSbftMembership,
+		oc.validateChannelState,
 	} {
 		if err := validator(); err != nil {
 			return err
@@ -167,9 +223,13 @@ func (oc *OrdererConfig) Validate(tx interface{}, groups map[string]ValuePropose
 }
 
 func (oc *OrdererConfig) validateConsensusType() error {
-	if oc.ordererGroup.OrdererConfig != nil && oc.ordererGroup.ConsensusType() != oc.protos.ConsensusType.Type {
+	if oc.ordererGroup.OrdererConfig == nil || oc.ordererGroup.ConsensusType() == oc.protos.ConsensusType.Type {
 		// The first config we accept the consensus type regardless
-		return fmt.Errorf("Attempted to change the consensus type from %s to %s after init", oc.ordererGroup.ConsensusType(), oc.protos.ConsensusType.Type)
+		return nil
+	}
+	// The consensus type is migrated by entering maintenance mode, changing the type, then leaving maintenance mode
+	if oc.ordererGroup.ConsensusState() != ab.ConsensusType_STATE_MAINTENANCE || oc.protos.ConsensusType.State != ab.ConsensusType_STATE_MAINTENANCE {
+		return fmt.Errorf("Attempted to change the consensus type from %s to %s outside of maintenance mode", oc.ordererGroup.ConsensusType(), oc.protos.ConsensusType.Type)
 	}
 	return nil
 }
@@ -211,6 +271,107 @@ func (oc *OrdererConfig) validateKafkaBrokers() error {
 	return nil
 }
 
+// validateRaftConsenters checks the consenter set. The consenters of a raft
+// chain authenticate each other by their client TLS certificates, and as
+// there is no joint consensus a config update adds or removes at most one
+// of them.
+func (oc *OrdererConfig) validateRaftConsenters() error {
+	isRaft := oc.protos.ConsensusType != nil && oc.protos.ConsensusType.Type == "raft"
+	seen := make(map[string]struct{})
+	for _, consenter := range oc.protos.RaftConsenters.Consenters {
+		if !brokerEntrySeemsValid(consenter.Address) {
+			return fmt.Errorf("Invalid raft consenter entry: %s", consenter.Address)
+		}
+		if _, ok := seen[consenter.Address]; ok {
+			return fmt.Errorf("Duplicate raft consenter entry: %s", consenter.Address)
+		}
+		seen[consenter.Address] = struct{}{}
+		if len(consenter.ClientTlsCert) == 0 && isRaft {
+			return fmt.Errorf("Raft consenter %s has no client TLS certificate", consenter.Address)
+		}
+		if len(consenter.ClientTlsCert) > 0 {
+			if block, _ := pem.Decode(consenter.ClientTlsCert); block == nil {
+				return fmt.Errorf("Invalid client TLS certificate of raft consenter %s", consenter.Address)
+			}
+		}
+	}
+	if isRaft && len(oc.protos.RaftConsenters.Consenters) == 0 {
+		return fmt.Errorf("Attempted to set an empty raft consenter set")
+	}
+
+	if !isRaft || oc.ordererGroup == nil || oc.ordererGroup.OrdererConfig == nil || oc.ordererGroup.ConsensusType() != "raft" {
+		// The first raft consenter set is accepted regardless
+		return nil
+	}
+	changes := 0
+	previous := make(map[string]struct{})
+	for _, consenter := range oc.ordererGroup.RaftConsenters() {
+		previous[consenter.Address] = struct{}{}
+		if _, ok := seen[consenter.Address]; !ok {
+			changes++
+		}
+	}
+	for address := range seen {
+		if _, ok := previous[address]; !ok {
+			changes++
+		}
+	}
+	if changes > 1 {
+		return fmt.Errorf("Attempted to change %d raft consenters at once, only one consenter may be added or removed per config update", changes)
+	}
+	return nil
+}
+
+func (oc *OrdererConfig) validateSbftMembership() error {
+	seenAddresses := make(map[string]struct{})
+	seenCerts := make(map[string]struct{})
+	for _, replica := range oc.protos.SbftMembership.Replicas {
+		if !brokerEntrySeemsValid(replica.Address) {
+			return fmt.Errorf("Invalid SBFT replica entry: %s", replica.Address)
+		}
+		if _, ok := seenAddresses[replica.Address]; ok {
+			return fmt.Errorf("Duplicate SBFT replica entry: %s", replica.Address)
+		}
+		seenAddresses[replica.Address] = struct{}{}
+		block, _ := pem.Decode(replica.Cert)
+		if block == nil {
+			return fmt.Errorf("Invalid certificate of SBFT replica %s", replica.Address)
+		}
+		if _, ok := seenCerts[string(block.Bytes)]; ok {
+			return fmt.Errorf("Duplicate certificate of SBFT replica %s", replica.Address)
+		}
+		seenCerts[string(block.Bytes)] = struct{}{}
+		identity := &fabricmsp.SerializedIdentity{}
+		if err := proto.Unmarshal(replica.Identity, identity); err != nil || identity.Mspid == "" {
+			return fmt.Errorf("Invalid orderer identity of SBFT replica %s", replica.Address)
+		}
+	}
+	if oc.protos.ConsensusType == nil || oc.protos.ConsensusType.Type != "sbft" {
+		return nil
+	}
+	n := uint64(len(oc.protos.SbftMembership.Replicas))
+	if n == 0 {
+		return fmt.Errorf("Attempted to set an empty SBFT replica set")
+	}
+	if 3*oc.protos.SbftMembership.F+1 > n {
+		return fmt.Errorf("Attempted to tolerate %d faulty SBFT replicas out of %d", oc.protos.SbftMembership.F, n)
+	}
+	if oc.protos.SbftMembership.RequestTimeoutNsec == 0 {
+		return fmt.Errorf("Attempted to set the SBFT request timeout to an invalid value: 0")
+	}
+	return nil
+}
+
+func (oc *OrdererConfig) validateChannelState() error {
+	if oc.ordererGroup.OrdererConfig != nil && oc.ordererGroup.Sealed() && !oc.protos.ChannelState.Sealed {
+		return fmt.Errorf("Attempted to unseal a sealed channel")
+	}
+	if oc.protos.ChannelState.Sealed && len(oc.protos.ChainCreationPolicyNames.Names) > 0 {
+		return fmt.Errorf("Attempted to seal the ordering system channel")
+	}
+	return nil
+}
+
 // This does just a barebones sanity check.
 func brokerEntrySeemsValid(broker string) bool {
 	if !strings.Contains(broker, ":") {
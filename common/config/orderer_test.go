@@ -17,9 +17,12 @@ limitations under the License.
 package config
 
 import (
+	"encoding/pem"
 	"testing"
 
+	fabricmsp "github.com/hyperledger/fabric/msp"
 	ab "github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric/protos/utils"
 
 	logging "github.com/op/go-logging"
 	"github.com/stretchr/testify/assert"
@@ -44,6 +47,25 @@ func TestConsensusType(t *testing.T) {
 		protos:       &OrdererProtos{ConsensusType: &ab.ConsensusType{Type: "foo"}},
 	}
 	assert.Error(t, oc.validateConsensusType(), "Should have failed to change consensus type")
+
+	maintenance := ab.ConsensusType_STATE_MAINTENANCE
+	oc = &OrdererConfig{
+		ordererGroup: &OrdererGroup{OrdererConfig: &OrdererConfig{protos: &OrdererProtos{ConsensusType: &ab.ConsensusType{Type: "bar", State: maintenance}}}},
+		protos:       &OrdererProtos{ConsensusType: &ab.ConsensusType{Type: "foo", State: maintenance}},
+	}
+	assert.NoError(t, oc.validateConsensusType(), "Should have changed consensus type in maintenance mode")
+
+	oc = &OrdererConfig{
+		ordererGroup: &OrdererGroup{OrdererConfig: &OrdererConfig{protos: &OrdererProtos{ConsensusType: &ab.ConsensusType{Type: "bar"}}}},
+		protos:       &OrdererProtos{ConsensusType: &ab.ConsensusType{Type: "foo", State: maintenance}},
+	}
+	assert.Error(t, oc.validateConsensusType(), "Should have failed to change consensus type while entering maintenance mode")
+
+	oc = &OrdererConfig{
+		ordererGroup: &OrdererGroup{OrdererConfig: &OrdererConfig{protos: &OrdererProtos{ConsensusType: &ab.ConsensusType{Type: "bar", State: maintenance}}}},
+		protos:       &OrdererProtos{ConsensusType: &ab.ConsensusType{Type: "foo"}},
+	}
+	assert.Error(t, oc.validateConsensusType(), "Should have failed to change consensus type while leaving maintenance mode")
 }
 
 func TestBatchSize(t *testing.T) {
@@ -83,3 +105,145 @@ func TestKafkaBrokers(t *testing.T) {
 	oc = &OrdererConfig{protos: &OrdererProtos{KafkaBrokers: &ab.KafkaBrokers{Brokers: []string{"127.0.0.1", "foo.bar", "127.0.0.1:-1", "localhost:65536", "foo.bar.:9092", ".127.0.0.1:9092", "-foo.bar:9092"}}}}
 	assert.Error(t, oc.validateKafkaBrokers(), "Invalid kafka brokers")
 }
+
+func TestRaftConsenters(t *testing.T) {
+	consenter := func(address string) *ab.RaftConsenter {
+		return &ab.RaftConsenter{Address: address, ClientTlsCert: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: []byte(address)})}
+	}
+
+	oc := &OrdererConfig{protos: &OrdererProtos{
+		ConsensusType:  &ab.ConsensusType{Type: "raft"},
+		RaftConsenters: &ab.RaftConsenters{Consenters: []*ab.RaftConsenter{consenter("127.0.0.1:7050"), consenter("orderer1.example.com:7050")}},
+	}}
+	assert.NoError(t, oc.validateRaftConsenters(), "Valid raft consenters")
+
+	oc.protos.RaftConsenters.Consenters = []*ab.RaftConsenter{consenter("127.0.0.1:7050"), consenter("127.0.0.1:7050")}
+	assert.Error(t, oc.validateRaftConsenters(), "Duplicate raft consenters")
+
+	oc.protos.RaftConsenters.Consenters = []*ab.RaftConsenter{consenter("orderer1.example.com")}
+	assert.Error(t, oc.validateRaftConsenters(), "Invalid raft consenters")
+
+	oc.protos.RaftConsenters.Consenters = []*ab.RaftConsenter{{Address: "127.0.0.1:7050", ClientTlsCert: []byte("not a certificate")}}
+	assert.Error(t, oc.validateRaftConsenters(), "Invalid raft consenter certificate")
+
+	oc.protos.RaftConsenters.Consenters = []*ab.RaftConsenter{{Address: "127.0.0.1:7050"}}
+	assert.Error(t, oc.validateRaftConsenters(), "Raft consenter without certificate")
+
+	oc.protos.ConsensusType.Type = "solo"
+	assert.NoError(t, oc.validateRaftConsenters(), "Raft consenter without certificate on a solo chain")
+	oc.protos.ConsensusType.Type = "raft"
+
+	oc.protos.RaftConsenters.Consenters = nil
+	assert.Error(t, oc.validateRaftConsenters(), "Empty raft consenter set")
+}
+
+func TestRaftConsenterChanges(t *testing.T) {
+	consenters := func(addresses ...string) *ab.RaftConsenters {
+		rc := &ab.RaftConsenters{}
+		for _, address := range addresses {
+			rc.Consenters = append(rc.Consenters, &ab.RaftConsenter{Address: address, ClientTlsCert: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: []byte(address)})})
+		}
+		return rc
+	}
+
+	og := NewOrdererGroup(nil)
+	current := NewOrdererConfig(og)
+	current.protos.ConsensusType = &ab.ConsensusType{Type: "raft"}
+	current.protos.RaftConsenters = consenters("orderer0:7050", "orderer1:7050", "orderer2:7050")
+	current.Commit()
+
+	oc := NewOrdererConfig(og)
+	oc.protos.ConsensusType = &ab.ConsensusType{Type: "raft"}
+
+	oc.protos.RaftConsenters = consenters("orderer0:7050", "orderer1:7050", "orderer2:7050", "orderer3:7050")
+	assert.NoError(t, oc.validateRaftConsenters(), "Adding one consenter")
+
+	oc.protos.RaftConsenters = consenters("orderer0:7050", "orderer1:7050")
+	assert.NoError(t, oc.validateRaftConsenters(), "Removing one consenter")
+
+	oc.protos.RaftConsenters = consenters("orderer0:7050", "orderer1:7050", "orderer2:7050", "orderer3:7050", "orderer4:7050")
+	assert.Error(t, oc.validateRaftConsenters(), "Adding two consenters")
+
+	oc.protos.RaftConsenters = consenters("orderer0:7050", "orderer1:7050", "orderer3:7050")
+	assert.Error(t, oc.validateRaftConsenters(), "Replacing a consenter")
+}
+
+func TestSbftMembership(t *testing.T) {
+	replica := func(address string, cert string) *ab.SbftReplica {
+		return &ab.SbftReplica{
+			Address:  address,
+			Cert:     pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: []byte(cert)}),
+			Identity: utils.MarshalOrPanic(&fabricmsp.SerializedIdentity{Mspid: "SampleOrg", IdBytes: []byte(cert)}),
+		}
+	}
+	oc := &OrdererConfig{protos: &OrdererProtos{
+		ConsensusType: &ab.ConsensusType{Type: "sbft"},
+		SbftMembership: &ab.SbftMembership{F: 1, RequestTimeoutNsec: 1000000000, Replicas: []*ab.SbftReplica{
+			replica("orderer0.example.com:6101", "cert0"),
+			replica("orderer1.example.com:6101", "cert1"),
+			replica("orderer2.example.com:6101", "cert2"),
+			replica("orderer3.example.com:6101", "cert3"),
+		}},
+	}}
+	assert.NoError(t, oc.validateSbftMembership(), "Valid SBFT membership")
+
+	oc.protos.SbftMembership.Replicas = oc.protos.SbftMembership.Replicas[:3]
+	assert.Error(t, oc.validateSbftMembership(), "Too few SBFT replicas for f")
+
+	oc.protos.SbftMembership.F = 0
+	assert.NoError(t, oc.validateSbftMembership(), "Valid SBFT membership without fault tolerance")
+
+	oc.protos.SbftMembership.RequestTimeoutNsec = 0
+	assert.Error(t, oc.validateSbftMembership(), "Invalid SBFT request timeout")
+	oc.protos.SbftMembership.RequestTimeoutNsec = 1000000000
+
+	oc.protos.SbftMembership.Replicas = []*ab.SbftReplica{replica("orderer0.example.com:6101", "cert0"), replica("orderer0.example.com:6101", "cert1")}
+	assert.Error(t, oc.validateSbftMembership(), "Duplicate SBFT replica address")
+
+	oc.protos.SbftMembership.Replicas = []*ab.SbftReplica{replica("orderer0.example.com:6101", "cert0"), replica("orderer1.example.com:6101", "cert0")}
+	assert.Error(t, oc.validateSbftMembership(), "Duplicate SBFT replica certificate")
+
+	oc.protos.SbftMembership.Replicas = []*ab.SbftReplica{{Address: "orderer0.example.com:6101", Cert: []byte("not a certificate")}}
+	assert.Error(t, oc.validateSbftMembership(), "Invalid SBFT replica certificate")
+
+	oc.protos.SbftMembership.Replicas = []*ab.SbftReplica{replica("orderer0.example.com:6101", "cert0")}
+	oc.protos.SbftMembership.Replicas[0].Identity = nil
+	assert.Error(t, oc.validateSbftMembership(), "Missing SBFT replica orderer identity")
+
+	oc.protos.SbftMembership.Replicas = []*ab.SbftReplica{replica("orderer0.example.com", "cert0")}
+	assert.Error(t, oc.validateSbftMembership(), "Invalid SBFT replica address")
+
+	oc.protos.SbftMembership.Replicas = nil
+	assert.Error(t, oc.validateSbftMembership(), "Empty SBFT replica set")
+
+	oc.protos.ConsensusType.Type = "solo"
+	assert.NoError(t, oc.validateSbftMembership(), "Empty SBFT replica set of another consensus type")
+}
+
+func TestChannelState(t *testing.T) {
+	sealedProtos := func() *OrdererProtos {
+		return &OrdererProtos{ChannelState: &ab.ChannelState{Sealed: true}, ChainCreationPolicyNames: &ab.ChainCreationPolicyNames{}}
+	}
+	unsealedProtos := func() *OrdererProtos {
+		return &OrdererProtos{ChannelState: &ab.ChannelState{}, ChainCreationPolicyNames: &ab.ChainCreationPolicyNames{}}
+	}
+
+	oc := &OrdererConfig{ordererGroup: &OrdererGroup{}, protos: sealedProtos()}
+	assert.NoError(t, oc.validateChannelState(), "Should have sealed the channel")
+
+	oc = &OrdererConfig{
+		ordererGroup: &OrdererGroup{OrdererConfig: &OrdererConfig{protos: unsealedProtos()}},
+		protos:       sealedProtos(),
+	}
+	assert.NoError(t, oc.validateChannelState(), "Should have sealed the unsealed channel")
+
+	oc = &OrdererConfig{
+		ordererGroup: &OrdererGroup{OrdererConfig: &OrdererConfig{protos: sealedProtos()}},
+		protos:       unsealedProtos(),
+	}
+	assert.Error(t, oc.validateChannelState(), "Should have failed to unseal the channel")
+
+	oc = &OrdererConfig{ordererGroup: &OrdererGroup{}, protos: sealedProtos()}
+	oc.protos.ChainCreationPolicyNames.Names = []string{"foo"}
+	assert.Error(t, oc.validateChannelState(), "Should have failed to seal the system channel")
+}
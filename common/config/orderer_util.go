@@ -36,6 +36,11 @@ func TemplateConsensusType(typeValue string) *cb.ConfigGroup {
 	return ordererConfigGroup(ConsensusTypeKey, utils.MarshalOrPanic(&ab.ConsensusType{Type: typeValue}))
 }
 
+// TemplateConsensusTypeState creates a headerless config item representing the consensus type and state
+func TemplateConsensusTypeState(typeValue string, state ab.ConsensusType_State) *cb.ConfigGroup {
+	return ordererConfigGroup(ConsensusTypeKey, utils.MarshalOrPanic(&ab.ConsensusType{Type: typeValue, State: state}))
+}
+
 // TemplateBatchSize creates a headerless config item representing the batch size
 func TemplateBatchSize(batchSize *ab.BatchSize) *cb.ConfigGroup {
 	return ordererConfigGroup(BatchSizeKey, utils.MarshalOrPanic(batchSize))
@@ -56,6 +61,31 @@ func TemplateChannelRestrictions(maxChannels uint64) *cb.ConfigGroup {
 	return ordererConfigGroup(ChannelRestrictionsKey, utils.MarshalOrPanic(&ab.ChannelRestrictions{MaxCount: maxChannels}))
 }
 
+// TemplateRaftConsenters creates a headerless config item representing the raft consenter set
+func TemplateRaftConsenters(consenters []*ab.RaftConsenter) *cb.ConfigGroup {
+	return ordererConfigGroup(RaftConsentersKey, utils.MarshalOrPanic(&ab.RaftConsenters{Consenters: consenters}))
+}
+
+// TemplateSbftMembership creates a headerless config item representing the SBFT replica set
+func TemplateSbftMembership(membership *ab.SbftMembership) *cb.ConfigGroup {
+	return ordererConfigGroup(SbftMembershipKey, utils.MarshalOrPanic(membership))
+}
+
+// TemplateRateLimits creates a headerless config item representing the broadcast rate limits
+func TemplateRateLimits(rateLimits *ab.RateLimits) *cb.ConfigGroup {
+	return ordererConfigGroup(RateLimitsKey, utils.MarshalOrPanic(rateLimits))
+}
+
+// TemplateChannelState creates a headerless config item representing the channel state
+func TemplateChannelState(sealed bool) *cb.ConfigGroup {
+	return ordererConfigGroup(ChannelStateKey, utils.MarshalOrPanic(&ab.ChannelState{Sealed: sealed}))
+}
+
+// TemplateKafkaTopic creates a headerless config item representing the checks of the kafka topic
+func TemplateKafkaTopic(topic *ab.KafkaTopic) *cb.ConfigGroup {
+	return ordererConfigGroup(KafkaTopicKey, utils.MarshalOrPanic(topic))
+}
+
 // TemplateKafkaBrokers creates a headerless config item representing the kafka brokers
 func TemplateKafkaBrokers(brokers []string) *cb.ConfigGroup {
 	return ordererConfigGroup(KafkaBrokersKey, utils.MarshalOrPanic(&ab.KafkaBrokers{Brokers: brokers}))
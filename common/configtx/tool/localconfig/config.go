@@ -91,8 +91,11 @@ type Orderer struct {
 	BatchTimeout  time.Duration   `yaml:"BatchTimeout"`
 	BatchSize     BatchSize       `yaml:"BatchSize"`
 	Kafka         Kafka           `yaml:"Kafka"`
+	Raft          Raft            `yaml:"Raft"`
+	Sbft          Sbft            `yaml:"Sbft"`
 	Organizations []*Organization `yaml:"Organizations"`
 	MaxChannels   uint64          `yaml:"MaxChannels"`
+	RateLimits    RateLimits      `yaml:"RateLimits"`
 }
 
 // BatchSize contains configuration affecting the size of batches
@@ -102,9 +105,55 @@ type BatchSize struct {
 	PreferredMaxBytes uint32 `yaml:"PreferredMaxBytes"`
 }
 
+// RateLimits contains the limits on the rate at which broadcast messages are admitted
+type RateLimits struct {
+	Channel  RateLimit `yaml:"Channel"`
+	Identity RateLimit `yaml:"Identity"`
+	MSP      RateLimit `yaml:"MSP"`
+}
+
+// RateLimit describes a token bucket, a rate of 0 indicates no limit
+type RateLimit struct {
+	MessagesPerSecond uint32 `yaml:"MessagesPerSecond"`
+	Burst             uint32 `yaml:"Burst"`
+}
+
 // Kafka contains config for the Kafka orderer
 type Kafka struct {
-	Brokers []string `yaml:"Brokers"`
+	Brokers []string   `yaml:"Brokers"`
+	Topic   KafkaTopic `yaml:"Topic"`
+}
+
+// KafkaTopic contains the checks the Kafka topic of each channel must pass
+type KafkaTopic struct {
+	MinReplicas     uint32 `yaml:"MinReplicas"`
+	RequireExisting bool   `yaml:"RequireExisting"`
+}
+
+// Raft contains config for the Raft orderer
+type Raft struct {
+	Consenters []*RaftConsenter `yaml:"Consenters"`
+}
+
+// RaftConsenter identifies an orderer replicating the raft log
+type RaftConsenter struct {
+	Address       string `yaml:"Address"`
+	ClientTLSCert string `yaml:"ClientTLSCert"`
+}
+
+// Sbft contains config for the SBFT orderer
+type Sbft struct {
+	F              uint64         `yaml:"F"`
+	RequestTimeout time.Duration  `yaml:"RequestTimeout"`
+	Replicas       []*SbftReplica `yaml:"Replicas"`
+}
+
+// SbftReplica identifies an orderer replica of the SBFT network
+type SbftReplica struct {
+	Address  string `yaml:"Address"`
+	Cert     string `yaml:"Cert"`
+	MSPID    string `yaml:"MSPID"`
+	SignCert string `yaml:"SignCert"`
 }
 
 var genesisDefaults = TopLevel{
@@ -120,6 +169,18 @@ var genesisDefaults = TopLevel{
 		Kafka: Kafka{
 			Brokers: []string{"127.0.0.1:9092"},
 		},
+		Raft: Raft{
+			Consenters: []*RaftConsenter{{Address: "127.0.0.1:7050", ClientTLSCert: "msp/sampleconfig/signcerts/peer.pem"}},
+		},
+		Sbft: Sbft{
+			RequestTimeout: time.Second,
+			Replicas: []*SbftReplica{{
+				Address:  "127.0.0.1:6101",
+				Cert:     "sbft/testdata/cert1.pem",
+				MSPID:    "DEFAULT",
+				SignCert: "msp/sampleconfig/signcerts/peer.pem",
+			}},
+		},
 	},
 }
 
@@ -147,6 +208,15 @@ func (g *Profile) completeInitialization() {
 		case g.Orderer.Kafka.Brokers == nil:
 			logger.Infof("Orderer.Kafka.Brokers unset, setting to %v", genesisDefaults.Orderer.Kafka.Brokers)
 			g.Orderer.Kafka.Brokers = genesisDefaults.Orderer.Kafka.Brokers
+		case g.Orderer.Raft.Consenters == nil:
+			logger.Infof("Orderer.Raft.Consenters unset, setting to %v", genesisDefaults.Orderer.Raft.Consenters)
+			g.Orderer.Raft.Consenters = genesisDefaults.Orderer.Raft.Consenters
+		case g.Orderer.Sbft.RequestTimeout == 0:
+			logger.Infof("Orderer.Sbft.RequestTimeout unset, setting to %s", genesisDefaults.Orderer.Sbft.RequestTimeout)
+			g.Orderer.Sbft.RequestTimeout = genesisDefaults.Orderer.Sbft.RequestTimeout
+		case g.Orderer.Sbft.Replicas == nil:
+			logger.Infof("Orderer.Sbft.Replicas unset, setting to %v", genesisDefaults.Orderer.Sbft.Replicas)
+			g.Orderer.Sbft.Replicas = genesisDefaults.Orderer.Sbft.Replicas
 		default:
 			return
 		}
@@ -17,7 +17,9 @@ limitations under the License.
 package provisional
 
 import (
+	"encoding/pem"
 	"fmt"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 
@@ -33,6 +35,7 @@ import (
 	cb "github.com/hyperledger/fabric/protos/common"
 	ab "github.com/hyperledger/fabric/protos/orderer"
 	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
 
 	logging "github.com/op/go-logging"
 )
@@ -56,6 +59,8 @@ const (
 	ConsensusTypeKafka = "kafka"
 	// ConsensusTypeSbft identifies the SBFT consensus implementation.
 	ConsensusTypeSbft = "sbft"
+	// ConsensusTypeRaft identifies the Raft-based consensus implementation.
+	ConsensusTypeRaft = "raft"
 
 	// TestChainID is the default value of ChainID. It is used by all testing
 	// networks. It it necessary to set and export this variable so that test
@@ -68,6 +73,10 @@ const (
 
 	// BlockValidationPolicyKey
 	BlockValidationPolicyKey = "BlockValidation"
+
+	// LifecycleEndorsementPolicyKey is the key of the application policy
+	// which must be satisfied by the approvals of a chaincode definition
+	LifecycleEndorsementPolicyKey = "LifecycleEndorsement"
 )
 
 func resolveMSPDir(path string) string {
@@ -102,6 +111,84 @@ func resolveMSPDir(path string) string {
 	return ""
 }
 
+func raftConsenters(consenters []*genesisconfig.RaftConsenter) []*ab.RaftConsenter {
+	var result []*ab.RaftConsenter
+	for _, consenter := range consenters {
+		raftConsenter := &ab.RaftConsenter{Address: consenter.Address}
+		if consenter.ClientTLSCert != "" {
+			cert, err := ioutil.ReadFile(resolveMSPDir(consenter.ClientTLSCert))
+			if err != nil {
+				logger.Panicf("Error loading client TLS certificate of raft consenter %s: %s", consenter.Address, err)
+			}
+			raftConsenter.ClientTlsCert = cert
+		}
+		result = append(result, raftConsenter)
+	}
+	return result
+}
+
+func sbftMembership(conf genesisconfig.Sbft) *ab.SbftMembership {
+	membership := &ab.SbftMembership{
+		F:                  conf.F,
+		RequestTimeoutNsec: uint64(conf.RequestTimeout.Nanoseconds()),
+	}
+	for _, replica := range conf.Replicas {
+		cert, err := ioutil.ReadFile(resolveMSPDir(replica.Cert))
+		if err != nil {
+			logger.Panicf("Error loading certificate of SBFT replica %s: %s", replica.Address, err)
+		}
+		signCert, err := ioutil.ReadFile(resolveMSPDir(replica.SignCert))
+		if err != nil {
+			logger.Panicf("Error loading signing certificate of SBFT replica %s: %s", replica.Address, err)
+		}
+		block, _ := pem.Decode(signCert)
+		if block == nil {
+			logger.Panicf("Error decoding signing certificate of SBFT replica %s", replica.Address)
+		}
+		// serialized the way the MSP serializes identities, see the BlockValidation policy
+		identity := utils.MarshalOrPanic(&msp.SerializedIdentity{
+			Mspid:   replica.MSPID,
+			IdBytes: pem.EncodeToMemory(&pem.Block{Bytes: block.Bytes}),
+		})
+		membership.Replicas = append(membership.Replicas, &ab.SbftReplica{Address: replica.Address, Cert: cert, Identity: identity})
+	}
+	return membership
+}
+
+func rateLimits(conf genesisconfig.RateLimits) *ab.RateLimits {
+	rateLimit := func(conf genesisconfig.RateLimit) *ab.RateLimit {
+		return &ab.RateLimit{MessagesPerSecond: conf.MessagesPerSecond, Burst: conf.Burst}
+	}
+	return &ab.RateLimits{
+		Channel:  rateLimit(conf.Channel),
+		Identity: rateLimit(conf.Identity),
+		Msp:      rateLimit(conf.MSP),
+	}
+}
+
+// sbftBlockValidationPolicy requires the signatures of a common case quorum
+// of SBFT replicas, 2F+1 when N=3F+1
+func sbftBlockValidationPolicy(membership *ab.SbftMembership) *cb.ConfigGroup {
+	var identities [][]byte
+	var signedBy []*cb.SignaturePolicy
+	for i, replica := range membership.Replicas {
+		identities = append(identities, replica.Identity)
+		signedBy = append(signedBy, cauthdsl.SignedBy(int32(i)))
+	}
+	quorum := (len(membership.Replicas) + int(membership.F) + 2) / 2
+	policy := cauthdsl.Envelope(cauthdsl.NOutOf(int32(quorum), signedBy), identities)
+
+	result := cb.NewConfigGroup()
+	result.Groups[config.OrdererGroupKey] = cb.NewConfigGroup()
+	result.Groups[config.OrdererGroupKey].Policies[BlockValidationPolicyKey] = &cb.ConfigPolicy{
+		Policy: &cb.Policy{
+			Type:   int32(cb.Policy_SIGNATURE),
+			Policy: utils.MarshalOrPanic(policy),
+		},
+	}
+	return result
+}
+
 // DefaultChainCreationPolicyNames is the default value of ChainCreatorsKey.
 var DefaultChainCreationPolicyNames = []string{AcceptAllPolicyKey}
 
@@ -132,6 +219,14 @@ func New(conf *genesisconfig.Profile) Generator {
 	}
 
 	if conf.Orderer != nil {
+		blockValidationPolicy := policies.TemplateImplicitMetaPolicyWithSubPolicy([]string{config.OrdererGroupKey}, BlockValidationPolicyKey, configvaluesmsp.WritersPolicyKey, cb.ImplicitMetaPolicy_ANY)
+		var sbftReplicas *ab.SbftMembership
+		if conf.Orderer.OrdererType == ConsensusTypeSbft {
+			// SBFT blocks carry the signatures of the replicas which agreed on them
+			sbftReplicas = sbftMembership(conf.Orderer.Sbft)
+			blockValidationPolicy = sbftBlockValidationPolicy(sbftReplicas)
+		}
+
 		bs.ordererGroups = []*cb.ConfigGroup{
 			// Orderer Config Types
 			config.TemplateConsensusType(conf.Orderer.OrdererType),
@@ -142,9 +237,10 @@ func New(conf *genesisconfig.Profile) Generator {
 			}),
 			config.TemplateBatchTimeout(conf.Orderer.BatchTimeout.String()),
 			config.TemplateChannelRestrictions(conf.Orderer.MaxChannels),
+			config.TemplateRateLimits(rateLimits(conf.Orderer.RateLimits)),
 
 			// Initialize the default Reader/Writer/Admins orderer policies, as well as block validation policy
-			policies.TemplateImplicitMetaPolicyWithSubPolicy([]string{config.OrdererGroupKey}, BlockValidationPolicyKey, configvaluesmsp.WritersPolicyKey, cb.ImplicitMetaPolicy_ANY),
+			blockValidationPolicy,
 			policies.TemplateImplicitMetaAnyPolicy([]string{config.OrdererGroupKey}, configvaluesmsp.ReadersPolicyKey),
 			policies.TemplateImplicitMetaAnyPolicy([]string{config.OrdererGroupKey}, configvaluesmsp.WritersPolicyKey),
 			policies.TemplateImplicitMetaMajorityPolicy([]string{config.OrdererGroupKey}, configvaluesmsp.AdminsPolicyKey),
@@ -159,9 +255,17 @@ func New(conf *genesisconfig.Profile) Generator {
 		}
 
 		switch conf.Orderer.OrdererType {
-		case ConsensusTypeSolo, ConsensusTypeSbft:
+		case ConsensusTypeSolo:
+		case ConsensusTypeSbft:
+			bs.ordererGroups = append(bs.ordererGroups, config.TemplateSbftMembership(sbftReplicas))
 		case ConsensusTypeKafka:
 			bs.ordererGroups = append(bs.ordererGroups, config.TemplateKafkaBrokers(conf.Orderer.Kafka.Brokers))
+			bs.ordererGroups = append(bs.ordererGroups, config.TemplateKafkaTopic(&ab.KafkaTopic{
+				MinReplicas:     conf.Orderer.Kafka.Topic.MinReplicas,
+				RequireExisting: conf.Orderer.Kafka.Topic.RequireExisting,
+			}))
+		case ConsensusTypeRaft:
+			bs.ordererGroups = append(bs.ordererGroups, config.TemplateRaftConsenters(raftConsenters(conf.Orderer.Raft.Consenters)))
 		default:
 			panic(fmt.Errorf("Wrong consenter type value given: %s", conf.Orderer.OrdererType))
 		}
@@ -179,6 +283,7 @@ func New(conf *genesisconfig.Profile) Generator {
 			policies.TemplateImplicitMetaAnyPolicy([]string{config.ApplicationGroupKey}, configvaluesmsp.ReadersPolicyKey),
 			policies.TemplateImplicitMetaAnyPolicy([]string{config.ApplicationGroupKey}, configvaluesmsp.WritersPolicyKey),
 			policies.TemplateImplicitMetaMajorityPolicy([]string{config.ApplicationGroupKey}, configvaluesmsp.AdminsPolicyKey),
+			policies.TemplateImplicitMetaPolicyWithSubPolicy([]string{config.ApplicationGroupKey}, LifecycleEndorsementPolicyKey, configvaluesmsp.AdminsPolicyKey, cb.ImplicitMetaPolicy_MAJORITY),
 		}
 		for _, org := range conf.Application.Organizations {
 			mspConfig, err := msp.GetVerifyingMspConfig(resolveMSPDir(org.MSPDir), org.BCCSP, org.ID)
@@ -22,8 +22,13 @@ import (
 	"path/filepath"
 	"testing"
 
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/config"
+	"github.com/hyperledger/fabric/common/configtx"
 	genesisconfig "github.com/hyperledger/fabric/common/configtx/tool/localconfig"
 	cb "github.com/hyperledger/fabric/protos/common"
+	ab "github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric/protos/utils"
 )
 
 var confSolo, confKafka *genesisconfig.Profile
@@ -67,3 +72,56 @@ func TestGenesisMetadata(t *testing.T) {
 		}
 	}
 }
+
+func sbftBlockValidationPolicyOf(t *testing.T, group *cb.ConfigGroup) *cb.SignaturePolicyEnvelope {
+	policy := group.Groups[config.OrdererGroupKey].Policies[BlockValidationPolicyKey].Policy
+	if policy.Type != int32(cb.Policy_SIGNATURE) {
+		t.Fatalf("Expected a signature policy, got type %d", policy.Type)
+	}
+	sigPolicy := &cb.SignaturePolicyEnvelope{}
+	if err := proto.Unmarshal(policy.Policy, sigPolicy); err != nil {
+		t.Fatal(err)
+	}
+	return sigPolicy
+}
+
+func TestSbftBlockValidationPolicy(t *testing.T) {
+	confSbft := genesisconfig.Load(genesisconfig.SampleSingleMSPSoloProfile)
+	confSbft.Orderer.OrdererType = ConsensusTypeSbft
+	confSbft.Orderer.Sbft.Replicas = []*genesisconfig.SbftReplica{{
+		Address:  "127.0.0.1:6101",
+		Cert:     "orderer/sbft/testdata/cert1.pem",
+		MSPID:    "DEFAULT",
+		SignCert: "msp/sampleconfig/signcerts/peer.pem",
+	}}
+
+	payload, err := utils.UnmarshalPayload(utils.ExtractEnvelopeOrPanic(New(confSbft).GenesisBlock(), 0).Payload)
+	if err != nil {
+		t.Fatal(err)
+	}
+	configEnv := configtx.UnmarshalConfigEnvelopeOrPanic(payload.Data)
+	sigPolicy := sbftBlockValidationPolicyOf(t, configEnv.Config.ChannelGroup)
+	if n := sigPolicy.Policy.GetNOutOf().N; n != 1 {
+		t.Errorf("Expected the signature of 1 replica to be required, got %d", n)
+	}
+	replicas := configEnv.Config.ChannelGroup.Groups[config.OrdererGroupKey].Values[config.SbftMembershipKey]
+	membership := &ab.SbftMembership{}
+	if err := proto.Unmarshal(replicas.Value, membership); err != nil {
+		t.Fatal(err)
+	}
+	if len(sigPolicy.Identities) != 1 || !bytes.Equal(sigPolicy.Identities[0].Principal, membership.Replicas[0].Identity) {
+		t.Errorf("Expected the policy to require the orderer identity of the replica, got %v", sigPolicy.Identities)
+	}
+
+	membership = &ab.SbftMembership{F: 1}
+	for i := 0; i < 4; i++ {
+		membership.Replicas = append(membership.Replicas, &ab.SbftReplica{Identity: []byte{byte(i)}})
+	}
+	sigPolicy = sbftBlockValidationPolicyOf(t, sbftBlockValidationPolicy(membership))
+	if n := sigPolicy.Policy.GetNOutOf().N; n != 3 {
+		t.Errorf("Expected the signatures of 3 replicas to be required, got %d", n)
+	}
+	if len(sigPolicy.Identities) != 4 {
+		t.Errorf("Expected the identities of 4 replicas, got %d", len(sigPolicy.Identities))
+	}
+}
@@ -55,6 +55,7 @@ type BlockStoreProvider interface {
 	OpenBlockStore(ledgerid string) (BlockStore, error)
 	Exists(ledgerid string) (bool, error)
 	List() ([]string, error)
+	Remove(ledgerid string) error
 	Close()
 }
 
@@ -111,13 +111,24 @@ func (itr *blocksItr) Next() (ledger.QueryResult, error) {
 	return &blockHolder{nextBlockBytes}, nil
 }
 
-// Close releases any resources held by the iterator
+// Close releases any resources held by the iterator. It may be called more than once, and
+// concurrently with Next to unblock a Next waiting for a block
 func (itr *blocksItr) Close() {
 	itr.closeMarkerLock.Lock()
-	defer itr.closeMarkerLock.Unlock()
+	if itr.closeMarker {
+		itr.closeMarkerLock.Unlock()
+		return
+	}
 	itr.closeMarker = true
+	// the stream is only opened once the first block is read
+	if itr.stream != nil {
+		itr.stream.close()
+	}
+	itr.closeMarkerLock.Unlock()
+
+	// waitForBlock checks the close marker while holding the lock of the condition, so
+	// the lock of the marker is released first
 	itr.mgr.cpInfoCond.L.Lock()
 	defer itr.mgr.cpInfoCond.L.Unlock()
 	itr.mgr.cpInfoCond.Broadcast()
-	itr.stream.close()
 }
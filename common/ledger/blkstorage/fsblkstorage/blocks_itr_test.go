@@ -52,6 +52,39 @@ func TestBlocksItrBlockingNext(t *testing.T) {
 	<-doneChan
 }
 
+func TestBlocksItrCloseWhileWaiting(t *testing.T) {
+	env := newTestEnv(t, NewConf(testPath(), 0))
+	defer env.Cleanup()
+	blkfileMgrWrapper := newTestBlockfileWrapper(env, "testLedger")
+	defer blkfileMgrWrapper.close()
+	blkfileMgr := blkfileMgrWrapper.blockfileMgr
+
+	blocks := testutil.ConstructTestBlocks(t, 5)
+	blkfileMgrWrapper.addBlocks(blocks)
+
+	// the iterator starting past the height waits before opening its stream and the
+	// one starting at the last block waits after reading it
+	for _, startBlockNum := range []uint64{5, 4} {
+		itr, err := blkfileMgr.retrieveBlocks(startBlockNum)
+		testutil.AssertNoError(t, err, "")
+		doneChan := make(chan bool)
+		go func() {
+			for {
+				bh, err := itr.Next()
+				testutil.AssertNoError(t, err, "")
+				if bh == nil {
+					break
+				}
+			}
+			doneChan <- true
+		}()
+		time.Sleep(time.Millisecond * 10)
+		itr.Close()
+		<-doneChan
+		itr.Close()
+	}
+}
+
 func testIterateAndVerify(t *testing.T, itr *blocksItr, blocks []*common.Block, doneChan chan bool) {
 	blocksIterated := 0
 	for {
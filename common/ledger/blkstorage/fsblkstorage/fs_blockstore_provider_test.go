@@ -92,6 +92,45 @@ func TestBlockStoreProvider(t *testing.T) {
 
 }
 
+func TestRemoveBlockStore(t *testing.T) {
+	env := newTestEnv(t, NewConf(testPath(), 0))
+	defer env.Cleanup()
+
+	provider := env.provider
+	store1, _ := provider.OpenBlockStore("ledger1")
+	defer store1.Shutdown()
+	store2, _ := provider.OpenBlockStore("ledger2")
+
+	blocks1 := testutil.ConstructTestBlocks(t, 5)
+	for _, b := range blocks1 {
+		store1.AddBlock(b)
+	}
+	for _, b := range testutil.ConstructTestBlocks(t, 10) {
+		store2.AddBlock(b)
+	}
+
+	store2.Shutdown()
+	testutil.AssertNoError(t, provider.Remove("ledger2"), "")
+
+	exists, err := provider.Exists("ledger2")
+	testutil.AssertNoError(t, err, "")
+	testutil.AssertEquals(t, exists, false)
+	storeNames, _ := provider.List()
+	testutil.AssertEquals(t, storeNames, []string{"ledger1"})
+	checkBlocks(t, blocks1, store1)
+
+	// A block store created again with the same id starts empty
+	store2, _ = provider.OpenBlockStore("ledger2")
+	defer store2.Shutdown()
+	bcInfo, _ := store2.GetBlockchainInfo()
+	testutil.AssertEquals(t, bcInfo.Height, uint64(0))
+	blocks2 := testutil.ConstructTestBlocks(t, 3)
+	for _, b := range blocks2 {
+		testutil.AssertNoError(t, store2.AddBlock(b), "")
+	}
+	checkBlocks(t, blocks2, store2)
+}
+
 func constructLedgerid(id int) string {
 	return fmt.Sprintf("ledger_%d", id)
 }
@@ -23,6 +23,8 @@ import "time"
 type SharedConfig struct {
 	// ConsensusTypeVal is returned as the result of ConsensusType()
 	ConsensusTypeVal string
+	// ConsensusStateVal is returned as the result of ConsensusState()
+	ConsensusStateVal ab.ConsensusType_State
 	// BatchSizeVal is returned as the result of BatchSize()
 	BatchSizeVal *ab.BatchSize
 	// BatchTimeoutVal is returned as the result of BatchTimeout()
@@ -31,6 +33,16 @@ type SharedConfig struct {
 	ChainCreationPolicyNamesVal []string
 	// KafkaBrokersVal is returned as the result of KafkaBrokers()
 	KafkaBrokersVal []string
+	// KafkaTopicVal is returned as the result of KafkaTopic()
+	KafkaTopicVal *ab.KafkaTopic
+	// RaftConsentersVal is returned as the result of RaftConsenters()
+	RaftConsentersVal []*ab.RaftConsenter
+	// SbftMembershipVal is returned as the result of SbftMembership()
+	SbftMembershipVal *ab.SbftMembership
+	// RateLimitsVal is returned as the result of RateLimits()
+	RateLimitsVal *ab.RateLimits
+	// SealedVal is returned as the result of Sealed()
+	SealedVal bool
 	// IngressPolicyNamesVal is returned as the result of IngressPolicyNames()
 	IngressPolicyNamesVal []string
 	// EgressPolicyNamesVal is returned as the result of EgressPolicyNames()
@@ -44,6 +56,11 @@ func (scm *SharedConfig) ConsensusType() string {
 	return scm.ConsensusTypeVal
 }
 
+// ConsensusState returns the ConsensusStateVal
+func (scm *SharedConfig) ConsensusState() ab.ConsensusType_State {
+	return scm.ConsensusStateVal
+}
+
 // BatchSize returns the BatchSizeVal
 func (scm *SharedConfig) BatchSize() *ab.BatchSize {
 	return scm.BatchSizeVal
@@ -64,6 +81,31 @@ func (scm *SharedConfig) KafkaBrokers() []string {
 	return scm.KafkaBrokersVal
 }
 
+// KafkaTopic returns the KafkaTopicVal
+func (scm *SharedConfig) KafkaTopic() *ab.KafkaTopic {
+	return scm.KafkaTopicVal
+}
+
+// RaftConsenters returns the RaftConsentersVal
+func (scm *SharedConfig) RaftConsenters() []*ab.RaftConsenter {
+	return scm.RaftConsentersVal
+}
+
+// SbftMembership returns the SbftMembershipVal
+func (scm *SharedConfig) SbftMembership() *ab.SbftMembership {
+	return scm.SbftMembershipVal
+}
+
+// RateLimits returns the RateLimitsVal
+func (scm *SharedConfig) RateLimits() *ab.RateLimits {
+	return scm.RateLimitsVal
+}
+
+// Sealed returns the SealedVal
+func (scm *SharedConfig) Sealed() bool {
+	return scm.SealedVal
+}
+
 // MaxChannelsCount returns the MaxChannelsCountVal
 func (scm *SharedConfig) MaxChannelsCount() uint64 {
 	return scm.MaxChannelsCountVal
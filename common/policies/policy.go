@@ -55,6 +55,9 @@ const (
 	// ChannelApplicationAdmins is the label for the channel's application admin policy
 	ChannelApplicationAdmins = PathSeparator + ChannelPrefix + PathSeparator + ApplicationPrefix + PathSeparator + "Admins"
 
+	// ChannelApplicationLifecycleEndorsement is the label for the policy which must be satisfied to commit a chaincode definition on the channel
+	ChannelApplicationLifecycleEndorsement = PathSeparator + ChannelPrefix + PathSeparator + ApplicationPrefix + PathSeparator + "LifecycleEndorsement"
+
 	// BlockValidation is the label for the policy which should validate the block signatures for the channel
 	BlockValidation = PathSeparator + ChannelPrefix + PathSeparator + OrdererPrefix + PathSeparator + "BlockValidation"
 )
@@ -40,6 +40,7 @@ import (
 	"github.com/hyperledger/fabric/core/container/ccintf"
 	"github.com/hyperledger/fabric/core/ledger"
 	pb "github.com/hyperledger/fabric/protos/peer"
+	putils "github.com/hyperledger/fabric/protos/utils"
 )
 
 type key string
@@ -88,6 +89,30 @@ func getHistoryQueryExecutor(context context.Context) ledger.HistoryQueryExecuto
 //This is where the VM that's running the chaincode would hook in
 type chaincodeRTEnv struct {
 	handler *Handler
+
+	//resource limits declared in the deployment spec, nil if none
+	limits *pb.ChaincodeResourceLimits
+
+	//bounds and schedules the transactions executing in the chaincode
+	queue *execQueue
+}
+
+//newChaincodeRTEnv creates the runtime environment of a chaincode
+func newChaincodeRTEnv(chaincode string, handler *Handler, limits *pb.ChaincodeResourceLimits) *chaincodeRTEnv {
+	maxConcurrency, maxDepth := getExecQueueConfig()
+	if limits != nil && limits.MaxConcurrency > 0 {
+		maxConcurrency = int(limits.MaxConcurrency)
+	}
+	return &chaincodeRTEnv{handler: handler, limits: limits, queue: newExecQueue(chaincode, maxConcurrency, maxDepth)}
+}
+
+//getExecuteTimeout returns the chaincode specific execute timeout if one
+//was declared, otherwise the given default
+func (chrte *chaincodeRTEnv) getExecuteTimeout(defaultTimeout time.Duration) time.Duration {
+	if chrte.limits != nil && chrte.limits.ExecuteTimeout > 0 {
+		return time.Duration(chrte.limits.ExecuteTimeout) * time.Millisecond
+	}
+	return defaultTimeout
 }
 
 // runningChaincodes contains maps of chaincodeIDs to their chaincodeRTEs
@@ -103,12 +128,12 @@ func GetChain() *ChaincodeSupport {
 }
 
 //call this under lock
-func (chaincodeSupport *ChaincodeSupport) preLaunchSetup(chaincode string) chan bool {
+func (chaincodeSupport *ChaincodeSupport) preLaunchSetup(chaincode string, limits *pb.ChaincodeResourceLimits) chan bool {
 	//register placeholder Handler. This will be transferred in registerHandler
 	//NOTE: from this point, existence of handler for this chaincode means the chaincode
 	//is in the process of getting started (or has been started)
 	notfy := make(chan bool, 1)
-	chaincodeSupport.runningChaincodes.chaincodeMap[chaincode] = &chaincodeRTEnv{handler: &Handler{readyNotify: notfy}}
+	chaincodeSupport.runningChaincodes.chaincodeMap[chaincode] = newChaincodeRTEnv(chaincode, &Handler{readyNotify: notfy}, limits)
 	return notfy
 }
 
@@ -253,7 +278,7 @@ func (chaincodeSupport *ChaincodeSupport) registerHandler(chaincodehandler *Hand
 		chaincodehandler.readyNotify = chrte2.handler.readyNotify
 		chrte2.handler = chaincodehandler
 	} else {
-		chaincodeSupport.runningChaincodes.chaincodeMap[key] = &chaincodeRTEnv{handler: chaincodehandler}
+		chaincodeSupport.runningChaincodes.chaincodeMap[key] = newChaincodeRTEnv(key, chaincodehandler, nil)
 	}
 
 	chaincodehandler.registered = true
@@ -394,7 +419,7 @@ func (chaincodeSupport *ChaincodeSupport) launchAndWaitForRegister(ctxt context.
 	}
 
 	//chaincodeHasBeenLaunch false... its not in the map, add it and proceed to launch
-	notfy := chaincodeSupport.preLaunchSetup(canName)
+	notfy := chaincodeSupport.preLaunchSetup(canName, cds.ResourceLimits)
 	chaincodeSupport.runningChaincodes.Unlock()
 
 	//launch the chaincode
@@ -410,7 +435,7 @@ func (chaincodeSupport *ChaincodeSupport) launchAndWaitForRegister(ctxt context.
 
 	vmtype, _ := chaincodeSupport.getVMType(cds)
 
-	sir := container.StartImageReq{CCID: ccintf.CCID{ChaincodeSpec: cds.ChaincodeSpec, NetworkID: chaincodeSupport.peerNetworkID, PeerID: chaincodeSupport.peerID, Version: cccid.Version}, Builder: builder, Args: args, Env: env}
+	sir := container.StartImageReq{CCID: ccintf.CCID{ChaincodeSpec: cds.ChaincodeSpec, NetworkID: chaincodeSupport.peerNetworkID, PeerID: chaincodeSupport.peerID, Version: cccid.Version, ResourceLimits: cds.ResourceLimits}, Builder: builder, Args: args, Env: env}
 
 	ipcCtxt := context.WithValue(ctxt, ccintf.GetCCHandlerKey(), chaincodeSupport)
 
@@ -572,6 +597,11 @@ func (chaincodeSupport *ChaincodeSupport) Launch(context context.Context, cccid
 				//continue to use ChaincodeDeploymentSpec for anything other than Install. In
 				//particular, instantiate, invoke, upgrade should be using just some form of
 				//ChaincodeInvocationSpec.
+				//Resource limits given at instantiate/upgrade take precedence over the
+				//ones the chaincode was installed with
+				if cds.ResourceLimits != nil {
+					cdsfs.ResourceLimits = cds.ResourceLimits
+				}
 				cds = cdsfs
 				chaincodeLogger.Debugf("launchAndWaitForRegister fetched %d from file system", len(cds.CodePackage), err)
 			}
@@ -648,6 +678,18 @@ func (chaincodeSupport *ChaincodeSupport) Execute(ctxt context.Context, cccid *c
 	}
 	chaincodeSupport.runningChaincodes.Unlock()
 
+	timeout = chrte.getExecuteTimeout(timeout)
+
+	//system chaincodes run in process and are never queued
+	if !cccid.Syscc {
+		deadline := time.Now().Add(timeout)
+		if err := chrte.queue.acquire(getExecClass(cccid), deadline); err != nil {
+			return nil, err
+		}
+		defer chrte.queue.release()
+		timeout = deadline.Sub(time.Now())
+	}
+
 	var notfy chan *pb.ChaincodeMessage
 	var err error
 	if notfy, err = chrte.handler.sendExecuteMessage(ctxt, cccid.ChainID, msg, cccid.SignedProposal, cccid.Proposal); err != nil {
@@ -659,7 +701,7 @@ func (chaincodeSupport *ChaincodeSupport) Execute(ctxt context.Context, cccid *c
 		//response is sent to user or calling chaincode. ChaincodeMessage_ERROR
 		//are typically treated as error
 	case <-time.After(timeout):
-		err = fmt.Errorf("Timeout expired while executing transaction")
+		err = fmt.Errorf("Timeout expired while executing transaction (timeout %s)", timeout)
 	}
 
 	//our responsibility to delete transaction context if sendExecuteMessage succeeded
@@ -668,6 +710,18 @@ func (chaincodeSupport *ChaincodeSupport) Execute(ctxt context.Context, cccid *c
 	return ccresp, err
 }
 
+//getExecClass returns the scheduling class of the proposal being executed
+func getExecClass(cccid *ccprovider.CCContext) execClass {
+	if cccid.Proposal == nil {
+		return invokeClass
+	}
+	readOnly, err := putils.IsReadOnlyProposal(cccid.Proposal)
+	if err != nil || !readOnly {
+		return invokeClass
+	}
+	return queryClass
+}
+
 // IsDevMode returns true if the peer was configured with development-mode enabled
 func IsDevMode() bool {
 	mode := viper.GetString("chaincode.mode")
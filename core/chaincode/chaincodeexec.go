@@ -83,6 +83,9 @@ func ExecuteChaincode(ctxt context.Context, cccid *ccprovider.CCContext, args []
 	spec, err = createCIS(cccid.Name, args)
 	res, ccevent, err = Execute(ctxt, cccid, spec)
 	if err != nil {
+		if _, ok := err.(*BackpressureError); ok {
+			return nil, nil, err
+		}
 		chaincodeLogger.Errorf("Error executing chaincode: %s", err)
 		return nil, nil, fmt.Errorf("Error executing chaincode: %s", err)
 	}
@@ -0,0 +1,181 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chaincode
+
+import (
+	"container/list"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/rcrowley/go-metrics"
+	"github.com/spf13/viper"
+)
+
+const (
+	//execQueueMaxDepthDefault is the number of transactions of each class
+	//that may wait for a chaincode when chaincode.executequeue.maxdepth is not set
+	execQueueMaxDepthDefault = 100
+)
+
+//execClass is the scheduling class of a transaction sent to a chaincode
+type execClass int
+
+const (
+	invokeClass execClass = iota
+	queryClass
+	numExecClasses
+)
+
+func (c execClass) String() string {
+	if c == queryClass {
+		return "query"
+	}
+	return "invoke"
+}
+
+// BackpressureError is returned when a chaincode cannot accept a transaction
+// because it is saturated. The proposal may be retried later.
+type BackpressureError struct {
+	ChaincodeName string
+	Reason        string
+}
+
+func (b *BackpressureError) Error() string {
+	return fmt.Sprintf("chaincode %s is busy, retry later: %s", b.ChaincodeName, b.Reason)
+}
+
+//execQueue bounds the number of transactions executing concurrently in a
+//chaincode. Transactions over the bound wait in one FIFO queue per class;
+//whenever a slot frees up the classes are served in turn so that a burst
+//of queries cannot starve invokes (and vice versa)
+type execQueue struct {
+	sync.Mutex
+	name           string
+	maxConcurrency int
+	maxDepth       int
+	inflight       int
+	waiting        [numExecClasses]*list.List
+	lastServed     execClass
+
+	depth    metrics.Gauge
+	wait     metrics.Timer
+	rejected metrics.Counter
+}
+
+//newExecQueue returns a queue for the given chaincode. A maxConcurrency of 0
+//or less does not bound the number of concurrent transactions
+func newExecQueue(name string, maxConcurrency int, maxDepth int) *execQueue {
+	q := &execQueue{
+		name:           name,
+		maxConcurrency: maxConcurrency,
+		maxDepth:       maxDepth,
+		depth:          metrics.GetOrRegisterGauge(fmt.Sprintf("chaincode.%s.queue.depth", name), metrics.DefaultRegistry),
+		wait:           metrics.GetOrRegisterTimer(fmt.Sprintf("chaincode.%s.queue.wait", name), metrics.DefaultRegistry),
+		rejected:       metrics.GetOrRegisterCounter(fmt.Sprintf("chaincode.%s.queue.rejected", name), metrics.DefaultRegistry),
+	}
+	for i := range q.waiting {
+		q.waiting[i] = list.New()
+	}
+	return q
+}
+
+//getExecQueueConfig returns the peer-wide default concurrency and maximum
+//queue depth per class
+func getExecQueueConfig() (int, int) {
+	maxConcurrency := viper.GetInt("chaincode.executequeue.maxconcurrency")
+	maxDepth := viper.GetInt("chaincode.executequeue.maxdepth")
+	if maxDepth <= 0 {
+		maxDepth = execQueueMaxDepthDefault
+	}
+	return maxConcurrency, maxDepth
+}
+
+//call this under lock
+func (q *execQueue) queued() int {
+	n := 0
+	for _, l := range q.waiting {
+		n += l.Len()
+	}
+	return n
+}
+
+//acquire blocks until the transaction can be executed by the chaincode.
+//It returns a BackpressureError if the queue of the class is full or if
+//no slot became available before the deadline
+func (q *execQueue) acquire(class execClass, deadline time.Time) error {
+	q.Lock()
+	if q.maxConcurrency <= 0 || (q.inflight < q.maxConcurrency && q.queued() == 0) {
+		q.inflight++
+		q.Unlock()
+		return nil
+	}
+
+	if q.waiting[class].Len() >= q.maxDepth {
+		q.Unlock()
+		q.rejected.Inc(1)
+		chaincodeLogger.Debugf("%s queue of chaincode %s is full (%d)", class, q.name, q.maxDepth)
+		return &BackpressureError{ChaincodeName: q.name, Reason: fmt.Sprintf("%s queue is full", class)}
+	}
+
+	start := time.Now()
+	ready := make(chan struct{})
+	elem := q.waiting[class].PushBack(ready)
+	q.depth.Update(int64(q.queued()))
+	q.Unlock()
+
+	select {
+	case <-ready:
+		q.wait.UpdateSince(start)
+		return nil
+	case <-time.After(deadline.Sub(start)):
+	}
+
+	q.Lock()
+	defer q.Unlock()
+	select {
+	case <-ready:
+		//the slot was handed over while the timer fired
+		q.wait.UpdateSince(start)
+		return nil
+	default:
+	}
+	q.waiting[class].Remove(elem)
+	q.depth.Update(int64(q.queued()))
+	q.rejected.Inc(1)
+	chaincodeLogger.Debugf("timed out waiting %s in %s queue of chaincode %s", time.Since(start), class, q.name)
+	return &BackpressureError{ChaincodeName: q.name, Reason: fmt.Sprintf("timed out waiting in %s queue", class)}
+}
+
+//release frees the slot of a transaction that completed. The slot is handed
+//over to the next waiting transaction, if any
+func (q *execQueue) release() {
+	q.Lock()
+	defer q.Unlock()
+
+	for i := 1; i <= int(numExecClasses); i++ {
+		class := (q.lastServed + execClass(i)) % numExecClasses
+		if front := q.waiting[class].Front(); front != nil {
+			q.waiting[class].Remove(front)
+			q.lastServed = class
+			q.depth.Update(int64(q.queued()))
+			close(front.Value.(chan struct{}))
+			return
+		}
+	}
+	q.inflight--
+}
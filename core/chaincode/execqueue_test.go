@@ -0,0 +1,149 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chaincode
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric/core/common/ccprovider"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+)
+
+func waitForQueued(t *testing.T, q *execQueue, n int) {
+	for i := 0; i < 1000; i++ {
+		q.Lock()
+		queued := q.queued()
+		q.Unlock()
+		if queued == n {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("expected %d queued transactions", n)
+}
+
+func TestExecQueueUnbounded(t *testing.T) {
+	q := newExecQueue("unbounded", 0, 1)
+	for i := 0; i < 10; i++ {
+		assert.NoError(t, q.acquire(queryClass, time.Now()))
+	}
+}
+
+func TestExecQueueFull(t *testing.T) {
+	q := newExecQueue("full", 1, 1)
+	assert.NoError(t, q.acquire(invokeClass, time.Now().Add(time.Second)))
+
+	done := make(chan error)
+	go func() { done <- q.acquire(queryClass, time.Now().Add(time.Minute)) }()
+
+	//wait for the query to be queued, the next one must be rejected
+	waitForQueued(t, q, 1)
+	err := q.acquire(queryClass, time.Now().Add(time.Minute))
+	assert.IsType(t, &BackpressureError{}, err)
+
+	q.release()
+	assert.NoError(t, <-done)
+	q.release()
+	assert.Equal(t, 0, q.inflight)
+}
+
+func TestExecQueueTimeout(t *testing.T) {
+	q := newExecQueue("timeout", 1, 10)
+	assert.NoError(t, q.acquire(invokeClass, time.Now().Add(time.Second)))
+
+	err := q.acquire(invokeClass, time.Now().Add(10*time.Millisecond))
+	assert.IsType(t, &BackpressureError{}, err)
+
+	q.Lock()
+	assert.Equal(t, 0, q.queued())
+	q.Unlock()
+	q.release()
+	assert.Equal(t, 0, q.inflight)
+}
+
+func TestExecQueueFairness(t *testing.T) {
+	q := newExecQueue("fairness", 1, 10)
+	assert.NoError(t, q.acquire(invokeClass, time.Now().Add(time.Second)))
+
+	order := make(chan execClass, 6)
+	enqueue := func(class execClass, n int) {
+		go func() {
+			assert.NoError(t, q.acquire(class, time.Now().Add(time.Minute)))
+			order <- class
+		}()
+		waitForQueued(t, q, n)
+	}
+
+	//a burst of queries followed by invokes
+	enqueue(queryClass, 1)
+	enqueue(queryClass, 2)
+	enqueue(queryClass, 3)
+	enqueue(invokeClass, 4)
+	enqueue(invokeClass, 5)
+
+	var served []execClass
+	for i := 0; i < 5; i++ {
+		q.release()
+		served = append(served, <-order)
+	}
+	assert.Equal(t, []execClass{queryClass, invokeClass, queryClass, invokeClass, queryClass}, served)
+}
+
+//newLimitedChaincodeSupport returns a ChaincodeSupport running a chaincode
+//that never answers, with the given resource limits
+func newLimitedChaincodeSupport(canName string, limits *pb.ChaincodeResourceLimits) (*ChaincodeSupport, *chaincodeRTEnv) {
+	handler := &Handler{txCtxs: make(map[string]*transactionContext), nextState: make(chan *nextStateInfo, 10)}
+	chrte := newChaincodeRTEnv(canName, handler, limits)
+	cs := &ChaincodeSupport{runningChaincodes: &runningChaincodes{chaincodeMap: map[string]*chaincodeRTEnv{canName: chrte}}}
+	return cs, chrte
+}
+
+func TestExecuteTimeoutLimit(t *testing.T) {
+	cs, _ := newLimitedChaincodeSupport("limited:0", &pb.ChaincodeResourceLimits{ExecuteTimeout: 50})
+	cccid := ccprovider.NewCCContext("testchainid", "limited", "0", "txid1", false, nil, nil)
+	msg := &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_TRANSACTION, Txid: "txid1"}
+
+	//the chaincode timeout takes precedence over the one of the caller
+	start := time.Now()
+	_, err := cs.Execute(context.Background(), cccid, msg, time.Minute)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Timeout expired")
+	assert.True(t, time.Since(start) < 10*time.Second, "execute should time out after the chaincode timeout")
+}
+
+func TestExecuteConcurrencyLimit(t *testing.T) {
+	cs, chrte := newLimitedChaincodeSupport("limited:0", &pb.ChaincodeResourceLimits{ExecuteTimeout: 50, MaxConcurrency: 1})
+	assert.Equal(t, 1, chrte.queue.maxConcurrency)
+
+	//occupy the only slot, the next transaction waits for it until it times out
+	assert.NoError(t, chrte.queue.acquire(invokeClass, time.Now().Add(time.Second)))
+
+	cccid := ccprovider.NewCCContext("testchainid", "limited", "0", "txid2", false, nil, nil)
+	msg := &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_TRANSACTION, Txid: "txid2"}
+	_, err := cs.Execute(context.Background(), cccid, msg, time.Minute)
+	assert.IsType(t, &BackpressureError{}, err)
+
+	//once the slot is released the transaction is sent to the chaincode
+	chrte.queue.release()
+	_, err = cs.Execute(context.Background(), cccid, msg, time.Minute)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Timeout expired")
+	assert.Equal(t, 0, chrte.queue.inflight)
+}
@@ -68,6 +68,10 @@ func Execute(ctxt context.Context, cccid *ccprovider.CCContext, spec interface{}
 
 	resp, err := theChaincodeSupport.Execute(ctxt, cccid, ccMsg, theChaincodeSupport.executetimeout)
 	if err != nil {
+		if _, ok := err.(*BackpressureError); ok {
+			//returned as is so that callers can tell the proposal may be retried
+			return nil, nil, err
+		}
 		// Rollback transaction
 		return nil, nil, fmt.Errorf("Failed to execute transaction (%s)", err)
 	} else if resp == nil {
@@ -229,11 +229,21 @@ func (v *vsccValidatorImpl) VSCCValidateTx(payload *common.Payload, envBytes []b
 	// LCCC should not undergo standard VSCC type of
 	// validation. It should instead go through system
 	// policy validation to determine whether the issuer
-	// is entitled to deploy a chaincode on our chain
-	// VSCCValidateTx should
+	// is entitled to deploy a chaincode on our chain:
+	// LCCC checks that the chaincode definitions the
+	// transaction commits satisfy the lifecycle policy
 	if hdrExt.ChaincodeId.Name == "lccc" {
-		// TODO: until FAB-1934 is in, we need to stop here
-		logger.Debugf("Invocation of LCCC detected, no further VSCC validation necessary")
+		logger.Debugf("Invocation of LCCC detected, validating it against the lifecycle policy")
+		cccid := v.ccprovider.GetCCContext(chainID, "lccc", coreUtil.GetSysCCVersion(), coreUtil.GenerateUUID(), true, nil, nil)
+		res, _, err := v.ccprovider.ExecuteChaincode(ctxt, cccid, [][]byte{[]byte("validate"), []byte(chainID), envBytes})
+		if err != nil {
+			logger.Errorf("Invoke LCCC validate failed for transaction txid=%s, error %s", txid, err)
+			return err
+		}
+		if res.Status != shim.OK {
+			logger.Errorf("LCCC check failed for transaction txid=%s, error %s", txid, res.Message)
+			return fmt.Errorf("%s", res.Message)
+		}
 		return nil
 	}
 
@@ -0,0 +1,242 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ccpackage
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/cauthdsl"
+	"github.com/hyperledger/fabric/msp"
+	mspmgmt "github.com/hyperledger/fabric/msp/mgmt"
+	"github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
+)
+
+//A signed chaincode package is an Envelope of type CHAINCODE_PACKAGE whose
+//data is a SignedChaincodeDeploymentSpec. Each owner of the chaincode signs
+//the deployment spec and the owner policy; the package can only be
+//installed if the owner signatures are valid and satisfy the owner policy.
+
+//getOwnerSignedData returns the data signed by an owner: the deployment spec,
+//the owner policy and the owner marshalled together, so that the boundaries
+//between them are part of what is signed
+func getOwnerSignedData(scds *pb.SignedChaincodeDeploymentSpec, owner []byte) ([]byte, error) {
+	data, err := proto.Marshal(&pb.SignedChaincodeDeploymentSpec{
+		ChaincodeDeploymentSpec: scds.ChaincodeDeploymentSpec,
+		OwnerPolicy:             scds.OwnerPolicy,
+		OwnerEndorsements:       []*pb.Endorsement{{Endorser: owner}},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error marshalling owner signed data: %s", err)
+	}
+	return data, nil
+}
+
+//createEnvelope wraps a signed deployment spec in a package envelope
+func createEnvelope(scds *pb.SignedChaincodeDeploymentSpec) (*common.Envelope, error) {
+	data, err := proto.Marshal(scds)
+	if err != nil {
+		return nil, fmt.Errorf("error marshalling signed deployment spec: %s", err)
+	}
+
+	payload := &common.Payload{
+		Header: &common.Header{ChannelHeader: utils.MarshalOrPanic(utils.MakeChannelHeader(common.HeaderType_CHAINCODE_PACKAGE, 0, "", 0))},
+		Data:   data,
+	}
+
+	payloadBytes, err := proto.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("error marshalling package payload: %s", err)
+	}
+
+	return &common.Envelope{Payload: payloadBytes}, nil
+}
+
+//addOwnerEndorsement signs the deployment spec with the given owner identity
+func addOwnerEndorsement(scds *pb.SignedChaincodeDeploymentSpec, owner msp.SigningIdentity) error {
+	endorser, err := owner.Serialize()
+	if err != nil {
+		return fmt.Errorf("could not serialize owner identity: %s", err)
+	}
+
+	for _, e := range scds.OwnerEndorsements {
+		if bytes.Equal(e.Endorser, endorser) {
+			return fmt.Errorf("package already signed by %s", owner.GetIdentifier())
+		}
+	}
+
+	data, err := getOwnerSignedData(scds, endorser)
+	if err != nil {
+		return err
+	}
+
+	signature, err := owner.Sign(data)
+	if err != nil {
+		return fmt.Errorf("could not sign the package: %s", err)
+	}
+
+	scds.OwnerEndorsements = append(scds.OwnerEndorsements, &pb.Endorsement{Endorser: endorser, Signature: signature})
+	return nil
+}
+
+// OwnerCreateSignedCCDepSpec creates a signed package of the deployment spec
+// with the given owner policy. The package is signed by owner unless owner
+// is nil, in which case the owners sign it later with SignExistingPackage
+func OwnerCreateSignedCCDepSpec(cds *pb.ChaincodeDeploymentSpec, ownerPolicy *common.SignaturePolicyEnvelope, owner msp.SigningIdentity) (*common.Envelope, error) {
+	if cds == nil || cds.ChaincodeSpec == nil || cds.ChaincodeSpec.ChaincodeId == nil {
+		return nil, fmt.Errorf("invalid chaincode deployment spec")
+	}
+
+	if ownerPolicy == nil {
+		return nil, fmt.Errorf("owner policy not provided")
+	}
+
+	cdsBytes, err := proto.Marshal(cds)
+	if err != nil {
+		return nil, fmt.Errorf("error marshalling deployment spec: %s", err)
+	}
+
+	policyBytes, err := proto.Marshal(ownerPolicy)
+	if err != nil {
+		return nil, fmt.Errorf("error marshalling owner policy: %s", err)
+	}
+
+	scds := &pb.SignedChaincodeDeploymentSpec{ChaincodeDeploymentSpec: cdsBytes, OwnerPolicy: policyBytes}
+	if owner != nil {
+		if err = addOwnerEndorsement(scds, owner); err != nil {
+			return nil, err
+		}
+	}
+
+	return createEnvelope(scds)
+}
+
+// SignExistingPackage adds the signature of owner to a signed package
+func SignExistingPackage(env *common.Envelope, owner msp.SigningIdentity) (*common.Envelope, error) {
+	scds, err := ExtractSignedCCDepSpec(env)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = addOwnerEndorsement(scds, owner); err != nil {
+		return nil, err
+	}
+
+	return createEnvelope(scds)
+}
+
+// ExtractSignedCCDepSpec returns the signed deployment spec of a package
+func ExtractSignedCCDepSpec(env *common.Envelope) (*pb.SignedChaincodeDeploymentSpec, error) {
+	scds := &pb.SignedChaincodeDeploymentSpec{}
+	if _, err := utils.UnmarshalEnvelopeOfType(env, common.HeaderType_CHAINCODE_PACKAGE, scds); err != nil {
+		return nil, fmt.Errorf("invalid chaincode package: %s", err)
+	}
+	return scds, nil
+}
+
+// GetSignedPackage returns the package envelope and true if pkg is a signed
+// package, or false if it is a plain deployment spec
+func GetSignedPackage(pkg []byte) (*common.Envelope, bool) {
+	env, err := utils.UnmarshalEnvelope(pkg)
+	if err != nil {
+		return nil, false
+	}
+
+	if _, err = ExtractSignedCCDepSpec(env); err != nil {
+		return nil, false
+	}
+
+	return env, true
+}
+
+// ValidateSignedPackage checks the owner signatures of a signed package and
+// returns its deployment spec. It fails if any signature is invalid, meaning
+// the package was tampered with, or if the signatures do not satisfy the
+// owner policy
+func ValidateSignedPackage(env *common.Envelope, deserializer msp.IdentityDeserializer) (*pb.ChaincodeDeploymentSpec, error) {
+	scds, err := ExtractSignedCCDepSpec(env)
+	if err != nil {
+		return nil, err
+	}
+
+	cds := &pb.ChaincodeDeploymentSpec{}
+	if err = proto.Unmarshal(scds.ChaincodeDeploymentSpec, cds); err != nil {
+		return nil, fmt.Errorf("invalid deployment spec in package: %s", err)
+	}
+
+	if len(scds.OwnerPolicy) == 0 {
+		return nil, fmt.Errorf("package has no owner policy")
+	}
+
+	policy, _, err := cauthdsl.NewPolicyProvider(deserializer).NewPolicy(scds.OwnerPolicy)
+	if err != nil {
+		return nil, fmt.Errorf("invalid owner policy: %s", err)
+	}
+
+	signedData := make([]*common.SignedData, len(scds.OwnerEndorsements))
+	for i, e := range scds.OwnerEndorsements {
+		owner, err := deserializer.DeserializeIdentity(e.Endorser)
+		if err != nil {
+			return nil, fmt.Errorf("unknown owner identity: %s", err)
+		}
+
+		data, err := getOwnerSignedData(scds, e.Endorser)
+		if err != nil {
+			return nil, err
+		}
+		if err = owner.Verify(data, e.Signature); err != nil {
+			return nil, fmt.Errorf("invalid signature of owner %s: %s", owner.GetIdentifier(), err)
+		}
+
+		signedData[i] = &common.SignedData{Data: data, Identity: e.Endorser, Signature: e.Signature}
+	}
+
+	if err = policy.Evaluate(signedData); err != nil {
+		return nil, fmt.Errorf("owner signatures do not satisfy the owner policy: %s", err)
+	}
+
+	return cds, nil
+}
+
+//peerDeserializer deserializes identities of the local MSP and of the MSPs
+//of the channels the peer has joined
+type peerDeserializer struct {
+}
+
+// NewPeerDeserializer returns a deserializer for the owner identities of
+// the packages installed on this peer
+func NewPeerDeserializer() msp.IdentityDeserializer {
+	return &peerDeserializer{}
+}
+
+func (d *peerDeserializer) DeserializeIdentity(serializedIdentity []byte) (msp.Identity, error) {
+	id, err := mspmgmt.GetLocalMSP().DeserializeIdentity(serializedIdentity)
+	if err == nil {
+		return id, nil
+	}
+
+	for _, deserializer := range mspmgmt.GetDeserializers() {
+		if id, err = deserializer.DeserializeIdentity(serializedIdentity); err == nil {
+			return id, nil
+		}
+	}
+
+	return nil, fmt.Errorf("identity is not known to the local MSP or to the MSPs of the channels: %s", err)
+}
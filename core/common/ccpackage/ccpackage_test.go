@@ -0,0 +1,208 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ccpackage
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/cauthdsl"
+	"github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/msp"
+	"github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+//mockIdentity is a member of the MSP it is named after. It serializes to
+//its MSP ID and its signature is the hash of the MSP ID and the message
+type mockIdentity struct {
+	mspid string
+}
+
+func (id *mockIdentity) SatisfiesPrincipal(p *common.MSPPrincipal) error {
+	role := &common.MSPRole{}
+	if err := proto.Unmarshal(p.Principal, role); err != nil {
+		return err
+	}
+	if role.MspIdentifier != id.mspid {
+		return errors.New("Principals do not match")
+	}
+	return nil
+}
+
+func (id *mockIdentity) GetIdentifier() *msp.IdentityIdentifier {
+	return &msp.IdentityIdentifier{Mspid: id.mspid, Id: id.mspid}
+}
+
+func (id *mockIdentity) GetMSPIdentifier() string {
+	return id.mspid
+}
+
+func (id *mockIdentity) Validate() error {
+	return nil
+}
+
+func (id *mockIdentity) GetOrganizationalUnits() []string {
+	return nil
+}
+
+func (id *mockIdentity) Verify(msg []byte, sig []byte) error {
+	if !bytes.Equal(sig, util.ComputeSHA256(append([]byte(id.mspid), msg...))) {
+		return errors.New("Invalid signature")
+	}
+	return nil
+}
+
+func (id *mockIdentity) VerifyOpts(msg []byte, sig []byte, opts msp.SignatureOpts) error {
+	return id.Verify(msg, sig)
+}
+
+func (id *mockIdentity) VerifyAttributes(proof []byte, spec *msp.AttributeProofSpec) error {
+	return nil
+}
+
+func (id *mockIdentity) Serialize() ([]byte, error) {
+	return []byte(id.mspid), nil
+}
+
+type mockSigningIdentity struct {
+	mockIdentity
+}
+
+func (id *mockSigningIdentity) Sign(msg []byte) ([]byte, error) {
+	return util.ComputeSHA256(append([]byte(id.mspid), msg...)), nil
+}
+
+func (id *mockSigningIdentity) SignOpts(msg []byte, opts msp.SignatureOpts) ([]byte, error) {
+	return id.Sign(msg)
+}
+
+func (id *mockSigningIdentity) GetAttributeProof(spec *msp.AttributeProofSpec) ([]byte, error) {
+	return nil, nil
+}
+
+func (id *mockSigningIdentity) GetPublicVersion() msp.Identity {
+	return &id.mockIdentity
+}
+
+func (id *mockSigningIdentity) Renew() error {
+	return nil
+}
+
+type mockDeserializer struct {
+}
+
+func (md *mockDeserializer) DeserializeIdentity(serializedIdentity []byte) (msp.Identity, error) {
+	if string(serializedIdentity) == "UnknownMSP" {
+		return nil, errors.New("Unknown MSP")
+	}
+	return &mockIdentity{mspid: string(serializedIdentity)}, nil
+}
+
+func getDeploymentSpec() *pb.ChaincodeDeploymentSpec {
+	spec := &pb.ChaincodeSpec{Type: 1, ChaincodeId: &pb.ChaincodeID{Name: "mycc", Path: "mycc/path", Version: "0"}, Input: &pb.ChaincodeInput{Args: [][]byte{[]byte("init")}}}
+	return &pb.ChaincodeDeploymentSpec{ChaincodeSpec: spec, CodePackage: []byte("code")}
+}
+
+func getOwnerPolicy(t *testing.T) *common.SignaturePolicyEnvelope {
+	p, err := cauthdsl.FromString("AND('Org1MSP.member', 'Org2MSP.member')")
+	assert.NoError(t, err)
+	return p
+}
+
+func TestMultiOwnerPackage(t *testing.T) {
+	cds := getDeploymentSpec()
+	env, err := OwnerCreateSignedCCDepSpec(cds, getOwnerPolicy(t), &mockSigningIdentity{mockIdentity{"Org1MSP"}})
+	assert.NoError(t, err)
+
+	//Org2 did not sign the package yet
+	_, err = ValidateSignedPackage(env, &mockDeserializer{})
+	assert.Error(t, err)
+
+	env, err = SignExistingPackage(env, &mockSigningIdentity{mockIdentity{"Org2MSP"}})
+	assert.NoError(t, err)
+
+	pkg, err := proto.Marshal(env)
+	assert.NoError(t, err)
+	env, ok := GetSignedPackage(pkg)
+	assert.True(t, ok)
+
+	validated, err := ValidateSignedPackage(env, &mockDeserializer{})
+	assert.NoError(t, err)
+	assert.True(t, proto.Equal(cds, validated))
+
+	//an owner signs a package only once
+	_, err = SignExistingPackage(env, &mockSigningIdentity{mockIdentity{"Org2MSP"}})
+	assert.Error(t, err)
+}
+
+func TestTamperedPackage(t *testing.T) {
+	env, err := OwnerCreateSignedCCDepSpec(getDeploymentSpec(), getOwnerPolicy(t), &mockSigningIdentity{mockIdentity{"Org1MSP"}})
+	assert.NoError(t, err)
+	env, err = SignExistingPackage(env, &mockSigningIdentity{mockIdentity{"Org2MSP"}})
+	assert.NoError(t, err)
+
+	scds, err := ExtractSignedCCDepSpec(env)
+	assert.NoError(t, err)
+
+	tampered := getDeploymentSpec()
+	tampered.CodePackage = []byte("malicious code")
+	scds.ChaincodeDeploymentSpec = utils.MarshalOrPanic(tampered)
+	env, err = createEnvelope(scds)
+	assert.NoError(t, err)
+
+	_, err = ValidateSignedPackage(env, &mockDeserializer{})
+	assert.Error(t, err)
+}
+
+func TestUnknownOwner(t *testing.T) {
+	env, err := OwnerCreateSignedCCDepSpec(getDeploymentSpec(), getOwnerPolicy(t), &mockSigningIdentity{mockIdentity{"UnknownMSP"}})
+	assert.NoError(t, err)
+
+	_, err = ValidateSignedPackage(env, &mockDeserializer{})
+	assert.Error(t, err)
+}
+
+func TestRawDeploymentSpecIsNotSignedPackage(t *testing.T) {
+	_, ok := GetSignedPackage(utils.MarshalOrPanic(getDeploymentSpec()))
+	assert.False(t, ok)
+}
+
+func TestShiftedBoundaryPackage(t *testing.T) {
+	env, err := OwnerCreateSignedCCDepSpec(getDeploymentSpec(), getOwnerPolicy(t), &mockSigningIdentity{mockIdentity{"Org1MSP"}})
+	assert.NoError(t, err)
+	env, err = SignExistingPackage(env, &mockSigningIdentity{mockIdentity{"Org2MSP"}})
+	assert.NoError(t, err)
+
+	scds, err := ExtractSignedCCDepSpec(env)
+	assert.NoError(t, err)
+
+	//moving bytes between the deployment spec and the owner policy keeps
+	//their concatenation but must invalidate the signatures
+	n := len(scds.ChaincodeDeploymentSpec) - 1
+	scds.OwnerPolicy = append(append([]byte{}, scds.ChaincodeDeploymentSpec[n:]...), scds.OwnerPolicy...)
+	scds.ChaincodeDeploymentSpec = scds.ChaincodeDeploymentSpec[:n]
+	env, err = createEnvelope(scds)
+	assert.NoError(t, err)
+
+	_, err = ValidateSignedPackage(env, &mockDeserializer{})
+	assert.Error(t, err)
+}
@@ -108,6 +108,28 @@ func PutChaincodeIntoFS(depSpec *pb.ChaincodeDeploymentSpec) error {
 	return nil
 }
 
+//PutSignedPackageIntoFS keeps the signed package a chaincode was installed
+//from next to its deployment spec, so that the owner signatures are not lost
+func PutSignedPackageIntoFS(ccname string, ccversion string, pkg []byte) error {
+	path := fmt.Sprintf("%s/%s.%s.signed", chaincodeInstallPath, ccname, ccversion)
+	if _, err := os.Stat(path); err == nil {
+		return fmt.Errorf("signed package %s exists", path)
+	}
+
+	return ioutil.WriteFile(path, pkg, 0644)
+}
+
+//GetSignedPackageFromFS returns the signed package a chaincode was installed
+//from, or nil if it was installed from a plain deployment spec
+func GetSignedPackageFromFS(ccname string, ccversion string) ([]byte, error) {
+	path := fmt.Sprintf("%s/%s.%s.signed", chaincodeInstallPath, ccname, ccversion)
+	pkg, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	return pkg, err
+}
+
 // GetInstalledChaincodes returns a map whose key is the chaincode id and
 // value is the ChaincodeDeploymentSpec struct for that chaincodes that have
 // been installed (but not necessarily instantiated) on the peer by searching
@@ -228,6 +250,10 @@ type ChaincodeData struct {
 	Escc    string `protobuf:"bytes,4,opt,name=escc"`
 	Vscc    string `protobuf:"bytes,5,opt,name=vscc"`
 	Policy  []byte `protobuf:"bytes,6,opt,name=policy"`
+
+	//ResourceLimits declared when the chaincode was instantiated or upgraded,
+	//they apply whenever the chaincode is launched
+	ResourceLimits *pb.ChaincodeResourceLimits `protobuf:"bytes,7,opt,name=resourceLimits"`
 }
 
 //implement functions needed from proto.Message for proto's mar/unmarshal functions
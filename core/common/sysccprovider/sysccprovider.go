@@ -16,6 +16,11 @@ limitations under the License.
 
 package sysccprovider
 
+import (
+	"github.com/hyperledger/fabric/common/policies"
+	"github.com/hyperledger/fabric/msp"
+)
+
 // SystemChaincodeProvider provides an abstraction layer that is
 // used for different packages to interact with code in the
 // system chaincode package without importing it; more methods
@@ -23,6 +28,14 @@ package sysccprovider
 type SystemChaincodeProvider interface {
 	// IsSysCC returns true if the supplied chaincode is a system chaincode
 	IsSysCC(name string) bool
+
+	// GetPolicyManager returns the policy manager of the supplied chain and
+	// true, or false if the chain does not exist
+	GetPolicyManager(chainID string) (policies.Manager, bool)
+
+	// GetIdentityDeserializer returns the identity deserializer of the
+	// supplied chain and true, or false if the chain does not exist
+	GetIdentityDeserializer(chainID string) (msp.IdentityDeserializer, bool)
 }
 
 var sccFactory SystemChaincodeProviderFactory
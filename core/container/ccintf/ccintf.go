@@ -52,6 +52,9 @@ type CCID struct {
 	PeerID        string
 	ChainID       string
 	Version       string
+	// ResourceLimits optionally overrides the VM's default resource settings.
+	// It does not take part in naming the chaincode instance.
+	ResourceLimits *pb.ChaincodeResourceLimits
 }
 
 //GetName returns canonical chaincode name based on chain name
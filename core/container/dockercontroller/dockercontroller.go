@@ -29,6 +29,7 @@ import (
 	container "github.com/hyperledger/fabric/core/container/api"
 	"github.com/hyperledger/fabric/core/container/ccintf"
 	cutil "github.com/hyperledger/fabric/core/container/util"
+	pb "github.com/hyperledger/fabric/protos/peer"
 	"github.com/op/go-logging"
 	"github.com/spf13/viper"
 	"golang.org/x/net/context"
@@ -104,9 +105,30 @@ func getDockerHostConfig() *docker.HostConfig {
 	return hostConfig
 }
 
-func (vm *DockerVM) createContainer(ctxt context.Context, client *docker.Client, imageID string, containerID string, args []string, env []string, attachStdout bool) error {
+//getChaincodeHostConfig returns the peer-wide host config with the
+//chaincode specific resource limits applied on top of it. The shared
+//host config is never modified
+func getChaincodeHostConfig(limits *pb.ChaincodeResourceLimits) *docker.HostConfig {
+	hc := getDockerHostConfig()
+	if limits == nil || (limits.Memory <= 0 && limits.CpuShares <= 0) {
+		return hc
+	}
+
+	ccHostConfig := *hc
+	if limits.Memory > 0 {
+		dockerLogger.Debugf("overriding container memory limit %d with %d", ccHostConfig.Memory, limits.Memory)
+		ccHostConfig.Memory = limits.Memory
+	}
+	if limits.CpuShares > 0 {
+		dockerLogger.Debugf("overriding container cpu shares %d with %d", ccHostConfig.CPUShares, limits.CpuShares)
+		ccHostConfig.CPUShares = limits.CpuShares
+	}
+	return &ccHostConfig
+}
+
+func (vm *DockerVM) createContainer(ctxt context.Context, client *docker.Client, imageID string, containerID string, args []string, env []string, attachStdout bool, limits *pb.ChaincodeResourceLimits) error {
 	config := docker.Config{Cmd: args, Image: imageID, Env: env, AttachStdout: attachStdout, AttachStderr: attachStdout}
-	copts := docker.CreateContainerOptions{Name: containerID, Config: &config, HostConfig: getDockerHostConfig()}
+	copts := docker.CreateContainerOptions{Name: containerID, Config: &config, HostConfig: getChaincodeHostConfig(limits)}
 	dockerLogger.Debugf("Create container: %s", containerID)
 	_, err := client.CreateContainer(copts)
 	if err != nil {
@@ -171,7 +193,7 @@ func (vm *DockerVM) Start(ctxt context.Context, ccid ccintf.CCID, args []string,
 	vm.stopInternal(ctxt, client, containerID, 0, false, false)
 
 	dockerLogger.Debugf("Start container %s", containerID)
-	err = vm.createContainer(ctxt, client, imageID, containerID, args, env, attachStdout)
+	err = vm.createContainer(ctxt, client, imageID, containerID, args, env, attachStdout, ccid.ResourceLimits)
 	if err != nil {
 		//if image not found try to create image and retry
 		if err == docker.ErrNoSuchImage {
@@ -188,7 +210,7 @@ func (vm *DockerVM) Start(ctxt context.Context, ccid ccintf.CCID, args []string,
 				}
 
 				dockerLogger.Debug("start-recreated image successfully")
-				if err = vm.createContainer(ctxt, client, imageID, containerID, args, env, attachStdout); err != nil {
+				if err = vm.createContainer(ctxt, client, imageID, containerID, args, env, attachStdout, ccid.ResourceLimits); err != nil {
 					dockerLogger.Errorf("start-could not recreate container post recreate image: %s", err)
 					return err
 				}
@@ -26,6 +26,7 @@ import (
 
 	"github.com/hyperledger/fabric/common/ledger/testutil"
 	"github.com/hyperledger/fabric/core/config"
+	pb "github.com/hyperledger/fabric/protos/peer"
 )
 
 func TestHostConfig(t *testing.T) {
@@ -54,3 +55,17 @@ func TestGetDockerHostConfig(t *testing.T) {
 	testutil.AssertEquals(t, hostConfig.Memory, int64(1024*1024*1024*2))
 	testutil.AssertEquals(t, hostConfig.CPUShares, int64(1024*1024*1024*2))
 }
+
+func TestGetChaincodeHostConfig(t *testing.T) {
+	config.SetupTestConfig("./../../../peer")
+	hostConfig := getDockerHostConfig()
+
+	testutil.AssertSame(t, getChaincodeHostConfig(nil), hostConfig)
+	testutil.AssertSame(t, getChaincodeHostConfig(&pb.ChaincodeResourceLimits{ExecuteTimeout: 1000}), hostConfig)
+
+	ccHostConfig := getChaincodeHostConfig(&pb.ChaincodeResourceLimits{Memory: 64 * 1024 * 1024, CpuShares: 512})
+	testutil.AssertEquals(t, ccHostConfig.Memory, int64(64*1024*1024))
+	testutil.AssertEquals(t, ccHostConfig.CPUShares, int64(512))
+	testutil.AssertEquals(t, ccHostConfig.NetworkMode, hostConfig.NetworkMode)
+	testutil.AssertNotEquals(t, hostConfig.Memory, int64(64*1024*1024))
+}
@@ -17,12 +17,15 @@ limitations under the License.
 package blocksprovider
 
 import (
+	"bytes"
+	"fmt"
 	"math"
 	"sync/atomic"
 
 	"github.com/golang/protobuf/proto"
 	gossipcommon "github.com/hyperledger/fabric/gossip/common"
 	"github.com/hyperledger/fabric/gossip/discovery"
+	"github.com/rcrowley/go-metrics"
 
 	"github.com/hyperledger/fabric/common/localmsp"
 	"github.com/hyperledger/fabric/gossip/api"
@@ -40,6 +43,13 @@ type LedgerInfo interface {
 	LedgerHeight() (uint64, error)
 }
 
+// BlocksRetriever is implemented by a LedgerInfo which also retrieves the committed
+// blocks, the first delivered block is then verified to be chained to the last one
+type BlocksRetriever interface {
+	// GetBlocks returns the committed blocks with the given sequence numbers
+	GetBlocks(blockSeqs []uint64) []*common.Block
+}
+
 // GossipServiceAdapter serves to provide basic functionality
 // required from gossip service by delivery service
 type GossipServiceAdapter interface {
@@ -77,6 +87,13 @@ type BlocksDeliverer interface {
 
 	// Send used to send request to the ordering service to obtain new blocks
 	Send(*common.Envelope) error
+
+	// Disconnect closes the connection to the orderer which failed or delivered a block
+	// failing verification, the next request is sent to another orderer
+	Disconnect()
+
+	// Close closes the connection to the ordering service
+	Close()
 }
 
 // blocksProviderImpl the actual implementation for BlocksProvider interface
@@ -89,6 +106,13 @@ type blocksProviderImpl struct {
 
 	mcs api.MessageCryptoService
 
+	ledgerInfo LedgerInfo
+
+	// nextNum is the number of the block expected next, which must be chained to prevHeader
+	nextNum uint64
+
+	prevHeader *common.BlockHeader
+
 	done int32
 }
 
@@ -114,8 +138,15 @@ func (b *blocksProviderImpl) DeliverBlocks() {
 	for !b.isDone() {
 		msg, err := b.client.Recv()
 		if err != nil {
+			if b.isDone() {
+				return
+			}
 			logger.Warningf("Receive error: %s", err.Error())
-			return
+			if err := b.reconnect(); err != nil {
+				logger.Errorf("Can't request blocks from another orderer, due to %s", err)
+				return
+			}
+			continue
 		}
 		switch t := msg.Type.(type) {
 		case *orderer.DeliverResponse_Status:
@@ -125,17 +156,18 @@ func (b *blocksProviderImpl) DeliverBlocks() {
 			}
 			logger.Warning("Got error ", t)
 		case *orderer.DeliverResponse_Block:
-			seqNum := t.Block.Header.Number
-
-			marshaledBlock, err := proto.Marshal(t.Block)
+			marshaledBlock, err := b.verifyBlock(t.Block)
 			if err != nil {
-				logger.Errorf("Error serializing block with sequence number %d, due to %s", seqNum, err)
-				continue
-			}
-			if err := b.mcs.VerifyBlock(gossipcommon.ChainID(b.chainID), marshaledBlock); err != nil {
-				logger.Errorf("Error verifying block with sequnce number %d, due to %s", seqNum, err)
+				logger.Errorf("Rejecting block delivered on channel %s, due to %s", b.chainID, err)
+				countBlocks(b.chainID, "rejected")
+				if err := b.reconnect(); err != nil {
+					logger.Errorf("Can't request blocks from another orderer, due to %s", err)
+					return
+				}
 				continue
 			}
+			countBlocks(b.chainID, "verified")
+			seqNum := t.Block.Header.Number
 
 			numberOfPeers := len(b.gossip.PeersOfChannel(gossipcommon.ChainID(b.chainID)))
 			// Create payload with a block received
@@ -157,9 +189,51 @@ func (b *blocksProviderImpl) DeliverBlocks() {
 	}
 }
 
+// verifyBlock checks that the block is the next one of the chain, that its header is
+// chained to the header of the previous block, and that it is signed by the orderers as
+// the BlockValidation policy of the channel requires, returning the marshaled block
+func (b *blocksProviderImpl) verifyBlock(block *common.Block) ([]byte, error) {
+	if block.Header == nil {
+		return nil, fmt.Errorf("Block has no header")
+	}
+	seqNum := block.Header.Number
+	if seqNum != b.nextNum {
+		return nil, fmt.Errorf("Expected block with sequence number %d, got %d", b.nextNum, seqNum)
+	}
+	if b.prevHeader != nil && !bytes.Equal(block.Header.PreviousHash, b.prevHeader.Hash()) {
+		return nil, fmt.Errorf("Previous hash of block with sequence number %d does not match the hash of block %d", seqNum, b.prevHeader.Number)
+	}
+
+	marshaledBlock, err := proto.Marshal(block)
+	if err != nil {
+		return nil, fmt.Errorf("Error serializing block with sequence number %d, due to %s", seqNum, err)
+	}
+	if err := b.mcs.VerifyBlock(gossipcommon.ChainID(b.chainID), marshaledBlock); err != nil {
+		return nil, fmt.Errorf("Error verifying block with sequence number %d, due to %s", seqNum, err)
+	}
+
+	b.nextNum = seqNum + 1
+	b.prevHeader = block.Header
+	return marshaledBlock, nil
+}
+
+// reconnect disconnects from the orderer which failed or delivered a block failing
+// verification, and requests the blocks from another orderer resuming at the ledger height
+func (b *blocksProviderImpl) reconnect() error {
+	metrics.GetOrRegisterCounter(fmt.Sprintf("deliverclient.%s.reconnects", b.chainID), nil).Inc(1)
+	b.client.Disconnect()
+	return b.RequestBlocks(b.ledgerInfo)
+}
+
+// countBlocks counts the blocks delivered on a channel by the result of their verification
+func countBlocks(chainID string, result string) {
+	metrics.GetOrRegisterCounter(fmt.Sprintf("deliverclient.%s.blocks.%s", chainID, result), nil).Inc(1)
+}
+
 // Stops blocks delivery provider
 func (b *blocksProviderImpl) Stop() {
 	atomic.StoreInt32(&b.done, 1)
+	b.client.Close()
 }
 
 // Check whenever provider is stopped
@@ -174,6 +248,15 @@ func (b *blocksProviderImpl) RequestBlocks(ledgerInfoProvider LedgerInfo) error
 		return err
 	}
 
+	b.ledgerInfo = ledgerInfoProvider
+	b.nextNum = height
+	b.prevHeader = nil
+	if retriever, ok := ledgerInfoProvider.(BlocksRetriever); ok && height > 0 {
+		if blocks := retriever.GetBlocks([]uint64{height - 1}); len(blocks) == 1 {
+			b.prevHeader = blocks[0].Header
+		}
+	}
+
 	if height > 0 {
 		logger.Debugf("Starting deliver with block [%d]", height)
 		if err := b.seekLatestFromCommitter(height); err != nil {
@@ -16,18 +16,28 @@ limitations under the License.
 package blocksprovider
 
 import (
+	"fmt"
+	"os"
 	"sync"
 	"testing"
 	"time"
 
 	"github.com/hyperledger/fabric/core/deliverservice/mocks"
 	"github.com/hyperledger/fabric/gossip/api"
+	msptesttools "github.com/hyperledger/fabric/msp/mgmt/testtools"
 	common2 "github.com/hyperledger/fabric/gossip/common"
 	"github.com/hyperledger/fabric/protos/common"
 	"github.com/hyperledger/fabric/protos/orderer"
+	"github.com/rcrowley/go-metrics"
 	"github.com/stretchr/testify/assert"
 )
 
+func TestMain(m *testing.M) {
+	// The requests for blocks are signed by the local MSP
+	msptesttools.LoadMSPSetupForTesting("../../../msp/sampleconfig")
+	os.Exit(m.Run())
+}
+
 type mockMCS struct {
 }
 
@@ -172,3 +182,201 @@ func TestBlocksProvider_CheckTerminationDeliveryResponseStatus(t *testing.T) {
 		}
 	}
 }
+
+type mockLedgerBlocks struct {
+	mocks.MockLedgerInfo
+	blocks map[uint64]*common.Block
+}
+
+func (li *mockLedgerBlocks) GetBlocks(blockSeqs []uint64) []*common.Block {
+	var blocks []*common.Block
+	for _, seqNum := range blockSeqs {
+		if block, ok := li.blocks[seqNum]; ok {
+			blocks = append(blocks, block)
+		}
+	}
+	return blocks
+}
+
+// Delivers blocks until count blocks were received, the block at the position for which
+// tamper returns true is modified once before it is returned
+func makeTamperingRecv(provider *blocksProviderImpl, count int32, tamper func(block *common.Block) bool) func(mock *mocks.MockBlocksDeliverer) (*orderer.DeliverResponse, error) {
+	tampered := false
+	return func(mock *mocks.MockBlocksDeliverer) (*orderer.DeliverResponse, error) {
+		resp, err := mocks.MockRecv(mock)
+		if !tampered && tamper(resp.GetBlock()) {
+			tampered = true
+		}
+		if mock.RecvCnt == count {
+			provider.Stop()
+		}
+		return resp, err
+	}
+}
+
+func rejectedBlocks(chainID string) int64 {
+	return metrics.GetOrRegisterCounter(fmt.Sprintf("deliverclient.%s.blocks.rejected", chainID), nil).Count()
+}
+
+func TestBlocksProviderImpl_RejectBrokenChain(t *testing.T) {
+	gossipServiceAdapter := &mocks.MockGossipServiceAdapter{}
+	deliverer := &mocks.MockBlocksDeliverer{}
+	provider := &blocksProviderImpl{
+		chainID: "TEST_BROKEN_CHAIN",
+		gossip:  gossipServiceAdapter,
+		client:  deliverer,
+		mcs:     &mockMCS{},
+	}
+
+	// Block 2 is not chained to block 1, its delivery is requested again from another
+	// orderer from the ledger height, which is still 0
+	deliverer.MockRecv = makeTamperingRecv(provider, 6, func(block *common.Block) bool {
+		if block.Header.Number == 2 {
+			block.Header.PreviousHash = []byte("forged")
+			return true
+		}
+		return false
+	})
+
+	assert.NoError(t, provider.RequestBlocks(&mocks.MockLedgerInfo{Height: 0}))
+	rejected := rejectedBlocks("TEST_BROKEN_CHAIN")
+	provider.DeliverBlocks()
+
+	assert.Equal(t, int32(1), deliverer.DisconnectCnt)
+	assert.Equal(t, int64(1), rejectedBlocks("TEST_BROKEN_CHAIN")-rejected)
+	// Blocks 0, 1, then 0, 1, 2 once reconnected
+	assert.Equal(t, int32(5), gossipServiceAdapter.AddPayloadsCnt)
+	assert.Equal(t, int32(5), gossipServiceAdapter.GossipCallsCnt)
+	assert.Equal(t, uint64(3), provider.nextNum)
+}
+
+func TestBlocksProviderImpl_RejectOutOfOrder(t *testing.T) {
+	gossipServiceAdapter := &mocks.MockGossipServiceAdapter{}
+	deliverer := &mocks.MockBlocksDeliverer{}
+	provider := &blocksProviderImpl{
+		chainID: "TEST_OUT_OF_ORDER",
+		gossip:  gossipServiceAdapter,
+		client:  deliverer,
+		mcs:     &mockMCS{},
+	}
+
+	deliverer.MockRecv = makeTamperingRecv(provider, 3, func(block *common.Block) bool {
+		if block.Header.Number == 11 {
+			block.Header.Number = 12
+			return true
+		}
+		return false
+	})
+
+	assert.NoError(t, provider.RequestBlocks(&mocks.MockLedgerInfo{Height: 10}))
+	rejected := rejectedBlocks("TEST_OUT_OF_ORDER")
+	provider.DeliverBlocks()
+
+	assert.Equal(t, int32(1), deliverer.DisconnectCnt)
+	assert.Equal(t, int64(1), rejectedBlocks("TEST_OUT_OF_ORDER")-rejected)
+	assert.Equal(t, int32(2), gossipServiceAdapter.AddPayloadsCnt)
+}
+
+func TestBlocksProviderImpl_RejectUnsignedBlock(t *testing.T) {
+	gossipServiceAdapter := &mocks.MockGossipServiceAdapter{}
+	deliverer := &mocks.MockBlocksDeliverer{}
+	deliverer.MockRecv = mocks.MockRecv
+	provider := &blocksProviderImpl{
+		chainID: "TEST_UNSIGNED",
+		gossip:  gossipServiceAdapter,
+		client:  deliverer,
+		mcs:     &rejectingMCS{},
+	}
+
+	assert.NoError(t, provider.RequestBlocks(&mocks.MockLedgerInfo{Height: 0}))
+	deliverer.MockRecv = func(mock *mocks.MockBlocksDeliverer) (*orderer.DeliverResponse, error) {
+		if mock.RecvCnt == 3 {
+			provider.Stop()
+		}
+		return mocks.MockRecv(mock)
+	}
+	rejected := rejectedBlocks("TEST_UNSIGNED")
+	provider.DeliverBlocks()
+
+	assert.Equal(t, int32(3), deliverer.DisconnectCnt)
+	assert.Equal(t, int64(3), rejectedBlocks("TEST_UNSIGNED")-rejected)
+	assert.Equal(t, int32(0), gossipServiceAdapter.AddPayloadsCnt)
+	assert.Equal(t, int32(0), gossipServiceAdapter.GossipCallsCnt)
+}
+
+func TestBlocksProviderImpl_ChainedToLedger(t *testing.T) {
+	committed := &common.Block{Header: &common.BlockHeader{Number: 4, DataHash: []byte("data")}}
+	ledgerInfo := &mockLedgerBlocks{
+		MockLedgerInfo: mocks.MockLedgerInfo{Height: 5},
+		blocks:         map[uint64]*common.Block{4: committed},
+	}
+
+	for _, test := range []struct {
+		name         string
+		previousHash []byte
+		rejected     int32
+	}{
+		{"Chained", committed.Header.Hash(), 0},
+		{"NotChained", []byte("forged"), 1},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			gossipServiceAdapter := &mocks.MockGossipServiceAdapter{}
+			deliverer := &mocks.MockBlocksDeliverer{}
+			provider := &blocksProviderImpl{
+				chainID: "TEST_CHAINED_TO_LEDGER",
+				gossip:  gossipServiceAdapter,
+				client:  deliverer,
+				mcs:     &mockMCS{},
+			}
+			deliverer.MockRecv = makeTamperingRecv(provider, 1, func(block *common.Block) bool {
+				block.Header.PreviousHash = test.previousHash
+				return true
+			})
+
+			assert.NoError(t, provider.RequestBlocks(ledgerInfo))
+			assert.Equal(t, committed.Header, provider.prevHeader)
+			provider.DeliverBlocks()
+
+			assert.Equal(t, test.rejected, deliverer.DisconnectCnt)
+			assert.Equal(t, 1-test.rejected, gossipServiceAdapter.AddPayloadsCnt)
+		})
+	}
+}
+
+type rejectingMCS struct {
+	mockMCS
+}
+
+func (*rejectingMCS) VerifyBlock(chainID common2.ChainID, signedBlock []byte) error {
+	return fmt.Errorf("Block is not signed by the orderers")
+}
+
+func TestBlocksProviderImpl_ReconnectOnRecvError(t *testing.T) {
+	gossipServiceAdapter := &mocks.MockGossipServiceAdapter{}
+	deliverer := &mocks.MockBlocksDeliverer{}
+	provider := &blocksProviderImpl{
+		chainID: "TEST_RECV_ERROR",
+		gossip:  gossipServiceAdapter,
+		client:  deliverer,
+		mcs:     &mockMCS{},
+	}
+
+	// The third receive fails, the blocks are requested again from the ledger height
+	deliverer.MockRecv = func(mock *mocks.MockBlocksDeliverer) (*orderer.DeliverResponse, error) {
+		switch mock.RecvCnt {
+		case 3:
+			return nil, fmt.Errorf("Orderer is down")
+		case 5:
+			provider.Stop()
+		}
+		return mocks.MockRecv(mock)
+	}
+
+	assert.NoError(t, provider.RequestBlocks(&mocks.MockLedgerInfo{Height: 5}))
+	provider.DeliverBlocks()
+
+	assert.Equal(t, int32(1), deliverer.DisconnectCnt)
+	// Blocks 5, 6, then 5, 6 again once reconnected
+	assert.Equal(t, int32(4), gossipServiceAdapter.AddPayloadsCnt)
+	assert.Equal(t, uint64(7), provider.nextNum)
+}
@@ -0,0 +1,301 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+                 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deliverclient
+
+import (
+	"errors"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/fabric/gossip/util"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/orderer"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+)
+
+const (
+	defaultReconnectBackoff    = 100 * time.Millisecond
+	defaultReconnectBackoffMax = 10 * time.Second
+	defaultUnhealthyPeriod     = time.Minute
+)
+
+// clientConfig configures how the client connects to the ordering service endpoints
+type clientConfig struct {
+	// reconnectBackoff is waited for before connecting after a failure, it doubles after
+	// each consecutive failure up to reconnectBackoffMax
+	reconnectBackoff    time.Duration
+	reconnectBackoffMax time.Duration
+
+	// unhealthyPeriod is how long an endpoint which failed is only connected to once
+	// every healthy endpoint failed as well
+	unhealthyPeriod time.Duration
+}
+
+func loadClientConfig() clientConfig {
+	return clientConfig{
+		reconnectBackoff:    util.GetDurationOrDefault("peer.deliveryclient.reconnectBackoff", defaultReconnectBackoff),
+		reconnectBackoffMax: util.GetDurationOrDefault("peer.deliveryclient.reconnectBackoffMax", defaultReconnectBackoffMax),
+		unhealthyPeriod:     util.GetDurationOrDefault("peer.deliveryclient.unhealthyPeriod", defaultUnhealthyPeriod),
+	}
+}
+
+// backoff returns how long to wait after the given number of consecutive failures
+func (cc clientConfig) backoff(failures int) time.Duration {
+	backoff := cc.reconnectBackoff
+	for i := 0; i < failures && backoff < cc.reconnectBackoffMax; i++ {
+		backoff *= 2
+	}
+	if backoff > cc.reconnectBackoffMax {
+		backoff = cc.reconnectBackoffMax
+	}
+	return backoff
+}
+
+// EndpointsUpdater is implemented by the BlocksDeliverers which connect to the ordering
+// service endpoints of the channel config, and follow its updates
+type EndpointsUpdater interface {
+	// UpdateEndpoints replaces the ordering service endpoints, disconnecting from the
+	// current one if it was removed
+	UpdateEndpoints(endpoints []string)
+}
+
+// ordererClient implements the BlocksDeliverer interface over a connection to one of the
+// ordering service endpoints. The endpoints which failed are unhealthy for a while, the
+// healthy ones are connected to first, and after failures the client backs off before
+// connecting again, until it is closed.
+type ordererClient struct {
+	lock sync.Mutex
+
+	endpoints []string
+
+	dial func(endpoint string) (*grpc.ClientConn, error)
+
+	config clientConfig
+
+	// endpoint is the endpoint connected to, or last connected to once disconnected
+	endpoint string
+
+	// unhealthy maps the endpoints which failed to the time they are healthy again
+	unhealthy map[string]time.Time
+
+	// failures counts the failures since a response was last received, the client
+	// backs off before connecting again after a failure
+	failures int
+
+	conn *grpc.ClientConn
+
+	stream orderer.AtomicBroadcast_DeliverClient
+
+	closed bool
+
+	closeChan chan struct{}
+}
+
+func newOrdererClient(endpoints []string, dial func(endpoint string) (*grpc.ClientConn, error), config clientConfig) *ordererClient {
+	return &ordererClient{
+		endpoints: endpoints,
+		dial:      dial,
+		config:    config,
+		unhealthy: make(map[string]time.Time),
+		closeChan: make(chan struct{}),
+	}
+}
+
+// connect opens a deliver stream to one of the endpoints, it only returns an error once closed
+func (oc *ordererClient) connect() error {
+	for {
+		oc.lock.Lock()
+		closed, connected, failures := oc.closed, oc.stream != nil, oc.failures
+		oc.lock.Unlock()
+		if closed {
+			return errors.New("Client to the ordering service is closed")
+		}
+		if connected {
+			return nil
+		}
+
+		if failures > 0 {
+			backoff := oc.config.backoff(failures - 1)
+			logger.Debugf("Waiting %s before connecting to the ordering service, after %d failures", backoff, failures)
+			select {
+			case <-time.After(backoff):
+			case <-oc.closeChan:
+				continue
+			}
+		}
+
+		for _, endpoint := range oc.candidates() {
+			logger.Infof("Connecting to the ordering service at %s", endpoint)
+			conn, stream, err := oc.open(endpoint)
+			if err != nil {
+				logger.Errorf("Cannot connect to %s, because of %s", endpoint, err)
+				oc.markUnhealthy(endpoint)
+				continue
+			}
+
+			oc.lock.Lock()
+			defer oc.lock.Unlock()
+			if oc.closed {
+				conn.Close()
+				return errors.New("Client to the ordering service is closed")
+			}
+			oc.endpoint = endpoint
+			oc.conn = conn
+			oc.stream = stream
+			return nil
+		}
+
+		oc.lock.Lock()
+		oc.failures++
+		logger.Warningf("Wasn't able to connect to any of ordering service endpoints %s", oc.endpoints)
+		oc.lock.Unlock()
+	}
+}
+
+func (oc *ordererClient) open(endpoint string) (*grpc.ClientConn, orderer.AtomicBroadcast_DeliverClient, error) {
+	conn, err := oc.dial(endpoint)
+	if err != nil {
+		return nil, nil, err
+	}
+	stream, err := orderer.NewAtomicBroadcastClient(conn).Deliver(context.TODO())
+	if err != nil {
+		conn.Close()
+		return nil, nil, err
+	}
+	return conn, stream, nil
+}
+
+// candidates returns the endpoints in the order they are connected to, the healthy ones
+// first starting after the endpoint last connected to, then the unhealthy ones
+func (oc *ordererClient) candidates() []string {
+	oc.lock.Lock()
+	defer oc.lock.Unlock()
+	if len(oc.endpoints) == 0 {
+		return nil
+	}
+
+	start := rand.Intn(len(oc.endpoints))
+	for i, endpoint := range oc.endpoints {
+		if endpoint == oc.endpoint {
+			start = i + 1
+		}
+	}
+
+	var healthy, unhealthy []string
+	now := time.Now()
+	for i := range oc.endpoints {
+		endpoint := oc.endpoints[(start+i)%len(oc.endpoints)]
+		if until, ok := oc.unhealthy[endpoint]; ok && now.Before(until) {
+			unhealthy = append(unhealthy, endpoint)
+			continue
+		}
+		delete(oc.unhealthy, endpoint)
+		healthy = append(healthy, endpoint)
+	}
+	return append(healthy, unhealthy...)
+}
+
+func (oc *ordererClient) markUnhealthy(endpoint string) {
+	oc.lock.Lock()
+	defer oc.lock.Unlock()
+	oc.unhealthy[endpoint] = time.Now().Add(oc.config.unhealthyPeriod)
+}
+
+// Send sends the request for blocks, connecting to the ordering service if disconnected
+func (oc *ordererClient) Send(env *common.Envelope) error {
+	if err := oc.connect(); err != nil {
+		return err
+	}
+	oc.lock.Lock()
+	stream := oc.stream
+	oc.lock.Unlock()
+	if stream == nil {
+		return errors.New("Client to the ordering service is disconnected")
+	}
+	return stream.Send(env)
+}
+
+// Recv receives the blocks from the endpoint the request was sent to
+func (oc *ordererClient) Recv() (*orderer.DeliverResponse, error) {
+	oc.lock.Lock()
+	stream := oc.stream
+	oc.lock.Unlock()
+	if stream == nil {
+		return nil, errors.New("Client to the ordering service is disconnected")
+	}
+	resp, err := stream.Recv()
+	if err == nil {
+		oc.lock.Lock()
+		oc.failures = 0
+		oc.lock.Unlock()
+	}
+	return resp, err
+}
+
+// Disconnect closes the connection to the current endpoint, which failed and is unhealthy
+// for a while
+func (oc *ordererClient) Disconnect() {
+	oc.lock.Lock()
+	defer oc.lock.Unlock()
+	oc.failures++
+	if oc.conn == nil {
+		return
+	}
+	logger.Warningf("Disconnecting from the ordering service at %s", oc.endpoint)
+	oc.unhealthy[oc.endpoint] = time.Now().Add(oc.config.unhealthyPeriod)
+	oc.disconnect()
+}
+
+// UpdateEndpoints replaces the endpoints connected to, and disconnects from the current
+// one if it is no longer an endpoint of the ordering service
+func (oc *ordererClient) UpdateEndpoints(endpoints []string) {
+	oc.lock.Lock()
+	defer oc.lock.Unlock()
+	oc.endpoints = endpoints
+	if oc.conn == nil {
+		return
+	}
+	for _, endpoint := range endpoints {
+		if endpoint == oc.endpoint {
+			return
+		}
+	}
+	logger.Infof("Disconnecting from %s, which is no longer an endpoint of the ordering service", oc.endpoint)
+	oc.disconnect()
+}
+
+// Close closes the connection, no more requests are sent once closed
+func (oc *ordererClient) Close() {
+	oc.lock.Lock()
+	defer oc.lock.Unlock()
+	if oc.closed {
+		return
+	}
+	oc.closed = true
+	close(oc.closeChan)
+	if oc.conn != nil {
+		oc.disconnect()
+	}
+}
+
+func (oc *ordererClient) disconnect() {
+	oc.conn.Close()
+	oc.conn = nil
+	oc.stream = nil
+}
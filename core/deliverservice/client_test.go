@@ -0,0 +1,201 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+                 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deliverclient
+
+import (
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/orderer"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+)
+
+// mockOrderer answers each deliver request with its status
+type mockOrderer struct {
+	status common.Status
+	server *grpc.Server
+	addr   string
+}
+
+func newMockOrderer(t *testing.T, status common.Status) *mockOrderer {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	mo := &mockOrderer{status: status, server: grpc.NewServer(), addr: lis.Addr().String()}
+	orderer.RegisterAtomicBroadcastServer(mo.server, mo)
+	go mo.server.Serve(lis)
+	return mo
+}
+
+func (mo *mockOrderer) Broadcast(srv orderer.AtomicBroadcast_BroadcastServer) error {
+	return errors.New("Broadcast is not supported")
+}
+
+func (mo *mockOrderer) Deliver(srv orderer.AtomicBroadcast_DeliverServer) error {
+	for {
+		if _, err := srv.Recv(); err != nil {
+			return err
+		}
+		if err := srv.Send(&orderer.DeliverResponse{Type: &orderer.DeliverResponse_Status{Status: mo.status}}); err != nil {
+			return err
+		}
+	}
+}
+
+func dialInsecure(endpoint string) (*grpc.ClientConn, error) {
+	if endpoint == "unreachable" {
+		return nil, errors.New("Unreachable endpoint")
+	}
+	return grpc.Dial(endpoint, grpc.WithInsecure(), grpc.WithBlock(), grpc.WithTimeout(time.Second))
+}
+
+var testClientConfig = clientConfig{
+	reconnectBackoff:    10 * time.Millisecond,
+	reconnectBackoffMax: 50 * time.Millisecond,
+	unhealthyPeriod:     time.Minute,
+}
+
+func request(t *testing.T, client *ordererClient) common.Status {
+	if err := client.Send(&common.Envelope{}); err != nil {
+		t.Fatalf("Failed sending the request: %s", err)
+	}
+	resp, err := client.Recv()
+	if err != nil {
+		t.Fatalf("Failed receiving the response: %s", err)
+	}
+	return resp.GetStatus()
+}
+
+func other(status common.Status) common.Status {
+	if status == common.Status_NOT_FOUND {
+		return common.Status_FORBIDDEN
+	}
+	return common.Status_NOT_FOUND
+}
+
+func TestOrdererClientFailover(t *testing.T) {
+	first := newMockOrderer(t, common.Status_NOT_FOUND)
+	defer first.server.Stop()
+	second := newMockOrderer(t, common.Status_FORBIDDEN)
+	defer second.server.Stop()
+
+	client := newOrdererClient([]string{first.addr, second.addr}, dialInsecure, testClientConfig)
+	defer client.Close()
+
+	status := request(t, client)
+	assert.Equal(t, status, request(t, client), "Requests should go to the same endpoint while connected")
+
+	// Once disconnected the healthy endpoint is connected to
+	client.Disconnect()
+	_, err := client.Recv()
+	assert.Error(t, err, "Should not receive while disconnected")
+	assert.Equal(t, other(status), request(t, client))
+
+	// And once both failed, the endpoint after the last one connected to
+	client.Disconnect()
+	assert.Equal(t, status, request(t, client))
+}
+
+func TestOrdererClientUnreachable(t *testing.T) {
+	mo := newMockOrderer(t, common.Status_NOT_FOUND)
+	defer mo.server.Stop()
+
+	client := newOrdererClient([]string{"unreachable", mo.addr, "unreachable"}, dialInsecure, testClientConfig)
+	defer client.Close()
+	for i := 0; i < 3; i++ {
+		assert.Equal(t, common.Status_NOT_FOUND, request(t, client))
+		client.Disconnect()
+	}
+}
+
+func TestOrdererClientRecvFailure(t *testing.T) {
+	first := newMockOrderer(t, common.Status_NOT_FOUND)
+	defer first.server.Stop()
+	second := newMockOrderer(t, common.Status_FORBIDDEN)
+	defer second.server.Stop()
+
+	client := newOrdererClient([]string{first.addr, second.addr}, dialInsecure, testClientConfig)
+	defer client.Close()
+
+	status := request(t, client)
+	if status == first.status {
+		first.server.Stop()
+	} else {
+		second.server.Stop()
+	}
+	_, err := client.Recv()
+	assert.Error(t, err, "Should fail receiving from a stopped orderer")
+
+	client.Disconnect()
+	assert.Equal(t, other(status), request(t, client))
+}
+
+func TestOrdererClientUpdateEndpoints(t *testing.T) {
+	first := newMockOrderer(t, common.Status_NOT_FOUND)
+	defer first.server.Stop()
+	second := newMockOrderer(t, common.Status_FORBIDDEN)
+	defer second.server.Stop()
+
+	client := newOrdererClient([]string{first.addr}, dialInsecure, testClientConfig)
+	defer client.Close()
+	assert.Equal(t, common.Status_NOT_FOUND, request(t, client))
+
+	// The client stays connected while its endpoint is kept
+	client.UpdateEndpoints([]string{first.addr, second.addr})
+	assert.Equal(t, common.Status_NOT_FOUND, request(t, client))
+
+	// And disconnects once it is removed
+	client.UpdateEndpoints([]string{second.addr})
+	_, err := client.Recv()
+	assert.Error(t, err, "Should not receive once the endpoint is removed")
+	assert.Equal(t, common.Status_FORBIDDEN, request(t, client))
+}
+
+func TestOrdererClientClose(t *testing.T) {
+	client := newOrdererClient([]string{"unreachable", "unreachable"}, dialInsecure, testClientConfig)
+
+	// Connecting is retried until the client is closed
+	errChan := make(chan error)
+	go func() {
+		errChan <- client.Send(&common.Envelope{})
+	}()
+	time.Sleep(100 * time.Millisecond)
+	client.Close()
+
+	select {
+	case err := <-errChan:
+		assert.Error(t, err, "Closed client should not send requests")
+	case <-time.After(time.Second):
+		t.Fatal("Client should stop connecting once closed")
+	}
+	_, err := client.Recv()
+	assert.Error(t, err, "Closed client should not receive responses")
+}
+
+func TestBackoff(t *testing.T) {
+	cc := clientConfig{reconnectBackoff: 100 * time.Millisecond, reconnectBackoffMax: time.Second}
+	assert.Equal(t, 100*time.Millisecond, cc.backoff(0))
+	assert.Equal(t, 200*time.Millisecond, cc.backoff(1))
+	assert.Equal(t, 800*time.Millisecond, cc.backoff(3))
+	assert.Equal(t, time.Second, cc.backoff(4))
+	assert.Equal(t, time.Second, cc.backoff(100))
+}
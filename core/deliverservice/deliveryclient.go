@@ -19,16 +19,13 @@ package deliverclient
 import (
 	"errors"
 	"fmt"
-	"math/rand"
 	"sync"
 	"time"
 
 	"github.com/hyperledger/fabric/core/comm"
 	"github.com/hyperledger/fabric/core/deliverservice/blocksprovider"
 	"github.com/hyperledger/fabric/gossip/api"
-	"github.com/hyperledger/fabric/protos/orderer"
 	"github.com/op/go-logging"
-	"golang.org/x/net/context"
 	"google.golang.org/grpc"
 )
 
@@ -49,6 +46,10 @@ type DeliverService interface {
 	// to channel peers.
 	StopDeliverForChannel(chainID string) error
 
+	// UpdateEndpoints updates the ordering service endpoints the blocks of the channel
+	// are delivered from, as the channel config changes.
+	UpdateEndpoints(chainID string, endpoints []string) error
+
 	// Stop terminates delivery service and closes the connection
 	Stop()
 }
@@ -57,36 +58,50 @@ type DeliverService interface {
 // of BlocksDeliverer interface which capable to bring blocks from
 // the ordering service
 type BlocksDelivererFactory interface {
-	// Create capable to instantiate new BlocksDeliverer
-	Create() (blocksprovider.BlocksDeliverer, error)
+	// Create capable to instantiate new BlocksDeliverer, which connects to
+	// one of the ordering service endpoints
+	Create(endpoints []string) (blocksprovider.BlocksDeliverer, error)
 }
 
-// blocksDelivererFactoryImpl the implementation of the blocks deliverer factory
-// holds the reference to the grpc client connection and capable to create new
-// grpc stream for ordering service, which will be used to pull out blocks for
-// specific chain
+// blocksDelivererFactoryImpl the implementation of the blocks deliverer factory,
+// each BlocksDeliverer it creates connects to one of the endpoints of its channel
+// and moves to another one when disconnected
 type blocksDelivererFactoryImpl struct {
-	conn *grpc.ClientConn
+	config clientConfig
 }
 
 // Create a factory method which is capable to instantiate new BlocksDeliverer
-func (factory *blocksDelivererFactoryImpl) Create() (blocksprovider.BlocksDeliverer, error) {
-	var abc orderer.AtomicBroadcast_DeliverClient
-	var err error
-	abc, err = orderer.NewAtomicBroadcastClient(factory.conn).Deliver(context.TODO())
-	if err != nil {
-		return nil, err
-	}
+func (factory *blocksDelivererFactoryImpl) Create(endpoints []string) (blocksprovider.BlocksDeliverer, error) {
+	return newOrdererClient(endpoints, dialOrderer, factory.config), nil
+}
 
-	return abc, nil
+// dialOrderer establishes the connection to an ordering service endpoint
+func dialOrderer(endpoint string) (*grpc.ClientConn, error) {
+	dialOpts := []grpc.DialOption{grpc.WithTimeout(3 * time.Second), grpc.WithBlock()}
+
+	if comm.TLSEnabled() {
+		dialOpts = append(dialOpts, grpc.WithTransportCredentials(comm.GetCASupport().GetDeliverServiceCredentials()))
+	} else {
+		dialOpts = append(dialOpts, grpc.WithInsecure())
+	}
+	grpc.EnableTracing = true
+	return grpc.Dial(endpoint, dialOpts...)
 }
 
 // deliverServiceImpl the implementation of the delivery service
-// maintains connection to the ordering service and maps of
-// blocks providers
+// maintains the maps of blocks providers, each connected to
+// the ordering service endpoints of its channel
 type deliverServiceImpl struct {
 	clients map[string]blocksprovider.BlocksProvider
 
+	deliverers map[string]blocksprovider.BlocksDeliverer
+
+	// endpoints maps the channels to their ordering service endpoints,
+	// defaultEndpoints are used for the channels not in the map
+	endpoints map[string][]string
+
+	defaultEndpoints []string
+
 	clientsFactory BlocksDelivererFactory
 
 	lock sync.RWMutex
@@ -95,55 +110,42 @@ type deliverServiceImpl struct {
 
 	stopping bool
 
-	conn *grpc.ClientConn
-
 	mcs api.MessageCryptoService
 }
 
 // NewDeliverService construction function to create and initialize
-// delivery service instance. It tries to establish connection to
-// the specified in the configuration ordering service, in case it
-// fails to dial to it, return nil
+// delivery service instance. The blocks of each channel are delivered
+// by one of its ordering service endpoints, which are the given ones
+// until they are updated, another one is connected to when the endpoint
+// fails or a delivered block fails verification
 func NewDeliverService(gossip blocksprovider.GossipServiceAdapter, endpoints []string, mcs api.MessageCryptoService) (DeliverService, error) {
-	indices := rand.Perm(len(endpoints))
-	for _, idx := range indices {
-		logger.Infof("Creating delivery service to get blocks from the ordering service, %s", endpoints[idx])
-
-		dialOpts := []grpc.DialOption{grpc.WithTimeout(3 * time.Second), grpc.WithBlock()}
-
-		if comm.TLSEnabled() {
-			dialOpts = append(dialOpts, grpc.WithTransportCredentials(comm.GetCASupport().GetDeliverServiceCredentials()))
-		} else {
-			dialOpts = append(dialOpts, grpc.WithInsecure())
-		}
-		grpc.EnableTracing = true
-		conn, err := grpc.Dial(endpoints[idx], dialOpts...)
-		if err != nil {
-			logger.Errorf("Cannot dial to %s, because of %s", endpoints[idx], err)
-			continue
-		}
-		return NewFactoryDeliverService(gossip, &blocksDelivererFactoryImpl{conn}, conn, mcs), nil
+	if len(endpoints) == 0 {
+		return nil, errors.New("No ordering service endpoints")
 	}
-	return nil, fmt.Errorf("Wasn't able to connect to any of ordering service endpoints %s", endpoints)
+	logger.Infof("Creating delivery service to get blocks from the ordering service, %s", endpoints)
+	return NewFactoryDeliverService(gossip, &blocksDelivererFactoryImpl{config: loadClientConfig()}, endpoints, mcs), nil
 }
 
 // NewFactoryDeliverService construction function to create and initialize
 // delivery service instance, with gossip service adapter and customized
 // factory to create blocks deliverers.
-func NewFactoryDeliverService(gossip blocksprovider.GossipServiceAdapter, factory BlocksDelivererFactory, conn *grpc.ClientConn, mcs api.MessageCryptoService) DeliverService {
+func NewFactoryDeliverService(gossip blocksprovider.GossipServiceAdapter, factory BlocksDelivererFactory, endpoints []string, mcs api.MessageCryptoService) DeliverService {
 	return &deliverServiceImpl{
-		clientsFactory: factory,
-		gossip:         gossip,
-		clients:        make(map[string]blocksprovider.BlocksProvider),
-		conn:           conn,
-		mcs:            mcs,
+		clientsFactory:   factory,
+		gossip:           gossip,
+		clients:          make(map[string]blocksprovider.BlocksProvider),
+		deliverers:       make(map[string]blocksprovider.BlocksDeliverer),
+		endpoints:        make(map[string][]string),
+		defaultEndpoints: endpoints,
+		mcs:              mcs,
 	}
 }
 
 // StartDeliverForChannel starts blocks delivery for channel
-// initializes the grpc stream for given chainID, creates blocks provider instance
-// that spawns in go routine to read new blocks starting from the position provided by ledger
-// info instance.
+// creates the blocks deliverer connecting to the ordering service
+// endpoints of the channel, and a blocks provider instance that spawns
+// in go routine to read new blocks starting from the position provided
+// by ledger info instance.
 func (d *deliverServiceImpl) StartDeliverForChannel(chainID string, ledgerInfo blocksprovider.LedgerInfo) error {
 	d.lock.Lock()
 	defer d.lock.Unlock()
@@ -157,18 +159,54 @@ func (d *deliverServiceImpl) StartDeliverForChannel(chainID string, ledgerInfo b
 		logger.Errorf(errMsg)
 		return errors.New(errMsg)
 	} else {
-		abc, err := d.clientsFactory.Create()
+		endpoints, ok := d.endpoints[chainID]
+		if !ok {
+			endpoints = d.defaultEndpoints
+		}
+		abc, err := d.clientsFactory.Create(endpoints)
 		if err != nil {
 			logger.Errorf("Unable to initialize atomic broadcast, due to %s", err)
 			return err
 		}
 		logger.Debug("This peer will pass blocks from orderer service to other peers")
-		d.clients[chainID] = blocksprovider.NewBlocksProvider(chainID, abc, d.gossip, d.mcs)
-
-		if err := d.clients[chainID].RequestBlocks(ledgerInfo); err == nil {
+		client := blocksprovider.NewBlocksProvider(chainID, abc, d.gossip, d.mcs)
+		d.clients[chainID] = client
+		d.deliverers[chainID] = abc
+
+		// Connecting to the ordering service is retried until the provider is stopped,
+		// so it is not waited for
+		go func() {
+			if err := client.RequestBlocks(ledgerInfo); err != nil {
+				logger.Errorf("Unable to request blocks for channel %s, due to %s", chainID, err)
+				return
+			}
 			// Start reading blocks from ordering service in case this peer is a leader for specified chain
-			go d.clients[chainID].DeliverBlocks()
-		}
+			client.DeliverBlocks()
+		}()
+	}
+	return nil
+}
+
+// UpdateEndpoints updates the ordering service endpoints of the channel, the blocks
+// deliverer of the channel moves to another endpoint if the current one was removed
+func (d *deliverServiceImpl) UpdateEndpoints(chainID string, endpoints []string) error {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	if d.stopping {
+		errMsg := fmt.Sprintf("Delivery service is stopping, cannot update the endpoints of channel %s", chainID)
+		logger.Errorf(errMsg)
+		return errors.New(errMsg)
+	}
+	if len(endpoints) == 0 {
+		errMsg := fmt.Sprintf("No ordering service endpoints for channel %s", chainID)
+		logger.Errorf(errMsg)
+		return errors.New(errMsg)
+	}
+
+	logger.Debugf("Ordering service endpoints of channel %s are %s", chainID, endpoints)
+	d.endpoints[chainID] = endpoints
+	if updater, ok := d.deliverers[chainID].(EndpointsUpdater); ok {
+		updater.UpdateEndpoints(endpoints)
 	}
 	return nil
 }
@@ -185,6 +223,7 @@ func (d *deliverServiceImpl) StopDeliverForChannel(chainID string) error {
 	if client, exist := d.clients[chainID]; exist {
 		client.Stop()
 		delete(d.clients, chainID)
+		delete(d.deliverers, chainID)
 		logger.Debug("This peer will stop pass blocks from orderer service to other peers")
 	} else {
 		errMsg := fmt.Sprintf("Delivery service - no block provider for %s found, can't stop delivery", chainID)
@@ -200,11 +239,8 @@ func (d *deliverServiceImpl) Stop() {
 	defer d.lock.Unlock()
 	// Marking flag to indicate the shutdown of the delivery service
 	d.stopping = true
-	// Closing grpc connection
-	if d.conn != nil {
-		d.conn.Close()
-	}
 
+	// Stopping the blocks providers closes their grpc connections
 	for _, client := range d.clients {
 		client.Stop()
 	}
@@ -30,11 +30,11 @@ import (
 )
 
 type mockBlocksDelivererFactory struct {
-	mockCreate func() (blocksprovider.BlocksDeliverer, error)
+	mockCreate func(endpoints []string) (blocksprovider.BlocksDeliverer, error)
 }
 
-func (mock *mockBlocksDelivererFactory) Create() (blocksprovider.BlocksDeliverer, error) {
-	return mock.mockCreate()
+func (mock *mockBlocksDelivererFactory) Create(endpoints []string) (blocksprovider.BlocksDeliverer, error) {
+	return mock.mockCreate(endpoints)
 }
 
 type mockMCS struct {
@@ -73,15 +73,15 @@ func TestNewDeliverService(t *testing.T) {
 	blocksDeliverer := &mocks.MockBlocksDeliverer{}
 	blocksDeliverer.MockRecv = mocks.MockRecv
 
-	factory.mockCreate = func() (blocksprovider.BlocksDeliverer, error) {
+	factory.mockCreate = func(endpoints []string) (blocksprovider.BlocksDeliverer, error) {
 		return blocksDeliverer, nil
 	}
 
-	service := NewFactoryDeliverService(gossipServiceAdapter, factory, nil, &mockMCS{})
-	assert.NilError(t, service.StartDeliverForChannel("TEST_CHAINID", &mocks.MockLedgerInfo{0}))
+	service := NewFactoryDeliverService(gossipServiceAdapter, factory, []string{"localhost:5005"}, &mockMCS{})
+	assert.NilError(t, service.StartDeliverForChannel("TEST_CHAINID", &mocks.MockLedgerInfo{Height: 0}))
 
 	// Lets start deliver twice
-	assert.Error(t, service.StartDeliverForChannel("TEST_CHAINID", &mocks.MockLedgerInfo{0}), "can't start delivery")
+	assert.Error(t, service.StartDeliverForChannel("TEST_CHAINID", &mocks.MockLedgerInfo{Height: 0}), "can't start delivery")
 	// Lets stop deliver that not started
 	assert.Error(t, service.StopDeliverForChannel("TEST_CHAINID2"), "can't stop delivery")
 
@@ -98,7 +98,47 @@ func TestNewDeliverService(t *testing.T) {
 	assert.Equal(t, atomic.LoadInt32(&blocksDeliverer.RecvCnt), atomic.LoadInt32(&gossipServiceAdapter.AddPayloadsCnt))
 	assert.Equal(t, atomic.LoadInt32(&blocksDeliverer.RecvCnt), atomic.LoadInt32(&gossipServiceAdapter.GossipCallsCnt))
 
-	assert.Error(t, service.StartDeliverForChannel("TEST_CHAINID", &mocks.MockLedgerInfo{0}), "Delivery service is stopping")
+	assert.Error(t, service.StartDeliverForChannel("TEST_CHAINID", &mocks.MockLedgerInfo{Height: 0}), "Delivery service is stopping")
 	assert.Error(t, service.StopDeliverForChannel("TEST_CHAINID"), "Delivery service is stopping")
 
 }
+
+type mockEndpointsDeliverer struct {
+	mocks.MockBlocksDeliverer
+	endpoints []string
+}
+
+func (mock *mockEndpointsDeliverer) UpdateEndpoints(endpoints []string) {
+	mock.endpoints = endpoints
+}
+
+func TestUpdateEndpoints(t *testing.T) {
+	gossipServiceAdapter := &mocks.MockGossipServiceAdapter{}
+	factory := &struct{ mockBlocksDelivererFactory }{}
+
+	deliverers := make(map[string]*mockEndpointsDeliverer)
+	factory.mockCreate = func(endpoints []string) (blocksprovider.BlocksDeliverer, error) {
+		deliverer := &mockEndpointsDeliverer{endpoints: endpoints}
+		deliverer.MockRecv = mocks.MockRecv
+		deliverers[endpoints[0]] = deliverer
+		return deliverer, nil
+	}
+
+	service := NewFactoryDeliverService(gossipServiceAdapter, factory, []string{"default:7050"}, &mockMCS{})
+	defer service.Stop()
+
+	// Channels whose endpoints were not updated use the default endpoints
+	assert.NilError(t, service.StartDeliverForChannel("TEST_DEFAULT", &mocks.MockLedgerInfo{Height: 0}))
+	assert.EqualStringSlice(t, deliverers["default:7050"].endpoints, []string{"default:7050"})
+
+	assert.NilError(t, service.UpdateEndpoints("TEST_UPDATED", []string{"orderer0:7050", "orderer1:7050"}))
+	assert.NilError(t, service.StartDeliverForChannel("TEST_UPDATED", &mocks.MockLedgerInfo{Height: 0}))
+	deliverer := deliverers["orderer0:7050"]
+	assert.EqualStringSlice(t, deliverer.endpoints, []string{"orderer0:7050", "orderer1:7050"})
+
+	// The endpoints of a channel delivering blocks are updated in its deliverer
+	assert.NilError(t, service.UpdateEndpoints("TEST_UPDATED", []string{"orderer1:7050"}))
+	assert.EqualStringSlice(t, deliverer.endpoints, []string{"orderer1:7050"})
+
+	assert.Error(t, service.UpdateEndpoints("TEST_UPDATED", nil), "No ordering service endpoints")
+}
@@ -60,7 +60,14 @@ type MockBlocksDeliverer struct {
 
 	RecvCnt int32
 
+	DisconnectCnt int32
+
+	CloseCnt int32
+
 	MockRecv func(mock *MockBlocksDeliverer) (*orderer.DeliverResponse, error)
+
+	// prevHeader is the header of the block returned last, the next block is chained to it
+	prevHeader *common.BlockHeader
 }
 
 // Recv gets responses from the ordering service, currently mocked to return
@@ -76,14 +83,19 @@ func MockRecv(mock *MockBlocksDeliverer) (*orderer.DeliverResponse, error) {
 
 	// Advance position for the next call
 	mock.Pos++
+	header := &common.BlockHeader{
+		Number:       pos,
+		DataHash:     []byte{},
+		PreviousHash: []byte{},
+	}
+	if mock.prevHeader != nil {
+		header.PreviousHash = mock.prevHeader.Hash()
+	}
+	mock.prevHeader = header
 	return &orderer.DeliverResponse{
 		Type: &orderer.DeliverResponse_Block{
 			Block: &common.Block{
-				Header: &common.BlockHeader{
-					Number:       pos,
-					DataHash:     []byte{},
-					PreviousHash: []byte{},
-				},
+				Header: header,
 				Data: &common.BlockData{
 					Data: [][]byte{},
 				},
@@ -110,9 +122,20 @@ func (mock *MockBlocksDeliverer) Send(env *common.Envelope) error {
 			mock.Pos = t.Specified.Number
 		}
 	}
+	mock.prevHeader = nil
 	return nil
 }
 
+// Disconnect counts the disconnections from the ordering service
+func (mock *MockBlocksDeliverer) Disconnect() {
+	atomic.AddInt32(&mock.DisconnectCnt, 1)
+}
+
+// Close counts the closings of the connection to the ordering service
+func (mock *MockBlocksDeliverer) Close() {
+	atomic.AddInt32(&mock.CloseCnt, 1)
+}
+
 // MockLedgerInfo mocking implementation of LedgerInfo interface, needed
 // for test initialization purposes
 type MockLedgerInfo struct {
@@ -31,6 +31,7 @@ import (
 	"github.com/hyperledger/fabric/core/common/ccprovider"
 	"github.com/hyperledger/fabric/core/common/validation"
 	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwset"
 	"github.com/hyperledger/fabric/core/peer"
 	syscc "github.com/hyperledger/fabric/core/scc"
 	"github.com/hyperledger/fabric/msp"
@@ -149,13 +150,23 @@ func (e *Endorser) callChaincode(ctxt context.Context, chainID string, version s
 	//
 	//NOTE that if there's an error all simulation, including the chaincode
 	//table changes in lccc will be thrown away
+	//
+	//a committed chaincode definition is initialized the same way if it
+	//requires Init, the chaincode is otherwise launched on first use
+	var cds *pb.ChaincodeDeploymentSpec
 	if cid.Name == "lccc" && len(cis.ChaincodeSpec.Input.Args) >= 3 && (string(cis.ChaincodeSpec.Input.Args[0]) == "deploy" || string(cis.ChaincodeSpec.Input.Args[0]) == "upgrade") {
-		var cds *pb.ChaincodeDeploymentSpec
 		cds, err = putils.GetChaincodeDeploymentSpec(cis.ChaincodeSpec.Input.Args[2])
 		if err != nil {
 			return nil, nil, err
 		}
+	} else if cid.Name == "lccc" && len(cis.ChaincodeSpec.Input.Args) == 3 && string(cis.ChaincodeSpec.Input.Args[0]) == "commit" {
+		cds, err = getCDSToInitialize(cis.ChaincodeSpec.Input.Args[2])
+		if err != nil {
+			return nil, nil, err
+		}
+	}
 
+	if cds != nil {
 		//this should not be a system chaincode
 		if syscc.IsSysCC(cds.ChaincodeSpec.ChaincodeId.Name) {
 			return nil, nil, fmt.Errorf("attempting to deploy a system chaincode %s/%s", cds.ChaincodeSpec.ChaincodeId.Name, chainID)
@@ -173,6 +184,26 @@ func (e *Endorser) callChaincode(ctxt context.Context, chainID string, version s
 	return res, ccevent, err
 }
 
+//getCDSToInitialize returns the installed deployment spec of a committed
+//chaincode definition if the definition requires Init, nil otherwise
+func getCDSToInitialize(defBytes []byte) (*pb.ChaincodeDeploymentSpec, error) {
+	def := &pb.ChaincodeDefinition{}
+	if err := proto.Unmarshal(defBytes, def); err != nil {
+		return nil, err
+	}
+
+	if !def.InitRequired {
+		return nil, nil
+	}
+
+	_, cds, err := ccprovider.GetChaincodeFromFS(def.Name, def.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	return cds, nil
+}
+
 //simulate the proposal by calling the chaincode
 func (e *Endorser) simulateProposal(ctx context.Context, chainID string, txid string, signedProp *pb.SignedProposal, prop *pb.Proposal, cid *pb.ChaincodeID, txsim ledger.TxSimulator) (*ccprovider.ChaincodeData, *pb.Response, []byte, *pb.ChaincodeEvent, error) {
 	//we do expect the payload to be a ChaincodeInvocationSpec
@@ -215,9 +246,43 @@ func (e *Endorser) simulateProposal(ctx context.Context, chainID string, txid st
 		}
 	}
 
+	//---4. a proposal scheduled as a query must not write
+	if err = checkReadOnlyProposal(prop, simResult); err != nil {
+		return nil, nil, nil, nil, err
+	}
+
 	return cd, res, simResult, ccevent, nil
 }
 
+//checkReadOnlyProposal rejects a proposal the client marked as read only if its
+//simulation wrote to the state. The flag gets the proposal scheduled as a query,
+//so it must not let an invoke through
+func checkReadOnlyProposal(prop *pb.Proposal, simResult []byte) error {
+	if len(simResult) == 0 {
+		return nil
+	}
+
+	readOnly, err := putils.IsReadOnlyProposal(prop)
+	if err != nil {
+		return err
+	}
+	if !readOnly {
+		return nil
+	}
+
+	txRWSet := &rwset.TxReadWriteSet{}
+	if err = txRWSet.Unmarshal(simResult); err != nil {
+		return fmt.Errorf("failed to unmarshal simulation results - %s", err)
+	}
+	for _, nsRWSet := range txRWSet.NsRWs {
+		if len(nsRWSet.Writes) > 0 {
+			return fmt.Errorf("proposal marked read only writes to namespace %s", nsRWSet.NameSpace)
+		}
+	}
+
+	return nil
+}
+
 func (e *Endorser) getCDSFromLCCC(ctx context.Context, chainID string, txid string, signedProp *pb.SignedProposal, prop *pb.Proposal, chaincodeID string, txsim ledger.TxSimulator) (*ccprovider.ChaincodeData, error) {
 	ctxt := ctx
 	if txsim != nil {
@@ -382,6 +447,11 @@ func (e *Endorser) ProcessProposal(ctx context.Context, signedProp *pb.SignedPro
 	//1 -- simulate
 	cd, res, simulationResult, ccevent, err := e.simulateProposal(ctx, chainID, txid, signedProp, prop, hdrExt.ChaincodeId, txsim)
 	if err != nil {
+		if _, ok := err.(*chaincode.BackpressureError); ok {
+			// the chaincode is saturated. The error is not returned so that the
+			// status reaches the client, which may retry the proposal later
+			return &pb.ProposalResponse{Response: &pb.Response{Status: int32(common.Status_SERVICE_UNAVAILABLE), Message: err.Error()}}, nil
+		}
 		return &pb.ProposalResponse{Response: &pb.Response{Status: 500, Message: err.Error()}}, err
 	}
 
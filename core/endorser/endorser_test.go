@@ -35,6 +35,7 @@ import (
 	"github.com/hyperledger/fabric/core/chaincode"
 	"github.com/hyperledger/fabric/core/common/ccprovider"
 	"github.com/hyperledger/fabric/core/container"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwset"
 	"github.com/hyperledger/fabric/core/peer"
 	syscc "github.com/hyperledger/fabric/core/scc"
 	"github.com/hyperledger/fabric/msp"
@@ -624,6 +625,47 @@ func TestAdminACLFail(t *testing.T) {
 	chaincode.GetChain().Stop(ctxt, cccid, &pb.ChaincodeDeploymentSpec{ChaincodeSpec: &pb.ChaincodeSpec{ChaincodeId: chaincodeID}})
 }
 
+//TestReadOnlyProposalWrites tests that a proposal marked read only is rejected
+//if its simulation writes to the state
+func TestReadOnlyProposalWrites(t *testing.T) {
+	chainID := util.GetTestChainID()
+	cis := &pb.ChaincodeInvocationSpec{ChaincodeSpec: &pb.ChaincodeSpec{Type: pb.ChaincodeSpec_GOLANG, ChaincodeId: &pb.ChaincodeID{Name: "ex02"}, Input: &pb.ChaincodeInput{Args: [][]byte{[]byte("invoke")}}}}
+	creator, err := signer.Serialize()
+	if err != nil {
+		t.Fatalf("Could not serialize the signer: %s", err)
+	}
+
+	queryProp, _, err := pbutils.CreateQueryProposalFromCIS(common.HeaderType_ENDORSER_TRANSACTION, chainID, cis, creator)
+	if err != nil {
+		t.Fatalf("Could not create the query proposal: %s", err)
+	}
+	invokeProp, _, err := pbutils.CreateProposalFromCIS(common.HeaderType_ENDORSER_TRANSACTION, chainID, cis, creator)
+	if err != nil {
+		t.Fatalf("Could not create the invoke proposal: %s", err)
+	}
+
+	reads := &rwset.TxReadWriteSet{NsRWs: []*rwset.NsReadWriteSet{{NameSpace: "ex02", Reads: []*rwset.KVRead{rwset.NewKVRead("a", nil)}}}}
+	readsOnly, err := reads.Marshal()
+	if err != nil {
+		t.Fatalf("Could not marshal the read set: %s", err)
+	}
+	writes := &rwset.TxReadWriteSet{NsRWs: []*rwset.NsReadWriteSet{{NameSpace: "ex02", Writes: []*rwset.KVWrite{rwset.NewKVWrite("a", []byte("90"))}}}}
+	withWrites, err := writes.Marshal()
+	if err != nil {
+		t.Fatalf("Could not marshal the write set: %s", err)
+	}
+
+	if err = checkReadOnlyProposal(queryProp, readsOnly); err != nil {
+		t.Fatalf("Read only proposal that only reads should be accepted, got %s", err)
+	}
+	if err = checkReadOnlyProposal(invokeProp, withWrites); err != nil {
+		t.Fatalf("Invoke proposal that writes should be accepted, got %s", err)
+	}
+	if err = checkReadOnlyProposal(queryProp, withWrites); err == nil {
+		t.Fatalf("Read only proposal that writes should be rejected")
+	}
+}
+
 func newTempDir() string {
 	tempDir, err := ioutil.TempDir("", "fabric-")
 	if err != nil {
@@ -192,10 +192,14 @@ func createChain(cid string, ledger ledger.PeerLedger, cb *common.Block) error {
 		updateTrustedRoots(cm)
 	}
 
+	ordererAddressesCallbackWrapper := func(cm configtxapi.Manager) {
+		service.GetGossipService().UpdateChannelEndpoints(cm.ChainID(), cm.ChannelConfig().OrdererAddresses())
+	}
+
 	configtxManager, err := configtx.NewManagerImpl(
 		envelopeConfig,
 		configtxInitializer,
-		[]func(cm configtxapi.Manager){gossipCallbackWrapper, trustedRootsCallbackWrapper},
+		[]func(cm configtxapi.Manager){gossipCallbackWrapper, trustedRootsCallbackWrapper, ordererAddressesCallbackWrapper},
 	)
 	if err != nil {
 		return err
@@ -17,6 +17,7 @@ limitations under the License.
 package lccc
 
 import (
+	"bytes"
 	"fmt"
 	"sort"
 	"strconv"
@@ -24,10 +25,15 @@ import (
 
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/cauthdsl"
+	"github.com/hyperledger/fabric/common/policies"
+	"github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/core/chaincode/shim"
+	"github.com/hyperledger/fabric/core/common/ccpackage"
 	"github.com/hyperledger/fabric/core/common/ccprovider"
 	"github.com/hyperledger/fabric/core/common/sysccprovider"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwset"
 	"github.com/hyperledger/fabric/core/peer"
+	"github.com/hyperledger/fabric/msp"
 	"github.com/hyperledger/fabric/protos/common"
 	pb "github.com/hyperledger/fabric/protos/peer"
 	"github.com/hyperledger/fabric/protos/utils"
@@ -36,10 +42,14 @@ import (
 
 //The life cycle system chaincode manages chaincodes deployed
 //on this peer. It manages chaincodes via Invoke proposals.
+//     "Args":["install",<ChaincodeDeploymentSpec or signed package>]
 //     "Args":["deploy",<ChaincodeDeploymentSpec>]
 //     "Args":["upgrade",<ChaincodeDeploymentSpec>]
 //     "Args":["stop",<ChaincodeInvocationSpec>]
 //     "Args":["start",<ChaincodeInvocationSpec>]
+//     "Args":["approve",<chainname>,<ChaincodeDefinition>,<signature>]
+//     "Args":["commit",<chainname>,<ChaincodeDefinition>]
+//     "Args":["validate",<chainname>,<Envelope>]
 
 var logger = logging.MustGetLogger("lccc")
 
@@ -73,8 +83,25 @@ const (
 	//GETINSTALLEDCHAINCODES gets the installed chaincodes on a peer
 	GETINSTALLEDCHAINCODES = "getinstalledchaincodes"
 
+	//APPROVE approve a chaincode definition for the organization of the creator
+	APPROVE = "approve"
+
+	//COMMIT commit a chaincode definition approved by the organizations of the channel
+	COMMIT = "commit"
+
+	//QUERYCOMMITTED get the committed ChaincodeDefinition
+	QUERYCOMMITTED = "querycommitted"
+
+	//VALIDATE validate the writes of a LCCC transaction being committed
+	VALIDATE = "validate"
+
 	//characters used in chaincodenamespace
 	specialChars = "/:[]${}"
+
+	//prefixes of the keys of approvals and committed definitions. They
+	//contain a special character so they cannot clash with chaincode names
+	approvalKeyPrefix   = "approval:"
+	definitionKeyPrefix = "definition:"
 )
 
 //---------- the LCCC -----------------
@@ -194,19 +221,75 @@ func (f EmptyVersionErr) Error() string {
 	return fmt.Sprintf("version not provided for chaincode %s", string(f))
 }
 
+//InvalidResourceLimitsErr invalid chaincode resource limits error
+type InvalidResourceLimitsErr string
+
+func (f InvalidResourceLimitsErr) Error() string {
+	return fmt.Sprintf("invalid resource limits for chaincode %s", string(f))
+}
+
+//InvalidChaincodeDefinitionErr invalid chaincode definition error
+type InvalidChaincodeDefinitionErr string
+
+func (f InvalidChaincodeDefinitionErr) Error() string {
+	return fmt.Sprintf("invalid chaincode definition %s", string(f))
+}
+
+//LifecyclePolicyNotSatisfiedErr approvals do not satisfy the lifecycle policy error
+type LifecyclePolicyNotSatisfiedErr string
+
+func (f LifecyclePolicyNotSatisfiedErr) Error() string {
+	return fmt.Sprintf("approvals of chaincode definition %s do not satisfy the lifecycle policy", string(f))
+}
+
+//PackageHashMismatchErr installed package does not match the chaincode definition error
+type PackageHashMismatchErr string
+
+func (f PackageHashMismatchErr) Error() string {
+	return fmt.Sprintf("installed package of chaincode %s does not match the package hash of the definition", string(f))
+}
+
+//ApproverNotAdminErr approval not signed by an admin of the organization error
+type ApproverNotAdminErr string
+
+func (f ApproverNotAdminErr) Error() string {
+	return fmt.Sprintf("chaincode definitions must be approved by an admin of organization %s", string(f))
+}
+
+//ApprovalsRequiredErr chaincode committed from an approved definition upgraded by a deploy or an upgrade error
+type ApprovalsRequiredErr string
+
+func (f ApprovalsRequiredErr) Error() string {
+	return fmt.Sprintf("chaincode %s was committed from an approved definition and may only be upgraded by committing one", string(f))
+}
+
+//InvalidLifecycleWriteErr LCCC transaction writes a key it may not write error
+type InvalidLifecycleWriteErr string
+
+func (f InvalidLifecycleWriteErr) Error() string {
+	return fmt.Sprintf("invalid write of lifecycle key %s", string(f))
+}
+
+//InvalidPackageErr signed chaincode package rejected error
+type InvalidPackageErr string
+
+func (f InvalidPackageErr) Error() string {
+	return fmt.Sprintf("invalid chaincode package: %s", string(f))
+}
+
 //-------------- helper functions ------------------
 //create the chaincode on the given chain
-func (lccc *LifeCycleSysCC) createChaincode(stub shim.ChaincodeStubInterface, chainname string, ccname string, version string, cccode []byte, policy []byte, escc []byte, vscc []byte) (*ccprovider.ChaincodeData, error) {
-	return lccc.putChaincodeData(stub, chainname, ccname, version, cccode, policy, escc, vscc)
+func (lccc *LifeCycleSysCC) createChaincode(stub shim.ChaincodeStubInterface, chainname string, ccname string, version string, cccode []byte, policy []byte, escc []byte, vscc []byte, limits *pb.ChaincodeResourceLimits) (*ccprovider.ChaincodeData, error) {
+	return lccc.putChaincodeData(stub, chainname, ccname, version, cccode, policy, escc, vscc, limits)
 }
 
 //upgrade the chaincode on the given chain
-func (lccc *LifeCycleSysCC) upgradeChaincode(stub shim.ChaincodeStubInterface, chainname string, ccname string, version string, cccode []byte, policy []byte, escc []byte, vscc []byte) (*ccprovider.ChaincodeData, error) {
-	return lccc.putChaincodeData(stub, chainname, ccname, version, cccode, policy, escc, vscc)
+func (lccc *LifeCycleSysCC) upgradeChaincode(stub shim.ChaincodeStubInterface, chainname string, ccname string, version string, cccode []byte, policy []byte, escc []byte, vscc []byte, limits *pb.ChaincodeResourceLimits) (*ccprovider.ChaincodeData, error) {
+	return lccc.putChaincodeData(stub, chainname, ccname, version, cccode, policy, escc, vscc, limits)
 }
 
-//create the chaincode on the given chain
-func (lccc *LifeCycleSysCC) putChaincodeData(stub shim.ChaincodeStubInterface, chainname string, ccname string, version string, cccode []byte, policy []byte, escc []byte, vscc []byte) (*ccprovider.ChaincodeData, error) {
+//put the chaincode data on the given chain
+func (lccc *LifeCycleSysCC) putChaincodeData(stub shim.ChaincodeStubInterface, chainname string, ccname string, version string, cccode []byte, policy []byte, escc []byte, vscc []byte, limits *pb.ChaincodeResourceLimits) (*ccprovider.ChaincodeData, error) {
 	// check that escc and vscc are real system chaincodes
 	if !lccc.sccprovider.IsSysCC(string(escc)) {
 		return nil, fmt.Errorf("%s is not a valid endorsement system chaincode", string(escc))
@@ -215,7 +298,7 @@ func (lccc *LifeCycleSysCC) putChaincodeData(stub shim.ChaincodeStubInterface, c
 		return nil, fmt.Errorf("%s is not a valid validation system chaincode", string(vscc))
 	}
 
-	cd := &ccprovider.ChaincodeData{Name: ccname, Version: version, DepSpec: cccode, Policy: policy, Escc: string(escc), Vscc: string(vscc)}
+	cd := &ccprovider.ChaincodeData{Name: ccname, Version: version, DepSpec: cccode, Policy: policy, Escc: string(escc), Vscc: string(vscc), ResourceLimits: limits}
 	cdbytes, err := proto.Marshal(cd)
 	if err != nil {
 		return nil, err
@@ -245,10 +328,20 @@ func (lccc *LifeCycleSysCC) getChaincode(stub shim.ChaincodeStubInterface, ccnam
 		}
 
 		if checkFS {
-			cd.DepSpec, _, err = ccprovider.GetChaincodeFromFS(ccname, cd.Version)
+			var cds *pb.ChaincodeDeploymentSpec
+			cd.DepSpec, cds, err = ccprovider.GetChaincodeFromFS(ccname, cd.Version)
 			if err != nil {
 				return cd, nil, InvalidDeploymentSpecErr(err.Error())
 			}
+
+			//the resource limits declared at instantiate/upgrade take precedence
+			//over the ones the chaincode was installed with
+			if cd.ResourceLimits != nil {
+				cds.ResourceLimits = cd.ResourceLimits
+				if cd.DepSpec, err = proto.Marshal(cds); err != nil {
+					return cd, nil, MarshallErr(ccname)
+				}
+			}
 		}
 
 		return cd, cdbytes, nil
@@ -271,11 +364,16 @@ func (lccc *LifeCycleSysCC) getChaincodes(stub shim.ChaincodeStubInterface) pb.R
 	var ccInfoArray []*pb.ChaincodeInfo
 
 	for itr.HasNext() {
-		_, value, err := itr.Next()
+		key, value, err := itr.Next()
 		if err != nil {
 			return shim.Error(err.Error())
 		}
 
+		// skip the approvals and committed definitions
+		if isLifecycleKey(key) {
+			continue
+		}
+
 		ccdata := &ccprovider.ChaincodeData{}
 		if err = proto.Unmarshal(value, ccdata); err != nil {
 			return shim.Error(err.Error())
@@ -323,6 +421,163 @@ func (lccc *LifeCycleSysCC) getInstalledChaincodes() pb.Response {
 	return shim.Success(cqrbytes)
 }
 
+//returns true for the keys of approvals and committed definitions
+func isLifecycleKey(key string) bool {
+	return strings.HasPrefix(key, approvalKeyPrefix) || strings.HasPrefix(key, definitionKeyPrefix)
+}
+
+//key of the approval of a chaincode definition by an organization
+func approvalKey(ccname string, mspid string) string {
+	return approvalKeyPrefix + ccname + ":" + mspid
+}
+
+//getChaincodeDefinition unmarshals and checks a chaincode definition
+func (lccc *LifeCycleSysCC) getChaincodeDefinition(defBytes []byte) (*pb.ChaincodeDefinition, error) {
+	def := &pb.ChaincodeDefinition{}
+	if err := proto.Unmarshal(defBytes, def); err != nil {
+		return nil, MarshallErr(err.Error())
+	}
+
+	if !lccc.isValidChaincodeName(def.Name) {
+		return nil, InvalidChaincodeNameErr(def.Name)
+	}
+
+	if def.Version == "" {
+		return nil, EmptyVersionErr(def.Name)
+	}
+
+	if len(def.PackageHash) == 0 {
+		return nil, InvalidChaincodeDefinitionErr(def.Name)
+	}
+
+	return def, nil
+}
+
+//checkApprovals evaluates the lifecycle policy of the chain against the
+//approvals of the given chaincode definition
+func (lccc *LifeCycleSysCC) checkApprovals(stub shim.ChaincodeStubInterface, chainname string, def *pb.ChaincodeDefinition, defBytes []byte) error {
+	// approvals of a chaincode are stored under "approval:<ccname>:<mspid>",
+	// ';' follows ':' so the range covers all of them
+	prefix := approvalKey(def.Name, "")
+	itr, err := stub.GetStateByRange(prefix, approvalKeyPrefix+def.Name+";")
+	if err != nil {
+		return err
+	}
+	defer itr.Close()
+
+	var signedData []*common.SignedData
+	for itr.HasNext() {
+		key, value, err := itr.Next()
+		if err != nil {
+			return err
+		}
+
+		approval := &pb.ChaincodeApproval{}
+		if err = proto.Unmarshal(value, approval); err != nil {
+			return MarshallErr(key)
+		}
+
+		// only the approvals of this exact definition count
+		if !bytes.Equal(approval.Definition, defBytes) {
+			continue
+		}
+
+		signedData = append(signedData, &common.SignedData{
+			Data:      approval.Definition,
+			Identity:  approval.Creator,
+			Signature: approval.Signature,
+		})
+	}
+
+	mgr, ok := lccc.sccprovider.GetPolicyManager(chainname)
+	if !ok {
+		return InvalidChainNameErr(chainname)
+	}
+
+	policy, ok := mgr.GetPolicy(policies.ChannelApplicationLifecycleEndorsement)
+	if !ok {
+		// channels created before the lifecycle policy was introduced
+		// fall back to the application admins policy
+		logger.Debugf("No lifecycle policy on chain %s, using %s", chainname, policies.ChannelApplicationAdmins)
+		if policy, ok = mgr.GetPolicy(policies.ChannelApplicationAdmins); !ok {
+			return fmt.Errorf("no lifecycle policy and no %s policy on chain %s", policies.ChannelApplicationAdmins, chainname)
+		}
+	}
+
+	if err = policy.Evaluate(signedData); err != nil {
+		logger.Debugf("%d approvals of %s:%s do not satisfy the lifecycle policy: %s", len(signedData), def.Name, def.Version, err)
+		return LifecyclePolicyNotSatisfiedErr(def.Name + ":" + def.Version)
+	}
+
+	return nil
+}
+
+//checkApproval verifies that an approval recorded for the given organization
+//is signed by an admin of that organization
+func (lccc *LifeCycleSysCC) checkApproval(chainname string, mspid string, approval *pb.ChaincodeApproval) error {
+	deserializer, ok := lccc.sccprovider.GetIdentityDeserializer(chainname)
+	if !ok {
+		return InvalidChainNameErr(chainname)
+	}
+
+	id, err := deserializer.DeserializeIdentity(approval.Creator)
+	if err != nil {
+		return fmt.Errorf("could not deserialize the approver of organization %s: %s", mspid, err)
+	}
+
+	if id.GetMSPIdentifier() != mspid {
+		return ApproverNotAdminErr(mspid)
+	}
+
+	admin := &common.MSPPrincipal{
+		PrincipalClassification: common.MSPPrincipal_ROLE,
+		Principal:               utils.MarshalOrPanic(&common.MSPRole{Role: common.MSPRole_ADMIN, MspIdentifier: mspid})}
+	if err = id.SatisfiesPrincipal(admin); err != nil {
+		return ApproverNotAdminErr(mspid)
+	}
+
+	if err = id.Verify(approval.Definition, approval.Signature); err != nil {
+		return fmt.Errorf("invalid signature of the approver of organization %s: %s", mspid, err)
+	}
+
+	return nil
+}
+
+//getValidationInfo returns the endorsement policy, escc and vscc a chaincode
+//definition is committed with, applying the defaults for the ones it omits
+func (lccc *LifeCycleSysCC) getValidationInfo(chainname string, def *pb.ChaincodeDefinition) ([]byte, string, string) {
+	policy := def.EndorsementPolicy
+	if len(policy) == 0 {
+		policy = lccc.getDefaultEndorsementPolicy(chainname)
+	}
+
+	escc := def.Escc
+	if escc == "" {
+		escc = "escc"
+	}
+
+	vscc := def.Vscc
+	if vscc == "" {
+		vscc = "vscc"
+	}
+
+	return policy, escc, vscc
+}
+
+//checkNotCommitted verifies that the chaincode was not committed from an
+//approved definition: such a chaincode may only be upgraded by committing an
+//approved definition, not by a deploy or an upgrade
+func (lccc *LifeCycleSysCC) checkNotCommitted(stub shim.ChaincodeStubInterface, ccname string) error {
+	defBytes, err := stub.GetState(definitionKeyPrefix + ccname)
+	if err != nil {
+		return err
+	}
+	if defBytes != nil {
+		return ApprovalsRequiredErr(ccname)
+	}
+	return nil
+}
+
 //do access control
 func (lccc *LifeCycleSysCC) acl(stub shim.ChaincodeStubInterface, chainname string, cds *pb.ChaincodeDeploymentSpec) error {
 	return nil
@@ -352,9 +607,71 @@ func (lccc *LifeCycleSysCC) isValidChaincodeName(chaincodename string) bool {
 	return true
 }
 
-//this implements "install" Invoke transaction
+//isValidResourceLimits checks that none of the optional resource limits is negative
+func (lccc *LifeCycleSysCC) isValidResourceLimits(limits *pb.ChaincodeResourceLimits) bool {
+	if limits == nil {
+		return true
+	}
+
+	return limits.ExecuteTimeout >= 0 && limits.Memory >= 0 && limits.CpuShares >= 0 && limits.MaxConcurrency >= 0
+}
+
+//getOwnerPolicy returns the owner policy of the signed packages a chaincode
+//is installed from on this peer, or nil if none of its versions is signed
+func (lccc *LifeCycleSysCC) getOwnerPolicy(ccname string) ([]byte, error) {
+	installed, err := ccprovider.GetInstalledChaincodes()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, cc := range installed.Chaincodes {
+		if cc.Name != ccname {
+			continue
+		}
+
+		pkg, err := ccprovider.GetSignedPackageFromFS(cc.Name, cc.Version)
+		if err != nil {
+			return nil, err
+		}
+		if pkg == nil {
+			continue
+		}
+
+		env, ok := ccpackage.GetSignedPackage(pkg)
+		if !ok {
+			return nil, fmt.Errorf("invalid signed package for %s:%s", cc.Name, cc.Version)
+		}
+		scds, err := ccpackage.ExtractSignedCCDepSpec(env)
+		if err != nil {
+			return nil, err
+		}
+		return scds.OwnerPolicy, nil
+	}
+
+	return nil, nil
+}
+
+//this implements "install" Invoke transaction. Once a chaincode is installed
+//from a signed package, its other versions must come in signed packages with
+//the same owner policy
 func (lccc *LifeCycleSysCC) executeInstall(stub shim.ChaincodeStubInterface, depSpec []byte) error {
-	cds, err := utils.GetChaincodeDeploymentSpec(depSpec)
+	var cds *pb.ChaincodeDeploymentSpec
+	var ownerPolicy []byte
+	var err error
+	env, signed := ccpackage.GetSignedPackage(depSpec)
+	if signed {
+		cds, err = ccpackage.ValidateSignedPackage(env, ccpackage.NewPeerDeserializer())
+		if err != nil {
+			return InvalidPackageErr(err.Error())
+		}
+		scds, err := ccpackage.ExtractSignedCCDepSpec(env)
+		if err != nil {
+			return InvalidPackageErr(err.Error())
+		}
+		ownerPolicy = scds.OwnerPolicy
+	} else {
+		cds, err = utils.GetChaincodeDeploymentSpec(depSpec)
+	}
 
 	if err != nil {
 		return err
@@ -368,11 +685,30 @@ func (lccc *LifeCycleSysCC) executeInstall(stub shim.ChaincodeStubInterface, dep
 		return EmptyVersionErr(cds.ChaincodeSpec.ChaincodeId.Name)
 	}
 
+	if !lccc.isValidResourceLimits(cds.ResourceLimits) {
+		return InvalidResourceLimitsErr(cds.ChaincodeSpec.ChaincodeId.Name)
+	}
+
+	owned, err := lccc.getOwnerPolicy(cds.ChaincodeSpec.ChaincodeId.Name)
+	if err != nil {
+		return err
+	}
+	if owned != nil && !bytes.Equal(owned, ownerPolicy) {
+		return InvalidPackageErr(fmt.Sprintf("chaincode %s is owned, its packages must be signed under its owner policy", cds.ChaincodeSpec.ChaincodeId.Name))
+	}
+
 	if err = ccprovider.PutChaincodeIntoFS(cds); err != nil {
 		return fmt.Errorf("Error installing chaincode code %s:%s(%s)", cds.ChaincodeSpec.ChaincodeId.Name, cds.ChaincodeSpec.ChaincodeId.Version, err)
 	}
 
-	return err
+	//the signed package is kept for its owner signatures
+	if signed {
+		if err = ccprovider.PutSignedPackageIntoFS(cds.ChaincodeSpec.ChaincodeId.Name, cds.ChaincodeSpec.ChaincodeId.Version, depSpec); err != nil {
+			return fmt.Errorf("Error installing chaincode package %s:%s(%s)", cds.ChaincodeSpec.ChaincodeId.Name, cds.ChaincodeSpec.ChaincodeId.Version, err)
+		}
+	}
+
+	return nil
 }
 
 //this implements "deploy" Invoke transaction
@@ -400,7 +736,11 @@ func (lccc *LifeCycleSysCC) executeDeploy(stub shim.ChaincodeStubInterface, chai
 		return EmptyVersionErr(cds.ChaincodeSpec.ChaincodeId.Name)
 	}
 
-	_, err = lccc.createChaincode(stub, chainname, cds.ChaincodeSpec.ChaincodeId.Name, cds.ChaincodeSpec.ChaincodeId.Version, depSpec, policy, escc, vscc)
+	if !lccc.isValidResourceLimits(cds.ResourceLimits) {
+		return InvalidResourceLimitsErr(cds.ChaincodeSpec.ChaincodeId.Name)
+	}
+
+	_, err = lccc.createChaincode(stub, chainname, cds.ChaincodeSpec.ChaincodeId.Name, cds.ChaincodeSpec.ChaincodeId.Version, depSpec, policy, escc, vscc, cds.ResourceLimits)
 
 	return err
 }
@@ -445,6 +785,10 @@ func (lccc *LifeCycleSysCC) executeUpgrade(stub shim.ChaincodeStubInterface, cha
 		return nil, InvalidChaincodeNameErr(chaincodeName)
 	}
 
+	if !lccc.isValidResourceLimits(cds.ResourceLimits) {
+		return nil, InvalidResourceLimitsErr(chaincodeName)
+	}
+
 	// check for existence of chaincode
 	cd, _, err := lccc.getChaincode(stub, chaincodeName, true)
 	if cd == nil {
@@ -456,7 +800,11 @@ func (lccc *LifeCycleSysCC) executeUpgrade(stub shim.ChaincodeStubInterface, cha
 		return nil, err
 	}
 
-	newCD, err := lccc.upgradeChaincode(stub, chainName, chaincodeName, ver, depSpec, policy, escc, vscc)
+	if err = lccc.checkNotCommitted(stub, chaincodeName); err != nil {
+		return nil, err
+	}
+
+	newCD, err := lccc.upgradeChaincode(stub, chainName, chaincodeName, ver, depSpec, policy, escc, vscc, cds.ResourceLimits)
 	if err != nil {
 		return nil, err
 	}
@@ -464,6 +812,232 @@ func (lccc *LifeCycleSysCC) executeUpgrade(stub shim.ChaincodeStubInterface, cha
 	return []byte(newCD.Version), nil
 }
 
+//this implements "approve" Invoke transaction. The creator must be an admin of
+//its organization; the approval is recorded for that organization and replaces
+//any previous approval of the organization for the chaincode
+func (lccc *LifeCycleSysCC) executeApprove(stub shim.ChaincodeStubInterface, chainname string, defBytes []byte, signature []byte) error {
+	def, err := lccc.getChaincodeDefinition(defBytes)
+	if err != nil {
+		return err
+	}
+
+	if len(signature) == 0 {
+		return InvalidArgsErr(3)
+	}
+
+	creator, err := stub.GetCreator()
+	if err != nil {
+		return err
+	}
+
+	sid := &msp.SerializedIdentity{}
+	if err = proto.Unmarshal(creator, sid); err != nil || sid.Mspid == "" {
+		return fmt.Errorf("could not determine the organization approving %s", def.Name)
+	}
+
+	approval := &pb.ChaincodeApproval{Definition: defBytes, Creator: creator, Signature: signature}
+	if err = lccc.checkApproval(chainname, sid.Mspid, approval); err != nil {
+		return err
+	}
+
+	approvalBytes, err := proto.Marshal(approval)
+	if err != nil {
+		return MarshallErr(def.Name)
+	}
+
+	logger.Debugf("%s approves chaincode definition %s:%s on chain %s", sid.Mspid, def.Name, def.Version, chainname)
+
+	return stub.PutState(approvalKey(def.Name, sid.Mspid), approvalBytes)
+}
+
+//this implements "commit" Invoke transaction. The definition must be
+//approved according to the lifecycle policy of the chain and the package
+//it refers to must be installed on this peer
+func (lccc *LifeCycleSysCC) executeCommit(stub shim.ChaincodeStubInterface, chainname string, defBytes []byte) error {
+	def, err := lccc.getChaincodeDefinition(defBytes)
+	if err != nil {
+		return err
+	}
+
+	cd, _, _ := lccc.getChaincode(stub, def.Name, false)
+	if cd != nil && cd.Version == def.Version {
+		return IdenticalVersionErr(def.Name)
+	}
+
+	if err = lccc.checkApprovals(stub, chainname, def, defBytes); err != nil {
+		return err
+	}
+
+	depSpec, cds, err := ccprovider.GetChaincodeFromFS(def.Name, def.Version)
+	if err != nil {
+		return InvalidDeploymentSpecErr(err.Error())
+	}
+
+	if !bytes.Equal(util.ComputeSHA256(depSpec), def.PackageHash) {
+		return PackageHashMismatchErr(def.Name)
+	}
+
+	if !lccc.isValidResourceLimits(cds.ResourceLimits) {
+		return InvalidResourceLimitsErr(def.Name)
+	}
+
+	policy, escc, vscc := lccc.getValidationInfo(chainname, def)
+	_, err = lccc.putChaincodeData(stub, chainname, def.Name, def.Version, depSpec, policy, []byte(escc), []byte(vscc), cds.ResourceLimits)
+	if err != nil {
+		return err
+	}
+
+	return stub.PutState(definitionKeyPrefix+def.Name, defBytes)
+}
+
+//getTxWrites returns the writes of a transaction by namespace
+func getTxWrites(envBytes []byte) (map[string][]*rwset.KVWrite, error) {
+	env, err := utils.GetEnvelopeFromBlock(envBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	payl, err := utils.GetPayload(env)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := utils.GetTransaction(payl.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	writes := make(map[string][]*rwset.KVWrite)
+	for _, act := range tx.Actions {
+		_, respPayload, err := utils.GetPayloads(act)
+		if err != nil {
+			return nil, err
+		}
+		if respPayload == nil {
+			return nil, fmt.Errorf("no chaincode action in transaction")
+		}
+
+		txRWSet := &rwset.TxReadWriteSet{}
+		if err = txRWSet.Unmarshal(respPayload.Results); err != nil {
+			return nil, err
+		}
+		for _, nsRWSet := range txRWSet.NsRWs {
+			writes[nsRWSet.NameSpace] = append(writes[nsRWSet.NameSpace], nsRWSet.Writes...)
+		}
+	}
+
+	return writes, nil
+}
+
+//validateCommit checks that the chaincode data written by a transaction was
+//committed from the definition written along with it and that the definition
+//satisfies the lifecycle policy of the chain
+func (lccc *LifeCycleSysCC) validateCommit(stub shim.ChaincodeStubInterface, chainname string, ccname string, cdbytes []byte, defBytes []byte) error {
+	def, err := lccc.getChaincodeDefinition(defBytes)
+	if err != nil {
+		return err
+	}
+
+	cd := &ccprovider.ChaincodeData{}
+	if err = proto.Unmarshal(cdbytes, cd); err != nil {
+		return MarshallErr(ccname)
+	}
+
+	if def.Name != ccname || cd.Name != ccname || cd.Version != def.Version {
+		return InvalidChaincodeDefinitionErr(ccname)
+	}
+
+	if !bytes.Equal(util.ComputeSHA256(cd.DepSpec), def.PackageHash) {
+		return PackageHashMismatchErr(ccname)
+	}
+
+	policy, escc, vscc := lccc.getValidationInfo(chainname, def)
+	if !bytes.Equal(cd.Policy, policy) || cd.Escc != escc || cd.Vscc != vscc {
+		return InvalidChaincodeDefinitionErr(ccname)
+	}
+
+	old, _, _ := lccc.getChaincode(stub, ccname, false)
+	if old != nil && old.Version == def.Version {
+		return IdenticalVersionErr(ccname)
+	}
+
+	return lccc.checkApprovals(stub, chainname, def, defBytes)
+}
+
+//this implements "validate", called by the committer for every LCCC
+//transaction since those are not validated by a VSCC. An approval must be
+//signed by an admin of the organization it is recorded for, chaincode data
+//written along with a definition must come from that approved definition,
+//chaincode data written without one, by a deploy or an upgrade, may not
+//replace a chaincode committed from a definition and the only other
+//namespaces written are those of the chaincodes, by their Init
+func (lccc *LifeCycleSysCC) executeValidate(stub shim.ChaincodeStubInterface, chainname string, envBytes []byte) error {
+	writes, err := getTxWrites(envBytes)
+	if err != nil {
+		return InvalidArgsErr(2)
+	}
+
+	definitions := make(map[string][]byte)
+	chaincodes := make(map[string][]byte)
+	for _, w := range writes["lccc"] {
+		if w.IsDelete {
+			return InvalidLifecycleWriteErr(w.Key)
+		}
+
+		switch {
+		case strings.HasPrefix(w.Key, approvalKeyPrefix):
+			approval := &pb.ChaincodeApproval{}
+			if err = proto.Unmarshal(w.Value, approval); err != nil {
+				return MarshallErr(w.Key)
+			}
+			def, err := lccc.getChaincodeDefinition(approval.Definition)
+			if err != nil {
+				return err
+			}
+			mspid := strings.TrimPrefix(w.Key, approvalKey(def.Name, ""))
+			if w.Key != approvalKey(def.Name, mspid) || mspid == "" {
+				return InvalidLifecycleWriteErr(w.Key)
+			}
+			if err = lccc.checkApproval(chainname, mspid, approval); err != nil {
+				return err
+			}
+		case strings.HasPrefix(w.Key, definitionKeyPrefix):
+			definitions[strings.TrimPrefix(w.Key, definitionKeyPrefix)] = w.Value
+		default:
+			chaincodes[w.Key] = w.Value
+		}
+	}
+
+	for ccname, cdbytes := range chaincodes {
+		defBytes, ok := definitions[ccname]
+		if !ok {
+			//a deploy or an upgrade, which does not require approvals
+			//unless the chaincode was committed from a definition
+			if err = lccc.checkNotCommitted(stub, ccname); err != nil {
+				return err
+			}
+			continue
+		}
+		if err = lccc.validateCommit(stub, chainname, ccname, cdbytes, defBytes); err != nil {
+			return err
+		}
+	}
+
+	for ccname := range definitions {
+		if _, ok := chaincodes[ccname]; !ok {
+			return InvalidLifecycleWriteErr(definitionKeyPrefix + ccname)
+		}
+	}
+
+	for namespace := range writes {
+		if _, ok := chaincodes[namespace]; namespace != "lccc" && !ok {
+			return InvalidLifecycleWriteErr(namespace)
+		}
+	}
+
+	return nil
+}
+
 //-------------- the chaincode stub interface implementation ----------
 
 //Init only initializes the system chaincode provider
@@ -503,8 +1077,13 @@ func (lccc *LifeCycleSysCC) getDefaultEndorsementPolicy(chain string) []byte {
 // Invoke implements lifecycle functions "deploy", "start", "stop", "upgrade".
 // Deploy's arguments -  {[]byte("deploy"), []byte(<chainname>), <unmarshalled pb.ChaincodeDeploymentSpec>}
 //
+// Approve's arguments - {[]byte("approve"), []byte(<chainname>), <marshalled pb.ChaincodeDefinition>, <signature of the creator over the definition>}
+// Commit's arguments - {[]byte("commit"), []byte(<chainname>), <marshalled pb.ChaincodeDefinition>}
+//
 // Invoke also implements some query-like functions
 // Get chaincode arguments -  {[]byte("getid"), []byte(<chainname>), []byte(<chaincodename>)}
+// Get committed definition arguments - {[]byte("querycommitted"), []byte(<chainname>), []byte(<chaincodename>)}
+// Validate arguments - {[]byte("validate"), []byte(<chainname>), <marshalled Envelope of a LCCC transaction>}
 func (lccc *LifeCycleSysCC) Invoke(stub shim.ChaincodeStubInterface) pb.Response {
 	args := stub.GetArgs()
 	if len(args) < 1 {
@@ -613,6 +1192,69 @@ func (lccc *LifeCycleSysCC) Invoke(stub shim.ChaincodeStubInterface) pb.Response
 			return shim.Error(err.Error())
 		}
 		return shim.Success(verBytes)
+	case APPROVE:
+		if len(args) != 4 {
+			return shim.Error(InvalidArgsLenErr(len(args)).Error())
+		}
+
+		chainname := string(args[1])
+		if !lccc.isValidChainName(chainname) {
+			return shim.Error(InvalidChainNameErr(chainname).Error())
+		}
+
+		err := lccc.executeApprove(stub, chainname, args[2], args[3])
+		if err != nil {
+			return shim.Error(err.Error())
+		}
+		return shim.Success(nil)
+	case COMMIT:
+		if len(args) != 3 {
+			return shim.Error(InvalidArgsLenErr(len(args)).Error())
+		}
+
+		chainname := string(args[1])
+		if !lccc.isValidChainName(chainname) {
+			return shim.Error(InvalidChainNameErr(chainname).Error())
+		}
+
+		err := lccc.executeCommit(stub, chainname, args[2])
+		if err != nil {
+			return shim.Error(err.Error())
+		}
+		return shim.Success(nil)
+	case QUERYCOMMITTED:
+		if len(args) != 3 {
+			return shim.Error(InvalidArgsLenErr(len(args)).Error())
+		}
+
+		chainname := string(args[1])
+		if !lccc.isValidChainName(chainname) {
+			return shim.Error(InvalidChainNameErr(chainname).Error())
+		}
+
+		ccname := string(args[2])
+		defBytes, err := stub.GetState(definitionKeyPrefix + ccname)
+		if err != nil {
+			return shim.Error(err.Error())
+		}
+		if defBytes == nil {
+			return shim.Error(NotFoundErr(ccname).Error())
+		}
+		return shim.Success(defBytes)
+	case VALIDATE:
+		if len(args) != 3 {
+			return shim.Error(InvalidArgsLenErr(len(args)).Error())
+		}
+
+		chainname := string(args[1])
+		if !lccc.isValidChainName(chainname) {
+			return shim.Error(InvalidChainNameErr(chainname).Error())
+		}
+
+		if err := lccc.executeValidate(stub, chainname, args[2]); err != nil {
+			return shim.Error(err.Error())
+		}
+		return shim.Success(nil)
 	case GETCCINFO, GETDEPSPEC, GETCCDATA:
 		if len(args) != 3 {
 			return shim.Error(InvalidArgsLenErr(len(args)).Error())
@@ -23,6 +23,7 @@ import (
 
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/core/chaincode/shim"
+	"github.com/hyperledger/fabric/core/common/ccpackage"
 	"github.com/hyperledger/fabric/core/common/ccprovider"
 	"github.com/hyperledger/fabric/core/common/sysccprovider"
 	//"github.com/hyperledger/fabric/core/container"
@@ -30,8 +31,15 @@ import (
 	"bytes"
 	"compress/gzip"
 
+	"github.com/hyperledger/fabric/common/cauthdsl"
+	"github.com/hyperledger/fabric/common/policies"
+	futil "github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/core/container/util"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwset"
+	"github.com/hyperledger/fabric/msp"
+	"github.com/hyperledger/fabric/protos/common"
 	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
 )
 
 var lccctestpath = "/tmp/lccctest"
@@ -44,12 +52,151 @@ func (c *mocksccProviderFactory) NewSystemChaincodeProvider() sysccprovider.Syst
 }
 
 type mocksccProviderImpl struct {
+	policyManager policies.Manager
 }
 
 func (c *mocksccProviderImpl) IsSysCC(name string) bool {
 	return true
 }
 
+func (c *mocksccProviderImpl) GetPolicyManager(chainID string) (policies.Manager, bool) {
+	if c.policyManager != nil {
+		return c.policyManager, true
+	}
+	return &mockPolicyManager{}, true
+}
+
+func (c *mocksccProviderImpl) GetIdentityDeserializer(chainID string) (msp.IdentityDeserializer, bool) {
+	return &mockDeserializer{}, true
+}
+
+//mockDeserializer deserializes the identities created by invokeAs, an
+//identity is an admin of its organization if its id is "admin"
+type mockDeserializer struct {
+}
+
+func (d *mockDeserializer) DeserializeIdentity(serializedIdentity []byte) (msp.Identity, error) {
+	sid := &msp.SerializedIdentity{}
+	if err := proto.Unmarshal(serializedIdentity, sid); err != nil {
+		return nil, err
+	}
+	return &mockIdentity{mspid: sid.Mspid, admin: string(sid.IdBytes) == "admin"}, nil
+}
+
+type mockIdentity struct {
+	msp.Identity
+	mspid string
+	admin bool
+}
+
+func (id *mockIdentity) GetMSPIdentifier() string {
+	return id.mspid
+}
+
+func (id *mockIdentity) SatisfiesPrincipal(principal *common.MSPPrincipal) error {
+	if !id.admin {
+		return fmt.Errorf("not an admin of %s", id.mspid)
+	}
+	return nil
+}
+
+func (id *mockIdentity) Verify(msg []byte, sig []byte) error {
+	if len(sig) == 0 {
+		return fmt.Errorf("empty signature")
+	}
+	return nil
+}
+
+//mockPolicyManager returns a lifecycle policy requiring two approvals,
+//or no lifecycle policy at all if empty is set, and admins as the
+//application admins policy
+type mockPolicyManager struct {
+	empty  bool
+	admins policies.Policy
+}
+
+func (m *mockPolicyManager) Manager(path []string) (policies.Manager, bool) {
+	return nil, false
+}
+
+func (m *mockPolicyManager) BasePath() string {
+	return ""
+}
+
+func (m *mockPolicyManager) PolicyNames() []string {
+	return nil
+}
+
+func (m *mockPolicyManager) GetPolicy(id string) (policies.Policy, bool) {
+	if id == policies.ChannelApplicationAdmins && m.admins != nil {
+		return m.admins, true
+	}
+	if m.empty || id != policies.ChannelApplicationLifecycleEndorsement {
+		return nil, false
+	}
+	return &mockLifecyclePolicy{required: 2}, true
+}
+
+type mockLifecyclePolicy struct {
+	required int
+}
+
+func (p *mockLifecyclePolicy) Evaluate(signatureSet []*common.SignedData) error {
+	if len(signatureSet) < p.required {
+		return fmt.Errorf("%d approvals, %d required", len(signatureSet), p.required)
+	}
+	return nil
+}
+
+//creatorStub invokes the lccc on behalf of an organization
+type creatorStub struct {
+	*shim.MockStub
+	args    [][]byte
+	creator []byte
+}
+
+func (s *creatorStub) GetArgs() [][]byte {
+	return s.args
+}
+
+func (s *creatorStub) GetCreator() ([]byte, error) {
+	return s.creator, nil
+}
+
+//invokeAs invokes the lccc on behalf of an admin of the organization
+func invokeAs(scc *LifeCycleSysCC, stub *shim.MockStub, mspid string, args [][]byte) pb.Response {
+	return invokeWithIdentity(scc, stub, mspid, "admin", args)
+}
+
+//invokeAsMember invokes the lccc on behalf of a member of the organization
+func invokeAsMember(scc *LifeCycleSysCC, stub *shim.MockStub, mspid string, args [][]byte) pb.Response {
+	return invokeWithIdentity(scc, stub, mspid, "member", args)
+}
+
+func invokeWithIdentity(scc *LifeCycleSysCC, stub *shim.MockStub, mspid string, id string, args [][]byte) pb.Response {
+	creator, _ := proto.Marshal(&msp.SerializedIdentity{Mspid: mspid, IdBytes: []byte(id)})
+	stub.MockTransactionStart("1")
+	defer stub.MockTransactionEnd("1")
+	return scc.Invoke(&creatorStub{MockStub: stub, args: args, creator: creator})
+}
+
+//approveInstalled approves the definition of an installed chaincode, with the
+//default endorsement policy, escc and vscc, by the two organizations the mock
+//lifecycle policy requires
+func approveInstalled(t *testing.T, scc *LifeCycleSysCC, stub *shim.MockStub, ccname string, version string) []byte {
+	pkg, err := ccprovider.GetChaincodePackage(ccname, version)
+	if err != nil {
+		t.Fatalf("chaincode %s:%s is not installed: %s", ccname, version, err)
+	}
+	def, _ := proto.Marshal(&pb.ChaincodeDefinition{Name: ccname, Version: version, PackageHash: futil.ComputeSHA256(pkg)})
+	for _, mspid := range []string{"Org1MSP", "Org2MSP"} {
+		if res := invokeAs(scc, stub, mspid, [][]byte{[]byte(APPROVE), []byte("test"), def, []byte("sig")}); res.Status != shim.OK {
+			t.Fatalf("approve failed: %s", res.Message)
+		}
+	}
+	return def
+}
+
 func register(stub *shim.MockStub, ccname string) error {
 	args := [][]byte{[]byte("register"), []byte(ccname)}
 	if res := stub.MockInvoke("1", args); res.Status != shim.OK {
@@ -208,6 +355,51 @@ func TestReinstall(t *testing.T) {
 	}
 }
 
+//TestInstallUnsignedOwnedChaincode tests that a chaincode installed from a
+//signed package only accepts signed packages afterwards
+func TestInstallUnsignedOwnedChaincode(t *testing.T) {
+	scc := new(LifeCycleSysCC)
+	stub := shim.NewMockStub("lccc", scc)
+
+	if res := stub.MockInit("1", nil); res.Status != shim.OK {
+		fmt.Println("Init failed", string(res.Message))
+		t.FailNow()
+	}
+
+	cds, err := constructDeploymentSpec("example02", "github.com/hyperledger/fabric/examples/chaincode/go/chaincode_example02", "0", [][]byte{[]byte("init")}, true)
+	defer os.Remove(lccctestpath + "/example02.0")
+	if err != nil {
+		t.FailNow()
+	}
+
+	//version 0 was installed from a package owned by Org1MSP
+	env, err := ccpackage.OwnerCreateSignedCCDepSpec(cds, cauthdsl.SignedByMspMember("Org1MSP"), nil)
+	if err != nil {
+		t.Fatalf("could not create the signed package: %s", err)
+	}
+	pkg, _ := proto.Marshal(env)
+	if err = ccprovider.PutSignedPackageIntoFS("example02", "0", pkg); err != nil {
+		t.Fatalf("could not store the signed package: %s", err)
+	}
+	defer os.Remove(lccctestpath + "/example02.0.signed")
+
+	cds, err = constructDeploymentSpec("example02", "github.com/hyperledger/fabric/examples/chaincode/go/chaincode_example02", "1", [][]byte{[]byte("init")}, false)
+	if err != nil {
+		t.FailNow()
+	}
+	b, _ := proto.Marshal(cds)
+	defer os.Remove(lccctestpath + "/example02.1")
+
+	args := [][]byte{[]byte(INSTALL), b}
+	if res := stub.MockInvoke("1", args); res.Status == shim.OK {
+		t.Fatalf("an unsigned package of an owned chaincode must not be installed")
+	}
+
+	if _, err = ccprovider.GetChaincodePackage("example02", "1"); err == nil {
+		t.Fatalf("the unsigned package was stored")
+	}
+}
+
 //TestInvalidCodeDeploy tests the deploy function with invalid code package
 func TestInvalidCodeDeploy(t *testing.T) {
 	scc := new(LifeCycleSysCC)
@@ -291,6 +483,85 @@ func TestEmptyChaincodeVersion(t *testing.T) {
 	}
 }
 
+//TestInvalidResourceLimits tests the deploy function with a negative resource limit
+func TestInvalidResourceLimits(t *testing.T) {
+	scc := new(LifeCycleSysCC)
+	stub := shim.NewMockStub("lccc", scc)
+
+	if res := stub.MockInit("1", nil); res.Status != shim.OK {
+		fmt.Println("Init failed", string(res.Message))
+		t.FailNow()
+	}
+
+	cds, err := constructDeploymentSpec("example02", "github.com/hyperledger/fabric/examples/chaincode/go/chaincode_example02", "0", [][]byte{[]byte("init"), []byte("a"), []byte("100"), []byte("b"), []byte("200")}, true)
+	defer os.Remove(lccctestpath + "/example02.0")
+	if err != nil {
+		t.FailNow()
+	}
+
+	cds.ResourceLimits = &pb.ChaincodeResourceLimits{ExecuteTimeout: 5000, MaxConcurrency: -1}
+
+	var b []byte
+	if b, err = proto.Marshal(cds); err != nil || b == nil {
+		t.FailNow()
+	}
+
+	args := [][]byte{[]byte(DEPLOY), []byte("test"), b}
+	res := stub.MockInvoke("1", args)
+	if string(res.Message) != InvalidResourceLimitsErr("example02").Error() {
+		t.Logf("Get error: %s", res.Message)
+		t.FailNow()
+	}
+}
+
+//TestResourceLimitsOnRelaunch tests that the resource limits given at deploy
+//are returned with the deployment spec the chaincode is relaunched from
+func TestResourceLimitsOnRelaunch(t *testing.T) {
+	scc := new(LifeCycleSysCC)
+	stub := shim.NewMockStub("lccc", scc)
+
+	if res := stub.MockInit("1", nil); res.Status != shim.OK {
+		fmt.Println("Init failed", string(res.Message))
+		t.FailNow()
+	}
+
+	cds, err := constructDeploymentSpec("example02", "github.com/hyperledger/fabric/examples/chaincode/go/chaincode_example02", "0", [][]byte{[]byte("init"), []byte("a"), []byte("100"), []byte("b"), []byte("200")}, true)
+	defer os.Remove(lccctestpath + "/example02.0")
+	if err != nil {
+		t.FailNow()
+	}
+
+	limits := &pb.ChaincodeResourceLimits{ExecuteTimeout: 5000, MaxConcurrency: 2}
+	cds.ResourceLimits = limits
+
+	var b []byte
+	if b, err = proto.Marshal(cds); err != nil || b == nil {
+		t.FailNow()
+	}
+
+	args := [][]byte{[]byte(DEPLOY), []byte("test"), b}
+	if res := stub.MockInvoke("1", args); res.Status != shim.OK {
+		t.Fatalf("Deploy failed: %s", res.Message)
+	}
+
+	//the installed package has no limits, the deployed ones are returned
+	args = [][]byte{[]byte(GETDEPSPEC), []byte("test"), []byte("example02")}
+	res := stub.MockInvoke("1", args)
+	if res.Status != shim.OK {
+		t.Fatalf("GetDepSpec failed: %s", res.Message)
+	}
+	depSpec := &pb.ChaincodeDeploymentSpec{}
+	if err = proto.Unmarshal(res.Payload, depSpec); err != nil {
+		t.FailNow()
+	}
+	if !proto.Equal(depSpec.ResourceLimits, limits) {
+		t.Fatalf("Expected resource limits %v, got %v", limits, depSpec.ResourceLimits)
+	}
+	if depSpec.CodePackage == nil {
+		t.Fatalf("Expected the code package of the installed chaincode")
+	}
+}
+
 //TestRedeploy tests the redeploying will fail function(and fail with "exists" error)
 func TestRedeploy(t *testing.T) {
 	scc := new(LifeCycleSysCC)
@@ -620,6 +891,354 @@ func TestGetAPIsWithoutInstall(t *testing.T) {
 
 }
 
+func TestApproveAndCommit(t *testing.T) {
+	scc := new(LifeCycleSysCC)
+	stub := shim.NewMockStub("lccc", scc)
+
+	if res := stub.MockInit("1", nil); res.Status != shim.OK {
+		t.Fatalf("Init failed: %s", res.Message)
+	}
+
+	_, err := constructDeploymentSpec("example02", "github.com/hyperledger/fabric/examples/chaincode/go/chaincode_example02", "0", [][]byte{[]byte("init")}, true)
+	if err != nil {
+		t.FailNow()
+	}
+	defer os.Remove(lccctestpath + "/example02.0")
+
+	pkg, err := ccprovider.GetChaincodePackage("example02", "0")
+	if err != nil {
+		t.FailNow()
+	}
+	def, _ := proto.Marshal(&pb.ChaincodeDefinition{Name: "example02", Version: "0", PackageHash: futil.ComputeSHA256(pkg), Escc: "escc", Vscc: "vscc"})
+	otherDef, _ := proto.Marshal(&pb.ChaincodeDefinition{Name: "example02", Version: "0", PackageHash: futil.ComputeSHA256(pkg), InitRequired: true})
+
+	commit := [][]byte{[]byte(COMMIT), []byte("test"), def}
+	expectErr := LifecyclePolicyNotSatisfiedErr("example02:0").Error()
+	if res := invokeAs(scc, stub, "Org1MSP", commit); res.Message != expectErr {
+		t.Fatalf("expected %s, got %s", expectErr, res.Message)
+	}
+
+	if res := invokeAs(scc, stub, "Org1MSP", [][]byte{[]byte(APPROVE), []byte("test"), def, []byte("sig1")}); res.Status != shim.OK {
+		t.Fatalf("approve failed: %s", res.Message)
+	}
+
+	//an approval of a different definition does not count
+	if res := invokeAs(scc, stub, "Org2MSP", [][]byte{[]byte(APPROVE), []byte("test"), otherDef, []byte("sig2")}); res.Status != shim.OK {
+		t.Fatalf("approve failed: %s", res.Message)
+	}
+	if res := invokeAs(scc, stub, "Org1MSP", commit); res.Message != expectErr {
+		t.Fatalf("expected %s, got %s", expectErr, res.Message)
+	}
+
+	//the new approval of Org2 replaces its previous one
+	if res := invokeAs(scc, stub, "Org2MSP", [][]byte{[]byte(APPROVE), []byte("test"), def, []byte("sig2")}); res.Status != shim.OK {
+		t.Fatalf("approve failed: %s", res.Message)
+	}
+	if res := invokeAs(scc, stub, "Org1MSP", commit); res.Status != shim.OK {
+		t.Fatalf("commit failed: %s", res.Message)
+	}
+
+	res := stub.MockInvoke("1", [][]byte{[]byte(QUERYCOMMITTED), []byte("test"), []byte("example02")})
+	if res.Status != shim.OK || !bytes.Equal(res.Payload, def) {
+		t.Fatalf("unexpected committed definition: %s", res.Message)
+	}
+
+	//approvals and definitions are not listed as chaincodes
+	res = stub.MockInvoke("1", [][]byte{[]byte(GETCHAINCODES)})
+	cqr := &pb.ChaincodeQueryResponse{}
+	if err = proto.Unmarshal(res.Payload, cqr); err != nil || len(cqr.GetChaincodes()) != 1 {
+		t.Fatalf("expected one chaincode, got %v", cqr.GetChaincodes())
+	}
+
+	if res = invokeAs(scc, stub, "Org1MSP", commit); res.Message != IdenticalVersionErr("example02").Error() {
+		t.Fatalf("expected the commit of the same version to fail, got %s", res.Message)
+	}
+}
+
+func TestCommitPackageHashMismatch(t *testing.T) {
+	scc := new(LifeCycleSysCC)
+	stub := shim.NewMockStub("lccc", scc)
+
+	if res := stub.MockInit("1", nil); res.Status != shim.OK {
+		t.Fatalf("Init failed: %s", res.Message)
+	}
+
+	_, err := constructDeploymentSpec("example02", "github.com/hyperledger/fabric/examples/chaincode/go/chaincode_example02", "0", [][]byte{[]byte("init")}, true)
+	if err != nil {
+		t.FailNow()
+	}
+	defer os.Remove(lccctestpath + "/example02.0")
+
+	def, _ := proto.Marshal(&pb.ChaincodeDefinition{Name: "example02", Version: "0", PackageHash: []byte("not the package hash")})
+	for _, mspid := range []string{"Org1MSP", "Org2MSP"} {
+		if res := invokeAs(scc, stub, mspid, [][]byte{[]byte(APPROVE), []byte("test"), def, []byte("sig")}); res.Status != shim.OK {
+			t.Fatalf("approve failed: %s", res.Message)
+		}
+	}
+
+	res := invokeAs(scc, stub, "Org1MSP", [][]byte{[]byte(COMMIT), []byte("test"), def})
+	if res.Message != PackageHashMismatchErr("example02").Error() {
+		t.Fatalf("expected package hash mismatch, got %s", res.Message)
+	}
+}
+
+func TestDeployWithoutApprovals(t *testing.T) {
+	scc := new(LifeCycleSysCC)
+	stub := shim.NewMockStub("lccc", scc)
+
+	if res := stub.MockInit("1", nil); res.Status != shim.OK {
+		t.Fatalf("Init failed: %s", res.Message)
+	}
+
+	//a channel which has not adopted approvals: no lifecycle policy and an
+	//admins policy no approval satisfies yet
+	admins, _, err := cauthdsl.NewPolicyProvider(&mockDeserializer{}).NewPolicy(utils.MarshalOrPanic(cauthdsl.SignedByMspAdmin("Org1MSP")))
+	if err != nil {
+		t.Fatalf("could not create the admins policy: %s", err)
+	}
+	scc.sccprovider = &mocksccProviderImpl{policyManager: &mockPolicyManager{empty: true, admins: admins}}
+
+	cds, err := constructDeploymentSpec("example02", "github.com/hyperledger/fabric/examples/chaincode/go/chaincode_example02", "0", [][]byte{[]byte("init")}, true)
+	defer os.Remove(lccctestpath + "/example02.0")
+	if err != nil {
+		t.FailNow()
+	}
+	b, _ := proto.Marshal(cds)
+
+	pkg, err := ccprovider.GetChaincodePackage("example02", "0")
+	if err != nil {
+		t.FailNow()
+	}
+	def, _ := proto.Marshal(&pb.ChaincodeDefinition{Name: "example02", Version: "0", PackageHash: futil.ComputeSHA256(pkg)})
+	if res := invokeAs(scc, stub, "Org1MSP", [][]byte{[]byte(COMMIT), []byte("test"), def}); res.Status == shim.OK {
+		t.Fatalf("expected the commit of an unapproved definition to fail")
+	}
+
+	//the deploy is not subject to approvals
+	if res := stub.MockInvoke("1", [][]byte{[]byte(DEPLOY), []byte("test"), b}); res.Status != shim.OK {
+		t.Fatalf("deploy failed: %s", res.Message)
+	}
+	if res := stub.MockInvoke("1", [][]byte{[]byte(QUERYCOMMITTED), []byte("test"), []byte("example02")}); res.Status == shim.OK {
+		t.Fatalf("expected no committed definition for a deployed chaincode")
+	}
+}
+
+func TestUpgradeCommittedChaincode(t *testing.T) {
+	scc := new(LifeCycleSysCC)
+	stub := shim.NewMockStub("lccc", scc)
+
+	if res := stub.MockInit("1", nil); res.Status != shim.OK {
+		t.Fatalf("Init failed: %s", res.Message)
+	}
+
+	_, err := constructDeploymentSpec("example02", "github.com/hyperledger/fabric/examples/chaincode/go/chaincode_example02", "0", [][]byte{[]byte("init")}, true)
+	defer os.Remove(lccctestpath + "/example02.0")
+	if err != nil {
+		t.FailNow()
+	}
+	def := approveInstalled(t, scc, stub, "example02", "0")
+	if res := invokeAs(scc, stub, "Org1MSP", [][]byte{[]byte(COMMIT), []byte("test"), def}); res.Status != shim.OK {
+		t.Fatalf("commit failed: %s", res.Message)
+	}
+
+	cds, err := constructDeploymentSpec("example02", "github.com/hyperledger/fabric/examples/chaincode/go/chaincode_example02", "1", [][]byte{[]byte("init")}, true)
+	defer os.Remove(lccctestpath + "/example02.1")
+	if err != nil {
+		t.FailNow()
+	}
+	b, _ := proto.Marshal(cds)
+
+	//the approvals may not be bypassed by an upgrade
+	res := stub.MockInvoke("1", [][]byte{[]byte(UPGRADE), []byte("test"), b})
+	if res.Message != ApprovalsRequiredErr("example02").Error() {
+		t.Fatalf("expected the upgrade of a committed chaincode to fail, got %s", res.Message)
+	}
+}
+
+func TestApproveByMember(t *testing.T) {
+	scc := new(LifeCycleSysCC)
+	stub := shim.NewMockStub("lccc", scc)
+
+	if res := stub.MockInit("1", nil); res.Status != shim.OK {
+		t.Fatalf("Init failed: %s", res.Message)
+	}
+
+	def, _ := proto.Marshal(&pb.ChaincodeDefinition{Name: "example02", Version: "0", PackageHash: []byte("hash")})
+	approve := [][]byte{[]byte(APPROVE), []byte("test"), def, []byte("sig")}
+	if res := invokeAsMember(scc, stub, "Org1MSP", approve); res.Message != ApproverNotAdminErr("Org1MSP").Error() {
+		t.Fatalf("expected the approval of a member to fail, got %s", res.Message)
+	}
+	if res := invokeAs(scc, stub, "Org1MSP", approve); res.Status != shim.OK {
+		t.Fatalf("approve failed: %s", res.Message)
+	}
+}
+
+func TestCommitWithoutAdminsPolicy(t *testing.T) {
+	scc := new(LifeCycleSysCC)
+	stub := shim.NewMockStub("lccc", scc)
+
+	if res := stub.MockInit("1", nil); res.Status != shim.OK {
+		t.Fatalf("Init failed: %s", res.Message)
+	}
+	scc.sccprovider = &mocksccProviderImpl{policyManager: &mockPolicyManager{empty: true}}
+
+	_, err := constructDeploymentSpec("example02", "github.com/hyperledger/fabric/examples/chaincode/go/chaincode_example02", "0", [][]byte{[]byte("init")}, true)
+	defer os.Remove(lccctestpath + "/example02.0")
+	if err != nil {
+		t.FailNow()
+	}
+
+	def := approveInstalled(t, scc, stub, "example02", "0")
+	if res := invokeAs(scc, stub, "Org1MSP", [][]byte{[]byte(COMMIT), []byte("test"), def}); res.Status == shim.OK {
+		t.Fatalf("expected the commit to fail without a lifecycle or admins policy")
+	}
+}
+
+func TestQueryCommittedInvalidChainName(t *testing.T) {
+	scc := new(LifeCycleSysCC)
+	stub := shim.NewMockStub("lccc", scc)
+
+	if res := stub.MockInit("1", nil); res.Status != shim.OK {
+		t.Fatalf("Init failed: %s", res.Message)
+	}
+
+	res := stub.MockInvoke("1", [][]byte{[]byte(QUERYCOMMITTED), []byte(""), []byte("example02")})
+	if res.Message != InvalidChainNameErr("").Error() {
+		t.Fatalf("expected invalid chain name, got %s", res.Message)
+	}
+}
+
+//lcccTxEnvelope returns the marshalled envelope of a LCCC transaction with the
+//given writes by namespace
+func lcccTxEnvelope(t *testing.T, writes map[string][]*rwset.KVWrite) []byte {
+	txRWSet := &rwset.TxReadWriteSet{}
+	for ns, w := range writes {
+		txRWSet.NsRWs = append(txRWSet.NsRWs, &rwset.NsReadWriteSet{NameSpace: ns, Writes: w})
+	}
+	results, err := txRWSet.Marshal()
+	if err != nil {
+		t.Fatalf("could not marshal the write set: %s", err)
+	}
+
+	action, _ := proto.Marshal(&pb.ChaincodeAction{Results: results})
+	prp, _ := proto.Marshal(&pb.ProposalResponsePayload{Extension: action})
+	capBytes, _ := proto.Marshal(&pb.ChaincodeActionPayload{Action: &pb.ChaincodeEndorsedAction{ProposalResponsePayload: prp}})
+	tx, _ := proto.Marshal(&pb.Transaction{Actions: []*pb.TransactionAction{{Payload: capBytes}}})
+	payl, _ := proto.Marshal(&common.Payload{Header: &common.Header{}, Data: tx})
+	env, _ := proto.Marshal(&common.Envelope{Payload: payl})
+	return env
+}
+
+func TestValidate(t *testing.T) {
+	scc := new(LifeCycleSysCC)
+	stub := shim.NewMockStub("lccc", scc)
+
+	if res := stub.MockInit("1", nil); res.Status != shim.OK {
+		t.Fatalf("Init failed: %s", res.Message)
+	}
+
+	_, err := constructDeploymentSpec("example02", "github.com/hyperledger/fabric/examples/chaincode/go/chaincode_example02", "0", [][]byte{[]byte("init")}, true)
+	defer os.Remove(lccctestpath + "/example02.0")
+	if err != nil {
+		t.FailNow()
+	}
+	pkg, err := ccprovider.GetChaincodePackage("example02", "0")
+	if err != nil {
+		t.FailNow()
+	}
+
+	def := approveInstalled(t, scc, stub, "example02", "0")
+	cd, _ := proto.Marshal(&ccprovider.ChaincodeData{Name: "example02", Version: "0", DepSpec: pkg, Policy: scc.getDefaultEndorsementPolicy("test"), Escc: "escc", Vscc: "vscc"})
+	otherEscc, _ := proto.Marshal(&ccprovider.ChaincodeData{Name: "example02", Version: "0", DepSpec: pkg, Policy: scc.getDefaultEndorsementPolicy("test"), Escc: "otherescc", Vscc: "vscc"})
+
+	//a definition approved by a single organization
+	unapproved, _ := proto.Marshal(&pb.ChaincodeDefinition{Name: "example02", Version: "0", PackageHash: futil.ComputeSHA256(pkg), InitRequired: true})
+	if res := invokeAs(scc, stub, "Org3MSP", [][]byte{[]byte(APPROVE), []byte("test"), unapproved, []byte("sig")}); res.Status != shim.OK {
+		t.Fatalf("approve failed: %s", res.Message)
+	}
+
+	admin, _ := proto.Marshal(&msp.SerializedIdentity{Mspid: "Org1MSP", IdBytes: []byte("admin")})
+	member, _ := proto.Marshal(&msp.SerializedIdentity{Mspid: "Org1MSP", IdBytes: []byte("member")})
+	otherOrg, _ := proto.Marshal(&msp.SerializedIdentity{Mspid: "Org2MSP", IdBytes: []byte("admin")})
+	approvalBy := func(creator []byte) []byte {
+		approval, _ := proto.Marshal(&pb.ChaincodeApproval{Definition: def, Creator: creator, Signature: []byte("sig")})
+		return approval
+	}
+
+	for _, tc := range []struct {
+		name      string
+		writes    map[string][]*rwset.KVWrite
+		expectErr string
+	}{
+		{
+			name: "commit of the approved definition",
+			writes: map[string][]*rwset.KVWrite{
+				"lccc":      {rwset.NewKVWrite("example02", cd), rwset.NewKVWrite(definitionKeyPrefix+"example02", def)},
+				"example02": {rwset.NewKVWrite("a", []byte("100"))},
+			},
+		},
+		{
+			name:   "deploy without definition",
+			writes: map[string][]*rwset.KVWrite{"lccc": {rwset.NewKVWrite("example02", cd)}},
+		},
+		{
+			name:      "definition without chaincode data",
+			writes:    map[string][]*rwset.KVWrite{"lccc": {rwset.NewKVWrite(definitionKeyPrefix+"example02", def)}},
+			expectErr: InvalidLifecycleWriteErr(definitionKeyPrefix + "example02").Error(),
+		},
+		{
+			name:      "chaincode data not matching the definition",
+			writes:    map[string][]*rwset.KVWrite{"lccc": {rwset.NewKVWrite("example02", otherEscc), rwset.NewKVWrite(definitionKeyPrefix+"example02", def)}},
+			expectErr: InvalidChaincodeDefinitionErr("example02").Error(),
+		},
+		{
+			name:      "definition without enough approvals",
+			writes:    map[string][]*rwset.KVWrite{"lccc": {rwset.NewKVWrite("example02", cd), rwset.NewKVWrite(definitionKeyPrefix+"example02", unapproved)}},
+			expectErr: LifecyclePolicyNotSatisfiedErr("example02:0").Error(),
+		},
+		{
+			name: "write to another namespace",
+			writes: map[string][]*rwset.KVWrite{
+				"lccc":  {rwset.NewKVWrite("example02", cd), rwset.NewKVWrite(definitionKeyPrefix+"example02", def)},
+				"other": {rwset.NewKVWrite("a", []byte("100"))},
+			},
+			expectErr: InvalidLifecycleWriteErr("other").Error(),
+		},
+		{
+			name:   "approval by an admin",
+			writes: map[string][]*rwset.KVWrite{"lccc": {rwset.NewKVWrite(approvalKey("example02", "Org1MSP"), approvalBy(admin))}},
+		},
+		{
+			name:      "approval by a member",
+			writes:    map[string][]*rwset.KVWrite{"lccc": {rwset.NewKVWrite(approvalKey("example02", "Org1MSP"), approvalBy(member))}},
+			expectErr: ApproverNotAdminErr("Org1MSP").Error(),
+		},
+		{
+			name:      "approval recorded for another organization",
+			writes:    map[string][]*rwset.KVWrite{"lccc": {rwset.NewKVWrite(approvalKey("example02", "Org1MSP"), approvalBy(otherOrg))}},
+			expectErr: ApproverNotAdminErr("Org1MSP").Error(),
+		},
+	} {
+		res := stub.MockInvoke("1", [][]byte{[]byte(VALIDATE), []byte("test"), lcccTxEnvelope(t, tc.writes)})
+		if tc.expectErr == "" && res.Status != shim.OK {
+			t.Fatalf("%s: expected the transaction to be valid, got %s", tc.name, res.Message)
+		}
+		if tc.expectErr != "" && res.Message != tc.expectErr {
+			t.Fatalf("%s: expected %s, got %s", tc.name, tc.expectErr, res.Message)
+		}
+	}
+
+	//once committed from a definition, the chaincode data may not be
+	//written without one
+	if res := invokeAs(scc, stub, "Org1MSP", [][]byte{[]byte(COMMIT), []byte("test"), def}); res.Status != shim.OK {
+		t.Fatalf("commit failed: %s", res.Message)
+	}
+	res := stub.MockInvoke("1", [][]byte{[]byte(VALIDATE), []byte("test"), lcccTxEnvelope(t, map[string][]*rwset.KVWrite{"lccc": {rwset.NewKVWrite("example02", cd)}})})
+	if res.Message != ApprovalsRequiredErr("example02").Error() {
+		t.Fatalf("expected the upgrade of a committed chaincode to be invalid, got %s", res.Message)
+	}
+}
+
 func TestMain(m *testing.M) {
 	ccprovider.SetChaincodesPath(lccctestpath)
 	sysccprovider.RegisterSystemChaincodeProviderFactory(&mocksccProviderFactory{})
@@ -17,7 +17,11 @@ limitations under the License.
 package scc
 
 import (
+	"github.com/hyperledger/fabric/common/policies"
 	"github.com/hyperledger/fabric/core/common/sysccprovider"
+	"github.com/hyperledger/fabric/core/peer"
+	"github.com/hyperledger/fabric/msp"
+	mspmgmt "github.com/hyperledger/fabric/msp/mgmt"
 )
 
 // sccProviderFactory implements the sysccprovider.SystemChaincodeProviderFactory
@@ -44,3 +48,15 @@ type sccProviderImpl struct {
 func (c *sccProviderImpl) IsSysCC(name string) bool {
 	return IsSysCC(name)
 }
+
+// GetPolicyManager returns the policy manager of the supplied chain
+func (c *sccProviderImpl) GetPolicyManager(chainID string) (policies.Manager, bool) {
+	mgr := peer.GetPolicyManager(chainID)
+	return mgr, mgr != nil
+}
+
+// GetIdentityDeserializer returns the identity deserializer of the supplied chain
+func (c *sccProviderImpl) GetIdentityDeserializer(chainID string) (msp.IdentityDeserializer, bool) {
+	mgr := mspmgmt.GetManagerForChainIfExists(chainID)
+	return mgr, mgr != nil
+}
@@ -0,0 +1,255 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package producer
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/ledger"
+	"github.com/hyperledger/fabric/common/policies"
+	ledgerUtil "github.com/hyperledger/fabric/core/ledger/util"
+	"github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
+)
+
+// ChannelSupport provides the ledger and the policies of the channels whose
+// chaincode events are replayed
+type ChannelSupport interface {
+	// GetLedger returns the ledger of the channel
+	GetLedger(chainID string) (ledger.Ledger, bool)
+
+	// GetPolicyManager returns the policy manager of the channel
+	GetPolicyManager(chainID string) (policies.Manager, bool)
+}
+
+// ReplayServer implementation of the ChaincodeEvents service. Unlike the
+// events hub it reads the chaincode events out of the committed blocks, so
+// a client which was disconnected can resume from the last block it saw
+type ReplayServer struct {
+	support ChannelSupport
+}
+
+// NewReplayServer returns a ReplayServer reading events from the ledgers
+// provided by support
+func NewReplayServer(support ChannelSupport) *ReplayServer {
+	return &ReplayServer{support: support}
+}
+
+// Deliver implementation of the Deliver streaming RPC function
+func (r *ReplayServer) Deliver(srv pb.ChaincodeEvents_DeliverServer) error {
+	producerLogger.Debug("Starting new chaincode events deliver loop")
+	for {
+		envelope, err := srv.Recv()
+		if err != nil {
+			producerLogger.Debugf("Error reading from stream: %s", err)
+			return err
+		}
+
+		chainID, req, status := r.validateRequest(envelope)
+		if status != common.Status_SUCCESS {
+			return sendReplayStatus(srv, status)
+		}
+
+		status, err = r.replay(srv, chainID, req)
+		if err != nil {
+			return err
+		}
+		if err = sendReplayStatus(srv, status); err != nil {
+			return err
+		}
+		if status != common.Status_SUCCESS {
+			return nil
+		}
+	}
+}
+
+// validateRequest checks that the request is well formed and that its
+// creator is a reader of the channel
+func (r *ReplayServer) validateRequest(envelope *common.Envelope) (string, *pb.ChaincodeEventsRequest, common.Status) {
+	req := &pb.ChaincodeEventsRequest{}
+	chdr, err := utils.UnmarshalEnvelopeOfType(envelope, common.HeaderType_DELIVER_SEEK_INFO, req)
+	if err != nil {
+		producerLogger.Warningf("Received malformed chaincode events request: %s", err)
+		return "", nil, common.Status_BAD_REQUEST
+	}
+	if req.ChaincodeId == "" {
+		producerLogger.Warning("Received chaincode events request without chaincode ID")
+		return "", nil, common.Status_BAD_REQUEST
+	}
+
+	policyManager, ok := r.support.GetPolicyManager(chdr.ChannelId)
+	if !ok {
+		producerLogger.Debugf("Client request for channel %s not found", chdr.ChannelId)
+		return "", nil, common.Status_NOT_FOUND
+	}
+	policy, ok := policyManager.GetPolicy(policies.ChannelApplicationReaders)
+	if !ok {
+		producerLogger.Errorf("Could not find policy %s for channel %s", policies.ChannelApplicationReaders, chdr.ChannelId)
+		return "", nil, common.Status_FORBIDDEN
+	}
+	signedData, err := envelope.AsSignedData()
+	if err != nil {
+		return "", nil, common.Status_BAD_REQUEST
+	}
+	if err = policy.Evaluate(signedData); err != nil {
+		producerLogger.Warningf("Received unauthorized chaincode events request for channel %s: %s", chdr.ChannelId, err)
+		return "", nil, common.Status_FORBIDDEN
+	}
+
+	return chdr.ChannelId, req, common.Status_SUCCESS
+}
+
+// replay sends the events of the blocks starting at req.StartBlock. It
+// returns when the ledger height is reached if req.StopAtHeight is set and
+// otherwise waits for new blocks until the stream is closed
+func (r *ReplayServer) replay(srv pb.ChaincodeEvents_DeliverServer, chainID string, req *pb.ChaincodeEventsRequest) (common.Status, error) {
+	filter, err := regexp.Compile(req.EventNameFilter)
+	if err != nil {
+		producerLogger.Warningf("Received invalid event name filter %s: %s", req.EventNameFilter, err)
+		return common.Status_BAD_REQUEST, nil
+	}
+
+	lgr, ok := r.support.GetLedger(chainID)
+	if !ok {
+		return common.Status_NOT_FOUND, nil
+	}
+	info, err := lgr.GetBlockchainInfo()
+	if err != nil {
+		producerLogger.Errorf("Error reading the height of channel %s: %s", chainID, err)
+		return common.Status_INTERNAL_SERVER_ERROR, nil
+	}
+	if req.StopAtHeight && req.StartBlock >= info.Height {
+		return common.Status_NOT_FOUND, nil
+	}
+
+	itr, err := lgr.GetBlocksIterator(req.StartBlock)
+	if err != nil {
+		producerLogger.Errorf("Error reading blocks of channel %s: %s", chainID, err)
+		return common.Status_INTERNAL_SERVER_ERROR, nil
+	}
+	defer itr.Close()
+
+	//unblock the iterator when the client goes away, blocks iterators may
+	//be closed more than once and while Next is waiting for a block
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-srv.Context().Done():
+			itr.Close()
+		case <-done:
+		}
+	}()
+
+	for {
+		res, err := itr.Next()
+		if err != nil {
+			producerLogger.Errorf("Error reading from channel %s: %s", chainID, err)
+			return common.Status_INTERNAL_SERVER_ERROR, nil
+		}
+		if res == nil {
+			//the iterator was closed
+			return common.Status_SERVICE_UNAVAILABLE, nil
+		}
+		block := res.(ledger.BlockHolder).GetBlock()
+
+		events, err := GetChaincodeEventsFromBlock(block, req.ChaincodeId, filter)
+		if err != nil {
+			producerLogger.Errorf("Error extracting chaincode events from block %d of channel %s: %s", block.Header.Number, chainID, err)
+			return common.Status_INTERNAL_SERVER_ERROR, nil
+		}
+		if len(events) > 0 {
+			if err = srv.Send(&pb.ChaincodeEventsResponse{
+				Type: &pb.ChaincodeEventsResponse_Events{Events: &pb.ChaincodeBlockEvents{BlockNumber: block.Header.Number, ChaincodeEvents: events}},
+			}); err != nil {
+				return common.Status_SUCCESS, err
+			}
+		}
+
+		if req.StopAtHeight && block.Header.Number == info.Height-1 {
+			return common.Status_SUCCESS, nil
+		}
+	}
+}
+
+// GetChaincodeEventsFromBlock returns the events set by chaincode ccID in the
+// valid transactions of a committed block whose name matches filter
+func GetChaincodeEventsFromBlock(block *common.Block, ccID string, filter *regexp.Regexp) ([]*pb.ChaincodeEvent, error) {
+	if block.Data == nil {
+		return nil, nil
+	}
+	var txsFilter ledgerUtil.TxValidationFlags
+	if block.Metadata != nil && len(block.Metadata.Metadata) > int(common.BlockMetadataIndex_TRANSACTIONS_FILTER) {
+		txsFilter = ledgerUtil.TxValidationFlags(block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER])
+	}
+
+	var events []*pb.ChaincodeEvent
+	for i, d := range block.Data.Data {
+		//transactions the committer did not mark as valid never produced events
+		if i >= len(txsFilter) || txsFilter.IsInvalid(i) {
+			continue
+		}
+		env, err := utils.GetEnvelopeFromBlock(d)
+		if err != nil {
+			return nil, err
+		}
+		payload, err := utils.GetPayload(env)
+		if err != nil {
+			return nil, err
+		}
+		if payload.Header == nil {
+			return nil, fmt.Errorf("transaction %d has no header", i)
+		}
+		chdr, err := utils.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+		if err != nil {
+			return nil, err
+		}
+		if common.HeaderType(chdr.Type) != common.HeaderType_ENDORSER_TRANSACTION {
+			continue
+		}
+		tx, err := utils.GetTransaction(payload.Data)
+		if err != nil {
+			return nil, err
+		}
+		for _, action := range tx.Actions {
+			_, ccAction, err := utils.GetPayloads(action)
+			if err != nil {
+				return nil, err
+			}
+			if ccAction == nil || len(ccAction.Events) == 0 {
+				continue
+			}
+			event := &pb.ChaincodeEvent{}
+			if err = proto.Unmarshal(ccAction.Events, event); err != nil {
+				return nil, err
+			}
+			if event.ChaincodeId == ccID && filter.MatchString(event.EventName) {
+				events = append(events, event)
+			}
+		}
+	}
+	return events, nil
+}
+
+func sendReplayStatus(srv pb.ChaincodeEvents_DeliverServer, status common.Status) error {
+	return srv.Send(&pb.ChaincodeEventsResponse{
+		Type: &pb.ChaincodeEventsResponse_Status{Status: status},
+	})
+}
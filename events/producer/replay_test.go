@@ -0,0 +1,252 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package producer
+
+import (
+	"errors"
+	"io"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric/common/ledger"
+	"github.com/hyperledger/fabric/common/ledger/testutil"
+	mockpolicies "github.com/hyperledger/fabric/common/mocks/policies"
+	"github.com/hyperledger/fabric/common/policies"
+	"github.com/hyperledger/fabric/core/ledger/ledgermgmt"
+	ledgerUtil "github.com/hyperledger/fabric/core/ledger/util"
+	"github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+)
+
+type mockBlockHolder struct {
+	block *common.Block
+}
+
+func (bh *mockBlockHolder) GetBlock() *common.Block {
+	return bh.block
+}
+
+func (bh *mockBlockHolder) GetBlockBytes() []byte {
+	return utils.MarshalOrPanic(bh.block)
+}
+
+//mockIterator returns nil once the blocks are exhausted, as a closed
+//blocks iterator does
+type mockIterator struct {
+	blocks []*common.Block
+}
+
+func (itr *mockIterator) Next() (ledger.QueryResult, error) {
+	if len(itr.blocks) == 0 {
+		return nil, nil
+	}
+	block := itr.blocks[0]
+	itr.blocks = itr.blocks[1:]
+	return &mockBlockHolder{block}, nil
+}
+
+func (itr *mockIterator) Close() {
+}
+
+type mockLedger struct {
+	blocks []*common.Block
+}
+
+func (l *mockLedger) GetBlockchainInfo() (*common.BlockchainInfo, error) {
+	return &common.BlockchainInfo{Height: uint64(len(l.blocks))}, nil
+}
+
+func (l *mockLedger) GetBlockByNumber(blockNumber uint64) (*common.Block, error) {
+	return l.blocks[blockNumber], nil
+}
+
+func (l *mockLedger) GetBlocksIterator(startBlockNumber uint64) (ledger.ResultsIterator, error) {
+	return &mockIterator{l.blocks[startBlockNumber:]}, nil
+}
+
+func (l *mockLedger) Close() {
+}
+
+func (l *mockLedger) Commit(block *common.Block) error {
+	return errors.New("not implemented")
+}
+
+type mockSupport struct {
+	ledger        ledger.Ledger
+	policyManager *mockpolicies.Manager
+}
+
+func (s *mockSupport) GetLedger(chainID string) (ledger.Ledger, bool) {
+	return s.ledger, chainID == "testchain"
+}
+
+func (s *mockSupport) GetPolicyManager(chainID string) (policies.Manager, bool) {
+	return s.policyManager, chainID == "testchain"
+}
+
+type mockDeliverServer struct {
+	grpc.ServerStream
+	ctx       context.Context
+	requests  []*common.Envelope
+	responses []*pb.ChaincodeEventsResponse
+}
+
+func (m *mockDeliverServer) Recv() (*common.Envelope, error) {
+	if len(m.requests) == 0 {
+		return nil, io.EOF
+	}
+	req := m.requests[0]
+	m.requests = m.requests[1:]
+	return req, nil
+}
+
+func (m *mockDeliverServer) Send(resp *pb.ChaincodeEventsResponse) error {
+	m.responses = append(m.responses, resp)
+	return nil
+}
+
+func (m *mockDeliverServer) Context() context.Context {
+	if m.ctx != nil {
+		return m.ctx
+	}
+	return context.Background()
+}
+
+func createTx(ccID string, eventName string) []byte {
+	event := &pb.ChaincodeEvent{ChaincodeId: ccID, TxId: "tx", EventName: eventName}
+	ccAction := &pb.ChaincodeAction{Events: utils.MarshalOrPanic(event)}
+	prp := &pb.ProposalResponsePayload{Extension: utils.MarshalOrPanic(ccAction)}
+	ccPayload := &pb.ChaincodeActionPayload{Action: &pb.ChaincodeEndorsedAction{ProposalResponsePayload: utils.MarshalOrPanic(prp)}}
+	tx := &pb.Transaction{Actions: []*pb.TransactionAction{{Payload: utils.MarshalOrPanic(ccPayload)}}}
+	chdr := utils.MakeChannelHeader(common.HeaderType_ENDORSER_TRANSACTION, 0, "testchain", 0)
+	payload := &common.Payload{Header: &common.Header{ChannelHeader: utils.MarshalOrPanic(chdr)}, Data: utils.MarshalOrPanic(tx)}
+	return utils.MarshalOrPanic(&common.Envelope{Payload: utils.MarshalOrPanic(payload)})
+}
+
+//createBlock creates a block whose first transaction is invalid
+func createBlock(number uint64, txs ...[]byte) *common.Block {
+	block := common.NewBlock(number, nil)
+	block.Data.Data = txs
+	txsFilter := ledgerUtil.NewTxValidationFlags(len(txs))
+	txsFilter.SetFlag(0, pb.TxValidationCode_MVCC_READ_CONFLICT)
+	block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER] = txsFilter
+	return block
+}
+
+func createRequest(chainID string, req *pb.ChaincodeEventsRequest) *common.Envelope {
+	chdr := utils.MakeChannelHeader(common.HeaderType_DELIVER_SEEK_INFO, 0, chainID, 0)
+	payload := &common.Payload{Header: &common.Header{ChannelHeader: utils.MarshalOrPanic(chdr), SignatureHeader: utils.MarshalOrPanic(&common.SignatureHeader{})}, Data: utils.MarshalOrPanic(req)}
+	return &common.Envelope{Payload: utils.MarshalOrPanic(payload)}
+}
+
+func newMockSupport(policyErr error) *mockSupport {
+	return &mockSupport{
+		ledger: &mockLedger{blocks: []*common.Block{
+			createBlock(0, createTx("mycc", "transfer"), createTx("mycc", "transfer")),
+			createBlock(1, createTx("mycc", "transfer"), createTx("othercc", "transfer"), createTx("mycc", "delete")),
+			createBlock(2, createTx("mycc", "transfer"), createTx("mycc", "transfer"), createTx("mycc", "transferAll")),
+		}},
+		policyManager: &mockpolicies.Manager{Policy: &mockpolicies.Policy{Err: policyErr}},
+	}
+}
+
+func TestGetChaincodeEventsFromBlock(t *testing.T) {
+	block := createBlock(0, createTx("mycc", "transfer"), createTx("othercc", "transfer"), createTx("mycc", "transfer"), createTx("mycc", "delete"))
+
+	events, err := GetChaincodeEventsFromBlock(block, "mycc", regexp.MustCompile(""))
+	assert.NoError(t, err)
+	assert.Len(t, events, 2)
+
+	events, err = GetChaincodeEventsFromBlock(block, "mycc", regexp.MustCompile("^transfer$"))
+	assert.NoError(t, err)
+	assert.Len(t, events, 1)
+	assert.Equal(t, "transfer", events[0].EventName)
+}
+
+func TestReplayFromBlock(t *testing.T) {
+	srv := &mockDeliverServer{requests: []*common.Envelope{
+		createRequest("testchain", &pb.ChaincodeEventsRequest{ChaincodeId: "mycc", EventNameFilter: "^transfer", StartBlock: 1, StopAtHeight: true}),
+	}}
+	err := NewReplayServer(newMockSupport(nil)).Deliver(srv)
+	assert.Equal(t, io.EOF, err)
+
+	//block 1 has no matching valid transaction
+	assert.Len(t, srv.responses, 2)
+	assert.Equal(t, uint64(2), srv.responses[0].GetEvents().BlockNumber)
+	assert.Len(t, srv.responses[0].GetEvents().ChaincodeEvents, 2)
+	assert.Equal(t, common.Status_SUCCESS, srv.responses[1].GetStatus())
+}
+
+func TestReplayBadRequests(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		support *mockSupport
+		req     *common.Envelope
+		status  common.Status
+	}{
+		{"unknown channel", newMockSupport(nil), createRequest("otherchain", &pb.ChaincodeEventsRequest{ChaincodeId: "mycc"}), common.Status_NOT_FOUND},
+		{"forbidden", newMockSupport(errors.New("not a reader")), createRequest("testchain", &pb.ChaincodeEventsRequest{ChaincodeId: "mycc"}), common.Status_FORBIDDEN},
+		{"no chaincode", newMockSupport(nil), createRequest("testchain", &pb.ChaincodeEventsRequest{}), common.Status_BAD_REQUEST},
+		{"bad filter", newMockSupport(nil), createRequest("testchain", &pb.ChaincodeEventsRequest{ChaincodeId: "mycc", EventNameFilter: "("}), common.Status_BAD_REQUEST},
+		{"beyond height", newMockSupport(nil), createRequest("testchain", &pb.ChaincodeEventsRequest{ChaincodeId: "mycc", StartBlock: 3, StopAtHeight: true}), common.Status_NOT_FOUND},
+	} {
+		srv := &mockDeliverServer{requests: []*common.Envelope{tc.req}}
+		err := NewReplayServer(tc.support).Deliver(srv)
+		assert.NoError(t, err, tc.name)
+		assert.Len(t, srv.responses, 1, tc.name)
+		assert.Equal(t, tc.status, srv.responses[0].GetStatus(), tc.name)
+	}
+}
+
+func TestReplayClientGoesAway(t *testing.T) {
+	viper.Set("peer.fileSystemPath", "/tmp/fabric/replaytest")
+	ledgermgmt.InitializeTestEnv()
+	defer ledgermgmt.CleanupTestEnv()
+	lgr, err := ledgermgmt.CreateLedger("testchain")
+	assert.NoError(t, err)
+	defer lgr.Close()
+
+	simulator, _ := lgr.NewTxSimulator()
+	simulator.SetState("ns1", "key1", []byte("value1"))
+	simulator.Done()
+	simRes, _ := simulator.GetTxSimulationResults()
+	assert.NoError(t, lgr.Commit(testutil.ConstructBlock(t, [][]byte{simRes}, true)))
+	support := &mockSupport{ledger: lgr, policyManager: &mockpolicies.Manager{Policy: &mockpolicies.Policy{}}}
+
+	//tailing from the last block waits after reading it, tailing past the
+	//height waits before reading any block
+	for _, startBlock := range []uint64{0, 1} {
+		ctx, cancel := context.WithCancel(context.Background())
+		srv := &mockDeliverServer{ctx: ctx, requests: []*common.Envelope{
+			createRequest("testchain", &pb.ChaincodeEventsRequest{ChaincodeId: "mycc", StartBlock: startBlock}),
+		}}
+		go func() {
+			time.Sleep(10 * time.Millisecond)
+			cancel()
+		}()
+		err = NewReplayServer(support).Deliver(srv)
+		assert.NoError(t, err)
+		assert.Len(t, srv.responses, 1)
+		assert.Equal(t, common.Status_SERVICE_UNAVAILABLE, srv.responses[0].GetStatus())
+	}
+}
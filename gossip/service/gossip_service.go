@@ -53,6 +53,8 @@ type GossipService interface {
 	NewConfigEventer() ConfigProcessor
 	// InitializeChannel allocates the state provider and should be invoked once per channel per execution
 	InitializeChannel(chainID string, committer committer.Committer, endpoints []string)
+	// UpdateChannelEndpoints updates the ordering service endpoints of the channel, whenever its config is updated
+	UpdateChannelEndpoints(chainID string, endpoints []string)
 	// GetBlock returns block for given chain
 	GetBlock(chainID string, index uint64) *common.Block
 	// AddPayload appends message payload to for given chain
@@ -190,6 +192,10 @@ func (g *gossipServiceImpl) InitializeChannel(chainID string, committer committe
 	// Delivery service might be nil only if it was not able to get connected
 	// to the ordering service
 	if g.deliveryService != nil {
+		if err := g.deliveryService.UpdateEndpoints(chainID, endpoints); err != nil {
+			logger.Warning("Delivery service can't use the ordering service endpoints of chain", chainID, "due to", err)
+		}
+
 		// Parameters:
 		//              - peer.gossip.useLeaderElection
 		//              - peer.gossip.orgLeader
@@ -217,6 +223,20 @@ func (g *gossipServiceImpl) InitializeChannel(chainID string, committer committe
 	}
 }
 
+// UpdateChannelEndpoints updates the ordering service endpoints of the channel, whenever its config is updated
+func (g *gossipServiceImpl) UpdateChannelEndpoints(chainID string, endpoints []string) {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+	// The delivery service is created with the first channel initialized, which
+	// is given the endpoints of the channel
+	if g.deliveryService == nil {
+		return
+	}
+	if err := g.deliveryService.UpdateEndpoints(chainID, endpoints); err != nil {
+		logger.Warning("Delivery service can't update the ordering service endpoints of chain", chainID, "due to", err)
+	}
+}
+
 // configUpdated constructs a joinChannelMessage and sends it to the gossipSvc
 func (g *gossipServiceImpl) configUpdated(config Config) {
 	myOrg := string(g.secAdv.OrgByPeerIdentity(api.PeerIdentityType(g.peerIdentity)))
@@ -275,7 +275,8 @@ func (mf *mockDeliverServiceFactory) Service(g GossipService, endpoints []string
 }
 
 type mockDeliverService struct {
-	running map[string]bool
+	running   map[string]bool
+	endpoints map[string][]string
 }
 
 func (ds *mockDeliverService) StartDeliverForChannel(chainID string, ledgerInfo blocksprovider.LedgerInfo) error {
@@ -288,6 +289,13 @@ func (ds *mockDeliverService) StopDeliverForChannel(chainID string) error {
 	return nil
 }
 
+func (ds *mockDeliverService) UpdateEndpoints(chainID string, endpoints []string) error {
+	if ds.endpoints != nil {
+		ds.endpoints[chainID] = endpoints
+	}
+	return nil
+}
+
 func (ds *mockDeliverService) Stop() {
 }
 
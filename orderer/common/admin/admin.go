@@ -0,0 +1,312 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+                 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package admin
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/fabric/common/config"
+	"github.com/hyperledger/fabric/common/policies"
+	"github.com/hyperledger/fabric/orderer/ledger"
+	cb "github.com/hyperledger/fabric/protos/common"
+	ab "github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric/protos/utils"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	"github.com/op/go-logging"
+	"golang.org/x/net/context"
+)
+
+var logger = logging.MustGetLogger("orderer/common/admin")
+
+// maxRequestBytes bounds the size of the envelopes posted over HTTP
+const maxRequestBytes = 100 * 1024 * 1024
+
+// Handler serves the Admin service over gRPC, and over HTTP where the body of a POST
+// request is a marshaled envelope and the response is the AdminResponse as JSON
+type Handler interface {
+	ab.AdminServer
+	http.Handler
+}
+
+// Manager provides the channel lifecycle operations of the orderer
+type Manager interface {
+	// ChainIDs returns the IDs of the chains hosted by the orderer
+	ChainIDs() []string
+
+	// GetChain retrieves the Support for a chain (and whether it exists)
+	GetChain(chainID string) (Support, bool)
+
+	// SystemChannelID returns the ID of the system channel, or the empty string
+	SystemChannelID() string
+
+	// JoinChain creates and starts an application chain from its genesis block
+	JoinChain(genesisBlock *cb.Block) error
+
+	// RemoveChain halts an application chain and deletes its ledger
+	RemoveChain(chainID string) error
+
+	// ArchiveChain halts a sealed application chain and moves its ledger aside,
+	// returning where it was moved to
+	ArchiveChain(chainID string) (string, error)
+}
+
+// Support provides the resources describing a chain
+type Support interface {
+	// SharedConfig returns the orderer config of the chain
+	SharedConfig() config.Orderer
+
+	// Reader returns the chain Reader for the chain
+	Reader() ledger.Reader
+}
+
+type handlerImpl struct {
+	manager    Manager
+	policy     policies.Policy
+	identity   []byte
+	timeWindow time.Duration
+
+	// seen holds the timestamps of the requests processed within the time window by their nonce
+	seenLock sync.Mutex
+	seen     map[string]time.Time
+}
+
+// NewHandlerImpl creates an implementation of the Handler interface, the requests must be
+// signed so as to satisfy the policy, be addressed to the orderer of the serialized identity,
+// and be timestamped within timeWindow of the local time. Requests are not processed twice.
+func NewHandlerImpl(manager Manager, policy policies.Policy, identity []byte, timeWindow time.Duration) Handler {
+	return &handlerImpl{
+		manager:    manager,
+		policy:     policy,
+		identity:   identity,
+		timeWindow: timeWindow,
+		seen:       make(map[string]time.Time),
+	}
+}
+
+// Process processes an envelope carrying an AdminRequest
+func (h *handlerImpl) Process(ctx context.Context, env *cb.Envelope) (*ab.AdminResponse, error) {
+	return h.process(env), nil
+}
+
+// ServeHTTP processes a marshaled envelope carrying an AdminRequest
+func (h *handlerImpl) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "Admin requests must be posted", http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, maxRequestBytes))
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Could not read the request: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	var response *ab.AdminResponse
+	env := &cb.Envelope{}
+	if err := proto.Unmarshal(body, env); err != nil {
+		response = statusReply(cb.Status_BAD_REQUEST, fmt.Errorf("Request is not an envelope: %s", err))
+	} else {
+		response = h.process(env)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(int(response.Status))
+	if err := (&jsonpb.Marshaler{}).Marshal(w, response); err != nil {
+		logger.Warningf("Could not write the response: %s", err)
+	}
+}
+
+func (h *handlerImpl) process(env *cb.Envelope) *ab.AdminResponse {
+	request, status, err := h.authenticate(env)
+	if err != nil {
+		logger.Warningf("Rejecting admin request: %s", err)
+		return statusReply(status, err)
+	}
+
+	switch r := request.Type.(type) {
+	case *ab.AdminRequest_ListChannels:
+		return h.listChannels()
+	case *ab.AdminRequest_JoinChannel:
+		return h.joinChannel(r.JoinChannel)
+	case *ab.AdminRequest_RemoveChannel:
+		return h.removeChannel(r.RemoveChannel)
+	case *ab.AdminRequest_ArchiveChannel:
+		return h.archiveChannel(r.ArchiveChannel)
+	default:
+		return statusReply(cb.Status_BAD_REQUEST, fmt.Errorf("Unknown admin request type %T", request.Type))
+	}
+}
+
+// authenticate returns the request carried by the envelope, once the envelope is checked
+// to be a signed, timely and fresh MESSAGE satisfying the policy, addressed to this orderer
+func (h *handlerImpl) authenticate(env *cb.Envelope) (*ab.AdminRequest, cb.Status, error) {
+	payload, err := utils.UnmarshalPayload(env.Payload)
+	if err != nil {
+		return nil, cb.Status_BAD_REQUEST, err
+	}
+	if payload.Header == nil {
+		return nil, cb.Status_BAD_REQUEST, fmt.Errorf("Envelope has no header")
+	}
+	chdr, err := utils.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+	if err != nil {
+		return nil, cb.Status_BAD_REQUEST, err
+	}
+	if chdr.Type != int32(cb.HeaderType_MESSAGE) {
+		return nil, cb.Status_BAD_REQUEST, fmt.Errorf("Envelope is of header type %d instead of %d", chdr.Type, cb.HeaderType_MESSAGE)
+	}
+	if chdr.Timestamp == nil {
+		return nil, cb.Status_BAD_REQUEST, fmt.Errorf("Envelope has no timestamp")
+	}
+	timestamp := time.Unix(chdr.Timestamp.Seconds, int64(chdr.Timestamp.Nanos))
+	if skew := time.Since(timestamp); skew > h.timeWindow || skew < -h.timeWindow {
+		return nil, cb.Status_FORBIDDEN, fmt.Errorf("Envelope timestamp %s is not within %s of the orderer time", timestamp, h.timeWindow)
+	}
+
+	signedData, err := env.AsSignedData()
+	if err != nil {
+		return nil, cb.Status_BAD_REQUEST, err
+	}
+	if err := h.policy.Evaluate(signedData); err != nil {
+		return nil, cb.Status_FORBIDDEN, fmt.Errorf("Envelope is not signed by an admin: %s", err)
+	}
+
+	request := &ab.AdminRequest{}
+	if err := proto.Unmarshal(payload.Data, request); err != nil {
+		return nil, cb.Status_BAD_REQUEST, fmt.Errorf("Envelope does not carry an admin request: %s", err)
+	}
+	if !bytes.Equal(request.Orderer, h.identity) {
+		return nil, cb.Status_FORBIDDEN, fmt.Errorf("Request is addressed to another orderer")
+	}
+
+	shdr, err := utils.GetSignatureHeader(payload.Header.SignatureHeader)
+	if err != nil {
+		return nil, cb.Status_BAD_REQUEST, err
+	}
+	if len(shdr.Nonce) == 0 {
+		return nil, cb.Status_BAD_REQUEST, fmt.Errorf("Envelope has no nonce")
+	}
+	if !h.markSeen(shdr.Nonce, timestamp) {
+		return nil, cb.Status_FORBIDDEN, fmt.Errorf("Request was processed already")
+	}
+	return request, cb.Status_SUCCESS, nil
+}
+
+// markSeen records the nonce of a request, and returns false if it was recorded already.
+// The nonces of requests which are no longer timely are forgotten, such requests are
+// rejected by their timestamp.
+func (h *handlerImpl) markSeen(nonce []byte, timestamp time.Time) bool {
+	h.seenLock.Lock()
+	defer h.seenLock.Unlock()
+
+	for seen, ts := range h.seen {
+		if time.Since(ts) > h.timeWindow {
+			delete(h.seen, seen)
+		}
+	}
+	if _, ok := h.seen[string(nonce)]; ok {
+		return false
+	}
+	h.seen[string(nonce)] = timestamp
+	return true
+}
+
+func (h *handlerImpl) listChannels() *ab.AdminResponse {
+	response := &ab.AdminResponse{Status: cb.Status_SUCCESS}
+	for _, chainID := range h.manager.ChainIDs() {
+		// The chain may have been removed since it was listed
+		if info, ok := h.channelInfo(chainID); ok {
+			response.Channels = append(response.Channels, info)
+		}
+	}
+	return response
+}
+
+func (h *handlerImpl) joinChannel(request *ab.AdminJoinChannel) *ab.AdminResponse {
+	if request.GenesisBlock == nil {
+		return statusReply(cb.Status_BAD_REQUEST, fmt.Errorf("Join request carries no genesis block"))
+	}
+	if err := h.manager.JoinChain(request.GenesisBlock); err != nil {
+		logger.Warningf("Could not join channel: %s", err)
+		return statusReply(cb.Status_BAD_REQUEST, err)
+	}
+
+	chainID, err := utils.GetChainIDFromBlock(request.GenesisBlock)
+	if err != nil {
+		return statusReply(cb.Status_INTERNAL_SERVER_ERROR, err)
+	}
+	logger.Infof("Joined channel %s", chainID)
+	response := &ab.AdminResponse{Status: cb.Status_SUCCESS}
+	if info, ok := h.channelInfo(chainID); ok {
+		response.Channels = []*ab.ChannelInfo{info}
+	}
+	return response
+}
+
+func (h *handlerImpl) removeChannel(request *ab.AdminRemoveChannel) *ab.AdminResponse {
+	if _, ok := h.manager.GetChain(request.ChannelId); !ok {
+		return statusReply(cb.Status_NOT_FOUND, fmt.Errorf("Channel %s does not exist", request.ChannelId))
+	}
+	if err := h.manager.RemoveChain(request.ChannelId); err != nil {
+		logger.Warningf("Could not remove channel %s: %s", request.ChannelId, err)
+		return statusReply(cb.Status_BAD_REQUEST, err)
+	}
+	logger.Infof("Removed channel %s", request.ChannelId)
+	return &ab.AdminResponse{Status: cb.Status_SUCCESS}
+}
+
+func (h *handlerImpl) archiveChannel(request *ab.AdminArchiveChannel) *ab.AdminResponse {
+	if _, ok := h.manager.GetChain(request.ChannelId); !ok {
+		return statusReply(cb.Status_NOT_FOUND, fmt.Errorf("Channel %s does not exist", request.ChannelId))
+	}
+	archivePath, err := h.manager.ArchiveChain(request.ChannelId)
+	if err != nil {
+		logger.Warningf("Could not archive channel %s: %s", request.ChannelId, err)
+		return statusReply(cb.Status_BAD_REQUEST, err)
+	}
+	logger.Infof("Archived channel %s", request.ChannelId)
+	response := &ab.AdminResponse{Status: cb.Status_SUCCESS}
+	if archivePath != "" {
+		response.Info = fmt.Sprintf("Ledger moved to %s", archivePath)
+	}
+	return response
+}
+
+func (h *handlerImpl) channelInfo(chainID string) (*ab.ChannelInfo, bool) {
+	support, ok := h.manager.GetChain(chainID)
+	if !ok {
+		return nil, false
+	}
+	return &ab.ChannelInfo{
+		ChannelId:     chainID,
+		Height:        support.Reader().Height(),
+		ConsensusType: support.SharedConfig().ConsensusType(),
+		SystemChannel: chainID == h.manager.SystemChannelID(),
+		Sealed:        support.SharedConfig().Sealed(),
+	}, true
+}
+
+func statusReply(status cb.Status, err error) *ab.AdminResponse {
+	return &ab.AdminResponse{Status: status, Info: err.Error()}
+}
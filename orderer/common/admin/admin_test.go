@@ -0,0 +1,297 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+                 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package admin
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric/common/config"
+	mockconfigvaluesorderer "github.com/hyperledger/fabric/common/mocks/configvalues/channel/orderer"
+	mockcrypto "github.com/hyperledger/fabric/common/mocks/crypto"
+	mockpolicies "github.com/hyperledger/fabric/common/mocks/policies"
+	"github.com/hyperledger/fabric/orderer/ledger"
+	ramledger "github.com/hyperledger/fabric/orderer/ledger/ram"
+	cb "github.com/hyperledger/fabric/protos/common"
+	ab "github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric/protos/utils"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+)
+
+const systemChainID = "system"
+
+var ordererIdentity = []byte("OrdererIdentityBytes")
+
+type mockSupport struct {
+	ledger.ReadWriter
+	sealed bool
+}
+
+func (ms *mockSupport) SharedConfig() config.Orderer {
+	return &mockconfigvaluesorderer.SharedConfig{ConsensusTypeVal: "solo", SealedVal: ms.sealed}
+}
+
+func (ms *mockSupport) Reader() ledger.Reader {
+	return ms.ReadWriter
+}
+
+type mockManager struct {
+	chains map[string]*mockSupport
+	lf     ledger.Factory
+}
+
+func newMockManager() *mockManager {
+	mm := &mockManager{chains: make(map[string]*mockSupport), lf: ramledger.New(10)}
+	mm.JoinChain(makeGenesisBlock(systemChainID))
+	return mm
+}
+
+func (mm *mockManager) ChainIDs() []string {
+	return mm.lf.ChainIDs()
+}
+
+func (mm *mockManager) GetChain(chainID string) (Support, bool) {
+	cs, ok := mm.chains[chainID]
+	return cs, ok
+}
+
+func (mm *mockManager) SystemChannelID() string {
+	return systemChainID
+}
+
+func (mm *mockManager) JoinChain(genesisBlock *cb.Block) error {
+	chainID, err := utils.GetChainIDFromBlock(genesisBlock)
+	if err != nil {
+		return err
+	}
+	if _, ok := mm.chains[chainID]; ok {
+		return fmt.Errorf("Chain %s already exists", chainID)
+	}
+	rl, _ := mm.lf.GetOrCreate(chainID)
+	rl.Append(genesisBlock)
+	mm.chains[chainID] = &mockSupport{ReadWriter: rl}
+	return nil
+}
+
+func (mm *mockManager) RemoveChain(chainID string) error {
+	if chainID == systemChainID {
+		return fmt.Errorf("The system chain cannot be removed")
+	}
+	delete(mm.chains, chainID)
+	return mm.lf.Remove(chainID)
+}
+
+func (mm *mockManager) ArchiveChain(chainID string) (string, error) {
+	if !mm.chains[chainID].sealed {
+		return "", fmt.Errorf("Chain %s must be sealed before it is archived", chainID)
+	}
+	delete(mm.chains, chainID)
+	return "/archive/" + chainID, mm.lf.Remove(chainID)
+}
+
+func makeGenesisBlock(chainID string) *cb.Block {
+	block := cb.NewBlock(0, nil)
+	block.Data.Data = [][]byte{utils.MarshalOrPanic(&cb.Envelope{
+		Payload: utils.MarshalOrPanic(&cb.Payload{
+			Header: &cb.Header{ChannelHeader: utils.MarshalOrPanic(utils.MakeChannelHeader(cb.HeaderType_CONFIG, 0, chainID, 0))},
+		}),
+	})}
+	return block
+}
+
+var nonces uint64
+
+// makeRequest signs a request addressed to the test orderer with a fresh nonce
+func makeRequest(request *ab.AdminRequest) *cb.Envelope {
+	nonces++
+	signer := &mockcrypto.LocalSigner{Identity: []byte("IdentityBytes"), Nonce: []byte(fmt.Sprintf("Nonce %d", nonces))}
+	request.Orderer = ordererIdentity
+	env, err := utils.CreateSignedEnvelope(cb.HeaderType_MESSAGE, "", signer, request, 0, 0)
+	if err != nil {
+		panic(err)
+	}
+	return env
+}
+
+func newTestHandler() (*mockManager, *mockpolicies.Policy, Handler) {
+	mm := newMockManager()
+	policy := &mockpolicies.Policy{}
+	return mm, policy, NewHandlerImpl(mm, policy, ordererIdentity, time.Minute)
+}
+
+func TestChannelLifecycle(t *testing.T) {
+	_, _, h := newTestHandler()
+
+	response, _ := h.Process(nil, makeRequest(&ab.AdminRequest{Type: &ab.AdminRequest_JoinChannel{
+		JoinChannel: &ab.AdminJoinChannel{GenesisBlock: makeGenesisBlock("foo")},
+	}}))
+	assert.Equal(t, cb.Status_SUCCESS, response.Status, response.Info)
+	assert.Equal(t, []*ab.ChannelInfo{{ChannelId: "foo", Height: 1, ConsensusType: "solo"}}, response.Channels)
+
+	response, _ = h.Process(nil, makeRequest(&ab.AdminRequest{Type: &ab.AdminRequest_JoinChannel{
+		JoinChannel: &ab.AdminJoinChannel{GenesisBlock: makeGenesisBlock("foo")},
+	}}))
+	assert.Equal(t, cb.Status_BAD_REQUEST, response.Status, "Should not join a channel twice")
+
+	response, _ = h.Process(nil, makeRequest(&ab.AdminRequest{Type: &ab.AdminRequest_ListChannels{ListChannels: &ab.AdminListChannels{}}}))
+	assert.Equal(t, cb.Status_SUCCESS, response.Status, response.Info)
+	assert.Len(t, response.Channels, 2)
+	for _, info := range response.Channels {
+		assert.Equal(t, info.ChannelId == systemChainID, info.SystemChannel, "Only the system channel should be marked as such")
+	}
+
+	response, _ = h.Process(nil, makeRequest(&ab.AdminRequest{Type: &ab.AdminRequest_RemoveChannel{RemoveChannel: &ab.AdminRemoveChannel{ChannelId: "foo"}}}))
+	assert.Equal(t, cb.Status_SUCCESS, response.Status, response.Info)
+
+	response, _ = h.Process(nil, makeRequest(&ab.AdminRequest{Type: &ab.AdminRequest_RemoveChannel{RemoveChannel: &ab.AdminRemoveChannel{ChannelId: "foo"}}}))
+	assert.Equal(t, cb.Status_NOT_FOUND, response.Status, "Should not remove a channel twice")
+
+	response, _ = h.Process(nil, makeRequest(&ab.AdminRequest{Type: &ab.AdminRequest_RemoveChannel{RemoveChannel: &ab.AdminRemoveChannel{ChannelId: systemChainID}}}))
+	assert.Equal(t, cb.Status_BAD_REQUEST, response.Status, "Should not remove the system channel")
+
+	response, _ = h.Process(nil, makeRequest(&ab.AdminRequest{Type: &ab.AdminRequest_JoinChannel{JoinChannel: &ab.AdminJoinChannel{}}}))
+	assert.Equal(t, cb.Status_BAD_REQUEST, response.Status, "Should not join without a genesis block")
+}
+
+func TestArchiveChannel(t *testing.T) {
+	mm, _, h := newTestHandler()
+	mm.JoinChain(makeGenesisBlock("foo"))
+
+	archiveRequest := func() *cb.Envelope {
+		return makeRequest(&ab.AdminRequest{Type: &ab.AdminRequest_ArchiveChannel{ArchiveChannel: &ab.AdminArchiveChannel{ChannelId: "foo"}}})
+	}
+	response, _ := h.Process(nil, archiveRequest())
+	assert.Equal(t, cb.Status_BAD_REQUEST, response.Status, "Should not archive a channel which is not sealed")
+
+	mm.chains["foo"].sealed = true
+	response, _ = h.Process(nil, makeRequest(&ab.AdminRequest{Type: &ab.AdminRequest_ListChannels{ListChannels: &ab.AdminListChannels{}}}))
+	for _, info := range response.Channels {
+		assert.Equal(t, info.ChannelId == "foo", info.Sealed, "Only the sealed channel should be marked as such")
+	}
+
+	response, _ = h.Process(nil, archiveRequest())
+	assert.Equal(t, cb.Status_SUCCESS, response.Status, response.Info)
+	assert.Contains(t, response.Info, "/archive/foo", "Should have reported where the ledger was moved")
+
+	response, _ = h.Process(nil, archiveRequest())
+	assert.Equal(t, cb.Status_NOT_FOUND, response.Status, "Should not archive a channel twice")
+}
+
+func TestUnauthorized(t *testing.T) {
+	_, policy, h := newTestHandler()
+	policy.Err = fmt.Errorf("Not an admin")
+
+	response, _ := h.Process(nil, makeRequest(&ab.AdminRequest{Type: &ab.AdminRequest_RemoveChannel{RemoveChannel: &ab.AdminRemoveChannel{ChannelId: systemChainID}}}))
+	assert.Equal(t, cb.Status_FORBIDDEN, response.Status, "Should reject requests which do not satisfy the policy")
+}
+
+func TestMalformedRequests(t *testing.T) {
+	_, _, h := newTestHandler()
+
+	response, _ := h.Process(nil, &cb.Envelope{Payload: []byte("garbage")})
+	assert.Equal(t, cb.Status_BAD_REQUEST, response.Status, "Should reject envelopes without payload")
+
+	env, _ := utils.CreateSignedEnvelope(cb.HeaderType_CONFIG_UPDATE, "", mockcrypto.FakeLocalSigner, &ab.AdminRequest{}, 0, 0)
+	response, _ = h.Process(nil, env)
+	assert.Equal(t, cb.Status_BAD_REQUEST, response.Status, "Should reject envelopes of other header types")
+
+	response, _ = h.Process(nil, makeRequest(&ab.AdminRequest{}))
+	assert.Equal(t, cb.Status_BAD_REQUEST, response.Status, "Should reject empty requests")
+}
+
+func TestReplayedRequest(t *testing.T) {
+	mm, _, h := newTestHandler()
+
+	env := makeRequest(&ab.AdminRequest{Type: &ab.AdminRequest_JoinChannel{JoinChannel: &ab.AdminJoinChannel{GenesisBlock: makeGenesisBlock("foo")}}})
+	response, _ := h.Process(nil, env)
+	assert.Equal(t, cb.Status_SUCCESS, response.Status, response.Info)
+
+	mm.RemoveChain("foo")
+	response, _ = h.Process(nil, env)
+	assert.Equal(t, cb.Status_FORBIDDEN, response.Status, "Should not process a request twice")
+	_, ok := mm.GetChain("foo")
+	assert.False(t, ok, "Should not have joined the channel again")
+}
+
+func TestMisaddressedRequest(t *testing.T) {
+	_, _, h := newTestHandler()
+
+	env, _ := utils.CreateSignedEnvelope(cb.HeaderType_MESSAGE, "", &mockcrypto.LocalSigner{Nonce: []byte("Nonce")}, &ab.AdminRequest{
+		Type:    &ab.AdminRequest_ListChannels{ListChannels: &ab.AdminListChannels{}},
+		Orderer: []byte("OtherOrdererIdentityBytes"),
+	}, 0, 0)
+	response, _ := h.Process(nil, env)
+	assert.Equal(t, cb.Status_FORBIDDEN, response.Status, "Should reject requests addressed to another orderer")
+}
+
+func TestStaleRequest(t *testing.T) {
+	_, _, h := newTestHandler()
+
+	chdr := utils.MakeChannelHeader(cb.HeaderType_MESSAGE, 0, "", 0)
+	chdr.Timestamp.Seconds -= int64((2 * time.Minute).Seconds())
+	env := &cb.Envelope{Payload: utils.MarshalOrPanic(&cb.Payload{
+		Header: &cb.Header{
+			ChannelHeader:   utils.MarshalOrPanic(chdr),
+			SignatureHeader: utils.MarshalOrPanic(&cb.SignatureHeader{}),
+		},
+		Data: utils.MarshalOrPanic(&ab.AdminRequest{Type: &ab.AdminRequest_ListChannels{ListChannels: &ab.AdminListChannels{}}}),
+	})}
+
+	response, _ := h.Process(nil, env)
+	assert.Equal(t, cb.Status_FORBIDDEN, response.Status, "Should reject requests outside of the time window")
+}
+
+func TestHTTP(t *testing.T) {
+	_, _, h := newTestHandler()
+	server := httptest.NewServer(h)
+	defer server.Close()
+
+	env := makeRequest(&ab.AdminRequest{Type: &ab.AdminRequest_ListChannels{ListChannels: &ab.AdminListChannels{}}})
+	resp, err := http.Post(server.URL, "application/octet-stream", bytes.NewReader(utils.MarshalOrPanic(env)))
+	if err != nil {
+		t.Fatalf("Could not post the request: %s", err)
+	}
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	response := &ab.AdminResponse{}
+	assert.NoError(t, jsonpb.Unmarshal(resp.Body, response), "Should have responded with JSON")
+	assert.True(t, proto.Equal(&ab.AdminResponse{
+		Status:   cb.Status_SUCCESS,
+		Channels: []*ab.ChannelInfo{{ChannelId: systemChainID, Height: 1, ConsensusType: "solo", SystemChannel: true}},
+	}, response), "Unexpected response %v", response)
+
+	resp, err = http.Post(server.URL, "application/octet-stream", bytes.NewReader([]byte("garbage")))
+	if err != nil {
+		t.Fatalf("Could not post the request: %s", err)
+	}
+	resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode, "Should reject bodies which are not envelopes")
+
+	resp, err = http.Get(server.URL)
+	if err != nil {
+		t.Fatalf("Could not get: %s", err)
+	}
+	resp.Body.Close()
+	assert.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode, "Should only accept posted requests")
+}
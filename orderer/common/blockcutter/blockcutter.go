@@ -22,10 +22,24 @@ import (
 	cb "github.com/hyperledger/fabric/protos/common"
 
 	"github.com/op/go-logging"
+	"github.com/rcrowley/go-metrics"
 )
 
 var logger = logging.MustGetLogger("orderer/common/blockcutter")
 
+// The reasons batches are cut for
+const (
+	cutIsolated = "isolated"
+	cutSize     = "size"
+	cutCount    = "count"
+	cutTimeout  = "timeout"
+)
+
+// countCut counts a batch cut by the reason it was cut for
+func countCut(reason string) {
+	metrics.GetOrRegisterCounter("blockcutter.cut."+reason, nil).Inc(1)
+}
+
 // Receiver defines a sink for the ordered broadcast messages
 type Receiver interface {
 	// Ordered should be invoked sequentially as messages are ordered
@@ -33,7 +47,7 @@ type Receiver interface {
 	//   - Ordered will return nil, nil, and true (indicating ok).
 	// If the current message valid, and batches need to be cut:
 	//   - Ordered will return 1 or 2 batches of messages, 1 or 2 batches of committers, and true (indicating ok).
-	// If the current message is invalid:
+	// If the current message is invalid, or larger than BatchSize.AbsoluteMaxBytes:
 	//   - Ordered will return nil, nil, and false (to indicate not ok).
 	//
 	// Given a valid message, if the current message needs to be isolated (as determined during filtering).
@@ -49,6 +63,10 @@ type Receiver interface {
 
 	// Cut returns the current batch and starts a new one
 	Cut() ([]*cb.Envelope, []filter.Committer)
+
+	// Timer returns the timer of the pending batch, it is started with the BatchTimeout of the channel
+	// when a message is added to an empty batch and stopped when the batch is cut
+	Timer() *Timer
 }
 
 type receiver struct {
@@ -57,6 +75,7 @@ type receiver struct {
 	pendingBatch          []*cb.Envelope
 	pendingBatchSizeBytes uint32
 	pendingCommitters     []filter.Committer
+	timer                 *Timer
 }
 
 // NewReceiverImpl creates a Receiver implementation based on the given configtxorderer manager and filters
@@ -64,6 +83,7 @@ func NewReceiverImpl(sharedConfigManager config.Orderer, filters *filter.RuleSet
 	return &receiver{
 		sharedConfigManager: sharedConfigManager,
 		filters:             filters,
+		timer:               NewTimer(sharedConfigManager.BatchTimeout),
 	}
 }
 
@@ -72,7 +92,7 @@ func NewReceiverImpl(sharedConfigManager config.Orderer, filters *filter.RuleSet
 //   - Ordered will return nil, nil, and true (indicating ok).
 // If the current message valid, and batches need to be cut:
 //   - Ordered will return 1 or 2 batches of messages, 1 or 2 batches of committers, and true (indicating ok).
-// If the current message is invalid:
+// If the current message is invalid, or larger than BatchSize.AbsoluteMaxBytes:
 //   - Ordered will return nil, nil, and false (to indicate not ok).
 //
 // Given a valid message, if the current message needs to be isolated (as determined during filtering).
@@ -94,6 +114,12 @@ func (r *receiver) Ordered(msg *cb.Envelope) ([][]*cb.Envelope, [][]filter.Commi
 
 	messageSizeBytes := messageSizeBytes(msg)
 
+	// Such messages are rejected at broadcast, unless the maximum was lowered meanwhile
+	if messageSizeBytes > r.sharedConfigManager.BatchSize().AbsoluteMaxBytes {
+		logger.Debugf("Rejecting message of %v bytes, which is larger than the absolute maximum of %v bytes", messageSizeBytes, r.sharedConfigManager.BatchSize().AbsoluteMaxBytes)
+		return nil, nil, false
+	}
+
 	if committer.Isolated() || messageSizeBytes > r.sharedConfigManager.BatchSize().PreferredMaxBytes {
 
 		if committer.Isolated() {
@@ -105,14 +131,20 @@ func (r *receiver) Ordered(msg *cb.Envelope) ([][]*cb.Envelope, [][]filter.Commi
 		messageBatches := [][]*cb.Envelope{}
 		committerBatches := [][]filter.Committer{}
 
+		cutReason := cutSize
+		if committer.Isolated() {
+			cutReason = cutIsolated
+		}
+
 		// cut pending batch, if it has any messages
 		if len(r.pendingBatch) > 0 {
-			messageBatch, committerBatch := r.Cut()
+			messageBatch, committerBatch := r.cut(cutReason)
 			messageBatches = append(messageBatches, messageBatch)
 			committerBatches = append(committerBatches, committerBatch)
 		}
 
 		// create new batch with single message
+		countCut(cutReason)
 		messageBatches = append(messageBatches, []*cb.Envelope{msg})
 		committerBatches = append(committerBatches, []filter.Committer{committer})
 
@@ -127,19 +159,22 @@ func (r *receiver) Ordered(msg *cb.Envelope) ([][]*cb.Envelope, [][]filter.Commi
 	if messageWillOverflowBatchSizeBytes {
 		logger.Debugf("The current message, with %v bytes, will overflow the pending batch of %v bytes.", messageSizeBytes, r.pendingBatchSizeBytes)
 		logger.Debugf("Pending batch would overflow if current message is added, cutting batch now.")
-		messageBatch, committerBatch := r.Cut()
+		messageBatch, committerBatch := r.cut(cutSize)
 		messageBatches = append(messageBatches, messageBatch)
 		committerBatches = append(committerBatches, committerBatch)
 	}
 
 	logger.Debugf("Enqueuing message into batch")
+	if len(r.pendingBatch) == 0 {
+		r.timer.Start()
+	}
 	r.pendingBatch = append(r.pendingBatch, msg)
 	r.pendingBatchSizeBytes += messageSizeBytes
 	r.pendingCommitters = append(r.pendingCommitters, committer)
 
 	if uint32(len(r.pendingBatch)) >= r.sharedConfigManager.BatchSize().MaxMessageCount {
 		logger.Debugf("Batch size met, cutting batch")
-		messageBatch, committerBatch := r.Cut()
+		messageBatch, committerBatch := r.cut(cutCount)
 		messageBatches = append(messageBatches, messageBatch)
 		committerBatches = append(committerBatches, committerBatch)
 	}
@@ -153,16 +188,30 @@ func (r *receiver) Ordered(msg *cb.Envelope) ([][]*cb.Envelope, [][]filter.Commi
 
 }
 
-// Cut returns the current batch and starts a new one
+// Cut returns the current batch and starts a new one, the consenters cut the batch once its timer expires
 func (r *receiver) Cut() ([]*cb.Envelope, []filter.Committer) {
+	return r.cut(cutTimeout)
+}
+
+// cut returns the current batch and starts a new one, counting the batch if it is not empty
+func (r *receiver) cut(reason string) ([]*cb.Envelope, []filter.Committer) {
+	if len(r.pendingBatch) > 0 {
+		countCut(reason)
+	}
 	batch := r.pendingBatch
 	r.pendingBatch = nil
 	committers := r.pendingCommitters
 	r.pendingCommitters = nil
 	r.pendingBatchSizeBytes = 0
+	r.timer.Stop()
 	return batch, committers
 }
 
+// Timer returns the timer of the pending batch
+func (r *receiver) Timer() *Timer {
+	return r.timer
+}
+
 func messageSizeBytes(message *cb.Envelope) uint32 {
 	return uint32(len(message.Payload) + len(message.Signature))
 }
@@ -19,6 +19,7 @@ package blockcutter
 import (
 	"bytes"
 	"testing"
+	"time"
 
 	mockconfigtxorderer "github.com/hyperledger/fabric/common/mocks/configvalues/channel/orderer"
 	"github.com/hyperledger/fabric/orderer/common/filter"
@@ -340,3 +341,67 @@ func TestBatchSizePreferredMaxBytesOverflowNoPending(t *testing.T) {
 	}
 
 }
+
+func TestBatchSizeAbsoluteMaxBytesOverflow(t *testing.T) {
+	filters := getFilters()
+
+	goodTxLargeBytes := messageSizeBytes(goodTxLarge)
+
+	r := NewReceiverImpl(&mockconfigtxorderer.SharedConfig{BatchSizeVal: &ab.BatchSize{MaxMessageCount: 20, AbsoluteMaxBytes: goodTxLargeBytes - 1, PreferredMaxBytes: goodTxLargeBytes - 1}}, filters)
+
+	r.Ordered(goodTx)
+
+	batches, committers, ok := r.Ordered(goodTxLarge)
+	if batches != nil || committers != nil {
+		t.Fatalf("Should not have created batch")
+	}
+	if ok {
+		t.Fatalf("Should have rejected the message larger than the absolute maximum")
+	}
+
+	messageBatch, _ := r.Cut()
+	if len(messageBatch) != 1 {
+		t.Fatalf("Should have kept only the pending message in the batch, got %d", len(messageBatch))
+	}
+}
+
+func TestBatchTimer(t *testing.T) {
+	filters := getFilters()
+	sharedConfig := &mockconfigtxorderer.SharedConfig{
+		BatchSizeVal:    &ab.BatchSize{MaxMessageCount: 2, AbsoluteMaxBytes: 1000, PreferredMaxBytes: 100},
+		BatchTimeoutVal: time.Hour,
+	}
+	r := NewReceiverImpl(sharedConfig, filters)
+
+	if r.Timer().C() != nil {
+		t.Fatalf("Timer should not run without pending messages")
+	}
+
+	r.Ordered(goodTx)
+	if r.Timer().C() == nil {
+		t.Fatalf("Timer should have started with the first pending message")
+	}
+
+	r.Ordered(goodTx)
+	if r.Timer().C() != nil {
+		t.Fatalf("Timer should have stopped when the batch was cut")
+	}
+
+	// The batch timeout is read from the configuration whenever the timer starts
+	sharedConfig.BatchTimeoutVal = time.Millisecond
+	r.Ordered(goodTx)
+
+	select {
+	case <-r.Timer().C():
+	case <-time.After(time.Second):
+		t.Fatalf("Timer should have expired after the updated batch timeout")
+	}
+
+	messageBatch, _ := r.Cut()
+	if len(messageBatch) != 1 {
+		t.Fatalf("Should have had one tx in the batch, got %d", len(messageBatch))
+	}
+	if r.Timer().C() != nil {
+		t.Fatalf("Timer should have stopped when the batch was cut")
+	}
+}
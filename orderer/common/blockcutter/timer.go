@@ -0,0 +1,60 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+                 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package blockcutter
+
+import "time"
+
+// Timer signals when the oldest pending message of a batch has waited for the batch timeout,
+// it is meant to be driven from the goroutine ordering the messages
+type Timer struct {
+	timeout func() time.Duration
+	timer   *time.Timer
+}
+
+// NewTimer creates a stopped Timer, the batch timeout is read from timeout whenever the timer starts
+func NewTimer(timeout func() time.Duration) *Timer {
+	return &Timer{timeout: timeout}
+}
+
+// Start starts the timer, unless it is running or has expired already
+func (t *Timer) Start() {
+	if t.timer == nil {
+		t.timer = time.NewTimer(t.timeout())
+	}
+}
+
+// Stop stops the timer
+func (t *Timer) Stop() {
+	if t.timer != nil {
+		t.timer.Stop()
+		t.timer = nil
+	}
+}
+
+// Restart restarts the timer, e.g. when its expiry could not be acted upon
+func (t *Timer) Restart() {
+	t.Stop()
+	t.Start()
+}
+
+// C returns the channel on which the expiry of the timer is delivered, or nil if the timer is stopped
+func (t *Timer) C() <-chan time.Time {
+	if t.timer == nil {
+		return nil
+	}
+	return t.timer.C
+}
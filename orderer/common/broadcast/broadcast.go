@@ -18,11 +18,16 @@ package broadcast
 
 import (
 	"github.com/hyperledger/fabric/orderer/common/filter"
+	"github.com/hyperledger/fabric/orderer/ledger"
 	cb "github.com/hyperledger/fabric/protos/common"
 	ab "github.com/hyperledger/fabric/protos/orderer"
 	"github.com/op/go-logging"
+	"github.com/rcrowley/go-metrics"
+	"google.golang.org/grpc/metadata"
 
+	"bytes"
 	"io"
+	"time"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/protos/utils"
@@ -30,6 +35,23 @@ import (
 
 var logger = logging.MustGetLogger("orderer/common/broadcast")
 
+// received measures the rate at which broadcast messages are received
+var received = metrics.GetOrRegisterMeter("broadcast.received", nil)
+
+// countRejection counts a rejected broadcast message by the reason it was rejected for
+func countRejection(reason string) {
+	metrics.GetOrRegisterCounter("broadcast.rejected."+reason, nil).Inc(1)
+}
+
+const (
+	// AckModeKey is the gRPC metadata key with which a client selects when the broadcast responses are sent
+	AckModeKey = "broadcast-ack"
+
+	// AckModeCommit makes the orderer respond to each envelope only once it has been written into a block,
+	// the response carries the number of the block and the index of the envelope in it
+	AckModeCommit = "commit"
+)
+
 // ConfigUpdateProcessor is used to transform CONFIG_UPDATE transactions which are used to generate other envelope
 // message types with preprocessing by the orderer
 type ConfigUpdateProcessor interface {
@@ -54,26 +76,53 @@ type SupportManager interface {
 
 // Support provides the backing resources needed to support broadcast on a chain
 type Support interface {
-	// Enqueue accepts a message and returns true on acceptance, or false on shutdown
+	// Enqueue accepts a message and returns true on acceptance, or false on shutdown, once the chain is sealed
+	// or if a message with the same transaction ID was accepted concurrently
 	Enqueue(env *cb.Envelope) bool
 
 	// Filters returns the set of broadcast filters for this chain
 	Filters() *filter.RuleSet
+
+	// Reader returns the chain Reader for the chain, in which enqueued envelopes are written
+	Reader() ledger.Reader
 }
 
 type handlerImpl struct {
-	sm SupportManager
+	sm            SupportManager
+	commitTimeout time.Duration
 }
 
-// NewHandlerImpl constructs a new implementation of the Handler interface
-func NewHandlerImpl(sm SupportManager) Handler {
+// NewHandlerImpl constructs a new implementation of the Handler interface, when acknowledging commits
+// the handler gives up waiting for an envelope to be written into a block after commitTimeout
+func NewHandlerImpl(sm SupportManager, commitTimeout time.Duration) Handler {
 	return &handlerImpl{
-		sm: sm,
+		sm:            sm,
+		commitTimeout: commitTimeout,
 	}
 }
 
 // Handle starts a service thread for a given gRPC connection and services the broadcast connection
-func (bh *handlerImpl) Handle(srv ab.AtomicBroadcast_BroadcastServer) error {
+func (bh *handlerImpl) Handle(srv ab.AtomicBroadcast_BroadcastServer) (err error) {
+	ackCommit := false
+	if md, ok := metadata.FromContext(srv.Context()); ok && len(md[AckModeKey]) > 0 {
+		ackCommit = md[AckModeKey][0] == AckModeCommit
+	}
+	logger.Debugf("Starting broadcast stream, acknowledging commits: %t", ackCommit)
+
+	// Waiting for commits must not hold up the following messages of the stream,
+	// their responses are queued and sent in order by the responder
+	send := srv.Send
+	var acks *responder
+	if ackCommit {
+		acks = newResponder(srv)
+		defer func() {
+			if ackErr := acks.close(); err == nil {
+				err = ackErr
+			}
+		}()
+		send = acks.send
+	}
+
 	for {
 		msg, err := srv.Recv()
 		if err == io.EOF {
@@ -82,6 +131,7 @@ func (bh *handlerImpl) Handle(srv ab.AtomicBroadcast_BroadcastServer) error {
 		if err != nil {
 			return err
 		}
+		received.Mark(1)
 
 		payload := &cb.Payload{}
 		err = proto.Unmarshal(msg.Payload, payload)
@@ -89,12 +139,14 @@ func (bh *handlerImpl) Handle(srv ab.AtomicBroadcast_BroadcastServer) error {
 			if logger.IsEnabledFor(logging.WARNING) {
 				logger.Warningf("Received malformed message, dropping connection: %s", err)
 			}
-			return srv.Send(&ab.BroadcastResponse{Status: cb.Status_BAD_REQUEST})
+			countRejection("malformed")
+			return send(&ab.BroadcastResponse{Status: cb.Status_BAD_REQUEST})
 		}
 
 		if payload.Header == nil {
 			logger.Warningf("Received malformed message, with missing header, dropping connection")
-			return srv.Send(&ab.BroadcastResponse{Status: cb.Status_BAD_REQUEST})
+			countRejection("malformed")
+			return send(&ab.BroadcastResponse{Status: cb.Status_BAD_REQUEST})
 		}
 
 		chdr, err := utils.UnmarshalChannelHeader(payload.Header.ChannelHeader)
@@ -102,7 +154,8 @@ func (bh *handlerImpl) Handle(srv ab.AtomicBroadcast_BroadcastServer) error {
 			if logger.IsEnabledFor(logging.WARNING) {
 				logger.Warningf("Received malformed message (bad channel header), dropping connection: %s", err)
 			}
-			return srv.Send(&ab.BroadcastResponse{Status: cb.Status_BAD_REQUEST})
+			countRejection("malformed")
+			return send(&ab.BroadcastResponse{Status: cb.Status_BAD_REQUEST})
 		}
 
 		if chdr.Type == int32(cb.HeaderType_CONFIG_UPDATE) {
@@ -112,24 +165,28 @@ func (bh *handlerImpl) Handle(srv ab.AtomicBroadcast_BroadcastServer) error {
 				if logger.IsEnabledFor(logging.WARNING) {
 					logger.Warningf("Rejecting CONFIG_UPDATE because: %s", err)
 				}
-				return srv.Send(&ab.BroadcastResponse{Status: cb.Status_BAD_REQUEST})
+				countRejection("bad_config_update")
+				return send(&ab.BroadcastResponse{Status: cb.Status_BAD_REQUEST})
 			}
 
 			err = proto.Unmarshal(msg.Payload, payload)
 			if err != nil || payload.Header == nil {
 				logger.Criticalf("Generated bad transaction after CONFIG_UPDATE processing")
-				return srv.Send(&ab.BroadcastResponse{Status: cb.Status_INTERNAL_SERVER_ERROR})
+				countRejection("internal_error")
+				return send(&ab.BroadcastResponse{Status: cb.Status_INTERNAL_SERVER_ERROR})
 			}
 
 			chdr, err = utils.UnmarshalChannelHeader(payload.Header.ChannelHeader)
 			if err != nil {
 				logger.Criticalf("Generated bad transaction after CONFIG_UPDATE processing (bad channel header): %s", err)
-				return srv.Send(&ab.BroadcastResponse{Status: cb.Status_INTERNAL_SERVER_ERROR})
+				countRejection("internal_error")
+				return send(&ab.BroadcastResponse{Status: cb.Status_INTERNAL_SERVER_ERROR})
 			}
 
 			if chdr.ChannelId == "" {
 				logger.Criticalf("Generated bad transaction after CONFIG_UPDATE processing (empty channel ID)")
-				return srv.Send(&ab.BroadcastResponse{Status: cb.Status_INTERNAL_SERVER_ERROR})
+				countRejection("internal_error")
+				return send(&ab.BroadcastResponse{Status: cb.Status_INTERNAL_SERVER_ERROR})
 			}
 		}
 
@@ -138,7 +195,8 @@ func (bh *handlerImpl) Handle(srv ab.AtomicBroadcast_BroadcastServer) error {
 			if logger.IsEnabledFor(logging.WARNING) {
 				logger.Warningf("Rejecting broadcast because channel %s was not found", chdr.ChannelId)
 			}
-			return srv.Send(&ab.BroadcastResponse{Status: cb.Status_NOT_FOUND})
+			countRejection("not_found")
+			return send(&ab.BroadcastResponse{Status: cb.Status_NOT_FOUND})
 		}
 
 		if logger.IsEnabledFor(logging.DEBUG) {
@@ -148,26 +206,213 @@ func (bh *handlerImpl) Handle(srv ab.AtomicBroadcast_BroadcastServer) error {
 		// Normal transaction for existing chain
 		_, filterErr := support.Filters().Apply(msg)
 
+		if throttled, ok := filterErr.(*filter.ThrottledError); ok {
+			if logger.IsEnabledFor(logging.DEBUG) {
+				logger.Debugf("Throttling broadcast message for channel %s: %s", chdr.ChannelId, throttled)
+			}
+			countRejection("throttled")
+			// The client may resubmit the message on the same stream
+			err = send(&ab.BroadcastResponse{Status: cb.Status_SERVICE_UNAVAILABLE, Info: throttled.Error()})
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		if tooLarge, ok := filterErr.(*filter.TooLargeError); ok {
+			if logger.IsEnabledFor(logging.WARNING) {
+				logger.Warningf("Rejecting broadcast message for channel %s: %s", chdr.ChannelId, tooLarge)
+			}
+			countRejection("too_large")
+			return send(&ab.BroadcastResponse{Status: cb.Status_REQUEST_ENTITY_TOO_LARGE, Info: tooLarge.Error()})
+		}
+
+		if maintenance, ok := filterErr.(*filter.MaintenanceError); ok {
+			if logger.IsEnabledFor(logging.WARNING) {
+				logger.Warningf("Rejecting broadcast message for channel %s: %s", chdr.ChannelId, maintenance)
+			}
+			countRejection("maintenance")
+			return send(&ab.BroadcastResponse{Status: cb.Status_SERVICE_UNAVAILABLE, Info: maintenance.Error()})
+		}
+
+		if sealed, ok := filterErr.(*filter.SealedError); ok {
+			if logger.IsEnabledFor(logging.WARNING) {
+				logger.Warningf("Rejecting broadcast message for channel %s: %s", chdr.ChannelId, sealed)
+			}
+			countRejection("sealed")
+			return send(&ab.BroadcastResponse{Status: cb.Status_FORBIDDEN, Info: sealed.Error()})
+		}
+
+		if duplicate, ok := filterErr.(*filter.DuplicateError); ok {
+			if logger.IsEnabledFor(logging.WARNING) {
+				logger.Warningf("Rejecting broadcast message for channel %s: %s", chdr.ChannelId, duplicate)
+			}
+			countRejection("duplicate")
+			// The message was already accepted, the client may go on with its next messages on the same stream
+			err = send(&ab.BroadcastResponse{Status: cb.Status_CONFLICT, Info: duplicate.Error()})
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
 		if filterErr != nil {
 			if logger.IsEnabledFor(logging.WARNING) {
 				logger.Warningf("Rejecting broadcast message because of filter error: %s", filterErr)
 			}
-			return srv.Send(&ab.BroadcastResponse{Status: cb.Status_BAD_REQUEST})
+			countRejection("filtered")
+			return send(&ab.BroadcastResponse{Status: cb.Status_BAD_REQUEST})
+		}
+
+		// The envelope can only be written into blocks following the current ones
+		height := support.Reader().Height()
+		var it ledger.Iterator
+		if ackCommit {
+			var start uint64
+			it, start = support.Reader().Iterator(&ab.SeekPosition{Type: &ab.SeekPosition_Specified{Specified: &ab.SeekSpecified{Number: height}}})
+			if start != height {
+				logger.Warningf("Error seeking block %d to wait for the commit of a broadcast message, got block %d", height, start)
+				countRejection("internal_error")
+				return send(&ab.BroadcastResponse{Status: cb.Status_INTERNAL_SERVER_ERROR})
+			}
 		}
 
 		if !support.Enqueue(msg) {
-			logger.Infof("Consenter instructed us to shut down")
-			return srv.Send(&ab.BroadcastResponse{Status: cb.Status_SERVICE_UNAVAILABLE})
+			logger.Infof("Chain %s did not accept the message, it may be shutting down or ordering the same transaction", chdr.ChannelId)
+			countRejection("unavailable")
+			return send(&ab.BroadcastResponse{Status: cb.Status_SERVICE_UNAVAILABLE})
 		}
 
 		if logger.IsEnabledFor(logging.DEBUG) {
 			logger.Debugf("Broadcast has successfully enqueued message of type %d for chain %s", chdr.Type, chdr.ChannelId)
 		}
 
-		err = srv.Send(&ab.BroadcastResponse{Status: cb.Status_SUCCESS})
+		if ackCommit {
+			timeout := time.After(bh.commitTimeout)
+			err = acks.enqueue(func() (*ab.BroadcastResponse, error) {
+				return awaitCommit(srv, it, msg, height, timeout)
+			})
+		} else {
+			err = send(&ab.BroadcastResponse{Status: cb.Status_SUCCESS})
+		}
 
 		if err != nil {
 			return err
 		}
 	}
 }
+
+// pendingAcks bounds the responses a stream acknowledging commits may have queued,
+// the handler stops receiving messages while the queue is full
+const pendingAcks = 1000
+
+// responder sends the responses of a stream acknowledging commits in the order of its messages
+type responder struct {
+	srv    ab.AtomicBroadcast_BroadcastServer
+	queue  chan func() (*ab.BroadcastResponse, error)
+	done   chan struct{}
+	failed chan struct{}
+	err    error
+}
+
+func newResponder(srv ab.AtomicBroadcast_BroadcastServer) *responder {
+	r := &responder{
+		srv:    srv,
+		queue:  make(chan func() (*ab.BroadcastResponse, error), pendingAcks),
+		done:   make(chan struct{}),
+		failed: make(chan struct{}),
+	}
+	go r.run()
+	return r
+}
+
+func (r *responder) run() {
+	defer close(r.done)
+	for next := range r.queue {
+		if r.err != nil {
+			// the stream is broken, the remaining responses are dropped
+			continue
+		}
+		response, err := next()
+		if err == nil {
+			err = r.srv.Send(response)
+		}
+		if err != nil {
+			r.err = err
+			close(r.failed)
+		}
+	}
+}
+
+// enqueue queues a response, which is computed by next once the preceding responses
+// were sent, it returns the error of the stream once a response could not be sent
+func (r *responder) enqueue(next func() (*ab.BroadcastResponse, error)) error {
+	select {
+	case r.queue <- next:
+		return nil
+	case <-r.failed:
+		return r.err
+	}
+}
+
+// send queues a response which is already known
+func (r *responder) send(response *ab.BroadcastResponse) error {
+	return r.enqueue(func() (*ab.BroadcastResponse, error) {
+		return response, nil
+	})
+}
+
+// close waits for the queued responses to be sent
+func (r *responder) close() error {
+	close(r.queue)
+	<-r.done
+	return r.err
+}
+
+// awaitCommit waits for the envelope to be written into a block read from the iterator, which starts
+// with block number height, until the timeout fires
+func awaitCommit(srv ab.AtomicBroadcast_BroadcastServer, it ledger.Iterator, msg *cb.Envelope, height uint64, timeout <-chan time.Time) (*ab.BroadcastResponse, error) {
+	for {
+		select {
+		case <-it.ReadyChan():
+			block, status := it.Next()
+			if status != cb.Status_SUCCESS {
+				logger.Warningf("Error reading block %d while waiting for the commit of a broadcast message: %s", height, status)
+				return &ab.BroadcastResponse{Status: cb.Status_INTERNAL_SERVER_ERROR}, nil
+			}
+			height++
+			if index, ok := envelopeIndex(block, msg); ok {
+				if logger.IsEnabledFor(logging.DEBUG) {
+					logger.Debugf("Broadcast message was written into block %d at index %d", block.Header.Number, index)
+				}
+				return &ab.BroadcastResponse{Status: cb.Status_SUCCESS, BlockNumber: block.Header.Number, TxIndex: index}, nil
+			}
+		case <-timeout:
+			// The message may still be ordered, or it may have been rejected by the filters of the consenter
+			logger.Warningf("Broadcast message was not written into block %d or later in time", height)
+			return &ab.BroadcastResponse{
+				Status: cb.Status_SERVICE_UNAVAILABLE,
+				Info:   "Message was enqueued but not written into a block in time",
+			}, nil
+		case <-srv.Context().Done():
+			return nil, srv.Context().Err()
+		}
+	}
+}
+
+// envelopeIndex returns the index of the envelope in the data of the block, if it is present
+func envelopeIndex(block *cb.Block, msg *cb.Envelope) (uint64, bool) {
+	if block.Data == nil {
+		return 0, false
+	}
+	for i, data := range block.Data.Data {
+		env, err := utils.UnmarshalEnvelope(data)
+		if err != nil {
+			continue
+		}
+		if bytes.Equal(env.Payload, msg.Payload) && bytes.Equal(env.Signature, msg.Signature) {
+			return uint64(i), true
+		}
+	}
+	return 0, false
+}
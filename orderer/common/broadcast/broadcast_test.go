@@ -22,13 +22,17 @@ import (
 	"time"
 
 	"github.com/hyperledger/fabric/orderer/common/filter"
+	"github.com/hyperledger/fabric/orderer/ledger"
+	ramledger "github.com/hyperledger/fabric/orderer/ledger/ram"
 	cb "github.com/hyperledger/fabric/protos/common"
 	ab "github.com/hyperledger/fabric/protos/orderer"
 	"github.com/hyperledger/fabric/protos/utils"
 
 	logging "github.com/op/go-logging"
 	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
 )
 
 func init() {
@@ -39,17 +43,29 @@ var systemChain = "systemChain"
 
 type mockB struct {
 	grpc.ServerStream
+	ctx      context.Context
 	recvChan chan *cb.Envelope
 	sendChan chan *ab.BroadcastResponse
 }
 
 func newMockB() *mockB {
 	return &mockB{
+		ctx:      context.Background(),
 		recvChan: make(chan *cb.Envelope),
 		sendChan: make(chan *ab.BroadcastResponse),
 	}
 }
 
+func newMockBAckCommit() *mockB {
+	m := newMockB()
+	m.ctx = metadata.NewContext(m.ctx, metadata.Pairs(AckModeKey, AckModeCommit))
+	return m
+}
+
+func (m *mockB) Context() context.Context {
+	return m.ctx
+}
+
 func (m *mockB) Send(br *ab.BroadcastResponse) error {
 	m.sendChan <- br
 	return nil
@@ -83,14 +99,30 @@ func (mm *mockSupportManager) Process(configTx *cb.Envelope) (*cb.Envelope, erro
 type mockSupport struct {
 	filters       *filter.RuleSet
 	rejectEnqueue bool
+	ledger        ledger.ReadWriter
+	// writeEnqueued, if set, writes enqueued messages into a block following a block of other messages
+	writeEnqueued bool
+	// enqueued, if set, receives the enqueued messages
+	enqueued chan *cb.Envelope
 }
 
 func (ms *mockSupport) Filters() *filter.RuleSet {
 	return ms.filters
 }
 
+func (ms *mockSupport) Reader() ledger.Reader {
+	return ms.ledger
+}
+
 // Enqueue sends a message for ordering
 func (ms *mockSupport) Enqueue(env *cb.Envelope) bool {
+	if ms.writeEnqueued {
+		ms.ledger.Append(ledger.CreateNextBlock(ms.ledger, []*cb.Envelope{makeMessage(systemChain, []byte("Other bytes"))}))
+		ms.ledger.Append(ledger.CreateNextBlock(ms.ledger, []*cb.Envelope{makeMessage(systemChain, []byte("Other bytes")), env}))
+	}
+	if ms.enqueued != nil {
+		ms.enqueued <- env
+	}
 	return !ms.rejectEnqueue
 }
 
@@ -131,8 +163,10 @@ func getMockSupportManager() (*mockSupportManager, *mockSupport) {
 	mm := &mockSupportManager{
 		chains: make(map[string]*mockSupport),
 	}
+	rl, _ := ramledger.New(10).GetOrCreate(systemChain)
 	mSysChain := &mockSupport{
 		filters: filters,
+		ledger:  rl,
 	}
 	mm.chains[string(systemChain)] = mSysChain
 	return mm, mSysChain
@@ -140,7 +174,7 @@ func getMockSupportManager() (*mockSupportManager, *mockSupport) {
 
 func TestEnqueueFailure(t *testing.T) {
 	mm, mSysChain := getMockSupportManager()
-	bh := NewHandlerImpl(mm)
+	bh := NewHandlerImpl(mm, time.Second)
 	m := newMockB()
 	defer close(m.recvChan)
 	done := make(chan struct{})
@@ -173,7 +207,7 @@ func TestEnqueueFailure(t *testing.T) {
 
 func TestEmptyEnvelope(t *testing.T) {
 	mm, _ := getMockSupportManager()
-	bh := NewHandlerImpl(mm)
+	bh := NewHandlerImpl(mm, time.Second)
 	m := newMockB()
 	defer close(m.recvChan)
 	done := make(chan struct{})
@@ -197,7 +231,7 @@ func TestEmptyEnvelope(t *testing.T) {
 
 func TestBadChannelId(t *testing.T) {
 	mm, _ := getMockSupportManager()
-	bh := NewHandlerImpl(mm)
+	bh := NewHandlerImpl(mm, time.Second)
 	m := newMockB()
 	defer close(m.recvChan)
 	done := make(chan struct{})
@@ -222,7 +256,7 @@ func TestBadChannelId(t *testing.T) {
 func TestGoodConfigUpdate(t *testing.T) {
 	mm, _ := getMockSupportManager()
 	mm.ProcessVal = &cb.Envelope{Payload: utils.MarshalOrPanic(&cb.Payload{Header: &cb.Header{ChannelHeader: utils.MarshalOrPanic(&cb.ChannelHeader{ChannelId: systemChain})}})}
-	bh := NewHandlerImpl(mm)
+	bh := NewHandlerImpl(mm, time.Second)
 	m := newMockB()
 	defer close(m.recvChan)
 	go bh.Handle(m)
@@ -235,7 +269,7 @@ func TestGoodConfigUpdate(t *testing.T) {
 
 func TestBadConfigUpdate(t *testing.T) {
 	mm, _ := getMockSupportManager()
-	bh := NewHandlerImpl(mm)
+	bh := NewHandlerImpl(mm, time.Second)
 	m := newMockB()
 	defer close(m.recvChan)
 	go bh.Handle(m)
@@ -244,3 +278,198 @@ func TestBadConfigUpdate(t *testing.T) {
 	reply := <-m.sendChan
 	assert.NotEqual(t, cb.Status_SUCCESS, reply.Status, "Should have rejected CONFIG_UPDATE")
 }
+
+type throttleRule struct{}
+
+func (r throttleRule) Apply(message *cb.Envelope) (filter.Action, filter.Committer) {
+	return filter.Reject, nil
+}
+
+func (r throttleRule) RetryAfter(message *cb.Envelope) time.Duration {
+	return time.Second
+}
+
+func TestThrottled(t *testing.T) {
+	mm, mSysChain := getMockSupportManager()
+	bh := NewHandlerImpl(mm, time.Second)
+	m := newMockB()
+	defer close(m.recvChan)
+	go bh.Handle(m)
+
+	throttledFilters := filter.NewRuleSet([]filter.Rule{throttleRule{}, filter.AcceptRule})
+	acceptFilters := mSysChain.filters
+
+	mSysChain.filters = throttledFilters
+	m.recvChan <- makeMessage(systemChain, []byte("Some bytes"))
+	reply := <-m.sendChan
+	assert.Equal(t, cb.Status_SERVICE_UNAVAILABLE, reply.Status, "Should have throttled the message")
+	assert.Contains(t, reply.Info, "retry after 1s", "Should have hinted when to retry")
+
+	mSysChain.filters = acceptFilters
+	m.recvChan <- makeMessage(systemChain, []byte("Some bytes"))
+	reply = <-m.sendChan
+	assert.Equal(t, cb.Status_SUCCESS, reply.Status, "Should have accepted the resubmitted message on the same stream")
+}
+
+type maxBytesRule struct{}
+
+func (r maxBytesRule) Apply(message *cb.Envelope) (filter.Action, filter.Committer) {
+	return filter.Reject, nil
+}
+
+func (r maxBytesRule) MaxBytes() uint32 {
+	return 1
+}
+
+func TestTooLarge(t *testing.T) {
+	mm, mSysChain := getMockSupportManager()
+	bh := NewHandlerImpl(mm, time.Second)
+	m := newMockB()
+	defer close(m.recvChan)
+	go bh.Handle(m)
+
+	mSysChain.filters = filter.NewRuleSet([]filter.Rule{maxBytesRule{}, filter.AcceptRule})
+	m.recvChan <- makeMessage(systemChain, []byte("Some bytes"))
+	reply := <-m.sendChan
+	assert.Equal(t, cb.Status_REQUEST_ENTITY_TOO_LARGE, reply.Status, "Should have rejected the message as too large")
+	assert.Contains(t, reply.Info, "maximum of 1 bytes", "Should have reported the maximum size")
+}
+
+type sealedRule struct{}
+
+func (r sealedRule) Apply(message *cb.Envelope) (filter.Action, filter.Committer) {
+	return filter.Reject, nil
+}
+
+func (r sealedRule) Sealed() bool {
+	return true
+}
+
+func TestSealed(t *testing.T) {
+	mm, mSysChain := getMockSupportManager()
+	bh := NewHandlerImpl(mm, time.Second)
+	m := newMockB()
+	defer close(m.recvChan)
+	go bh.Handle(m)
+
+	mSysChain.filters = filter.NewRuleSet([]filter.Rule{sealedRule{}, filter.AcceptRule})
+	m.recvChan <- makeMessage(systemChain, []byte("Some bytes"))
+	reply := <-m.sendChan
+	assert.Equal(t, cb.Status_FORBIDDEN, reply.Status, "Should have rejected the message of the sealed channel")
+	assert.Contains(t, reply.Info, "sealed", "Should have reported the channel as sealed")
+}
+
+type maintenanceRule struct{}
+
+func (r maintenanceRule) Apply(message *cb.Envelope) (filter.Action, filter.Committer) {
+	return filter.Reject, nil
+}
+
+func (r maintenanceRule) InMaintenance() bool {
+	return true
+}
+
+func TestInMaintenance(t *testing.T) {
+	mm, mSysChain := getMockSupportManager()
+	bh := NewHandlerImpl(mm, time.Second)
+	m := newMockB()
+	defer close(m.recvChan)
+	go bh.Handle(m)
+
+	mSysChain.filters = filter.NewRuleSet([]filter.Rule{maintenanceRule{}, filter.AcceptRule})
+	m.recvChan <- makeMessage(systemChain, []byte("Some bytes"))
+	reply := <-m.sendChan
+	assert.Equal(t, cb.Status_SERVICE_UNAVAILABLE, reply.Status, "Should have rejected the message of the channel in maintenance mode")
+	assert.Contains(t, reply.Info, "maintenance", "Should have reported the channel as in maintenance mode")
+}
+
+type duplicateRule struct{}
+
+func (r duplicateRule) Apply(message *cb.Envelope) (filter.Action, filter.Committer) {
+	return filter.Reject, nil
+}
+
+func (r duplicateRule) TxID(message *cb.Envelope) string {
+	return "txID"
+}
+
+func TestDuplicate(t *testing.T) {
+	mm, mSysChain := getMockSupportManager()
+	bh := NewHandlerImpl(mm, time.Second)
+	m := newMockB()
+	defer close(m.recvChan)
+	go bh.Handle(m)
+
+	acceptFilters := mSysChain.filters
+
+	mSysChain.filters = filter.NewRuleSet([]filter.Rule{duplicateRule{}, filter.AcceptRule})
+	m.recvChan <- makeMessage(systemChain, []byte("Some bytes"))
+	reply := <-m.sendChan
+	assert.Equal(t, cb.Status_CONFLICT, reply.Status, "Should have rejected the duplicate message")
+	assert.Contains(t, reply.Info, "txID", "Should have reported the transaction ID")
+
+	mSysChain.filters = acceptFilters
+	m.recvChan <- makeMessage(systemChain, []byte("Some more bytes"))
+	reply = <-m.sendChan
+	assert.Equal(t, cb.Status_SUCCESS, reply.Status, "Should have accepted the next message on the same stream")
+}
+
+func TestAckCommit(t *testing.T) {
+	mm, mSysChain := getMockSupportManager()
+	mSysChain.writeEnqueued = true
+	bh := NewHandlerImpl(mm, time.Second)
+	m := newMockBAckCommit()
+	defer close(m.recvChan)
+	go bh.Handle(m)
+
+	m.recvChan <- makeMessage(systemChain, []byte("Some bytes"))
+	reply := <-m.sendChan
+	assert.Equal(t, cb.Status_SUCCESS, reply.Status, "Should have acknowledged the commit of the message")
+	assert.Equal(t, uint64(1), reply.BlockNumber, "Should have been written into the second block")
+	assert.Equal(t, uint64(1), reply.TxIndex, "Should have been written at the second index")
+
+	m.recvChan <- makeMessage(systemChain, []byte("Some more bytes"))
+	reply = <-m.sendChan
+	assert.Equal(t, cb.Status_SUCCESS, reply.Status, "Should have acknowledged the commit of the message")
+	assert.Equal(t, uint64(3), reply.BlockNumber, "Should have been written into the fourth block")
+}
+
+func TestAckCommitTimeout(t *testing.T) {
+	mm, _ := getMockSupportManager()
+	bh := NewHandlerImpl(mm, 10*time.Millisecond)
+	m := newMockBAckCommit()
+	defer close(m.recvChan)
+	go bh.Handle(m)
+
+	m.recvChan <- makeMessage(systemChain, []byte("Some bytes"))
+	reply := <-m.sendChan
+	assert.Equal(t, cb.Status_SERVICE_UNAVAILABLE, reply.Status, "Should have given up waiting for the commit of the message")
+}
+
+func TestAckCommitKeepsReceiving(t *testing.T) {
+	mm, mSysChain := getMockSupportManager()
+	mSysChain.enqueued = make(chan *cb.Envelope, 2)
+	bh := NewHandlerImpl(mm, time.Second)
+	m := newMockBAckCommit()
+	defer close(m.recvChan)
+	go bh.Handle(m)
+
+	first := makeMessage(systemChain, []byte("Some bytes"))
+	second := makeMessage(systemChain, []byte("Some more bytes"))
+	m.recvChan <- first
+	select {
+	case m.recvChan <- second:
+	case <-time.After(time.Second):
+		t.Fatal("Should have received the next message while waiting for the commit of the first")
+	}
+	<-mSysChain.enqueued
+	<-mSysChain.enqueued
+
+	mSysChain.ledger.Append(ledger.CreateNextBlock(mSysChain.ledger, []*cb.Envelope{first, second}))
+	reply := <-m.sendChan
+	assert.Equal(t, cb.Status_SUCCESS, reply.Status, "Should have acknowledged the commit of the first message")
+	assert.Equal(t, uint64(0), reply.TxIndex, "Should have acknowledged the first message first")
+	reply = <-m.sendChan
+	assert.Equal(t, cb.Status_SUCCESS, reply.Status, "Should have acknowledged the commit of the second message")
+	assert.Equal(t, uint64(1), reply.TxIndex, "Should have acknowledged the second message second")
+}
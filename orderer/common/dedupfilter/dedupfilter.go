@@ -0,0 +1,201 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+                 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dedupfilter
+
+import (
+	"sync"
+
+	"github.com/hyperledger/fabric/orderer/common/filter"
+	"github.com/hyperledger/fabric/orderer/ledger"
+	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/utils"
+	logging "github.com/op/go-logging"
+)
+
+var logger = logging.MustGetLogger("orderer/common/dedupfilter")
+
+// Window holds the transaction IDs of the messages of a chain most recently accepted for
+// ordering or written to the ledger, once full, the oldest transaction ID is dropped for
+// each one added
+type Window struct {
+	size  int
+	txIDs map[string]struct{}
+	order []string
+	next  int
+	mutex sync.RWMutex
+}
+
+// NewWindow creates an empty window of at most size transaction IDs, a window of size 0
+// holds no transaction IDs
+func NewWindow(size int) *Window {
+	return &Window{
+		size:  size,
+		txIDs: make(map[string]struct{}),
+		order: make([]string, 0, size),
+	}
+}
+
+// Load replaces the transaction IDs of the window with those of the last blocks of the chain
+func (w *Window) Load(reader ledger.Reader) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	w.txIDs = make(map[string]struct{})
+	w.order = make([]string, 0, w.size)
+	w.next = 0
+	if w.size == 0 || reader.Height() == 0 {
+		return
+	}
+
+	// Collect the blocks backwards until they hold enough transaction IDs, the blocks which are
+	// no longer available, such as those dropped by the RAM ledger, end the collection
+	var blocks []*cb.Block
+	count := 0
+	for number := reader.Height(); number > 0 && count < w.size; number-- {
+		block := ledger.GetBlock(reader, number-1)
+		if block == nil {
+			break
+		}
+		blocks = append(blocks, block)
+		if block.Data != nil {
+			count += len(block.Data.Data)
+		}
+	}
+	for i := len(blocks) - 1; i >= 0; i-- {
+		w.addBlock(blocks[i])
+	}
+	logger.Debugf("Loaded %d transaction IDs from the last %d blocks", len(w.txIDs), len(blocks))
+}
+
+// AddBlock adds the transaction IDs of the messages of the block to the window
+func (w *Window) AddBlock(block *cb.Block) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	w.addBlock(block)
+}
+
+// AddIfAbsent adds the transaction ID of a message accepted for ordering to the window, so that
+// the message is not accepted again before it is written. It returns false if the window already
+// contains the transaction ID, so that of concurrent callers only one reserves it. Messages without
+// a transaction ID are not reserved.
+func (w *Window) AddIfAbsent(message *cb.Envelope) bool {
+	txID := TxID(message)
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	if _, ok := w.txIDs[txID]; ok {
+		return false
+	}
+	w.add(txID)
+	return true
+}
+
+// Remove drops the transaction ID of a message from the window, releasing the reservation of
+// a message which was not accepted for ordering after all
+func (w *Window) Remove(message *cb.Envelope) {
+	txID := TxID(message)
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	if _, ok := w.txIDs[txID]; !ok {
+		return
+	}
+	delete(w.txIDs, txID)
+
+	// Keep the remaining transaction IDs from the oldest to the newest, so that they are
+	// still dropped in the order they were added
+	order := make([]string, 0, w.size)
+	for i := range w.order {
+		if id := w.order[(w.next+i)%len(w.order)]; id != txID {
+			order = append(order, id)
+		}
+	}
+	w.order = order
+	w.next = 0
+}
+
+// Contains returns whether the transaction ID is in the window
+func (w *Window) Contains(txID string) bool {
+	w.mutex.RLock()
+	defer w.mutex.RUnlock()
+	_, ok := w.txIDs[txID]
+	return ok
+}
+
+func (w *Window) addBlock(block *cb.Block) {
+	if w.size == 0 || block.Data == nil {
+		return
+	}
+	for _, data := range block.Data.Data {
+		env, err := utils.UnmarshalEnvelope(data)
+		if err != nil {
+			continue
+		}
+		w.add(TxID(env))
+	}
+}
+
+func (w *Window) add(txID string) {
+	if w.size == 0 || txID == "" {
+		return
+	}
+	if _, ok := w.txIDs[txID]; ok {
+		return
+	}
+	if len(w.order) < w.size {
+		w.order = append(w.order, txID)
+	} else {
+		delete(w.txIDs, w.order[w.next])
+		w.order[w.next] = txID
+		w.next = (w.next + 1) % w.size
+	}
+	w.txIDs[txID] = struct{}{}
+}
+
+// TxID returns the transaction ID of the message, or the empty string if it has none
+func TxID(message *cb.Envelope) string {
+	payload, err := utils.UnmarshalPayload(message.Payload)
+	if err != nil || payload.Header == nil {
+		return ""
+	}
+	chdr, err := utils.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+	if err != nil {
+		return ""
+	}
+	return chdr.TxId
+}
+
+// New creates a rule which rejects the messages whose transaction ID is in the window,
+// the messages without a transaction ID are forwarded
+func New(window *Window) filter.Rule {
+	return &dedupRule{window: window}
+}
+
+type dedupRule struct {
+	window *Window
+}
+
+// TxID returns the transaction ID of the message, it implements filter.Deduplicator
+func (r *dedupRule) TxID(message *cb.Envelope) string {
+	return TxID(message)
+}
+
+func (r *dedupRule) Apply(message *cb.Envelope) (filter.Action, filter.Committer) {
+	txID := TxID(message)
+	if txID != "" && r.window.Contains(txID) {
+		logger.Debugf("Rejecting message with transaction ID %s which was already accepted", txID)
+		return filter.Reject, nil
+	}
+	return filter.Forward, nil
+}
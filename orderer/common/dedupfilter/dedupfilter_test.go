@@ -0,0 +1,184 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+                 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dedupfilter
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/hyperledger/fabric/orderer/common/filter"
+	"github.com/hyperledger/fabric/orderer/ledger"
+	ramledger "github.com/hyperledger/fabric/orderer/ledger/ram"
+	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/utils"
+)
+
+func makeEnvelope(txID string) *cb.Envelope {
+	chdr := utils.MakeChannelHeader(cb.HeaderType_ENDORSER_TRANSACTION, 0, "foo", 0)
+	chdr.TxId = txID
+	return &cb.Envelope{Payload: utils.MarshalOrPanic(&cb.Payload{
+		Header: &cb.Header{ChannelHeader: utils.MarshalOrPanic(chdr)},
+		Data:   []byte(txID),
+	})}
+}
+
+func makeEnvelopes(txIDs ...string) []*cb.Envelope {
+	envs := make([]*cb.Envelope, len(txIDs))
+	for i, txID := range txIDs {
+		envs[i] = makeEnvelope(txID)
+	}
+	return envs
+}
+
+func makeBlock(txIDs ...string) *cb.Block {
+	block := cb.NewBlock(0, nil)
+	for _, env := range makeEnvelopes(txIDs...) {
+		block.Data.Data = append(block.Data.Data, utils.MarshalOrPanic(env))
+	}
+	return block
+}
+
+func TestWindowEviction(t *testing.T) {
+	w := NewWindow(2)
+	w.AddBlock(makeBlock("a", "b"))
+	if !w.Contains("a") || !w.Contains("b") {
+		t.Fatalf("Window should contain a and b")
+	}
+
+	w.AddBlock(makeBlock("b", "c"))
+	if w.Contains("a") {
+		t.Fatalf("Window should have dropped the oldest transaction ID")
+	}
+	if !w.Contains("b") || !w.Contains("c") {
+		t.Fatalf("Window should contain b and c")
+	}
+}
+
+func TestWindowAdd(t *testing.T) {
+	w := NewWindow(2)
+	if !w.AddIfAbsent(makeEnvelope("a")) {
+		t.Fatalf("Window should have added the new transaction ID")
+	}
+	if !w.Contains("a") {
+		t.Fatalf("Window should contain the accepted transaction ID")
+	}
+	if w.AddIfAbsent(makeEnvelope("a")) {
+		t.Fatalf("Window should not add the transaction ID twice")
+	}
+	if !w.AddIfAbsent(&cb.Envelope{Payload: []byte("Some bytes")}) {
+		t.Fatalf("Window should accept the message without a transaction ID")
+	}
+
+	// Once written, the accepted transaction ID is not added again
+	w.AddBlock(makeBlock("a", "b"))
+	if !w.Contains("a") || !w.Contains("b") {
+		t.Fatalf("Window should contain a and b")
+	}
+}
+
+func TestWindowAddIfAbsentConcurrently(t *testing.T) {
+	w := NewWindow(10)
+	var added int32
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if w.AddIfAbsent(makeEnvelope("a")) {
+				atomic.AddInt32(&added, 1)
+			}
+		}()
+	}
+	wg.Wait()
+	if added != 1 {
+		t.Fatalf("Transaction ID should have been added once, got %d", added)
+	}
+}
+
+func TestWindowRemove(t *testing.T) {
+	w := NewWindow(3)
+	w.AddBlock(makeBlock("a", "b", "c"))
+	w.Remove(makeEnvelope("b"))
+	if w.Contains("b") {
+		t.Fatalf("Window should not contain the removed transaction ID")
+	}
+
+	// The remaining transaction IDs are still dropped from the oldest
+	w.AddBlock(makeBlock("d", "e"))
+	if w.Contains("a") {
+		t.Fatalf("Window should have dropped the oldest transaction ID")
+	}
+	for _, txID := range []string{"c", "d", "e"} {
+		if !w.Contains(txID) {
+			t.Fatalf("Window should contain %s", txID)
+		}
+	}
+
+	if !w.AddIfAbsent(makeEnvelope("b")) {
+		t.Fatalf("Window should add the removed transaction ID again")
+	}
+}
+
+func TestWindowDisabled(t *testing.T) {
+	w := NewWindow(0)
+	w.AddBlock(makeBlock("a"))
+	w.AddIfAbsent(makeEnvelope("a"))
+	if w.Contains("a") {
+		t.Fatalf("Window of size 0 should not contain any transaction ID")
+	}
+}
+
+func TestWindowLoad(t *testing.T) {
+	rl, _ := ramledger.New(10).GetOrCreate("foo")
+	for i := 0; i < 4; i++ {
+		rl.Append(ledger.CreateNextBlock(rl, makeEnvelopes(fmt.Sprintf("tx%da", i), fmt.Sprintf("tx%db", i))))
+	}
+
+	w := NewWindow(3)
+	w.Load(rl)
+	for _, txID := range []string{"tx2b", "tx3a", "tx3b"} {
+		if !w.Contains(txID) {
+			t.Fatalf("Window should contain %s of the last blocks", txID)
+		}
+	}
+	for _, txID := range []string{"tx0a", "tx1b", "tx2a"} {
+		if w.Contains(txID) {
+			t.Fatalf("Window should not contain %s of the earlier blocks", txID)
+		}
+	}
+}
+
+func TestDedupRule(t *testing.T) {
+	w := NewWindow(10)
+	rs := filter.NewRuleSet([]filter.Rule{New(w), filter.AcceptRule})
+
+	if _, err := rs.Apply(makeEnvelope("a")); err != nil {
+		t.Fatalf("Should have accepted the message which was not ordered, got %s", err)
+	}
+
+	w.AddBlock(makeBlock("a"))
+	_, err := rs.Apply(makeEnvelope("a"))
+	if duplicate, ok := err.(*filter.DuplicateError); !ok || duplicate.TxID != "a" {
+		t.Fatalf("Should have rejected the message which was already ordered, got %v", err)
+	}
+
+	if _, err := rs.Apply(&cb.Envelope{Payload: []byte("Some bytes")}); err != nil {
+		t.Fatalf("Should have accepted the message without a transaction ID, got %s", err)
+	}
+}
@@ -17,6 +17,8 @@ limitations under the License.
 package deliver
 
 import (
+	"fmt"
+
 	"github.com/hyperledger/fabric/common/policies"
 	"github.com/hyperledger/fabric/orderer/common/filter"
 	"github.com/hyperledger/fabric/orderer/common/sigfilter"
@@ -24,6 +26,7 @@ import (
 	cb "github.com/hyperledger/fabric/protos/common"
 	ab "github.com/hyperledger/fabric/protos/orderer"
 	"github.com/op/go-logging"
+	"github.com/rcrowley/go-metrics"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/protos/utils"
@@ -31,6 +34,9 @@ import (
 
 var logger = logging.MustGetLogger("orderer/common/deliver")
 
+// streams counts the deliver streams currently open
+var streams = metrics.GetOrRegisterCounter("deliver.streams", nil)
+
 // Handler defines an interface which handles Deliver requests
 type Handler interface {
 	Handle(srv ab.AtomicBroadcast_DeliverServer) error
@@ -63,6 +69,8 @@ func NewHandlerImpl(sm SupportManager) Handler {
 
 func (ds *deliverServer) Handle(srv ab.AtomicBroadcast_DeliverServer) error {
 	logger.Debugf("Starting new deliver loop")
+	streams.Inc(1)
+	defer streams.Dec(1)
 	for {
 		logger.Debugf("Attempting to read seek info message")
 		envelope, err := srv.Recv()
@@ -127,6 +135,13 @@ func (ds *deliverServer) Handle(srv ab.AtomicBroadcast_DeliverServer) error {
 			return sendStatusReply(srv, cb.Status_BAD_REQUEST)
 		}
 
+		if _, ok := ab.SeekInfo_SeekContentType_name[int32(seekInfo.ContentType)]; !ok {
+			if logger.IsEnabledFor(logging.WARNING) {
+				logger.Warningf("Received seekInfo message with unknown content type %d", seekInfo.ContentType)
+			}
+			return sendStatusReply(srv, cb.Status_BAD_REQUEST)
+		}
+
 		if logger.IsEnabledFor(logging.DEBUG) {
 			logger.Debugf("Received seekInfo (%p)  %v for chain %s", seekInfo, seekInfo, chdr.ChannelId)
 		}
@@ -162,7 +177,7 @@ func (ds *deliverServer) Handle(srv ab.AtomicBroadcast_DeliverServer) error {
 			if logger.IsEnabledFor(logging.DEBUG) {
 				logger.Debugf("Delivering block for (%p) channel: %s", seekInfo, chdr.ChannelId)
 			}
-			if err := sendBlockReply(srv, block); err != nil {
+			if err := sendBlockReply(srv, chdr.ChannelId, seekInfo.ContentType, block); err != nil {
 				return err
 			}
 
@@ -187,8 +202,59 @@ func sendStatusReply(srv ab.AtomicBroadcast_DeliverServer, status cb.Status) err
 
 }
 
-func sendBlockReply(srv ab.AtomicBroadcast_DeliverServer, block *cb.Block) error {
+func sendBlockReply(srv ab.AtomicBroadcast_DeliverServer, chainID string, contentType ab.SeekInfo_SeekContentType, block *cb.Block) error {
+	switch contentType {
+	case ab.SeekInfo_HEADER_WITH_METADATA:
+		block = &cb.Block{Header: block.Header, Metadata: block.Metadata}
+	case ab.SeekInfo_FILTERED:
+		return srv.Send(&ab.DeliverResponse{
+			Type: &ab.DeliverResponse_FilteredBlock{FilteredBlock: filterBlock(chainID, block)},
+		})
+	}
 	return srv.Send(&ab.DeliverResponse{
 		Type: &ab.DeliverResponse_Block{Block: block},
 	})
 }
+
+// filterBlock summarizes the transactions of a block, transactions which cannot be
+// parsed are summarized without identifier to keep the indexes of the others
+func filterBlock(chainID string, block *cb.Block) *ab.FilteredBlock {
+	fb := &ab.FilteredBlock{ChannelId: chainID, Number: block.Header.Number}
+
+	var txFilter []byte
+	if block.Metadata != nil && len(block.Metadata.Metadata) > int(cb.BlockMetadataIndex_TRANSACTIONS_FILTER) {
+		txFilter = block.Metadata.Metadata[cb.BlockMetadataIndex_TRANSACTIONS_FILTER]
+	}
+
+	for i, data := range block.Data.Data {
+		ft := &ab.FilteredTransaction{}
+		if i < len(txFilter) {
+			ft.TxValidationCode, ft.Validated = uint32(txFilter[i]), true
+		}
+		fb.FilteredTransactions = append(fb.FilteredTransactions, ft)
+
+		chdr, err := channelHeader(data)
+		if err != nil {
+			logger.Warningf("Cannot summarize transaction %d of block %d: %s", i, block.Header.Number, err)
+			continue
+		}
+		ft.Txid = chdr.TxId
+		ft.Type = cb.HeaderType(chdr.Type)
+	}
+	return fb
+}
+
+func channelHeader(data []byte) (*cb.ChannelHeader, error) {
+	env, err := utils.UnmarshalEnvelope(data)
+	if err != nil {
+		return nil, err
+	}
+	payload, err := utils.UnmarshalPayload(env.Payload)
+	if err != nil {
+		return nil, err
+	}
+	if payload.Header == nil {
+		return nil, fmt.Errorf("Missing header")
+	}
+	return utils.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+}
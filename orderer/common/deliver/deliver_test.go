@@ -237,6 +237,112 @@ func TestSpecificSeek(t *testing.T) {
 	}
 }
 
+func makeTx(chainID string, txID string) *cb.Envelope {
+	return &cb.Envelope{
+		Payload: utils.MarshalOrPanic(&cb.Payload{
+			Header: &cb.Header{
+				ChannelHeader: utils.MarshalOrPanic(&cb.ChannelHeader{
+					Type:      int32(cb.HeaderType_ENDORSER_TRANSACTION),
+					ChannelId: chainID,
+					TxId:      txID,
+				}),
+			},
+		}),
+	}
+}
+
+func TestHeaderWithMetadataSeek(t *testing.T) {
+	mm := newMockMultichainManager()
+	l := mm.chains[systemChainID].ledger
+	l.Append(ledger.CreateNextBlock(l, []*cb.Envelope{makeTx(systemChainID, "tx1")}))
+
+	m := newMockD()
+	defer close(m.recvChan)
+	ds := NewHandlerImpl(mm)
+
+	go ds.Handle(m)
+
+	m.recvChan <- makeSeek(systemChainID, &ab.SeekInfo{Start: seekSpecified(1), Stop: seekSpecified(1), Behavior: ab.SeekInfo_BLOCK_UNTIL_READY, ContentType: ab.SeekInfo_HEADER_WITH_METADATA})
+
+	select {
+	case deliverReply := <-m.sendChan:
+		block := deliverReply.GetBlock()
+		if block == nil {
+			t.Fatalf("Expected a block, got %v", deliverReply)
+		}
+		if block.Header.Number != 1 || block.Metadata == nil {
+			t.Fatalf("Expected the header and metadata of block 1, got %v", block)
+		}
+		if block.Data != nil {
+			t.Fatalf("Expected the block without its data")
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("Timed out waiting for the block")
+	}
+}
+
+func TestFilteredSeek(t *testing.T) {
+	mm := newMockMultichainManager()
+	l := mm.chains[systemChainID].ledger
+	l.Append(ledger.CreateNextBlock(l, []*cb.Envelope{makeTx(systemChainID, "tx1"), &cb.Envelope{Payload: []byte("garbage")}, makeTx(systemChainID, "tx3")}))
+
+	m := newMockD()
+	defer close(m.recvChan)
+	ds := NewHandlerImpl(mm)
+
+	go ds.Handle(m)
+
+	m.recvChan <- makeSeek(systemChainID, &ab.SeekInfo{Start: seekSpecified(1), Stop: seekSpecified(1), Behavior: ab.SeekInfo_BLOCK_UNTIL_READY, ContentType: ab.SeekInfo_FILTERED})
+
+	select {
+	case deliverReply := <-m.sendChan:
+		fb := deliverReply.GetFilteredBlock()
+		if fb == nil {
+			t.Fatalf("Expected a filtered block, got %v", deliverReply)
+		}
+		if fb.Number != 1 || fb.ChannelId != systemChainID {
+			t.Fatalf("Expected a summary of block 1 of %s, got block %d of %s", systemChainID, fb.Number, fb.ChannelId)
+		}
+		if len(fb.FilteredTransactions) != 3 {
+			t.Fatalf("Expected 3 transactions, got %d", len(fb.FilteredTransactions))
+		}
+		for i, txID := range []string{"tx1", "", "tx3"} {
+			ft := fb.FilteredTransactions[i]
+			if ft.Txid != txID {
+				t.Errorf("Expected transaction %d to be %q, got %q", i, txID, ft.Txid)
+			}
+			if ft.Validated {
+				t.Errorf("Expected transaction %d not to be validated, got code %d", i, ft.TxValidationCode)
+			}
+		}
+		if fb.FilteredTransactions[0].Type != cb.HeaderType_ENDORSER_TRANSACTION {
+			t.Errorf("Expected an endorser transaction, got %s", fb.FilteredTransactions[0].Type)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("Timed out waiting for the filtered block")
+	}
+}
+
+func TestUnknownContentTypeSeek(t *testing.T) {
+	mm := newMockMultichainManager()
+	m := newMockD()
+	defer close(m.recvChan)
+	ds := NewHandlerImpl(mm)
+
+	go ds.Handle(m)
+
+	m.recvChan <- makeSeek(systemChainID, &ab.SeekInfo{Start: seekOldest, Stop: seekOldest, Behavior: ab.SeekInfo_BLOCK_UNTIL_READY, ContentType: 42})
+
+	select {
+	case deliverReply := <-m.sendChan:
+		if deliverReply.GetStatus() != cb.Status_BAD_REQUEST {
+			t.Fatalf("Received wrong error on the reply channel")
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("Timed out waiting to get all blocks")
+	}
+}
+
 func TestUnauthorizedSeek(t *testing.T) {
 	mm := newMockMultichainManager()
 	for i := 1; i < ledgerSize; i++ {
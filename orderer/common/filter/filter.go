@@ -18,6 +18,7 @@ package filter
 
 import (
 	"fmt"
+	"time"
 
 	ab "github.com/hyperledger/fabric/protos/common"
 )
@@ -41,6 +42,82 @@ type Rule interface {
 	Apply(message *ab.Envelope) (Action, Committer)
 }
 
+// Throttler is implemented by rules which reject messages only until the rate of messages drops
+type Throttler interface {
+	// RetryAfter returns how long the submitter of a rejected message should wait before resubmitting it
+	RetryAfter(message *ab.Envelope) time.Duration
+}
+
+// ThrottledError is returned by a RuleSet when a Throttler rejected the message
+type ThrottledError struct {
+	// RetryAfter is how long to wait before resubmitting the message
+	RetryAfter time.Duration
+}
+
+func (te *ThrottledError) Error() string {
+	return fmt.Sprintf("Rate limit exceeded, retry after %s", te.RetryAfter)
+}
+
+// SizeLimiter is implemented by rules which reject messages larger than a maximum size
+type SizeLimiter interface {
+	// MaxBytes returns the maximum size in bytes of a message
+	MaxBytes() uint32
+}
+
+// TooLargeError is returned by a RuleSet when a SizeLimiter rejected the message
+type TooLargeError struct {
+	// Size is the size in bytes of the message
+	Size uint32
+	// MaxBytes is the maximum size in bytes of a message
+	MaxBytes uint32
+}
+
+func (tle *TooLargeError) Error() string {
+	return fmt.Sprintf("Message of %d bytes exceeds the maximum of %d bytes", tle.Size, tle.MaxBytes)
+}
+
+// Sealer is implemented by rules which reject messages because the channel no longer orders transactions
+type Sealer interface {
+	// Sealed returns whether the channel is sealed
+	Sealed() bool
+}
+
+// SealedError is returned by a RuleSet when a Sealer rejected the message
+type SealedError struct{}
+
+func (se *SealedError) Error() string {
+	return "Channel is sealed and no longer orders transactions"
+}
+
+// Maintainer is implemented by rules which reject messages while the channel is in maintenance mode
+type Maintainer interface {
+	// InMaintenance returns whether the channel is in maintenance mode
+	InMaintenance() bool
+}
+
+// MaintenanceError is returned by a RuleSet when a Maintainer rejected the message
+type MaintenanceError struct{}
+
+func (me *MaintenanceError) Error() string {
+	return "Channel is in maintenance mode and only orders config transactions"
+}
+
+// Deduplicator is implemented by rules which reject messages whose transaction ID was already accepted for ordering
+type Deduplicator interface {
+	// TxID returns the transaction ID of a message
+	TxID(message *ab.Envelope) string
+}
+
+// DuplicateError is returned by a RuleSet when a Deduplicator rejected the message
+type DuplicateError struct {
+	// TxID is the transaction ID of the message
+	TxID string
+}
+
+func (de *DuplicateError) Error() string {
+	return fmt.Sprintf("Transaction %s was already accepted for ordering", de.TxID)
+}
+
 // Committer is returned by postfiltering and should be invoked once the message has been written to the blockchain
 type Committer interface {
 	// Commit performs whatever action should be performed upon commiting of a message
@@ -99,6 +176,21 @@ func (rs *RuleSet) Apply(message *ab.Envelope) (Committer, error) {
 		case Accept:
 			return committer, nil
 		case Reject:
+			if throttler, ok := rule.(Throttler); ok {
+				return nil, &ThrottledError{RetryAfter: throttler.RetryAfter(message)}
+			}
+			if limiter, ok := rule.(SizeLimiter); ok {
+				return nil, &TooLargeError{Size: uint32(len(message.Payload) + len(message.Signature)), MaxBytes: limiter.MaxBytes()}
+			}
+			if _, ok := rule.(Sealer); ok {
+				return nil, &SealedError{}
+			}
+			if _, ok := rule.(Maintainer); ok {
+				return nil, &MaintenanceError{}
+			}
+			if deduplicator, ok := rule.(Deduplicator); ok {
+				return nil, &DuplicateError{TxID: deduplicator.TxID(message)}
+			}
 			return nil, fmt.Errorf("Rejected by rule: %T", rule)
 		default:
 		}
@@ -18,6 +18,7 @@ package filter
 
 import (
 	"testing"
+	"time"
 
 	cb "github.com/hyperledger/fabric/protos/common"
 )
@@ -38,6 +39,16 @@ func (r forwardRule) Apply(message *cb.Envelope) (Action, Committer) {
 	return Forward, nil
 }
 
+type throttleRule struct{}
+
+func (r throttleRule) Apply(message *cb.Envelope) (Action, Committer) {
+	return Reject, nil
+}
+
+func (r throttleRule) RetryAfter(message *cb.Envelope) time.Duration {
+	return time.Second
+}
+
 func TestEmptyRejectRule(t *testing.T) {
 	result, _ := EmptyRejectRule.Apply(&cb.Envelope{})
 	if result != Reject {
@@ -88,3 +99,15 @@ func TestNoRule(t *testing.T) {
 		t.Fatalf("Should have rejected")
 	}
 }
+
+func TestThrottle(t *testing.T) {
+	rs := NewRuleSet([]Rule{throttleRule{}, AcceptRule})
+	_, err := rs.Apply(&cb.Envelope{})
+	throttled, ok := err.(*ThrottledError)
+	if !ok {
+		t.Fatalf("Should have throttled, got %v", err)
+	}
+	if throttled.RetryAfter != time.Second {
+		t.Fatalf("Should have hinted to retry after a second, got %s", throttled.RetryAfter)
+	}
+}
@@ -0,0 +1,118 @@
+/*
+Copyright IBM Corp. 2016 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+                 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maintenancefilter
+
+import (
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/config"
+	"github.com/hyperledger/fabric/common/configtx"
+	"github.com/hyperledger/fabric/orderer/common/filter"
+	cb "github.com/hyperledger/fabric/protos/common"
+	ab "github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric/protos/utils"
+	logging "github.com/op/go-logging"
+)
+
+var logger = logging.MustGetLogger("orderer/common/maintenancefilter")
+
+// Support provides the channel configuration the consensus state is read from
+type Support interface {
+	// SharedConfig returns the current orderer config of the channel
+	SharedConfig() config.Orderer
+}
+
+// New creates a rule which rejects all messages but config transactions while the channel is in
+// maintenance mode, so that the consensus type is changed once the pending messages are drained
+func New(support Support) filter.Rule {
+	return &maintenanceRule{support: support}
+}
+
+type maintenanceRule struct {
+	support Support
+}
+
+// InMaintenance returns whether the channel is in maintenance mode, it implements filter.Maintainer
+func (r *maintenanceRule) InMaintenance() bool {
+	return r.support.SharedConfig().ConsensusState() == ab.ConsensusType_STATE_MAINTENANCE
+}
+
+func (r *maintenanceRule) Apply(message *cb.Envelope) (filter.Action, filter.Committer) {
+	if !r.InMaintenance() {
+		return filter.Forward, nil
+	}
+	if _, ok := configPayload(message); ok {
+		return filter.Forward, nil
+	}
+	logger.Debugf("Rejecting message as the channel is in maintenance mode")
+	return filter.Reject, nil
+}
+
+// ConsensusTypeRule creates a rule which rejects the config transactions setting a consensus
+// type other than the given ones, which are those the orderer has a consenter for
+func ConsensusTypeRule(consensusTypes []string) filter.Rule {
+	rule := &consensusTypeRule{consensusTypes: make(map[string]struct{})}
+	for _, consensusType := range consensusTypes {
+		rule.consensusTypes[consensusType] = struct{}{}
+	}
+	return rule
+}
+
+type consensusTypeRule struct {
+	consensusTypes map[string]struct{}
+}
+
+func (r *consensusTypeRule) Apply(message *cb.Envelope) (filter.Action, filter.Committer) {
+	payload, ok := configPayload(message)
+	if !ok {
+		return filter.Forward, nil
+	}
+	configEnvelope, err := configtx.UnmarshalConfigEnvelope(payload.Data)
+	if err != nil || configEnvelope.Config == nil || configEnvelope.Config.ChannelGroup == nil {
+		// The config filter rejects malformed config transactions
+		return filter.Forward, nil
+	}
+	ordererGroup, ok := configEnvelope.Config.ChannelGroup.Groups[config.OrdererGroupKey]
+	if !ok {
+		return filter.Forward, nil
+	}
+	value, ok := ordererGroup.Values[config.ConsensusTypeKey]
+	if !ok {
+		return filter.Forward, nil
+	}
+	consensusType := &ab.ConsensusType{}
+	if err := proto.Unmarshal(value.Value, consensusType); err != nil {
+		return filter.Forward, nil
+	}
+	if _, ok := r.consensusTypes[consensusType.Type]; !ok {
+		logger.Warningf("Rejecting config transaction setting the unsupported consensus type %s", consensusType.Type)
+		return filter.Reject, nil
+	}
+	return filter.Forward, nil
+}
+
+// configPayload returns the payload of the message if the message is a config transaction
+func configPayload(message *cb.Envelope) (*cb.Payload, bool) {
+	payload, err := utils.UnmarshalPayload(message.Payload)
+	if err != nil || payload.Header == nil {
+		return nil, false
+	}
+	chdr, err := utils.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+	if err != nil || chdr.Type != int32(cb.HeaderType_CONFIG) {
+		return nil, false
+	}
+	return payload, true
+}
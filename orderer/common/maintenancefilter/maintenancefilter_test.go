@@ -0,0 +1,81 @@
+/*
+Copyright IBM Corp. 2016 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+                 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maintenancefilter
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric/common/config"
+	mockconfig "github.com/hyperledger/fabric/common/mocks/configvalues/channel/orderer"
+	"github.com/hyperledger/fabric/orderer/common/filter"
+	cb "github.com/hyperledger/fabric/protos/common"
+	ab "github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric/protos/utils"
+)
+
+type mockSupport struct {
+	sharedConfig *mockconfig.SharedConfig
+}
+
+func (ms *mockSupport) SharedConfig() config.Orderer {
+	return ms.sharedConfig
+}
+
+func makeMessage(headerType cb.HeaderType, data []byte) *cb.Envelope {
+	return &cb.Envelope{Payload: utils.MarshalOrPanic(&cb.Payload{
+		Header: &cb.Header{ChannelHeader: utils.MarshalOrPanic(utils.MakeChannelHeader(headerType, 0, "foo", 0))},
+		Data:   data,
+	})}
+}
+
+func makeConfigMessage(consensusType string) *cb.Envelope {
+	return makeMessage(cb.HeaderType_CONFIG, utils.MarshalOrPanic(&cb.ConfigEnvelope{
+		Config: &cb.Config{ChannelGroup: config.TemplateConsensusType(consensusType)},
+	}))
+}
+
+func TestMaintenanceRule(t *testing.T) {
+	support := &mockSupport{sharedConfig: &mockconfig.SharedConfig{}}
+	rs := filter.NewRuleSet([]filter.Rule{New(support), filter.AcceptRule})
+
+	if _, err := rs.Apply(makeMessage(cb.HeaderType_ENDORSER_TRANSACTION, []byte("Some bytes"))); err != nil {
+		t.Fatalf("Should have accepted the message in normal operation, got %s", err)
+	}
+
+	support.sharedConfig.ConsensusStateVal = ab.ConsensusType_STATE_MAINTENANCE
+	_, err := rs.Apply(makeMessage(cb.HeaderType_ENDORSER_TRANSACTION, []byte("Some bytes")))
+	if _, ok := err.(*filter.MaintenanceError); !ok {
+		t.Fatalf("Should have rejected the message in maintenance mode, got %v", err)
+	}
+	if _, err := rs.Apply(makeConfigMessage("solo")); err != nil {
+		t.Fatalf("Should have accepted the config transaction in maintenance mode, got %s", err)
+	}
+}
+
+func TestConsensusTypeRule(t *testing.T) {
+	rs := filter.NewRuleSet([]filter.Rule{ConsensusTypeRule([]string{"solo", "kafka"}), filter.AcceptRule})
+
+	if _, err := rs.Apply(makeConfigMessage("kafka")); err != nil {
+		t.Fatalf("Should have accepted the config transaction setting a supported consensus type, got %s", err)
+	}
+	if _, err := rs.Apply(makeConfigMessage("unknown")); err == nil {
+		t.Fatalf("Should have rejected the config transaction setting an unsupported consensus type")
+	}
+	if _, err := rs.Apply(makeMessage(cb.HeaderType_ENDORSER_TRANSACTION, []byte("Some bytes"))); err != nil {
+		t.Fatalf("Should have accepted the message which is not a config transaction, got %s", err)
+	}
+}
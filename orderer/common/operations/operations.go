@@ -0,0 +1,151 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+                 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package operations
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/hyperledger/fabric/orderer/ledger"
+
+	"github.com/op/go-logging"
+	"github.com/rcrowley/go-metrics"
+)
+
+var logger = logging.MustGetLogger("orderer/common/operations")
+
+// Check reports an error when a component of the orderer is not ready to serve requests
+type Check func() error
+
+// Support provides the chains whose readiness and height are reported
+type Support interface {
+	// ChainIDs returns the IDs of the chains hosted by the orderer
+	ChainIDs() []string
+
+	// GetChain retrieves the Chain for a chain (and whether it exists)
+	GetChain(chainID string) (Chain, bool)
+}
+
+// Chain provides the resources describing the state of a chain
+type Chain interface {
+	// Reader returns the chain Reader for the chain
+	Reader() ledger.Reader
+
+	// HealthCheck returns an error when the chain cannot order messages
+	HealthCheck() error
+}
+
+// Readiness is the JSON body of the response to a readiness request
+type Readiness struct {
+	Ready    bool              `json:"ready"`
+	Failures map[string]string `json:"failures,omitempty"`
+}
+
+type handlerImpl struct {
+	support  Support
+	registry metrics.Registry
+	checks   map[string]Check
+}
+
+// NewHandler creates an http.Handler serving the liveness of the orderer on /healthz, its
+// readiness on /readyz, which runs the named checks and the health check of every chain,
+// and the metrics of the registry along with the height of every chain on /metrics as JSON
+// and on /metrics/prometheus in the Prometheus text format
+func NewHandler(support Support, registry metrics.Registry, checks map[string]Check) http.Handler {
+	h := &handlerImpl{
+		support:  support,
+		registry: registry,
+		checks:   checks,
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", h.healthz)
+	mux.HandleFunc("/readyz", h.readyz)
+	mux.HandleFunc("/metrics", h.metrics)
+	mux.HandleFunc("/metrics/prometheus", h.prometheus)
+	return mux
+}
+
+func (h *handlerImpl) healthz(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain")
+	fmt.Fprintln(w, "OK")
+}
+
+func (h *handlerImpl) readyz(w http.ResponseWriter, r *http.Request) {
+	readiness := &Readiness{Failures: make(map[string]string)}
+	for name, check := range h.checks {
+		if err := check(); err != nil {
+			readiness.Failures[name] = err.Error()
+		}
+	}
+	for _, chainID := range h.support.ChainIDs() {
+		chain, ok := h.support.GetChain(chainID)
+		if !ok {
+			continue
+		}
+		if err := chain.HealthCheck(); err != nil {
+			readiness.Failures["chain."+chainID] = err.Error()
+		}
+	}
+	readiness.Ready = len(readiness.Failures) == 0
+
+	status := http.StatusOK
+	if !readiness.Ready {
+		logger.Warningf("Orderer is not ready: %v", readiness.Failures)
+		status = http.StatusServiceUnavailable
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(readiness); err != nil {
+		logger.Warningf("Could not write the readiness response: %s", err)
+	}
+}
+
+// heights reads the height of every chain, when scraped so they are never stale
+func (h *handlerImpl) heights() map[string]uint64 {
+	heights := make(map[string]uint64)
+	for _, chainID := range h.support.ChainIDs() {
+		chain, ok := h.support.GetChain(chainID)
+		if !ok {
+			continue
+		}
+		heights[chainID] = chain.Reader().Height()
+	}
+	return heights
+}
+
+func (h *handlerImpl) metrics(w http.ResponseWriter, r *http.Request) {
+	// The heights are registered in a copy of the registry so they are never stale
+	snapshot := metrics.NewRegistry()
+	h.registry.Each(func(name string, metric interface{}) {
+		snapshot.Register(name, metric)
+	})
+	for chainID, height := range h.heights() {
+		snapshot.Register("chain."+chainID+".height", metrics.GaugeSnapshot(height))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	metrics.WriteJSONOnce(snapshot, w)
+}
+
+func (h *handlerImpl) prometheus(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", PrometheusContentType)
+	if err := writePrometheus(w, h.registry, h.heights()); err != nil {
+		logger.Warningf("Could not write the Prometheus metrics: %s", err)
+	}
+}
@@ -0,0 +1,178 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+                 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package operations
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hyperledger/fabric/orderer/ledger"
+	ramledger "github.com/hyperledger/fabric/orderer/ledger/ram"
+	cb "github.com/hyperledger/fabric/protos/common"
+
+	"github.com/rcrowley/go-metrics"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockChain struct {
+	ledger.ReadWriter
+	err error
+}
+
+func (mc *mockChain) Reader() ledger.Reader {
+	return mc.ReadWriter
+}
+
+func (mc *mockChain) HealthCheck() error {
+	return mc.err
+}
+
+type mockSupport struct {
+	chains map[string]*mockChain
+	lf     ledger.Factory
+}
+
+func newMockSupport(chainIDs ...string) *mockSupport {
+	ms := &mockSupport{chains: make(map[string]*mockChain), lf: ramledger.New(10)}
+	for _, chainID := range chainIDs {
+		rl, _ := ms.lf.GetOrCreate(chainID)
+		rl.Append(cb.NewBlock(0, nil))
+		ms.chains[chainID] = &mockChain{ReadWriter: rl}
+	}
+	return ms
+}
+
+func (ms *mockSupport) ChainIDs() []string {
+	return ms.lf.ChainIDs()
+}
+
+func (ms *mockSupport) GetChain(chainID string) (Chain, bool) {
+	mc, ok := ms.chains[chainID]
+	return mc, ok
+}
+
+func get(t *testing.T, h http.Handler, path string) *httptest.ResponseRecorder {
+	req, err := http.NewRequest(http.MethodGet, path, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestHealthz(t *testing.T) {
+	h := NewHandler(newMockSupport(), metrics.NewRegistry(), nil)
+
+	rec := get(t, h, "/healthz")
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestReadyz(t *testing.T) {
+	ms := newMockSupport("foo", "bar")
+	var ledgerErr error
+	h := NewHandler(ms, metrics.NewRegistry(), map[string]Check{
+		"ledger": func() error { return ledgerErr },
+	})
+
+	rec := get(t, h, "/readyz")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	readiness := &Readiness{}
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), readiness))
+	assert.True(t, readiness.Ready)
+	assert.Empty(t, readiness.Failures)
+
+	ledgerErr = fmt.Errorf("ledger is read only")
+	ms.chains["bar"].err = fmt.Errorf("not connected")
+	rec = get(t, h, "/readyz")
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	readiness = &Readiness{}
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), readiness))
+	assert.False(t, readiness.Ready)
+	assert.Equal(t, map[string]string{
+		"ledger":    "ledger is read only",
+		"chain.bar": "not connected",
+	}, readiness.Failures)
+}
+
+func TestMetrics(t *testing.T) {
+	ms := newMockSupport("foo")
+	registry := metrics.NewRegistry()
+	metrics.GetOrRegisterCounter("deliver.streams", registry).Inc(2)
+	h := NewHandler(ms, registry, nil)
+
+	rec := get(t, h, "/metrics")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	values := make(map[string]map[string]interface{})
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &values))
+	assert.Equal(t, float64(2), values["deliver.streams"]["count"])
+	assert.Equal(t, float64(1), values["chain.foo.height"]["value"])
+
+	rl, _ := ms.lf.GetOrCreate("foo")
+	rl.Append(ledger.CreateNextBlock(rl, []*cb.Envelope{&cb.Envelope{Payload: []byte("My Data")}}))
+	rec = get(t, h, "/metrics")
+	values = make(map[string]map[string]interface{})
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &values))
+	assert.Equal(t, float64(2), values["chain.foo.height"]["value"], "Height should be read when scraped")
+	assert.Nil(t, registry.Get("chain.foo.height"), "Heights should not be registered in the registry")
+}
+
+func TestPrometheusMetrics(t *testing.T) {
+	ms := newMockSupport("foo", "bar")
+	registry := metrics.NewRegistry()
+	metrics.GetOrRegisterCounter("deliver.streams", registry).Inc(2)
+	metrics.GetOrRegisterCounter("broadcast.rejected.BAD_REQUEST", registry).Inc(1)
+	metrics.GetOrRegisterMeter("broadcast.received", registry).Mark(3)
+	histogram := metrics.GetOrRegisterHistogram("blockcutter.batch.size", registry, metrics.NewUniformSample(10))
+	histogram.Update(1)
+	histogram.Update(3)
+	h := NewHandler(ms, registry, nil)
+
+	rec := get(t, h, "/metrics/prometheus")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, PrometheusContentType, rec.Header().Get("Content-Type"))
+	assert.Equal(t, `# TYPE orderer_blockcutter_batch_size summary
+orderer_blockcutter_batch_size{quantile="0.5"} 2
+orderer_blockcutter_batch_size{quantile="0.75"} 3
+orderer_blockcutter_batch_size{quantile="0.95"} 3
+orderer_blockcutter_batch_size{quantile="0.99"} 3
+orderer_blockcutter_batch_size_sum 4
+orderer_blockcutter_batch_size_count 2
+# TYPE orderer_broadcast_received_total counter
+orderer_broadcast_received_total 3
+# TYPE orderer_broadcast_rejected_BAD_REQUEST gauge
+orderer_broadcast_rejected_BAD_REQUEST 1
+# TYPE orderer_deliver_streams gauge
+orderer_deliver_streams 2
+# TYPE orderer_chain_height gauge
+orderer_chain_height{channel="bar"} 1
+orderer_chain_height{channel="foo"} 1
+`, rec.Body.String())
+
+	rl, _ := ms.lf.GetOrCreate("foo")
+	rl.Append(ledger.CreateNextBlock(rl, []*cb.Envelope{&cb.Envelope{Payload: []byte("My Data")}}))
+	rec = get(t, h, "/metrics/prometheus")
+	assert.Contains(t, rec.Body.String(), "orderer_chain_height{channel=\"foo\"} 2\n", "Height should be read when scraped")
+}
+
+func TestPrometheusName(t *testing.T) {
+	assert.Equal(t, "orderer_chain_my_channel_height", prometheusName("chain.my-channel.height"))
+	assert.Equal(t, `a\"b\\c\n`, escapeLabelValue("a\"b\\c\n"))
+}
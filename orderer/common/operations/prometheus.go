@@ -0,0 +1,120 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+                 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package operations
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/rcrowley/go-metrics"
+)
+
+// PrometheusContentType is the content type of the Prometheus text exposition format
+const PrometheusContentType = "text/plain; version=0.0.4"
+
+// prometheusNamespace prefixes the name of every metric exposed to Prometheus
+const prometheusNamespace = "orderer"
+
+// quantiles are the quantiles of the histograms and timers exposed as summaries
+var quantiles = []float64{0.5, 0.75, 0.95, 0.99}
+
+// writePrometheus writes the metrics of the registry, and the height of every chain
+// as the chain_height gauge labelled by channel, in the Prometheus text format.
+// As go-metrics counters may be decremented they are exposed as gauges, meters as
+// counters of their events and histograms and timers as summaries
+func writePrometheus(w io.Writer, registry metrics.Registry, heights map[string]uint64) error {
+	bw := bufio.NewWriter(w)
+
+	var names []string
+	registry.Each(func(name string, _ interface{}) {
+		names = append(names, name)
+	})
+	sort.Strings(names)
+
+	for _, name := range names {
+		family := prometheusName(name)
+		switch metric := registry.Get(name).(type) {
+		case metrics.Counter:
+			writeFamily(bw, family, "gauge")
+			fmt.Fprintf(bw, "%s %d\n", family, metric.Count())
+		case metrics.Gauge:
+			writeFamily(bw, family, "gauge")
+			fmt.Fprintf(bw, "%s %d\n", family, metric.Value())
+		case metrics.GaugeFloat64:
+			writeFamily(bw, family, "gauge")
+			fmt.Fprintf(bw, "%s %g\n", family, metric.Value())
+		case metrics.Meter:
+			writeFamily(bw, family+"_total", "counter")
+			fmt.Fprintf(bw, "%s_total %d\n", family, metric.Snapshot().Count())
+		case metrics.Histogram:
+			h := metric.Snapshot()
+			writeSummary(bw, family, h.Percentiles(quantiles), h.Sum(), h.Count())
+		case metrics.Timer:
+			t := metric.Snapshot()
+			writeSummary(bw, family, t.Percentiles(quantiles), t.Sum(), t.Count())
+		default:
+			logger.Debugf("Metric %s of type %T cannot be exposed to Prometheus", name, metric)
+		}
+	}
+
+	if len(heights) > 0 {
+		var chainIDs []string
+		for chainID := range heights {
+			chainIDs = append(chainIDs, chainID)
+		}
+		sort.Strings(chainIDs)
+
+		family := prometheusNamespace + "_chain_height"
+		writeFamily(bw, family, "gauge")
+		for _, chainID := range chainIDs {
+			fmt.Fprintf(bw, "%s{channel=\"%s\"} %d\n", family, escapeLabelValue(chainID), heights[chainID])
+		}
+	}
+
+	return bw.Flush()
+}
+
+func writeFamily(w io.Writer, family, metricType string) {
+	fmt.Fprintf(w, "# TYPE %s %s\n", family, metricType)
+}
+
+func writeSummary(w io.Writer, family string, values []float64, sum int64, count int64) {
+	writeFamily(w, family, "summary")
+	for i, q := range quantiles {
+		fmt.Fprintf(w, "%s{quantile=\"%g\"} %g\n", family, q, values[i])
+	}
+	fmt.Fprintf(w, "%s_sum %d\n", family, sum)
+	fmt.Fprintf(w, "%s_count %d\n", family, count)
+}
+
+// prometheusName maps a go-metrics name such as broadcast.rejected.BAD_REQUEST to a
+// valid Prometheus metric name such as orderer_broadcast_rejected_BAD_REQUEST
+func prometheusName(name string) string {
+	return prometheusNamespace + "_" + strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '_' || r == ':' {
+			return r
+		}
+		return '_'
+	}, name)
+}
+
+func escapeLabelValue(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(value)
+}
@@ -0,0 +1,245 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+                 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ratelimitfilter
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/config"
+	"github.com/hyperledger/fabric/msp"
+	"github.com/hyperledger/fabric/orderer/common/filter"
+	cb "github.com/hyperledger/fabric/protos/common"
+	ab "github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/op/go-logging"
+)
+
+var logger = logging.MustGetLogger("orderer/common/ratelimitfilter")
+
+// pruneThreshold is the number of per identity and per MSP buckets above which
+// idle buckets are discarded
+const pruneThreshold = 1024
+
+// Support provides the channel configuration the rate limits are read from
+type Support interface {
+	// SharedConfig returns the current orderer config of the channel
+	SharedConfig() config.Orderer
+}
+
+type bucket struct {
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func limited(limit *ab.RateLimit) bool {
+	return limit != nil && limit.MessagesPerSecond > 0
+}
+
+func newBucket(limit *ab.RateLimit, now time.Time) *bucket {
+	if !limited(limit) {
+		return nil
+	}
+	burst := limit.Burst
+	if burst == 0 {
+		burst = limit.MessagesPerSecond
+	}
+	return &bucket{
+		rate:   float64(limit.MessagesPerSecond),
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   now,
+	}
+}
+
+func (b *bucket) refill(now time.Time) {
+	if elapsed := now.Sub(b.last); elapsed > 0 {
+		b.tokens += elapsed.Seconds() * b.rate
+		if b.tokens > b.burst {
+			b.tokens = b.burst
+		}
+	}
+	b.last = now
+}
+
+// wait returns how long it takes until the bucket holds a token
+func (b *bucket) wait(now time.Time) time.Duration {
+	b.refill(now)
+	if b.tokens >= 1 {
+		return 0
+	}
+	return time.Duration((1 - b.tokens) / b.rate * float64(time.Second))
+}
+
+func (b *bucket) full(now time.Time) bool {
+	b.refill(now)
+	return b.tokens >= b.burst
+}
+
+type rateLimitFilter struct {
+	support Support
+	now     func() time.Time
+
+	mutex      sync.Mutex
+	limits     *ab.RateLimits
+	channel    *bucket
+	identities map[string]*bucket
+	msps       map[string]*bucket
+}
+
+// New creates a rule which rejects messages exceeding the rate limits configured for the channel.
+// The channel, each creator identity and each MSP of the creators are assigned a token bucket
+// and a message is admitted only if all of its buckets hold a token. Rejected messages may be
+// resubmitted after the time returned by RetryAfter.
+func New(support Support) filter.Rule {
+	return newRateLimitFilter(support, time.Now)
+}
+
+func newRateLimitFilter(support Support, now func() time.Time) *rateLimitFilter {
+	return &rateLimitFilter{
+		support: support,
+		now:     now,
+	}
+}
+
+// Apply rejects the message if it exceeds the rate limits, otherwise it forwards it, never Accept and always with nil Committer
+func (rf *rateLimitFilter) Apply(message *cb.Envelope) (filter.Action, filter.Committer) {
+	rf.mutex.Lock()
+	defer rf.mutex.Unlock()
+
+	now := rf.now()
+	buckets := rf.buckets(message, now)
+	for _, b := range buckets {
+		if b.wait(now) > 0 {
+			if logger.IsEnabledFor(logging.DEBUG) {
+				logger.Debugf("Rejecting message because it exceeds the rate limits")
+			}
+			return filter.Reject, nil
+		}
+	}
+	for _, b := range buckets {
+		b.tokens--
+	}
+	return filter.Forward, nil
+}
+
+// RetryAfter returns the time until all buckets of the message hold a token again
+func (rf *rateLimitFilter) RetryAfter(message *cb.Envelope) time.Duration {
+	rf.mutex.Lock()
+	defer rf.mutex.Unlock()
+
+	now := rf.now()
+	var retryAfter time.Duration
+	for _, b := range rf.buckets(message, now) {
+		if wait := b.wait(now); wait > retryAfter {
+			retryAfter = wait
+		}
+	}
+	return retryAfter
+}
+
+// buckets returns the buckets a message draws its tokens from
+func (rf *rateLimitFilter) buckets(message *cb.Envelope, now time.Time) []*bucket {
+	rf.update(now)
+
+	var buckets []*bucket
+	if rf.channel != nil {
+		buckets = append(buckets, rf.channel)
+	}
+	if !limited(rf.limits.Identity) && !limited(rf.limits.Msp) {
+		return buckets
+	}
+
+	identity, err := creator(message)
+	if err != nil {
+		// The signature filter rejects such messages, leave it to it
+		logger.Debugf("Could not determine the creator of the message: %s", err)
+		return buckets
+	}
+	if b := rf.bucket(rf.identities, identityKey(identity), rf.limits.Identity, now); b != nil {
+		buckets = append(buckets, b)
+	}
+	if b := rf.bucket(rf.msps, identity.Mspid, rf.limits.Msp, now); b != nil {
+		buckets = append(buckets, b)
+	}
+	return buckets
+}
+
+// update resets the buckets if the rate limits of the channel changed
+func (rf *rateLimitFilter) update(now time.Time) {
+	limits := rf.support.SharedConfig().RateLimits()
+	if limits == nil {
+		limits = &ab.RateLimits{}
+	}
+	if rf.limits != nil && (rf.limits == limits || proto.Equal(rf.limits, limits)) {
+		return
+	}
+	logger.Debugf("Applying rate limits %v", limits)
+	rf.limits = limits
+	rf.channel = newBucket(limits.Channel, now)
+	rf.identities = make(map[string]*bucket)
+	rf.msps = make(map[string]*bucket)
+}
+
+func (rf *rateLimitFilter) bucket(buckets map[string]*bucket, key string, limit *ab.RateLimit, now time.Time) *bucket {
+	if b, ok := buckets[key]; ok {
+		return b
+	}
+	b := newBucket(limit, now)
+	if b == nil {
+		return nil
+	}
+	if len(buckets) >= pruneThreshold {
+		// A full bucket admits the same messages as a new one
+		for k, idle := range buckets {
+			if idle.full(now) {
+				delete(buckets, k)
+			}
+		}
+	}
+	buckets[key] = b
+	return b
+}
+
+func creator(message *cb.Envelope) (*msp.SerializedIdentity, error) {
+	payload, err := utils.UnmarshalPayload(message.Payload)
+	if err != nil {
+		return nil, err
+	}
+	if payload.Header == nil {
+		return nil, fmt.Errorf("missing header")
+	}
+	shdr, err := utils.GetSignatureHeader(payload.Header.SignatureHeader)
+	if err != nil {
+		return nil, err
+	}
+	identity := &msp.SerializedIdentity{}
+	if err := proto.Unmarshal(shdr.Creator, identity); err != nil {
+		return nil, err
+	}
+	return identity, nil
+}
+
+func identityKey(identity *msp.SerializedIdentity) string {
+	hash := sha256.Sum256(identity.IdBytes)
+	return identity.Mspid + string(hash[:])
+}
@@ -0,0 +1,176 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+                 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ratelimitfilter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric/common/config"
+	mockconfig "github.com/hyperledger/fabric/common/mocks/configvalues/channel/orderer"
+	"github.com/hyperledger/fabric/msp"
+	"github.com/hyperledger/fabric/orderer/common/filter"
+	cb "github.com/hyperledger/fabric/protos/common"
+	ab "github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric/protos/utils"
+)
+
+type mockSupport struct {
+	sharedConfig *mockconfig.SharedConfig
+}
+
+func (ms *mockSupport) SharedConfig() config.Orderer {
+	return ms.sharedConfig
+}
+
+type testClock struct {
+	now time.Time
+}
+
+func (tc *testClock) Now() time.Time {
+	return tc.now
+}
+
+func newTestRuleSet(limits *ab.RateLimits) (*filter.RuleSet, *mockSupport, *testClock) {
+	support := &mockSupport{sharedConfig: &mockconfig.SharedConfig{RateLimitsVal: limits}}
+	clock := &testClock{now: time.Unix(0, 0)}
+	return filter.NewRuleSet([]filter.Rule{newRateLimitFilter(support, clock.Now), filter.AcceptRule}), support, clock
+}
+
+func makeMessage(mspID string, cert string) *cb.Envelope {
+	creator := utils.MarshalOrPanic(&msp.SerializedIdentity{Mspid: mspID, IdBytes: []byte(cert)})
+	return &cb.Envelope{Payload: utils.MarshalOrPanic(&cb.Payload{
+		Header: &cb.Header{SignatureHeader: utils.MarshalOrPanic(&cb.SignatureHeader{Creator: creator})},
+	})}
+}
+
+func expectAccept(t *testing.T, rs *filter.RuleSet, msg *cb.Envelope) {
+	if _, err := rs.Apply(msg); err != nil {
+		t.Fatalf("Should have accepted: %s", err)
+	}
+}
+
+func expectThrottle(t *testing.T, rs *filter.RuleSet, msg *cb.Envelope, retryAfter time.Duration) {
+	_, err := rs.Apply(msg)
+	throttled, ok := err.(*filter.ThrottledError)
+	if !ok {
+		t.Fatalf("Should have throttled, got %v", err)
+	}
+	if throttled.RetryAfter != retryAfter {
+		t.Fatalf("Should have hinted to retry after %s, got %s", retryAfter, throttled.RetryAfter)
+	}
+}
+
+func TestNoLimits(t *testing.T) {
+	rs, _, _ := newTestRuleSet(&ab.RateLimits{})
+	for i := 0; i < 100; i++ {
+		expectAccept(t, rs, makeMessage("Org1MSP", "cert1"))
+	}
+}
+
+func TestChannelLimit(t *testing.T) {
+	rs, _, clock := newTestRuleSet(&ab.RateLimits{Channel: &ab.RateLimit{MessagesPerSecond: 2, Burst: 3}})
+	for i := 0; i < 3; i++ {
+		expectAccept(t, rs, makeMessage("Org1MSP", "cert1"))
+	}
+	expectThrottle(t, rs, makeMessage("Org2MSP", "cert2"), 500*time.Millisecond)
+
+	clock.now = clock.now.Add(500 * time.Millisecond)
+	expectAccept(t, rs, makeMessage("Org2MSP", "cert2"))
+	expectThrottle(t, rs, makeMessage("Org1MSP", "cert1"), 500*time.Millisecond)
+
+	// The bucket does not refill beyond the burst
+	clock.now = clock.now.Add(time.Hour)
+	for i := 0; i < 3; i++ {
+		expectAccept(t, rs, makeMessage("Org1MSP", "cert1"))
+	}
+	expectThrottle(t, rs, makeMessage("Org1MSP", "cert1"), 500*time.Millisecond)
+}
+
+func TestIdentityLimit(t *testing.T) {
+	rs, _, clock := newTestRuleSet(&ab.RateLimits{Identity: &ab.RateLimit{MessagesPerSecond: 1}})
+	expectAccept(t, rs, makeMessage("Org1MSP", "cert1"))
+	expectThrottle(t, rs, makeMessage("Org1MSP", "cert1"), time.Second)
+	expectAccept(t, rs, makeMessage("Org1MSP", "cert2"))
+	expectAccept(t, rs, makeMessage("Org2MSP", "cert1"))
+
+	clock.now = clock.now.Add(time.Second)
+	expectAccept(t, rs, makeMessage("Org1MSP", "cert1"))
+}
+
+func TestMSPLimit(t *testing.T) {
+	rs, _, _ := newTestRuleSet(&ab.RateLimits{
+		Identity: &ab.RateLimit{MessagesPerSecond: 10},
+		Msp:      &ab.RateLimit{MessagesPerSecond: 2},
+	})
+	expectAccept(t, rs, makeMessage("Org1MSP", "cert1"))
+	expectAccept(t, rs, makeMessage("Org1MSP", "cert2"))
+	expectThrottle(t, rs, makeMessage("Org1MSP", "cert3"), 500*time.Millisecond)
+	expectAccept(t, rs, makeMessage("Org2MSP", "cert3"))
+
+	// A rejected message does not consume the tokens of its other buckets
+	rf := newRateLimitFilter(&mockSupport{sharedConfig: &mockconfig.SharedConfig{RateLimitsVal: &ab.RateLimits{
+		Identity: &ab.RateLimit{MessagesPerSecond: 1},
+		Msp:      &ab.RateLimit{MessagesPerSecond: 1},
+	}}}, time.Now)
+	rf.Apply(makeMessage("Org1MSP", "cert1"))
+	if action, _ := rf.Apply(makeMessage("Org1MSP", "cert2")); action != filter.Reject {
+		t.Fatalf("Should have rejected")
+	}
+	if rf.identities[identityKey(&msp.SerializedIdentity{Mspid: "Org1MSP", IdBytes: []byte("cert2")})].tokens != 1 {
+		t.Fatalf("Should not have consumed the token of the identity")
+	}
+}
+
+func TestMalformedCreator(t *testing.T) {
+	rs, _, _ := newTestRuleSet(&ab.RateLimits{Identity: &ab.RateLimit{MessagesPerSecond: 1}})
+	for i := 0; i < 3; i++ {
+		expectAccept(t, rs, &cb.Envelope{Payload: []byte("garbage")})
+	}
+}
+
+func TestLimitsUpdate(t *testing.T) {
+	rs, support, _ := newTestRuleSet(&ab.RateLimits{Channel: &ab.RateLimit{MessagesPerSecond: 1}})
+	expectAccept(t, rs, makeMessage("Org1MSP", "cert1"))
+	expectThrottle(t, rs, makeMessage("Org1MSP", "cert1"), time.Second)
+
+	// An identical config keeps the state of the buckets
+	support.sharedConfig.RateLimitsVal = &ab.RateLimits{Channel: &ab.RateLimit{MessagesPerSecond: 1}}
+	expectThrottle(t, rs, makeMessage("Org1MSP", "cert1"), time.Second)
+
+	support.sharedConfig.RateLimitsVal = &ab.RateLimits{Channel: &ab.RateLimit{MessagesPerSecond: 4, Burst: 1}}
+	expectAccept(t, rs, makeMessage("Org1MSP", "cert1"))
+	expectThrottle(t, rs, makeMessage("Org1MSP", "cert1"), 250*time.Millisecond)
+
+	support.sharedConfig.RateLimitsVal = nil
+	expectAccept(t, rs, makeMessage("Org1MSP", "cert1"))
+}
+
+func TestPruneIdleBuckets(t *testing.T) {
+	clock := &testClock{now: time.Unix(0, 0)}
+	rf := newRateLimitFilter(&mockSupport{sharedConfig: &mockconfig.SharedConfig{RateLimitsVal: &ab.RateLimits{
+		Identity: &ab.RateLimit{MessagesPerSecond: 1},
+	}}}, clock.Now)
+	for i := 0; i < pruneThreshold; i++ {
+		rf.Apply(makeMessage("Org1MSP", string(rune(i))))
+	}
+	clock.now = clock.now.Add(time.Second)
+	rf.Apply(makeMessage("Org1MSP", "new"))
+	if len(rf.identities) != 1 {
+		t.Fatalf("Should have pruned the idle buckets, %d remain", len(rf.identities))
+	}
+}
@@ -0,0 +1,369 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+                 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package replication
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	"github.com/hyperledger/fabric/common/configtx"
+	"github.com/hyperledger/fabric/common/crypto"
+	"github.com/hyperledger/fabric/common/policies"
+	"github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/orderer/ledger"
+	cb "github.com/hyperledger/fabric/protos/common"
+	ab "github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric/protos/utils"
+
+	"github.com/op/go-logging"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+)
+
+var logger = logging.MustGetLogger("orderer/common/replication")
+
+// Replicator pulls the blocks of the chains of the orderer from other orderers, so that
+// an orderer added to a kafka or sbft network does not rebuild them through its consenter
+type Replicator interface {
+	// Replicate pulls the blocks missing from every chain of the ledger factory, and
+	// creates and pulls the chains created in the pulled blocks of the system chain
+	Replicate() error
+}
+
+type replicatorImpl struct {
+	ledgerFactory ledger.Factory
+	signer        crypto.LocalSigner
+	endpoints     []string
+	dialOpts      []grpc.DialOption
+}
+
+// New creates a Replicator pulling the blocks from the orderers at the endpoints, which
+// are dialed with the options and must be reached within the timeout. The deliver
+// requests are signed by the signer, which must be a reader of the chains.
+func New(ledgerFactory ledger.Factory, signer crypto.LocalSigner, endpoints []string, timeout time.Duration, dialOpts ...grpc.DialOption) Replicator {
+	return &replicatorImpl{
+		ledgerFactory: ledgerFactory,
+		signer:        signer,
+		endpoints:     endpoints,
+		dialOpts:      append([]grpc.DialOption{grpc.WithBlock(), grpc.WithTimeout(timeout)}, dialOpts...),
+	}
+}
+
+// Replicate pulls each chain from every endpoint in turn, so the chains end up as long as
+// on the most advanced orderer. The chains which could not be pulled from any endpoint
+// are reported in the error, once the other chains were pulled.
+func (r *replicatorImpl) Replicate() error {
+	pending := r.ledgerFactory.ChainIDs()
+	var failed []string
+	for len(pending) > 0 {
+		chainID := pending[0]
+		pending = pending[1:]
+
+		configTxs, err := r.replicateChain(chainID)
+		if err != nil {
+			logger.Errorf("Could not replicate chain %s: %s", chainID, err)
+			failed = append(failed, chainID)
+		}
+
+		for _, configTx := range configTxs {
+			createdID, err := r.createChain(configTx)
+			if err != nil {
+				logger.Errorf("Could not create a chain created in chain %s: %s", chainID, err)
+				continue
+			}
+			if createdID != "" {
+				pending = append(pending, createdID)
+			}
+		}
+	}
+
+	if len(failed) > 0 {
+		return fmt.Errorf("Could not replicate chains %v", failed)
+	}
+	return nil
+}
+
+// replicateChain pulls the blocks of a chain, and returns the config transactions of the
+// chains created in the pulled blocks
+func (r *replicatorImpl) replicateChain(chainID string) ([]*cb.Envelope, error) {
+	rl, err := r.ledgerFactory.GetOrCreate(chainID)
+	if err != nil {
+		return nil, err
+	}
+	replica, err := newChainReplica(chainID, rl)
+	if err != nil {
+		return nil, err
+	}
+
+	pulled := false
+	for _, endpoint := range r.endpoints {
+		if err := r.pull(endpoint, replica); err != nil {
+			logger.Warningf("[channel: %s] Could not pull blocks from %s: %s", chainID, endpoint, err)
+			continue
+		}
+		pulled = true
+	}
+	if !pulled {
+		return replica.configTxs, fmt.Errorf("no orderer could be pulled from")
+	}
+
+	logger.Infof("[channel: %s] Replicated up to block %d", chainID, replica.prevHeader.Number)
+	return replica.configTxs, nil
+}
+
+// createChain creates the ledger of a chain created by the config transaction, with the
+// genesis block the system chain creates, unless the ledger exists already. It returns the
+// ID of the created chain, or the empty string if it existed.
+func (r *replicatorImpl) createChain(configTx *cb.Envelope) (string, error) {
+	configManager, err := configtx.NewManagerImpl(configTx, configtx.NewInitializer(), nil)
+	if err != nil {
+		return "", fmt.Errorf("Error creating configtx manager: %s", err)
+	}
+	chainID := configManager.ChainID()
+
+	for _, existing := range r.ledgerFactory.ChainIDs() {
+		if existing == chainID {
+			return "", nil
+		}
+	}
+
+	rl, err := r.ledgerFactory.GetOrCreate(chainID)
+	if err != nil {
+		return "", err
+	}
+	if err := rl.Append(ledger.CreateNextBlock(rl, []*cb.Envelope{configTx})); err != nil {
+		return "", err
+	}
+	logger.Infof("Created chain %s to replicate it", chainID)
+	return chainID, nil
+}
+
+// pull requests the blocks after the last one of the replica up to the newest one of the
+// orderer at the endpoint, and appends them to the replica
+func (r *replicatorImpl) pull(endpoint string, replica *chainReplica) error {
+	conn, err := grpc.Dial(endpoint, r.dialOpts...)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream, err := ab.NewAtomicBroadcastClient(conn).Deliver(ctx)
+	if err != nil {
+		return err
+	}
+
+	seekInfo := &ab.SeekInfo{
+		Start:    &ab.SeekPosition{Type: &ab.SeekPosition_Specified{Specified: &ab.SeekSpecified{Number: replica.prevHeader.Number + 1}}},
+		Stop:     &ab.SeekPosition{Type: &ab.SeekPosition_Newest{Newest: &ab.SeekNewest{}}},
+		Behavior: ab.SeekInfo_FAIL_IF_NOT_READY,
+	}
+	env, err := utils.CreateSignedEnvelope(cb.HeaderType_DELIVER_SEEK_INFO, replica.chainID, r.signer, seekInfo, int32(0), uint64(0))
+	if err != nil {
+		return err
+	}
+	if err := stream.Send(env); err != nil {
+		return err
+	}
+
+	for {
+		resp, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+		switch t := resp.Type.(type) {
+		case *ab.DeliverResponse_Block:
+			if err := replica.append(t.Block); err != nil {
+				return err
+			}
+		case *ab.DeliverResponse_Status:
+			switch t.Status {
+			case cb.Status_SUCCESS:
+				return nil
+			case cb.Status_NOT_FOUND:
+				// The orderer has no block after the last one of the replica
+				logger.Debugf("[channel: %s] No block after %d at %s", replica.chainID, replica.prevHeader.Number, endpoint)
+				return nil
+			default:
+				return fmt.Errorf("Orderer replied with status %s", t.Status)
+			}
+		default:
+			return fmt.Errorf("Unexpected response %T", t)
+		}
+	}
+}
+
+// chainReplica verifies the pulled blocks of a chain before appending them to its ledger:
+// each block must be the next one, chained to the previous one, consistent with its data,
+// and signed according to the BlockValidation policy of the chain config in effect.
+type chainReplica struct {
+	chainID string
+	ledger  ledger.ReadWriter
+
+	// prevHeader is the header of the last block of the ledger
+	prevHeader *cb.BlockHeader
+
+	// policy is the BlockValidation policy of the last config of the ledger
+	policy policies.Policy
+
+	// configTxs holds the config transactions of the chains created in the pulled blocks
+	configTxs []*cb.Envelope
+}
+
+func newChainReplica(chainID string, rl ledger.ReadWriter) (*chainReplica, error) {
+	if rl.Height() == 0 {
+		return nil, fmt.Errorf("Chain has no genesis block, the orderer must be bootstrapped or joined to it first")
+	}
+	lastBlock := ledger.GetBlock(rl, rl.Height()-1)
+	if lastBlock == nil {
+		return nil, fmt.Errorf("Could not read the last block")
+	}
+	index, err := utils.GetLastConfigIndexFromBlock(lastBlock)
+	if err != nil {
+		return nil, fmt.Errorf("Could not read the last config index of the last block: %s", err)
+	}
+	configBlock := ledger.GetBlock(rl, index)
+	if configBlock == nil {
+		return nil, fmt.Errorf("Config block %d does not exist", index)
+	}
+	configTx, err := utils.ExtractEnvelope(configBlock, 0)
+	if err != nil {
+		return nil, err
+	}
+	policy, err := blockValidationPolicy(configTx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &chainReplica{
+		chainID:    chainID,
+		ledger:     rl,
+		prevHeader: lastBlock.Header,
+		policy:     policy,
+	}, nil
+}
+
+// blockValidationPolicy returns the BlockValidation policy of the config transaction
+func blockValidationPolicy(configTx *cb.Envelope) (policies.Policy, error) {
+	configManager, err := configtx.NewManagerImpl(configTx, configtx.NewInitializer(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("Error creating configtx manager: %s", err)
+	}
+	policy, ok := configManager.PolicyManager().GetPolicy(policies.BlockValidation)
+	if !ok {
+		return nil, fmt.Errorf("Config has no %s policy", policies.BlockValidation)
+	}
+	return policy, nil
+}
+
+func (cr *chainReplica) append(block *cb.Block) error {
+	if block.Header == nil || block.Data == nil || block.Metadata == nil {
+		return fmt.Errorf("Malformed block")
+	}
+	if block.Header.Number != cr.prevHeader.Number+1 {
+		return fmt.Errorf("Expected block %d but got block %d", cr.prevHeader.Number+1, block.Header.Number)
+	}
+	if !bytes.Equal(block.Header.PreviousHash, cr.prevHeader.Hash()) {
+		return fmt.Errorf("Block %d is not chained to block %d", block.Header.Number, cr.prevHeader.Number)
+	}
+	if !bytes.Equal(block.Header.DataHash, block.Data.Hash()) {
+		return fmt.Errorf("Data hash of block %d does not match its data", block.Header.Number)
+	}
+	if err := cr.verifySignatures(block); err != nil {
+		return fmt.Errorf("Signatures of block %d are not valid: %s", block.Header.Number, err)
+	}
+
+	// The config is inspected before appending, so a block whose config cannot be
+	// processed is not appended
+	policy, configTxs, err := cr.inspect(block)
+	if err != nil {
+		return fmt.Errorf("Could not process the config of block %d: %s", block.Header.Number, err)
+	}
+
+	if err := cr.ledger.Append(block); err != nil {
+		return err
+	}
+	cr.prevHeader = block.Header
+	if policy != nil {
+		cr.policy = policy
+	}
+	cr.configTxs = append(cr.configTxs, configTxs...)
+	return nil
+}
+
+// verifySignatures checks that the signatures of the block satisfy the BlockValidation
+// policy, each orderer signs the metadata value along with the header of the block
+func (cr *chainReplica) verifySignatures(block *cb.Block) error {
+	if len(block.Metadata.Metadata) <= int(cb.BlockMetadataIndex_SIGNATURES) {
+		return fmt.Errorf("Block has no signatures metadata")
+	}
+	metadata, err := utils.GetMetadataFromBlock(block, cb.BlockMetadataIndex_SIGNATURES)
+	if err != nil {
+		return err
+	}
+
+	signedData := make([]*cb.SignedData, 0, len(metadata.Signatures))
+	for _, sig := range metadata.Signatures {
+		shdr, err := utils.GetSignatureHeader(sig.SignatureHeader)
+		if err != nil {
+			return err
+		}
+		signedData = append(signedData, &cb.SignedData{
+			Identity:  shdr.Creator,
+			Data:      util.ConcatenateBytes(metadata.Value, sig.SignatureHeader, block.Header.Bytes()),
+			Signature: sig.Signature,
+		})
+	}
+	return cr.policy.Evaluate(signedData)
+}
+
+// inspect returns the BlockValidation policy of the config transaction of the block, if
+// any, and the config transactions of the chains the block creates
+func (cr *chainReplica) inspect(block *cb.Block) (policies.Policy, []*cb.Envelope, error) {
+	var policy policies.Policy
+	var configTxs []*cb.Envelope
+	for i := range block.Data.Data {
+		env, err := utils.ExtractEnvelope(block, i)
+		if err != nil {
+			continue
+		}
+		payload, err := utils.ExtractPayload(env)
+		if err != nil || payload.Header == nil {
+			continue
+		}
+		chdr, err := utils.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+		if err != nil {
+			continue
+		}
+
+		switch cb.HeaderType(chdr.Type) {
+		case cb.HeaderType_CONFIG:
+			if policy, err = blockValidationPolicy(env); err != nil {
+				return nil, nil, err
+			}
+		case cb.HeaderType_ORDERER_TRANSACTION:
+			configTx, err := utils.UnmarshalEnvelope(payload.Data)
+			if err != nil {
+				return nil, nil, err
+			}
+			configTxs = append(configTxs, configTx)
+		}
+	}
+	return policy, configTxs, nil
+}
@@ -0,0 +1,337 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+                 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package replication
+
+import (
+	"errors"
+	"net"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	genesisconfig "github.com/hyperledger/fabric/common/configtx/tool/localconfig"
+	"github.com/hyperledger/fabric/common/configtx/tool/provisional"
+	"github.com/hyperledger/fabric/common/localmsp"
+	"github.com/hyperledger/fabric/common/util"
+	msptesttools "github.com/hyperledger/fabric/msp/mgmt/testtools"
+	"github.com/hyperledger/fabric/orderer/ledger"
+	ramledger "github.com/hyperledger/fabric/orderer/ledger/ram"
+	cb "github.com/hyperledger/fabric/protos/common"
+	ab "github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric/protos/utils"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+)
+
+var bootstrapper provisional.Generator
+
+func TestMain(m *testing.M) {
+	if err := msptesttools.LoadMSPSetupForTesting("../../../msp/sampleconfig"); err != nil {
+		panic(err)
+	}
+	// The BlockValidation policy of the insecure profile is satisfied by any signatures,
+	// the verification of the signatures is tested against mock policies
+	bootstrapper = provisional.New(genesisconfig.Load(genesisconfig.SampleInsecureProfile))
+	os.Exit(m.Run())
+}
+
+// mockOrderer delivers the blocks of its ledger factory from the requested block to the
+// newest one, without authorizing the requests. The delivered copies are passed to tamper
+// if set.
+type mockOrderer struct {
+	lf     ledger.Factory
+	tamper func(block *cb.Block)
+	server *grpc.Server
+	addr   string
+}
+
+func newMockOrderer(t *testing.T, lf ledger.Factory) *mockOrderer {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	mo := &mockOrderer{lf: lf, server: grpc.NewServer(), addr: lis.Addr().String()}
+	ab.RegisterAtomicBroadcastServer(mo.server, mo)
+	go mo.server.Serve(lis)
+	return mo
+}
+
+func (mo *mockOrderer) Broadcast(srv ab.AtomicBroadcast_BroadcastServer) error {
+	return errors.New("Broadcast is not supported")
+}
+
+func (mo *mockOrderer) Deliver(srv ab.AtomicBroadcast_DeliverServer) error {
+	env, err := srv.Recv()
+	if err != nil {
+		return err
+	}
+	payload := utils.UnmarshalPayloadOrPanic(env.Payload)
+	chdr, err := utils.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+	if err != nil {
+		return err
+	}
+	seekInfo := &ab.SeekInfo{}
+	if err := proto.Unmarshal(payload.Data, seekInfo); err != nil {
+		return err
+	}
+
+	status := cb.Status_NOT_FOUND
+	found := false
+	for _, chainID := range mo.lf.ChainIDs() {
+		found = found || chainID == chdr.ChannelId
+	}
+	if found {
+		rl, _ := mo.lf.GetOrCreate(chdr.ChannelId)
+		for number := seekInfo.Start.GetSpecified().Number; number < rl.Height(); number++ {
+			block := proto.Clone(ledger.GetBlock(rl, number)).(*cb.Block)
+			if mo.tamper != nil {
+				mo.tamper(block)
+			}
+			if err := srv.Send(&ab.DeliverResponse{Type: &ab.DeliverResponse_Block{Block: block}}); err != nil {
+				return err
+			}
+			status = cb.Status_SUCCESS
+		}
+	}
+	return srv.Send(&ab.DeliverResponse{Type: &ab.DeliverResponse_Status{Status: status}})
+}
+
+// newLedger creates a ledger factory with a chain holding the genesis block
+func newLedger(genesisBlock *cb.Block) (ledger.Factory, ledger.ReadWriter) {
+	lf := ramledger.New(100)
+	chainID, _ := utils.GetChainIDFromBlock(genesisBlock)
+	rl, _ := lf.GetOrCreate(chainID)
+	rl.Append(genesisBlock)
+	return lf, rl
+}
+
+// signBlock signs the block the way the orderers do
+func signBlock(block *cb.Block) {
+	signer := localmsp.NewSigner()
+	sig := &cb.MetadataSignature{SignatureHeader: utils.MarshalOrPanic(utils.NewSignatureHeaderOrPanic(signer))}
+	sig.Signature = utils.SignOrPanic(signer, util.ConcatenateBytes(nil, sig.SignatureHeader, block.Header.Bytes()))
+	block.Metadata.Metadata[cb.BlockMetadataIndex_SIGNATURES] = utils.MarshalOrPanic(&cb.Metadata{Signatures: []*cb.MetadataSignature{sig}})
+}
+
+// appendBlocks appends signed blocks of one transaction to the ledger
+func appendBlocks(rl ledger.ReadWriter, count int) {
+	for i := 0; i < count; i++ {
+		block := ledger.CreateNextBlock(rl, []*cb.Envelope{&cb.Envelope{Payload: []byte("My Data")}})
+		signBlock(block)
+		rl.Append(block)
+	}
+}
+
+func newReplicator(lf ledger.Factory, endpoints ...string) Replicator {
+	return New(lf, localmsp.NewSigner(), endpoints, 100*time.Millisecond, grpc.WithInsecure())
+}
+
+func assertSameBlocks(t *testing.T, expected, actual ledger.Reader) {
+	if !assert.Equal(t, expected.Height(), actual.Height()) {
+		return
+	}
+	for number := uint64(0); number < expected.Height(); number++ {
+		assert.True(t, proto.Equal(ledger.GetBlock(expected, number), ledger.GetBlock(actual, number)), "Block %d should be replicated", number)
+	}
+}
+
+func TestReplicate(t *testing.T) {
+	genesisBlock := bootstrapper.GenesisBlock()
+	sourceLF, source := newLedger(genesisBlock)
+	appendBlocks(source, 3)
+	mo := newMockOrderer(t, sourceLF)
+	defer mo.server.Stop()
+
+	lf, rl := newLedger(genesisBlock)
+	assert.NoError(t, newReplicator(lf, mo.addr).Replicate())
+	assertSameBlocks(t, source, rl)
+
+	// Only the blocks appended meanwhile are pulled again
+	appendBlocks(source, 2)
+	assert.NoError(t, newReplicator(lf, mo.addr).Replicate())
+	assertSameBlocks(t, source, rl)
+
+	// And nothing is pulled once up to date
+	assert.NoError(t, newReplicator(lf, mo.addr).Replicate())
+	assertSameBlocks(t, source, rl)
+}
+
+func TestReplicateFromEveryEndpoint(t *testing.T) {
+	genesisBlock := bootstrapper.GenesisBlock()
+	behindLF, behind := newLedger(genesisBlock)
+	appendBlocks(behind, 2)
+	aheadLF, ahead := newLedger(genesisBlock)
+	for number := uint64(1); number < behind.Height(); number++ {
+		ahead.Append(ledger.GetBlock(behind, number))
+	}
+	appendBlocks(ahead, 2)
+
+	behindOrderer := newMockOrderer(t, behindLF)
+	defer behindOrderer.server.Stop()
+	aheadOrderer := newMockOrderer(t, aheadLF)
+	defer aheadOrderer.server.Stop()
+	stopped := newMockOrderer(t, aheadLF)
+	stopped.server.Stop()
+
+	lf, rl := newLedger(genesisBlock)
+	assert.NoError(t, newReplicator(lf, stopped.addr, behindOrderer.addr, aheadOrderer.addr).Replicate())
+	assertSameBlocks(t, ahead, rl)
+}
+
+func TestReplicateUnreachable(t *testing.T) {
+	stopped := newMockOrderer(t, ramledger.New(10))
+	stopped.server.Stop()
+
+	lf, rl := newLedger(bootstrapper.GenesisBlock())
+	assert.Error(t, newReplicator(lf, stopped.addr).Replicate(), "Should fail when no orderer can be pulled from")
+	assert.Equal(t, uint64(1), rl.Height())
+}
+
+func TestReplicateWithoutGenesisBlock(t *testing.T) {
+	sourceLF, source := newLedger(bootstrapper.GenesisBlock())
+	appendBlocks(source, 1)
+	mo := newMockOrderer(t, sourceLF)
+	defer mo.server.Stop()
+
+	lf := ramledger.New(10)
+	lf.GetOrCreate(provisional.TestChainID)
+	assert.Error(t, newReplicator(lf, mo.addr).Replicate(), "Should not replicate a chain without genesis block")
+}
+
+func TestReplicateRejected(t *testing.T) {
+	for name, tamper := range map[string]func(block *cb.Block){
+		"DataChanged": func(block *cb.Block) {
+			block.Data.Data[0] = utils.MarshalOrPanic(&cb.Envelope{Payload: []byte("Other Data")})
+			signBlock(block)
+		},
+		"Unchained": func(block *cb.Block) {
+			block.Header.PreviousHash = []byte("Other Hash")
+			signBlock(block)
+		},
+		"Skipped": func(block *cb.Block) {
+			block.Header.Number++
+			signBlock(block)
+		},
+	} {
+		tamper := tamper
+		t.Run(name, func(t *testing.T) {
+			genesisBlock := bootstrapper.GenesisBlock()
+			sourceLF, source := newLedger(genesisBlock)
+			appendBlocks(source, 3)
+			mo := newMockOrderer(t, sourceLF)
+			defer mo.server.Stop()
+			mo.tamper = func(block *cb.Block) {
+				if block.Header.Number == 2 {
+					tamper(block)
+				}
+			}
+
+			lf, rl := newLedger(genesisBlock)
+			assert.Error(t, newReplicator(lf, mo.addr).Replicate())
+			assert.Equal(t, uint64(2), rl.Height(), "Only the blocks before the rejected one should be appended")
+		})
+	}
+}
+
+func TestReplicateCreatedChains(t *testing.T) {
+	genesisBlock := bootstrapper.GenesisBlock()
+	sourceLF, system := newLedger(genesisBlock)
+	appendBlocks(system, 1)
+
+	// The system chain creates a chain, whose genesis block holds the config transaction
+	newChainID := "foo"
+	configTx := utils.ExtractEnvelopeOrPanic(bootstrapper.GenesisBlockForChannel(newChainID), 0)
+	ordererTx, err := utils.CreateSignedEnvelope(cb.HeaderType_ORDERER_TRANSACTION, provisional.TestChainID, localmsp.NewSigner(), configTx, int32(0), uint64(0))
+	assert.NoError(t, err)
+	block := ledger.CreateNextBlock(system, []*cb.Envelope{ordererTx})
+	signBlock(block)
+	system.Append(block)
+	created, _ := sourceLF.GetOrCreate(newChainID)
+	created.Append(ledger.CreateNextBlock(created, []*cb.Envelope{configTx}))
+	appendBlocks(created, 2)
+
+	mo := newMockOrderer(t, sourceLF)
+	defer mo.server.Stop()
+
+	lf, rl := newLedger(genesisBlock)
+	assert.NoError(t, newReplicator(lf, mo.addr).Replicate())
+	assertSameBlocks(t, system, rl)
+	assert.Equal(t, []string{newChainID, provisional.TestChainID}, sortedChainIDs(lf))
+	createdReplica, _ := lf.GetOrCreate(newChainID)
+	assertSameBlocks(t, created, createdReplica)
+}
+
+// recordingPolicy records the signatures it evaluates
+type recordingPolicy struct {
+	signatureSet []*cb.SignedData
+	err          error
+}
+
+func (rp *recordingPolicy) Evaluate(signatureSet []*cb.SignedData) error {
+	rp.signatureSet = signatureSet
+	return rp.err
+}
+
+func TestVerifySignatures(t *testing.T) {
+	_, rl := newLedger(bootstrapper.GenesisBlock())
+	block := ledger.CreateNextBlock(rl, []*cb.Envelope{&cb.Envelope{Payload: []byte("My Data")}})
+	signBlock(block)
+	policy := &recordingPolicy{}
+	replica := &chainReplica{chainID: provisional.TestChainID, ledger: rl, policy: policy}
+
+	assert.NoError(t, replica.verifySignatures(block))
+	metadata := utils.GetMetadataFromBlockOrPanic(block, cb.BlockMetadataIndex_SIGNATURES)
+	shdr, err := utils.GetSignatureHeader(metadata.Signatures[0].SignatureHeader)
+	assert.NoError(t, err)
+	assert.Equal(t, []*cb.SignedData{&cb.SignedData{
+		Identity:  shdr.Creator,
+		Data:      util.ConcatenateBytes(metadata.Value, metadata.Signatures[0].SignatureHeader, block.Header.Bytes()),
+		Signature: metadata.Signatures[0].Signature,
+	}}, policy.signatureSet, "The signatures should be evaluated over the header of the block")
+
+	policy.err = errors.New("Signatures do not satisfy the policy")
+	assert.Error(t, replica.verifySignatures(block))
+
+	block.Metadata.Metadata = nil
+	assert.Error(t, replica.verifySignatures(block), "Block without signatures metadata should be rejected")
+}
+
+func TestAppendRejectsPolicy(t *testing.T) {
+	_, rl := newLedger(bootstrapper.GenesisBlock())
+	replica, err := newChainReplica(provisional.TestChainID, rl)
+	assert.NoError(t, err)
+	replica.policy = &recordingPolicy{err: errors.New("Signatures do not satisfy the policy")}
+
+	block := ledger.CreateNextBlock(rl, []*cb.Envelope{&cb.Envelope{Payload: []byte("My Data")}})
+	signBlock(block)
+	assert.Error(t, replica.append(block))
+	assert.Equal(t, uint64(1), rl.Height(), "Block should not be appended")
+}
+
+func sortedChainIDs(lf ledger.Factory) []string {
+	chainIDs := lf.ChainIDs()
+	for i := range chainIDs {
+		for j := i + 1; j < len(chainIDs); j++ {
+			if chainIDs[j] < chainIDs[i] {
+				chainIDs[i], chainIDs[j] = chainIDs[j], chainIDs[i]
+			}
+		}
+	}
+	return chainIDs
+}
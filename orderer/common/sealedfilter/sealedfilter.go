@@ -0,0 +1,54 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+                 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sealedfilter
+
+import (
+	"github.com/hyperledger/fabric/common/config"
+	"github.com/hyperledger/fabric/orderer/common/filter"
+	ab "github.com/hyperledger/fabric/protos/common"
+	logging "github.com/op/go-logging"
+)
+
+var logger = logging.MustGetLogger("orderer/common/sealedfilter")
+
+// Support provides the channel configuration the channel state is read from
+type Support interface {
+	// SharedConfig returns the current orderer config of the channel
+	SharedConfig() config.Orderer
+}
+
+// New creates a rule which rejects all messages once the channel configuration seals the channel
+func New(support Support) filter.Rule {
+	return &sealedRule{support: support}
+}
+
+type sealedRule struct {
+	support Support
+}
+
+// Sealed returns whether the channel is sealed, it implements filter.Sealer
+func (r *sealedRule) Sealed() bool {
+	return r.support.SharedConfig().Sealed()
+}
+
+func (r *sealedRule) Apply(message *ab.Envelope) (filter.Action, filter.Committer) {
+	if r.Sealed() {
+		logger.Debugf("Rejecting message as the channel is sealed")
+		return filter.Reject, nil
+	}
+	return filter.Forward, nil
+}
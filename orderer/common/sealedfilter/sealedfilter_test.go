@@ -0,0 +1,49 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+                 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sealedfilter
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric/common/config"
+	mockconfig "github.com/hyperledger/fabric/common/mocks/configvalues/channel/orderer"
+	"github.com/hyperledger/fabric/orderer/common/filter"
+	cb "github.com/hyperledger/fabric/protos/common"
+)
+
+type mockSupport struct {
+	sharedConfig *mockconfig.SharedConfig
+}
+
+func (ms *mockSupport) SharedConfig() config.Orderer {
+	return ms.sharedConfig
+}
+
+func TestSealedRule(t *testing.T) {
+	support := &mockSupport{sharedConfig: &mockconfig.SharedConfig{}}
+	rs := filter.NewRuleSet([]filter.Rule{New(support), filter.AcceptRule})
+
+	if _, err := rs.Apply(&cb.Envelope{Payload: []byte("Some bytes")}); err != nil {
+		t.Fatalf("Should have accepted the message of the unsealed channel, got %s", err)
+	}
+
+	support.sharedConfig.SealedVal = true
+	_, err := rs.Apply(&cb.Envelope{Payload: []byte("Some bytes")})
+	if _, ok := err.(*filter.SealedError); !ok {
+		t.Fatalf("Should have rejected the message as the channel is sealed, got %v", err)
+	}
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package sizefilter
 
 import (
+	"github.com/hyperledger/fabric/common/config"
 	"github.com/hyperledger/fabric/orderer/common/filter"
 	ab "github.com/hyperledger/fabric/protos/common"
 	logging "github.com/op/go-logging"
@@ -24,18 +25,29 @@ import (
 
 var logger = logging.MustGetLogger("orderer/common/sizefilter")
 
-// MaxBytesRule rejects messages larger than maxBytes
-func MaxBytesRule(maxBytes uint32) filter.Rule {
-	return &maxBytesRule{maxBytes: maxBytes}
+// Support provides the channel configuration the maximum message size is read from
+type Support interface {
+	// SharedConfig returns the current orderer config of the channel
+	SharedConfig() config.Orderer
+}
+
+// MaxBytesRule rejects messages larger than the AbsoluteMaxBytes of the current channel configuration
+func MaxBytesRule(support Support) filter.Rule {
+	return &maxBytesRule{support: support}
 }
 
 type maxBytesRule struct {
-	maxBytes uint32
+	support Support
+}
+
+// MaxBytes returns the maximum size in bytes of a message, it implements filter.SizeLimiter
+func (r *maxBytesRule) MaxBytes() uint32 {
+	return r.support.SharedConfig().BatchSize().AbsoluteMaxBytes
 }
 
 func (r *maxBytesRule) Apply(message *ab.Envelope) (filter.Action, filter.Committer) {
-	if size := messageByteSize(message); size > r.maxBytes {
-		logger.Warningf("%d byte message payload exceeds maximum allowed %d bytes", size, r.maxBytes)
+	if size, maxBytes := messageByteSize(message), r.MaxBytes(); size > maxBytes {
+		logger.Warningf("%d byte message payload exceeds maximum allowed %d bytes", size, maxBytes)
 		return filter.Reject, nil
 	}
 	return filter.Forward, nil
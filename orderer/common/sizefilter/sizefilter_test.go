@@ -20,14 +20,29 @@ import (
 	"testing"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/config"
+	mockconfig "github.com/hyperledger/fabric/common/mocks/configvalues/channel/orderer"
 	"github.com/hyperledger/fabric/orderer/common/filter"
 	cb "github.com/hyperledger/fabric/protos/common"
+	ab "github.com/hyperledger/fabric/protos/orderer"
 )
 
+type mockSupport struct {
+	sharedConfig *mockconfig.SharedConfig
+}
+
+func (ms *mockSupport) SharedConfig() config.Orderer {
+	return ms.sharedConfig
+}
+
+func newMockSupport(maxBytes uint32) *mockSupport {
+	return &mockSupport{sharedConfig: &mockconfig.SharedConfig{BatchSizeVal: &ab.BatchSize{AbsoluteMaxBytes: maxBytes}}}
+}
+
 func TestMaxBytesRule(t *testing.T) {
 	dataSize := uint32(100)
 	maxBytes := calcMessageBytesForPayloadDataSize(dataSize)
-	rs := filter.NewRuleSet([]filter.Rule{MaxBytesRule(maxBytes), filter.AcceptRule})
+	rs := filter.NewRuleSet([]filter.Rule{MaxBytesRule(newMockSupport(maxBytes)), filter.AcceptRule})
 
 	t.Run("LessThan", func(t *testing.T) {
 		_, err := rs.Apply(makeMessage(make([]byte, dataSize-1)))
@@ -43,12 +58,31 @@ func TestMaxBytesRule(t *testing.T) {
 	})
 	t.Run("TooBig", func(t *testing.T) {
 		_, err := rs.Apply(makeMessage(make([]byte, dataSize+1)))
-		if err == nil {
-			t.Fatalf("Should have rejected")
+		tooLarge, ok := err.(*filter.TooLargeError)
+		if !ok {
+			t.Fatalf("Should have rejected as too large, got %v", err)
+		}
+		if tooLarge.MaxBytes != maxBytes {
+			t.Fatalf("Should have reported a maximum of %d bytes, got %d", maxBytes, tooLarge.MaxBytes)
 		}
 	})
 }
 
+func TestMaxBytesRuleConfigUpdate(t *testing.T) {
+	dataSize := uint32(100)
+	support := newMockSupport(calcMessageBytesForPayloadDataSize(dataSize))
+	rs := filter.NewRuleSet([]filter.Rule{MaxBytesRule(support), filter.AcceptRule})
+
+	if _, err := rs.Apply(makeMessage(make([]byte, dataSize+1))); err == nil {
+		t.Fatalf("Should have rejected")
+	}
+
+	support.sharedConfig.BatchSizeVal = &ab.BatchSize{AbsoluteMaxBytes: calcMessageBytesForPayloadDataSize(2 * dataSize)}
+	if _, err := rs.Apply(makeMessage(make([]byte, dataSize+1))); err != nil {
+		t.Fatalf("Should have accepted after the maximum was raised, got %v", err)
+	}
+}
+
 func calcMessageBytesForPayloadDataSize(dataSize uint32) uint32 {
 	return messageByteSize(makeMessage(make([]byte, dataSize)))
 }
@@ -144,6 +144,10 @@ func createInitialConfig(envConfigUpdate *cb.Envelope) (*cb.ConfigEnvelope, erro
 }
 
 func (p *Processor) newChannelConfig(channelID string, envConfigUpdate *cb.Envelope) (*cb.Envelope, error) {
+	if p.systemChannelID == "" {
+		return nil, fmt.Errorf("Channel %s does not exist and cannot be created without a system channel, the orderer must be joined to it", channelID)
+	}
+
 	initialConfig, err := createInitialConfig(envConfigUpdate)
 	if err != nil {
 		return nil, err
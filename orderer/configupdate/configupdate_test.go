@@ -155,3 +155,10 @@ func TestNewChannel(t *testing.T) {
 
 	assert.Equal(t, int32(cb.HeaderType_ORDERER_TRANSACTION), chdr.Type, "Wrong wrapper tx type")
 }
+
+func TestNewChannelWithoutSystemChannel(t *testing.T) {
+	p := New("", &mockSupportManager{}, mockcrypto.FakeLocalSigner)
+
+	_, err := p.Process(testConfigUpdate())
+	assert.Error(t, err, "Channels cannot be created without a system channel")
+}
@@ -20,6 +20,7 @@ import (
 	"fmt"
 
 	"github.com/Shopify/sarama"
+	"github.com/hyperledger/fabric/orderer/localconfig"
 )
 
 // Broker allows the caller to get info on the cluster's partitions
@@ -34,13 +35,14 @@ type brokerImpl struct {
 
 // Connects to the broker that handles all produce and consume
 // requests for the given chain (Partition Leader Replica)
-func newBroker(brokers []string, cp ChainPartition) (Broker, error) {
+func newBroker(brokers []string, kafkaVersion sarama.KafkaVersion, tls config.TLS, sasl config.SASL, cp ChainPartition) (Broker, error) {
 	var candidateBroker, connectedBroker, leaderBroker *sarama.Broker
+	brokerConfig := newBrokerConfig(kafkaVersion, rawPartition, tls, sasl)
 
 	// Connect to one of the given brokers
 	for _, hostPort := range brokers {
 		candidateBroker = sarama.NewBroker(hostPort)
-		if err := candidateBroker.Open(nil); err != nil {
+		if err := candidateBroker.Open(brokerConfig); err != nil {
 			logger.Warningf("Failed to connect to broker %s: %s", hostPort, err)
 			continue
 		}
@@ -80,7 +82,7 @@ func newBroker(brokers []string, cp ChainPartition) (Broker, error) {
 	}
 
 	// Connect to broker
-	if err := leaderBroker.Open(nil); err != nil {
+	if err := leaderBroker.Open(brokerConfig); err != nil {
 		return nil, fmt.Errorf("Failed to connect ho Kafka broker: %s", err)
 	}
 	if connected, err := leaderBroker.Connected(); !connected {
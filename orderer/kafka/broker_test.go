@@ -21,6 +21,7 @@ import (
 
 	"github.com/Shopify/sarama"
 	"github.com/hyperledger/fabric/common/configtx/tool/provisional"
+	"github.com/hyperledger/fabric/orderer/localconfig"
 )
 
 func TestBrokerGetOffset(t *testing.T) {
@@ -72,7 +73,7 @@ func TestNewBrokerReturnsPartitionLeader(t *testing.T) {
 	})
 
 	// Get leader for the test chain partition
-	leaderBroker, _ := newBroker([]string{broker1.Addr(), broker2.Addr()}, cp)
+	leaderBroker, _ := newBroker([]string{broker1.Addr(), broker2.Addr()}, testConf.Kafka.Version, config.TLS{Enabled: false}, config.SASL{Enabled: false}, cp)
 
 	// Only broker3 will respond successfully to an offset request
 	offsetRequest := new(sarama.OffsetRequest)
@@ -31,6 +31,7 @@ var (
 	testOldestOffset = int64(100)                                    // The oldest block available on the broker
 	testNewestOffset = int64(1100)                                   // The offset that will be assigned to the next block
 	testMiddleOffset = (testOldestOffset + testNewestOffset - 1) / 2 // Just an offset in the middle
+	testReplicas     = 1                                             // The count of replicas of each partition on the broker
 
 	// Amount of time to wait for block processing when doing time-based tests
 	// We generally want this value to be as small as possible so as to make tests execute faster
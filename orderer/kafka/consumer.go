@@ -34,8 +34,8 @@ type consumerImpl struct {
 	partition sarama.PartitionConsumer
 }
 
-func newConsumer(brokers []string, kafkaVersion sarama.KafkaVersion, tls config.TLS, cp ChainPartition, offset int64) (Consumer, error) {
-	parent, err := sarama.NewConsumer(brokers, newBrokerConfig(kafkaVersion, rawPartition, tls))
+func newConsumer(brokers []string, kafkaVersion sarama.KafkaVersion, tls config.TLS, sasl config.SASL, cp ChainPartition, offset int64) (Consumer, error) {
+	parent, err := sarama.NewConsumer(brokers, newBrokerConfig(kafkaVersion, rawPartition, tls, sasl))
 	if err != nil {
 		return nil, err
 	}
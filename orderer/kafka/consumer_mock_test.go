@@ -121,7 +121,7 @@ func (mc *mockConsumerImpl) Close() error {
 func (mc *mockConsumerImpl) testFillWithBlocks(offset int64) {
 	for i := int64(1); i <= offset; i++ {
 		go func() {
-			mc.disk <- newRegularMessage(utils.MarshalOrPanic(newTestEnvelope(fmt.Sprintf("consumer fill-in %d", i))))
+			mc.disk <- newRegularMessage(utils.MarshalOrPanic(newTestEnvelope(fmt.Sprintf("consumer fill-in %d", i))), 0)
 		}()
 		<-mc.Recv()
 	}
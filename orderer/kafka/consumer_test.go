@@ -52,7 +52,7 @@ func testConsumerRecvFunc(given, expected int64) func(t *testing.T) {
 		}
 		<-mc.(*mockConsumerImpl).isSetup
 		go func() {
-			disk <- newRegularMessage([]byte("foo"))
+			disk <- newRegularMessage([]byte("foo"), 0)
 		}()
 		msg := <-mc.Recv()
 		if (msg.Topic != cp.Topic()) ||
@@ -17,7 +17,7 @@ limitations under the License.
 package kafka
 
 import (
-	"time"
+	"fmt"
 
 	"github.com/Shopify/sarama"
 	"github.com/golang/protobuf/proto"
@@ -28,39 +28,55 @@ import (
 	"github.com/hyperledger/fabric/protos/utils"
 )
 
+const (
+	msgVersion = int32(0)
+	epoch      = 0
+
+	// maxOriginalOffsetsProcessed bounds the original offsets of resubmitted messages kept to drop their copies
+	maxOriginalOffsetsProcessed = 1000
+)
+
 // New creates a Kafka-backed consenter. Called by orderer's main.go.
-func New(kv sarama.KafkaVersion, ro config.Retry, tls config.TLS) multichain.Consenter {
-	return newConsenter(kv, ro, tls, bfValue, pfValue, cfValue)
+func New(kv sarama.KafkaVersion, ro config.Retry, tls config.TLS, sasl config.SASL) multichain.Consenter {
+	return newConsenter(kv, ro, tls, sasl, bfValue, pfValue, cfValue, tfValue)
 }
 
 // New calls here because we need to pass additional arguments to
 // the constructor and New() should only read from the config file.
-func newConsenter(kv sarama.KafkaVersion, ro config.Retry, tls config.TLS, bf bfType, pf pfType, cf cfType) multichain.Consenter {
-	return &consenterImpl{kv, ro, tls, bf, pf, cf}
+func newConsenter(kv sarama.KafkaVersion, ro config.Retry, tls config.TLS, sasl config.SASL, bf bfType, pf pfType, cf cfType, tf tfType) multichain.Consenter {
+	return &consenterImpl{kv, ro, tls, sasl, bf, pf, cf, tf}
 }
 
 // bfType defines the signature of the broker constructor.
-type bfType func([]string, ChainPartition) (Broker, error)
+type bfType func([]string, sarama.KafkaVersion, config.TLS, config.SASL, ChainPartition) (Broker, error)
 
 // pfType defines the signature of the producer constructor.
-type pfType func([]string, sarama.KafkaVersion, config.Retry, config.TLS) Producer
+type pfType func([]string, sarama.KafkaVersion, config.Retry, config.TLS, config.SASL) Producer
 
 // cfType defines the signature of the consumer constructor.
-type cfType func([]string, sarama.KafkaVersion, config.TLS, ChainPartition, int64) (Consumer, error)
+type cfType func([]string, sarama.KafkaVersion, config.TLS, config.SASL, ChainPartition, int64) (Consumer, error)
+
+// tfType defines the signature of the function retrieving the count of replicas of a chain partition.
+type tfType func([]string, sarama.KafkaVersion, config.TLS, config.SASL, ChainPartition) (int, error)
 
 // bfValue holds the value for the broker constructor that's used in the non-test case.
-var bfValue = func(brokers []string, cp ChainPartition) (Broker, error) {
-	return newBroker(brokers, cp)
+var bfValue = func(brokers []string, kafkaVersion sarama.KafkaVersion, tls config.TLS, sasl config.SASL, cp ChainPartition) (Broker, error) {
+	return newBroker(brokers, kafkaVersion, tls, sasl, cp)
 }
 
 // pfValue holds the value for the producer constructor that's used in the non-test case.
-var pfValue = func(brokers []string, kafkaVersion sarama.KafkaVersion, retryOptions config.Retry, tls config.TLS) Producer {
-	return newProducer(brokers, kafkaVersion, retryOptions, tls)
+var pfValue = func(brokers []string, kafkaVersion sarama.KafkaVersion, retryOptions config.Retry, tls config.TLS, sasl config.SASL) Producer {
+	return newProducer(brokers, kafkaVersion, retryOptions, tls, sasl)
 }
 
 // cfValue holds the value for the consumer constructor that's used in the non-test case.
-var cfValue = func(brokers []string, kafkaVersion sarama.KafkaVersion, tls config.TLS, cp ChainPartition, offset int64) (Consumer, error) {
-	return newConsumer(brokers, kafkaVersion, tls, cp, offset)
+var cfValue = func(brokers []string, kafkaVersion sarama.KafkaVersion, tls config.TLS, sasl config.SASL, cp ChainPartition, offset int64) (Consumer, error) {
+	return newConsumer(brokers, kafkaVersion, tls, sasl, cp, offset)
+}
+
+// tfValue holds the value for the function retrieving the count of replicas that's used in the non-test case.
+var tfValue = func(brokers []string, kafkaVersion sarama.KafkaVersion, tls config.TLS, sasl config.SASL, cp ChainPartition) (int, error) {
+	return getTopicReplicas(brokers, kafkaVersion, tls, sasl, cp)
 }
 
 // consenterImpl holds the implementation of type that satisfies the
@@ -68,31 +84,42 @@ var cfValue = func(brokers []string, kafkaVersion sarama.KafkaVersion, tls confi
 // is needed because that is what the HandleChain contract requires.
 // The latter is needed for testing.
 type consenterImpl struct {
-	kv  sarama.KafkaVersion
-	ro  config.Retry
-	tls config.TLS
-	bf  bfType
-	pf  pfType
-	cf  cfType
+	kv   sarama.KafkaVersion
+	ro   config.Retry
+	tls  config.TLS
+	sasl config.SASL
+	bf   bfType
+	pf   pfType
+	cf   cfType
+	tf   tfType
 }
 
 // HandleChain creates/returns a reference to a Chain for the given set of support resources.
 // Implements the multichain.Consenter interface. Called by multichain.newChainSupport(), which
 // is itself called by multichain.NewManagerImpl() when ranging over the ledgerFactory's existingChains.
 func (co *consenterImpl) HandleChain(cs multichain.ConsenterSupport, metadata *cb.Metadata) (multichain.Chain, error) {
-	return newChain(co, cs, getLastOffsetPersisted(metadata)), nil
+	kafkaMetadata := getKafkaMetadata(metadata)
+	ch := newChain(co, cs, kafkaMetadata.LastOffsetPersisted)
+	// The time-to-cut messages for the blocks of the ledger, and the copies of the
+	// resubmitted messages which were ordered already, are stale after a restart
+	ch.lastCutBlock = cs.Reader().Height() - 1
+	ch.originalOffsetsProcessed = kafkaMetadata.OriginalOffsetsProcessed
+	return ch, nil
 }
 
-func getLastOffsetPersisted(metadata *cb.Metadata) int64 {
+func getKafkaMetadata(metadata *cb.Metadata) *ab.KafkaMetadata {
+	kafkaMetadata := &ab.KafkaMetadata{LastOffsetPersisted: sarama.OffsetOldest - 1} // default
 	if metadata.Value != nil {
 		// Extract orderer-related metadata from the tip of the ledger first
-		kafkaMetadata := &ab.KafkaMetadata{}
 		if err := proto.Unmarshal(metadata.Value, kafkaMetadata); err != nil {
 			panic("Ledger may be corrupted: cannot unmarshal orderer metadata in most recent block")
 		}
-		return kafkaMetadata.LastOffsetPersisted
 	}
-	return (sarama.OffsetOldest - 1) // default
+	return kafkaMetadata
+}
+
+func getLastOffsetPersisted(metadata *cb.Metadata) int64 {
+	return getKafkaMetadata(metadata).LastOffsetPersisted
 }
 
 // When testing we need to inject our own broker/producer/consumer.
@@ -109,9 +136,8 @@ func newChain(consenter testableConsenter, support multichain.ConsenterSupport,
 		consenter:           consenter,
 		support:             support,
 		partition:           newChainPartition(support.ChainID(), rawPartition),
-		batchTimeout:        support.SharedConfig().BatchTimeout(),
 		lastOffsetPersisted: lastOffsetPersisted,
-		producer:            consenter.prodFunc()(support.SharedConfig().KafkaBrokers(), consenter.kafkaVersion(), consenter.retryOptions(), consenter.tlsConfig()),
+		producer:            consenter.prodFunc()(support.SharedConfig().KafkaBrokers(), consenter.kafkaVersion(), consenter.retryOptions(), consenter.tlsConfig(), consenter.saslConfig()),
 		halted:              false, // Redundant as the default value for booleans is false but added for readability
 		exitChan:            make(chan struct{}),
 		haltedChan:          make(chan struct{}),
@@ -125,27 +151,34 @@ type testableConsenter interface {
 	kafkaVersion() sarama.KafkaVersion
 	retryOptions() config.Retry
 	tlsConfig() config.TLS
+	saslConfig() config.SASL
 	brokFunc() bfType
 	prodFunc() pfType
 	consFunc() cfType
+	topicFunc() tfType
 }
 
 func (co *consenterImpl) kafkaVersion() sarama.KafkaVersion { return co.kv }
 func (co *consenterImpl) retryOptions() config.Retry        { return co.ro }
 func (co *consenterImpl) tlsConfig() config.TLS             { return co.tls }
+func (co *consenterImpl) saslConfig() config.SASL           { return co.sasl }
 func (co *consenterImpl) brokFunc() bfType                  { return co.bf }
 func (co *consenterImpl) prodFunc() pfType                  { return co.pf }
 func (co *consenterImpl) consFunc() cfType                  { return co.cf }
+func (co *consenterImpl) topicFunc() tfType                 { return co.tf }
 
 type chainImpl struct {
 	consenter testableConsenter
 	support   multichain.ConsenterSupport
 
 	partition           ChainPartition
-	batchTimeout        time.Duration
 	lastOffsetPersisted int64
 	lastCutBlock        uint64
 
+	// originalOffsetsProcessed are the original offsets of the resubmitted messages which were
+	// ordered, in the order they were ordered
+	originalOffsetsProcessed []int64
+
 	producer Producer
 	consumer Consumer
 
@@ -161,7 +194,19 @@ type chainImpl struct {
 // Implements the multichain.Chain interface. Called by multichain.NewManagerImpl()
 // which is invoked when the ordering process is launched, before the call to NewServer().
 func (ch *chainImpl) Start() {
-	// 1. Post the CONNECT message to prevent panicking that occurs
+	// 1. Verify the topic of the partition exists beforehand if it
+	// is required to, rather than letting the CONNECT message create it.
+	topic := ch.support.SharedConfig().KafkaTopic()
+	if topic.RequireExisting {
+		if err := ch.checkTopic(topic); err != nil {
+			logger.Criticalf("Cannot start chain %s: %s", ch.partition, err)
+			close(ch.exitChan)
+			ch.halted = true
+			return
+		}
+	}
+
+	// 2. Post the CONNECT message to prevent panicking that occurs
 	// when seeking on a partition that hasn't been created yet.
 	logger.Debug("Posting the CONNECT message...")
 	if err := ch.producer.Send(ch.partition, utils.MarshalOrPanic(newConnectMessage())); err != nil {
@@ -171,8 +216,18 @@ func (ch *chainImpl) Start() {
 		return
 	}
 
-	// 2. Set up the listener/consumer for this partition.
-	consumer, err := ch.consenter.consFunc()(ch.support.SharedConfig().KafkaBrokers(), ch.consenter.kafkaVersion(), ch.consenter.tlsConfig(), ch.partition, ch.lastOffsetPersisted+1)
+	// 3. Verify the replicas of the partition, once it was created.
+	if !topic.RequireExisting && topic.MinReplicas > 0 {
+		if err := ch.checkTopic(topic); err != nil {
+			logger.Criticalf("Cannot start chain %s: %s", ch.partition, err)
+			close(ch.exitChan)
+			ch.halted = true
+			return
+		}
+	}
+
+	// 4. Set up the listener/consumer for this partition.
+	consumer, err := ch.consenter.consFunc()(ch.support.SharedConfig().KafkaBrokers(), ch.consenter.kafkaVersion(), ch.consenter.tlsConfig(), ch.consenter.saslConfig(), ch.partition, ch.lastOffsetPersisted+1)
 	if err != nil {
 		logger.Criticalf("Cannot retrieve required offset from Kafka cluster for chain %s: %s", ch.partition, err)
 		close(ch.exitChan)
@@ -183,7 +238,7 @@ func (ch *chainImpl) Start() {
 	close(ch.setupChan)
 	go ch.listenForErrors()
 
-	// 3. Set the loop the keep up to date with the chain.
+	// 5. Set the loop the keep up to date with the chain.
 	go ch.loop()
 }
 
@@ -196,6 +251,16 @@ func (ch *chainImpl) listenForErrors() {
 	}
 }
 
+// checkTopic verifies the partition of the chain on the Kafka cluster
+// against the checks of its topic.
+func (ch *chainImpl) checkTopic(topic *ab.KafkaTopic) error {
+	replicas, err := ch.consenter.topicFunc()(ch.support.SharedConfig().KafkaBrokers(), ch.consenter.kafkaVersion(), ch.consenter.tlsConfig(), ch.consenter.saslConfig(), ch.partition)
+	if err != nil {
+		return fmt.Errorf("Cannot retrieve the replicas of the partition: %s", err)
+	}
+	return checkTopic(topic, ch.partition, replicas)
+}
+
 // Halt frees the resources which were allocated for this Chain.
 // Implements the multichain.Chain interface.
 func (ch *chainImpl) Halt() {
@@ -209,6 +274,23 @@ func (ch *chainImpl) Halt() {
 	}
 }
 
+// HealthCheck returns an error unless the chain is set up to consume its partition.
+// Implements the multichain.HealthChecker interface. Called by the operations service
+// to report whether the orderer is ready.
+func (ch *chainImpl) HealthCheck() error {
+	select {
+	case <-ch.exitChan:
+		return fmt.Errorf("Chain %s is halted", ch.partition)
+	default:
+	}
+	select {
+	case <-ch.setupChan:
+		return nil
+	default:
+		return fmt.Errorf("Chain %s is not connected to the Kafka cluster yet", ch.partition)
+	}
+}
+
 // Enqueue accepts a message and returns true on acceptance, or false on shutdown.
 // Implements the multichain.Chain interface. Called by the drainQueue goroutine,
 // which is spawned when the broadcast handler's Handle() function is invoked.
@@ -218,7 +300,8 @@ func (ch *chainImpl) Enqueue(env *cb.Envelope) bool {
 	}
 
 	logger.Debug("Enqueueing:", env)
-	if err := ch.producer.Send(ch.partition, utils.MarshalOrPanic(newRegularMessage(utils.MarshalOrPanic(env)))); err != nil {
+	// The envelope was validated by the broadcast filters against the current config
+	if err := ch.producer.Send(ch.partition, utils.MarshalOrPanic(newRegularMessage(utils.MarshalOrPanic(env), ch.support.Sequence()))); err != nil {
 		logger.Errorf("Couldn't post to %s: %s", ch.partition, err)
 		return false
 	}
@@ -228,7 +311,6 @@ func (ch *chainImpl) Enqueue(env *cb.Envelope) bool {
 
 func (ch *chainImpl) loop() {
 	msg := new(ab.KafkaMessage)
-	var timer <-chan time.Time
 	var ttcNumber uint64
 	var encodedLastOffsetPersisted []byte
 
@@ -253,8 +335,6 @@ func (ch *chainImpl) loop() {
 				ttcNumber = msg.GetTimeToCut().BlockNumber
 				logger.Debug("It's a time-to-cut message for block", ttcNumber)
 				if ttcNumber == ch.lastCutBlock+1 {
-					timer = nil
-					logger.Debug("Nil'd the timer")
 					batch, committers := ch.support.BlockCutter().Cut()
 					if len(batch) == 0 {
 						logger.Warningf("Got right time-to-cut message (%d) but no pending requests - this might indicate a bug", ch.lastCutBlock)
@@ -262,7 +342,7 @@ func (ch *chainImpl) loop() {
 						return
 					}
 					block := ch.support.CreateNextBlock(batch)
-					encodedLastOffsetPersisted = utils.MarshalOrPanic(&ab.KafkaMetadata{LastOffsetPersisted: in.Offset})
+					encodedLastOffsetPersisted = ch.encodeMetadata(in.Offset)
 					ch.support.WriteBlock(block, committers, encodedLastOffsetPersisted)
 					ch.lastCutBlock++
 					logger.Debug("Proper time-to-cut received, just cut block", ch.lastCutBlock)
@@ -274,37 +354,48 @@ func (ch *chainImpl) loop() {
 				}
 				logger.Debug("Ignoring stale time-to-cut-message for", ch.lastCutBlock)
 			case *ab.KafkaMessage_Regular:
+				regular := msg.GetRegular()
 				env := new(cb.Envelope)
-				if err := proto.Unmarshal(msg.GetRegular().Payload, env); err != nil {
+				if err := proto.Unmarshal(regular.Payload, env); err != nil {
 					// This shouldn't happen, it should be filtered at ingress
 					logger.Critical("Unable to unmarshal consumed regular message:", err)
 					continue
 				}
+				if regular.Resubmitted && ch.processed(regular.OriginalOffset) {
+					logger.Debugf("Dropping copy of the message resubmitted from offset %d, which was ordered already", regular.OriginalOffset)
+					continue
+				}
+				// The block cutter filters every message again, but a config message which was validated
+				// against an older config must be computed again from its config update
+				if regular.ConfigSeq < ch.support.Sequence() && isConfigMessage(env) {
+					originalOffset := in.Offset
+					if regular.Resubmitted {
+						originalOffset = regular.OriginalOffset
+					}
+					ch.resubmit(env, originalOffset)
+					continue
+				}
 				batches, committers, ok := ch.support.BlockCutter().Ordered(env)
 				logger.Debugf("Ordering results: batches: %v, ok: %v", batches, ok)
-				if ok && len(batches) == 0 && timer == nil {
-					timer = time.After(ch.batchTimeout)
-					logger.Debugf("Just began %s batch timer", ch.batchTimeout.String())
-					continue
+				if ok && regular.Resubmitted {
+					ch.markProcessed(regular.OriginalOffset)
 				}
 				// If !ok, batches == nil, so this will be skipped
 				for i, batch := range batches {
 					block := ch.support.CreateNextBlock(batch)
-					encodedLastOffsetPersisted = utils.MarshalOrPanic(&ab.KafkaMetadata{LastOffsetPersisted: in.Offset})
+					encodedLastOffsetPersisted = ch.encodeMetadata(in.Offset)
 					ch.support.WriteBlock(block, committers[i], encodedLastOffsetPersisted)
 					ch.lastCutBlock++
 					logger.Debug("Batch filled, just cut block", ch.lastCutBlock)
 				}
-				if len(batches) > 0 {
-					timer = nil
-				}
 			}
-		case <-timer:
+		case <-ch.support.BlockCutter().Timer().C():
+			// The expired timer is only stopped once the time-to-cut message is consumed and the batch cut
 			logger.Debugf("Time-to-cut block %d timer expired", ch.lastCutBlock+1)
-			timer = nil
 			if err := ch.producer.Send(ch.partition, utils.MarshalOrPanic(newTimeToCutMessage(ch.lastCutBlock+1))); err != nil {
 				logger.Errorf("Couldn't post to %s: %s", ch.partition, err)
-				// Do not exit
+				// Do not exit, retry once the batch timeout expires again
+				ch.support.BlockCutter().Timer().Restart()
 			}
 		case <-ch.exitChan: // When Halt() is called
 			logger.Infof("Consenter for chain %s exiting", ch.partition.Topic())
@@ -313,6 +404,56 @@ func (ch *chainImpl) loop() {
 	}
 }
 
+// resubmit computes the config message of a config update again against the current config, and
+// posts it to be ordered. As every orderer resubmits the message, the copies following the first
+// one which is ordered are dropped by their original offset.
+func (ch *chainImpl) resubmit(env *cb.Envelope, originalOffset int64) {
+	configEnv := &cb.ConfigEnvelope{}
+	if _, err := utils.UnmarshalEnvelopeOfType(env, cb.HeaderType_CONFIG, configEnv); err != nil {
+		logger.Warningf("Discarding malformed config message from offset %d: %s", originalOffset, err)
+		return
+	}
+	newConfigEnv, err := ch.support.ProposeConfigUpdate(configEnv.LastUpdate)
+	if err != nil {
+		logger.Warningf("Discarding config message from offset %d, which is invalid against the current config: %s", originalOffset, err)
+		return
+	}
+	newEnv, err := utils.CreateSignedEnvelope(cb.HeaderType_CONFIG, ch.support.ChainID(), ch.support, newConfigEnv, msgVersion, epoch)
+	if err != nil {
+		logger.Errorf("Cannot create the config message resubmitting offset %d: %s", originalOffset, err)
+		return
+	}
+	logger.Debugf("Resubmitting the config message from offset %d", originalOffset)
+	if err := ch.producer.Send(ch.partition, utils.MarshalOrPanic(newResubmittedMessage(utils.MarshalOrPanic(newEnv), ch.support.Sequence(), originalOffset))); err != nil {
+		logger.Errorf("Couldn't post to %s: %s", ch.partition, err)
+	}
+}
+
+// processed returns whether a message resubmitted from the original offset was ordered already
+func (ch *chainImpl) processed(originalOffset int64) bool {
+	for _, offset := range ch.originalOffsetsProcessed {
+		if offset == originalOffset {
+			return true
+		}
+	}
+	return false
+}
+
+// markProcessed records that a message resubmitted from the original offset was ordered. Only the
+// last maxOriginalOffsetsProcessed are kept, the copies of a message are posted by the orderers
+// right after they consume it, long before as many other messages are resubmitted.
+func (ch *chainImpl) markProcessed(originalOffset int64) {
+	ch.originalOffsetsProcessed = append(ch.originalOffsetsProcessed, originalOffset)
+	if len(ch.originalOffsetsProcessed) > maxOriginalOffsetsProcessed {
+		ch.originalOffsetsProcessed = ch.originalOffsetsProcessed[1:]
+	}
+}
+
+// encodeMetadata returns the orderer metadata of a block cut at the offset
+func (ch *chainImpl) encodeMetadata(offset int64) []byte {
+	return utils.MarshalOrPanic(&ab.KafkaMetadata{LastOffsetPersisted: offset, OriginalOffsetsProcessed: ch.originalOffsetsProcessed})
+}
+
 // Closeable allows the shut down of the calling resource.
 type Closeable interface {
 	Close() error
@@ -23,8 +23,11 @@ import (
 	"time"
 
 	"github.com/Shopify/sarama"
+	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/configtx/tool/provisional"
 	mockconfigvaluesorderer "github.com/hyperledger/fabric/common/mocks/configvalues/channel/orderer"
+	"github.com/hyperledger/fabric/orderer/ledger"
+	ramledger "github.com/hyperledger/fabric/orderer/ledger/ram"
 	"github.com/hyperledger/fabric/orderer/localconfig"
 	mockblockcutter "github.com/hyperledger/fabric/orderer/mocks/blockcutter"
 	mockmultichain "github.com/hyperledger/fabric/orderer/mocks/multichain"
@@ -37,7 +40,7 @@ import (
 var cp = newChainPartition(provisional.TestChainID, rawPartition)
 
 func newMockSharedConfigManager() *mockconfigvaluesorderer.SharedConfig {
-	return &mockconfigvaluesorderer.SharedConfig{KafkaBrokersVal: testGenesisConf.Orderer.Kafka.Brokers}
+	return &mockconfigvaluesorderer.SharedConfig{KafkaBrokersVal: testGenesisConf.Orderer.Kafka.Brokers, KafkaTopicVal: &ab.KafkaTopic{}}
 }
 
 type mockConsenterImpl struct {
@@ -52,29 +55,35 @@ func mockNewConsenter(t *testing.T, kafkaVersion sarama.KafkaVersion, retryOptio
 	consDisk := make(chan *ab.KafkaMessage)
 
 	mockTLS := config.TLS{Enabled: false}
+	mockSASL := config.SASL{Enabled: false}
 
-	mockBfValue := func(brokers []string, cp ChainPartition) (Broker, error) {
+	mockBfValue := func(brokers []string, kafkaVersion sarama.KafkaVersion, tls config.TLS, sasl config.SASL, cp ChainPartition) (Broker, error) {
 		return mockNewBroker(t, cp)
 	}
-	mockPfValue := func(brokers []string, kafkaVersion sarama.KafkaVersion, retryOptions config.Retry, tls config.TLS) Producer {
+	mockPfValue := func(brokers []string, kafkaVersion sarama.KafkaVersion, retryOptions config.Retry, tls config.TLS, sasl config.SASL) Producer {
 		// The first Send on this producer will return a blob with offset #nextProducedOffset
 		return mockNewProducer(t, cp, nextProducedOffset, prodDisk)
 	}
-	mockCfValue := func(brokers []string, kafkaVersion sarama.KafkaVersion, tls config.TLS, cp ChainPartition, lastPersistedOffset int64) (Consumer, error) {
+	mockCfValue := func(brokers []string, kafkaVersion sarama.KafkaVersion, tls config.TLS, sasl config.SASL, cp ChainPartition, lastPersistedOffset int64) (Consumer, error) {
 		if lastPersistedOffset != nextProducedOffset {
 			panic(fmt.Errorf("Mock objects about to be set up incorrectly (consumer to seek to %d, producer to post %d)", lastPersistedOffset, nextProducedOffset))
 		}
 		return mockNewConsumer(t, cp, lastPersistedOffset, consDisk)
 	}
+	mockTfValue := func(brokers []string, kafkaVersion sarama.KafkaVersion, tls config.TLS, sasl config.SASL, cp ChainPartition) (int, error) {
+		return testReplicas, nil
+	}
 
 	return &mockConsenterImpl{
 		consenterImpl: consenterImpl{
-			kv:  kafkaVersion,
-			ro:  retryOptions,
-			tls: mockTLS,
-			bf:  mockBfValue,
-			pf:  mockPfValue,
-			cf:  mockCfValue,
+			kv:   kafkaVersion,
+			ro:   retryOptions,
+			tls:  mockTLS,
+			sasl: mockSASL,
+			bf:   mockBfValue,
+			pf:   mockPfValue,
+			cf:   mockCfValue,
+			tf:   mockTfValue,
 		},
 		prodDisk: prodDisk,
 		consDisk: consDisk,
@@ -132,8 +141,9 @@ func TestKafkaConsenterEmptyBatch(t *testing.T) {
 		Batches:         make(chan []*cb.Envelope),
 		BlockCutterVal:  mockblockcutter.NewReceiver(),
 		ChainIDVal:      provisional.TestChainID,
-		SharedConfigVal: &mockconfigvaluesorderer.SharedConfig{BatchTimeoutVal: testTimePadding},
+		SharedConfigVal: &mockconfigvaluesorderer.SharedConfig{KafkaTopicVal: &ab.KafkaTopic{}, BatchTimeoutVal: testTimePadding},
 	}
+	cs.BlockCutterVal.BatchTimeout = testTimePadding
 	defer close(cs.BlockCutterVal.Block)
 
 	lastPersistedOffset := testOldestOffset - 1
@@ -158,6 +168,39 @@ func TestKafkaConsenterEmptyBatch(t *testing.T) {
 	}
 }
 
+func TestKafkaConsenterHealthCheck(t *testing.T) {
+	cs := &mockmultichain.ConsenterSupport{
+		Batches:         make(chan []*cb.Envelope),
+		BlockCutterVal:  mockblockcutter.NewReceiver(),
+		ChainIDVal:      provisional.TestChainID,
+		SharedConfigVal: &mockconfigvaluesorderer.SharedConfig{KafkaTopicVal: &ab.KafkaTopic{}, BatchTimeoutVal: testTimePadding},
+	}
+	defer close(cs.BlockCutterVal.Block)
+
+	lastPersistedOffset := testOldestOffset - 1
+	nextProducedOffset := lastPersistedOffset + 1
+	co := mockNewConsenter(t, testConf.Kafka.Version, testConf.Kafka.Retry, nextProducedOffset)
+	ch := newChain(co, cs, lastPersistedOffset)
+
+	if err := ch.HealthCheck(); err == nil {
+		t.Fatal("Chain should not be healthy before it is set up")
+	}
+
+	go ch.Start()
+	defer ch.Halt()
+
+	prepareMockObjectDisks(t, co, ch)
+
+	if err := ch.HealthCheck(); err != nil {
+		t.Fatalf("Chain should be healthy once set up, got %s", err)
+	}
+
+	ch.Halt()
+	if err := ch.HealthCheck(); err == nil {
+		t.Fatal("Chain should not be healthy once halted")
+	}
+}
+
 func TestKafkaConsenterBatchTimer(t *testing.T) {
 	var wg sync.WaitGroup
 	defer wg.Wait()
@@ -167,8 +210,9 @@ func TestKafkaConsenterBatchTimer(t *testing.T) {
 		Batches:         make(chan []*cb.Envelope),
 		BlockCutterVal:  mockblockcutter.NewReceiver(),
 		ChainIDVal:      provisional.TestChainID,
-		SharedConfigVal: &mockconfigvaluesorderer.SharedConfig{BatchTimeoutVal: batchTimeout},
+		SharedConfigVal: &mockconfigvaluesorderer.SharedConfig{KafkaTopicVal: &ab.KafkaTopic{}, BatchTimeoutVal: batchTimeout},
 	}
+	cs.BlockCutterVal.BatchTimeout = batchTimeout
 	defer close(cs.BlockCutterVal.Block)
 
 	lastPersistedOffset := testOldestOffset - 1
@@ -219,8 +263,9 @@ func TestKafkaConsenterTimerHaltOnFilledBatch(t *testing.T) {
 		Batches:         make(chan []*cb.Envelope),
 		BlockCutterVal:  mockblockcutter.NewReceiver(),
 		ChainIDVal:      provisional.TestChainID,
-		SharedConfigVal: &mockconfigvaluesorderer.SharedConfig{BatchTimeoutVal: batchTimeout},
+		SharedConfigVal: &mockconfigvaluesorderer.SharedConfig{KafkaTopicVal: &ab.KafkaTopic{}, BatchTimeoutVal: batchTimeout},
 	}
+	cs.BlockCutterVal.BatchTimeout = batchTimeout
 	defer close(cs.BlockCutterVal.Block)
 
 	lastPersistedOffset := testOldestOffset - 1
@@ -247,7 +292,7 @@ func TestKafkaConsenterTimerHaltOnFilledBatch(t *testing.T) {
 
 	// Change the batch timeout to be near instant.
 	// If the timer was not reset, it will still be waiting an hour.
-	ch.batchTimeout = time.Millisecond
+	cs.BlockCutterVal.BatchTimeout = time.Millisecond
 
 	cs.BlockCutterVal.CutNext = false
 
@@ -279,8 +324,9 @@ func TestKafkaConsenterConfigStyleMultiBatch(t *testing.T) {
 		Batches:         make(chan []*cb.Envelope),
 		BlockCutterVal:  mockblockcutter.NewReceiver(),
 		ChainIDVal:      provisional.TestChainID,
-		SharedConfigVal: &mockconfigvaluesorderer.SharedConfig{BatchTimeoutVal: testTimePadding},
+		SharedConfigVal: &mockconfigvaluesorderer.SharedConfig{KafkaTopicVal: &ab.KafkaTopic{}, BatchTimeoutVal: testTimePadding},
 	}
+	cs.BlockCutterVal.BatchTimeout = testTimePadding
 	defer close(cs.BlockCutterVal.Block)
 
 	lastPersistedOffset := testOldestOffset - 1
@@ -329,8 +375,9 @@ func TestKafkaConsenterTimeToCutForced(t *testing.T) {
 		Batches:         make(chan []*cb.Envelope),
 		BlockCutterVal:  mockblockcutter.NewReceiver(),
 		ChainIDVal:      provisional.TestChainID,
-		SharedConfigVal: &mockconfigvaluesorderer.SharedConfig{BatchTimeoutVal: batchTimeout},
+		SharedConfigVal: &mockconfigvaluesorderer.SharedConfig{KafkaTopicVal: &ab.KafkaTopic{}, BatchTimeoutVal: batchTimeout},
 	}
+	cs.BlockCutterVal.BatchTimeout = batchTimeout
 	defer close(cs.BlockCutterVal.Block)
 
 	lastPersistedOffset := testOldestOffset - 1
@@ -386,8 +433,9 @@ func TestKafkaConsenterTimeToCutDuplicate(t *testing.T) {
 		Batches:         make(chan []*cb.Envelope),
 		BlockCutterVal:  mockblockcutter.NewReceiver(),
 		ChainIDVal:      provisional.TestChainID,
-		SharedConfigVal: &mockconfigvaluesorderer.SharedConfig{BatchTimeoutVal: batchTimeout},
+		SharedConfigVal: &mockconfigvaluesorderer.SharedConfig{KafkaTopicVal: &ab.KafkaTopic{}, BatchTimeoutVal: batchTimeout},
 	}
+	cs.BlockCutterVal.BatchTimeout = batchTimeout
 	defer close(cs.BlockCutterVal.Block)
 
 	lastPersistedOffset := testOldestOffset - 1
@@ -475,8 +523,9 @@ func TestKafkaConsenterTimeToCutStale(t *testing.T) {
 		Batches:         make(chan []*cb.Envelope),
 		BlockCutterVal:  mockblockcutter.NewReceiver(),
 		ChainIDVal:      provisional.TestChainID,
-		SharedConfigVal: &mockconfigvaluesorderer.SharedConfig{BatchTimeoutVal: batchTimeout},
+		SharedConfigVal: &mockconfigvaluesorderer.SharedConfig{KafkaTopicVal: &ab.KafkaTopic{}, BatchTimeoutVal: batchTimeout},
 	}
+	cs.BlockCutterVal.BatchTimeout = batchTimeout
 	defer close(cs.BlockCutterVal.Block)
 
 	lastPersistedOffset := testOldestOffset - 1
@@ -534,8 +583,9 @@ func TestKafkaConsenterTimeToCutLarger(t *testing.T) {
 		Batches:         make(chan []*cb.Envelope),
 		BlockCutterVal:  mockblockcutter.NewReceiver(),
 		ChainIDVal:      provisional.TestChainID,
-		SharedConfigVal: &mockconfigvaluesorderer.SharedConfig{BatchTimeoutVal: batchTimeout},
+		SharedConfigVal: &mockconfigvaluesorderer.SharedConfig{KafkaTopicVal: &ab.KafkaTopic{}, BatchTimeoutVal: batchTimeout},
 	}
+	cs.BlockCutterVal.BatchTimeout = batchTimeout
 	defer close(cs.BlockCutterVal.Block)
 
 	lastPersistedOffset := testOldestOffset - 1
@@ -616,8 +666,9 @@ func TestKafkaConsenterRestart(t *testing.T) {
 		Batches:         make(chan []*cb.Envelope),
 		BlockCutterVal:  mockblockcutter.NewReceiver(),
 		ChainIDVal:      provisional.TestChainID,
-		SharedConfigVal: &mockconfigvaluesorderer.SharedConfig{BatchTimeoutVal: batchTimeout},
+		SharedConfigVal: &mockconfigvaluesorderer.SharedConfig{KafkaTopicVal: &ab.KafkaTopic{}, BatchTimeoutVal: batchTimeout},
 	}
+	cs.BlockCutterVal.BatchTimeout = batchTimeout
 	defer close(cs.BlockCutterVal.Block)
 
 	lastPersistedOffset := testOldestOffset - 1
@@ -668,3 +719,230 @@ func TestKafkaConsenterRestart(t *testing.T) {
 		t.Fatalf("Restarted orderer post-connect should have been at offset %d, got %d instead", nextProducedOffset, actual)
 	}
 }
+
+func TestGetKafkaMetadataRight(t *testing.T) {
+	expected := &ab.KafkaMetadata{LastOffsetPersisted: 100, OriginalOffsetsProcessed: []int64{0, 42}}
+	actual := getKafkaMetadata(&cb.Metadata{Value: utils.MarshalOrPanic(expected)})
+	if !proto.Equal(actual, expected) {
+		t.Fatalf("Expected metadata %v, got %v", expected, actual)
+	}
+}
+
+func TestKafkaConsenterHandleChainState(t *testing.T) {
+	rl, _ := ramledger.New(10).GetOrCreate(provisional.TestChainID)
+	for i := 0; i < 3; i++ {
+		rl.Append(ledger.CreateNextBlock(rl, []*cb.Envelope{newTestEnvelope(fmt.Sprintf("block %d", i))}))
+	}
+	cs := &mockmultichain.ConsenterSupport{
+		BlockCutterVal:  mockblockcutter.NewReceiver(),
+		ChainIDVal:      provisional.TestChainID,
+		ReaderVal:       rl,
+		SharedConfigVal: &mockconfigvaluesorderer.SharedConfig{KafkaTopicVal: &ab.KafkaTopic{}},
+	}
+
+	lastPersistedOffset := testOldestOffset - 1
+	co := mockNewConsenter(t, testConf.Kafka.Version, testConf.Kafka.Retry, lastPersistedOffset+1)
+	chain, err := co.HandleChain(cs, &cb.Metadata{Value: utils.MarshalOrPanic(&ab.KafkaMetadata{
+		LastOffsetPersisted:      lastPersistedOffset,
+		OriginalOffsetsProcessed: []int64{7},
+	})})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	ch := chain.(*chainImpl)
+
+	if ch.lastOffsetPersisted != lastPersistedOffset {
+		t.Fatalf("Expected last offset persisted %d, got %d", lastPersistedOffset, ch.lastOffsetPersisted)
+	}
+	if ch.lastCutBlock != 2 {
+		t.Fatalf("Expected last cut block to be the tip of the ledger 2, got %d", ch.lastCutBlock)
+	}
+	if !ch.processed(7) {
+		t.Fatalf("Expected original offset 7 to be processed, got %v", ch.originalOffsetsProcessed)
+	}
+}
+
+func TestKafkaConsenterResubmittedDuplicate(t *testing.T) {
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	batchTimeout, _ := time.ParseDuration("1h")
+	cs := &mockmultichain.ConsenterSupport{
+		Batches:         make(chan []*cb.Envelope),
+		BlockCutterVal:  mockblockcutter.NewReceiver(),
+		ChainIDVal:      provisional.TestChainID,
+		SharedConfigVal: &mockconfigvaluesorderer.SharedConfig{KafkaTopicVal: &ab.KafkaTopic{}, BatchTimeoutVal: batchTimeout},
+	}
+	cs.BlockCutterVal.BatchTimeout = batchTimeout
+	defer close(cs.BlockCutterVal.Block)
+
+	lastPersistedOffset := testOldestOffset - 1
+	nextProducedOffset := lastPersistedOffset + 1
+	co := mockNewConsenter(t, testConf.Kafka.Version, testConf.Kafka.Retry, nextProducedOffset)
+	ch := newChain(co, cs, lastPersistedOffset)
+	ch.markProcessed(0)
+	ch.markProcessed(5)
+
+	go ch.Start()
+	defer ch.Halt()
+
+	prepareMockObjectDisks(t, co, ch)
+
+	// Post copies of messages resubmitted from offsets which were ordered already, including
+	// offset zero, followed by a message resubmitted from an offset in between
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 3; i++ {
+			co.consDisk <- <-co.prodDisk
+		}
+	}()
+	if err := ch.producer.Send(ch.partition, utils.MarshalOrPanic(newResubmittedMessage(utils.MarshalOrPanic(newTestEnvelope("dup")), 0, 5))); err != nil {
+		t.Fatalf("Couldn't post to %s: %s", ch.partition, err)
+	}
+	if err := ch.producer.Send(ch.partition, utils.MarshalOrPanic(newResubmittedMessage(utils.MarshalOrPanic(newTestEnvelope("dup")), 0, 0))); err != nil {
+		t.Fatalf("Couldn't post to %s: %s", ch.partition, err)
+	}
+	if err := ch.producer.Send(ch.partition, utils.MarshalOrPanic(newResubmittedMessage(utils.MarshalOrPanic(newTestEnvelope("new")), 0, 3))); err != nil {
+		t.Fatalf("Couldn't post to %s: %s", ch.partition, err)
+	}
+	wg.Wait()
+
+	select {
+	case cs.BlockCutterVal.Block <- struct{}{}:
+	case <-time.After(testTimePadding):
+		t.Fatal("Should have ordered the resubmitted message")
+	}
+
+	ch.Halt()
+	<-ch.haltedChan
+
+	if len(cs.BlockCutterVal.CurBatch) != 1 || string(cs.BlockCutterVal.CurBatch[0].Payload) != "new" {
+		t.Fatalf("Should have dropped the duplicate of the resubmitted message, got batch %v", cs.BlockCutterVal.CurBatch)
+	}
+	if !ch.processed(3) {
+		t.Fatalf("Expected original offset 3 to be processed, got %v", ch.originalOffsetsProcessed)
+	}
+}
+
+func TestKafkaConsenterStaleConfigResubmit(t *testing.T) {
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	batchTimeout, _ := time.ParseDuration("1h")
+	cs := &mockmultichain.ConsenterSupport{
+		Batches:                make(chan []*cb.Envelope),
+		BlockCutterVal:         mockblockcutter.NewReceiver(),
+		ChainIDVal:             provisional.TestChainID,
+		SharedConfigVal:        &mockconfigvaluesorderer.SharedConfig{KafkaTopicVal: &ab.KafkaTopic{}, BatchTimeoutVal: batchTimeout},
+		SequenceVal:            1,
+		ProposeConfigUpdateVal: &cb.ConfigEnvelope{Config: &cb.Config{Sequence: 2}},
+	}
+	cs.BlockCutterVal.BatchTimeout = batchTimeout
+	defer close(cs.BlockCutterVal.Block)
+
+	lastPersistedOffset := testOldestOffset - 1
+	nextProducedOffset := lastPersistedOffset + 1
+	co := mockNewConsenter(t, testConf.Kafka.Version, testConf.Kafka.Retry, nextProducedOffset)
+	ch := newChain(co, cs, lastPersistedOffset)
+
+	go ch.Start()
+	defer ch.Halt()
+
+	prepareMockObjectDisks(t, co, ch)
+
+	configEnv, err := utils.CreateSignedEnvelope(cb.HeaderType_CONFIG, provisional.TestChainID, cs, &cb.ConfigEnvelope{LastUpdate: newTestEnvelope("update")}, msgVersion, epoch)
+	if err != nil {
+		t.Fatalf("Couldn't create config message: %s", err)
+	}
+
+	// Post a config message which was validated against the previous config
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		co.consDisk <- <-co.prodDisk
+	}()
+	if err := ch.producer.Send(ch.partition, utils.MarshalOrPanic(newRegularMessage(utils.MarshalOrPanic(configEnv), 0))); err != nil {
+		t.Fatalf("Couldn't post to %s: %s", ch.partition, err)
+	}
+	wg.Wait()
+
+	var resubmitted *ab.KafkaMessage
+	select {
+	case resubmitted = <-co.prodDisk:
+	case cs.BlockCutterVal.Block <- struct{}{}:
+		t.Fatal("Should not have ordered the stale config message")
+	case <-time.After(testTimePadding):
+		t.Fatal("Should have resubmitted the stale config message")
+	}
+
+	regular := resubmitted.GetRegular()
+	if regular == nil {
+		t.Fatalf("Expected a regular message, got %v", resubmitted)
+	}
+	if regular.ConfigSeq != 1 {
+		t.Fatalf("Expected the resubmitted message to be validated against config sequence 1, got %d", regular.ConfigSeq)
+	}
+	if !regular.Resubmitted || regular.OriginalOffset != nextProducedOffset {
+		t.Fatalf("Expected the resubmitted message to carry original offset %d, got %d", nextProducedOffset, regular.OriginalOffset)
+	}
+	newConfigEnv := &cb.ConfigEnvelope{}
+	if _, err := utils.UnmarshalEnvelopeOfType(utils.UnmarshalEnvelopeOrPanic(regular.Payload), cb.HeaderType_CONFIG, newConfigEnv); err != nil {
+		t.Fatalf("Couldn't unmarshal resubmitted config message: %s", err)
+	}
+	if newConfigEnv.Config.Sequence != 2 {
+		t.Fatalf("Expected the config computed against the current config, got %v", newConfigEnv)
+	}
+}
+
+func TestKafkaConsenterTopicSettings(t *testing.T) {
+	testCases := []struct {
+		name    string
+		topic   *ab.KafkaTopic
+		started bool
+	}{
+		{"Default", &ab.KafkaTopic{}, true},
+		{"Replicated", &ab.KafkaTopic{MinReplicas: uint32(testReplicas)}, true},
+		{"UnderReplicated", &ab.KafkaTopic{MinReplicas: uint32(testReplicas) + 1}, false},
+		{"Existing", &ab.KafkaTopic{RequireExisting: true}, true},
+		{"ExistingUnderReplicated", &ab.KafkaTopic{RequireExisting: true, MinReplicas: uint32(testReplicas) + 1}, false},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cs := &mockmultichain.ConsenterSupport{
+				BlockCutterVal:  mockblockcutter.NewReceiver(),
+				ChainIDVal:      provisional.TestChainID,
+				SharedConfigVal: &mockconfigvaluesorderer.SharedConfig{KafkaTopicVal: tc.topic, BatchTimeoutVal: testTimePadding},
+			}
+			defer close(cs.BlockCutterVal.Block)
+
+			lastPersistedOffset := testOldestOffset - 1
+			co := mockNewConsenter(t, testConf.Kafka.Version, testConf.Kafka.Retry, lastPersistedOffset+1)
+			ch := newChain(co, cs, lastPersistedOffset)
+
+			go ch.Start()
+			defer ch.Halt()
+
+			// Dispense with the CONNECT message, which is not posted when the topic is verified beforehand
+			select {
+			case <-co.prodDisk:
+			case <-ch.exitChan:
+			case <-time.After(testTimePadding):
+				t.Fatal("Chain neither posted the CONNECT message nor exited in time")
+			}
+
+			select {
+			case <-ch.setupChan:
+				if !tc.started {
+					t.Fatal("Expected the chain not to start")
+				}
+			case <-ch.exitChan:
+				if tc.started {
+					t.Fatal("Expected the chain to start")
+				}
+			case <-time.After(testTimePadding):
+				t.Fatal("Chain neither started nor exited in time")
+			}
+		})
+	}
+}
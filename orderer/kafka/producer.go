@@ -34,10 +34,10 @@ type producerImpl struct {
 	producer sarama.SyncProducer
 }
 
-func newProducer(brokers []string, kafkaVersion sarama.KafkaVersion, retryOptions config.Retry, tls config.TLS) Producer {
+func newProducer(brokers []string, kafkaVersion sarama.KafkaVersion, retryOptions config.Retry, tls config.TLS, sasl config.SASL) Producer {
 	var p sarama.SyncProducer
 	var err error
-	brokerConfig := newBrokerConfig(kafkaVersion, rawPartition, tls)
+	brokerConfig := newBrokerConfig(kafkaVersion, rawPartition, tls, sasl)
 
 	repeatTick := time.NewTicker(retryOptions.Period)
 	panicTick := time.NewTicker(retryOptions.Stop)
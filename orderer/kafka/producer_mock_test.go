@@ -107,7 +107,7 @@ func (mp *mockProducerImpl) testFillWithBlocks(cp ChainPartition, offset int64)
 	}()
 
 	for i := int64(1); i <= offset; i++ {
-		mp.Send(cp, utils.MarshalOrPanic(newRegularMessage(utils.MarshalOrPanic(newTestEnvelope(fmt.Sprintf("producer fill-in %d", i))))))
+		mp.Send(cp, utils.MarshalOrPanic(newRegularMessage(utils.MarshalOrPanic(newTestEnvelope(fmt.Sprintf("producer fill-in %d", i))), 0)))
 	}
 
 	close(dieChan)
@@ -31,7 +31,7 @@ func TestProducerSend(t *testing.T) {
 		<-mp.(*mockProducerImpl).disk // Retrieve the message that we'll be sending below
 	}()
 
-	if err := mp.Send(cp, utils.MarshalOrPanic(newRegularMessage([]byte("foo")))); err != nil {
+	if err := mp.Send(cp, utils.MarshalOrPanic(newRegularMessage([]byte("foo"), 0))); err != nil {
 		t.Fatalf("Mock producer was not initialized correctly: %s", err)
 	}
 }
@@ -0,0 +1,65 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+                 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kafka
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"fmt"
+	"hash"
+
+	"github.com/Shopify/sarama"
+	"github.com/xdg/scram"
+)
+
+// The hash functions of the SCRAM mechanisms supported by the Kafka brokers
+var (
+	scramSHA256 scram.HashGeneratorFcn = func() hash.Hash { return sha256.New() }
+	scramSHA512 scram.HashGeneratorFcn = func() hash.Hash { return sha512.New() }
+)
+
+// scramClient adapts a SCRAM client conversation (RFC 5802) to the client
+// sarama uses to authenticate with the Kafka brokers
+type scramClient struct {
+	hashGenerator scram.HashGeneratorFcn
+	conversation  *scram.ClientConversation
+}
+
+// newSCRAMClient returns a SCRAM client for the mechanism of the given hash function
+func newSCRAMClient(hashGenerator scram.HashGeneratorFcn) sarama.SCRAMClient {
+	return &scramClient{hashGenerator: hashGenerator}
+}
+
+// Begin starts a new conversation with the broker
+func (sc *scramClient) Begin(user, password, authzID string) error {
+	client, err := sc.hashGenerator.NewClient(user, password, authzID)
+	if err != nil {
+		return fmt.Errorf("Could not create SCRAM client: %s", err)
+	}
+	sc.conversation = client.NewConversation()
+	return nil
+}
+
+// Step returns the client message following the given server message
+func (sc *scramClient) Step(challenge string) (string, error) {
+	return sc.conversation.Step(challenge)
+}
+
+// Done returns whether the conversation is over
+func (sc *scramClient) Done() bool {
+	return sc.conversation.Done()
+}
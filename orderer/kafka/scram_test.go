@@ -0,0 +1,70 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+                 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kafka
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/xdg/scram"
+)
+
+// converse runs the exchange of a client with a SCRAM server knowing the
+// password "pencil" and returns the last error of the client
+func converse(t *testing.T, hashGenerator scram.HashGeneratorFcn, password string) error {
+	credentials := func(user string) (scram.StoredCredentials, error) {
+		client, err := hashGenerator.NewClient(user, "pencil", "")
+		assert.NoError(t, err)
+		return client.GetStoredCredentials(scram.KeyFactors{Salt: "salt", Iters: 4096}), nil
+	}
+	server, err := hashGenerator.NewServer(credentials)
+	assert.NoError(t, err)
+	serverConversation := server.NewConversation()
+
+	sc := newSCRAMClient(hashGenerator)
+	assert.NoError(t, sc.Begin("user", password, ""))
+
+	var challenge string
+	for !sc.Done() {
+		response, err := sc.Step(challenge)
+		if err != nil {
+			return err
+		}
+		if sc.Done() {
+			break
+		}
+		// the server reports a failed authentication in its final message
+		challenge, _ = serverConversation.Step(response)
+	}
+	return nil
+}
+
+func TestSCRAMExchange(t *testing.T) {
+	for _, hashGenerator := range []scram.HashGeneratorFcn{scramSHA256, scramSHA512} {
+		assert.NoError(t, converse(t, hashGenerator, "pencil"))
+		assert.Error(t, converse(t, hashGenerator, "pen"), "Expected the server to reject a wrong password")
+	}
+}
+
+func TestSCRAMBadServer(t *testing.T) {
+	sc := newSCRAMClient(scramSHA256)
+	assert.NoError(t, sc.Begin("user", "pencil", ""))
+	_, err := sc.Step("")
+	assert.NoError(t, err)
+	_, err = sc.Step("r=foo,s=c2FsdA==,i=4096")
+	assert.Error(t, err, "Expected a server nonce not extending the client nonce to be rejected")
+}
@@ -0,0 +1,62 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+                 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kafka
+
+import (
+	"fmt"
+
+	"github.com/Shopify/sarama"
+	"github.com/hyperledger/fabric/orderer/localconfig"
+	ab "github.com/hyperledger/fabric/protos/orderer"
+)
+
+// getTopicReplicas returns the count of replicas of the chain partition,
+// or 0 if its topic does not exist on the Kafka cluster.
+func getTopicReplicas(brokers []string, kafkaVersion sarama.KafkaVersion, tls config.TLS, sasl config.SASL, cp ChainPartition) (int, error) {
+	client, err := sarama.NewClient(brokers, newBrokerConfig(kafkaVersion, rawPartition, tls, sasl))
+	if err != nil {
+		return 0, err
+	}
+	defer client.Close()
+
+	// The client lists the topics from the metadata of the whole cluster, rather
+	// than requesting the metadata of the topic, which the broker may create.
+	topics, err := client.Topics()
+	if err != nil {
+		return 0, err
+	}
+	for _, topic := range topics {
+		if topic == cp.Topic() {
+			replicas, err := client.Replicas(cp.Topic(), cp.Partition())
+			return len(replicas), err
+		}
+	}
+	return 0, nil
+}
+
+// checkTopic returns an error if the chain partition does not pass the checks
+// of its topic, given the count of its replicas on the Kafka cluster. The
+// orderer does not create or configure the topic itself.
+func checkTopic(topic *ab.KafkaTopic, cp ChainPartition, replicas int) error {
+	if topic.RequireExisting && replicas == 0 {
+		return fmt.Errorf("Topic %s does not exist and may not be created automatically", cp.Topic())
+	}
+	if replicas < int(topic.MinReplicas) {
+		return fmt.Errorf("Chain partition %s has %d replicas, %d are required", cp, replicas, topic.MinReplicas)
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+                 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kafka
+
+import (
+	"testing"
+
+	"github.com/Shopify/sarama"
+	"github.com/hyperledger/fabric/common/configtx/tool/provisional"
+	"github.com/hyperledger/fabric/orderer/localconfig"
+	ab "github.com/hyperledger/fabric/protos/orderer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetTopicReplicas(t *testing.T) {
+	cp := newChainPartition(provisional.TestChainID, rawPartition)
+	broker := sarama.NewMockBroker(t, testBrokerID)
+	defer broker.Close()
+
+	metadataResponse := new(sarama.MetadataResponse)
+	metadataResponse.AddBroker(broker.Addr(), broker.BrokerID())
+	metadataResponse.AddTopicPartition(cp.Topic(), cp.Partition(), broker.BrokerID(), []int32{broker.BrokerID(), 1, 2}, []int32{broker.BrokerID()}, []int32{}, sarama.ErrNoError)
+	broker.SetHandlerByMap(map[string]sarama.MockResponse{
+		"MetadataRequest": sarama.NewMockWrapper(metadataResponse),
+	})
+
+	replicas, err := getTopicReplicas([]string{broker.Addr()}, testConf.Kafka.Version, config.TLS{Enabled: false}, config.SASL{Enabled: false}, cp)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, replicas)
+
+	missing := newChainPartition("missing", rawPartition)
+	replicas, err = getTopicReplicas([]string{broker.Addr()}, testConf.Kafka.Version, config.TLS{Enabled: false}, config.SASL{Enabled: false}, missing)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, replicas, "Expected no replicas for a topic which does not exist")
+}
+
+func TestCheckTopic(t *testing.T) {
+	cp := newChainPartition(provisional.TestChainID, rawPartition)
+
+	assert.NoError(t, checkTopic(&ab.KafkaTopic{}, cp, 0), "Expected a missing topic to be created automatically")
+	assert.Error(t, checkTopic(&ab.KafkaTopic{RequireExisting: true}, cp, 0), "Expected a missing topic to be rejected")
+	assert.NoError(t, checkTopic(&ab.KafkaTopic{RequireExisting: true}, cp, 1))
+	assert.NoError(t, checkTopic(&ab.KafkaTopic{MinReplicas: 3}, cp, 3))
+	assert.Error(t, checkTopic(&ab.KafkaTopic{MinReplicas: 3}, cp, 2), "Expected an under-replicated partition to be rejected")
+}
@@ -24,10 +24,12 @@ import (
 
 	"github.com/Shopify/sarama"
 	"github.com/hyperledger/fabric/orderer/localconfig"
+	cb "github.com/hyperledger/fabric/protos/common"
 	ab "github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric/protos/utils"
 )
 
-func newBrokerConfig(kafkaVersion sarama.KafkaVersion, chosenStaticPartition int32, tlsConfig config.TLS) *sarama.Config {
+func newBrokerConfig(kafkaVersion sarama.KafkaVersion, chosenStaticPartition int32, tlsConfig config.TLS, sasl config.SASL) *sarama.Config {
 	brokerConfig := sarama.NewConfig()
 
 	brokerConfig.Consumer.Return.Errors = true
@@ -54,6 +56,19 @@ func newBrokerConfig(kafkaVersion sarama.KafkaVersion, chosenStaticPartition int
 		}
 	}
 
+	brokerConfig.Net.SASL.Enable = sasl.Enabled
+	if brokerConfig.Net.SASL.Enable {
+		brokerConfig.Net.SASL.Mechanism = sarama.SASLMechanism(sasl.Mechanism)
+		brokerConfig.Net.SASL.User = sasl.User
+		brokerConfig.Net.SASL.Password = sasl.Password
+		switch brokerConfig.Net.SASL.Mechanism {
+		case sarama.SASLTypeSCRAMSHA256:
+			brokerConfig.Net.SASL.SCRAMClientGeneratorFunc = func() sarama.SCRAMClient { return newSCRAMClient(scramSHA256) }
+		case sarama.SASLTypeSCRAMSHA512:
+			brokerConfig.Net.SASL.SCRAMClientGeneratorFunc = func() sarama.SCRAMClient { return newSCRAMClient(scramSHA512) }
+		}
+	}
+
 	// Set the level of acknowledgement reliability needed from the broker.
 	// WaitForAll means that the partition leader will wait till all ISRs
 	// got the message before sending back an ACK to the sender.
@@ -64,6 +79,9 @@ func newBrokerConfig(kafkaVersion sarama.KafkaVersion, chosenStaticPartition int
 	// Set equivalent of Kafka producer config max.request.bytes to the default
 	// value of a Kafka broker's socket.request.max.bytes property (100 MiB).
 	brokerConfig.Producer.MaxMessageBytes = int(sarama.MaxRequestSize)
+	// The sync producer requires the successes to be returned, as it reports
+	// the offset of every message it sends.
+	brokerConfig.Producer.Return.Successes = true
 
 	brokerConfig.Version = kafkaVersion
 
@@ -80,11 +98,25 @@ func newConnectMessage() *ab.KafkaMessage {
 	}
 }
 
-func newRegularMessage(payload []byte) *ab.KafkaMessage {
+func newRegularMessage(payload []byte, configSeq uint64) *ab.KafkaMessage {
+	return &ab.KafkaMessage{
+		Type: &ab.KafkaMessage_Regular{
+			Regular: &ab.KafkaMessageRegular{
+				Payload:   payload,
+				ConfigSeq: configSeq,
+			},
+		},
+	}
+}
+
+func newResubmittedMessage(payload []byte, configSeq uint64, originalOffset int64) *ab.KafkaMessage {
 	return &ab.KafkaMessage{
 		Type: &ab.KafkaMessage_Regular{
 			Regular: &ab.KafkaMessageRegular{
-				Payload: payload,
+				Payload:        payload,
+				ConfigSeq:      configSeq,
+				OriginalOffset: originalOffset,
+				Resubmitted:    true,
 			},
 		},
 	}
@@ -100,6 +132,16 @@ func newTimeToCutMessage(blockNumber uint64) *ab.KafkaMessage {
 	}
 }
 
+// isConfigMessage returns whether the envelope carries a config transaction
+func isConfigMessage(env *cb.Envelope) bool {
+	payload, err := utils.UnmarshalPayload(env.Payload)
+	if err != nil || payload.Header == nil {
+		return false
+	}
+	chdr, err := utils.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+	return err == nil && chdr.Type == int32(cb.HeaderType_CONFIG)
+}
+
 func newProducerMessage(cp ChainPartition, payload []byte) *sarama.ProducerMessage {
 	return &sarama.ProducerMessage{
 		Topic: cp.Topic(),
@@ -39,7 +39,7 @@ func TestProducerConfigMessageMaxBytes(t *testing.T) {
 	})
 
 	mockTLS := config.TLS{Enabled: false}
-	config := newBrokerConfig(testConf.Kafka.Version, rawPartition, mockTLS)
+	config := newBrokerConfig(testConf.Kafka.Version, rawPartition, mockTLS, config.SASL{Enabled: false})
 	producer, err := sarama.NewSyncProducer([]string{broker.Addr()}, config)
 	if err != nil {
 		t.Fatal(err)
@@ -90,7 +90,7 @@ func TestNewBrokerConfig(t *testing.T) {
 		"ProduceRequest": sarama.NewMockProduceResponse(t),
 	})
 
-	config := newBrokerConfig(testConf.Kafka.Version, differentPartition, config.TLS{Enabled: false})
+	config := newBrokerConfig(testConf.Kafka.Version, differentPartition, config.TLS{Enabled: false}, config.SASL{Enabled: false})
 	producer, err := sarama.NewSyncProducer([]string{broker.Addr()}, config)
 	if err != nil {
 		t.Fatal("Failed to create producer:", err)
@@ -125,7 +125,7 @@ func TestTLSConfigEnabled(t *testing.T) {
 		PrivateKey:  privateKey,
 		Certificate: publicKey,
 		RootCAs:     []string{caPublicKey},
-	})
+	}, config.SASL{Enabled: false})
 
 	assert.True(t, config.Net.TLS.Enable)
 	assert.NotNil(t, config.Net.TLS.Config)
@@ -150,7 +150,7 @@ func TestTLSConfigDisabled(t *testing.T) {
 		PrivateKey:  privateKey,
 		Certificate: publicKey,
 		RootCAs:     []string{caPublicKey},
-	})
+	}, config.SASL{Enabled: false})
 
 	assert.False(t, config.Net.TLS.Enable)
 	assert.Zero(t, config.Net.TLS.Config)
@@ -174,7 +174,7 @@ func TestTLSConfigBadCert(t *testing.T) {
 				PrivateKey:  privateKey,
 				Certificate: "TRASH",
 				RootCAs:     []string{caPublicKey},
-			})
+			}, config.SASL{Enabled: false})
 		})
 	})
 	t.Run("BadPublicKey", func(t *testing.T) {
@@ -184,7 +184,7 @@ func TestTLSConfigBadCert(t *testing.T) {
 				PrivateKey:  "TRASH",
 				Certificate: publicKey,
 				RootCAs:     []string{caPublicKey},
-			})
+			}, config.SASL{Enabled: false})
 		})
 	})
 	t.Run("BadRootCAs", func(t *testing.T) {
@@ -194,7 +194,51 @@ func TestTLSConfigBadCert(t *testing.T) {
 				PrivateKey:  privateKey,
 				Certificate: publicKey,
 				RootCAs:     []string{"TRASH"},
-			})
+			}, config.SASL{Enabled: false})
 		})
 	})
 }
+
+func TestSASLPlainConfig(t *testing.T) {
+	config := newBrokerConfig(testConf.Kafka.Version, 0, config.TLS{Enabled: false}, config.SASL{
+		Enabled:   true,
+		Mechanism: string(sarama.SASLTypePlaintext),
+		User:      "user",
+		Password:  "password",
+	})
+
+	assert.True(t, config.Net.SASL.Enable)
+	assert.Equal(t, sarama.SASLMechanism(sarama.SASLTypePlaintext), config.Net.SASL.Mechanism)
+	assert.Nil(t, config.Net.SASL.SCRAMClientGeneratorFunc)
+	assert.Equal(t, "user", config.Net.SASL.User)
+	assert.Equal(t, "password", config.Net.SASL.Password)
+}
+
+func TestSASLSCRAMConfig(t *testing.T) {
+	for _, mechanism := range []sarama.SASLMechanism{sarama.SASLTypeSCRAMSHA256, sarama.SASLTypeSCRAMSHA512} {
+		config := newBrokerConfig(testConf.Kafka.Version, 0, config.TLS{Enabled: false}, config.SASL{
+			Enabled:   true,
+			Mechanism: string(mechanism),
+			User:      "user",
+			Password:  "password",
+		})
+
+		assert.Equal(t, mechanism, config.Net.SASL.Mechanism)
+		assert.NoError(t, config.Validate())
+		if assert.NotNil(t, config.Net.SASL.SCRAMClientGeneratorFunc, "Expected a SCRAM client for %s", mechanism) {
+			assert.NotNil(t, config.Net.SASL.SCRAMClientGeneratorFunc())
+		}
+	}
+}
+
+func TestSASLPlainConfigDisabled(t *testing.T) {
+	config := newBrokerConfig(testConf.Kafka.Version, 0, config.TLS{Enabled: false}, config.SASL{
+		Enabled:  false,
+		User:     "user",
+		Password: "password",
+	})
+
+	assert.False(t, config.Net.SASL.Enable)
+	assert.Empty(t, config.Net.SASL.User)
+	assert.Empty(t, config.Net.SASL.Password)
+}
@@ -18,6 +18,7 @@ package ledger_test
 
 import (
 	"bytes"
+	"os"
 	"reflect"
 	"testing"
 
@@ -256,3 +257,73 @@ func testMultichain(lf ledgerTestFactory, t *testing.T) {
 		t.Fatalf("Did not properly store block 1 on chain 1")
 	}
 }
+
+func TestRemove(t *testing.T) {
+	allTest(t, testRemove)
+}
+
+func testRemove(lf ledgerTestFactory, t *testing.T) {
+	f, _ := lf.New()
+	chainID := "removed"
+
+	c, err := f.GetOrCreate(chainID)
+	if err != nil {
+		t.Fatalf("Error creating chain: %s", err)
+	}
+	c.Append(CreateNextBlock(c, []*cb.Envelope{&cb.Envelope{Payload: []byte("My Data")}}))
+
+	if err := f.Remove(chainID); err != nil {
+		t.Fatalf("Error removing chain: %s", err)
+	}
+	for _, id := range f.ChainIDs() {
+		if id == chainID {
+			t.Fatalf("Removed chain should not be listed, got %v", f.ChainIDs())
+		}
+	}
+
+	c, err = f.GetOrCreate(chainID)
+	if err != nil {
+		t.Fatalf("Error creating chain again: %s", err)
+	}
+	if c.Height() != 0 {
+		t.Fatalf("Chain created again should be empty, got height %d", c.Height())
+	}
+}
+
+func TestArchive(t *testing.T) {
+	allTest(t, testArchive)
+}
+
+func testArchive(lf ledgerTestFactory, t *testing.T) {
+	f, _ := lf.New()
+	chainID := "archived"
+
+	c, err := f.GetOrCreate(chainID)
+	if err != nil {
+		t.Fatalf("Error creating chain: %s", err)
+	}
+	c.Append(CreateNextBlock(c, []*cb.Envelope{&cb.Envelope{Payload: []byte("My Data")}}))
+
+	archivePath, err := f.Archive(chainID)
+	if err != nil {
+		t.Fatalf("Error archiving chain: %s", err)
+	}
+	if lf.Persistent() {
+		if _, err := os.Stat(archivePath); err != nil {
+			t.Fatalf("Archived chain should have been moved to %s: %s", archivePath, err)
+		}
+	}
+	for _, id := range f.ChainIDs() {
+		if id == chainID {
+			t.Fatalf("Archived chain should not be listed, got %v", f.ChainIDs())
+		}
+	}
+
+	c, err = f.GetOrCreate(chainID)
+	if err != nil {
+		t.Fatalf("Error creating chain again: %s", err)
+	}
+	if c.Height() != 0 {
+		t.Fatalf("Chain created again should be empty, got height %d", c.Height())
+	}
+}
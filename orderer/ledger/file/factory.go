@@ -17,6 +17,8 @@ limitations under the License.
 package fileledger
 
 import (
+	"os"
+	"path/filepath"
 	"sync"
 
 	"github.com/hyperledger/fabric/common/ledger/blkstorage"
@@ -25,6 +27,7 @@ import (
 )
 
 type fileLedgerFactory struct {
+	directory          string
 	blkstorageProvider blkstorage.BlockStoreProvider
 	ledgers            map[string]ledger.ReadWriter
 	mutex              sync.Mutex
@@ -60,14 +63,50 @@ func (flf *fileLedgerFactory) ChainIDs() []string {
 	return chainIDs
 }
 
+// Remove deletes the ledger of a chain
+func (flf *fileLedgerFactory) Remove(chainID string) error {
+	flf.mutex.Lock()
+	defer flf.mutex.Unlock()
+	if l, ok := flf.ledgers[chainID]; ok {
+		l.(*fileLedger).blockStore.Shutdown()
+		delete(flf.ledgers, chainID)
+	}
+	return flf.blkstorageProvider.Remove(chainID)
+}
+
+// Archive closes the ledger of a chain and moves its block files to the archive directory,
+// the index of the blocks is deleted
+func (flf *fileLedgerFactory) Archive(chainID string) (string, error) {
+	flf.mutex.Lock()
+	defer flf.mutex.Unlock()
+	if l, ok := flf.ledgers[chainID]; ok {
+		l.(*fileLedger).blockStore.Shutdown()
+		delete(flf.ledgers, chainID)
+	}
+	archivePath, err := ledger.NewArchivePath(flf.directory, chainID)
+	if err != nil {
+		return "", err
+	}
+	if err := os.Rename(filepath.Join(flf.directory, fsblkstorage.ChainsDir, chainID), archivePath); err != nil {
+		return "", err
+	}
+	return archivePath, flf.blkstorageProvider.Remove(chainID)
+}
+
 // Close releases all resources acquired by the factory
 func (flf *fileLedgerFactory) Close() {
 	flf.blkstorageProvider.Close()
 }
 
-// New creates a new ledger factory
+// New creates a new ledger factory, the blocks of each chain are stored by fsblkstorage
+// and indexed by their number
 func New(directory string) ledger.Factory {
+	// The provider lists the chains from this directory, which it only creates with the first chain
+	if err := os.MkdirAll(filepath.Join(directory, fsblkstorage.ChainsDir), 0755); err != nil {
+		logger.Panicf("Could not create the chains directory in %s: %s", directory, err)
+	}
 	return &fileLedgerFactory{
+		directory: directory,
 		blkstorageProvider: fsblkstorage.NewProvider(
 			fsblkstorage.NewConf(directory, -1),
 			&blkstorage.IndexConfig{
@@ -17,6 +17,8 @@ limitations under the License.
 package fileledger
 
 import (
+	"sync"
+
 	"github.com/hyperledger/fabric/common/ledger/blkstorage"
 	ledger "github.com/hyperledger/fabric/orderer/ledger"
 	cb "github.com/hyperledger/fabric/protos/common"
@@ -35,6 +37,7 @@ func init() {
 type fileLedger struct {
 	blockStore blkstorage.BlockStore
 	signal     chan struct{}
+	signalLock sync.RWMutex
 }
 
 type fileLedgerIterator struct {
@@ -46,6 +49,8 @@ type fileLedgerIterator struct {
 // next block is no longer retrievable
 func (i *fileLedgerIterator) Next() (*cb.Block, cb.Status) {
 	for {
+		// As in ReadyChan, the signal is taken before the height
+		signal := i.ledger.readySignal()
 		if i.blockNumber < i.ledger.Height() {
 			block, err := i.ledger.blockStore.RetrieveBlockByNumber(i.blockNumber)
 			if err != nil {
@@ -54,13 +59,15 @@ func (i *fileLedgerIterator) Next() (*cb.Block, cb.Status) {
 			i.blockNumber++
 			return block, cb.Status_SUCCESS
 		}
-		<-i.ledger.signal
+		<-signal
 	}
 }
 
 // ReadyChan supplies a channel which will block until Next will not block
 func (i *fileLedgerIterator) ReadyChan() <-chan struct{} {
-	signal := i.ledger.signal
+	// The signal must be taken before the height, so that it is not missed
+	// when a block is appended meanwhile
+	signal := i.ledger.readySignal()
 	if i.blockNumber > i.ledger.Height()-1 {
 		return signal
 	}
@@ -91,6 +98,13 @@ func (fl *fileLedger) Iterator(startPosition *ab.SeekPosition) (ledger.Iterator,
 	return &ledger.NotFoundErrorIterator{}, 0
 }
 
+// readySignal returns the channel which is closed when the next block is appended
+func (fl *fileLedger) readySignal() <-chan struct{} {
+	fl.signalLock.RLock()
+	defer fl.signalLock.RUnlock()
+	return fl.signal
+}
+
 // Height returns the number of blocks on the ledger
 func (fl *fileLedger) Height() uint64 {
 	info, err := fl.blockStore.GetBlockchainInfo()
@@ -104,8 +118,10 @@ func (fl *fileLedger) Height() uint64 {
 func (fl *fileLedger) Append(block *cb.Block) error {
 	err := fl.blockStore.AddBlock(block)
 	if err == nil {
+		fl.signalLock.Lock()
 		close(fl.signal)
 		fl.signal = make(chan struct{})
+		fl.signalLock.Unlock()
 	}
 	return err
 }
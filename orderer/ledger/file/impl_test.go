@@ -84,6 +84,20 @@ func TestInitialization(t *testing.T) {
 	}
 }
 
+func TestEmptyFactory(t *testing.T) {
+	name, err := ioutil.TempDir("", "hyperledger_fabric")
+	if err != nil {
+		t.Fatalf("Error creating temp dir: %s", err)
+	}
+	defer os.RemoveAll(name)
+
+	flf := New(name)
+	defer flf.Close()
+	if chains := flf.ChainIDs(); len(chains) != 0 {
+		t.Fatalf("Expected no chains, got %v", chains)
+	}
+}
+
 func TestReinitialization(t *testing.T) {
 	// initialize ledger provider and a ledger for the test chain
 	tev, leger1 := initialize(t)
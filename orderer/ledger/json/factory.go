@@ -121,6 +121,26 @@ func (jlf *jsonLedgerFactory) ChainIDs() []string {
 	return ids
 }
 
+// Remove deletes the ledger of a chain
+func (jlf *jsonLedgerFactory) Remove(chainID string) error {
+	jlf.mutex.Lock()
+	defer jlf.mutex.Unlock()
+	delete(jlf.ledgers, chainID)
+	return os.RemoveAll(filepath.Join(jlf.directory, fmt.Sprintf(chainDirectoryFormatString, chainID)))
+}
+
+// Archive moves the directory of the ledger of a chain to the archive directory
+func (jlf *jsonLedgerFactory) Archive(chainID string) (string, error) {
+	jlf.mutex.Lock()
+	defer jlf.mutex.Unlock()
+	delete(jlf.ledgers, chainID)
+	archivePath, err := ledger.NewArchivePath(jlf.directory, chainID)
+	if err != nil {
+		return "", err
+	}
+	return archivePath, os.Rename(filepath.Join(jlf.directory, fmt.Sprintf(chainDirectoryFormatString, chainID)), archivePath)
+}
+
 // Close is a no-op for the JSON ledger
 func (jlf *jsonLedgerFactory) Close() {
 	return // nothing to do
@@ -17,10 +17,18 @@ limitations under the License.
 package ledger
 
 import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
 	cb "github.com/hyperledger/fabric/protos/common"
 	ab "github.com/hyperledger/fabric/protos/orderer"
 )
 
+// ArchiveDir is the directory, within the directory of a Factory, the archived ledgers are moved to
+const ArchiveDir = "archive"
+
 // Factory retrieves or creates new ledgers by chainID
 type Factory interface {
 	// GetOrCreate gets an existing ledger (if it exists)
@@ -30,6 +38,13 @@ type Factory interface {
 	// ChainIDs returns the chain IDs the Factory is aware of
 	ChainIDs() []string
 
+	// Remove deletes the ledger of a chain, the ledger must no longer be written
+	Remove(chainID string) error
+
+	// Archive closes the ledger of a chain and moves its data aside, returning where
+	// it was moved to, the ledger must no longer be written
+	Archive(chainID string) (string, error)
+
 	// Close releases all resources acquired by the factory
 	Close()
 }
@@ -63,3 +78,13 @@ type ReadWriter interface {
 	Reader
 	Writer
 }
+
+// NewArchivePath creates the archive directory of a Factory and returns the path within it
+// the data of the ledger of a chain is moved to, distinct for each time it is archived
+func NewArchivePath(directory string, chainID string) (string, error) {
+	archiveDir := filepath.Join(directory, ArchiveDir)
+	if err := os.MkdirAll(archiveDir, 0755); err != nil {
+		return "", err
+	}
+	return filepath.Join(archiveDir, fmt.Sprintf("%s_%d", chainID, time.Now().UnixNano())), nil
+}
@@ -0,0 +1,80 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+                 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrate
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/hyperledger/fabric/orderer/ledger"
+	cb "github.com/hyperledger/fabric/protos/common"
+	ab "github.com/hyperledger/fabric/protos/orderer"
+	"github.com/op/go-logging"
+)
+
+var logger = logging.MustGetLogger("orderer/ledger/migrate")
+
+// Migrate copies the blocks of all chains of the source factory to the target factory. The blocks
+// the target holds already are checked against the source, so that an interrupted migration resumes
+// where it stopped.
+func Migrate(source, target ledger.Factory) error {
+	for _, chainID := range source.ChainIDs() {
+		if err := migrateChain(chainID, source, target); err != nil {
+			return fmt.Errorf("Error migrating chain %s: %s", chainID, err)
+		}
+	}
+	return nil
+}
+
+func migrateChain(chainID string, source, target ledger.Factory) error {
+	src, err := source.GetOrCreate(chainID)
+	if err != nil {
+		return err
+	}
+	dst, err := target.GetOrCreate(chainID)
+	if err != nil {
+		return err
+	}
+
+	height, start := src.Height(), dst.Height()
+	if start > height {
+		return fmt.Errorf("Target holds %d blocks, more than the %d blocks of the source", start, height)
+	}
+	if start > 0 {
+		srcBlock, dstBlock := ledger.GetBlock(src, start-1), ledger.GetBlock(dst, start-1)
+		if srcBlock == nil || dstBlock == nil || !bytes.Equal(srcBlock.Header.Hash(), dstBlock.Header.Hash()) {
+			return fmt.Errorf("Block %d of the target differs from the one of the source", start-1)
+		}
+	}
+	if start == height {
+		logger.Infof("Chain %s with %d blocks was migrated already", chainID, height)
+		return nil
+	}
+
+	iterator, _ := src.Iterator(&ab.SeekPosition{Type: &ab.SeekPosition_Specified{Specified: &ab.SeekSpecified{Number: start}}})
+	for number := start; number < height; number++ {
+		block, status := iterator.Next()
+		if status != cb.Status_SUCCESS {
+			return fmt.Errorf("Cannot read block %d: %s", number, status)
+		}
+		if err := dst.Append(block); err != nil {
+			return fmt.Errorf("Cannot append block %d: %s", number, err)
+		}
+	}
+	logger.Infof("Migrated blocks %d to %d of chain %s", start, height-1, chainID)
+	return nil
+}
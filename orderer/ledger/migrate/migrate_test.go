@@ -0,0 +1,126 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+                 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrate
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/hyperledger/fabric/orderer/ledger"
+	fileledger "github.com/hyperledger/fabric/orderer/ledger/file"
+	jsonledger "github.com/hyperledger/fabric/orderer/ledger/json"
+	ramledger "github.com/hyperledger/fabric/orderer/ledger/ram"
+	cb "github.com/hyperledger/fabric/protos/common"
+)
+
+func appendBlocks(t *testing.T, rw ledger.ReadWriter, count int) {
+	for i := 0; i < count; i++ {
+		var block *cb.Block
+		if rw.Height() == 0 {
+			block = cb.NewBlock(0, nil)
+		} else {
+			block = ledger.CreateNextBlock(rw, []*cb.Envelope{&cb.Envelope{Payload: []byte(fmt.Sprintf("%d", rw.Height()))}})
+		}
+		if err := rw.Append(block); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func tempDir(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "hyperledger_fabric")
+	if err != nil {
+		t.Fatalf("Error creating temp dir: %s", err)
+	}
+	return dir
+}
+
+func assertSameBlocks(t *testing.T, expected, actual ledger.Reader) {
+	if expected.Height() != actual.Height() {
+		t.Fatalf("Expected %d blocks, got %d", expected.Height(), actual.Height())
+	}
+	for i := uint64(0); i < expected.Height(); i++ {
+		if !bytes.Equal(ledger.GetBlock(expected, i).Header.Hash(), ledger.GetBlock(actual, i).Header.Hash()) {
+			t.Fatalf("Block %d differs", i)
+		}
+	}
+}
+
+func TestMigrateJSONToFile(t *testing.T) {
+	srcDir, dstDir := tempDir(t), tempDir(t)
+	defer os.RemoveAll(srcDir)
+	defer os.RemoveAll(dstDir)
+
+	source := jsonledger.New(srcDir)
+	for chainID, count := range map[string]int{"foo": 5, "bar": 1} {
+		rw, _ := source.GetOrCreate(chainID)
+		appendBlocks(t, rw, count)
+	}
+
+	target := fileledger.New(dstDir)
+	defer target.Close()
+	if err := Migrate(source, target); err != nil {
+		t.Fatalf("Migration failed: %s", err)
+	}
+
+	for _, chainID := range []string{"foo", "bar"} {
+		src, _ := source.GetOrCreate(chainID)
+		dst, _ := target.GetOrCreate(chainID)
+		assertSameBlocks(t, src, dst)
+	}
+}
+
+func TestMigrateResume(t *testing.T) {
+	source := ramledger.New(10)
+	src, _ := source.GetOrCreate("foo")
+	appendBlocks(t, src, 3)
+
+	target := ramledger.New(10)
+	if err := Migrate(source, target); err != nil {
+		t.Fatalf("Migration failed: %s", err)
+	}
+
+	appendBlocks(t, src, 2)
+	if err := Migrate(source, target); err != nil {
+		t.Fatalf("Resumed migration failed: %s", err)
+	}
+	dst, _ := target.GetOrCreate("foo")
+	assertSameBlocks(t, src, dst)
+}
+
+func TestMigrateDiverged(t *testing.T) {
+	source := ramledger.New(10)
+	src, _ := source.GetOrCreate("foo")
+	appendBlocks(t, src, 3)
+
+	target := ramledger.New(10)
+	dst, _ := target.GetOrCreate("foo")
+	appendBlocks(t, dst, 1)
+	dst.Append(ledger.CreateNextBlock(dst, []*cb.Envelope{&cb.Envelope{Payload: []byte("other")}}))
+
+	if err := Migrate(source, target); err == nil {
+		t.Fatalf("Migration should have failed on the diverged target")
+	}
+
+	appendBlocks(t, dst, 2)
+	if err := Migrate(source, target); err == nil {
+		t.Fatalf("Migration should have failed on the target holding more blocks")
+	}
+}
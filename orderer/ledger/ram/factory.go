@@ -81,6 +81,19 @@ func (rlf *ramLedgerFactory) ChainIDs() []string {
 	return ids
 }
 
+// Remove deletes the ledger of a chain
+func (rlf *ramLedgerFactory) Remove(chainID string) error {
+	rlf.mutex.Lock()
+	defer rlf.mutex.Unlock()
+	delete(rlf.ledgers, chainID)
+	return nil
+}
+
+// Archive deletes the ledger of a chain, as the RAM ledger has no data to move aside
+func (rlf *ramLedgerFactory) Archive(chainID string) (string, error) {
+	return "", rlf.Remove(chainID)
+}
+
 // Close is a no-op for the RAM ledger
 func (rlf *ramLedgerFactory) Close() {
 	return // nothing to do
@@ -50,6 +50,8 @@ type General struct {
 	GenesisMethod  string
 	GenesisProfile string
 	GenesisFile    string
+	CommitTimeout  time.Duration
+	TxIDWindowSize uint
 	Profile        Profile
 	LogLevel       string
 	LocalMSPDir    string
@@ -67,22 +69,37 @@ type TLS struct {
 	ClientRootCAs     []string
 }
 
-// Genesis is a deprecated structure which was used to put
-// values into the genesis block, but this is now handled elsewhere
-// SBFT did not reference these values via the genesis block however
-// so it is being left here for backwards compatibility purposes
-type Genesis struct {
-	DeprecatedBatchTimeout time.Duration
-	DeprecatedBatchSize    uint32
-	SbftShared             SbftShared
-}
-
 // Profile contains configuration for Go pprof profiling
 type Profile struct {
 	Enabled bool
 	Address string
 }
 
+// Admin contains config for the orderer admin service
+type Admin struct {
+	TimeWindow time.Duration
+	HTTP       AdminHTTP
+}
+
+// AdminHTTP contains config for serving the admin service over HTTP
+type AdminHTTP struct {
+	Enabled bool
+	Address string
+}
+
+// Operations contains config for the HTTP service reporting the health and metrics of the orderer
+type Operations struct {
+	Enabled bool
+	Address string
+}
+
+// Replication contains config for pulling the blocks of the chains from other orderers on start
+type Replication struct {
+	Enabled   bool
+	Endpoints []string
+	Timeout   time.Duration
+}
+
 // RAMLedger contains config for the RAM ledger
 type RAMLedger struct {
 	HistorySize uint
@@ -100,6 +117,27 @@ type Kafka struct {
 	Verbose bool
 	Version sarama.KafkaVersion
 	TLS     TLS
+	SASL    SASL
+}
+
+// SASL contains config for the SASL authentication with the Kafka brokers, the
+// mechanism is one of PLAIN, SCRAM-SHA-256 and SCRAM-SHA-512
+type SASL struct {
+	Enabled   bool
+	Mechanism string
+	User      string
+	Password  string
+}
+
+// Raft contains config for the Raft orderer
+type Raft struct {
+	Address          string
+	WALDir           string
+	SnapDir          string
+	TickInterval     time.Duration
+	ElectionTick     int
+	HeartbeatTick    int
+	SnapshotInterval uint64
 }
 
 // SbftLocal contains config for the SBFT peer/replica
@@ -110,37 +148,27 @@ type SbftLocal struct {
 	DataDir      string
 }
 
-// SbftShared contains config for the SBFT network
-type SbftShared struct {
-	N                  uint64
-	F                  uint64
-	RequestTimeoutNsec uint64
-	Peers              map[string]string // Address to Cert mapping
-}
-
 // Retry contains config for the reconnection attempts to the Kafka brokers
 type Retry struct {
 	Period time.Duration
 	Stop   time.Duration
 }
 
-type RuntimeAndGenesis struct {
-	runtime *TopLevel
-	genesis *Genesis
-}
-
 // TopLevel directly corresponds to the orderer config yaml
 // Note, for non 1-1 mappings, you may append
 // something like `mapstructure:"weirdFoRMat"` to
 // modify the default mapping, see the "Unmarshal"
 // section of https://github.com/spf13/viper for more info
 type TopLevel struct {
-	General    General
-	RAMLedger  RAMLedger
-	FileLedger FileLedger
-	Kafka      Kafka
-	Genesis    Genesis
-	SbftLocal  SbftLocal
+	General     General
+	Admin       Admin
+	Operations  Operations
+	Replication Replication
+	RAMLedger   RAMLedger
+	FileLedger  FileLedger
+	Kafka       Kafka
+	Raft        Raft
+	SbftLocal   SbftLocal
 }
 
 var defaults = TopLevel{
@@ -151,6 +179,8 @@ var defaults = TopLevel{
 		GenesisMethod:  "provisional",
 		GenesisProfile: "SampleSingleMSPSolo",
 		GenesisFile:    "./genesisblock",
+		CommitTimeout:  30 * time.Second,
+		TxIDWindowSize: 10000,
 		Profile: Profile{
 			Enabled: false,
 			Address: "0.0.0.0:6060",
@@ -160,6 +190,21 @@ var defaults = TopLevel{
 		LocalMSPID:  "DEFAULT",
 		BCCSP:       &bccsp.DefaultOpts,
 	},
+	Admin: Admin{
+		TimeWindow: 15 * time.Minute,
+		HTTP: AdminHTTP{
+			Enabled: false,
+			Address: "127.0.0.1:7055",
+		},
+	},
+	Operations: Operations{
+		Enabled: false,
+		Address: "127.0.0.1:9443",
+	},
+	Replication: Replication{
+		Enabled: false,
+		Timeout: 10 * time.Second,
+	},
 	RAMLedger: RAMLedger{
 		HistorySize: 10000,
 	},
@@ -177,15 +222,20 @@ var defaults = TopLevel{
 		TLS: TLS{
 			Enabled: false,
 		},
-	},
-	Genesis: Genesis{
-		SbftShared: SbftShared{
-			N:                  1,
-			F:                  0,
-			RequestTimeoutNsec: uint64(time.Second.Nanoseconds()),
-			Peers:              map[string]string{":6101": "sbft/testdata/cert1.pem"},
+		SASL: SASL{
+			Enabled:   false,
+			Mechanism: sarama.SASLTypePlaintext,
 		},
 	},
+	Raft: Raft{
+		Address:          "127.0.0.1:7050",
+		WALDir:           "/tmp/hyperledger/orderer/raft/wal",
+		SnapDir:          "/tmp/hyperledger/orderer/raft/snap",
+		TickInterval:     100 * time.Millisecond,
+		ElectionTick:     10,
+		HeartbeatTick:    1,
+		SnapshotInterval: 100,
+	},
 	SbftLocal: SbftLocal{
 		PeerCommAddr: ":6101",
 		CertFile:     "sbft/testdata/cert1.pem",
@@ -217,15 +267,42 @@ func (c *TopLevel) completeInitialization() {
 			c.General.GenesisFile = defaults.General.GenesisFile
 		case c.General.GenesisProfile == "":
 			c.General.GenesisProfile = defaults.General.GenesisProfile
+		case c.General.CommitTimeout == 0:
+			logger.Infof("General.CommitTimeout unset, setting to %v", defaults.General.CommitTimeout)
+			c.General.CommitTimeout = defaults.General.CommitTimeout
 		case c.Kafka.TLS.Enabled && c.Kafka.TLS.Certificate == "":
 			logger.Panicf("General.Kafka.TLS.Certificate must be set if General.Kafka.TLS.Enabled is set to true.")
 		case c.Kafka.TLS.Enabled && c.Kafka.TLS.PrivateKey == "":
 			logger.Panicf("General.Kafka.TLS.PrivateKey must be set if General.Kafka.TLS.Enabled is set to true.")
 		case c.Kafka.TLS.Enabled && c.Kafka.TLS.RootCAs == nil:
 			logger.Panicf("General.Kafka.TLS.CertificatePool must be set if General.Kafka.TLS.Enabled is set to true.")
+		case c.Kafka.SASL.Enabled && c.Kafka.SASL.User == "":
+			logger.Panicf("Kafka.SASL.User must be set if Kafka.SASL.Enabled is set to true.")
+		case c.Kafka.SASL.Enabled && c.Kafka.SASL.Password == "":
+			logger.Panicf("Kafka.SASL.Password must be set if Kafka.SASL.Enabled is set to true.")
+		case c.Kafka.SASL.Enabled && c.Kafka.SASL.Mechanism == "":
+			logger.Infof("Kafka.SASL.Mechanism unset, setting to %s", defaults.Kafka.SASL.Mechanism)
+			c.Kafka.SASL.Mechanism = defaults.Kafka.SASL.Mechanism
+		case c.Kafka.SASL.Enabled && c.Kafka.SASL.Mechanism != sarama.SASLTypePlaintext &&
+			c.Kafka.SASL.Mechanism != sarama.SASLTypeSCRAMSHA256 && c.Kafka.SASL.Mechanism != sarama.SASLTypeSCRAMSHA512:
+			logger.Panicf("Kafka.SASL.Mechanism %s is not supported.", c.Kafka.SASL.Mechanism)
 		case c.General.Profile.Enabled && (c.General.Profile.Address == ""):
 			logger.Infof("Profiling enabled and General.Profile.Address unset, setting to %s", defaults.General.Profile.Address)
 			c.General.Profile.Address = defaults.General.Profile.Address
+		case c.Admin.TimeWindow == 0:
+			logger.Infof("Admin.TimeWindow unset, setting to %v", defaults.Admin.TimeWindow)
+			c.Admin.TimeWindow = defaults.Admin.TimeWindow
+		case c.Admin.HTTP.Enabled && c.Admin.HTTP.Address == "":
+			logger.Infof("Admin HTTP enabled and Admin.HTTP.Address unset, setting to %s", defaults.Admin.HTTP.Address)
+			c.Admin.HTTP.Address = defaults.Admin.HTTP.Address
+		case c.Operations.Enabled && c.Operations.Address == "":
+			logger.Infof("Operations enabled and Operations.Address unset, setting to %s", defaults.Operations.Address)
+			c.Operations.Address = defaults.Operations.Address
+		case c.Replication.Enabled && len(c.Replication.Endpoints) == 0:
+			logger.Panicf("Replication.Endpoints must be set if Replication.Enabled is set to true.")
+		case c.Replication.Enabled && c.Replication.Timeout == 0:
+			logger.Infof("Replication enabled and Replication.Timeout unset, setting to %v", defaults.Replication.Timeout)
+			c.Replication.Timeout = defaults.Replication.Timeout
 		case c.General.LocalMSPDir == "":
 			logger.Infof("General.LocalMSPDir unset, setting to %s", defaults.General.LocalMSPDir)
 			// Note, this is a bit of a weird one, the orderer may set the ORDERER_CFG_PATH after
@@ -244,6 +321,29 @@ func (c *TopLevel) completeInitialization() {
 		case c.Kafka.Retry.Stop == 0*time.Second:
 			logger.Infof("Kafka.Retry.Stop unset, setting to %v", defaults.Kafka.Retry.Stop)
 			c.Kafka.Retry.Stop = defaults.Kafka.Retry.Stop
+		case c.Raft.Address == "":
+			logger.Infof("Raft.Address unset, setting to %s", defaults.Raft.Address)
+			c.Raft.Address = defaults.Raft.Address
+		case c.Raft.WALDir == "":
+			logger.Infof("Raft.WALDir unset, setting to %s", defaults.Raft.WALDir)
+			c.Raft.WALDir = defaults.Raft.WALDir
+		case c.Raft.SnapDir == "":
+			logger.Infof("Raft.SnapDir unset, setting to %s", defaults.Raft.SnapDir)
+			c.Raft.SnapDir = defaults.Raft.SnapDir
+		case c.Raft.TickInterval == 0*time.Second:
+			logger.Infof("Raft.TickInterval unset, setting to %v", defaults.Raft.TickInterval)
+			c.Raft.TickInterval = defaults.Raft.TickInterval
+		case c.Raft.ElectionTick == 0:
+			logger.Infof("Raft.ElectionTick unset, setting to %d", defaults.Raft.ElectionTick)
+			c.Raft.ElectionTick = defaults.Raft.ElectionTick
+		case c.Raft.HeartbeatTick == 0:
+			logger.Infof("Raft.HeartbeatTick unset, setting to %d", defaults.Raft.HeartbeatTick)
+			c.Raft.HeartbeatTick = defaults.Raft.HeartbeatTick
+		case c.Raft.HeartbeatTick >= c.Raft.ElectionTick:
+			logger.Panicf("Raft.HeartbeatTick (%d) must be lower than Raft.ElectionTick (%d)", c.Raft.HeartbeatTick, c.Raft.ElectionTick)
+		case c.Raft.SnapshotInterval == 0:
+			logger.Infof("Raft.SnapshotInterval unset, setting to %d", defaults.Raft.SnapshotInterval)
+			c.Raft.SnapshotInterval = defaults.Raft.SnapshotInterval
 		default:
 			// A bit hacky, but its type makes it impossible to test for a nil value.
 			// This may be overwritten by the Kafka orderer upon instantiation.
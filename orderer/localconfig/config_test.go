@@ -104,3 +104,28 @@ func TestKafkaTLSConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestKafkaSASLConfig(t *testing.T) {
+	testCases := []struct {
+		name        string
+		sasl        SASL
+		shouldPanic bool
+	}{
+		{"Disabled", SASL{Enabled: false}, false},
+		{"EnabledNoUser", SASL{Enabled: true, Password: "password"}, true},
+		{"EnabledNoPassword", SASL{Enabled: true, User: "user"}, true},
+		{"Enabled", SASL{Enabled: true, User: "user", Password: "password"}, false},
+		{"SCRAM", SASL{Enabled: true, Mechanism: "SCRAM-SHA-512", User: "user", Password: "password"}, false},
+		{"UnknownMechanism", SASL{Enabled: true, Mechanism: "GSSAPI", User: "user", Password: "password"}, true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			uconf := &TopLevel{Kafka: Kafka{SASL: tc.sasl}}
+			if tc.shouldPanic {
+				assert.Panics(t, func() { uconf.completeInitialization() }, "should panic")
+			} else {
+				assert.NotPanics(t, func() { uconf.completeInitialization() }, "should not panic")
+			}
+		})
+	}
+}
@@ -29,10 +29,14 @@ import (
 	"github.com/hyperledger/fabric/common/configtx/tool/provisional"
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/core/comm"
+	"github.com/hyperledger/fabric/orderer/common/admin"
 	"github.com/hyperledger/fabric/orderer/common/bootstrap/file"
+	"github.com/hyperledger/fabric/orderer/common/operations"
+	"github.com/hyperledger/fabric/orderer/common/replication"
 	"github.com/hyperledger/fabric/orderer/kafka"
 	"github.com/hyperledger/fabric/orderer/localconfig"
 	"github.com/hyperledger/fabric/orderer/multichain"
+	"github.com/hyperledger/fabric/orderer/raft"
 	"github.com/hyperledger/fabric/orderer/sbft"
 	"github.com/hyperledger/fabric/orderer/solo"
 	cb "github.com/hyperledger/fabric/protos/common"
@@ -43,6 +47,7 @@ import (
 	"github.com/hyperledger/fabric/common/localmsp"
 	mspmgmt "github.com/hyperledger/fabric/msp/mgmt"
 	logging "github.com/op/go-logging"
+	"github.com/rcrowley/go-metrics"
 )
 
 var logger = logging.MustGetLogger("orderer/main")
@@ -128,7 +133,7 @@ func main() {
 		logger.Panic("Failed to initialize local MSP:", err)
 	}
 
-	lf, _ := createLedgerFactory(conf)
+	lf, ld := createLedgerFactory(conf)
 
 	// Are we bootstrapping?
 	if len(lf.ChainIDs()) == 0 {
@@ -140,25 +145,29 @@ func main() {
 			genesisBlock = provisional.New(genesisconfig.Load(conf.General.GenesisProfile)).GenesisBlock()
 		case "file":
 			genesisBlock = file.New(conf.General.GenesisFile).GenesisBlock()
+		case "none":
+			logger.Info("Not bootstrapping, the orderer serves the channels it is joined to")
 		default:
 			logger.Panic("Unknown genesis method:", conf.General.GenesisMethod)
 		}
 
-		chainID, err := utils.GetChainIDFromBlock(genesisBlock)
-		if err != nil {
-			logger.Error("Failed to parse chain ID from genesis block:", err)
-			return
-		}
-		gl, err := lf.GetOrCreate(chainID)
-		if err != nil {
-			logger.Error("Failed to create the system chain:", err)
-			return
-		}
+		if genesisBlock != nil {
+			chainID, err := utils.GetChainIDFromBlock(genesisBlock)
+			if err != nil {
+				logger.Error("Failed to parse chain ID from genesis block:", err)
+				return
+			}
+			gl, err := lf.GetOrCreate(chainID)
+			if err != nil {
+				logger.Error("Failed to create the system chain:", err)
+				return
+			}
 
-		err = gl.Append(genesisBlock)
-		if err != nil {
-			logger.Error("Could not write genesis block to ledger:", err)
-			return
+			err = gl.Append(genesisBlock)
+			if err != nil {
+				logger.Error("Could not write genesis block to ledger:", err)
+				return
+			}
 		}
 	} else {
 		logger.Info("Not bootstrapping because of existing chains")
@@ -166,19 +175,79 @@ func main() {
 
 	consenters := make(map[string]multichain.Consenter)
 	consenters["solo"] = solo.New()
-	consenters["kafka"] = kafka.New(conf.Kafka.Version, conf.Kafka.Retry, conf.Kafka.TLS)
-	consenters["sbft"] = sbft.New(makeSbftConsensusConfig(conf), makeSbftStackConfig(conf))
+	consenters["kafka"] = kafka.New(conf.Kafka.Version, conf.Kafka.Retry, conf.Kafka.TLS, conf.Kafka.SASL)
+	consenters["sbft"] = sbft.New(makeSbftStackConfig(conf))
+
+	raftDialOpts, err := makeRaftDialOptions(secureConfig)
+	if err != nil {
+		logger.Error("Failed to create the raft dial options:", err)
+		return
+	}
+	raftConsenter := raft.New(conf.Raft, raftDialOpts...)
+	consenters["raft"] = raftConsenter
 
 	signer := localmsp.NewSigner()
 
-	manager := multichain.NewManagerImpl(lf, consenters, signer)
+	// Pull the blocks of the chains from the other orderers before the consenters start,
+	// they are dialed the way the raft consenters are
+	if conf.Replication.Enabled {
+		logger.Info("Replicating the chains from:", conf.Replication.Endpoints)
+		if err := replication.New(lf, signer, conf.Replication.Endpoints, conf.Replication.Timeout, raftDialOpts...).Replicate(); err != nil {
+			logger.Warning("Chains were not fully replicated, their consenters catch up instead:", err)
+		}
+	}
+
+	manager := multichain.NewManagerImpl(lf, consenters, signer, int(conf.General.TxIDWindowSize))
 
 	server := NewServer(
 		manager,
 		signer,
+		conf.General.CommitTimeout,
 	)
 
+	adminPolicy, err := makeAdminPolicy(mspmgmt.GetLocalMSP(), conf.General.LocalMSPID)
+	if err != nil {
+		logger.Error("Failed to create the admin policy:", err)
+		return
+	}
+	// The admin requests are addressed to the orderer by its identity
+	shdr, err := signer.NewSignatureHeader()
+	if err != nil {
+		logger.Error("Failed to get the identity of the orderer:", err)
+		return
+	}
+	adminHandler := admin.NewHandlerImpl(adminSupport{Manager: manager}, adminPolicy, shdr.Creator, conf.Admin.TimeWindow)
+
+	// Serve the admin service over HTTP if enabled.
+	// The ListenAndServe() call does not return unless an error occurs.
+	if conf.Admin.HTTP.Enabled {
+		go func() {
+			logger.Info("Starting admin HTTP service on:", conf.Admin.HTTP.Address)
+			if conf.General.TLS.Enabled {
+				logger.Panic("Admin HTTP service failed:", http.ListenAndServeTLS(conf.Admin.HTTP.Address, conf.General.TLS.Certificate, conf.General.TLS.PrivateKey, adminHandler))
+			}
+			logger.Panic("Admin HTTP service failed:", http.ListenAndServe(conf.Admin.HTTP.Address, adminHandler))
+		}()
+	}
+
+	// Serve the health and metrics of the orderer over HTTP if enabled.
+	// The ListenAndServe() call does not return unless an error occurs.
+	if conf.Operations.Enabled {
+		operationsHandler := operations.NewHandler(operationsSupport{Manager: manager}, metrics.DefaultRegistry, map[string]operations.Check{
+			"ledger": makeLedgerCheck(ld),
+		})
+		go func() {
+			logger.Info("Starting operations HTTP service on:", conf.Operations.Address)
+			if conf.General.TLS.Enabled {
+				logger.Panic("Operations HTTP service failed:", http.ListenAndServeTLS(conf.Operations.Address, conf.General.TLS.Certificate, conf.General.TLS.PrivateKey, operationsHandler))
+			}
+			logger.Panic("Operations HTTP service failed:", http.ListenAndServe(conf.Operations.Address, operationsHandler))
+		}()
+	}
+
 	ab.RegisterAtomicBroadcastServer(grpcServer.Server(), server)
+	ab.RegisterClusterServer(grpcServer.Server(), raftConsenter)
+	ab.RegisterAdminServer(grpcServer.Server(), adminHandler)
 	logger.Info("Beginning to serve requests")
 	grpcServer.Start()
 }
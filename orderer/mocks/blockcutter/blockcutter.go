@@ -17,6 +17,9 @@ limitations under the License.
 package mocks
 
 import (
+	"time"
+
+	"github.com/hyperledger/fabric/orderer/common/blockcutter"
 	"github.com/hyperledger/fabric/orderer/common/filter"
 	cb "github.com/hyperledger/fabric/protos/common"
 )
@@ -44,16 +47,23 @@ type Receiver struct {
 	// Block is a channel which is read from before returning from Ordered, it is useful for synchronization
 	// If you do not wish synchronization for whatever reason, simply close the channel
 	Block chan struct{}
+
+	// BatchTimeout is the timeout the timer of a pending batch is started with
+	BatchTimeout time.Duration
+
+	timer *blockcutter.Timer
 }
 
 // NewReceiver returns the mock blockcutter.Receiver implemenation
 func NewReceiver() *Receiver {
-	return &Receiver{
+	mbc := &Receiver{
 		QueueNext:  true,
 		IsolatedTx: false,
 		CutNext:    false,
 		Block:      make(chan struct{}),
 	}
+	mbc.timer = blockcutter.NewTimer(func() time.Duration { return mbc.BatchTimeout })
+	return mbc
 }
 
 func noopCommitters(size int) []filter.Committer {
@@ -79,15 +89,18 @@ func (mbc *Receiver) Ordered(env *cb.Envelope) ([][]*cb.Envelope, [][]filter.Com
 		logger.Debugf("Receiver: Returning dual batch")
 		res := [][]*cb.Envelope{mbc.CurBatch, []*cb.Envelope{env}}
 		mbc.CurBatch = nil
+		mbc.timer.Stop()
 		return res, [][]filter.Committer{noopCommitters(len(res[0])), noopCommitters(len(res[1]))}, true
 	}
 
 	mbc.CurBatch = append(mbc.CurBatch, env)
+	mbc.timer.Start()
 
 	if mbc.CutNext {
 		logger.Debugf("Returning regular batch")
 		res := [][]*cb.Envelope{mbc.CurBatch}
 		mbc.CurBatch = nil
+		mbc.timer.Stop()
 		return res, [][]filter.Committer{noopCommitters(len(res))}, true
 	}
 
@@ -100,5 +113,11 @@ func (mbc *Receiver) Cut() ([]*cb.Envelope, []filter.Committer) {
 	logger.Debugf("Cutting batch")
 	res := mbc.CurBatch
 	mbc.CurBatch = nil
+	mbc.timer.Stop()
 	return res, noopCommitters(len(res))
 }
+
+// Timer returns the timer of the current batch, which is started with BatchTimeout
+func (mbc *Receiver) Timer() *blockcutter.Timer {
+	return mbc.timer
+}
@@ -21,6 +21,7 @@ import (
 	mockconfigtxorderer "github.com/hyperledger/fabric/common/mocks/configvalues/channel/orderer"
 	"github.com/hyperledger/fabric/orderer/common/blockcutter"
 	"github.com/hyperledger/fabric/orderer/common/filter"
+	"github.com/hyperledger/fabric/orderer/ledger"
 	mockblockcutter "github.com/hyperledger/fabric/orderer/mocks/blockcutter"
 	cb "github.com/hyperledger/fabric/protos/common"
 	"github.com/hyperledger/fabric/protos/utils"
@@ -50,6 +51,18 @@ type ConsenterSupport struct {
 
 	// WriteBlockVal stores the block created by the most recent WriteBlock() call
 	WriteBlockVal *cb.Block
+
+	// ReaderVal is the value returned by Reader()
+	ReaderVal ledger.Reader
+
+	// SequenceVal is the value returned by Sequence()
+	SequenceVal uint64
+
+	// ProposeConfigUpdateVal is the value returned by ProposeConfigUpdate()
+	ProposeConfigUpdateVal *cb.ConfigEnvelope
+
+	// ProposeConfigUpdateErr is the error returned by ProposeConfigUpdate()
+	ProposeConfigUpdateErr error
 }
 
 // BlockCutter returns BlockCutterVal
@@ -95,6 +108,21 @@ func (mcs *ConsenterSupport) ChainID() string {
 	return mcs.ChainIDVal
 }
 
+// Reader returns ReaderVal
+func (mcs *ConsenterSupport) Reader() ledger.Reader {
+	return mcs.ReaderVal
+}
+
+// Sequence returns SequenceVal
+func (mcs *ConsenterSupport) Sequence() uint64 {
+	return mcs.SequenceVal
+}
+
+// ProposeConfigUpdate returns ProposeConfigUpdateVal and ProposeConfigUpdateErr
+func (mcs *ConsenterSupport) ProposeConfigUpdate(env *cb.Envelope) (*cb.ConfigEnvelope, error) {
+	return mcs.ProposeConfigUpdateVal, mcs.ProposeConfigUpdateErr
+}
+
 // Sign returns the bytes passed in
 func (mcs *ConsenterSupport) Sign(message []byte) ([]byte, error) {
 	return message, nil
@@ -17,6 +17,9 @@ limitations under the License.
 package multichain
 
 import (
+	"fmt"
+	"sync"
+
 	"github.com/hyperledger/fabric/common/config"
 	"github.com/hyperledger/fabric/common/crypto"
 	"github.com/hyperledger/fabric/common/policies"
@@ -24,12 +27,19 @@ import (
 	"github.com/hyperledger/fabric/orderer/common/blockcutter"
 	"github.com/hyperledger/fabric/orderer/common/broadcast"
 	"github.com/hyperledger/fabric/orderer/common/configtxfilter"
+	"github.com/hyperledger/fabric/orderer/common/dedupfilter"
 	"github.com/hyperledger/fabric/orderer/common/filter"
+	"github.com/hyperledger/fabric/orderer/common/maintenancefilter"
+	"github.com/hyperledger/fabric/orderer/common/ratelimitfilter"
+	"github.com/hyperledger/fabric/orderer/common/sealedfilter"
 	"github.com/hyperledger/fabric/orderer/common/sigfilter"
 	"github.com/hyperledger/fabric/orderer/common/sizefilter"
 	"github.com/hyperledger/fabric/orderer/ledger"
 	cb "github.com/hyperledger/fabric/protos/common"
+	ab "github.com/hyperledger/fabric/protos/orderer"
 	"github.com/hyperledger/fabric/protos/utils"
+
+	"github.com/golang/protobuf/proto"
 )
 
 // Consenter defines the backing ordering mechanism
@@ -62,6 +72,22 @@ type Chain interface {
 	Halt()
 }
 
+// HealthChecker is implemented by the chains which rely on connections to other processes to order messages
+type HealthChecker interface {
+	// HealthCheck returns an error if the chain cannot currently order messages
+	HealthCheck() error
+}
+
+// Remover is implemented by the consenters which keep state for their chains besides the ledger
+type Remover interface {
+	// RemoveChain releases a halted chain and deletes its state, so that the chain may be joined again
+	RemoveChain(chainID string) error
+
+	// ArchiveChain releases a halted chain and moves its state within the archive path of its ledger,
+	// or deletes it if the ledger was not archived to a path
+	ArchiveChain(chainID string, archivePath string) error
+}
+
 // ConsenterSupport provides the resources available to a Consenter implementation
 type ConsenterSupport interface {
 	crypto.LocalSigner
@@ -69,7 +95,12 @@ type ConsenterSupport interface {
 	SharedConfig() config.Orderer
 	CreateNextBlock(messages []*cb.Envelope) *cb.Block
 	WriteBlock(block *cb.Block, committers []filter.Committer, encodedMetadataValue []byte) *cb.Block
-	ChainID() string // ChainID returns the chain ID this specific consenter instance is associated with
+	ChainID() string       // ChainID returns the chain ID this specific consenter instance is associated with
+	Reader() ledger.Reader // Reader returns the chain Reader for the chain
+	Sequence() uint64      // Sequence returns the current config sequence of the chain
+
+	// ProposeConfigUpdate applies a CONFIG_UPDATE to an existing config to produce a *cb.ConfigEnvelope
+	ProposeConfigUpdate(env *cb.Envelope) (*cb.ConfigEnvelope, error)
 }
 
 // ChainSupport provides a wrapper for the resources backing a chain
@@ -80,31 +111,47 @@ type ChainSupport interface {
 	// PolicyManager returns the current policy manager as specified by the chain config
 	PolicyManager() policies.Manager
 
-	// Reader returns the chain Reader for the chain
-	Reader() ledger.Reader
+	// HealthCheck returns an error if the chain is halted, or if its consenter cannot currently order messages
+	HealthCheck() error
 
 	broadcast.Support
 	ConsenterSupport
-
-	// ProposeConfigUpdate applies a CONFIG_UPDATE to an existing config to produce a *cb.ConfigEnvelope
-	ProposeConfigUpdate(env *cb.Envelope) (*cb.ConfigEnvelope, error)
 }
 
 type chainSupport struct {
 	*ledgerResources
-	chain         Chain
-	cutter        blockcutter.Receiver
-	filters       *filter.RuleSet
-	signer        crypto.LocalSigner
-	lastConfig    uint64
-	lastConfigSeq uint64
+	chain            Chain
+	consensusType    string
+	cutter           blockcutter.Receiver
+	filters          *filter.RuleSet
+	broadcastFilters *filter.RuleSet
+	signer           crypto.LocalSigner
+	lastConfig       uint64
+	lastConfigSeq    uint64
+
+	// metadata is the orderer metadata value last written by the consenter, it is recorded
+	// by the block changing the consensus type so the consenter resumes from it if the
+	// chain is migrated back to its type
+	metadata []byte
+
+	// migrate is invoked once a block changing the consensus type is written,
+	// to restart the chain with the consenter of the new type
+	migrate func(cs *chainSupport)
+
+	// writeLock guards halted, the blocks written once the chain is halted are dropped
+	writeLock sync.Mutex
+	halted    bool
 }
 
+// newChainSupport creates the support for a chain, the filters are applied to ordered messages
+// before they are cut into blocks, the broadcastFilters when the messages are broadcast
 func newChainSupport(
 	filters *filter.RuleSet,
+	broadcastFilters *filter.RuleSet,
 	ledgerResources *ledgerResources,
 	consenters map[string]Consenter,
 	signer crypto.LocalSigner,
+	migrate func(cs *chainSupport),
 ) *chainSupport {
 
 	cutter := blockcutter.NewReceiverImpl(ledgerResources.SharedConfig(), filters)
@@ -115,10 +162,13 @@ func newChainSupport(
 	}
 
 	cs := &chainSupport{
-		ledgerResources: ledgerResources,
-		cutter:          cutter,
-		filters:         filters,
-		signer:          signer,
+		ledgerResources:  ledgerResources,
+		consensusType:    consenterType,
+		cutter:           cutter,
+		filters:          filters,
+		broadcastFilters: broadcastFilters,
+		signer:           signer,
+		migrate:          migrate,
 	}
 
 	var err error
@@ -132,6 +182,16 @@ func newChainSupport(
 	}
 	logger.Debugf("Retrieved metadata for tip of chain (block #%d): %+v", cs.Reader().Height()-1, metadata)
 
+	cs.txIDs.Load(cs.Reader())
+
+	if migration := getConsensusTypeMigration(lastBlock, metadata); migration != nil && migration.ToType == consenterType {
+		// The consenter starts from the block migrating the chain with the metadata it wrote last,
+		// or as it would from the genesis block if it never ordered the chain
+		logger.Infof("Starting chain %s migrated from consensus type %s to %s", cs.ChainID(), migration.FromType, migration.ToType)
+		metadata = &cb.Metadata{Value: migration.Metadata[consenterType]}
+	}
+	cs.metadata = metadata.Value
+
 	cs.chain, err = consenter.HandleChain(cs, metadata)
 	if err != nil {
 		logger.Fatalf("Error creating consenter for chain %x: %s", ledgerResources.ChainID(), err)
@@ -140,34 +200,114 @@ func newChainSupport(
 	return cs
 }
 
+// getConsensusTypeMigration returns the migration recorded in the orderer metadata of the block,
+// if the block is the config block which changed the consensus type of the chain
+func getConsensusTypeMigration(block *cb.Block, metadata *cb.Metadata) *ab.ConsensusTypeMigration {
+	lastConfig, err := utils.GetLastConfigIndexFromBlock(block)
+	if err != nil || lastConfig != block.Header.Number || metadata.Value == nil {
+		return nil
+	}
+	migration := &ab.ConsensusTypeMigration{}
+	if err := proto.Unmarshal(metadata.Value, migration); err != nil || migration.FromType == "" || migration.FromType == migration.ToType {
+		return nil
+	}
+	return migration
+}
+
+// lastConsensusTypeMigration returns the migration recorded by the last block which changed the
+// consensus type of the chain, or nil if the chain was never migrated
+func (cs *chainSupport) lastConsensusTypeMigration() *ab.ConsensusTypeMigration {
+	for number := cs.Reader().Height(); number > 0; number-- {
+		block := ledger.GetBlock(cs.Reader(), number-1)
+		metadata, err := utils.GetMetadataFromBlock(block, cb.BlockMetadataIndex_ORDERER)
+		if err != nil {
+			continue
+		}
+		if migration := getConsensusTypeMigration(block, metadata); migration != nil {
+			return migration
+		}
+	}
+	return nil
+}
+
 // createStandardFilters creates the set of filters for a normal (non-system) chain
-func createStandardFilters(ledgerResources *ledgerResources) *filter.RuleSet {
-	return filter.NewRuleSet([]filter.Rule{
+// The admission rules are applied to validly signed messages before they are processed further,
+// all messages are rejected once the chain is sealed
+func createStandardFilters(ledgerResources *ledgerResources, admission ...filter.Rule) *filter.RuleSet {
+	rules := []filter.Rule{
 		filter.EmptyRejectRule,
-		sizefilter.MaxBytesRule(ledgerResources.SharedConfig().BatchSize().AbsoluteMaxBytes),
+		sizefilter.MaxBytesRule(ledgerResources),
 		sigfilter.New(policies.ChannelWriters, ledgerResources.PolicyManager()),
+		sealedfilter.New(ledgerResources),
+	}
+	rules = append(rules, admission...)
+	return filter.NewRuleSet(append(rules,
 		configtxfilter.NewFilter(ledgerResources),
 		filter.AcceptRule,
-	})
-
+	))
 }
 
 // createSystemChainFilters creates the set of filters for the ordering system chain
-func createSystemChainFilters(ml *multiLedger, ledgerResources *ledgerResources) *filter.RuleSet {
-	return filter.NewRuleSet([]filter.Rule{
+// The admission rules are applied to validly signed messages before they are processed further
+func createSystemChainFilters(ml *multiLedger, ledgerResources *ledgerResources, admission ...filter.Rule) *filter.RuleSet {
+	rules := []filter.Rule{
 		filter.EmptyRejectRule,
-		sizefilter.MaxBytesRule(ledgerResources.SharedConfig().BatchSize().AbsoluteMaxBytes),
+		sizefilter.MaxBytesRule(ledgerResources),
 		sigfilter.New(policies.ChannelWriters, ledgerResources.PolicyManager()),
+	}
+	rules = append(rules, admission...)
+	return filter.NewRuleSet(append(rules,
 		newSystemChainFilter(ledgerResources, ml),
 		configtxfilter.NewFilter(ledgerResources),
 		filter.AcceptRule,
-	})
+	))
+}
+
+// createAdmissionRules creates the rules which bound the rate at which messages are broadcast, which
+// reject the messages already written to the ledger, and which reject the config transactions switching
+// to a consensus type the orderer has no consenter for, as the outcome depends on the time of arrival
+// or on the orderer, they must not be applied to ordered messages
+// All but config transactions are rejected while the chain is in maintenance mode, the messages which
+// were accepted before are still ordered, so that they are drained before the consensus type changes
+func createAdmissionRules(ledgerResources *ledgerResources, consensusTypes []string) []filter.Rule {
+	return []filter.Rule{
+		maintenancefilter.New(ledgerResources),
+		ratelimitfilter.New(ledgerResources),
+		dedupfilter.New(ledgerResources.txIDs),
+		maintenancefilter.ConsensusTypeRule(consensusTypes),
+	}
 }
 
 func (cs *chainSupport) start() {
 	cs.chain.Start()
 }
 
+// halt halts the chain, as the consenter may still be writing a block, the ledger may
+// only be released once halt has returned
+func (cs *chainSupport) halt() {
+	cs.chain.Halt()
+	cs.writeLock.Lock()
+	defer cs.writeLock.Unlock()
+	cs.halted = true
+}
+
+func (cs *chainSupport) isHalted() bool {
+	cs.writeLock.Lock()
+	defer cs.writeLock.Unlock()
+	return cs.halted
+}
+
+// HealthCheck returns an error if the chain is halted, or if its consenter reports it cannot order messages
+func (cs *chainSupport) HealthCheck() error {
+	if cs.isHalted() {
+		return fmt.Errorf("Chain %s is halted", cs.ChainID())
+	}
+	if checker, ok := cs.chain.(HealthChecker); ok {
+		return checker.HealthCheck()
+	}
+	return nil
+}
+
 func (cs *chainSupport) NewSignatureHeader() (*cb.SignatureHeader, error) {
 	return cs.signer.NewSignatureHeader()
 }
@@ -177,7 +317,7 @@ func (cs *chainSupport) Sign(message []byte) ([]byte, error) {
 }
 
 func (cs *chainSupport) Filters() *filter.RuleSet {
-	return cs.filters
+	return cs.broadcastFilters
 }
 
 func (cs *chainSupport) BlockCutter() blockcutter.Receiver {
@@ -188,8 +328,28 @@ func (cs *chainSupport) Reader() ledger.Reader {
 	return cs.ledger
 }
 
+// Enqueue passes the message to the consenter, unless the chain is sealed or halted, or its
+// transaction ID was already accepted. The transaction ID is reserved before the message is
+// passed, so that of concurrent broadcasts of the same message only one is ordered, and released
+// if the consenter does not accept the message
 func (cs *chainSupport) Enqueue(env *cb.Envelope) bool {
-	return cs.chain.Enqueue(env)
+	if cs.SharedConfig().Sealed() {
+		logger.Warningf("Rejecting message of sealed chain %s", cs.ChainID())
+		return false
+	}
+	if cs.isHalted() {
+		logger.Warningf("Rejecting message of halted chain %s", cs.ChainID())
+		return false
+	}
+	if !cs.txIDs.AddIfAbsent(env) {
+		logger.Warningf("Rejecting message of chain %s whose transaction ID was already accepted", cs.ChainID())
+		return false
+	}
+	if !cs.chain.Enqueue(env) {
+		cs.txIDs.Remove(env)
+		return false
+	}
+	return true
 }
 
 func (cs *chainSupport) CreateNextBlock(messages []*cb.Envelope) *cb.Block {
@@ -200,6 +360,13 @@ func (cs *chainSupport) addBlockSignature(block *cb.Block) {
 	logger.Debugf("%+v", cs)
 	logger.Debugf("%+v", cs.signer)
 
+	// Consenters which agree on blocks, such as sbft, may already carry the
+	// signatures of the orderers which agreed on the block.
+	if len(block.Metadata.Metadata[cb.BlockMetadataIndex_SIGNATURES]) != 0 {
+		logger.Debugf("Block [%d] already carries signatures", block.Header.Number)
+		return
+	}
+
 	blockSignature := &cb.MetadataSignature{
 		SignatureHeader: utils.MarshalOrPanic(utils.NewSignatureHeaderOrPanic(cs.signer)),
 	}
@@ -242,12 +409,40 @@ func (cs *chainSupport) addLastConfigSignature(block *cb.Block) {
 }
 
 func (cs *chainSupport) WriteBlock(block *cb.Block, committers []filter.Committer, encodedMetadataValue []byte) *cb.Block {
+	cs.writeLock.Lock()
+	defer cs.writeLock.Unlock()
+	if cs.halted {
+		logger.Warningf("Dropping block %d of halted chain %s", block.Header.Number, cs.ChainID())
+		return block
+	}
+
 	for _, committer := range committers {
 		committer.Commit()
 	}
 	// Set the orderer-related metadata field
 	if encodedMetadataValue != nil {
 		block.Metadata.Metadata[cb.BlockMetadataIndex_ORDERER] = utils.MarshalOrPanic(&cb.Metadata{Value: encodedMetadataValue})
+		cs.metadata = encodedMetadataValue
+	}
+	// The consensus type is changed by a config block, which records the migration in place of the
+	// metadata of the consenter, along with the metadata last written by each consensus type, as the
+	// consenter of the new type starts from it
+	migrating := cs.SharedConfig().ConsensusType() != cs.consensusType
+	if migrating {
+		migration := &ab.ConsensusTypeMigration{
+			FromType: cs.consensusType,
+			ToType:   cs.SharedConfig().ConsensusType(),
+			Metadata: make(map[string][]byte),
+		}
+		if previous := cs.lastConsensusTypeMigration(); previous != nil {
+			for consensusType, metadata := range previous.Metadata {
+				migration.Metadata[consensusType] = metadata
+			}
+		}
+		if cs.metadata != nil {
+			migration.Metadata[cs.consensusType] = cs.metadata
+		}
+		block.Metadata.Metadata[cb.BlockMetadataIndex_ORDERER] = utils.MarshalOrPanic(&cb.Metadata{Value: utils.MarshalOrPanic(migration)})
 	}
 	cs.addBlockSignature(block)
 	cs.addLastConfigSignature(block)
@@ -256,5 +451,16 @@ func (cs *chainSupport) WriteBlock(block *cb.Block, committers []filter.Committe
 	if err != nil {
 		logger.Panicf("Could not append block: %s", err)
 	}
+	cs.txIDs.AddBlock(block)
+
+	if migrating {
+		logger.Infof("Block %d migrates chain %s from consensus type %s to %s", block.Header.Number, cs.ChainID(), cs.consensusType, cs.SharedConfig().ConsensusType())
+		// The blocks the current consenter may still write are dropped, it cannot be halted from
+		// within WriteBlock as it may wait for WriteBlock to return
+		cs.halted = true
+		if cs.migrate != nil {
+			go cs.migrate(cs)
+		}
+	}
 	return block
 }
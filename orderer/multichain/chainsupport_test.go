@@ -22,6 +22,8 @@ import (
 
 	"github.com/golang/protobuf/proto"
 	mockconfigtx "github.com/hyperledger/fabric/common/mocks/configtx"
+	mockconfigvaluesorderer "github.com/hyperledger/fabric/common/mocks/configvalues/channel/orderer"
+	"github.com/hyperledger/fabric/orderer/common/dedupfilter"
 	"github.com/hyperledger/fabric/orderer/common/filter"
 	"github.com/hyperledger/fabric/orderer/ledger"
 	cb "github.com/hyperledger/fabric/protos/common"
@@ -62,10 +64,18 @@ func (mc *mockCommitter) Commit() {
 	mc.committed++
 }
 
+// newMockConfigtxManager returns a config manager whose orderer config keeps the consensus type
+// of the chain support
+func newMockConfigtxManager() *mockconfigtx.Manager {
+	cm := &mockconfigtx.Manager{}
+	cm.OrdererConfigVal = &mockconfigvaluesorderer.SharedConfig{}
+	return cm
+}
+
 func TestCommitConfig(t *testing.T) {
 	ml := &mockLedgerReadWriter{}
-	cm := &mockconfigtx.Manager{}
-	cs := &chainSupport{ledgerResources: &ledgerResources{configResources: &configResources{Manager: cm}, ledger: ml}, signer: mockCrypto()}
+	cm := newMockConfigtxManager()
+	cs := &chainSupport{ledgerResources: &ledgerResources{configResources: &configResources{Manager: cm}, ledger: ml, txIDs: dedupfilter.NewWindow(0)}, signer: mockCrypto()}
 	txs := []*cb.Envelope{makeNormalTx("foo", 0), makeNormalTx("bar", 1)}
 	committers := []filter.Committer{&mockCommitter{}, &mockCommitter{}}
 	block := cs.CreateNextBlock(txs)
@@ -89,18 +99,35 @@ func TestCommitConfig(t *testing.T) {
 
 func TestWriteBlockSignatures(t *testing.T) {
 	ml := &mockLedgerReadWriter{}
-	cm := &mockconfigtx.Manager{}
-	cs := &chainSupport{ledgerResources: &ledgerResources{configResources: &configResources{Manager: cm}, ledger: ml}, signer: mockCrypto()}
+	cm := newMockConfigtxManager()
+	cs := &chainSupport{ledgerResources: &ledgerResources{configResources: &configResources{Manager: cm}, ledger: ml, txIDs: dedupfilter.NewWindow(0)}, signer: mockCrypto()}
 
 	if utils.GetMetadataFromBlockOrPanic(cs.WriteBlock(cb.NewBlock(0, nil), nil, nil), cb.BlockMetadataIndex_SIGNATURES) == nil {
 		t.Fatalf("Block should have block signature")
 	}
 }
 
+func TestWriteBlockConsenterSignatures(t *testing.T) {
+	ml := &mockLedgerReadWriter{}
+	cm := newMockConfigtxManager()
+	cs := &chainSupport{ledgerResources: &ledgerResources{configResources: &configResources{Manager: cm}, ledger: ml, txIDs: dedupfilter.NewWindow(0)}, signer: mockCrypto()}
+
+	expected := &cb.Metadata{Signatures: []*cb.MetadataSignature{
+		{SignatureHeader: []byte("header1"), Signature: []byte("sig1")},
+		{SignatureHeader: []byte("header2"), Signature: []byte("sig2")},
+	}}
+	block := cb.NewBlock(0, nil)
+	block.Metadata.Metadata[cb.BlockMetadataIndex_SIGNATURES] = utils.MarshalOrPanic(expected)
+
+	if actual := utils.GetMetadataFromBlockOrPanic(cs.WriteBlock(block, nil, nil), cb.BlockMetadataIndex_SIGNATURES); !proto.Equal(expected, actual) {
+		t.Fatalf("Signatures of the consenter should have been kept, got %v", actual)
+	}
+}
+
 func TestWriteBlockOrdererMetadata(t *testing.T) {
 	ml := &mockLedgerReadWriter{}
-	cm := &mockconfigtx.Manager{}
-	cs := &chainSupport{ledgerResources: &ledgerResources{configResources: &configResources{Manager: cm}, ledger: ml}, signer: mockCrypto()}
+	cm := newMockConfigtxManager()
+	cs := &chainSupport{ledgerResources: &ledgerResources{configResources: &configResources{Manager: cm}, ledger: ml, txIDs: dedupfilter.NewWindow(0)}, signer: mockCrypto()}
 
 	value := []byte("foo")
 	expected := &cb.Metadata{Value: value}
@@ -117,8 +144,8 @@ func TestWriteBlockOrdererMetadata(t *testing.T) {
 
 func TestWriteLastConfig(t *testing.T) {
 	ml := &mockLedgerReadWriter{}
-	cm := &mockconfigtx.Manager{}
-	cs := &chainSupport{ledgerResources: &ledgerResources{configResources: &configResources{Manager: cm}, ledger: ml}, signer: mockCrypto()}
+	cm := newMockConfigtxManager()
+	cs := &chainSupport{ledgerResources: &ledgerResources{configResources: &configResources{Manager: cm}, ledger: ml, txIDs: dedupfilter.NewWindow(0)}, signer: mockCrypto()}
 
 	expected := uint64(0)
 
@@ -17,9 +17,14 @@ limitations under the License.
 package multichain
 
 import (
+	"fmt"
+	"sort"
+	"sync"
+
 	"github.com/hyperledger/fabric/common/config"
 	"github.com/hyperledger/fabric/common/configtx"
 	configtxapi "github.com/hyperledger/fabric/common/configtx/api"
+	"github.com/hyperledger/fabric/orderer/common/dedupfilter"
 	"github.com/hyperledger/fabric/orderer/ledger"
 	cb "github.com/hyperledger/fabric/protos/common"
 	"github.com/hyperledger/fabric/protos/utils"
@@ -35,8 +40,22 @@ type Manager interface {
 	// GetChain retrieves the chain support for a chain (and whether it exists)
 	GetChain(chainID string) (ChainSupport, bool)
 
-	// SystemChannelID returns the channel ID for the system channel
+	// SystemChannelID returns the channel ID for the system channel, or the empty
+	// string when the orderer has no system channel
 	SystemChannelID() string
+
+	// ChainIDs returns the IDs of the chains hosted by the orderer
+	ChainIDs() []string
+
+	// JoinChain creates and starts an application chain from its genesis block
+	JoinChain(genesisBlock *cb.Block) error
+
+	// RemoveChain halts an application chain and deletes its ledger, together with the state its consenter keeps
+	RemoveChain(chainID string) error
+
+	// ArchiveChain halts a sealed application chain and moves its ledger aside,
+	// returning where it was moved to
+	ArchiveChain(chainID string) (string, error)
 }
 
 type configResources struct {
@@ -50,6 +69,9 @@ func (cr *configResources) SharedConfig() config.Orderer {
 type ledgerResources struct {
 	*configResources
 	ledger ledger.ReadWriter
+
+	// txIDs holds the transaction IDs of the messages most recently enqueued or written to the ledger
+	txIDs *dedupfilter.Window
 }
 
 type multiLedger struct {
@@ -58,6 +80,10 @@ type multiLedger struct {
 	ledgerFactory   ledger.Factory
 	signer          crypto.LocalSigner
 	systemChannelID string
+	txIDWindowSize  int
+
+	// mutex serializes the updates of the chains map, which is replaced rather than modified
+	mutex sync.Mutex
 }
 
 func getConfigTx(reader ledger.Reader) *cb.Envelope {
@@ -74,13 +100,15 @@ func getConfigTx(reader ledger.Reader) *cb.Envelope {
 	return utils.ExtractEnvelopeOrPanic(configBlock, 0)
 }
 
-// NewManagerImpl produces an instance of a Manager
-func NewManagerImpl(ledgerFactory ledger.Factory, consenters map[string]Consenter, signer crypto.LocalSigner) Manager {
+// NewManagerImpl produces an instance of a Manager, the broadcast messages whose transaction ID
+// is among the last txIDWindowSize ones written to their chain are rejected
+func NewManagerImpl(ledgerFactory ledger.Factory, consenters map[string]Consenter, signer crypto.LocalSigner, txIDWindowSize int) Manager {
 	ml := &multiLedger{
-		chains:        make(map[string]*chainSupport),
-		ledgerFactory: ledgerFactory,
-		consenters:    consenters,
-		signer:        signer,
+		chains:         make(map[string]*chainSupport),
+		ledgerFactory:  ledgerFactory,
+		consenters:     consenters,
+		signer:         signer,
+		txIDWindowSize: txIDWindowSize,
 	}
 
 	existingChains := ledgerFactory.ChainIDs()
@@ -93,7 +121,10 @@ func NewManagerImpl(ledgerFactory ledger.Factory, consenters map[string]Consente
 		if configTx == nil {
 			logger.Fatalf("Could not find config transaction for chain %s", chainID)
 		}
-		ledgerResources := ml.newLedgerResources(configTx)
+		ledgerResources, err := ml.newLedgerResources(configTx)
+		if err != nil {
+			logger.Fatalf("Could not create the resources of chain %s: %s", chainID, err)
+		}
 		chainID := ledgerResources.ChainID()
 
 		if ledgerResources.SharedConfig().ChainCreationPolicyNames() != nil {
@@ -101,9 +132,11 @@ func NewManagerImpl(ledgerFactory ledger.Factory, consenters map[string]Consente
 				logger.Fatalf("There appear to be two system chains %s and %s", ml.systemChannelID, chainID)
 			}
 			chain := newChainSupport(createSystemChainFilters(ml, ledgerResources),
+				createSystemChainFilters(ml, ledgerResources, createAdmissionRules(ledgerResources, ml.consensusTypes())...),
 				ledgerResources,
 				consenters,
-				signer)
+				signer,
+				ml.migrateChain)
 			logger.Infof("Starting with system channel: %s and orderer type %s", chainID, chain.SharedConfig().ConsensusType())
 			ml.chains[string(chainID)] = chain
 			ml.systemChannelID = chainID
@@ -112,9 +145,11 @@ func NewManagerImpl(ledgerFactory ledger.Factory, consenters map[string]Consente
 		} else {
 			logger.Debugf("Starting chain: %x", chainID)
 			chain := newChainSupport(createStandardFilters(ledgerResources),
+				createStandardFilters(ledgerResources, createAdmissionRules(ledgerResources, ml.consensusTypes())...),
 				ledgerResources,
 				consenters,
-				signer)
+				signer,
+				ml.migrateChain)
 			ml.chains[string(chainID)] = chain
 			chain.start()
 		}
@@ -122,7 +157,7 @@ func NewManagerImpl(ledgerFactory ledger.Factory, consenters map[string]Consente
 	}
 
 	if ml.systemChannelID == "" {
-		logger.Panicf("No system chain found")
+		logger.Infof("Starting without a system channel, chains are only created by joining them")
 	}
 
 	return ml
@@ -138,37 +173,65 @@ func (ml *multiLedger) GetChain(chainID string) (ChainSupport, bool) {
 	return cs, ok
 }
 
-func (ml *multiLedger) newLedgerResources(configTx *cb.Envelope) *ledgerResources {
+// ChainIDs returns the IDs of the chains hosted by the orderer
+func (ml *multiLedger) ChainIDs() []string {
+	chains := ml.chains
+	chainIDs := make([]string, 0, len(chains))
+	for chainID := range chains {
+		chainIDs = append(chainIDs, chainID)
+	}
+	sort.Strings(chainIDs)
+	return chainIDs
+}
+
+func (ml *multiLedger) newLedgerResources(configTx *cb.Envelope) (*ledgerResources, error) {
 	initializer := configtx.NewInitializer()
 	configManager, err := configtx.NewManagerImpl(configTx, initializer, nil)
 	if err != nil {
-		logger.Fatalf("Error creating configtx manager and handlers: %s", err)
+		return nil, fmt.Errorf("Error creating configtx manager and handlers: %s", err)
 	}
 
 	chainID := configManager.ChainID()
 
 	ledger, err := ml.ledgerFactory.GetOrCreate(chainID)
 	if err != nil {
-		logger.Fatalf("Error getting ledger for %s", chainID)
+		return nil, fmt.Errorf("Error getting ledger for %s: %s", chainID, err)
 	}
 
 	return &ledgerResources{
 		configResources: &configResources{Manager: configManager},
 		ledger:          ledger,
-	}
+		txIDs:           dedupfilter.NewWindow(ml.txIDWindowSize),
+	}, nil
 }
 
 func (ml *multiLedger) newChain(configtx *cb.Envelope) {
-	ledgerResources := ml.newLedgerResources(configtx)
+	ml.mutex.Lock()
+	defer ml.mutex.Unlock()
+
+	ledgerResources, err := ml.newLedgerResources(configtx)
+	if err != nil {
+		logger.Fatalf("Could not create the resources of the new chain: %s", err)
+	}
 	ledgerResources.ledger.Append(ledger.CreateNextBlock(ledgerResources.ledger, []*cb.Envelope{configtx}))
 
+	ml.startChain(ledgerResources)
+}
+
+// startChain creates and starts the support of an application chain, the caller must hold the mutex
+func (ml *multiLedger) startChain(ledgerResources *ledgerResources) {
 	// Copy the map to allow concurrent reads from broadcast/deliver while the new chainSupport is
 	newChains := make(map[string]*chainSupport)
 	for key, value := range ml.chains {
 		newChains[key] = value
 	}
 
-	cs := newChainSupport(createStandardFilters(ledgerResources), ledgerResources, ml.consenters, ml.signer)
+	cs := newChainSupport(createStandardFilters(ledgerResources),
+		createStandardFilters(ledgerResources, createAdmissionRules(ledgerResources, ml.consensusTypes())...),
+		ledgerResources,
+		ml.consenters,
+		ml.signer,
+		ml.migrateChain)
 	chainID := ledgerResources.ChainID()
 
 	logger.Infof("Created and starting new chain %s", chainID)
@@ -179,6 +242,167 @@ func (ml *multiLedger) newChain(configtx *cb.Envelope) {
 	ml.chains = newChains
 }
 
+// migrateChain restarts a chain, whose last block changed its consensus type, with the
+// consenter of the new type
+func (ml *multiLedger) migrateChain(migrated *chainSupport) {
+	ml.mutex.Lock()
+	defer ml.mutex.Unlock()
+
+	chainID := migrated.ChainID()
+	if cs, ok := ml.chains[chainID]; !ok || cs != migrated {
+		logger.Warningf("Not migrating chain %s which was removed", chainID)
+		return
+	}
+
+	migrated.halt()
+	cs := newChainSupport(migrated.filters, migrated.broadcastFilters, migrated.ledgerResources, ml.consenters, ml.signer, ml.migrateChain)
+	cs.lastConfig = migrated.lastConfig
+	cs.lastConfigSeq = migrated.lastConfigSeq
+
+	newChains := make(map[string]*chainSupport)
+	for key, value := range ml.chains {
+		newChains[key] = value
+	}
+	newChains[chainID] = cs
+
+	logger.Infof("Restarting chain %s with consensus type %s", chainID, cs.consensusType)
+	cs.start()
+
+	ml.chains = newChains
+}
+
+// consensusTypes returns the consensus types the orderer has a consenter for
+func (ml *multiLedger) consensusTypes() []string {
+	consensusTypes := make([]string, 0, len(ml.consenters))
+	for consensusType := range ml.consenters {
+		consensusTypes = append(consensusTypes, consensusType)
+	}
+	sort.Strings(consensusTypes)
+	return consensusTypes
+}
+
+// JoinChain creates and starts an application chain from its genesis block, so that
+// the orderer serves the chain without it being created through the system chain
+func (ml *multiLedger) JoinChain(genesisBlock *cb.Block) error {
+	if genesisBlock.Header == nil || genesisBlock.Header.Number != 0 {
+		return fmt.Errorf("A chain can only be joined from its genesis block")
+	}
+	configTx, err := utils.ExtractEnvelope(genesisBlock, 0)
+	if err != nil {
+		return fmt.Errorf("Genesis block does not carry a config transaction: %s", err)
+	}
+	configManager, err := configtx.NewManagerImpl(configTx, configtx.NewInitializer(), nil)
+	if err != nil {
+		return fmt.Errorf("Genesis block does not carry a valid config: %s", err)
+	}
+	chainID := configManager.ChainID()
+	if configManager.OrdererConfig().ChainCreationPolicyNames() != nil {
+		return fmt.Errorf("Chain %s is a system chain, which cannot be joined", chainID)
+	}
+	if _, ok := ml.consenters[configManager.OrdererConfig().ConsensusType()]; !ok {
+		return fmt.Errorf("Chain %s is ordered by the unknown consensus type %s", chainID, configManager.OrdererConfig().ConsensusType())
+	}
+
+	ml.mutex.Lock()
+	defer ml.mutex.Unlock()
+
+	if _, ok := ml.chains[chainID]; ok {
+		return fmt.Errorf("Chain %s already exists", chainID)
+	}
+	ledger, err := ml.ledgerFactory.GetOrCreate(chainID)
+	if err != nil {
+		return fmt.Errorf("Error getting ledger for %s: %s", chainID, err)
+	}
+	if ledger.Height() != 0 {
+		return fmt.Errorf("Ledger of chain %s is not empty", chainID)
+	}
+	if err := ledger.Append(genesisBlock); err != nil {
+		return fmt.Errorf("Could not write the genesis block of chain %s: %s", chainID, err)
+	}
+
+	logger.Infof("Joining chain %s", chainID)
+	ml.startChain(&ledgerResources{
+		configResources: &configResources{Manager: configManager},
+		ledger:          ledger,
+		txIDs:           dedupfilter.NewWindow(ml.txIDWindowSize),
+	})
+	return nil
+}
+
+// RemoveChain halts an application chain and deletes its ledger, together with the state its consenter keeps
+func (ml *multiLedger) RemoveChain(chainID string) error {
+	ml.mutex.Lock()
+	defer ml.mutex.Unlock()
+
+	cs, err := ml.applicationChain(chainID)
+	if err != nil {
+		return err
+	}
+
+	logger.Infof("Halting and removing chain %s", chainID)
+	ml.haltChain(cs)
+	if remover, ok := ml.consenters[cs.consensusType].(Remover); ok {
+		if err := remover.RemoveChain(chainID); err != nil {
+			return fmt.Errorf("Could not remove the consenter state of chain %s: %s", chainID, err)
+		}
+	}
+	return ml.ledgerFactory.Remove(chainID)
+}
+
+// ArchiveChain halts a sealed application chain and moves its ledger and the state its consenter
+// keeps aside, so that the chain is retired once it no longer orders transactions
+func (ml *multiLedger) ArchiveChain(chainID string) (string, error) {
+	ml.mutex.Lock()
+	defer ml.mutex.Unlock()
+
+	cs, err := ml.applicationChain(chainID)
+	if err != nil {
+		return "", err
+	}
+	if !cs.SharedConfig().Sealed() {
+		return "", fmt.Errorf("Chain %s must be sealed before it is archived", chainID)
+	}
+
+	logger.Infof("Halting and archiving chain %s", chainID)
+	ml.haltChain(cs)
+	archivePath, err := ml.ledgerFactory.Archive(chainID)
+	if err != nil {
+		return "", err
+	}
+	if remover, ok := ml.consenters[cs.consensusType].(Remover); ok {
+		if err := remover.ArchiveChain(chainID, archivePath); err != nil {
+			return "", fmt.Errorf("Could not archive the consenter state of chain %s: %s", chainID, err)
+		}
+	}
+	logger.Infof("Archived the ledger of chain %s to %s", chainID, archivePath)
+	return archivePath, nil
+}
+
+// applicationChain retrieves the support of an application chain, the caller must hold the mutex
+func (ml *multiLedger) applicationChain(chainID string) (*chainSupport, error) {
+	if chainID == ml.systemChannelID {
+		return nil, fmt.Errorf("The system chain cannot be removed")
+	}
+	cs, ok := ml.chains[chainID]
+	if !ok {
+		return nil, fmt.Errorf("Chain %s does not exist", chainID)
+	}
+	return cs, nil
+}
+
+// haltChain stops serving a chain and halts it, the caller must hold the mutex
+func (ml *multiLedger) haltChain(cs *chainSupport) {
+	newChains := make(map[string]*chainSupport)
+	for key, value := range ml.chains {
+		if key != cs.ChainID() {
+			newChains[key] = value
+		}
+	}
+	ml.chains = newChains
+
+	cs.halt()
+}
+
 func (ml *multiLedger) channelsCount() int {
 	return len(ml.chains)
 }
@@ -18,13 +18,20 @@ package multichain
 
 import (
 	"reflect"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/hyperledger/fabric/common/config"
 	"github.com/hyperledger/fabric/common/configtx"
+	configtxapi "github.com/hyperledger/fabric/common/configtx/api"
 	genesisconfig "github.com/hyperledger/fabric/common/configtx/tool/localconfig"
 	"github.com/hyperledger/fabric/common/configtx/tool/provisional"
+	mockconfigtx "github.com/hyperledger/fabric/common/mocks/configtx"
+	mockconfigvaluesorderer "github.com/hyperledger/fabric/common/mocks/configvalues/channel/orderer"
 	mockcrypto "github.com/hyperledger/fabric/common/mocks/crypto"
+	"github.com/hyperledger/fabric/orderer/common/filter"
 	"github.com/hyperledger/fabric/orderer/ledger"
 	ramledger "github.com/hyperledger/fabric/orderer/ledger/ram"
 	cb "github.com/hyperledger/fabric/protos/common"
@@ -32,11 +39,16 @@ import (
 	"github.com/hyperledger/fabric/protos/utils"
 
 	"errors"
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
 
 	logging "github.com/op/go-logging"
 	"github.com/stretchr/testify/assert"
 )
 
+const txIDWindowSize = 100
+
 var conf *genesisconfig.Profile
 var genesisBlock = cb.NewBlock(0, nil) // *cb.Block
 
@@ -128,20 +140,16 @@ func TestGetConfigTxFailure(t *testing.T) {
 
 }
 
-// This test essentially brings the entire system up and is ultimately what main.go will replicate
+// Tests that the orderer starts without a system chain, in which case chains are only joined
 func TestNoSystemChain(t *testing.T) {
-	defer func() {
-		if recover() == nil {
-			t.Fatalf("Should have panicked when starting without a system chain")
-		}
-	}()
-
 	lf := ramledger.New(10)
 
 	consenters := make(map[string]Consenter)
 	consenters[conf.Orderer.OrdererType] = &mockConsenter{}
 
-	NewManagerImpl(lf, consenters, mockCrypto())
+	manager := NewManagerImpl(lf, consenters, mockCrypto(), txIDWindowSize)
+	assert.Equal(t, "", manager.SystemChannelID(), "Should not have a system chain")
+	assert.Empty(t, manager.ChainIDs(), "Should not host any chain")
 }
 
 // This test essentially brings the entire system up and is ultimately what main.go will replicate
@@ -151,7 +159,7 @@ func TestManagerImpl(t *testing.T) {
 	consenters := make(map[string]Consenter)
 	consenters[conf.Orderer.OrdererType] = &mockConsenter{}
 
-	manager := NewManagerImpl(lf, consenters, mockCrypto())
+	manager := NewManagerImpl(lf, consenters, mockCrypto(), txIDWindowSize)
 
 	_, ok := manager.GetChain("Fake")
 	if ok {
@@ -190,6 +198,158 @@ func TestManagerImpl(t *testing.T) {
 	}
 }
 
+// This test makes sure that broadcast messages are rate limited, but ordered ones are not
+func TestRateLimits(t *testing.T) {
+	rateLimitConf := *conf
+	ordererConf := *conf.Orderer
+	ordererConf.RateLimits.Channel = genesisconfig.RateLimit{MessagesPerSecond: 1}
+	rateLimitConf.Orderer = &ordererConf
+
+	rlf := ramledger.New(10)
+	rl, _ := rlf.GetOrCreate(provisional.TestChainID)
+	rl.Append(provisional.New(&rateLimitConf).GenesisBlock())
+
+	consenters := make(map[string]Consenter)
+	consenters[conf.Orderer.OrdererType] = &mockConsenter{}
+
+	manager := NewManagerImpl(rlf, consenters, mockCrypto(), txIDWindowSize)
+	cs, _ := manager.GetChain(provisional.TestChainID)
+
+	_, err := cs.Filters().Apply(makeNormalTx(provisional.TestChainID, 0))
+	assert.NoError(t, err, "Should have admitted the first message")
+	_, err = cs.Filters().Apply(makeNormalTx(provisional.TestChainID, 1))
+	assert.IsType(t, &filter.ThrottledError{}, err, "Should have throttled the second message")
+
+	for i := 0; i < int(conf.Orderer.BatchSize.MaxMessageCount); i++ {
+		cs.Enqueue(makeNormalTx(provisional.TestChainID, i))
+	}
+
+	it, _ := rl.Iterator(&ab.SeekPosition{Type: &ab.SeekPosition_Specified{Specified: &ab.SeekSpecified{Number: 1}}})
+	select {
+	case <-it.ReadyChan():
+		block, status := it.Next()
+		assert.Equal(t, cb.Status_SUCCESS, status, "Could not retrieve block")
+		assert.Len(t, block.Data.Data, int(conf.Orderer.BatchSize.MaxMessageCount), "Should not have rate limited ordered messages")
+	case <-time.After(time.Second):
+		t.Fatalf("Block 1 not produced after timeout")
+	}
+}
+
+// This test makes sure that broadcast messages are rejected once enqueued, and once written, also after a restart
+func TestDuplicateTxIDs(t *testing.T) {
+	lf, rl := NewRAMLedgerAndFactory(10)
+
+	consenters := make(map[string]Consenter)
+	consenters[conf.Orderer.OrdererType] = &mockConsenter{}
+
+	manager := NewManagerImpl(lf, consenters, mockCrypto(), txIDWindowSize)
+	cs, _ := manager.GetChain(provisional.TestChainID)
+
+	messages := make([]*cb.Envelope, conf.Orderer.BatchSize.MaxMessageCount)
+	for i := range messages {
+		messages[i] = makeNormalTxWithTxID(provisional.TestChainID, i, fmt.Sprintf("tx%d", i))
+	}
+
+	_, err := cs.Filters().Apply(messages[0])
+	assert.NoError(t, err, "Should have admitted the message which was not enqueued")
+
+	cs.Enqueue(messages[0])
+	_, err = cs.Filters().Apply(messages[0])
+	assert.IsType(t, &filter.DuplicateError{}, err, "Should have rejected the message which is being ordered")
+
+	for _, message := range messages[1:] {
+		cs.Enqueue(message)
+	}
+
+	it, _ := rl.Iterator(&ab.SeekPosition{Type: &ab.SeekPosition_Specified{Specified: &ab.SeekSpecified{Number: 1}}})
+	select {
+	case <-it.ReadyChan():
+	case <-time.After(time.Second):
+		t.Fatalf("Block 1 not produced after timeout")
+	}
+
+	_, err = cs.Filters().Apply(messages[0])
+	assert.IsType(t, &filter.DuplicateError{}, err, "Should have rejected the message which was written")
+
+	restarted, _ := NewManagerImpl(lf, consenters, mockCrypto(), txIDWindowSize).GetChain(provisional.TestChainID)
+	_, err = restarted.Filters().Apply(messages[1])
+	assert.IsType(t, &filter.DuplicateError{}, err, "Should have rejected the message written before the restart")
+	_, err = restarted.Filters().Apply(makeNormalTxWithTxID(provisional.TestChainID, 0, "other"))
+	assert.NoError(t, err, "Should have admitted the message which was not written")
+}
+
+// This test makes sure that of concurrent broadcasts of the same message only one is ordered
+func TestConcurrentDuplicateTxIDs(t *testing.T) {
+	lf, rl := NewRAMLedgerAndFactory(10)
+
+	consenters := make(map[string]Consenter)
+	consenters[conf.Orderer.OrdererType] = &mockConsenter{}
+
+	manager := NewManagerImpl(lf, consenters, mockCrypto(), txIDWindowSize)
+	cs, _ := manager.GetChain(provisional.TestChainID)
+
+	duplicate := makeNormalTxWithTxID(provisional.TestChainID, 0, "tx0")
+	var enqueued int32
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := cs.Filters().Apply(duplicate); err != nil {
+				return
+			}
+			if cs.Enqueue(duplicate) {
+				atomic.AddInt32(&enqueued, 1)
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int32(1), enqueued, "Should have enqueued the message once")
+
+	for i := 1; i < int(conf.Orderer.BatchSize.MaxMessageCount); i++ {
+		cs.Enqueue(makeNormalTxWithTxID(provisional.TestChainID, i, fmt.Sprintf("tx%d", i)))
+	}
+
+	it, _ := rl.Iterator(&ab.SeekPosition{Type: &ab.SeekPosition_Specified{Specified: &ab.SeekSpecified{Number: 1}}})
+	select {
+	case <-it.ReadyChan():
+		block, status := it.Next()
+		assert.Equal(t, cb.Status_SUCCESS, status, "Could not retrieve block")
+		assert.Len(t, block.Data.Data, int(conf.Orderer.BatchSize.MaxMessageCount), "Should have ordered each message once")
+	case <-time.After(time.Second):
+		t.Fatalf("Block 1 not produced after timeout")
+	}
+}
+
+type rejectingChain struct {
+	Chain
+}
+
+func (rc *rejectingChain) Enqueue(env *cb.Envelope) bool {
+	return false
+}
+
+// This test makes sure that the transaction ID of a message the consenter did not accept may be broadcast again
+func TestRejectedTxIDReleased(t *testing.T) {
+	lf, _ := NewRAMLedgerAndFactory(10)
+
+	consenters := make(map[string]Consenter)
+	consenters[conf.Orderer.OrdererType] = &mockConsenter{}
+
+	manager := NewManagerImpl(lf, consenters, mockCrypto(), txIDWindowSize)
+	cs, _ := manager.GetChain(provisional.TestChainID)
+	chain := cs.(*chainSupport).chain
+	cs.(*chainSupport).chain = &rejectingChain{Chain: chain}
+
+	message := makeNormalTxWithTxID(provisional.TestChainID, 0, "tx0")
+	assert.False(t, cs.Enqueue(message), "Should not have enqueued the message the consenter rejected")
+	_, err := cs.Filters().Apply(message)
+	assert.NoError(t, err, "Should have admitted the message which was not enqueued")
+
+	cs.(*chainSupport).chain = chain
+	assert.True(t, cs.Enqueue(message), "Should have enqueued the message again")
+}
+
 /*
 // This test makes sure that the signature filter works
 func TestSignatureFilter(t *testing.T) {
@@ -198,7 +358,7 @@ func TestSignatureFilter(t *testing.T) {
 	consenters := make(map[string]Consenter)
 	consenters[conf.Orderer.OrdererType] = &mockConsenter{}
 
-	manager := NewManagerImpl(lf, consenters, mockCryptoRejector())
+	manager := NewManagerImpl(lf, consenters, mockCryptoRejector(), txIDWindowSize)
 
 	cs, ok := manager.GetChain(provisional.TestChainID)
 
@@ -236,7 +396,7 @@ func TestNewChain(t *testing.T) {
 	consenters := make(map[string]Consenter)
 	consenters[conf.Orderer.OrdererType] = &mockConsenter{}
 
-	manager := NewManagerImpl(lf, consenters, mockCrypto())
+	manager := NewManagerImpl(lf, consenters, mockCrypto(), txIDWindowSize)
 
 	newChainID := "TestNewChain"
 
@@ -313,3 +473,228 @@ func TestNewChain(t *testing.T) {
 		t.Fatalf("Block 1 not produced after timeout on new chain")
 	}
 }
+
+func makeGenesisBlock(chainID string, templates ...configtx.Template) *cb.Block {
+	template := configtx.NewCompositeTemplate(append([]configtx.Template{provisional.New(conf).ChannelTemplate()}, templates...)...)
+	configEnv, err := configtx.NewChainCreationTemplate(provisional.AcceptAllPolicyKey, template).Envelope(chainID)
+	if err != nil {
+		panic(err)
+	}
+	block := cb.NewBlock(0, nil)
+	block.Data.Data = [][]byte{utils.MarshalOrPanic(makeConfigTxFromConfigUpdateEnvelope(chainID, configEnv))}
+	block.Header.DataHash = block.Data.Hash()
+	return block
+}
+
+// This test joins a chain without a system chain, orders messages on it and removes it
+func TestJoinAndRemoveChain(t *testing.T) {
+	lf := ramledger.New(10)
+
+	consenters := make(map[string]Consenter)
+	consenters[conf.Orderer.OrdererType] = &mockConsenter{}
+
+	manager := NewManagerImpl(lf, consenters, mockCrypto(), txIDWindowSize)
+
+	joinedChainID := "TestJoinChain"
+	genesis := makeGenesisBlock(joinedChainID)
+	assert.NoError(t, manager.JoinChain(genesis), "Should have joined the chain")
+	assert.Equal(t, []string{joinedChainID}, manager.ChainIDs())
+	assert.Error(t, manager.JoinChain(genesis), "Should not join a chain twice")
+
+	chainSupport, ok := manager.GetChain(joinedChainID)
+	if !ok {
+		t.Fatalf("Should have gotten the chain which was joined")
+	}
+
+	for i := 0; i < int(conf.Orderer.BatchSize.MaxMessageCount); i++ {
+		chainSupport.Enqueue(makeNormalTx(joinedChainID, i))
+	}
+
+	it, _ := chainSupport.Reader().Iterator(&ab.SeekPosition{Type: &ab.SeekPosition_Specified{Specified: &ab.SeekSpecified{Number: 1}}})
+	select {
+	case <-it.ReadyChan():
+		block, status := it.Next()
+		assert.Equal(t, cb.Status_SUCCESS, status, "Could not retrieve block")
+		assert.Len(t, block.Data.Data, int(conf.Orderer.BatchSize.MaxMessageCount))
+	case <-time.After(time.Second):
+		t.Fatalf("Block 1 not produced after timeout on joined chain")
+	}
+
+	assert.NoError(t, manager.RemoveChain(joinedChainID), "Should have removed the chain")
+	_, ok = manager.GetChain(joinedChainID)
+	assert.False(t, ok, "Should not have found the removed chain")
+	assert.Empty(t, manager.ChainIDs(), "Should not host the removed chain")
+	assert.Empty(t, lf.ChainIDs(), "Should have deleted the ledger of the removed chain")
+	assert.Error(t, manager.RemoveChain(joinedChainID), "Should not remove a chain twice")
+
+	assert.NoError(t, manager.JoinChain(genesis), "Should have joined the removed chain again")
+}
+
+func TestJoinChainRejected(t *testing.T) {
+	lf, _ := NewRAMLedgerAndFactory(10)
+
+	consenters := make(map[string]Consenter)
+	consenters[conf.Orderer.OrdererType] = &mockConsenter{}
+
+	manager := NewManagerImpl(lf, consenters, mockCrypto(), txIDWindowSize)
+
+	notGenesis := makeGenesisBlock("TestJoinChain")
+	notGenesis.Header.Number = 1
+	assert.Error(t, manager.JoinChain(notGenesis), "Should only join chains from their genesis block")
+
+	systemGenesis := provisional.New(conf).GenesisBlock()
+	assert.Error(t, manager.JoinChain(systemGenesis), "Should not join a system chain")
+
+	assert.Error(t, manager.RemoveChain(provisional.TestChainID), "Should not remove the system chain")
+	assert.Equal(t, []string{provisional.TestChainID}, manager.ChainIDs())
+}
+
+// This test seals a chain, whose messages are then rejected, and archives it
+func TestSealAndArchiveChain(t *testing.T) {
+	lf, _ := NewRAMLedgerAndFactory(10)
+
+	consenters := make(map[string]Consenter)
+	consenters[conf.Orderer.OrdererType] = &mockConsenter{}
+
+	manager := NewManagerImpl(lf, consenters, mockCrypto(), txIDWindowSize)
+
+	unsealedChainID := "TestUnsealedChain"
+	assert.NoError(t, manager.JoinChain(makeGenesisBlock(unsealedChainID)), "Should have joined the unsealed chain")
+	_, err := manager.ArchiveChain(unsealedChainID)
+	assert.Error(t, err, "Should not archive a chain which is not sealed")
+
+	sealedChainID := "TestSealedChain"
+	assert.NoError(t, manager.JoinChain(makeGenesisBlock(sealedChainID, configtx.NewSimpleTemplate(config.TemplateChannelState(true)))), "Should have joined the sealed chain")
+	cs, _ := manager.GetChain(sealedChainID)
+	_, err = cs.Filters().Apply(makeNormalTx(sealedChainID, 0))
+	assert.IsType(t, &filter.SealedError{}, err, "Should have rejected the message of the sealed chain")
+	assert.False(t, cs.Enqueue(makeNormalTx(sealedChainID, 0)), "Should not have enqueued the message of the sealed chain")
+	assert.Equal(t, uint64(1), cs.Reader().Height(), "Should still read the blocks of the sealed chain")
+
+	_, err = manager.ArchiveChain(sealedChainID)
+	assert.NoError(t, err, "Should have archived the sealed chain")
+	_, ok := manager.GetChain(sealedChainID)
+	assert.False(t, ok, "Should not have found the archived chain")
+	assert.Equal(t, []string{unsealedChainID, provisional.TestChainID}, manager.ChainIDs())
+
+	_, err = manager.ArchiveChain(provisional.TestChainID)
+	assert.Error(t, err, "Should not archive the system chain")
+}
+
+// This test checks that a chain in maintenance mode rejects the broadcast of normal messages, but
+// still orders those which were accepted before, so that they are drained before the migration
+func TestMaintenanceDrainsMessages(t *testing.T) {
+	lf := ramledger.New(10)
+
+	consenters := make(map[string]Consenter)
+	consenters[conf.Orderer.OrdererType] = &mockConsenter{}
+
+	manager := NewManagerImpl(lf, consenters, mockCrypto(), txIDWindowSize)
+
+	chainID := "TestMaintenanceChain"
+	assert.NoError(t, manager.JoinChain(makeGenesisBlock(chainID)), "Should have joined the chain")
+	cs := manager.(*multiLedger).chains[chainID]
+	cs.configResources.Manager = &mockconfigtx.Manager{
+		ChainIDVal: chainID,
+		OrdererConfigVal: &mockconfigvaluesorderer.SharedConfig{
+			ConsensusTypeVal:  conf.Orderer.OrdererType,
+			ConsensusStateVal: ab.ConsensusType_STATE_MAINTENANCE,
+			BatchSizeVal:      &ab.BatchSize{AbsoluteMaxBytes: 1024 * 1024},
+		},
+	}
+
+	_, err := cs.Filters().Apply(makeNormalTx(chainID, 0))
+	assert.IsType(t, &filter.MaintenanceError{}, err, "Should have rejected the broadcast message")
+	_, err = cs.filters.Apply(makeNormalTx(chainID, 0))
+	assert.NoError(t, err, "Should have ordered the message accepted before maintenance mode")
+}
+
+// waitForRestart returns the support which replaced the given one, once the chain is migrated
+func waitForRestart(t *testing.T, ml *multiLedger, migrated *chainSupport) *chainSupport {
+	var cs *chainSupport
+	for start := time.Now(); time.Since(start) < time.Second; time.Sleep(10 * time.Millisecond) {
+		ml.mutex.Lock()
+		cs = ml.chains[migrated.ChainID()]
+		ml.mutex.Unlock()
+		if cs != migrated {
+			break
+		}
+	}
+	assert.NotEqual(t, migrated, cs, "Should have restarted the migrated chain")
+	return cs
+}
+
+// writeMigration writes the config block changing the consensus type of the chain to the one of the
+// given config, and returns the migration it records
+func writeMigration(t *testing.T, cs *chainSupport, cm configtxapi.Manager, configTx *cb.Envelope, encodedMetadataValue []byte) *ab.ConsensusTypeMigration {
+	// The block is recorded as a config block, as the mocked config sequence does not advance
+	cs.configResources.Manager = cm
+	cs.lastConfig = cs.Reader().Height()
+	block := cs.WriteBlock(cs.CreateNextBlock([]*cb.Envelope{configTx}), nil, encodedMetadataValue)
+
+	metadata, err := utils.GetMetadataFromBlock(block, cb.BlockMetadataIndex_ORDERER)
+	assert.NoError(t, err, "Should have read the orderer metadata")
+	migration := &ab.ConsensusTypeMigration{}
+	assert.NoError(t, proto.Unmarshal(metadata.Value, migration), "Should have recorded the migration")
+	assert.False(t, cs.Enqueue(makeNormalTx(cs.ChainID(), 1)), "Should not have enqueued the message of the migrated chain")
+	return migration
+}
+
+// This test migrates a chain to another consensus type and back, and checks the chain is restarted
+// with the consenter of the new type, from the metadata the consenter last wrote
+func TestMigrateChain(t *testing.T) {
+	lf := ramledger.New(10)
+
+	consenters := make(map[string]Consenter)
+	consenters[conf.Orderer.OrdererType] = &mockConsenter{}
+	consenters["other"] = &mockConsenter{}
+
+	manager := NewManagerImpl(lf, consenters, mockCrypto(), txIDWindowSize)
+	ml := manager.(*multiLedger)
+
+	migratedChainID := "TestMigratedChain"
+	genesis := makeGenesisBlock(migratedChainID)
+	assert.NoError(t, manager.JoinChain(genesis), "Should have joined the chain")
+	migrated := ml.chains[migratedChainID]
+	migrated.WriteBlock(migrated.CreateNextBlock([]*cb.Envelope{makeNormalTx(migratedChainID, 0)}), nil, []byte("own metadata"))
+
+	genesisConfig := migrated.configResources.Manager
+	otherConfig := &mockconfigtx.Manager{
+		ChainIDVal:       migratedChainID,
+		OrdererConfigVal: &mockconfigvaluesorderer.SharedConfig{ConsensusTypeVal: "other"},
+	}
+	migration := writeMigration(t, migrated, otherConfig, utils.ExtractEnvelopeOrPanic(genesis, 0), []byte("last own metadata"))
+	assert.Equal(t, &ab.ConsensusTypeMigration{
+		FromType: conf.Orderer.OrdererType,
+		ToType:   "other",
+		Metadata: map[string][]byte{conf.Orderer.OrdererType: []byte("last own metadata")},
+	}, migration)
+
+	cs := waitForRestart(t, ml, migrated)
+	if cs == nil {
+		return
+	}
+	assert.Equal(t, "other", cs.consensusType)
+	assert.Equal(t, &cb.Metadata{}, cs.chain.(*mockChain).metadata, "Should have started the new consenter as from a genesis block")
+
+	cs.WriteBlock(cs.CreateNextBlock([]*cb.Envelope{makeNormalTx(migratedChainID, 0)}), nil, []byte("other metadata"))
+	migration = writeMigration(t, cs, genesisConfig, utils.ExtractEnvelopeOrPanic(genesis, 0), nil)
+	assert.Equal(t, &ab.ConsensusTypeMigration{
+		FromType: "other",
+		ToType:   conf.Orderer.OrdererType,
+		Metadata: map[string][]byte{
+			conf.Orderer.OrdererType: []byte("last own metadata"),
+			"other":                  []byte("other metadata"),
+		},
+	}, migration)
+
+	cs = waitForRestart(t, ml, cs)
+	if cs == nil {
+		return
+	}
+	assert.Equal(t, conf.Orderer.OrdererType, cs.consensusType)
+	assert.Equal(t, &cb.Metadata{Value: []byte("last own metadata")}, cs.chain.(*mockChain).metadata, "Should have resumed the consenter from its last metadata")
+
+	restarted := NewManagerImpl(lf, consenters, mockCrypto(), txIDWindowSize).(*multiLedger).chains[migratedChainID]
+	assert.Equal(t, &cb.Metadata{Value: []byte("last own metadata")}, restarted.chain.(*mockChain).metadata, "Should have resumed the consenter from its last metadata on restart")
+}
@@ -140,6 +140,23 @@ func makeNormalTx(chainID string, i int) *cb.Envelope {
 	}
 }
 
+func makeNormalTxWithTxID(chainID string, i int, txID string) *cb.Envelope {
+	payload := &cb.Payload{
+		Header: &cb.Header{
+			ChannelHeader: utils.MarshalOrPanic(&cb.ChannelHeader{
+				Type:      int32(cb.HeaderType_ENDORSER_TRANSACTION),
+				ChannelId: chainID,
+				TxId:      txID,
+			}),
+			SignatureHeader: utils.MarshalOrPanic(&cb.SignatureHeader{}),
+		},
+		Data: []byte(fmt.Sprintf("%d", i)),
+	}
+	return &cb.Envelope{
+		Payload: utils.MarshalOrPanic(payload),
+	}
+}
+
 func makeSignaturelessTx(chainID string, i int) *cb.Envelope {
 	payload := &cb.Payload{
 		Header: &cb.Header{
@@ -31,8 +31,6 @@ import (
 	"testing"
 	"time"
 
-	"encoding/json"
-
 	"github.com/golang/protobuf/proto"
 	genesisconfig "github.com/hyperledger/fabric/common/configtx/tool/localconfig"
 	"github.com/hyperledger/fabric/common/configtx/tool/provisional"
@@ -282,17 +280,22 @@ func parallelIfNotPpc64(t *testing.T) {
 func InitPeers(num uint64, startingPort int) []*Peer {
 	peers := make([]*Peer, 0, num)
 	certFiles := make([]string, 0, num)
-	peersWithCerts := map[string]string{}
+	replicas := make([]*genesisconfig.SbftReplica, 0, num)
 	for i := uint64(0); i < num; i++ {
 		certFile := generateCertificate(i, keyfile)
 		certFiles = append(certFiles, certFile)
-		peerCommPort := listenAddress(i, startingPort)
-		peersWithCerts[peerCommPort] = certFile
-	}
+		replicas = append(replicas, &genesisconfig.SbftReplica{
+			Address:  "127.0.0.1" + listenAddress(i, startingPort),
+			Cert:     certFile,
+			MSPID:    "DEFAULT",
+			SignCert: ordererDir + "/../msp/sampleconfig/signcerts/peer.pem",
+		})
+	}
+	genesisFile := generateGenesisFile(num, replicas)
 	for i := uint64(0); i < num; i++ {
 		peerCommPort := listenAddress(i, startingPort)
 		grpcPort := grpcPort(i, startingPort)
-		configEnv := generateConfigEnv(num, grpcPort, peerCommPort, certFiles[i], peersWithCerts)
+		configEnv := generateConfigEnv(grpcPort, peerCommPort, certFiles[i], genesisFile)
 		peers = append(peers, initPeer(i, configEnv))
 	}
 	return peers
@@ -313,22 +316,27 @@ func StopPeers(peers []*Peer) {
 	}
 }
 
-func generateConfigEnv(peerNum uint64, grpcPort int, peerCommPort string, certFile string, peersWithCerts map[string]string) []string {
+func generateGenesisFile(peerNum uint64, replicas []*genesisconfig.SbftReplica) string {
+	tempDir, err := ioutil.TempDir("", "sbft_test_genesis")
+	panicOnError(err)
+	genConf := genesisconfig.Load(genesisconfig.SampleInsecureProfile)
+	genConf.Orderer.OrdererType = sbftName
+	genConf.Orderer.Sbft.F = (peerNum - 1) / 3
+	genConf.Orderer.Sbft.Replicas = replicas
+	genesisFile := tempDir + "/genesisblock"
+	panicOnError(ioutil.WriteFile(genesisFile, utils.MarshalOrPanic(provisional.New(genConf).GenesisBlock()), 0644))
+	return genesisFile
+}
+
+func generateConfigEnv(grpcPort int, peerCommPort string, certFile string, genesisFile string) []string {
 	tempDir, err := ioutil.TempDir("", "sbft_test_config")
 	panicOnError(err)
 	envs := []string{}
 	envs = append(envs, fmt.Sprintf("ORDERER_CFG_PATH=%s", ordererDir))
 	envs = append(envs, fmt.Sprintf("ORDERER_GENERAL_LOCALMSPDIR=%s", ordererDir+"/../msp/sampleconfig"))
 	envs = append(envs, fmt.Sprintf("ORDERER_GENERAL_LISTENPORT=%d", grpcPort))
-	envs = append(envs, fmt.Sprintf("CONFIGTX_ORDERER_ORDERERTYPE=%s", "sbft"))
-	envs = append(envs, fmt.Sprintf("ORDERER_GENERAL_GENESISPROFILE=%s", genesisconfig.SampleInsecureProfile))
-	envs = append(envs, fmt.Sprintf("ORDERER_GENESIS_DEPRECATEDBATCHTIMEOUT=%d", 1000))
-	envs = append(envs, fmt.Sprintf("ORDERER_GENESIS_DEPRECATED=%d", 1000000000))
-	envs = append(envs, fmt.Sprintf("ORDERER_GENESIS_SBFTSHARED_REQUESTTIMEOUTNSEC=%d", 1000000000))
-	envs = append(envs, fmt.Sprintf("ORDERER_GENESIS_SBFTSHARED_N=%d", peerNum))
-	envs = append(envs, fmt.Sprintf("ORDERER_GENESIS_SBFTSHARED_F=%d", (peerNum-1)/3))
-	js, _ := json.Marshal(peersWithCerts)
-	envs = append(envs, fmt.Sprintf("ORDERER_GENESIS_SBFTSHARED_PEERS=%s", js))
+	envs = append(envs, fmt.Sprintf("ORDERER_GENERAL_GENESISMETHOD=%s", "file"))
+	envs = append(envs, fmt.Sprintf("ORDERER_GENERAL_GENESISFILE=%s", genesisFile))
 	envs = append(envs, fmt.Sprintf("ORDERER_SBFTLOCAL_PEERCOMMADDR=%s", peerCommPort))
 	envs = append(envs, fmt.Sprintf("ORDERER_SBFTLOCAL_CERTFILE=%s", certFile))
 	envs = append(envs, fmt.Sprintf("ORDERER_SBFTLOCAL_KEYFILE=%s", keyfile))
@@ -0,0 +1,534 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package raft
+
+import (
+	"bytes"
+	"encoding/pem"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/orderer/common/filter"
+	"github.com/hyperledger/fabric/orderer/localconfig"
+	"github.com/hyperledger/fabric/orderer/multichain"
+	cb "github.com/hyperledger/fabric/protos/common"
+	ab "github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric/protos/utils"
+)
+
+// stepBufferSize is the number of received raft messages queued for a
+// chain, further messages are dropped until the queue drains
+const stepBufferSize = 1024
+
+// pullRetryInterval is the time to wait before pulling blocks again once
+// all the consenters failed to provide them
+const pullRetryInterval = time.Second
+
+// submission is an envelope waiting to be proposed to the raft log
+type submission struct {
+	env      *cb.Envelope
+	accepted chan bool
+}
+
+// forwarded is a submission proposed to the leader. It is accepted once the
+// leader replicates it to this consenter, or rejected if this does not
+// happen within forwardTimeout ticks.
+type forwarded struct {
+	data     []byte
+	accepted chan bool
+	ticks    int
+}
+
+// chain orders the envelopes of a channel through a raft log. Every
+// consenter passes the committed entries, in log order, to its own
+// blockcutter, so that all of them write the same blocks. The ORDERER
+// metadata of each block records the index of the last raft entry whose
+// envelope is part of the ledger, which is where the chain resumes after a
+// restart.
+type chain struct {
+	support   multichain.ConsenterSupport
+	transport transport
+	node      *node
+	storage   *storage
+
+	tickInterval     time.Duration
+	snapshotInterval uint64
+
+	consentersLock sync.RWMutex
+	consenters     []*ab.RaftConsenter
+
+	applied             uint64 // index of the last entry passed to the blockcutter
+	persisted           uint64 // index of the last entry which is part of the ledger
+	lastCutBlock        uint64
+	blocksSinceSnapshot uint64
+
+	forwarded      []*forwarded
+	forwardTimeout int
+
+	submitC chan *submission
+	stepC   chan *ab.RaftStep
+	exitC   chan struct{}
+	doneC   chan struct{}
+}
+
+func newChain(conf config.Raft, support multichain.ConsenterSupport, transport transport, raftIndex uint64) (*chain, error) {
+	storage, err := openStorage(filepath.Join(conf.WALDir, support.ChainID()), filepath.Join(conf.SnapDir, support.ChainID()))
+	if err != nil {
+		return nil, fmt.Errorf("Could not open the raft storage of channel %s: %s", support.ChainID(), err)
+	}
+
+	ch := &chain{
+		support:          support,
+		transport:        transport,
+		storage:          storage,
+		tickInterval:     conf.TickInterval,
+		snapshotInterval: conf.SnapshotInterval,
+		applied:          raftIndex,
+		persisted:        raftIndex,
+		lastCutBlock:     support.Reader().Height() - 1,
+		forwardTimeout:   2 * conf.ElectionTick,
+		submitC:          make(chan *submission),
+		stepC:            make(chan *ab.RaftStep, stepBufferSize),
+		exitC:            make(chan struct{}),
+		doneC:            make(chan struct{}),
+	}
+
+	ch.consenters = support.SharedConfig().RaftConsenters()
+	ch.node = newNode(conf.Address, support.ChainID(), consenterAddresses(ch.consenters), storage, conf.ElectionTick, conf.HeartbeatTick)
+	if !ch.node.isConsenter(conf.Address) {
+		logger.Warningf("[channel: %s] This orderer (%s) is not in the raft consenter set", support.ChainID(), conf.Address)
+	}
+
+	// the entries which are part of the ledger are committed, even if the
+	// hard state was not persisted since
+	if ch.node.commit < raftIndex {
+		ch.node.commit = raftIndex
+		if ch.node.commit > storage.lastIndex() {
+			ch.node.commit = storage.lastIndex()
+		}
+	}
+	// a snapshot installed before the ledger caught up with it
+	if storage.snapshot.Index > raftIndex {
+		ch.node.pendingSnapshot = storage.snapshot
+	}
+
+	logger.Debugf("[channel: %s] Starting raft chain at index %d, block %d", support.ChainID(), raftIndex, ch.lastCutBlock)
+	return ch, nil
+}
+
+// Start launches the go routine driving the raft node of the chain
+func (ch *chain) Start() {
+	go ch.run()
+}
+
+// Halt stops the chain, it may be called multiple times
+func (ch *chain) Halt() {
+	select {
+	case <-ch.exitC:
+		// Allow multiple halts without panic
+	default:
+		close(ch.exitC)
+	}
+}
+
+// Enqueue proposes the envelope to the raft log and returns true once the
+// leader appended it, or false on shutdown, if the chain has no leader or if
+// the leader did not append the envelope in time
+func (ch *chain) Enqueue(env *cb.Envelope) bool {
+	sub := &submission{env: env, accepted: make(chan bool, 1)}
+	select {
+	case ch.submitC <- sub:
+	case <-ch.exitC:
+		return false
+	}
+	select {
+	case accepted := <-sub.accepted:
+		return accepted
+	case <-ch.exitC:
+		return false
+	}
+}
+
+// submitStep hands a message received from another consenter to the chain
+func (ch *chain) submitStep(msg *ab.RaftStep) {
+	select {
+	case ch.stepC <- msg:
+	default:
+		logger.Warningf("[channel: %s] Dropping raft message from %s, the chain is lagging", ch.support.ChainID(), msg.From)
+	}
+}
+
+// authenticate checks that the client certificate matches the one of the
+// consenter at the given address, or of any consenter if address is empty
+func (ch *chain) authenticate(address string, cert []byte) bool {
+	ch.consentersLock.RLock()
+	defer ch.consentersLock.RUnlock()
+
+	for _, consenter := range ch.consenters {
+		if address != "" && consenter.Address != address {
+			continue
+		}
+		if len(consenter.ClientTlsCert) == 0 {
+			logger.Warningf("[channel: %s] Raft consenter %s has no client TLS certificate, its messages are rejected", ch.support.ChainID(), consenter.Address)
+			continue
+		}
+		if len(cert) == 0 {
+			continue
+		}
+		if block, _ := pem.Decode(consenter.ClientTlsCert); block != nil && bytes.Equal(block.Bytes, cert) {
+			return true
+		}
+	}
+	return false
+}
+
+func (ch *chain) run() {
+	defer close(ch.doneC)
+	defer ch.storage.close()
+
+	ticker := time.NewTicker(ch.tickInterval)
+	defer ticker.Stop()
+
+	// apply the entries which were committed before a restart
+	if !ch.ready() {
+		return
+	}
+
+	for {
+		select {
+		case <-ticker.C:
+			ch.node.tick()
+			ch.expireForwarded()
+		case msg := <-ch.stepC:
+			ch.node.step(msg)
+		case sub := <-ch.submitC:
+			ch.propose(sub)
+		case <-ch.support.BlockCutter().Timer().C():
+			if ch.node.state != stateLeader {
+				// the leader sends the time-to-cut message, the timer is
+				// kept in case this node becomes the leader
+				ch.support.BlockCutter().Timer().Restart()
+				continue
+			}
+			logger.Debugf("[channel: %s] Time-to-cut block %d timer expired", ch.support.ChainID(), ch.lastCutBlock+1)
+			ch.node.propose(utils.MarshalOrPanic(newTimeToCutMessage(ch.lastCutBlock + 1)))
+		case <-ch.exitC:
+			logger.Debugf("[channel: %s] Exiting", ch.support.ChainID())
+			return
+		}
+		if !ch.ready() {
+			return
+		}
+	}
+}
+
+// propose appends the envelope to the raft log if this consenter is the
+// leader, or forwards it to the leader
+func (ch *chain) propose(sub *submission) {
+	data := utils.MarshalOrPanic(newRegularMessage(utils.MarshalOrPanic(sub.env)))
+	if ch.node.state == stateLeader {
+		sub.accepted <- ch.node.propose(data)
+		return
+	}
+	if !ch.node.propose(data) {
+		sub.accepted <- false
+		return
+	}
+	ch.forwarded = append(ch.forwarded, &forwarded{data: data, accepted: sub.accepted, ticks: ch.forwardTimeout})
+}
+
+// ackForwarded accepts the forwarded submissions the leader appended
+func (ch *chain) ackForwarded(appended []*ab.RaftEntry) {
+	for _, entry := range appended {
+		for i, f := range ch.forwarded {
+			if bytes.Equal(f.data, entry.Data) {
+				f.accepted <- true
+				ch.forwarded = append(ch.forwarded[:i], ch.forwarded[i+1:]...)
+				break
+			}
+		}
+	}
+}
+
+// expireForwarded rejects the forwarded submissions the leader did not
+// append in time, the client may submit them again
+func (ch *chain) expireForwarded() {
+	pending := ch.forwarded[:0]
+	for _, f := range ch.forwarded {
+		f.ticks--
+		if f.ticks > 0 {
+			pending = append(pending, f)
+			continue
+		}
+		f.accepted <- false
+	}
+	ch.forwarded = pending
+}
+
+// ready sends the outgoing messages of the node, catches up with a snapshot
+// received from the leader and applies the newly committed entries. It
+// returns false if the chain was halted while catching up.
+func (ch *chain) ready() bool {
+	for _, m := range ch.node.readMessages() {
+		ch.transport.send(m.to, m.msg)
+	}
+	ch.ackForwarded(ch.node.readAppended())
+
+	if snapshot := ch.node.pendingSnapshot; snapshot != nil {
+		ch.node.pendingSnapshot = nil
+		if !ch.catchUp(snapshot) {
+			return false
+		}
+	}
+
+	for _, entry := range ch.node.committedEntries(ch.applied) {
+		ch.apply(entry)
+	}
+	return true
+}
+
+func (ch *chain) apply(entry *ab.RaftEntry) {
+	ch.applied = entry.Index
+	if len(entry.Data) == 0 {
+		// the empty entry of a new leader
+		return
+	}
+
+	msg := new(ab.RaftMessage)
+	if err := proto.Unmarshal(entry.Data, msg); err != nil {
+		logger.Criticalf("[channel: %s] Unable to unmarshal raft entry %d: %s", ch.support.ChainID(), entry.Index, err)
+		return
+	}
+
+	switch t := msg.Type.(type) {
+	case *ab.RaftMessage_TimeToCut:
+		ttcNumber := t.TimeToCut.BlockNumber
+		if ttcNumber != ch.lastCutBlock+1 {
+			logger.Debugf("[channel: %s] Ignoring stale time-to-cut message for block %d", ch.support.ChainID(), ttcNumber)
+			return
+		}
+		batch, committers := ch.support.BlockCutter().Cut()
+		if len(batch) == 0 {
+			logger.Warningf("[channel: %s] Got right time-to-cut message (%d) but no pending requests - this might indicate a bug", ch.support.ChainID(), ttcNumber)
+			return
+		}
+		ch.writeBlock(batch, committers, entry.Index)
+	case *ab.RaftMessage_Regular:
+		env := new(cb.Envelope)
+		if err := proto.Unmarshal(t.Regular.Payload, env); err != nil {
+			logger.Criticalf("[channel: %s] Unable to unmarshal envelope of raft entry %d: %s", ch.support.ChainID(), entry.Index, err)
+			return
+		}
+		batches, committers, ok := ch.support.BlockCutter().Ordered(env)
+		if !ok {
+			return
+		}
+		for i, batch := range batches {
+			// a batch cut because the envelope overflows it does not
+			// contain the envelope, which must be replayed after a restart
+			index := entry.Index
+			if batch[len(batch)-1] != env {
+				index--
+			}
+			ch.writeBlock(batch, committers[i], index)
+		}
+	default:
+		logger.Criticalf("[channel: %s] Unknown type of raft entry %d", ch.support.ChainID(), entry.Index)
+	}
+}
+
+func (ch *chain) writeBlock(batch []*cb.Envelope, committers []filter.Committer, raftIndex uint64) {
+	block := ch.support.CreateNextBlock(batch)
+	ch.support.WriteBlock(block, committers, utils.MarshalOrPanic(&ab.RaftMetadata{RaftIndex: raftIndex}))
+	ch.blockWritten(block.Header.Number, raftIndex, committers)
+	logger.Debugf("[channel: %s] Wrote block %d at raft index %d", ch.support.ChainID(), block.Header.Number, raftIndex)
+
+	ch.blocksSinceSnapshot++
+	if ch.snapshotInterval > 0 && ch.blocksSinceSnapshot >= ch.snapshotInterval {
+		ch.takeSnapshot()
+	}
+}
+
+func (ch *chain) blockWritten(number uint64, raftIndex uint64, committers []filter.Committer) {
+	ch.lastCutBlock = number
+	ch.persisted = raftIndex
+	for _, committer := range committers {
+		if committer.Isolated() {
+			// the block may have changed the consenter set
+			ch.updateConsenters()
+			return
+		}
+	}
+}
+
+func (ch *chain) updateConsenters() {
+	consenters := ch.support.SharedConfig().RaftConsenters()
+
+	ch.consentersLock.Lock()
+	ch.consenters = consenters
+	ch.consentersLock.Unlock()
+
+	ch.node.setConsenters(consenterAddresses(consenters))
+}
+
+// takeSnapshot compacts the raft log up to the last entry which is part of
+// the ledger, the consenters lagging further behind pull the blocks instead
+func (ch *chain) takeSnapshot() {
+	term, ok := ch.storage.term(ch.persisted)
+	if !ok {
+		return
+	}
+	snapshot := &ab.RaftSnapshot{Index: ch.persisted, Term: term, BlockNumber: ch.lastCutBlock}
+	if err := ch.storage.saveSnapshot(snapshot); err != nil {
+		logger.Errorf("[channel: %s] Could not take raft snapshot at index %d: %s", ch.support.ChainID(), ch.persisted, err)
+		return
+	}
+	logger.Debugf("[channel: %s] Took raft snapshot at index %d, block %d", ch.support.ChainID(), snapshot.Index, snapshot.BlockNumber)
+	ch.blocksSinceSnapshot = 0
+}
+
+// catchUp pulls the blocks up to the one of the snapshot from the other
+// consenters. The envelopes of each block are passed through the
+// blockcutter, so that the configuration is processed as if the entries
+// had been applied, and the resulting block is checked against the pulled
+// one. It returns false if the chain was halted meanwhile.
+func (ch *chain) catchUp(snapshot *ab.RaftSnapshot) bool {
+	if ch.applied >= snapshot.Index {
+		return true
+	}
+	logger.Infof("[channel: %s] Catching up with the snapshot at index %d, block %d", ch.support.ChainID(), snapshot.Index, snapshot.BlockNumber)
+
+	// the pending envelopes are part of the blocks to pull
+	ch.support.BlockCutter().Cut()
+
+	for ch.lastCutBlock < snapshot.BlockNumber {
+		req := &ab.RaftPullRequest{Channel: ch.support.ChainID(), Start: ch.lastCutBlock + 1, End: snapshot.BlockNumber}
+		for _, from := range ch.pullSources() {
+			if err := ch.transport.pull(from, req, ch.commitPulledBlock); err != nil {
+				logger.Warningf("[channel: %s] Could not pull blocks %d to %d from %s: %s", ch.support.ChainID(), ch.lastCutBlock+1, req.End, from, err)
+				continue
+			}
+			break
+		}
+		if ch.lastCutBlock >= snapshot.BlockNumber {
+			break
+		}
+		select {
+		case <-time.After(pullRetryInterval):
+		case <-ch.exitC:
+			return false
+		}
+	}
+
+	ch.applied = snapshot.Index
+	ch.persisted = snapshot.Index
+	ch.blocksSinceSnapshot = 0
+	return true
+}
+
+// pullSources returns the consenters to pull blocks from, the leader first
+func (ch *chain) pullSources() []string {
+	sources := []string{}
+	if ch.node.leader != "" && ch.node.leader != ch.node.id {
+		sources = append(sources, ch.node.leader)
+	}
+	for _, c := range ch.node.consenters {
+		if c != ch.node.id && c != ch.node.leader {
+			sources = append(sources, c)
+		}
+	}
+	return sources
+}
+
+func (ch *chain) commitPulledBlock(pulled *cb.Block) error {
+	if pulled.Header == nil || pulled.Data == nil {
+		return fmt.Errorf("Malformed block")
+	}
+	if pulled.Header.Number != ch.lastCutBlock+1 {
+		return fmt.Errorf("Expected block %d but got block %d", ch.lastCutBlock+1, pulled.Header.Number)
+	}
+
+	var batches [][]*cb.Envelope
+	var committerBatches [][]filter.Committer
+	for _, data := range pulled.Data.Data {
+		env, err := utils.UnmarshalEnvelope(data)
+		if err != nil {
+			return err
+		}
+		batch, committers, ok := ch.support.BlockCutter().Ordered(env)
+		if !ok {
+			ch.support.BlockCutter().Cut()
+			return fmt.Errorf("Envelope of block %d was rejected", pulled.Header.Number)
+		}
+		batches = append(batches, batch...)
+		committerBatches = append(committerBatches, committers...)
+	}
+	if batch, committers := ch.support.BlockCutter().Cut(); len(batch) > 0 {
+		batches = append(batches, batch)
+		committerBatches = append(committerBatches, committers)
+	}
+	if len(batches) != 1 {
+		return fmt.Errorf("Envelopes of block %d were cut into %d batches", pulled.Header.Number, len(batches))
+	}
+
+	block := ch.support.CreateNextBlock(batches[0])
+	if !bytes.Equal(block.Header.Bytes(), pulled.Header.Bytes()) {
+		return fmt.Errorf("Header of block %d does not match the one of the pulled block", pulled.Header.Number)
+	}
+
+	metadata, err := utils.GetMetadataFromBlock(pulled, cb.BlockMetadataIndex_ORDERER)
+	if err != nil {
+		return err
+	}
+	raftIndex := getRaftIndex(metadata)
+
+	ch.support.WriteBlock(block, committerBatches[0], metadata.Value)
+	ch.blockWritten(block.Header.Number, raftIndex, committerBatches[0])
+	logger.Debugf("[channel: %s] Wrote pulled block %d", ch.support.ChainID(), block.Header.Number)
+	return nil
+}
+
+func consenterAddresses(consenters []*ab.RaftConsenter) []string {
+	addresses := make([]string, len(consenters))
+	for i, consenter := range consenters {
+		addresses[i] = consenter.Address
+	}
+	return addresses
+}
+
+func newRegularMessage(payload []byte) *ab.RaftMessage {
+	return &ab.RaftMessage{
+		Type: &ab.RaftMessage_Regular{
+			Regular: &ab.RaftMessageRegular{
+				Payload: payload,
+			},
+		},
+	}
+}
+
+func newTimeToCutMessage(blockNumber uint64) *ab.RaftMessage {
+	return &ab.RaftMessage{
+		Type: &ab.RaftMessage_TimeToCut{
+			TimeToCut: &ab.RaftMessageTimeToCut{
+				BlockNumber: blockNumber,
+			},
+		},
+	}
+}
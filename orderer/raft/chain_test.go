@@ -0,0 +1,532 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package raft
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric/common/config"
+	mockconfigtxorderer "github.com/hyperledger/fabric/common/mocks/configvalues/channel/orderer"
+	"github.com/hyperledger/fabric/orderer/common/blockcutter"
+	"github.com/hyperledger/fabric/orderer/common/filter"
+	"github.com/hyperledger/fabric/orderer/ledger"
+	ramledger "github.com/hyperledger/fabric/orderer/ledger/ram"
+	localconfig "github.com/hyperledger/fabric/orderer/localconfig"
+	cb "github.com/hyperledger/fabric/protos/common"
+	ab "github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+)
+
+const testChainID = "testchain"
+
+const testTimeout = 10 * time.Second
+
+// lockedLedger serializes the accesses to a ledger shared by the chain and
+// the consenters pulling from it
+type lockedLedger struct {
+	sync.Mutex
+	rl ledger.ReadWriter
+}
+
+func (l *lockedLedger) Iterator(startType *ab.SeekPosition) (ledger.Iterator, uint64) {
+	l.Lock()
+	defer l.Unlock()
+	return l.rl.Iterator(startType)
+}
+
+func (l *lockedLedger) Height() uint64 {
+	l.Lock()
+	defer l.Unlock()
+	return l.rl.Height()
+}
+
+func (l *lockedLedger) Append(block *cb.Block) error {
+	l.Lock()
+	defer l.Unlock()
+	return l.rl.Append(block)
+}
+
+// testSupport is a multichain.ConsenterSupport backed by a RAM ledger and a
+// real blockcutter, so that the consenters write identical blocks
+type testSupport struct {
+	ledger       *lockedLedger
+	cutter       blockcutter.Receiver
+	sharedConfig *mockconfigtxorderer.SharedConfig
+}
+
+func newTestSupport(sharedConfig *mockconfigtxorderer.SharedConfig) *testSupport {
+	rl, _ := ramledger.New(100).GetOrCreate(testChainID)
+	if err := rl.Append(cb.NewBlock(0, nil)); err != nil {
+		panic(err)
+	}
+	return &testSupport{
+		ledger:       &lockedLedger{rl: rl},
+		cutter:       blockcutter.NewReceiverImpl(sharedConfig, filter.NewRuleSet([]filter.Rule{filter.AcceptRule})),
+		sharedConfig: sharedConfig,
+	}
+}
+
+func (s *testSupport) BlockCutter() blockcutter.Receiver { return s.cutter }
+
+func (s *testSupport) SharedConfig() config.Orderer { return s.sharedConfig }
+
+func (s *testSupport) CreateNextBlock(messages []*cb.Envelope) *cb.Block {
+	return ledger.CreateNextBlock(s.ledger, messages)
+}
+
+func (s *testSupport) WriteBlock(block *cb.Block, committers []filter.Committer, encodedMetadataValue []byte) *cb.Block {
+	for _, committer := range committers {
+		committer.Commit()
+	}
+	if encodedMetadataValue != nil {
+		block.Metadata.Metadata[cb.BlockMetadataIndex_ORDERER] = utils.MarshalOrPanic(&cb.Metadata{Value: encodedMetadataValue})
+	}
+	if err := s.ledger.Append(block); err != nil {
+		panic(err)
+	}
+	return block
+}
+
+func (s *testSupport) ChainID() string { return testChainID }
+
+func (s *testSupport) Reader() ledger.Reader { return s.ledger }
+
+func (s *testSupport) Sequence() uint64 { return 0 }
+
+func (s *testSupport) ProposeConfigUpdate(env *cb.Envelope) (*cb.ConfigEnvelope, error) {
+	return nil, fmt.Errorf("Config updates are not supported")
+}
+
+func (s *testSupport) Sign(message []byte) ([]byte, error) { return message, nil }
+
+func (s *testSupport) NewSignatureHeader() (*cb.SignatureHeader, error) {
+	return &cb.SignatureHeader{}, nil
+}
+
+// testNetwork routes the messages of the consenters through their Cluster
+// service, messages from and to the isolated consenters are dropped, as are
+// the forwarded proposals if dropProposals is set
+type testNetwork struct {
+	lock          sync.Mutex
+	consenters    map[string]*consenter
+	isolated      map[string]bool
+	dropProposals bool
+}
+
+func (n *testNetwork) get(from, to string) *consenter {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	if n.isolated[from] || n.isolated[to] {
+		return nil
+	}
+	return n.consenters[to]
+}
+
+func (n *testNetwork) drops(msg *ab.RaftStep) bool {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	return n.dropProposals && msg.GetProposal() != nil
+}
+
+func (n *testNetwork) isolate(address string, isolated bool) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	n.isolated[address] = isolated
+}
+
+type testTransport struct {
+	network *testNetwork
+	address string
+}
+
+func (t *testTransport) send(to string, msg *ab.RaftStep) {
+	if t.network.drops(msg) {
+		return
+	}
+	if c := t.network.get(t.address, to); c != nil {
+		c.Step(clientContext(t.address), msg)
+	}
+}
+
+func (t *testTransport) pull(from string, req *ab.RaftPullRequest, deliver func(block *cb.Block) error) error {
+	c := t.network.get(t.address, from)
+	if c == nil {
+		return fmt.Errorf("%s is unreachable", from)
+	}
+	return c.Pull(req, &pullStream{ctx: clientContext(t.address), deliver: deliver})
+}
+
+// testCert is the DER certificate the consenter at address authenticates
+// with, it does not need to parse
+func testCert(address string) []byte {
+	return []byte("cert of " + address)
+}
+
+// clientContext is the context of a request of the consenter at address
+// over mutual TLS
+func clientContext(address string) context.Context {
+	state := tls.ConnectionState{PeerCertificates: []*x509.Certificate{{Raw: testCert(address)}}}
+	return peer.NewContext(context.Background(), &peer.Peer{AuthInfo: credentials.TLSInfo{State: state}})
+}
+
+type pullStream struct {
+	grpc.ServerStream
+	ctx     context.Context
+	deliver func(block *cb.Block) error
+}
+
+func (s *pullStream) Context() context.Context { return s.ctx }
+
+func (s *pullStream) Send(block *cb.Block) error { return s.deliver(block) }
+
+type testOrderer struct {
+	conf    localconfig.Raft
+	support *testSupport
+	chain   *chain
+}
+
+func newTestNetwork(t *testing.T, size int, batchSize uint32, snapshotInterval uint64) (*testNetwork, []*testOrderer, func()) {
+	dir, err := ioutil.TempDir("", "raft-chain")
+	if err != nil {
+		t.Fatalf("Error creating temp dir: %s", err)
+	}
+
+	network := &testNetwork{consenters: make(map[string]*consenter), isolated: make(map[string]bool)}
+	var consenters []*ab.RaftConsenter
+	for i := 0; i < size; i++ {
+		address := fmt.Sprintf("orderer%d:7050", i)
+		consenters = append(consenters, &ab.RaftConsenter{Address: address, ClientTlsCert: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: testCert(address)})})
+	}
+
+	var orderers []*testOrderer
+	for _, c := range consenters {
+		sharedConfig := &mockconfigtxorderer.SharedConfig{
+			BatchSizeVal:      &ab.BatchSize{MaxMessageCount: batchSize, AbsoluteMaxBytes: 1024 * 1024, PreferredMaxBytes: 1024 * 1024},
+			BatchTimeoutVal:   50 * time.Millisecond,
+			RaftConsentersVal: consenters,
+		}
+		o := &testOrderer{
+			conf: localconfig.Raft{
+				Address:          c.Address,
+				WALDir:           filepath.Join(dir, c.Address, "wal"),
+				SnapDir:          filepath.Join(dir, c.Address, "snap"),
+				TickInterval:     10 * time.Millisecond,
+				ElectionTick:     10,
+				HeartbeatTick:    1,
+				SnapshotInterval: snapshotInterval,
+			},
+			support: newTestSupport(sharedConfig),
+		}
+		orderers = append(orderers, o)
+	}
+
+	for _, o := range orderers {
+		o.start(t, network, nil)
+	}
+
+	return network, orderers, func() {
+		for _, o := range orderers {
+			o.halt()
+		}
+		os.RemoveAll(dir)
+	}
+}
+
+func (o *testOrderer) start(t *testing.T, network *testNetwork, metadata *cb.Metadata) {
+	c := newConsenter(o.conf, &testTransport{network: network, address: o.conf.Address})
+	ch, err := c.HandleChain(o.support, metadata)
+	if err != nil {
+		t.Fatalf("Error creating chain: %s", err)
+	}
+	o.chain = ch.(*chain)
+
+	network.lock.Lock()
+	network.consenters[o.conf.Address] = c
+	network.lock.Unlock()
+
+	o.chain.Start()
+}
+
+func (o *testOrderer) halt() {
+	o.chain.Halt()
+	<-o.chain.doneC
+}
+
+// enqueue retries until the chain has a leader to accept the envelope
+func enqueue(t *testing.T, o *testOrderer, env *cb.Envelope) {
+	deadline := time.Now().Add(testTimeout)
+	for !o.chain.Enqueue(env) {
+		if time.Now().After(deadline) {
+			t.Fatalf("Envelope was not accepted")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func waitForHeight(t *testing.T, o *testOrderer, height uint64) {
+	deadline := time.Now().Add(testTimeout)
+	for o.support.ledger.Height() < height {
+		if time.Now().After(deadline) {
+			t.Fatalf("Ledger of %s reached height %d instead of %d", o.conf.Address, o.support.ledger.Height(), height)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func testEnvelope(i int) *cb.Envelope {
+	return &cb.Envelope{Payload: []byte(fmt.Sprintf("tx%d", i))}
+}
+
+func blockEnvelopes(t *testing.T, o *testOrderer, number uint64) []string {
+	block := ledger.GetBlock(o.support.ledger, number)
+	if block == nil {
+		t.Fatalf("Block %d of %s is missing", number, o.conf.Address)
+	}
+	var payloads []string
+	for _, data := range block.Data.Data {
+		payloads = append(payloads, string(utils.UnmarshalEnvelopeOrPanic(data).Payload))
+	}
+	return payloads
+}
+
+// assertSameLedgers checks that the orderers wrote the same blocks
+func assertSameLedgers(t *testing.T, orderers []*testOrderer, height uint64) {
+	for _, o := range orderers {
+		waitForHeight(t, o, height)
+	}
+	for number := uint64(1); number < height; number++ {
+		expected := ledger.GetBlock(orderers[0].support.ledger, number)
+		for _, o := range orderers[1:] {
+			block := ledger.GetBlock(o.support.ledger, number)
+			if !bytes.Equal(expected.Header.Hash(), block.Header.Hash()) {
+				t.Fatalf("Block %d of %s differs from the one of %s", number, o.conf.Address, orderers[0].conf.Address)
+			}
+		}
+	}
+}
+
+func TestChainOrdering(t *testing.T) {
+	_, orderers, cleanup := newTestNetwork(t, 3, 2, 0)
+	defer cleanup()
+
+	for i := 0; i < 5; i++ {
+		enqueue(t, orderers[i%3], testEnvelope(i))
+	}
+
+	// two full batches, and a batch cut by the time-to-cut message
+	assertSameLedgers(t, orderers, 4)
+	var total int
+	for number := uint64(1); number < 4; number++ {
+		total += len(blockEnvelopes(t, orderers[0], number))
+	}
+	if total != 5 {
+		t.Fatalf("Expected 5 envelopes in the blocks, got %d", total)
+	}
+}
+
+func TestChainRestart(t *testing.T) {
+	network, orderers, cleanup := newTestNetwork(t, 1, 2, 0)
+	defer cleanup()
+	o := orderers[0]
+	o.support.sharedConfig.BatchTimeoutVal = time.Hour
+
+	for i := 0; i < 3; i++ {
+		enqueue(t, o, testEnvelope(i))
+	}
+	waitForHeight(t, o, 2)
+	o.halt()
+
+	// the third envelope was pending in the blockcutter, it must be
+	// replayed from the raft log
+	lastBlock := ledger.GetBlock(o.support.ledger, o.support.ledger.Height()-1)
+	metadata, err := utils.GetMetadataFromBlock(lastBlock, cb.BlockMetadataIndex_ORDERER)
+	if err != nil {
+		t.Fatalf("Error extracting metadata: %s", err)
+	}
+	o.support.cutter = blockcutter.NewReceiverImpl(o.support.sharedConfig, filter.NewRuleSet([]filter.Rule{filter.AcceptRule}))
+	o.start(t, network, metadata)
+
+	enqueue(t, o, testEnvelope(3))
+	waitForHeight(t, o, 3)
+	if payloads := blockEnvelopes(t, o, 2); fmt.Sprint(payloads) != "[tx2 tx3]" {
+		t.Fatalf("Unexpected block after restart: %v", payloads)
+	}
+}
+
+func TestChainRemoveAndJoin(t *testing.T) {
+	network, orderers, cleanup := newTestNetwork(t, 1, 2, 0)
+	defer cleanup()
+	o := orderers[0]
+	c := network.consenters[o.conf.Address]
+
+	for i := 0; i < 4; i++ {
+		enqueue(t, o, testEnvelope(i))
+	}
+	waitForHeight(t, o, 3)
+
+	if err := c.RemoveChain(testChainID); err != nil {
+		t.Fatalf("Error removing chain: %s", err)
+	}
+	if _, err := c.chain(testChainID); err == nil {
+		t.Fatalf("The removed chain should not be served")
+	}
+	for _, dir := range []string{o.conf.WALDir, o.conf.SnapDir} {
+		if _, err := os.Stat(filepath.Join(dir, testChainID)); !os.IsNotExist(err) {
+			t.Fatalf("The raft state of the removed chain should be deleted from %s", dir)
+		}
+	}
+
+	// the joined chain starts from an empty ledger, the entries of the
+	// removed chain must not be replayed into it
+	o.support = newTestSupport(o.support.sharedConfig)
+	o.start(t, network, nil)
+	for i := 4; i < 6; i++ {
+		enqueue(t, o, testEnvelope(i))
+	}
+	waitForHeight(t, o, 2)
+	if payloads := blockEnvelopes(t, o, 1); fmt.Sprint(payloads) != "[tx4 tx5]" {
+		t.Fatalf("Unexpected block after joining again: %v", payloads)
+	}
+}
+
+func TestChainArchive(t *testing.T) {
+	network, orderers, cleanup := newTestNetwork(t, 1, 1, 0)
+	defer cleanup()
+	o := orderers[0]
+	c := network.consenters[o.conf.Address]
+
+	enqueue(t, o, testEnvelope(0))
+	waitForHeight(t, o, 2)
+
+	archivePath, err := ioutil.TempDir("", "raft-archive")
+	if err != nil {
+		t.Fatalf("Error creating temp dir: %s", err)
+	}
+	defer os.RemoveAll(archivePath)
+
+	if err := c.ArchiveChain(testChainID, archivePath); err != nil {
+		t.Fatalf("Error archiving chain: %s", err)
+	}
+	if _, err := c.chain(testChainID); err == nil {
+		t.Fatalf("The archived chain should not be served")
+	}
+	if _, err := os.Stat(filepath.Join(o.conf.WALDir, testChainID)); !os.IsNotExist(err) {
+		t.Fatalf("The WAL of the archived chain should be moved")
+	}
+	if _, err := os.Stat(filepath.Join(archivePath, archivedWALDir)); err != nil {
+		t.Fatalf("The WAL of the archived chain should be in the archive path: %s", err)
+	}
+}
+
+func TestChainSnapshotCatchUp(t *testing.T) {
+	network, orderers, cleanup := newTestNetwork(t, 3, 1, 1)
+	defer cleanup()
+
+	lagging := orderers[2]
+	network.isolate(lagging.conf.Address, true)
+	for i := 0; i < 3; i++ {
+		enqueue(t, orderers[0], testEnvelope(i))
+	}
+	assertSameLedgers(t, orderers[:2], 4)
+
+	// the entries were compacted, the lagging consenter has to pull the
+	// blocks
+	network.isolate(lagging.conf.Address, false)
+	assertSameLedgers(t, orderers, 4)
+
+	enqueue(t, orderers[0], testEnvelope(3))
+	assertSameLedgers(t, orderers, 5)
+}
+
+func TestPullUnavailableBlocks(t *testing.T) {
+	network, orderers, cleanup := newTestNetwork(t, 1, 1, 0)
+	defer cleanup()
+
+	c := network.consenters[orderers[0].conf.Address]
+	ctx := clientContext(orderers[0].conf.Address)
+	err := c.Pull(&ab.RaftPullRequest{Channel: testChainID, Start: 1, End: 5}, &pullStream{ctx: ctx, deliver: func(*cb.Block) error { return nil }})
+	if err == nil {
+		t.Fatalf("Pulling blocks beyond the height of the ledger should fail")
+	}
+
+	err = c.Pull(&ab.RaftPullRequest{Channel: "unknown", Start: 0, End: 0}, &pullStream{ctx: ctx, deliver: func(*cb.Block) error { return nil }})
+	if err == nil {
+		t.Fatalf("Pulling blocks of an unknown channel should fail")
+	}
+}
+
+func TestAuthenticate(t *testing.T) {
+	_, orderers, cleanup := newTestNetwork(t, 2, 1, 0)
+	defer cleanup()
+	ch := orderers[0].chain
+	address := orderers[1].conf.Address
+
+	if !ch.authenticate(address, testCert(address)) {
+		t.Fatalf("The certificate of %s should be accepted", address)
+	}
+	if ch.authenticate(address, testCert(orderers[0].conf.Address)) {
+		t.Fatalf("The certificate of another consenter should be rejected")
+	}
+	if ch.authenticate(address, nil) {
+		t.Fatalf("A connection without client certificate should be rejected")
+	}
+
+	ch.consentersLock.Lock()
+	ch.consenters = []*ab.RaftConsenter{{Address: address}}
+	ch.consentersLock.Unlock()
+	if ch.authenticate(address, nil) || ch.authenticate("", nil) {
+		t.Fatalf("A consenter without client certificate should be rejected")
+	}
+}
+
+func TestForwardedEnqueueWaitsForLeader(t *testing.T) {
+	network, orderers, cleanup := newTestNetwork(t, 3, 10, 0)
+	defer cleanup()
+
+	// wait for a leader
+	enqueue(t, orderers[0], testEnvelope(0))
+
+	// the proposals the followers forward get lost, only the leader may
+	// accept an envelope
+	network.lock.Lock()
+	network.dropProposals = true
+	network.lock.Unlock()
+
+	accepted := 0
+	for i, o := range orderers {
+		if o.chain.Enqueue(testEnvelope(i + 1)) {
+			accepted++
+		}
+	}
+	if accepted != 1 {
+		t.Fatalf("Expected the leader only to accept an envelope, %d accepted", accepted)
+	}
+}
@@ -0,0 +1,216 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package raft
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/orderer/ledger"
+	"github.com/hyperledger/fabric/orderer/localconfig"
+	"github.com/hyperledger/fabric/orderer/multichain"
+	cb "github.com/hyperledger/fabric/protos/common"
+	ab "github.com/hyperledger/fabric/protos/orderer"
+	"github.com/op/go-logging"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+)
+
+var logger = logging.MustGetLogger("orderer/raft")
+
+const (
+	// archivedWALDir is the directory, within the archive path of the
+	// ledger, the WAL of an archived chain is moved to
+	archivedWALDir = "raftwal"
+	// archivedSnapDir is the directory, within the archive path of the
+	// ledger, the snapshots of an archived chain are moved to
+	archivedSnapDir = "raftsnap"
+)
+
+// Consenter is the Raft-based consenter. Besides handling the chains, it
+// serves the Cluster service through which the consenters of a channel
+// exchange raft messages and blocks.
+type Consenter interface {
+	multichain.Consenter
+	multichain.Remover
+	ab.ClusterServer
+}
+
+type consenter struct {
+	conf      config.Raft
+	transport transport
+
+	lock   sync.RWMutex
+	chains map[string]*chain
+}
+
+// New creates a Raft-based consenter. Called by orderer's main.go, the dial
+// options are used to connect to the other consenters.
+func New(conf config.Raft, dialOpts ...grpc.DialOption) Consenter {
+	return newConsenter(conf, newGRPCTransport(dialOpts...))
+}
+
+func newConsenter(conf config.Raft, transport transport) *consenter {
+	return &consenter{
+		conf:      conf,
+		transport: transport,
+		chains:    make(map[string]*chain),
+	}
+}
+
+// HandleChain creates a raft chain resuming after the raft entry recorded
+// in the metadata of the last block
+func (c *consenter) HandleChain(support multichain.ConsenterSupport, metadata *cb.Metadata) (multichain.Chain, error) {
+	ch, err := newChain(c.conf, support, c.transport, getRaftIndex(metadata))
+	if err != nil {
+		return nil, err
+	}
+
+	c.lock.Lock()
+	c.chains[support.ChainID()] = ch
+	c.lock.Unlock()
+
+	return ch, nil
+}
+
+func getRaftIndex(metadata *cb.Metadata) uint64 {
+	if metadata == nil || metadata.Value == nil {
+		return 0
+	}
+	raftMetadata := &ab.RaftMetadata{}
+	if err := proto.Unmarshal(metadata.Value, raftMetadata); err != nil {
+		panic("Ledger may be corrupted: cannot unmarshal orderer metadata in most recent block")
+	}
+	return raftMetadata.RaftIndex
+}
+
+func (c *consenter) chain(chainID string) (*chain, error) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	ch, ok := c.chains[chainID]
+	if !ok {
+		return nil, fmt.Errorf("Channel %s is not served by this consenter", chainID)
+	}
+	return ch, nil
+}
+
+// RemoveChain drops a halted chain and deletes its WAL and snapshots, so
+// that joining the channel again does not replay them
+func (c *consenter) RemoveChain(chainID string) error {
+	c.release(chainID)
+	if err := os.RemoveAll(filepath.Join(c.conf.WALDir, chainID)); err != nil {
+		return err
+	}
+	return os.RemoveAll(filepath.Join(c.conf.SnapDir, chainID))
+}
+
+// ArchiveChain drops a halted chain and moves its WAL and snapshots within
+// the archive path of the ledger, they are deleted if the path is empty
+func (c *consenter) ArchiveChain(chainID string, archivePath string) error {
+	if archivePath == "" {
+		return c.RemoveChain(chainID)
+	}
+	c.release(chainID)
+	if err := os.MkdirAll(archivePath, 0755); err != nil {
+		return err
+	}
+	if err := archiveDir(filepath.Join(c.conf.WALDir, chainID), filepath.Join(archivePath, archivedWALDir)); err != nil {
+		return err
+	}
+	return archiveDir(filepath.Join(c.conf.SnapDir, chainID), filepath.Join(archivePath, archivedSnapDir))
+}
+
+// release removes the chain from the consenter and waits for it to exit,
+// so that its storage is closed
+func (c *consenter) release(chainID string) {
+	c.lock.Lock()
+	ch, ok := c.chains[chainID]
+	delete(c.chains, chainID)
+	c.lock.Unlock()
+
+	if ok {
+		ch.Halt()
+		<-ch.doneC
+	}
+}
+
+// archiveDir moves a directory, if it exists
+func archiveDir(dir string, archivedDir string) error {
+	if _, err := os.Stat(dir); os.IsNotExist(err) {
+		return nil
+	}
+	return os.Rename(dir, archivedDir)
+}
+
+// Step delivers a raft message sent by another consenter of the channel
+func (c *consenter) Step(ctx context.Context, msg *ab.RaftStep) (*ab.RaftStepResponse, error) {
+	ch, err := c.chain(msg.Channel)
+	if err != nil {
+		return nil, err
+	}
+	if !ch.authenticate(msg.From, clientCertificate(ctx)) {
+		return nil, fmt.Errorf("Client certificate does not match the one of raft consenter %s", msg.From)
+	}
+	ch.submitStep(msg)
+	return &ab.RaftStepResponse{}, nil
+}
+
+// Pull streams the requested blocks of the channel to another consenter
+func (c *consenter) Pull(req *ab.RaftPullRequest, srv ab.Cluster_PullServer) error {
+	ch, err := c.chain(req.Channel)
+	if err != nil {
+		return err
+	}
+	if !ch.authenticate("", clientCertificate(srv.Context())) {
+		return fmt.Errorf("Client certificate does not match the one of any raft consenter")
+	}
+
+	reader := ch.support.Reader()
+	if req.Start > req.End || req.End >= reader.Height() {
+		return fmt.Errorf("Blocks %d to %d are not available, the height of the ledger is %d", req.Start, req.End, reader.Height())
+	}
+	for number := req.Start; number <= req.End; number++ {
+		block := ledger.GetBlock(reader, number)
+		if block == nil {
+			return fmt.Errorf("Could not retrieve block %d", number)
+		}
+		if err := srv.Send(block); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// clientCertificate returns the DER encoded TLS certificate of the client,
+// or nil if the connection does not use TLS client authentication
+func clientCertificate(ctx context.Context) []byte {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return nil
+	}
+	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(tlsInfo.State.PeerCertificates) == 0 {
+		return nil
+	}
+	return tlsInfo.State.PeerCertificates[0].Raw
+}
@@ -0,0 +1,566 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package raft
+
+import (
+	"math/rand"
+	"sort"
+	"time"
+
+	ab "github.com/hyperledger/fabric/protos/orderer"
+)
+
+type stateType int
+
+const (
+	stateFollower stateType = iota
+	stateCandidate
+	stateLeader
+)
+
+func (s stateType) String() string {
+	switch s {
+	case stateFollower:
+		return "follower"
+	case stateCandidate:
+		return "candidate"
+	default:
+		return "leader"
+	}
+}
+
+// maxAppendBytes bounds the size of the entries of an append request, an
+// entry larger than the bound is sent on its own
+const maxAppendBytes = 1024 * 1024
+
+// outgoing is a message waiting to be sent to a consenter
+type outgoing struct {
+	to  string
+	msg *ab.RaftStep
+}
+
+// node is the raft state machine of a chain. It is not safe for concurrent
+// use: the chain drives it from a single go routine by ticking it, stepping
+// it with the messages received from the other consenters and proposing
+// new entries, and then collects the messages to send and the entries
+// which were appended and committed. Entries and hard states are persisted
+// to storage before any message is sent.
+//
+// The node checks quorum: a leader which did not hear from a quorum of the
+// consenters during an election timeout steps down, and a node which heard
+// from a leader during the last election timeout ignores the vote requests
+// of higher terms, so that a consenter which was partitioned away does not
+// depose a working leader.
+type node struct {
+	id         string
+	chainID    string
+	consenters []string
+	storage    *storage
+
+	state  stateType
+	term   uint64
+	vote   string
+	leader string
+	commit uint64
+
+	electionTick     int
+	heartbeatTick    int
+	electionTimeout  int
+	electionElapsed  int
+	heartbeatElapsed int
+
+	votes  map[string]bool
+	next   map[string]uint64
+	match  map[string]uint64
+	active map[string]bool // consenters the leader heard from
+
+	msgs     []outgoing
+	appended []*ab.RaftEntry
+
+	// snapshot received from the leader which the chain must catch up with
+	pendingSnapshot *ab.RaftSnapshot
+
+	rand *rand.Rand
+}
+
+func newNode(id string, chainID string, consenters []string, storage *storage, electionTick int, heartbeatTick int) *node {
+	n := &node{
+		id:            id,
+		chainID:       chainID,
+		storage:       storage,
+		term:          storage.hardState.Term,
+		vote:          storage.hardState.Vote,
+		commit:        storage.hardState.Commit,
+		electionTick:  electionTick,
+		heartbeatTick: heartbeatTick,
+		rand:          rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+	if n.commit < storage.snapshot.Index {
+		n.commit = storage.snapshot.Index
+	}
+	if n.commit > storage.lastIndex() {
+		n.commit = storage.lastIndex()
+	}
+	n.setConsenters(consenters)
+	n.becomeFollower(n.term, "")
+	return n
+}
+
+// setConsenters changes the consenter set, a leader which is no longer a
+// consenter steps down
+func (n *node) setConsenters(consenters []string) {
+	n.consenters = append([]string(nil), consenters...)
+	if n.state != stateLeader {
+		return
+	}
+	if !n.isConsenter(n.id) {
+		logger.Infof("[channel: %s] Leader %s was removed from the consenter set, stepping down", n.chainID, n.id)
+		n.becomeFollower(n.term, "")
+		return
+	}
+	for _, c := range n.consenters {
+		if _, ok := n.next[c]; !ok {
+			n.next[c] = n.storage.lastIndex() + 1
+			n.match[c] = 0
+		}
+	}
+	n.maybeCommit()
+}
+
+func (n *node) isConsenter(id string) bool {
+	for _, c := range n.consenters {
+		if c == id {
+			return true
+		}
+	}
+	return false
+}
+
+func (n *node) quorum() int {
+	return len(n.consenters)/2 + 1
+}
+
+func (n *node) resetElectionTimeout() {
+	n.electionElapsed = 0
+	n.electionTimeout = n.electionTick + n.rand.Intn(n.electionTick)
+}
+
+func (n *node) persistHardState() {
+	hs := n.storage.hardState
+	if hs.Term == n.term && hs.Vote == n.vote && hs.Commit == n.commit {
+		return
+	}
+	if err := n.storage.setHardState(&ab.RaftHardState{Term: n.term, Vote: n.vote, Commit: n.commit}); err != nil {
+		logger.Panicf("[channel: %s] Could not persist the raft hard state: %s", n.chainID, err)
+	}
+}
+
+func (n *node) appendEntries(entries ...*ab.RaftEntry) {
+	if err := n.storage.append(entries...); err != nil {
+		logger.Panicf("[channel: %s] Could not persist raft entries: %s", n.chainID, err)
+	}
+	n.appended = append(n.appended, entries...)
+}
+
+func (n *node) lastTerm() uint64 {
+	term, _ := n.storage.term(n.storage.lastIndex())
+	return term
+}
+
+func (n *node) send(to string, msg *ab.RaftStep) {
+	msg.Channel = n.chainID
+	msg.From = n.id
+	msg.Term = n.term
+	n.msgs = append(n.msgs, outgoing{to: to, msg: msg})
+}
+
+// readMessages returns the messages to send and clears the outbox
+func (n *node) readMessages() []outgoing {
+	msgs := n.msgs
+	n.msgs = nil
+	return msgs
+}
+
+// readAppended returns the entries appended to the log since the last call
+func (n *node) readAppended() []*ab.RaftEntry {
+	appended := n.appended
+	n.appended = nil
+	return appended
+}
+
+// committedEntries returns the committed entries which follow applied
+func (n *node) committedEntries(applied uint64) []*ab.RaftEntry {
+	return n.storage.slice(applied+1, n.commit)
+}
+
+func (n *node) becomeFollower(term uint64, leader string) {
+	if n.leader != leader && leader != "" {
+		logger.Infof("[channel: %s] %s is following leader %s at term %d", n.chainID, n.id, leader, term)
+	}
+	if term > n.term {
+		n.vote = ""
+	}
+	n.state = stateFollower
+	n.term = term
+	n.leader = leader
+	n.resetElectionTimeout()
+	n.persistHardState()
+}
+
+func (n *node) campaign() {
+	n.state = stateCandidate
+	n.term++
+	n.vote = n.id
+	n.leader = ""
+	n.votes = map[string]bool{n.id: true}
+	n.resetElectionTimeout()
+	n.persistHardState()
+	logger.Infof("[channel: %s] %s is starting an election at term %d", n.chainID, n.id, n.term)
+
+	if len(n.votes) >= n.quorum() {
+		n.becomeLeader()
+		return
+	}
+	for _, c := range n.consenters {
+		if c == n.id {
+			continue
+		}
+		n.send(c, &ab.RaftStep{Type: &ab.RaftStep_VoteRequest{VoteRequest: &ab.RaftVoteRequest{
+			LastIndex: n.storage.lastIndex(),
+			LastTerm:  n.lastTerm(),
+		}}})
+	}
+}
+
+func (n *node) becomeLeader() {
+	logger.Infof("[channel: %s] %s became the leader at term %d", n.chainID, n.id, n.term)
+	n.state = stateLeader
+	n.leader = n.id
+	n.heartbeatElapsed = 0
+	n.electionElapsed = 0
+	n.next = make(map[string]uint64)
+	n.match = make(map[string]uint64)
+	n.active = map[string]bool{n.id: true}
+	for _, c := range n.consenters {
+		n.next[c] = n.storage.lastIndex() + 1
+		n.match[c] = 0
+	}
+
+	// entries of previous terms are committed once an entry of the current
+	// term is
+	n.propose(nil)
+}
+
+// tick advances the logical clock of the node
+func (n *node) tick() {
+	if n.state == stateLeader {
+		n.electionElapsed++
+		if n.electionElapsed >= n.electionTick {
+			n.electionElapsed = 0
+			if !n.checkQuorum() {
+				return
+			}
+		}
+		n.heartbeatElapsed++
+		if n.heartbeatElapsed >= n.heartbeatTick {
+			n.heartbeatElapsed = 0
+			n.broadcastAppend()
+		}
+		return
+	}
+
+	n.electionElapsed++
+	if n.electionElapsed >= n.electionTimeout {
+		n.resetElectionTimeout()
+		if n.isConsenter(n.id) {
+			n.campaign()
+		}
+	}
+}
+
+// checkQuorum steps the leader down if it did not hear from a quorum of the
+// consenters since the last check
+func (n *node) checkQuorum() bool {
+	active := 0
+	for _, c := range n.consenters {
+		if n.active[c] {
+			active++
+		}
+	}
+	n.active = map[string]bool{n.id: true}
+	if active < n.quorum() {
+		logger.Warningf("[channel: %s] Leader %s heard from %d consenters only, stepping down", n.chainID, n.id, active)
+		n.becomeFollower(n.term, "")
+		return false
+	}
+	return true
+}
+
+// propose appends data to the log if the node is the leader, or forwards it
+// to the leader. It returns false if there is no known leader.
+func (n *node) propose(data []byte) bool {
+	switch {
+	case n.state == stateLeader:
+		entry := &ab.RaftEntry{Term: n.term, Index: n.storage.lastIndex() + 1, Data: data}
+		n.appendEntries(entry)
+		n.match[n.id] = entry.Index
+		n.next[n.id] = entry.Index + 1
+		n.maybeCommit()
+		n.broadcastAppend()
+		return true
+	case n.leader != "":
+		n.send(n.leader, &ab.RaftStep{Type: &ab.RaftStep_Proposal{Proposal: &ab.RaftProposal{Data: data}}})
+		return true
+	default:
+		return false
+	}
+}
+
+func (n *node) broadcastAppend() {
+	for _, c := range n.consenters {
+		if c != n.id {
+			n.sendAppend(c)
+		}
+	}
+}
+
+func (n *node) sendAppend(to string) {
+	next := n.next[to]
+	prevIndex := next - 1
+	prevTerm, ok := n.storage.term(prevIndex)
+	if !ok {
+		// the entries the follower needs were compacted
+		logger.Debugf("[channel: %s] Sending snapshot at index %d to %s", n.chainID, n.storage.snapshot.Index, to)
+		n.send(to, &ab.RaftStep{Type: &ab.RaftStep_Snapshot{Snapshot: n.storage.snapshot}})
+		n.next[to] = n.storage.snapshot.Index + 1
+		return
+	}
+
+	var entries []*ab.RaftEntry
+	size := 0
+	for _, entry := range n.storage.slice(next, n.storage.lastIndex()) {
+		size += len(entry.Data)
+		if len(entries) > 0 && size > maxAppendBytes {
+			break
+		}
+		entries = append(entries, entry)
+	}
+	n.send(to, &ab.RaftStep{Type: &ab.RaftStep_AppendRequest{AppendRequest: &ab.RaftAppendRequest{
+		PrevIndex: prevIndex,
+		PrevTerm:  prevTerm,
+		Entries:   entries,
+		Commit:    n.commit,
+	}}})
+	// optimistically assume the entries will be appended, a rejection
+	// brings next back
+	n.next[to] = next + uint64(len(entries))
+}
+
+// maybeCommit advances the commit index to the highest index replicated on a
+// quorum of the consenters, provided that it is an entry of the current term
+func (n *node) maybeCommit() {
+	var matches []uint64
+	for _, c := range n.consenters {
+		matches = append(matches, n.match[c])
+	}
+	if len(matches) == 0 {
+		return
+	}
+	sort.Sort(sort.Reverse(uint64Slice(matches)))
+	index := matches[n.quorum()-1]
+	if index <= n.commit {
+		return
+	}
+	if term, ok := n.storage.term(index); !ok || term != n.term {
+		return
+	}
+	n.commit = index
+}
+
+// step processes a message received from another consenter
+func (n *node) step(msg *ab.RaftStep) {
+	if !n.isConsenter(msg.From) {
+		logger.Warningf("[channel: %s] Dropping raft message from %s which is not a consenter", n.chainID, msg.From)
+		return
+	}
+
+	if _, ok := msg.Type.(*ab.RaftStep_VoteRequest); ok && msg.Term > n.term && n.leader != "" && n.electionElapsed < n.electionTick {
+		// the leader is alive, the candidate was cut off from it
+		logger.Debugf("[channel: %s] Ignoring the vote request of %s at term %d, %s is the leader", n.chainID, msg.From, msg.Term, n.leader)
+		return
+	}
+
+	if msg.Term > n.term {
+		leader := ""
+		switch msg.Type.(type) {
+		case *ab.RaftStep_AppendRequest, *ab.RaftStep_Snapshot:
+			leader = msg.From
+		}
+		n.becomeFollower(msg.Term, leader)
+	}
+
+	if msg.Term < n.term {
+		switch msg.Type.(type) {
+		case *ab.RaftStep_AppendRequest, *ab.RaftStep_Snapshot:
+			// let the stale leader know about the new term
+			n.send(msg.From, &ab.RaftStep{Type: &ab.RaftStep_AppendResponse{AppendResponse: &ab.RaftAppendResponse{}}})
+		case *ab.RaftStep_VoteRequest:
+			n.send(msg.From, &ab.RaftStep{Type: &ab.RaftStep_VoteResponse{VoteResponse: &ab.RaftVoteResponse{}}})
+		case *ab.RaftStep_Proposal:
+			n.handleProposal(msg.GetProposal())
+		}
+		return
+	}
+
+	switch t := msg.Type.(type) {
+	case *ab.RaftStep_VoteRequest:
+		n.handleVoteRequest(msg.From, t.VoteRequest)
+	case *ab.RaftStep_VoteResponse:
+		n.handleVoteResponse(msg.From, t.VoteResponse)
+	case *ab.RaftStep_AppendRequest:
+		n.handleAppendRequest(msg.From, t.AppendRequest)
+	case *ab.RaftStep_AppendResponse:
+		n.handleAppendResponse(msg.From, t.AppendResponse)
+	case *ab.RaftStep_Snapshot:
+		n.handleSnapshot(msg.From, t.Snapshot)
+	case *ab.RaftStep_Proposal:
+		n.handleProposal(t.Proposal)
+	}
+}
+
+func (n *node) handleVoteRequest(from string, req *ab.RaftVoteRequest) {
+	upToDate := req.LastTerm > n.lastTerm() || (req.LastTerm == n.lastTerm() && req.LastIndex >= n.storage.lastIndex())
+	granted := n.state == stateFollower && (n.vote == "" || n.vote == from) && upToDate
+	if granted {
+		n.vote = from
+		n.resetElectionTimeout()
+		n.persistHardState()
+	}
+	n.send(from, &ab.RaftStep{Type: &ab.RaftStep_VoteResponse{VoteResponse: &ab.RaftVoteResponse{Granted: granted}}})
+}
+
+func (n *node) handleVoteResponse(from string, resp *ab.RaftVoteResponse) {
+	if n.state != stateCandidate || !resp.Granted {
+		return
+	}
+	n.votes[from] = true
+	if len(n.votes) >= n.quorum() {
+		n.becomeLeader()
+	}
+}
+
+func (n *node) handleAppendRequest(from string, req *ab.RaftAppendRequest) {
+	if n.state != stateFollower || n.leader != from {
+		n.becomeFollower(n.term, from)
+	}
+	n.resetElectionTimeout()
+
+	entries := req.Entries
+	prevIndex, prevTerm := req.PrevIndex, req.PrevTerm
+	// entries up to the snapshot are committed, hence already matched
+	if prevIndex < n.storage.snapshot.Index {
+		for len(entries) > 0 && entries[0].Index <= n.storage.snapshot.Index {
+			entries = entries[1:]
+		}
+		prevIndex, prevTerm = n.storage.snapshot.Index, n.storage.snapshot.Term
+	}
+
+	if term, ok := n.storage.term(prevIndex); !ok || term != prevTerm {
+		hint := n.storage.lastIndex()
+		if prevIndex <= hint {
+			hint = prevIndex - 1
+		}
+		n.send(from, &ab.RaftStep{Type: &ab.RaftStep_AppendResponse{AppendResponse: &ab.RaftAppendResponse{MatchIndex: hint}}})
+		return
+	}
+
+	// skip the entries which are already in the log and append the rest,
+	// starting at the first conflicting entry
+	for i, entry := range entries {
+		if term, ok := n.storage.term(entry.Index); !ok || term != entry.Term {
+			n.appendEntries(entries[i:]...)
+			break
+		}
+	}
+
+	lastNew := prevIndex + uint64(len(entries))
+	if req.Commit > n.commit {
+		n.commit = req.Commit
+		if n.commit > lastNew {
+			n.commit = lastNew
+		}
+	}
+	n.send(from, &ab.RaftStep{Type: &ab.RaftStep_AppendResponse{AppendResponse: &ab.RaftAppendResponse{Success: true, MatchIndex: lastNew}}})
+}
+
+func (n *node) handleAppendResponse(from string, resp *ab.RaftAppendResponse) {
+	if n.state != stateLeader {
+		return
+	}
+	n.active[from] = true
+	if !resp.Success {
+		next := resp.MatchIndex + 1
+		if next < n.next[from] {
+			n.next[from] = next
+		}
+		if n.next[from] <= n.match[from] {
+			n.next[from] = n.match[from] + 1
+		}
+		n.sendAppend(from)
+		return
+	}
+	if resp.MatchIndex > n.match[from] {
+		n.match[from] = resp.MatchIndex
+	}
+	if n.next[from] <= n.match[from] {
+		n.next[from] = n.match[from] + 1
+	}
+	n.maybeCommit()
+	if n.next[from] <= n.storage.lastIndex() {
+		n.sendAppend(from)
+	}
+}
+
+func (n *node) handleSnapshot(from string, snapshot *ab.RaftSnapshot) {
+	if n.state != stateFollower || n.leader != from {
+		n.becomeFollower(n.term, from)
+	}
+	n.resetElectionTimeout()
+
+	if snapshot.Index > n.commit {
+		logger.Infof("[channel: %s] %s is installing the snapshot of %s at index %d", n.chainID, n.id, from, snapshot.Index)
+		if err := n.storage.saveSnapshot(snapshot); err != nil {
+			logger.Panicf("[channel: %s] Could not persist raft snapshot: %s", n.chainID, err)
+		}
+		n.commit = snapshot.Index
+		n.persistHardState()
+		n.pendingSnapshot = snapshot
+	}
+	n.send(from, &ab.RaftStep{Type: &ab.RaftStep_AppendResponse{AppendResponse: &ab.RaftAppendResponse{Success: true, MatchIndex: snapshot.Index}}})
+}
+
+func (n *node) handleProposal(proposal *ab.RaftProposal) {
+	if !n.propose(proposal.Data) {
+		logger.Warningf("[channel: %s] Dropping forwarded proposal, there is no leader", n.chainID)
+	}
+}
+
+type uint64Slice []uint64
+
+func (s uint64Slice) Len() int           { return len(s) }
+func (s uint64Slice) Less(i, j int) bool { return s[i] < s[j] }
+func (s uint64Slice) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
@@ -0,0 +1,338 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package raft
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	ab "github.com/hyperledger/fabric/protos/orderer"
+	"github.com/stretchr/testify/assert"
+)
+
+// testCluster connects nodes through an in memory network, messages from
+// and to the isolated nodes are dropped
+type testCluster struct {
+	t        *testing.T
+	ids      []string
+	nodes    map[string]*node
+	dirs     []string
+	isolated map[string]bool
+}
+
+func newTestCluster(t *testing.T, size int) *testCluster {
+	c := &testCluster{
+		t:        t,
+		nodes:    make(map[string]*node),
+		isolated: make(map[string]bool),
+	}
+	for i := 0; i < size; i++ {
+		c.ids = append(c.ids, fmt.Sprintf("orderer%d:7050", i))
+	}
+	for _, id := range c.ids {
+		s, dir := newTestStorage(t)
+		c.dirs = append(c.dirs, dir)
+		c.nodes[id] = newNode(id, "testchain", c.ids, s, 10, 1)
+	}
+	return c
+}
+
+func (c *testCluster) close() {
+	for _, n := range c.nodes {
+		n.storage.close()
+	}
+	for _, dir := range c.dirs {
+		os.RemoveAll(dir)
+	}
+}
+
+// deliver passes the messages around until there are no more
+func (c *testCluster) deliver() {
+	for {
+		delivered := false
+		for _, id := range c.ids {
+			for _, m := range c.nodes[id].readMessages() {
+				if c.isolated[id] || c.isolated[m.to] {
+					continue
+				}
+				c.nodes[m.to].step(m.msg)
+				delivered = true
+			}
+		}
+		if !delivered {
+			return
+		}
+	}
+}
+
+func (c *testCluster) tick() {
+	for _, id := range c.ids {
+		c.nodes[id].tick()
+	}
+	c.deliver()
+}
+
+// waitForLeader ticks the cluster until the connected nodes agree on a
+// leader other than exclude
+func (c *testCluster) waitForLeader(exclude string) string {
+	for i := 0; i < 1000; i++ {
+		c.tick()
+		leader := ""
+		agreed := true
+		for _, id := range c.ids {
+			if c.isolated[id] {
+				continue
+			}
+			if leader == "" {
+				leader = c.nodes[id].leader
+			}
+			if c.nodes[id].leader != leader {
+				agreed = false
+			}
+		}
+		if agreed && leader != "" && leader != exclude && c.nodes[leader].state == stateLeader {
+			return leader
+		}
+	}
+	c.t.Fatalf("No leader was elected")
+	return ""
+}
+
+func (c *testCluster) committedData(id string) [][]byte {
+	var data [][]byte
+	for _, entry := range c.nodes[id].committedEntries(0) {
+		if len(entry.Data) > 0 {
+			data = append(data, entry.Data)
+		}
+	}
+	return data
+}
+
+func TestNodeElection(t *testing.T) {
+	c := newTestCluster(t, 3)
+	defer c.close()
+
+	leader := c.waitForLeader("")
+	for _, id := range c.ids {
+		if id == leader {
+			assert.Equal(t, stateLeader, c.nodes[id].state)
+		} else {
+			assert.Equal(t, stateFollower, c.nodes[id].state)
+		}
+		assert.Equal(t, c.nodes[leader].term, c.nodes[id].term)
+	}
+}
+
+func TestNodeSingleConsenter(t *testing.T) {
+	c := newTestCluster(t, 1)
+	defer c.close()
+
+	leader := c.waitForLeader("")
+	assert.True(t, c.nodes[leader].propose([]byte("data")))
+	assert.Equal(t, [][]byte{[]byte("data")}, c.committedData(leader))
+}
+
+func TestNodeReplication(t *testing.T) {
+	c := newTestCluster(t, 3)
+	defer c.close()
+
+	leader := c.waitForLeader("")
+	for i := 0; i < 3; i++ {
+		assert.True(t, c.nodes[leader].propose([]byte{byte(i)}))
+	}
+	c.deliver()
+	// followers learn about the commit index with the next heartbeat
+	c.tick()
+
+	for _, id := range c.ids {
+		assert.Equal(t, [][]byte{{0}, {1}, {2}}, c.committedData(id), "Node %s", id)
+	}
+}
+
+func TestNodeForwardProposal(t *testing.T) {
+	c := newTestCluster(t, 3)
+	defer c.close()
+
+	leader := c.waitForLeader("")
+	var follower string
+	for _, id := range c.ids {
+		if id != leader {
+			follower = id
+		}
+	}
+	assert.True(t, c.nodes[follower].propose([]byte("forwarded")))
+	c.deliver()
+	c.tick()
+
+	for _, id := range c.ids {
+		assert.Equal(t, [][]byte{[]byte("forwarded")}, c.committedData(id), "Node %s", id)
+	}
+}
+
+func TestNodeNoLeader(t *testing.T) {
+	c := newTestCluster(t, 3)
+	defer c.close()
+
+	assert.False(t, c.nodes[c.ids[0]].propose([]byte("data")), "Proposal should be rejected without a leader")
+}
+
+func TestNodeLeaderFailure(t *testing.T) {
+	c := newTestCluster(t, 3)
+	defer c.close()
+
+	oldLeader := c.waitForLeader("")
+	assert.True(t, c.nodes[oldLeader].propose([]byte("committed")))
+	c.deliver()
+
+	// the entries proposed to the isolated leader cannot be committed
+	c.isolated[oldLeader] = true
+	assert.True(t, c.nodes[oldLeader].propose([]byte("lost")))
+
+	newLeader := c.waitForLeader(oldLeader)
+	assert.True(t, c.nodes[newLeader].propose([]byte("new")))
+	c.deliver()
+
+	// the old leader stepped down and may have campaigned meanwhile, so
+	// another election may follow its return
+	delete(c.isolated, oldLeader)
+	leader := c.waitForLeader(oldLeader)
+	for i := 0; i < 5; i++ {
+		c.tick()
+	}
+
+	assert.Equal(t, stateFollower, c.nodes[oldLeader].state)
+	assert.Equal(t, leader, c.nodes[oldLeader].leader)
+	for _, id := range c.ids {
+		assert.Equal(t, [][]byte{[]byte("committed"), []byte("new")}, c.committedData(id), "Node %s", id)
+	}
+}
+
+func TestNodeCheckQuorum(t *testing.T) {
+	c := newTestCluster(t, 3)
+	defer c.close()
+
+	leader := c.waitForLeader("")
+	c.isolated[leader] = true
+	for i := 0; i < 2*c.nodes[leader].electionTick; i++ {
+		c.tick()
+	}
+	assert.NotEqual(t, stateLeader, c.nodes[leader].state, "Isolated leader should have stepped down")
+}
+
+func TestNodeVoteLease(t *testing.T) {
+	c := newTestCluster(t, 3)
+	defer c.close()
+
+	leader := c.waitForLeader("")
+	var followers []string
+	for _, id := range c.ids {
+		if id != leader {
+			followers = append(followers, id)
+		}
+	}
+
+	// a consenter which heard from the leader ignores the candidate
+	f := c.nodes[followers[0]]
+	term := f.term
+	f.step(&ab.RaftStep{From: followers[1], Term: term + 5, Type: &ab.RaftStep_VoteRequest{VoteRequest: &ab.RaftVoteRequest{LastIndex: 100, LastTerm: term + 5}}})
+	assert.Equal(t, term, f.term)
+	assert.Equal(t, leader, f.leader)
+	assert.Empty(t, f.readMessages())
+}
+
+func TestNodeRestart(t *testing.T) {
+	c := newTestCluster(t, 3)
+	defer c.close()
+
+	leader := c.waitForLeader("")
+	assert.True(t, c.nodes[leader].propose([]byte("data")))
+	c.deliver()
+	c.tick()
+
+	for _, id := range c.ids {
+		n := c.nodes[id]
+		term, vote := n.term, n.vote
+		c.nodes[id] = newNode(id, "testchain", c.ids, reopen(t, n.storage), 10, 1)
+		assert.Equal(t, term, c.nodes[id].term)
+		assert.Equal(t, vote, c.nodes[id].vote)
+	}
+
+	leader = c.waitForLeader("")
+	assert.True(t, c.nodes[leader].propose([]byte("more")))
+	c.deliver()
+	c.tick()
+	for _, id := range c.ids {
+		assert.Equal(t, [][]byte{[]byte("data"), []byte("more")}, c.committedData(id), "Node %s", id)
+	}
+}
+
+func TestNodeSnapshot(t *testing.T) {
+	c := newTestCluster(t, 3)
+	defer c.close()
+
+	leader := c.waitForLeader("")
+	var lagging string
+	for _, id := range c.ids {
+		if id != leader {
+			lagging = id
+		}
+	}
+
+	c.isolated[lagging] = true
+	for i := 0; i < 5; i++ {
+		assert.True(t, c.nodes[leader].propose([]byte{byte(i)}))
+	}
+	c.deliver()
+	ls := c.nodes[leader].storage
+	term, _ := ls.term(ls.lastIndex())
+	snapshot := &ab.RaftSnapshot{Index: ls.lastIndex(), Term: term, BlockNumber: 5}
+	assert.NoError(t, ls.saveSnapshot(snapshot))
+
+	delete(c.isolated, lagging)
+	c.tick()
+
+	assert.Equal(t, snapshot, c.nodes[lagging].pendingSnapshot)
+	assert.Equal(t, snapshot.Index, c.nodes[lagging].commit)
+	assert.Equal(t, snapshot.Index, c.nodes[lagging].storage.lastIndex())
+
+	assert.True(t, c.nodes[leader].propose([]byte("after")))
+	c.deliver()
+	c.tick()
+	assert.Equal(t, []*ab.RaftEntry{{Term: term, Index: snapshot.Index + 1, Data: []byte("after")}}, c.nodes[lagging].committedEntries(snapshot.Index))
+}
+
+func TestNodeRemovedLeader(t *testing.T) {
+	c := newTestCluster(t, 3)
+	defer c.close()
+
+	leader := c.waitForLeader("")
+	var remaining []string
+	for _, id := range c.ids {
+		if id != leader {
+			remaining = append(remaining, id)
+		}
+	}
+	for _, id := range c.ids {
+		c.nodes[id].setConsenters(remaining)
+	}
+	assert.Equal(t, stateFollower, c.nodes[leader].state, "Removed leader should have stepped down")
+
+	c.ids = remaining
+	newLeader := c.waitForLeader(leader)
+	assert.Contains(t, remaining, newLeader)
+}
@@ -0,0 +1,350 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package raft
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/golang/protobuf/proto"
+	ab "github.com/hyperledger/fabric/protos/orderer"
+)
+
+const (
+	walFileName  = "wal"
+	snapFileName = "snapshot"
+
+	// each record is prefixed by its length and its checksum
+	recordHeaderSize = 8
+)
+
+// storage persists the raft state of a chain: the write ahead log of the
+// entries and hard states, and the last snapshot. The entries which follow
+// the snapshot are also kept in memory.
+type storage struct {
+	walDir  string
+	snapDir string
+	wal     *os.File
+
+	hardState *ab.RaftHardState
+	snapshot  *ab.RaftSnapshot
+	entries   []*ab.RaftEntry
+}
+
+// openStorage loads the state persisted in walDir and snapDir. A record
+// torn by a crash at the end of the log is discarded.
+func openStorage(walDir, snapDir string) (*storage, error) {
+	for _, dir := range []string{walDir, snapDir} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return nil, fmt.Errorf("Error creating directory %s: %s", dir, err)
+		}
+	}
+
+	s := &storage{
+		walDir:    walDir,
+		snapDir:   snapDir,
+		hardState: &ab.RaftHardState{},
+		snapshot:  &ab.RaftSnapshot{},
+	}
+
+	if err := s.loadSnapshot(); err != nil {
+		return nil, err
+	}
+	if err := s.loadWAL(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *storage) loadSnapshot() error {
+	f, err := os.Open(filepath.Join(s.snapDir, snapFileName))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+
+	data, err := readRecord(bufio.NewReader(f), info.Size())
+	if err != nil {
+		return fmt.Errorf("Error reading snapshot: %s", err)
+	}
+	return proto.Unmarshal(data, s.snapshot)
+}
+
+func (s *storage) loadWAL() error {
+	var err error
+	s.wal, err = os.OpenFile(filepath.Join(s.walDir, walFileName), os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return err
+	}
+
+	info, err := s.wal.Stat()
+	if err != nil {
+		return err
+	}
+
+	r := bufio.NewReader(s.wal)
+	var offset int64
+	for {
+		data, err := readRecord(r, info.Size()-offset)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			logger.Warningf("Discarding the tail of the write ahead log in %s at offset %d: %s", s.walDir, offset, err)
+			if err = s.wal.Truncate(offset); err != nil {
+				return err
+			}
+			break
+		}
+		offset += int64(recordHeaderSize + len(data))
+
+		record := &ab.RaftWALRecord{}
+		if err = proto.Unmarshal(data, record); err != nil {
+			return fmt.Errorf("Error unmarshaling record of the write ahead log: %s", err)
+		}
+		switch t := record.Type.(type) {
+		case *ab.RaftWALRecord_Entry:
+			if err = s.appendInMemory(t.Entry); err != nil {
+				return err
+			}
+		case *ab.RaftWALRecord_HardState:
+			s.hardState = t.HardState
+		}
+	}
+
+	_, err = s.wal.Seek(offset, io.SeekStart)
+	return err
+}
+
+// appendInMemory appends an entry to the in memory log, dropping the
+// entries it conflicts with
+func (s *storage) appendInMemory(entry *ab.RaftEntry) error {
+	if entry.Index <= s.snapshot.Index {
+		return nil
+	}
+	if entry.Index > s.lastIndex()+1 {
+		return fmt.Errorf("Missing raft entries before index %d, last index is %d", entry.Index, s.lastIndex())
+	}
+	s.entries = append(s.entries[:entry.Index-s.firstIndex()], entry)
+	return nil
+}
+
+// firstIndex returns the index of the first entry available in the log
+func (s *storage) firstIndex() uint64 {
+	return s.snapshot.Index + 1
+}
+
+// lastIndex returns the index of the last entry of the log
+func (s *storage) lastIndex() uint64 {
+	return s.snapshot.Index + uint64(len(s.entries))
+}
+
+// term returns the term of the entry at index, false is returned when the
+// entry was compacted or does not exist
+func (s *storage) term(index uint64) (uint64, bool) {
+	if index == s.snapshot.Index {
+		return s.snapshot.Term, true
+	}
+	if index < s.firstIndex() || index > s.lastIndex() {
+		return 0, false
+	}
+	return s.entries[index-s.firstIndex()].Term, true
+}
+
+// slice returns a copy of the entries from lo to hi (inclusive) which are
+// available
+func (s *storage) slice(lo, hi uint64) []*ab.RaftEntry {
+	if lo < s.firstIndex() {
+		lo = s.firstIndex()
+	}
+	if hi > s.lastIndex() {
+		hi = s.lastIndex()
+	}
+	if lo > hi {
+		return nil
+	}
+	return append([]*ab.RaftEntry(nil), s.entries[lo-s.firstIndex():hi-s.firstIndex()+1]...)
+}
+
+// append persists entries, which replace the entries of the log they
+// conflict with
+func (s *storage) append(entries ...*ab.RaftEntry) error {
+	for _, entry := range entries {
+		if err := s.appendInMemory(entry); err != nil {
+			return err
+		}
+		if err := s.writeRecord(&ab.RaftWALRecord{Type: &ab.RaftWALRecord_Entry{Entry: entry}}); err != nil {
+			return err
+		}
+	}
+	return s.wal.Sync()
+}
+
+// setHardState persists the hard state
+func (s *storage) setHardState(hardState *ab.RaftHardState) error {
+	if err := s.writeRecord(&ab.RaftWALRecord{Type: &ab.RaftWALRecord_HardState{HardState: hardState}}); err != nil {
+		return err
+	}
+	s.hardState = hardState
+	return s.wal.Sync()
+}
+
+// saveSnapshot persists snapshot and compacts the log. The entries which
+// follow the snapshot are kept only if the log agrees with the snapshot.
+func (s *storage) saveSnapshot(snapshot *ab.RaftSnapshot) error {
+	if snapshot.Index <= s.snapshot.Index {
+		return nil
+	}
+
+	data, err := proto.Marshal(snapshot)
+	if err != nil {
+		return err
+	}
+	if err = writeFileAtomically(filepath.Join(s.snapDir, snapFileName), func(w io.Writer) error {
+		return writeRecordTo(w, data)
+	}); err != nil {
+		return fmt.Errorf("Error writing snapshot: %s", err)
+	}
+
+	var entries []*ab.RaftEntry
+	if term, ok := s.term(snapshot.Index); ok && term == snapshot.Term {
+		entries = s.slice(snapshot.Index+1, s.lastIndex())
+	}
+	s.snapshot = snapshot
+	s.entries = entries
+
+	return s.rewriteWAL()
+}
+
+// rewriteWAL replaces the write ahead log with the current hard state and
+// the entries which follow the snapshot
+func (s *storage) rewriteWAL() error {
+	path := filepath.Join(s.walDir, walFileName)
+	if err := writeFileAtomically(path, func(w io.Writer) error {
+		records := []*ab.RaftWALRecord{{Type: &ab.RaftWALRecord_HardState{HardState: s.hardState}}}
+		for _, entry := range s.entries {
+			records = append(records, &ab.RaftWALRecord{Type: &ab.RaftWALRecord_Entry{Entry: entry}})
+		}
+		for _, record := range records {
+			data, err := proto.Marshal(record)
+			if err != nil {
+				return err
+			}
+			if err = writeRecordTo(w, data); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		return fmt.Errorf("Error compacting the write ahead log: %s", err)
+	}
+
+	s.wal.Close()
+	var err error
+	if s.wal, err = os.OpenFile(path, os.O_RDWR, 0644); err != nil {
+		return err
+	}
+	_, err = s.wal.Seek(0, io.SeekEnd)
+	return err
+}
+
+func (s *storage) close() {
+	s.wal.Close()
+}
+
+func (s *storage) writeRecord(record *ab.RaftWALRecord) error {
+	data, err := proto.Marshal(record)
+	if err != nil {
+		return err
+	}
+	return writeRecordTo(s.wal, data)
+}
+
+func writeRecordTo(w io.Writer, data []byte) error {
+	header := make([]byte, recordHeaderSize)
+	binary.BigEndian.PutUint32(header, uint32(len(data)))
+	binary.BigEndian.PutUint32(header[4:], crc32.ChecksumIEEE(data))
+	if _, err := w.Write(append(header, data...)); err != nil {
+		return err
+	}
+	return nil
+}
+
+// readRecord reads the next record, of which at most remaining bytes are
+// left to read. The length of a torn record is not trusted further than
+// that, the checksum then detects its corruption.
+func readRecord(r io.Reader, remaining int64) ([]byte, error) {
+	header := make([]byte, recordHeaderSize)
+	n, err := io.ReadFull(r, header)
+	if err == io.EOF {
+		return nil, io.EOF
+	}
+	if err != nil {
+		return nil, fmt.Errorf("incomplete record header of %d bytes", n)
+	}
+	length := int64(binary.BigEndian.Uint32(header))
+	if length > remaining-recordHeaderSize {
+		return nil, fmt.Errorf("record of %d bytes exceeds the %d bytes left", length, remaining-recordHeaderSize)
+	}
+	data := make([]byte, length)
+	if _, err = io.ReadFull(r, data); err != nil {
+		return nil, fmt.Errorf("incomplete record of %d bytes", len(data))
+	}
+	if crc32.ChecksumIEEE(data) != binary.BigEndian.Uint32(header[4:]) {
+		return nil, fmt.Errorf("record checksum mismatch")
+	}
+	return data, nil
+}
+
+// writeFileAtomically replaces the file at path by the content written by
+// write once it is synced to disk
+func writeFileAtomically(path string, write func(w io.Writer) error) error {
+	tmp, err := ioutil.TempFile(filepath.Dir(path), filepath.Base(path)+".tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	w := bufio.NewWriter(tmp)
+	if err = write(w); err == nil {
+		err = w.Flush()
+	}
+	if err == nil {
+		err = tmp.Sync()
+	}
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
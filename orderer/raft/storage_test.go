@@ -0,0 +1,155 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package raft
+
+import (
+	"encoding/binary"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	ab "github.com/hyperledger/fabric/protos/orderer"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestStorage(t *testing.T) (*storage, string) {
+	dir, err := ioutil.TempDir("", "raft-storage")
+	if err != nil {
+		t.Fatalf("Error creating temp dir: %s", err)
+	}
+	s, err := openStorage(filepath.Join(dir, "wal"), filepath.Join(dir, "snap"))
+	if err != nil {
+		os.RemoveAll(dir)
+		t.Fatalf("Error opening storage: %s", err)
+	}
+	return s, dir
+}
+
+func reopen(t *testing.T, s *storage) *storage {
+	s.close()
+	s, err := openStorage(s.walDir, s.snapDir)
+	if err != nil {
+		t.Fatalf("Error reopening storage: %s", err)
+	}
+	return s
+}
+
+func testEntries(term uint64, lo, hi uint64) []*ab.RaftEntry {
+	var entries []*ab.RaftEntry
+	for i := lo; i <= hi; i++ {
+		entries = append(entries, &ab.RaftEntry{Term: term, Index: i, Data: []byte{byte(i)}})
+	}
+	return entries
+}
+
+func TestStorageReopen(t *testing.T) {
+	s, dir := newTestStorage(t)
+	defer os.RemoveAll(dir)
+
+	assert.NoError(t, s.append(testEntries(1, 1, 5)...))
+	assert.NoError(t, s.setHardState(&ab.RaftHardState{Term: 1, Vote: "a", Commit: 3}))
+
+	s = reopen(t, s)
+	defer s.close()
+	assert.Equal(t, uint64(5), s.lastIndex())
+	assert.Equal(t, &ab.RaftHardState{Term: 1, Vote: "a", Commit: 3}, s.hardState)
+	assert.Equal(t, testEntries(1, 2, 4), s.slice(2, 4))
+}
+
+func TestStorageConflictingEntries(t *testing.T) {
+	s, dir := newTestStorage(t)
+	defer os.RemoveAll(dir)
+
+	assert.NoError(t, s.append(testEntries(1, 1, 5)...))
+	assert.NoError(t, s.append(testEntries(2, 3, 3)...))
+	assert.Equal(t, uint64(3), s.lastIndex())
+	assert.Error(t, s.append(testEntries(2, 5, 5)...), "Appending past the end of the log should fail")
+
+	s = reopen(t, s)
+	defer s.close()
+	assert.Equal(t, uint64(3), s.lastIndex())
+	term, ok := s.term(3)
+	assert.True(t, ok)
+	assert.Equal(t, uint64(2), term)
+}
+
+func TestStorageTornTail(t *testing.T) {
+	s, dir := newTestStorage(t)
+	defer os.RemoveAll(dir)
+
+	assert.NoError(t, s.append(testEntries(1, 1, 3)...))
+	info, err := s.wal.Stat()
+	assert.NoError(t, err)
+	assert.NoError(t, s.wal.Truncate(info.Size()-1))
+
+	s = reopen(t, s)
+	assert.Equal(t, uint64(2), s.lastIndex(), "The torn entry should have been discarded")
+	assert.NoError(t, s.append(testEntries(1, 3, 4)...))
+
+	s = reopen(t, s)
+	defer s.close()
+	assert.Equal(t, testEntries(1, 1, 4), s.slice(1, 4))
+}
+
+func TestStorageTornLength(t *testing.T) {
+	s, dir := newTestStorage(t)
+	defer os.RemoveAll(dir)
+
+	assert.NoError(t, s.append(testEntries(1, 1, 2)...))
+	info, err := s.wal.Stat()
+	assert.NoError(t, err)
+
+	// the header of the torn record claims more bytes than the file holds
+	header := make([]byte, recordHeaderSize)
+	binary.BigEndian.PutUint32(header, 0xffffffff)
+	_, err = s.wal.Write(append(header, 1, 2, 3))
+	assert.NoError(t, err)
+
+	s = reopen(t, s)
+	defer s.close()
+	assert.Equal(t, uint64(2), s.lastIndex(), "The torn record should have been discarded")
+	truncated, err := s.wal.Stat()
+	assert.NoError(t, err)
+	assert.Equal(t, info.Size(), truncated.Size(), "The torn record should have been truncated")
+}
+
+func TestStorageSnapshot(t *testing.T) {
+	s, dir := newTestStorage(t)
+	defer os.RemoveAll(dir)
+
+	assert.NoError(t, s.append(testEntries(1, 1, 10)...))
+	assert.NoError(t, s.saveSnapshot(&ab.RaftSnapshot{Index: 6, Term: 1, BlockNumber: 2}))
+	assert.Equal(t, uint64(7), s.firstIndex())
+	_, ok := s.term(5)
+	assert.False(t, ok, "Compacted entries should not be available")
+	assert.Equal(t, testEntries(1, 7, 10), s.slice(1, 10))
+
+	s = reopen(t, s)
+	assert.Equal(t, &ab.RaftSnapshot{Index: 6, Term: 1, BlockNumber: 2}, s.snapshot)
+	assert.Equal(t, uint64(10), s.lastIndex())
+	assert.Equal(t, testEntries(1, 7, 10), s.slice(7, 10))
+
+	// a snapshot of another term replaces the whole log
+	assert.NoError(t, s.saveSnapshot(&ab.RaftSnapshot{Index: 8, Term: 2, BlockNumber: 3}))
+	assert.Equal(t, uint64(8), s.lastIndex())
+
+	s = reopen(t, s)
+	defer s.close()
+	assert.Equal(t, uint64(8), s.lastIndex())
+	assert.NoError(t, s.append(testEntries(2, 9, 9)...))
+}
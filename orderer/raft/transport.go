@@ -0,0 +1,143 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package raft
+
+import (
+	"io"
+	"sync"
+	"time"
+
+	cb "github.com/hyperledger/fabric/protos/common"
+	ab "github.com/hyperledger/fabric/protos/orderer"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+)
+
+const (
+	// sendBufferSize is the number of messages queued for a consenter,
+	// further messages are dropped until the queue drains
+	sendBufferSize = 256
+
+	// stepTimeout bounds the delivery of a single raft message
+	stepTimeout = 5 * time.Second
+)
+
+// transport carries the messages of the chains to the other consenters
+type transport interface {
+	// send delivers a raft message to the consenter at the given address.
+	// It does not block, and the message may be lost.
+	send(to string, msg *ab.RaftStep)
+
+	// pull retrieves the blocks requested from the consenter at the given
+	// address, and passes them in order to deliver
+	pull(from string, req *ab.RaftPullRequest, deliver func(block *cb.Block) error) error
+}
+
+type grpcTransport struct {
+	dialOpts []grpc.DialOption
+
+	lock    sync.Mutex
+	remotes map[string]*remote
+}
+
+// remote is the connection to another consenter
+type remote struct {
+	address string
+	client  ab.ClusterClient
+	sendC   chan *ab.RaftStep
+}
+
+func newGRPCTransport(dialOpts ...grpc.DialOption) *grpcTransport {
+	return &grpcTransport{
+		dialOpts: dialOpts,
+		remotes:  make(map[string]*remote),
+	}
+}
+
+func (t *grpcTransport) remote(address string) (*remote, error) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if r, ok := t.remotes[address]; ok {
+		return r, nil
+	}
+
+	// grpc.Dial does not block, the connection is established in the
+	// background and re-established whenever it breaks
+	conn, err := grpc.Dial(address, t.dialOpts...)
+	if err != nil {
+		return nil, err
+	}
+	r := &remote{
+		address: address,
+		client:  ab.NewClusterClient(conn),
+		sendC:   make(chan *ab.RaftStep, sendBufferSize),
+	}
+	go r.sendLoop()
+	t.remotes[address] = r
+	return r, nil
+}
+
+func (t *grpcTransport) send(to string, msg *ab.RaftStep) {
+	r, err := t.remote(to)
+	if err != nil {
+		logger.Warningf("[channel: %s] Could not connect to raft consenter %s: %s", msg.Channel, to, err)
+		return
+	}
+	select {
+	case r.sendC <- msg:
+	default:
+		logger.Debugf("[channel: %s] Dropping raft message to %s, the send buffer is full", msg.Channel, to)
+	}
+}
+
+func (t *grpcTransport) pull(from string, req *ab.RaftPullRequest, deliver func(block *cb.Block) error) error {
+	r, err := t.remote(from)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stream, err := r.client.Pull(ctx, req)
+	if err != nil {
+		return err
+	}
+	for {
+		block, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := deliver(block); err != nil {
+			return err
+		}
+	}
+}
+
+func (r *remote) sendLoop() {
+	for msg := range r.sendC {
+		ctx, cancel := context.WithTimeout(context.Background(), stepTimeout)
+		if _, err := r.client.Step(ctx, msg); err != nil {
+			logger.Debugf("[channel: %s] Could not send raft message to %s: %s", msg.Channel, r.address, err)
+		}
+		cancel()
+	}
+}
@@ -1,144 +0,0 @@
-/*
-Copyright IBM Corp. 2016 All Rights Reserved.
-
-Licensed under the Apache License, Version 2.0 (the "License");
-you may not use this file except in compliance with the License.
-You may obtain a copy of the License at
-
-                 http://www.apache.org/licenses/LICENSE-2.0
-
-Unless required by applicable law or agreed to in writing, software
-distributed under the License is distributed on an "AS IS" BASIS,
-WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
-See the License for the specific language governing permissions and
-limitations under the License.
-*/
-
-package main
-
-import (
-	"flag"
-	"fmt"
-	"math"
-
-	"github.com/hyperledger/fabric/common/configtx/tool/provisional"
-	"github.com/hyperledger/fabric/orderer/localconfig"
-	cb "github.com/hyperledger/fabric/protos/common"
-	ab "github.com/hyperledger/fabric/protos/orderer"
-	"github.com/hyperledger/fabric/protos/utils"
-	"golang.org/x/net/context"
-	"google.golang.org/grpc"
-)
-
-var (
-	oldest  = &ab.SeekPosition{Type: &ab.SeekPosition_Oldest{Oldest: &ab.SeekOldest{}}}
-	newest  = &ab.SeekPosition{Type: &ab.SeekPosition_Newest{Newest: &ab.SeekNewest{}}}
-	maxStop = &ab.SeekPosition{Type: &ab.SeekPosition_Specified{Specified: &ab.SeekSpecified{Number: math.MaxUint64}}}
-)
-
-type deliverClient struct {
-	client  ab.AtomicBroadcast_DeliverClient
-	chainID string
-}
-
-func newDeliverClient(client ab.AtomicBroadcast_DeliverClient, chainID string) *deliverClient {
-	return &deliverClient{client: client, chainID: chainID}
-}
-
-func seekHelper(chainID string, start *ab.SeekPosition, stop *ab.SeekPosition) *cb.Envelope {
-	return &cb.Envelope{
-		Payload: utils.MarshalOrPanic(&cb.Payload{
-			Header: &cb.Header{
-				ChannelHeader: utils.MarshalOrPanic(&cb.ChannelHeader{
-					ChannelId: chainID,
-				}),
-				SignatureHeader: utils.MarshalOrPanic(&cb.SignatureHeader{}),
-			},
-
-			Data: utils.MarshalOrPanic(&ab.SeekInfo{
-				Start:    start,
-				Stop:     stop,
-				Behavior: ab.SeekInfo_BLOCK_UNTIL_READY,
-			}),
-		}),
-	}
-}
-
-func (r *deliverClient) seekOldest() error {
-	return r.client.Send(seekHelper(r.chainID, oldest, maxStop))
-}
-
-func (r *deliverClient) seekNewest() error {
-	return r.client.Send(seekHelper(r.chainID, newest, maxStop))
-}
-
-func (r *deliverClient) seekSingle(blockNumber uint64) error {
-	specific := &ab.SeekPosition{Type: &ab.SeekPosition_Specified{Specified: &ab.SeekSpecified{Number: blockNumber}}}
-	return r.client.Send(seekHelper(r.chainID, specific, specific))
-}
-
-func (r *deliverClient) readUntilClose() {
-	for {
-		msg, err := r.client.Recv()
-		if err != nil {
-			fmt.Println("Error receiving:", err)
-			return
-		}
-
-		switch t := msg.Type.(type) {
-		case *ab.DeliverResponse_Status:
-			fmt.Println("Got status ", t)
-			return
-		case *ab.DeliverResponse_Block:
-			fmt.Println("Received block: ", t.Block)
-		}
-	}
-}
-
-func main() {
-	config := config.Load()
-
-	var chainID string
-	var serverAddr string
-	var seek int
-
-	flag.StringVar(&serverAddr, "server", fmt.Sprintf("%s:%d", config.General.ListenAddress, config.General.ListenPort), "The RPC server to connect to.")
-	flag.StringVar(&chainID, "chainID", provisional.TestChainID, "The chain ID to deliver from.")
-	flag.IntVar(&seek, "seek", -2, "Specify the range of requested blocks."+
-		"Acceptable values:"+
-		"-2 (or -1) to start from oldest (or newest) and keep at it indefinitely."+
-		"N >= 0 to fetch block N only.")
-	flag.Parse()
-
-	if seek < -2 {
-		fmt.Println("Wrong seek value.")
-		flag.PrintDefaults()
-	}
-
-	conn, err := grpc.Dial(serverAddr, grpc.WithInsecure())
-	if err != nil {
-		fmt.Println("Error connecting:", err)
-		return
-	}
-	client, err := ab.NewAtomicBroadcastClient(conn).Deliver(context.TODO())
-	if err != nil {
-		fmt.Println("Error connecting:", err)
-		return
-	}
-
-	s := newDeliverClient(client, chainID)
-	switch seek {
-	case -2:
-		err = s.seekOldest()
-	case -1:
-		err = s.seekNewest()
-	default:
-		err = s.seekSingle(uint64(seek))
-	}
-
-	if err != nil {
-		fmt.Println("Received error:", err)
-	}
-
-	s.readUntilClose()
-}
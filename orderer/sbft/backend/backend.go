@@ -21,7 +21,6 @@ import (
 	"fmt"
 	"io"
 	"sort"
-	"strings"
 	"sync"
 	"time"
 
@@ -36,36 +35,41 @@ import (
 	"crypto"
 	"crypto/rsa"
 	"crypto/sha256"
+	"crypto/x509"
 	"encoding/asn1"
-	"encoding/gob"
+	"encoding/pem"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/msp"
 	"github.com/hyperledger/fabric/orderer/common/filter"
 	commonfilter "github.com/hyperledger/fabric/orderer/common/filter"
+	"github.com/hyperledger/fabric/orderer/ledger"
 	"github.com/hyperledger/fabric/orderer/multichain"
 	"github.com/hyperledger/fabric/orderer/sbft/connection"
+	sbftcrypto "github.com/hyperledger/fabric/orderer/sbft/crypto"
 	"github.com/hyperledger/fabric/orderer/sbft/persist"
 	s "github.com/hyperledger/fabric/orderer/sbft/simplebft"
 	cb "github.com/hyperledger/fabric/protos/common"
+	ab "github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric/protos/utils"
 	"github.com/op/go-logging"
 )
 
-const headerIndex = 0
-const signaturesIndex = 1
-const metadataLen = 2
-
 var logger = logging.MustGetLogger("backend")
 
 type Backend struct {
 	conn        *connection.Manager
 	lock        sync.Mutex
-	peers       map[uint64]chan<- *s.MultiChainMsg
+	peers       map[string]chan<- *s.MultiChainMsg
 	queue       chan Executable
 	persistence *persist.Persist
 
-	self *PeerInfo
-	// address to PeerInfo mapping
+	// fingerprint to PeerInfo mapping, for the replicas of all chains
 	peerInfo map[string]*PeerInfo
+	// chainId to replica set mapping, indexed by replica id
+	replicas    map[string][]*PeerInfo
+	memberships map[string]*ab.SbftMembership
 
 	// chainId to instance mapping
 	consensus   map[string]s.Receiver
@@ -84,65 +88,28 @@ type StackConfig struct {
 
 type PeerInfo struct {
 	info connection.PeerInfo
-	id   uint64
-}
-
-type peerInfoSlice []*PeerInfo
-
-func (pi peerInfoSlice) Len() int {
-	return len(pi)
-}
-
-func (pi peerInfoSlice) Less(i, j int) bool {
-	return strings.Compare(pi[i].info.Fingerprint(), pi[j].info.Fingerprint()) == -1
+	// the serialized orderer identity the replica signs blocks with
+	identity []byte
+	// closed once the replica is not part of any chain anymore
+	done chan struct{}
 }
 
-func (pi peerInfoSlice) Swap(i, j int) {
-	pi[i], pi[j] = pi[j], pi[i]
-}
-
-func NewBackend(peers map[string][]byte, conn *connection.Manager, persist *persist.Persist) (*Backend, error) {
+// NewBackend creates a backend which takes part in the chains whose SBFT
+// replica set contains the certificate of the given connection
+func NewBackend(conn *connection.Manager, persist *persist.Persist) (*Backend, error) {
 	c := &Backend{
 		conn:        conn,
-		peers:       make(map[uint64]chan<- *s.MultiChainMsg),
+		peers:       make(map[string]chan<- *s.MultiChainMsg),
 		peerInfo:    make(map[string]*PeerInfo),
+		replicas:    make(map[string][]*PeerInfo),
+		memberships: make(map[string]*ab.SbftMembership),
 		supports:    make(map[string]multichain.ConsenterSupport),
 		consensus:   make(map[string]s.Receiver),
 		lastBatches: make(map[string]*s.Batch),
 	}
 
-	var peerInfo []*PeerInfo
-	for addr, cert := range peers {
-		pi, err := connection.NewPeerInfo(addr, cert)
-		if err != nil {
-			return nil, err
-		}
-		cpi := &PeerInfo{info: pi}
-		if pi.Fingerprint() == conn.Self.Fingerprint() {
-			c.self = cpi
-		}
-		peerInfo = append(peerInfo, cpi)
-		c.peerInfo[pi.Fingerprint()] = cpi
-	}
-
-	sort.Sort(peerInfoSlice(peerInfo))
-	for i, pi := range peerInfo {
-		pi.id = uint64(i)
-		logger.Infof("replica %d: %s", i, pi.info.Fingerprint())
-	}
-
-	if c.self == nil {
-		return nil, fmt.Errorf("peer list does not contain local node")
-	}
-
-	logger.Infof("we are replica %d (%s)", c.self.id, c.self.info)
+	logger.Infof("we are %s", conn.Self())
 
-	for _, peer := range c.peerInfo {
-		if peer == c.self {
-			continue
-		}
-		go c.connectWorker(peer)
-	}
 	RegisterConsensusServer(conn.Server, (*consensusConn)(c))
 	c.persistence = persist
 	c.queue = make(chan Executable)
@@ -150,13 +117,11 @@ func NewBackend(peers map[string][]byte, conn *connection.Manager, persist *pers
 	return c, nil
 }
 
-// GetMyId returns the ID of the backend in the SFTT network (1..N)
-func (b *Backend) GetMyId() uint64 {
-	return b.self.id
-}
-
 // Enqueue enqueues an Envelope for a chainId for ordering, marshalling it first
 func (b *Backend) Enqueue(chainID string, env *cb.Envelope) bool {
+	if _, ok := b.receiver(chainID); !ok {
+		return false
+	}
 	requestbytes, err := proto.Marshal(env)
 	if err != nil {
 		return false
@@ -171,15 +136,20 @@ func (b *Backend) connectWorker(peer *PeerInfo) {
 	delay := time.After(0)
 	for {
 		// pace reconnect attempts
-		<-delay
+		select {
+		case <-delay:
+		case <-peer.done:
+			logger.Infof("replica %s left, no longer connecting", peer.info)
+			return
+		}
 
 		// set up for next
 		delay = time.After(timeout)
 
-		logger.Infof("connecting to replica %d (%s)", peer.id, peer.info)
+		logger.Infof("connecting to replica %s", peer.info)
 		conn, err := b.conn.DialPeer(peer.info, grpc.WithBlock(), grpc.WithTimeout(timeout))
 		if err != nil {
-			logger.Warningf("could not connect to replica %d (%s): %s", peer.id, peer.info, err)
+			logger.Warningf("could not connect to replica %s: %s", peer.info, err)
 			continue
 		}
 
@@ -188,10 +158,20 @@ func (b *Backend) connectWorker(peer *PeerInfo) {
 		client := NewConsensusClient(conn)
 		consensus, err := client.Consensus(ctx, &Handshake{})
 		if err != nil {
-			logger.Warningf("could not establish consensus stream with replica %d (%s): %s", peer.id, peer.info, err)
+			logger.Warningf("could not establish consensus stream with replica %s: %s", peer.info, err)
 			continue
 		}
-		logger.Noticef("connection to replica %d (%s) established", peer.id, peer.info)
+		logger.Noticef("connection to replica %s established", peer.info)
+
+		// tear down the connection when the replica leaves
+		stop := make(chan struct{})
+		go func() {
+			select {
+			case <-peer.done:
+				conn.Close()
+			case <-stop:
+			}
+		}()
 
 		for {
 			msg, err := consensus.Recv()
@@ -199,17 +179,18 @@ func (b *Backend) connectWorker(peer *PeerInfo) {
 				break
 			}
 			if err != nil {
-				logger.Warningf("consensus stream with replica %d (%s) broke: %v", peer.id, peer.info, err)
+				logger.Warningf("consensus stream with replica %s broke: %v", peer.info, err)
 				break
 			}
-			b.enqueueForReceive(msg.ChainID, msg.Msg, peer.id)
+			b.enqueueForReceive(msg.ChainID, msg.Msg, peer.info.Fingerprint())
 		}
+		close(stop)
 	}
 }
 
-func (b *Backend) enqueueConnection(chainID string, peerid uint64) {
+func (b *Backend) enqueueConnection(chainID string, peer string) {
 	go func() {
-		b.queue <- &connectionEvent{chainID: chainID, peerid: peerid}
+		b.queue <- &connectionEvent{chainID: chainID, peer: peer}
 	}()
 }
 
@@ -219,7 +200,7 @@ func (b *Backend) enqueueRequest(chainID string, request []byte) {
 	}()
 }
 
-func (b *Backend) enqueueForReceive(chainID string, msg *s.Msg, src uint64) {
+func (b *Backend) enqueueForReceive(chainID string, msg *s.Msg, src string) {
 	go func() {
 		b.queue <- &msgEvent{chainId: chainID, msg: msg, src: src}
 	}()
@@ -241,10 +222,185 @@ func (b *Backend) run() {
 	}
 }
 
-// AddSbftPeer adds a new SBFT peer for the given chainId using the given support and configuration
-func (b *Backend) AddSbftPeer(chainID string, support multichain.ConsenterSupport, config *s.Config) (*s.SBFT, error) {
+// AddSbftPeer adds a new SBFT peer for the given chainId using the given support.
+// The replica set is taken from the channel configuration of the chain.
+func (b *Backend) AddSbftPeer(chainID string, support multichain.ConsenterSupport) (*s.SBFT, error) {
 	b.supports[chainID] = support
-	return s.New(b.GetMyId(), chainID, config, b)
+	if err := b.updateReplicas(chainID); err != nil {
+		return nil, err
+	}
+	id, config, _ := b.Membership(chainID)
+	if config == nil {
+		return nil, fmt.Errorf("we are not a replica of chain %s", chainID)
+	}
+	logger.Infof("we are replica %d of chain %s", id, chainID)
+	return s.New(id, chainID, config, b)
+}
+
+// updateReplicas reads the replica set of a chain from its channel
+// configuration, connects to replicas which joined and disconnects
+// from replicas which left all chains
+func (b *Backend) updateReplicas(chainID string) error {
+	membership := b.supports[chainID].SharedConfig().SbftMembership()
+	if membership == nil {
+		return fmt.Errorf("no SBFT replica set configured for chain %s", chainID)
+	}
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if proto.Equal(membership, b.memberships[chainID]) {
+		return nil
+	}
+
+	replicas := make([]*PeerInfo, 0, len(membership.Replicas))
+	for _, r := range membership.Replicas {
+		cert, err := sbftcrypto.DecodeCertPEM(r.Cert)
+		if err != nil {
+			return err
+		}
+		pi, err := connection.NewPeerInfo(r.Address, cert)
+		if err != nil {
+			return err
+		}
+		peer, ok := b.peerInfo[pi.Fingerprint()]
+		if !ok || peer.info.String() != pi.String() || !bytes.Equal(peer.identity, r.Identity) {
+			peer = &PeerInfo{info: pi, identity: r.Identity, done: make(chan struct{})}
+		}
+		replicas = append(replicas, peer)
+	}
+	b.replicas[chainID] = replicas
+	b.memberships[chainID] = membership
+
+	peerInfo := make(map[string]*PeerInfo)
+	for _, replicas := range b.replicas {
+		for _, peer := range replicas {
+			peerInfo[peer.info.Fingerprint()] = peer
+		}
+	}
+	// our own key is rotated by a config carrying our new certificate, which
+	// must already be in our certificate and key files
+	if _, ok := peerInfo[b.conn.Self().Fingerprint()]; !ok {
+		rotated, err := b.conn.Rotate(func(self connection.PeerInfo) bool {
+			_, ok := peerInfo[self.Fingerprint()]
+			return ok
+		})
+		if err != nil {
+			logger.Errorf("Cannot reload our certificate: %s", err)
+		} else if rotated {
+			logger.Infof("Rotated our certificate, we are %s", b.conn.Self())
+		}
+	}
+	for fp, peer := range b.peerInfo {
+		if peerInfo[fp] == peer {
+			continue
+		}
+		logger.Infof("replica %s left", peer.info)
+		close(peer.done)
+		if ch, ok := b.peers[fp]; ok {
+			close(ch)
+			delete(b.peers, fp)
+		}
+	}
+	for fp, peer := range peerInfo {
+		if b.peerInfo[fp] == peer {
+			continue
+		}
+		logger.Infof("replica %s joined", peer.info)
+		if fp != b.conn.Self().Fingerprint() {
+			go b.connectWorker(peer)
+		}
+	}
+	b.peerInfo = peerInfo
+	return nil
+}
+
+// Membership returns our replica id, the configuration and the fingerprints
+// of the replicas of a chain. The configuration is nil if we are not one of
+// the replicas.
+func (b *Backend) Membership(chainID string) (uint64, *s.Config, []string) {
+	b.lock.Lock()
+	replicas := b.replicas[chainID]
+	membership := b.memberships[chainID]
+	b.lock.Unlock()
+
+	var id uint64
+	var member bool
+	fingerprints := make([]string, len(replicas))
+	for i, peer := range replicas {
+		fingerprints[i] = peer.info.Fingerprint()
+		if fingerprints[i] == b.conn.Self().Fingerprint() {
+			id, member = uint64(i), true
+		}
+	}
+	if !member {
+		return 0, nil, fingerprints
+	}
+
+	sc := b.supports[chainID].SharedConfig()
+	config := &s.Config{
+		N:                  uint64(len(replicas)),
+		F:                  membership.F,
+		BatchDurationNsec:  uint64(sc.BatchTimeout()),
+		BatchSizeBytes:     uint64(sc.BatchSize().AbsoluteMaxBytes),
+		RequestTimeoutNsec: membership.RequestTimeoutNsec,
+	}
+	return id, config, fingerprints
+}
+
+// HealthCheck returns an error unless enough replicas of a chain are connected to
+// us for a quorum, we count as connected to ourselves
+func (b *Backend) HealthCheck(chainID string) error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	replicas := b.replicas[chainID]
+	membership := b.memberships[chainID]
+	if membership == nil {
+		return fmt.Errorf("no membership for chain %s", chainID)
+	}
+
+	connected := 0
+	for _, peer := range replicas {
+		fp := peer.info.Fingerprint()
+		if _, ok := b.peers[fp]; ok || fp == b.conn.Self().Fingerprint() {
+			connected++
+		}
+	}
+	if quorum := len(replicas) - int(membership.F); connected < quorum {
+		return fmt.Errorf("%d of the %d replicas of chain %s are connected, a quorum of %d is needed", connected, len(replicas), chainID, quorum)
+	}
+	return nil
+}
+
+// replicaID returns the id of the replica with the given fingerprint in a chain
+func (b *Backend) replicaID(chainID string, fingerprint string) (uint64, bool) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	for i, peer := range b.replicas[chainID] {
+		if peer.info.Fingerprint() == fingerprint {
+			return uint64(i), true
+		}
+	}
+	return 0, false
+}
+
+// replica returns the replica with the given id in a chain
+func (b *Backend) replica(chainID string, id uint64) (*PeerInfo, bool) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	replicas := b.replicas[chainID]
+	if id >= uint64(len(replicas)) {
+		return nil, false
+	}
+	return replicas[id], true
+}
+
+// receiver returns the instance of a chain, unless we are not one of its replicas
+func (b *Backend) receiver(chainID string) (s.Receiver, bool) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	recv, ok := b.consensus[chainID]
+	return recv, ok
 }
 
 func (b *Backend) Validate(chainID string, req *s.Request) ([][]*s.Request, [][]filter.Committer, bool) {
@@ -295,25 +451,37 @@ func toRequestBatch(envelopes []*cb.Envelope) []*s.Request {
 // Consensus implements the SBFT consensus gRPC interface
 func (c *consensusConn) Consensus(_ *Handshake, srv Consensus_ConsensusServer) error {
 	pi := connection.GetPeerInfo(srv)
-	peer, ok := c.peerInfo[pi.Fingerprint()]
+	fp := pi.Fingerprint()
+
+	c.lock.Lock()
+	peer, ok := c.peerInfo[fp]
+	c.lock.Unlock()
 
 	if !ok || !peer.info.Cert().Equal(pi.Cert()) {
 		logger.Infof("rejecting connection from unknown replica %s", pi)
 		return fmt.Errorf("unknown peer certificate")
 	}
-	logger.Infof("connection from replica %d (%s)", peer.id, pi)
+	logger.Infof("connection from replica %s", pi)
 
 	ch := make(chan *s.MultiChainMsg)
+	var chains []string
 	c.lock.Lock()
-	if oldch, ok := c.peers[peer.id]; ok {
-		logger.Debugf("replacing connection from replica %d", peer.id)
+	if oldch, ok := c.peers[fp]; ok {
+		logger.Debugf("replacing connection from replica %s", pi)
 		close(oldch)
 	}
-	c.peers[peer.id] = ch
+	c.peers[fp] = ch
+	for chainID, replicas := range c.replicas {
+		for _, r := range replicas {
+			if r == peer {
+				chains = append(chains, chainID)
+			}
+		}
+	}
 	c.lock.Unlock()
 
-	for chainID, _ := range c.supports {
-		((*Backend)(c)).enqueueConnection(chainID, peer.id)
+	for _, chainID := range chains {
+		((*Backend)(c)).enqueueConnection(chainID, fp)
 	}
 
 	var err error
@@ -321,17 +489,17 @@ func (c *consensusConn) Consensus(_ *Handshake, srv Consensus_ConsensusServer) e
 		err = srv.Send(msg)
 		if err != nil {
 			c.lock.Lock()
-			delete(c.peers, peer.id)
+			delete(c.peers, fp)
 			c.lock.Unlock()
 
-			logger.Infof("lost connection from replica %d (%s): %s", peer.id, pi, err)
+			logger.Infof("lost connection from replica %s: %s", pi, err)
 		}
 	}
 
 	return err
 }
 
-// Unicast sends to all external SBFT peers
+// Broadcast sends to all external SBFT peers
 func (b *Backend) Broadcast(msg *s.MultiChainMsg) error {
 	b.lock.Lock()
 	for _, ch := range b.peers {
@@ -343,12 +511,19 @@ func (b *Backend) Broadcast(msg *s.MultiChainMsg) error {
 
 // Unicast sends to a specific external SBFT peer identified by chainId and dest
 func (b *Backend) Unicast(chainID string, msg *s.Msg, dest uint64) error {
+	peer, ok := b.replica(chainID, dest)
+	if !ok {
+		err := fmt.Errorf("peer not found: %v", dest)
+		logger.Debug(err)
+		return err
+	}
+
 	b.lock.Lock()
-	ch, ok := b.peers[dest]
+	ch, ok := b.peers[peer.info.Fingerprint()]
 	b.lock.Unlock()
 
 	if !ok {
-		err := fmt.Errorf("peer not found: %v", dest)
+		err := fmt.Errorf("peer not connected: %v", dest)
 		logger.Debug(err)
 		return err
 	}
@@ -358,14 +533,23 @@ func (b *Backend) Unicast(chainID string, msg *s.Msg, dest uint64) error {
 
 // AddReceiver adds a receiver instance for a given chainId
 func (b *Backend) AddReceiver(chainId string, recv s.Receiver) {
+	b.lock.Lock()
 	b.consensus[chainId] = recv
+	b.lock.Unlock()
+	// resume after the last batch written to the ledger
+	if support, ok := b.supports[chainId]; ok {
+		if batch := b.GetBatch(chainId, support.Reader().Height()-1); batch != nil {
+			b.lastBatches[chainId] = batch
+			return
+		}
+	}
 	b.lastBatches[chainId] = &s.Batch{Header: nil, Signatures: nil, Payloads: [][]byte{}}
 }
 
 // Send sends to a specific SBFT peer identified by chainId and dest
 func (b *Backend) Send(chainID string, msg *s.Msg, dest uint64) {
-	if dest == b.self.id {
-		b.enqueueForReceive(chainID, msg, b.self.id)
+	if peer, ok := b.replica(chainID, dest); ok && peer.info.Fingerprint() == b.conn.Self().Fingerprint() {
+		b.enqueueForReceive(chainID, msg, peer.info.Fingerprint())
 		return
 	}
 	b.Unicast(chainID, msg, dest)
@@ -378,8 +562,53 @@ func (b *Backend) Timer(d time.Duration, tf func()) s.Canceller {
 	return tm
 }
 
+// BatchTimer starts a timer, which expires with the timer of the pending
+// batch of the block cutter of the chain
+func (b *Backend) BatchTimer(chainId string, tf func()) s.Canceller {
+	tm := &batchTimer{Timer: Timer{tf: tf, execute: true}, stop: make(chan struct{})}
+	expired := b.supports[chainId].BlockCutter().Timer().C()
+	go func() {
+		select {
+		case <-expired:
+			b.queue <- tm
+		case <-tm.stop:
+		}
+	}()
+	return tm
+}
+
 // Deliver writes a block
 func (b *Backend) Deliver(chainId string, batch *s.Batch, committers []commonfilter.Committer) {
+	block := b.nextBlock(chainId, batch)
+
+	// The replicas which agreed on the batch sign the block, so that
+	// peers can check it against the BlockValidation policy.
+	if sigs := blockSignatures(batch); len(sigs.Signatures) > 0 {
+		block.Metadata.Metadata[cb.BlockMetadataIndex_SIGNATURES] = utils.MarshalOrPanic(sigs)
+	}
+
+	// The batch header and signatures are kept in the orderer metadata of the
+	// block, from which the batch is read back for state transfer.
+	b.lastBatches[chainId] = batch
+	b.supports[chainId].WriteBlock(block, committers, utils.MarshalOrPanic(&s.Batch{Header: batch.Header, Signatures: batch.Signatures, BlockSignatures: batch.BlockSignatures}))
+
+	// a config transaction in the block may have changed the replica set,
+	// which orders the batches from the next one on
+	if err := b.updateReplicas(chainId); err != nil {
+		logger.Errorf("Cannot update the replica set of chain %s: %s", chainId, err)
+		return
+	}
+	if _, config, _ := b.Membership(chainId); config == nil {
+		logger.Warningf("We are no longer a replica of chain %s", chainId)
+		b.lock.Lock()
+		delete(b.consensus, chainId)
+		b.lock.Unlock()
+	}
+}
+
+// nextBlock returns the block a batch is written as following the last
+// block of the ledger
+func (b *Backend) nextBlock(chainId string, batch *s.Batch) *cb.Block {
 	blockContents := make([]*cb.Envelope, 0, len(batch.Payloads))
 	for _, p := range batch.Payloads {
 		envelope := &cb.Envelope{}
@@ -390,19 +619,35 @@ func (b *Backend) Deliver(chainId string, batch *s.Batch, committers []commonfil
 			logger.Warningf("Payload cannot be unmarshalled.")
 		}
 	}
-	block := b.supports[chainId].CreateNextBlock(blockContents)
+	return b.supports[chainId].CreateNextBlock(blockContents)
+}
+
+// blockSignatures returns the block signatures of a batch ordered by replica id
+func blockSignatures(batch *s.Batch) *cb.Metadata {
+	var ids []uint64
+	for id := range batch.BlockSignatures {
+		ids = append(ids, id)
+	}
+	sort.Sort(uint64Slice(ids))
 
-	// TODO SBFT needs to use Rawledger's structures and signatures over the Block.
-	// This a quick and dirty solution to make it work.
-	block.Metadata = &cb.BlockMetadata{}
-	metadata := make([][]byte, metadataLen)
-	metadata[headerIndex] = batch.Header
-	metadata[signaturesIndex] = encodeSignatures(batch.Signatures)
-	block.Metadata.Metadata = metadata
-	b.lastBatches[chainId] = batch
-	b.supports[chainId].WriteBlock(block, committers, nil)
+	metadata := &cb.Metadata{}
+	for _, id := range ids {
+		sig := &cb.MetadataSignature{}
+		if err := proto.Unmarshal(batch.BlockSignatures[id], sig); err != nil {
+			logger.Warningf("Block signature of replica %d cannot be unmarshalled: %s", id, err)
+			continue
+		}
+		metadata.Signatures = append(metadata.Signatures, sig)
+	}
+	return metadata
 }
 
+type uint64Slice []uint64
+
+func (p uint64Slice) Len() int           { return len(p) }
+func (p uint64Slice) Less(i, j int) bool { return p[i] < p[j] }
+func (p uint64Slice) Swap(i, j int)      { p[i], p[j] = p[j], p[i] }
+
 // Persist persists data identified by a chainId and a key
 func (b *Backend) Persist(chainId string, key string, data proto.Message) {
 	compk := fmt.Sprintf("chain-%s-%s", chainId, key)
@@ -433,23 +678,118 @@ func (b *Backend) LastBatch(chainId string) *s.Batch {
 	return b.lastBatches[chainId]
 }
 
+// GetBatch returns the batch with the given sequence number as read back from
+// the ledger, or nil if it is not available
+func (b *Backend) GetBatch(chainId string, seq uint64) *s.Batch {
+	support, ok := b.supports[chainId]
+	if !ok {
+		return nil
+	}
+	block := ledger.GetBlock(support.Reader(), seq)
+	if block == nil {
+		return nil
+	}
+	metadata, err := utils.GetMetadataFromBlock(block, cb.BlockMetadataIndex_ORDERER)
+	if err != nil || len(metadata.Value) == 0 {
+		// the genesis block, or a block not written by SBFT
+		return nil
+	}
+	batch := &s.Batch{}
+	if err := proto.Unmarshal(metadata.Value, batch); err != nil {
+		logger.Warningf("Batch metadata of block %d cannot be unmarshalled: %s", seq, err)
+		return nil
+	}
+	batch.Payloads = block.Data.Data
+	return batch
+}
+
 // Sign signs a given data
 func (b *Backend) Sign(data []byte) []byte {
-	return Sign(b.conn.Cert.PrivateKey, data)
+	return Sign(b.conn.Cert().PrivateKey, data)
 }
 
-// CheckSig checks a signature
-func (b *Backend) CheckSig(data []byte, src uint64, sig []byte) error {
-	leaf := b.conn.Cert.Leaf
-	if leaf == nil {
-		panic("No public key found: certificate leaf is nil.")
+// CheckSig checks a signature of a replica of a chain against its current certificate
+func (b *Backend) CheckSig(chainId string, data []byte, src uint64, sig []byte) error {
+	peer, ok := b.replica(chainId, src)
+	if !ok {
+		return fmt.Errorf("unknown replica %d", src)
+	}
+	return CheckSig(peer.info.Cert().PublicKey, data, sig)
+}
+
+// SignBlock signs the block a batch is written as with our orderer identity,
+// in the format of the SIGNATURES block metadata
+func (b *Backend) SignBlock(chainId string, batch *s.Batch) []byte {
+	block := b.nextBlock(chainId, batch)
+	if block.Header.Number != batch.DecodeHeader().Seq {
+		// we are lagging behind and cannot tell the block
+		return nil
+	}
+
+	support := b.supports[chainId]
+	shdr, err := support.NewSignatureHeader()
+	if err != nil {
+		logger.Errorf("Cannot create signature header for block %d: %s", block.Header.Number, err)
+		return nil
+	}
+	sig := &cb.MetadataSignature{SignatureHeader: utils.MarshalOrPanic(shdr)}
+	sig.Signature, err = support.Sign(util.ConcatenateBytes(sig.SignatureHeader, block.Header.Bytes()))
+	if err != nil {
+		logger.Errorf("Cannot sign block %d: %s", block.Header.Number, err)
+		return nil
+	}
+	return utils.MarshalOrPanic(sig)
+}
+
+// CheckBlockSig checks that a block signature of a replica is made with the
+// orderer identity configured for the replica over the block a batch is
+// written as
+func (b *Backend) CheckBlockSig(chainId string, batch *s.Batch, src uint64, sig []byte) error {
+	peer, ok := b.replica(chainId, src)
+	if !ok {
+		return fmt.Errorf("unknown replica %d", src)
 	}
-	return CheckSig(leaf.PublicKey, data, sig)
+	block := b.nextBlock(chainId, batch)
+	if block.Header.Number != batch.DecodeHeader().Seq {
+		return fmt.Errorf("batch %d does not follow the last block %d", batch.DecodeHeader().Seq, block.Header.Number-1)
+	}
+	return checkBlockSig(block, peer.identity, sig)
+}
+
+func checkBlockSig(block *cb.Block, identity []byte, sig []byte) error {
+	msig := &cb.MetadataSignature{}
+	if err := proto.Unmarshal(sig, msig); err != nil {
+		return err
+	}
+	shdr, err := utils.GetSignatureHeader(msig.SignatureHeader)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(shdr.Creator, identity) {
+		return fmt.Errorf("block signed by another identity than the configured one")
+	}
+
+	sid := &msp.SerializedIdentity{}
+	if err := proto.Unmarshal(identity, sid); err != nil {
+		return err
+	}
+	// the MSP serializes certificates as untyped PEM blocks
+	pemBlock, _ := pem.Decode(sid.IdBytes)
+	if pemBlock == nil {
+		return fmt.Errorf("could not decode the certificate of identity %s", sid.Mspid)
+	}
+	cert, err := x509.ParseCertificate(pemBlock.Bytes)
+	if err != nil {
+		return err
+	}
+	return CheckSig(cert.PublicKey, util.ConcatenateBytes(msig.SignatureHeader, block.Header.Bytes()), msig.Signature)
 }
 
 // Reconnect requests connection to a replica identified by its ID and chainId
 func (b *Backend) Reconnect(chainId string, replica uint64) {
-	b.enqueueConnection(chainId, replica)
+	if peer, ok := b.replica(chainId, replica); ok {
+		b.enqueueConnection(chainId, peer.info.Fingerprint())
+	}
 }
 
 // Sign signs a given data
@@ -503,27 +843,3 @@ func CheckSig(publicKey crypto.PublicKey, data []byte, sig []byte) error {
 		return fmt.Errorf("Unsupported public key type.")
 	}
 }
-
-func encodeSignatures(signatures map[uint64][]byte) []byte {
-	var buf bytes.Buffer
-	enc := gob.NewEncoder(&buf)
-	err := enc.Encode(signatures)
-	if err != nil {
-		panic(err)
-	}
-	return buf.Bytes()
-}
-
-func decodeSignatures(encodedSignatures []byte) map[uint64][]byte {
-	if len(encodedSignatures) == 0 {
-		return nil
-	}
-	buf := bytes.NewBuffer(encodedSignatures)
-	var r map[uint64][]byte
-	dec := gob.NewDecoder(buf)
-	err := dec.Decode(&r)
-	if err != nil {
-		panic(err)
-	}
-	return r
-}
@@ -21,15 +21,33 @@ import (
 	"crypto/elliptic"
 	crand "crypto/rand"
 	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/configtx/tool/provisional"
+	mockconfig "github.com/hyperledger/fabric/common/mocks/configvalues/channel/orderer"
+	"github.com/hyperledger/fabric/common/util"
+	fabricmsp "github.com/hyperledger/fabric/msp"
 	"github.com/hyperledger/fabric/orderer/common/filter"
+	"github.com/hyperledger/fabric/orderer/ledger"
+	ramledger "github.com/hyperledger/fabric/orderer/ledger/ram"
+	mockblockcutter "github.com/hyperledger/fabric/orderer/mocks/blockcutter"
 	"github.com/hyperledger/fabric/orderer/mocks/multichain"
 	mc "github.com/hyperledger/fabric/orderer/multichain"
+	"github.com/hyperledger/fabric/orderer/sbft/connection"
 	"github.com/hyperledger/fabric/orderer/sbft/simplebft"
 	cb "github.com/hyperledger/fabric/protos/common"
+	ab "github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric/protos/utils"
 )
 
 func TestSignAndVerifyRsa(t *testing.T) {
@@ -74,9 +92,9 @@ func TestLedgerReadWrite(t *testing.T) {
 	testChainID3 := "testID2"
 	b := Backend{supports: map[string]mc.ConsenterSupport{}, lastBatches: map[string]*simplebft.Batch{}}
 
-	b.supports[testChainID1] = &multichain.ConsenterSupport{Batches: make(chan []*cb.Envelope, 10)}
-	b.supports[testChainID2] = &multichain.ConsenterSupport{Batches: make(chan []*cb.Envelope, 10)}
-	b.supports[testChainID3] = &multichain.ConsenterSupport{Batches: make(chan []*cb.Envelope, 10)}
+	b.supports[testChainID1] = &multichain.ConsenterSupport{Batches: make(chan []*cb.Envelope, 10), SharedConfigVal: &mockconfig.SharedConfig{}}
+	b.supports[testChainID2] = &multichain.ConsenterSupport{Batches: make(chan []*cb.Envelope, 10), SharedConfigVal: &mockconfig.SharedConfig{}}
+	b.supports[testChainID3] = &multichain.ConsenterSupport{Batches: make(chan []*cb.Envelope, 10), SharedConfigVal: &mockconfig.SharedConfig{}}
 
 	header := []byte("header")
 	e1 := &cb.Envelope{Payload: []byte("data1")}
@@ -106,36 +124,298 @@ func TestLedgerReadWrite(t *testing.T) {
 	}
 }
 
-func TestEncoderEncodesDecodesSgnsWithoutPanic(t *testing.T) {
-	defer func() {
-		if r := recover(); r != nil {
-			t.Errorf("Encoding/decoding failed for valid signatures, code panicked.")
-		}
-	}()
-	sgns1 := make(map[uint64][]byte)
-	e1 := encodeSignatures(sgns1)
-
-	sgns2 := make(map[uint64][]byte)
-	sgns2[uint64(1)] = []byte("sgn1")
-	e2 := encodeSignatures(sgns2)
-
-	sgns3 := make(map[uint64][]byte)
-	sgns3[uint64(22)] = []byte("sgn22")
-	sgns3[uint64(143)] = []byte("sgn22")
-	sgns3[uint64(200)] = []byte("sgn200")
-	e3 := encodeSignatures(sgns3)
-
-	rsgns1 := decodeSignatures(e1)
-	rsgns2 := decodeSignatures(e2)
-	rsgns3 := decodeSignatures(e3)
-
-	if !reflect.DeepEqual(sgns1, rsgns1) {
-		t.Errorf("Decoding error: %v (original: %v). (1)", rsgns1, sgns1)
-	}
-	if !reflect.DeepEqual(sgns2, rsgns2) {
-		t.Errorf("Decoding error: %v (original: %v). (2)", rsgns2, sgns2)
-	}
-	if !reflect.DeepEqual(sgns3, rsgns3) {
-		t.Errorf("Decoding error: %v (original: %v). (3)", rsgns3, sgns3)
+func TestGetBatchFromLedger(t *testing.T) {
+	rl, _ := ramledger.New(10).GetOrCreate(provisional.TestChainID)
+	genesis := ledger.CreateNextBlock(rl, []*cb.Envelope{{Payload: []byte("genesis")}})
+	rl.Append(genesis)
+	b := Backend{supports: map[string]mc.ConsenterSupport{}, lastBatches: map[string]*simplebft.Batch{}}
+	b.supports[provisional.TestChainID] = &multichain.ConsenterSupport{ReaderVal: rl}
+
+	if batch := b.GetBatch(provisional.TestChainID, 0); batch != nil {
+		t.Errorf("Expected no batch for the genesis block, got %v", batch)
+	}
+
+	block := ledger.CreateNextBlock(rl, []*cb.Envelope{{Payload: []byte("data1")}})
+	batch := &simplebft.Batch{Header: []byte("header"), Payloads: block.Data.Data, Signatures: map[uint64][]byte{1: []byte("sgn1")}}
+	block.Metadata.Metadata[cb.BlockMetadataIndex_ORDERER] = utils.MarshalOrPanic(&cb.Metadata{
+		Value: utils.MarshalOrPanic(&simplebft.Batch{Header: batch.Header, Signatures: batch.Signatures}),
+	})
+	rl.Append(block)
+
+	if read := b.GetBatch(provisional.TestChainID, 1); !reflect.DeepEqual(batch, read) {
+		t.Errorf("The wrong batch was read back from the ledger: %v (original was: %v)", read, batch)
+	}
+	if read := b.GetBatch(provisional.TestChainID, 2); read != nil {
+		t.Errorf("Expected no batch beyond the ledger height, got %v", read)
+	}
+	if read := b.GetBatch("unknown", 1); read != nil {
+		t.Errorf("Expected no batch for an unknown chain, got %v", read)
+	}
+}
+
+func newTestReplica(t *testing.T, id int64) (*ab.SbftReplica, *ecdsa.PrivateKey) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), crand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(id),
+		Subject:      pkix.Name{Organization: []string{"Acme Co"}},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(crand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	return &ab.SbftReplica{Address: "127.0.0.1:1", Cert: cert}, key
+}
+
+func TestMembershipFromChannelConfig(t *testing.T) {
+	conn, err := connection.New("127.0.0.1:0", "../testdata/cert1.pem", "../testdata/key.pem")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Server.Stop()
+	b, err := NewBackend(conn, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	selfCert, err := ioutil.ReadFile("../testdata/cert1.pem")
+	if err != nil {
+		t.Fatal(err)
+	}
+	self := &ab.SbftReplica{Address: "127.0.0.1:6101", Cert: selfCert}
+	other, key := newTestReplica(t, 1)
+	sc := &mockconfig.SharedConfig{
+		BatchTimeoutVal:   time.Second,
+		BatchSizeVal:      &ab.BatchSize{AbsoluteMaxBytes: 1000},
+		SbftMembershipVal: &ab.SbftMembership{F: 0, RequestTimeoutNsec: 1000, Replicas: []*ab.SbftReplica{self, other}},
+	}
+	b.supports[provisional.TestChainID] = &multichain.ConsenterSupport{SharedConfigVal: sc}
+
+	if err := b.updateReplicas(provisional.TestChainID); err != nil {
+		t.Fatal(err)
+	}
+	id, config, replicas := b.Membership(provisional.TestChainID)
+	if id != 0 || config == nil || len(replicas) != 2 {
+		t.Fatalf("Expected to be replica 0 of 2, got %d of %v", id, replicas)
+	}
+	expected := simplebft.Config{N: 2, F: 0, BatchDurationNsec: uint64(time.Second), BatchSizeBytes: 1000, RequestTimeoutNsec: 1000}
+	if *config != expected {
+		t.Errorf("Expected configuration %v, got %v", expected, config)
+	}
+
+	data := []byte{1, 2, 3}
+	if err := b.CheckSig(provisional.TestChainID, data, 1, Sign(key, data)); err != nil {
+		t.Errorf("Signature of replica 1 was rejected: %s", err)
+	}
+
+	// replica 1 rotates its key
+	oldPeer := b.peerInfo[replicas[1]]
+	rotated, newKey := newTestReplica(t, 2)
+	sc.SbftMembershipVal = &ab.SbftMembership{F: 0, RequestTimeoutNsec: 1000, Replicas: []*ab.SbftReplica{self, rotated}}
+	if err := b.updateReplicas(provisional.TestChainID); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.CheckSig(provisional.TestChainID, data, 1, Sign(key, data)); err == nil {
+		t.Error("Signature with the replaced key of replica 1 was accepted")
+	}
+	if err := b.CheckSig(provisional.TestChainID, data, 1, Sign(newKey, data)); err != nil {
+		t.Errorf("Signature with the new key of replica 1 was rejected: %s", err)
+	}
+	select {
+	case <-oldPeer.done:
+	default:
+		t.Error("Expected the connection to the replaced replica to be torn down")
+	}
+
+	// we leave the replica set
+	sc.SbftMembershipVal = &ab.SbftMembership{F: 0, RequestTimeoutNsec: 1000, Replicas: []*ab.SbftReplica{rotated}}
+	if err := b.updateReplicas(provisional.TestChainID); err != nil {
+		t.Fatal(err)
+	}
+	if _, config, _ := b.Membership(provisional.TestChainID); config != nil {
+		t.Errorf("Expected no configuration after leaving the replica set, got %v", config)
+	}
+}
+
+// writeKeyPair writes the certificate of a replica and its key to the
+// files a connection manager loads them from
+func writeKeyPair(t *testing.T, replica *ab.SbftReplica, key *ecdsa.PrivateKey, certFile, keyFile string) {
+	der, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(certFile, replica.Cert, 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der}), 0600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLocalKeyRotation(t *testing.T) {
+	dir, err := ioutil.TempDir("", "sbft-rotation")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	certFile, keyFile := filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem")
+
+	self, key := newTestReplica(t, 1)
+	writeKeyPair(t, self, key, certFile, keyFile)
+	conn, err := connection.New("127.0.0.1:0", certFile, keyFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Server.Stop()
+	b, err := NewBackend(conn, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	other, _ := newTestReplica(t, 2)
+	sc := &mockconfig.SharedConfig{
+		BatchTimeoutVal:   time.Second,
+		BatchSizeVal:      &ab.BatchSize{AbsoluteMaxBytes: 1000},
+		SbftMembershipVal: &ab.SbftMembership{F: 0, RequestTimeoutNsec: 1000, Replicas: []*ab.SbftReplica{self, other}},
+	}
+	b.supports[provisional.TestChainID] = &multichain.ConsenterSupport{SharedConfigVal: sc}
+	if err := b.updateReplicas(provisional.TestChainID); err != nil {
+		t.Fatal(err)
+	}
+
+	// we rotate our key: the new key pair is put in place, then the
+	// config carrying our new certificate is committed
+	rotated, newKey := newTestReplica(t, 3)
+	writeKeyPair(t, rotated, newKey, certFile, keyFile)
+	sc.SbftMembershipVal = &ab.SbftMembership{F: 0, RequestTimeoutNsec: 1000, Replicas: []*ab.SbftReplica{rotated, other}}
+	if err := b.updateReplicas(provisional.TestChainID); err != nil {
+		t.Fatal(err)
+	}
+
+	id, config, replicas := b.Membership(provisional.TestChainID)
+	if id != 0 || config == nil {
+		t.Fatalf("Expected to remain replica 0 after rotating our key, got %d of %v", id, replicas)
+	}
+	if conn.Self().Fingerprint() != replicas[0] {
+		t.Errorf("Expected to present the rotated certificate %s, got %s", replicas[0], conn.Self().Fingerprint())
+	}
+	data := []byte{1, 2, 3}
+	if err := b.CheckSig(provisional.TestChainID, data, 0, b.Sign(data)); err != nil {
+		t.Errorf("Our signature with the rotated key was rejected: %s", err)
+	}
+}
+
+func TestHealthCheck(t *testing.T) {
+	conn, err := connection.New("127.0.0.1:0", "../testdata/cert1.pem", "../testdata/key.pem")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Server.Stop()
+	b, err := NewBackend(conn, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := b.HealthCheck(provisional.TestChainID); err == nil {
+		t.Error("Expected the health check of an unknown chain to fail")
+	}
+
+	selfCert, err := ioutil.ReadFile("../testdata/cert1.pem")
+	if err != nil {
+		t.Fatal(err)
+	}
+	self := &ab.SbftReplica{Address: "127.0.0.1:6101", Cert: selfCert}
+	other, _ := newTestReplica(t, 1)
+	sc := &mockconfig.SharedConfig{
+		BatchTimeoutVal:   time.Second,
+		BatchSizeVal:      &ab.BatchSize{AbsoluteMaxBytes: 1000},
+		SbftMembershipVal: &ab.SbftMembership{F: 0, RequestTimeoutNsec: 1000, Replicas: []*ab.SbftReplica{self, other}},
+	}
+	b.supports[provisional.TestChainID] = &multichain.ConsenterSupport{SharedConfigVal: sc}
+	if err := b.updateReplicas(provisional.TestChainID); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := b.HealthCheck(provisional.TestChainID); err == nil {
+		t.Error("Expected the health check to fail without a quorum of connected replicas")
+	}
+
+	_, _, replicas := b.Membership(provisional.TestChainID)
+	b.lock.Lock()
+	b.peers[replicas[1]] = make(chan *simplebft.MultiChainMsg)
+	b.lock.Unlock()
+	if err := b.HealthCheck(provisional.TestChainID); err != nil {
+		t.Errorf("Expected the health check to pass with all replicas connected, got %s", err)
+	}
+}
+
+func TestCheckBlockSig(t *testing.T) {
+	replica, key := newTestReplica(t, 1)
+	block, _ := pem.Decode(replica.Cert)
+	identity := utils.MarshalOrPanic(&fabricmsp.SerializedIdentity{
+		Mspid:   "SampleOrg",
+		IdBytes: pem.EncodeToMemory(&pem.Block{Bytes: block.Bytes}),
+	})
+	other, _ := newTestReplica(t, 2)
+	otherIdentity := utils.MarshalOrPanic(&fabricmsp.SerializedIdentity{Mspid: "SampleOrg", IdBytes: other.Cert})
+
+	blk := cb.NewBlock(1, []byte("prev"))
+	sign := func(creator []byte, b *cb.Block) []byte {
+		sigHeader := utils.MarshalOrPanic(&cb.SignatureHeader{Creator: creator, Nonce: []byte{1}})
+		return utils.MarshalOrPanic(&cb.MetadataSignature{
+			SignatureHeader: sigHeader,
+			Signature:       Sign(key, util.ConcatenateBytes(sigHeader, b.Header.Bytes())),
+		})
+	}
+
+	if err := checkBlockSig(blk, identity, sign(identity, blk)); err != nil {
+		t.Errorf("Valid block signature was rejected: %s", err)
+	}
+	if err := checkBlockSig(blk, otherIdentity, sign(identity, blk)); err == nil {
+		t.Error("Block signature by another identity than the configured one was accepted")
+	}
+	if err := checkBlockSig(blk, identity, sign(identity, cb.NewBlock(2, []byte("prev")))); err == nil {
+		t.Error("Signature of another block was accepted")
+	}
+	if err := checkBlockSig(blk, identity, []byte("garbage")); err == nil {
+		t.Error("Garbage block signature was accepted")
+	}
+}
+
+func TestBatchTimer(t *testing.T) {
+	cutter := mockblockcutter.NewReceiver()
+	cutter.BatchTimeout = time.Millisecond
+	close(cutter.Block)
+	b := &Backend{
+		queue:    make(chan Executable),
+		supports: map[string]mc.ConsenterSupport{provisional.TestChainID: &multichain.ConsenterSupport{BlockCutterVal: cutter}},
+	}
+
+	cutter.Ordered(&cb.Envelope{Payload: []byte("pending")})
+	expired := false
+	b.BatchTimer(provisional.TestChainID, func() { expired = true })
+	select {
+	case e := <-b.queue:
+		e.Execute(b)
+	case <-time.After(time.Second):
+		t.Fatal("Expected the batch timer to expire with the timer of the block cutter")
+	}
+	if !expired {
+		t.Error("Expected the batch timer function to be called")
+	}
+
+	// a cancelled timer is not scheduled
+	cutter.Cut()
+	cutter.Ordered(&cb.Envelope{Payload: []byte("pending")})
+	b.BatchTimer(provisional.TestChainID, func() { t.Error("Expected the cancelled timer not to be called") }).Cancel()
+	select {
+	case <-b.queue:
+		t.Fatal("Expected the cancelled timer not to be scheduled")
+	case <-time.After(10 * time.Millisecond):
 	}
 }
@@ -29,6 +29,20 @@ func (t *Timer) Cancel() {
 	t.execute = false
 }
 
+// batchTimer is a Timer which is scheduled by the expiry of the timer of
+// the pending batch of a block cutter
+type batchTimer struct {
+	Timer
+	stop chan struct{}
+}
+
+func (t *batchTimer) Cancel() {
+	if t.execute {
+		t.execute = false
+		close(t.stop)
+	}
+}
+
 type Executable interface {
 	Execute(*Backend)
 }
@@ -42,11 +56,21 @@ func (t *Timer) Execute(backend *Backend) {
 type msgEvent struct {
 	chainId string
 	msg     *s.Msg
-	src     uint64
+	// fingerprint of the sender
+	src string
 }
 
 func (m *msgEvent) Execute(backend *Backend) {
-	backend.consensus[m.chainId].Receive(m.msg, m.src)
+	recv, ok := backend.receiver(m.chainId)
+	if !ok {
+		return
+	}
+	src, ok := backend.replicaID(m.chainId, m.src)
+	if !ok {
+		logger.Debugf("dropping message from %.6s, which is not a replica of chain %s", m.src, m.chainId)
+		return
+	}
+	recv.Receive(m.msg, src)
 }
 
 type requestEvent struct {
@@ -55,14 +79,23 @@ type requestEvent struct {
 }
 
 func (r *requestEvent) Execute(backend *Backend) {
-	backend.consensus[r.chainId].Request(r.req)
+	if recv, ok := backend.receiver(r.chainId); ok {
+		recv.Request(r.req)
+	}
 }
 
 type connectionEvent struct {
 	chainID string
-	peerid  uint64
+	// fingerprint of the peer
+	peer string
 }
 
 func (c *connectionEvent) Execute(backend *Backend) {
-	backend.consensus[c.chainID].Connection(c.peerid)
+	recv, ok := backend.receiver(c.chainID)
+	if !ok {
+		return
+	}
+	if id, ok := backend.replicaID(c.chainID, c.peer); ok {
+		recv.Connection(id)
+	}
 }
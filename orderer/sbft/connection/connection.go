@@ -22,6 +22,7 @@ import (
 	"crypto/x509"
 	"fmt"
 	"net"
+	"sync"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
@@ -35,31 +36,33 @@ type PeerInfo struct {
 	cp   *x509.CertPool
 }
 
+// Manager serves and dials the connections between replicas. Its
+// certificate may be rotated, the new one is presented from then on.
 type Manager struct {
 	Server    *grpc.Server
 	Listener  net.Listener
-	Self      PeerInfo
 	tlsConfig *tls.Config
-	Cert      *tls.Certificate
+
+	certFile string
+	keyFile  string
+
+	lock sync.RWMutex
+	self PeerInfo
+	cert *tls.Certificate
 }
 
 func New(addr string, certFile string, keyFile string) (_ *Manager, err error) {
-	c := &Manager{}
+	c := &Manager{certFile: certFile, keyFile: keyFile}
 
-	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
-	if err != nil {
-		return nil, err
-	}
-	cert.Leaf, err = x509.ParseCertificate(cert.Certificate[0])
+	c.cert, c.self, err = loadCert(certFile, keyFile)
 	if err != nil {
 		return nil, err
 	}
 
-	c.Cert = &cert
-	c.Self, err = NewPeerInfo("", cert.Certificate[0])
-
 	c.tlsConfig = &tls.Config{
-		Certificates:       []tls.Certificate{cert},
+		GetCertificate: func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+			return c.Cert(), nil
+		},
 		ClientAuth:         tls.RequestClientCert,
 		InsecureSkipVerify: true,
 	}
@@ -76,8 +79,56 @@ func New(addr string, certFile string, keyFile string) (_ *Manager, err error) {
 	return c, nil
 }
 
+func loadCert(certFile string, keyFile string) (*tls.Certificate, PeerInfo, error) {
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, PeerInfo{}, err
+	}
+	cert.Leaf, err = x509.ParseCertificate(cert.Certificate[0])
+	if err != nil {
+		return nil, PeerInfo{}, err
+	}
+	self, err := NewPeerInfo("", cert.Certificate[0])
+	if err != nil {
+		return nil, PeerInfo{}, err
+	}
+	return &cert, self, nil
+}
+
+// Self returns the peer info of our current certificate
+func (c *Manager) Self() PeerInfo {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	return c.self
+}
+
+// Cert returns our current certificate and its private key
+func (c *Manager) Cert() *tls.Certificate {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	return c.cert
+}
+
+// Rotate reloads the certificate and key files and switches to the
+// certificate they hold if accept approves it. It returns whether the
+// certificate changed.
+func (c *Manager) Rotate(accept func(PeerInfo) bool) (bool, error) {
+	cert, self, err := loadCert(c.certFile, c.keyFile)
+	if err != nil {
+		return false, err
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if self.Fingerprint() == c.self.Fingerprint() || !accept(self) {
+		return false, nil
+	}
+	c.cert, c.self = cert, self
+	return true, nil
+}
+
 func (c *Manager) DialPeer(peer PeerInfo, opts ...grpc.DialOption) (*grpc.ClientConn, error) {
-	return dialPeer(&c.tlsConfig.Certificates[0], peer, opts...)
+	return dialPeer(c.Cert(), peer, opts...)
 }
 
 // to check client: credentials.FromContext() -> AuthInfo
@@ -149,7 +200,7 @@ func NewPeerInfo(addr string, cert []byte) (_ PeerInfo, err error) {
 	return p, nil
 }
 
-func (pi *PeerInfo) Fingerprint() string {
+func (pi PeerInfo) Fingerprint() string {
 	return fmt.Sprintf("%x", sha256.Sum256(pi.cert.Raw))
 }
 
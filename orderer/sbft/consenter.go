@@ -35,7 +35,6 @@ var logger = logging.MustGetLogger("orderer/main")
 
 // Consenter interface implementation for new main application
 type consenter struct {
-	config          *ConsensusConfig
 	consensusStack  *consensusStack
 	sbftStackConfig *backend.StackConfig
 	sbftPeers       map[string]*simplebft.SBFT
@@ -49,8 +48,9 @@ type chain struct {
 // New creates a new consenter for the SBFT consensus scheme.
 // It accepts messages being delivered via Enqueue, orders them, and then uses the blockcutter to form the messages
 // into blocks before writing to the given ledger.
-func New(c *ConsensusConfig, sc *backend.StackConfig) multichain.Consenter {
-	return &consenter{config: c, sbftStackConfig: sc}
+// The replicas of each chain are taken from the SBFT replica set in its channel configuration.
+func New(sc *backend.StackConfig) multichain.Consenter {
+	return &consenter{sbftStackConfig: sc}
 }
 
 func (sbft *consenter) HandleChain(support multichain.ConsenterSupport, metadata *cb.Metadata) (multichain.Chain, error) {
@@ -80,7 +80,7 @@ func createConsensusStack(sbft *consenter) *consensusStack {
 		panic(err)
 	}
 	persist := persist.New(sbft.sbftStackConfig.DataDir)
-	backend, err := backend.NewBackend(sbft.config.Peers, conn, persist)
+	backend, err := backend.NewBackend(conn, persist)
 	if err != nil {
 		logger.Errorf("Backend instantiation error.")
 		panic(err)
@@ -92,10 +92,11 @@ func createConsensusStack(sbft *consenter) *consensusStack {
 }
 
 func initSbftPeer(chainID string, sbft *consenter, support multichain.ConsenterSupport) *simplebft.SBFT {
-	sbftPeer, err := sbft.consensusStack.backend.AddSbftPeer(support.ChainID(), support, sbft.config.Consensus)
+	sbftPeer, err := sbft.consensusStack.backend.AddSbftPeer(support.ChainID(), support)
 	if err != nil {
-		logger.Errorf("SBFT peer instantiation error.")
-		panic(err)
+		// the backend rejects the messages of chains we do not order
+		logger.Warningf("Not ordering chain %s: %s", chainID, err)
+		return nil
 	}
 	return sbftPeer
 }
@@ -114,6 +115,12 @@ func (ch *chain) Halt() {
 	panic("There is no way to halt SBFT")
 }
 
+// HealthCheck returns an error unless enough replicas of the chain are connected for a quorum.
+// It implements the multichain.HealthChecker interface.
+func (ch *chain) HealthCheck() error {
+	return ch.consensusStack.backend.HealthCheck(ch.chainID)
+}
+
 // Enqueue accepts a message and returns true on acceptance, or false on shutdown
 func (ch *chain) Enqueue(env *cb.Envelope) bool {
 	return ch.consensusStack.backend.Enqueue(ch.chainID, env)
@@ -27,7 +27,12 @@ func ParseCertPEM(certFile string) ([]byte, error) {
 	if err != nil {
 		return nil, err
 	}
+	return DecodeCertPEM(certBytes)
+}
 
+// DecodeCertPEM returns the DER bytes of the first certificate in the given
+// PEM encoded data
+func DecodeCertPEM(certBytes []byte) ([]byte, error) {
 	var b *pem.Block
 	for {
 		b, certBytes = pem.Decode(certBytes)
@@ -66,7 +66,7 @@ func (s *SBFT) checkBatch(b *Batch, checkData bool, needSigs bool) (*BatchHeader
 
 	bh := b.Hash()
 	for r, sig := range b.Signatures {
-		err = s.sys.CheckSig(bh, r, sig)
+		err = s.sys.CheckSig(s.chainId, bh, r, sig)
 		if err != nil {
 			return nil, err
 		}
@@ -24,9 +24,10 @@ import (
 func (s *SBFT) makeCheckpoint() *Checkpoint {
 	sig := s.sys.Sign(s.cur.subject.Digest)
 	c := &Checkpoint{
-		Seq:       s.cur.subject.Seq.Seq,
-		Digest:    s.cur.subject.Digest,
-		Signature: sig,
+		Seq:            s.cur.subject.Seq.Seq,
+		Digest:         s.cur.subject.Digest,
+		Signature:      sig,
+		BlockSignature: s.sys.SignBlock(s.chainId, s.cur.preprep.Batch),
 	}
 	return c
 }
@@ -56,6 +57,14 @@ func (s *SBFT) handleCheckpoint(c *Checkpoint, src uint64) {
 		log.Infof("replica %d: checkpoint does not match expected subject %v, got %v", s.id, &s.cur.subject, c)
 		return
 	}
+	// a replica lagging behind cannot sign the block, its checkpoint
+	// still counts towards the weak checkpoint
+	if c.BlockSignature != nil {
+		if err := s.sys.CheckBlockSig(s.chainId, s.cur.preprep.Batch, src, c.BlockSignature); err != nil {
+			log.Warningf("replica %d: checkpoint block signature invalid for %d from %d: %s", s.id, c.Seq, src, err)
+			return
+		}
+	}
 	if _, ok := s.cur.checkpoint[src]; ok {
 		log.Infof("replica %d: duplicate checkpoint for %d from %d", s.id, c.Seq, src)
 	}
@@ -79,12 +88,6 @@ func (s *SBFT) handleCheckpoint(c *Checkpoint, src uint64) {
 
 	// got a weak checkpoint
 
-	cpset := make(map[uint64][]byte)
-	for _, r := range replicas {
-		cp := s.cur.checkpoint[r]
-		cpset[r] = cp.Signature
-	}
-
 	c = s.cur.checkpoint[replicas[0]]
 
 	if !reflect.DeepEqual(c.Digest, s.cur.subject.Digest) {
@@ -94,9 +97,33 @@ func (s *SBFT) handleCheckpoint(c *Checkpoint, src uint64) {
 		return
 	}
 
+	if s.cur.subject.Seq.Seq != s.seq()+1 {
+		// we missed batches, the signers of the checkpoint have them
+		log.Infof("replica %d: weak checkpoint for %d while our last batch is %d", s.id, c.Seq, s.seq())
+		s.fetchBatches(c.Seq, replicas...)
+		return
+	}
+
+	// the block is only delivered with the signatures of a common case
+	// quorum, which the BlockValidation policy asks of peers
+	cpset := make(map[uint64][]byte)
+	blocksigs := make(map[uint64][]byte)
+	for _, r := range replicas {
+		cp := s.cur.checkpoint[r]
+		cpset[r] = cp.Signature
+		if cp.BlockSignature != nil {
+			blocksigs[r] = cp.BlockSignature
+		}
+	}
+	if len(blocksigs) < s.commonCaseQuorum() {
+		log.Debugf("replica %d: weak checkpoint for %d has %d block signatures, waiting for %d", s.id, c.Seq, len(blocksigs), s.commonCaseQuorum())
+		return
+	}
+
 	// ignore null requests
 	batch := *s.cur.preprep.Batch
 	batch.Signatures = cpset
+	batch.BlockSignatures = blocksigs
 	s.deliverBatch(&batch, s.cur.committers)
 	log.Infof("replica %d: request %s %s delivered on %d (completed common case)", s.id, s.cur.subject.Seq, hash2str(s.cur.subject.Digest), s.id)
 	s.maybeSendNextBatch()
@@ -91,5 +91,5 @@ func (s *SBFT) checkSig(sig *Signed, signer uint64, msg proto.Message) error {
 }
 
 func (s *SBFT) checkBytesSig(digest []byte, signer uint64, sig []byte) error {
-	return s.sys.CheckSig(digest, signer, sig)
+	return s.sys.CheckSig(s.chainId, digest, signer, sig)
 }
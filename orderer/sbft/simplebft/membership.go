@@ -0,0 +1,67 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simplebft
+
+import "reflect"
+
+// maybeReconfigure switches to the replica set configured by the batches
+// delivered so far.  It is called after delivering a batch only, so that
+// all correct replicas order the next batch with the same replica set.
+func (s *SBFT) maybeReconfigure() {
+	id, config, replicas := s.sys.Membership(s.chainId)
+	if config == nil {
+		return
+	}
+	if id == s.id && reflect.DeepEqual(*config, s.config) && reflect.DeepEqual(replicas, s.replicas) {
+		return
+	}
+	if config.F*3+1 > config.N || uint64(len(replicas)) != config.N {
+		log.Errorf("replica %d: ignoring invalid configuration of %d replicas (f=%d)", s.id, len(replicas), config.F)
+		return
+	}
+
+	log.Noticef("replica %d: switching to %d replicas (f=%d) after batch %d, we are replica %d", s.id, config.N, config.F, s.seq(), id)
+
+	// the state of the remaining replicas, including the messages they
+	// sent for the next batch, moves along with their identity
+	remap := make(map[uint64]uint64)
+	for oldID, replica := range s.replicas {
+		for newID, r := range replicas {
+			if r == replica && oldID < len(s.replicaState) {
+				remap[uint64(oldID)] = uint64(newID)
+			}
+		}
+	}
+	state := make([]replicaInfo, config.N)
+	for oldID, newID := range remap {
+		state[newID] = s.replicaState[oldID]
+	}
+	if s.transfer != nil {
+		var sources []uint64
+		for _, src := range s.transfer.sources {
+			if newID, ok := remap[src]; ok {
+				sources = append(sources, newID)
+			}
+		}
+		s.transfer.sources = sources
+	}
+
+	s.id = id
+	s.config = *config
+	s.replicas = replicas
+	s.replicaState = state
+}
@@ -147,24 +147,20 @@ func (s *SBFT) handleNewView(nv *NewView, src uint64) {
 	s.discardBacklog(s.primaryID())
 
 	// maybe deliver previous batches
-	if s.sys.LastBatch(s.chainId).DecodeHeader().Seq < prevBatch.DecodeHeader().Seq {
-		if prevBatch.DecodeHeader().Seq == s.cur.subject.Seq.Seq {
+	prevSeq := prevBatch.DecodeHeader().Seq
+	if s.seq() < prevSeq {
+		if prevSeq == s.seq()+1 && prevSeq == s.cur.subject.Seq.Seq && s.cur.preprep != nil &&
+			reflect.DeepEqual(prevBatch.DecodeHeader().DataHash, s.cur.preprep.Batch.DecodeHeader().DataHash) {
 			// we just received a signature set for a request which we preprepared, but never delivered.
-			// check first if the locally preprepared request matches the signature set
-			if !reflect.DeepEqual(prevBatch.DecodeHeader().DataHash, s.cur.preprep.Batch.DecodeHeader().DataHash) {
-				log.Warningf("replica %d: [seq %d] request checkpointed in a previous view does not match locally preprepared one, delivering batches without payload", s.id, s.cur.subject.Seq.Seq)
-			} else {
-				log.Debugf("replica %d: [seq %d] request checkpointed in a previous view with matching preprepare, completing and delivering the batches with payload", s.id, s.cur.subject.Seq.Seq)
-				prevBatch.Payloads = s.cur.preprep.Batch.Payloads
-			}
-		}
-		// TODO we should not do this here, as prevBatch was already delivered
-		blockOK, committers := s.getCommittersFromBatch(prevBatch)
-		if !blockOK {
-			log.Panic("Replica %d: our last checkpointed batch is erroneous (block cutter).", s.id)
+			log.Debugf("replica %d: [seq %d] request checkpointed in a previous view with matching preprepare, completing and delivering the batches with payload", s.id, s.cur.subject.Seq.Seq)
+			prevBatch.Payloads = s.cur.preprep.Batch.Payloads
+			s.deliverBatch(prevBatch, s.cur.committers)
+		} else {
+			// the batches we miss, up to the checkpointed one, are fetched
+			// from the replicas which delivered it
+			log.Infof("replica %d: [seq %d] request checkpointed in a previous view is missing, fetching batches from %d", s.id, prevSeq, s.seq()+1)
+			s.fetchBatches(prevSeq, s.checkpointHolders(nv, prevSeq)...)
 		}
-		// TODO what should we do with the remaining?
-		s.deliverBatch(prevBatch, committers)
 	}
 
 	// after a new-view message, prepare to accept new requests.
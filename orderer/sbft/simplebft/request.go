@@ -16,10 +16,6 @@ limitations under the License.
 
 package simplebft
 
-import (
-	"time"
-)
-
 // Request proposes a new request to the BFT network.
 func (s *SBFT) Request(req []byte) {
 	log.Debugf("replica %d: broadcasting a request", s.id)
@@ -52,7 +48,7 @@ func (s *SBFT) handleRequest(req *Request, src uint64) {
 
 func (s *SBFT) startBatchTimer() {
 	if s.batchTimer == nil {
-		s.batchTimer = s.sys.Timer(time.Duration(s.config.BatchDurationNsec), s.cutAndMaybeSend)
+		s.batchTimer = s.sys.BatchTimer(s.chainId, s.cutAndMaybeSend)
 	}
 }
 
@@ -88,6 +84,11 @@ func (s *SBFT) maybeSendNextBatch() {
 		return
 	}
 
+	// we cannot assign sequence numbers before we caught up
+	if s.transfer != nil {
+		return
+	}
+
 	if len(s.batches) == 0 {
 		hasPending := len(s.pending) != 0
 		for k, req := range s.pending {
@@ -44,16 +44,28 @@ type Receiver interface {
 type System interface {
 	Send(chainId string, msg *Msg, dest uint64)
 	Timer(d time.Duration, f func()) Canceller
+	// BatchTimer calls f once the pending requests passed to Validate
+	// have waited for the batch timeout.
+	BatchTimer(chainId string, f func()) Canceller
 	Deliver(chainId string, batch *Batch, committers []filter.Committer)
 	AddReceiver(chainId string, receiver Receiver)
 	Persist(chainId string, key string, data proto.Message)
 	Restore(chainId string, key string, out proto.Message) bool
 	LastBatch(chainId string) *Batch
+	GetBatch(chainId string, seq uint64) *Batch
 	Sign(data []byte) []byte
-	CheckSig(data []byte, src uint64, sig []byte) error
+	CheckSig(chainId string, data []byte, src uint64, sig []byte) error
+	// SignBlock returns our signature of the block the batch is written
+	// as, or nil if we cannot tell the block yet.
+	SignBlock(chainId string, batch *Batch) []byte
+	CheckBlockSig(chainId string, batch *Batch, src uint64, sig []byte) error
 	Reconnect(chainId string, replica uint64)
 	Validate(chainID string, req *Request) ([][]*Request, [][]filter.Committer, bool)
 	Cut(chainID string) ([]*Request, []filter.Committer)
+	// Membership returns our id, the configuration and the identities of
+	// the replicas which order the batches following the last delivered
+	// one. The configuration is nil if we are not one of them.
+	Membership(chainId string) (uint64, *Config, []string)
 }
 
 // Canceller allows cancelling of a scheduled timer event.
@@ -67,6 +79,7 @@ type SBFT struct {
 
 	config            Config
 	id                uint64
+	replicas          []string
 	view              uint64
 	batches           [][]*Request
 	batchTimer        Canceller
@@ -80,6 +93,7 @@ type SBFT struct {
 	validated         map[string]bool
 	chainId           string
 	primarycommitters [][]filter.Committer
+	transfer          *stateTransfer
 }
 
 type reqInfo struct {
@@ -127,6 +141,7 @@ func New(id uint64, chainID string, config *Config, sys System) (*SBFT, error) {
 		primarycommitters: make([][]filter.Committer, 0),
 	}
 	s.sys.AddReceiver(chainID, s)
+	_, _, s.replicas = s.sys.Membership(chainID)
 
 	s.view = 0
 	s.cur.subject.Seq = &SeqView{}
@@ -244,6 +259,12 @@ func (s *SBFT) Receive(m *Msg, src uint64) {
 	} else if nv := m.GetNewView(); nv != nil {
 		s.handleNewView(nv, src)
 		return
+	} else if fb := m.GetFetchBatches(); fb != nil {
+		s.handleFetchBatches(fb, src)
+		return
+	} else if fb := m.GetFetchedBatches(); fb != nil {
+		s.handleFetchedBatches(fb, src)
+		return
 	}
 
 	if s.testBacklogMessage(m, src) {
@@ -290,4 +311,6 @@ func (s *SBFT) deliverBatch(batch *Batch, committers []filter.Committer) {
 		delete(s.pending, key)
 		delete(s.validated, key)
 	}
+
+	s.maybeReconfigure()
 }
@@ -23,6 +23,8 @@ It has these top-level messages:
 	NewView
 	Checkpoint
 	Hello
+	FetchBatches
+	FetchedBatches
 */
 package simplebft
 
@@ -81,6 +83,8 @@ type Msg struct {
 	//	*Msg_NewView
 	//	*Msg_Checkpoint
 	//	*Msg_Hello
+	//	*Msg_FetchBatches
+	//	*Msg_FetchedBatches
 	Type isMsg_Type `protobuf_oneof:"type"`
 }
 
@@ -89,9 +93,7 @@ func (m *Msg) String() string            { return proto.CompactTextString(m) }
 func (*Msg) ProtoMessage()               {}
 func (*Msg) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{2} }
 
-type isMsg_Type interface {
-	isMsg_Type()
-}
+type isMsg_Type interface{ isMsg_Type() }
 
 type Msg_Request struct {
 	Request *Request `protobuf:"bytes,1,opt,name=request,oneof"`
@@ -117,15 +119,23 @@ type Msg_Checkpoint struct {
 type Msg_Hello struct {
 	Hello *Hello `protobuf:"bytes,8,opt,name=hello,oneof"`
 }
+type Msg_FetchBatches struct {
+	FetchBatches *FetchBatches `protobuf:"bytes,9,opt,name=fetch_batches,json=fetchBatches,oneof"`
+}
+type Msg_FetchedBatches struct {
+	FetchedBatches *FetchedBatches `protobuf:"bytes,10,opt,name=fetched_batches,json=fetchedBatches,oneof"`
+}
 
-func (*Msg_Request) isMsg_Type()    {}
-func (*Msg_Preprepare) isMsg_Type() {}
-func (*Msg_Prepare) isMsg_Type()    {}
-func (*Msg_Commit) isMsg_Type()     {}
-func (*Msg_ViewChange) isMsg_Type() {}
-func (*Msg_NewView) isMsg_Type()    {}
-func (*Msg_Checkpoint) isMsg_Type() {}
-func (*Msg_Hello) isMsg_Type()      {}
+func (*Msg_Request) isMsg_Type()        {}
+func (*Msg_Preprepare) isMsg_Type()     {}
+func (*Msg_Prepare) isMsg_Type()        {}
+func (*Msg_Commit) isMsg_Type()         {}
+func (*Msg_ViewChange) isMsg_Type()     {}
+func (*Msg_NewView) isMsg_Type()        {}
+func (*Msg_Checkpoint) isMsg_Type()     {}
+func (*Msg_Hello) isMsg_Type()          {}
+func (*Msg_FetchBatches) isMsg_Type()   {}
+func (*Msg_FetchedBatches) isMsg_Type() {}
 
 func (m *Msg) GetType() isMsg_Type {
 	if m != nil {
@@ -190,6 +200,20 @@ func (m *Msg) GetHello() *Hello {
 	return nil
 }
 
+func (m *Msg) GetFetchBatches() *FetchBatches {
+	if x, ok := m.GetType().(*Msg_FetchBatches); ok {
+		return x.FetchBatches
+	}
+	return nil
+}
+
+func (m *Msg) GetFetchedBatches() *FetchedBatches {
+	if x, ok := m.GetType().(*Msg_FetchedBatches); ok {
+		return x.FetchedBatches
+	}
+	return nil
+}
+
 // XXX_OneofFuncs is for the internal use of the proto package.
 func (*Msg) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
 	return _Msg_OneofMarshaler, _Msg_OneofUnmarshaler, _Msg_OneofSizer, []interface{}{
@@ -201,6 +225,8 @@ func (*Msg) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, fu
 		(*Msg_NewView)(nil),
 		(*Msg_Checkpoint)(nil),
 		(*Msg_Hello)(nil),
+		(*Msg_FetchBatches)(nil),
+		(*Msg_FetchedBatches)(nil),
 	}
 }
 
@@ -248,6 +274,16 @@ func _Msg_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
 		if err := b.EncodeMessage(x.Hello); err != nil {
 			return err
 		}
+	case *Msg_FetchBatches:
+		b.EncodeVarint(9<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.FetchBatches); err != nil {
+			return err
+		}
+	case *Msg_FetchedBatches:
+		b.EncodeVarint(10<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.FetchedBatches); err != nil {
+			return err
+		}
 	case nil:
 	default:
 		return fmt.Errorf("Msg.Type has unexpected type %T", x)
@@ -322,6 +358,22 @@ func _Msg_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto.Buffer) (b
 		err := b.DecodeMessage(msg)
 		m.Type = &Msg_Hello{msg}
 		return true, err
+	case 9: // type.fetch_batches
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(FetchBatches)
+		err := b.DecodeMessage(msg)
+		m.Type = &Msg_FetchBatches{msg}
+		return true, err
+	case 10: // type.fetched_batches
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(FetchedBatches)
+		err := b.DecodeMessage(msg)
+		m.Type = &Msg_FetchedBatches{msg}
+		return true, err
 	default:
 		return false, nil
 	}
@@ -371,6 +423,16 @@ func _Msg_OneofSizer(msg proto.Message) (n int) {
 		n += proto.SizeVarint(8<<3 | proto.WireBytes)
 		n += proto.SizeVarint(uint64(s))
 		n += s
+	case *Msg_FetchBatches:
+		s := proto.Size(x.FetchBatches)
+		n += proto.SizeVarint(9<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Msg_FetchedBatches:
+		s := proto.Size(x.FetchedBatches)
+		n += proto.SizeVarint(10<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
 	case nil:
 	default:
 		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
@@ -409,9 +471,10 @@ func (*BatchHeader) ProtoMessage()               {}
 func (*BatchHeader) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
 
 type Batch struct {
-	Header     []byte            `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
-	Payloads   [][]byte          `protobuf:"bytes,2,rep,name=payloads,proto3" json:"payloads,omitempty"`
-	Signatures map[uint64][]byte `protobuf:"bytes,3,rep,name=signatures" json:"signatures,omitempty" protobuf_key:"varint,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Header          []byte            `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	Payloads        [][]byte          `protobuf:"bytes,2,rep,name=payloads,proto3" json:"payloads,omitempty"`
+	Signatures      map[uint64][]byte `protobuf:"bytes,3,rep,name=signatures" json:"signatures,omitempty" protobuf_key:"varint,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	BlockSignatures map[uint64][]byte `protobuf:"bytes,4,rep,name=block_signatures,json=blockSignatures" json:"block_signatures,omitempty" protobuf_key:"varint,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value,proto3"`
 }
 
 func (m *Batch) Reset()                    { *m = Batch{} }
@@ -426,6 +489,13 @@ func (m *Batch) GetSignatures() map[uint64][]byte {
 	return nil
 }
 
+func (m *Batch) GetBlockSignatures() map[uint64][]byte {
+	if m != nil {
+		return m.BlockSignatures
+	}
+	return nil
+}
+
 type Preprepare struct {
 	Seq   *SeqView `protobuf:"bytes,1,opt,name=seq" json:"seq,omitempty"`
 	Batch *Batch   `protobuf:"bytes,2,opt,name=batch" json:"batch,omitempty"`
@@ -544,9 +614,10 @@ func (m *NewView) GetBatch() *Batch {
 }
 
 type Checkpoint struct {
-	Seq       uint64 `protobuf:"varint,1,opt,name=seq" json:"seq,omitempty"`
-	Digest    []byte `protobuf:"bytes,2,opt,name=digest,proto3" json:"digest,omitempty"`
-	Signature []byte `protobuf:"bytes,3,opt,name=signature,proto3" json:"signature,omitempty"`
+	Seq            uint64 `protobuf:"varint,1,opt,name=seq" json:"seq,omitempty"`
+	Digest         []byte `protobuf:"bytes,2,opt,name=digest,proto3" json:"digest,omitempty"`
+	Signature      []byte `protobuf:"bytes,3,opt,name=signature,proto3" json:"signature,omitempty"`
+	BlockSignature []byte `protobuf:"bytes,4,opt,name=block_signature,json=blockSignature,proto3" json:"block_signature,omitempty"`
 }
 
 func (m *Checkpoint) Reset()                    { *m = Checkpoint{} }
@@ -578,6 +649,32 @@ func (m *Hello) GetNewView() *NewView {
 	return nil
 }
 
+type FetchBatches struct {
+	FromSeq uint64 `protobuf:"varint,1,opt,name=from_seq,json=fromSeq" json:"from_seq,omitempty"`
+	ToSeq   uint64 `protobuf:"varint,2,opt,name=to_seq,json=toSeq" json:"to_seq,omitempty"`
+}
+
+func (m *FetchBatches) Reset()                    { *m = FetchBatches{} }
+func (m *FetchBatches) String() string            { return proto.CompactTextString(m) }
+func (*FetchBatches) ProtoMessage()               {}
+func (*FetchBatches) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
+
+type FetchedBatches struct {
+	Batches []*Batch `protobuf:"bytes,1,rep,name=batches" json:"batches,omitempty"`
+}
+
+func (m *FetchedBatches) Reset()                    { *m = FetchedBatches{} }
+func (m *FetchedBatches) String() string            { return proto.CompactTextString(m) }
+func (*FetchedBatches) ProtoMessage()               {}
+func (*FetchedBatches) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
+
+func (m *FetchedBatches) GetBatches() []*Batch {
+	if m != nil {
+		return m.Batches
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*Config)(nil), "simplebft.Config")
 	proto.RegisterType((*MultiChainMsg)(nil), "simplebft.MultiChainMsg")
@@ -593,63 +690,74 @@ func init() {
 	proto.RegisterType((*NewView)(nil), "simplebft.NewView")
 	proto.RegisterType((*Checkpoint)(nil), "simplebft.Checkpoint")
 	proto.RegisterType((*Hello)(nil), "simplebft.Hello")
+	proto.RegisterType((*FetchBatches)(nil), "simplebft.FetchBatches")
+	proto.RegisterType((*FetchedBatches)(nil), "simplebft.FetchedBatches")
 }
 
 func init() { proto.RegisterFile("simplebft/simplebft.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 842 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0x8c, 0x55, 0xdd, 0x8e, 0xdb, 0x44,
-	0x14, 0x5e, 0xaf, 0x1d, 0x67, 0x73, 0xb2, 0x94, 0xed, 0x50, 0x2a, 0xb3, 0xf4, 0x22, 0x32, 0xa8,
-	0xe4, 0x02, 0x92, 0x55, 0xa8, 0x00, 0x55, 0x42, 0x42, 0x49, 0x11, 0x01, 0xb4, 0x15, 0xf2, 0x56,
-	0x2b, 0xd1, 0x0b, 0x22, 0xc7, 0x3e, 0xb1, 0x87, 0x4d, 0x6c, 0xc7, 0x33, 0x4e, 0x9a, 0x3e, 0x0c,
-	0x17, 0x3c, 0x01, 0x0f, 0xc0, 0x1b, 0xf1, 0x12, 0x68, 0x7e, 0x62, 0x7b, 0xf3, 0x53, 0x55, 0xf2,
-	0xc5, 0xcc, 0xf9, 0xbe, 0x39, 0xe7, 0x7c, 0x67, 0xce, 0x19, 0xc3, 0x27, 0x8c, 0x2e, 0xb2, 0x39,
-	0x4e, 0x67, 0xbc, 0x5f, 0xae, 0x7a, 0x59, 0x9e, 0xf2, 0x94, 0xb4, 0x4a, 0x83, 0xfb, 0x8f, 0x01,
-	0xf6, 0x28, 0x4d, 0x66, 0x34, 0x22, 0xe7, 0x60, 0x24, 0x8e, 0xd1, 0x31, 0xba, 0x96, 0x67, 0x24,
-	0x62, 0x37, 0x73, 0x4e, 0xd5, 0x6e, 0x46, 0x7a, 0xf0, 0xd1, 0xd4, 0xe7, 0x41, 0x3c, 0x09, 0x8b,
-	0xdc, 0xe7, 0x34, 0x4d, 0x26, 0x09, 0xc3, 0xc0, 0x31, 0x25, 0xfe, 0x50, 0x42, 0x2f, 0x34, 0xf2,
-	0x92, 0x61, 0x40, 0xba, 0x70, 0xa1, 0xf8, 0x8c, 0xbe, 0xc5, 0xc9, 0x74, 0xc3, 0x91, 0x39, 0x96,
-	0x24, 0x3f, 0x90, 0xf6, 0x1b, 0xfa, 0x16, 0x87, 0xc2, 0x4a, 0xae, 0xe0, 0x51, 0x8e, 0xcb, 0x02,
-	0x19, 0x9f, 0x70, 0xba, 0xc0, 0xb4, 0xe0, 0xca, 0x75, 0x43, 0xb2, 0x89, 0xc6, 0x5e, 0x29, 0x48,
-	0xf8, 0x76, 0x7f, 0x85, 0x0f, 0xae, 0x8b, 0x39, 0xa7, 0xa3, 0xd8, 0xa7, 0xc9, 0x35, 0x8b, 0x88,
-	0x03, 0xcd, 0x40, 0xac, 0x7f, 0x7e, 0x21, 0xd3, 0x6f, 0x79, 0xdb, 0x2d, 0xe9, 0x80, 0xb9, 0x60,
-	0x91, 0x94, 0xd1, 0x1e, 0x3c, 0xe8, 0x55, 0x75, 0xb8, 0x66, 0x91, 0x27, 0x20, 0xf7, 0x2f, 0x13,
-	0x4c, 0xe1, 0xa3, 0x07, 0x4d, 0x1d, 0x4a, 0xfa, 0x68, 0x0f, 0x48, 0x8d, 0xed, 0x29, 0x64, 0x7c,
-	0xe2, 0x6d, 0x49, 0xe4, 0x5b, 0x80, 0x2c, 0x47, 0xf1, 0xf9, 0x39, 0xea, 0x00, 0x1f, 0xd7, 0x8e,
-	0xfc, 0x56, 0x82, 0xe3, 0x13, 0xaf, 0x46, 0x15, 0x81, 0xb6, 0xa7, 0xcc, 0xbd, 0x40, 0x37, 0xc5,
-	0xf4, 0x4f, 0x0c, 0x64, 0xa0, 0x2d, 0xff, 0x4b, 0xb0, 0x83, 0x74, 0xb1, 0xa0, 0x5c, 0xd6, 0xef,
-	0x18, 0x5d, 0x73, 0xc8, 0x33, 0x68, 0xaf, 0x28, 0xae, 0x27, 0x41, 0xec, 0x27, 0x11, 0xca, 0x22,
-	0xb6, 0x07, 0x0f, 0xeb, 0x47, 0x68, 0x94, 0x60, 0x28, 0x72, 0x12, 0xbc, 0x91, 0xa4, 0x91, 0x3e,
-	0x9c, 0x25, 0xb8, 0x9e, 0x08, 0x8b, 0x63, 0xef, 0x45, 0x79, 0x89, 0xeb, 0x5b, 0x8a, 0x6b, 0x91,
-	0x54, 0xa2, 0x96, 0x42, 0x7d, 0x10, 0x63, 0x70, 0x97, 0xa5, 0x34, 0xe1, 0x4e, 0x73, 0x4f, 0xfd,
-	0xa8, 0x04, 0x45, 0xa4, 0x8a, 0x4a, 0xba, 0xd0, 0x88, 0x71, 0x3e, 0x4f, 0x9d, 0x33, 0x79, 0xe6,
-	0xa2, 0x76, 0x66, 0x2c, 0xec, 0xe3, 0x13, 0x4f, 0x11, 0x86, 0x36, 0x58, 0x7c, 0x93, 0xa1, 0xfb,
-	0x19, 0x34, 0x75, 0xf9, 0xc5, 0x3d, 0x67, 0xfe, 0x66, 0x9e, 0xfa, 0xa1, 0xbc, 0xa3, 0x73, 0x6f,
-	0xbb, 0x75, 0xfb, 0xd0, 0xbc, 0xc1, 0xa5, 0x4c, 0x8d, 0x80, 0x25, 0x75, 0xa8, 0x46, 0x96, 0x6b,
-	0x72, 0x01, 0x26, 0xc3, 0xa5, 0xee, 0x66, 0xb1, 0x74, 0x7f, 0x87, 0xf6, 0x50, 0xf4, 0xe1, 0x18,
-	0xfd, 0x10, 0xf3, 0x2d, 0xc1, 0x28, 0x09, 0xe4, 0x53, 0x68, 0x65, 0x39, 0xae, 0x26, 0xb1, 0xcf,
-	0x62, 0x79, 0xf0, 0xdc, 0x3b, 0x13, 0x86, 0xb1, 0xcf, 0x62, 0x01, 0x86, 0x3e, 0xf7, 0x15, 0x68,
-	0x2a, 0x50, 0x18, 0x04, 0xe8, 0xfe, 0x6b, 0x40, 0x43, 0xfa, 0x26, 0x8f, 0xc1, 0x8e, 0xa5, 0x7f,
-	0x9d, 0xae, 0xde, 0x91, 0x4b, 0x38, 0xd3, 0x89, 0x33, 0xe7, 0xb4, 0x63, 0x4a, 0xd7, 0x7a, 0x4f,
-	0x7e, 0x00, 0x60, 0x34, 0x4a, 0x7c, 0x5e, 0xe4, 0xc8, 0x1c, 0xb3, 0x63, 0x76, 0xdb, 0x83, 0x4e,
-	0xad, 0x4a, 0xd2, 0xb3, 0xbc, 0x45, 0x45, 0xf9, 0x31, 0xe1, 0xf9, 0xc6, 0xab, 0x9d, 0xb9, 0xfc,
-	0x1e, 0x3e, 0xdc, 0x81, 0x85, 0xbc, 0x3b, 0xdc, 0x6c, 0xe5, 0xdd, 0xe1, 0x86, 0x3c, 0x82, 0xc6,
-	0xca, 0x9f, 0x17, 0xa8, 0xa5, 0xa9, 0xcd, 0xf3, 0xd3, 0xef, 0x0c, 0xf7, 0x35, 0x40, 0xd5, 0xbb,
-	0xe4, 0xf3, 0xaa, 0x30, 0x3b, 0xad, 0xa7, 0xca, 0xad, 0x8a, 0xf5, 0x14, 0x1a, 0x72, 0xaa, 0xf5,
-	0x1c, 0x5c, 0xec, 0xe6, 0xeb, 0x29, 0xd8, 0xfd, 0x09, 0x9a, 0xba, 0x65, 0xdf, 0xd3, 0xf1, 0x63,
-	0xb0, 0x43, 0x1a, 0x89, 0xa1, 0x54, 0x79, 0xea, 0x9d, 0xfb, 0xb7, 0x01, 0x70, 0x5b, 0xf5, 0xef,
-	0xa1, 0x3b, 0x7f, 0x0a, 0x56, 0xc6, 0x90, 0xcb, 0x02, 0x1f, 0x9c, 0x1a, 0x4f, 0xe2, 0x82, 0xb7,
-	0x14, 0x3c, 0xf3, 0x38, 0x4f, 0xe0, 0xe4, 0xea, 0x5e, 0xcb, 0x5b, 0x47, 0x84, 0xd6, 0x38, 0xee,
-	0x73, 0xb0, 0xd5, 0xb4, 0x89, 0xfc, 0x44, 0x7b, 0xe8, 0x36, 0x90, 0x6b, 0xf2, 0x04, 0x5a, 0xe5,
-	0xa5, 0x69, 0x75, 0x95, 0xc1, 0xfd, 0xcf, 0x80, 0xa6, 0x9e, 0xbb, 0x83, 0xea, 0xae, 0xc0, 0x5a,
-	0x55, 0xea, 0x9e, 0xec, 0x4f, 0x6b, 0xef, 0x96, 0x21, 0x57, 0xcd, 0x21, 0x99, 0x42, 0xe7, 0x1b,
-	0xa5, 0xd3, 0x38, 0xa6, 0xf3, 0x8d, 0xe2, 0xe9, 0xbb, 0xb4, 0xde, 0x79, 0x97, 0x97, 0xbf, 0x40,
-	0xab, 0x0c, 0x71, 0xa0, 0xc1, 0xbe, 0xa8, 0x37, 0xd8, 0xa1, 0x27, 0xa8, 0xde, 0x73, 0xaf, 0x00,
-	0xaa, 0x17, 0xe3, 0xc0, 0x30, 0x1e, 0x69, 0x83, 0xfb, 0x35, 0x34, 0x77, 0x6b, 0xf8, 0x07, 0x34,
-	0xe4, 0x9b, 0x52, 0x49, 0x32, 0xde, 0x29, 0x89, 0x7c, 0x55, 0x7b, 0x06, 0x4f, 0x8f, 0x3d, 0x83,
-	0xe5, 0x23, 0x38, 0xfc, 0xe6, 0xf5, 0xb3, 0x88, 0xf2, 0xb8, 0x98, 0xf6, 0x82, 0x74, 0xd1, 0x8f,
-	0x37, 0x19, 0xe6, 0x73, 0x0c, 0x23, 0xcc, 0xfb, 0x33, 0x7f, 0x9a, 0xd3, 0xa0, 0x9f, 0xe6, 0x21,
-	0xe6, 0x98, 0xf7, 0xd9, 0xbd, 0x7f, 0xf0, 0xd4, 0x96, 0x3f, 0xe1, 0xaf, 0xff, 0x0f, 0x00, 0x00,
-	0xff, 0xff, 0x42, 0xf4, 0xb2, 0x00, 0xa1, 0x07, 0x00, 0x00,
+	// 989 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0x94, 0x56, 0xed, 0x6e, 0xe3, 0x44,
+	0x17, 0xae, 0x63, 0xe7, 0xeb, 0x24, 0x9b, 0x76, 0xe7, 0xed, 0xee, 0xeb, 0x96, 0xfd, 0x11, 0x19,
+	0xd8, 0xad, 0x10, 0x24, 0x55, 0x59, 0x01, 0x5a, 0x01, 0x5a, 0xa5, 0x05, 0x02, 0xa8, 0xab, 0x95,
+	0x83, 0x2a, 0xb1, 0x3f, 0xb0, 0x1c, 0xfb, 0xc4, 0x36, 0x4d, 0xec, 0xc4, 0x33, 0x49, 0x37, 0x2b,
+	0x71, 0x33, 0x88, 0x0b, 0xe0, 0x5a, 0xb8, 0x0d, 0x6e, 0x02, 0xcd, 0x47, 0x6c, 0xe7, 0xab, 0x02,
+	0x29, 0x3f, 0xe6, 0x9c, 0xe7, 0x99, 0xe7, 0xcc, 0x39, 0x73, 0xe6, 0x38, 0x70, 0x42, 0xa3, 0xc9,
+	0x74, 0x8c, 0xc3, 0x11, 0xeb, 0x66, 0xab, 0xce, 0x34, 0x4d, 0x58, 0x42, 0xea, 0x99, 0xc3, 0xfa,
+	0x53, 0x83, 0xca, 0x65, 0x12, 0x8f, 0xa2, 0x80, 0x34, 0x41, 0x8b, 0x4d, 0xad, 0xad, 0x9d, 0x19,
+	0xb6, 0x16, 0x73, 0x6b, 0x64, 0x96, 0xa4, 0x35, 0x22, 0x1d, 0xf8, 0xdf, 0xd0, 0x65, 0x5e, 0xe8,
+	0xf8, 0xf3, 0xd4, 0x65, 0x51, 0x12, 0x3b, 0x31, 0x45, 0xcf, 0xd4, 0x05, 0xfe, 0x50, 0x40, 0x57,
+	0x0a, 0x79, 0x45, 0xd1, 0x23, 0x67, 0x70, 0x24, 0xf9, 0x34, 0x7a, 0x87, 0xce, 0x70, 0xc9, 0x90,
+	0x9a, 0x86, 0x20, 0xb7, 0x84, 0x7f, 0x10, 0xbd, 0xc3, 0x1e, 0xf7, 0x92, 0x73, 0x38, 0x4e, 0x71,
+	0x36, 0x47, 0xca, 0x1c, 0x16, 0x4d, 0x30, 0x99, 0x33, 0x29, 0x5d, 0x16, 0x6c, 0xa2, 0xb0, 0x9f,
+	0x24, 0xc4, 0xb5, 0xad, 0x1f, 0xe1, 0xc1, 0xf5, 0x7c, 0xcc, 0xa2, 0xcb, 0xd0, 0x8d, 0xe2, 0x6b,
+	0x1a, 0x10, 0x13, 0xaa, 0x1e, 0x5f, 0x7f, 0x7f, 0x25, 0x8e, 0x5f, 0xb7, 0x57, 0x26, 0x69, 0x83,
+	0x3e, 0xa1, 0x81, 0x48, 0xa3, 0x71, 0xd1, 0xea, 0xe4, 0x75, 0xb8, 0xa6, 0x81, 0xcd, 0x21, 0xeb,
+	0x0f, 0x03, 0x74, 0xae, 0xd1, 0x81, 0xaa, 0x0a, 0x25, 0x34, 0x1a, 0x17, 0xa4, 0xc0, 0xb6, 0x25,
+	0xd2, 0x3f, 0xb0, 0x57, 0x24, 0xf2, 0x39, 0xc0, 0x34, 0x45, 0xfe, 0x73, 0x53, 0x54, 0x01, 0x1e,
+	0x15, 0xb6, 0xbc, 0xce, 0xc0, 0xfe, 0x81, 0x5d, 0xa0, 0xf2, 0x40, 0xab, 0x5d, 0xfa, 0x56, 0xa0,
+	0xc1, 0x7c, 0xf8, 0x2b, 0x7a, 0x22, 0xd0, 0x8a, 0xff, 0x31, 0x54, 0xbc, 0x64, 0x32, 0x89, 0x98,
+	0x69, 0xdc, 0x43, 0x57, 0x1c, 0xf2, 0x1c, 0x1a, 0x8b, 0x08, 0xef, 0x1c, 0x2f, 0x74, 0xe3, 0x00,
+	0x45, 0x11, 0x1b, 0x17, 0x0f, 0x8b, 0x5b, 0xa2, 0x20, 0x46, 0x9f, 0x9f, 0x89, 0xf3, 0x2e, 0x05,
+	0x8d, 0x74, 0xa1, 0x16, 0xe3, 0x9d, 0xc3, 0x3d, 0x66, 0x65, 0x2b, 0xca, 0x2b, 0xbc, 0xbb, 0x89,
+	0xf0, 0x8e, 0x1f, 0x2a, 0x96, 0x4b, 0x9e, 0xbd, 0x17, 0xa2, 0x77, 0x3b, 0x4d, 0xa2, 0x98, 0x99,
+	0xd5, 0xad, 0xec, 0x2f, 0x33, 0x90, 0x47, 0xca, 0xa9, 0xe4, 0x0c, 0xca, 0x21, 0x8e, 0xc7, 0x89,
+	0x59, 0x13, 0x7b, 0x8e, 0x0a, 0x7b, 0xfa, 0xdc, 0xdf, 0x3f, 0xb0, 0x25, 0x81, 0x7c, 0x0d, 0x0f,
+	0x46, 0xc8, 0x3b, 0x48, 0xf4, 0x0b, 0x52, 0xb3, 0x2e, 0x76, 0xfc, 0xbf, 0xb0, 0xe3, 0x5b, 0x8e,
+	0xf7, 0x24, 0xdc, 0x3f, 0xb0, 0x9b, 0xa3, 0x82, 0x4d, 0xae, 0xe0, 0x50, 0xd8, 0xe8, 0x67, 0x0a,
+	0x20, 0x14, 0x4e, 0x36, 0x15, 0xd0, 0xcf, 0x35, 0x5a, 0xa3, 0x35, 0x4f, 0xaf, 0x02, 0x06, 0x5b,
+	0x4e, 0xd1, 0x7a, 0x1f, 0xaa, 0xaa, 0x09, 0x78, 0xb7, 0x4d, 0xdd, 0xe5, 0x38, 0x71, 0x7d, 0xd1,
+	0x29, 0x4d, 0x7b, 0x65, 0x5a, 0x5d, 0xa8, 0x0e, 0x70, 0x26, 0x0a, 0x44, 0xc0, 0x10, 0xd5, 0x94,
+	0xcf, 0x49, 0xac, 0xc9, 0x11, 0xe8, 0x14, 0x67, 0xea, 0x4d, 0xf1, 0xa5, 0xf5, 0x33, 0x34, 0x44,
+	0xa0, 0x3e, 0xba, 0x3e, 0xa6, 0x2b, 0x82, 0x96, 0x11, 0xc8, 0x7b, 0x50, 0x9f, 0xa6, 0xb8, 0x70,
+	0x42, 0x97, 0x86, 0x62, 0x63, 0xd3, 0xae, 0x71, 0x47, 0xdf, 0xa5, 0x21, 0x07, 0x7d, 0x97, 0xb9,
+	0x12, 0xd4, 0x25, 0xc8, 0x1d, 0x1c, 0xb4, 0xfe, 0x2a, 0x41, 0x59, 0x68, 0x93, 0xc7, 0x50, 0x09,
+	0x85, 0xbe, 0x3a, 0xae, 0xb2, 0xc8, 0x29, 0xd4, 0xd4, 0xc1, 0xa9, 0x59, 0x6a, 0xeb, 0x42, 0x5a,
+	0xd9, 0xe4, 0x25, 0x00, 0x8d, 0x82, 0xd8, 0x65, 0xf3, 0x14, 0xa9, 0xa9, 0xb7, 0xf5, 0xb3, 0xc6,
+	0x45, 0xbb, 0x50, 0x37, 0xa1, 0xdc, 0x19, 0x64, 0x94, 0x6f, 0x62, 0x96, 0x2e, 0xed, 0xc2, 0x1e,
+	0xf2, 0x1a, 0x8e, 0x86, 0xe3, 0xc4, 0xbb, 0x75, 0x0a, 0x3a, 0x86, 0xd0, 0xf9, 0x70, 0x4b, 0xa7,
+	0xc7, 0x89, 0x9b, 0x62, 0x87, 0xc3, 0x75, 0xef, 0xe9, 0x57, 0x70, 0xb8, 0xc1, 0xe1, 0x05, 0xbb,
+	0xc5, 0xe5, 0xaa, 0x60, 0xb7, 0xb8, 0x24, 0xc7, 0x50, 0x5e, 0xb8, 0xe3, 0x39, 0xaa, 0x62, 0x49,
+	0xe3, 0x45, 0xe9, 0x0b, 0xed, 0xb4, 0x07, 0xc7, 0xbb, 0xe2, 0xfc, 0x17, 0x0d, 0xeb, 0x0d, 0x40,
+	0xfe, 0xae, 0xc9, 0x07, 0xf9, 0x75, 0x6d, 0x3c, 0x4b, 0xd9, 0x04, 0xf2, 0x0a, 0x9f, 0x42, 0x59,
+	0xf4, 0x9f, 0x59, 0xda, 0xea, 0x78, 0x91, 0xbd, 0x2d, 0x61, 0xeb, 0x3b, 0xa8, 0xaa, 0xe7, 0xfc,
+	0x2f, 0x85, 0x1f, 0x43, 0xc5, 0x8f, 0x02, 0x3e, 0xb0, 0xe4, 0x39, 0x95, 0x65, 0xfd, 0xae, 0x01,
+	0xdc, 0xe4, 0x6f, 0x7b, 0x57, 0x27, 0x3e, 0x05, 0x63, 0x4a, 0x91, 0x89, 0x6b, 0xdf, 0x39, 0x51,
+	0x6c, 0x81, 0x73, 0xde, 0x8c, 0xf3, 0xf4, 0xfd, 0x3c, 0x8e, 0x93, 0xf3, 0xb5, 0x71, 0x60, 0xec,
+	0x49, 0xb4, 0xc0, 0xb1, 0x5e, 0x40, 0x45, 0x4e, 0x22, 0x7e, 0x3e, 0xde, 0xb4, 0xaa, 0x39, 0xc5,
+	0x9a, 0x3c, 0x81, 0x7a, 0xd6, 0x36, 0x2a, 0xbb, 0xdc, 0x61, 0xfd, 0xad, 0x41, 0x55, 0xcd, 0xa4,
+	0x9d, 0xd9, 0x9d, 0x83, 0xb1, 0xc8, 0xb3, 0x7b, 0xb2, 0x3d, 0xc9, 0x3a, 0x37, 0x14, 0x99, 0xec,
+	0x32, 0x63, 0xa1, 0xf2, 0x7c, 0x2b, 0xf3, 0xd4, 0xf6, 0xe5, 0xf9, 0x56, 0xf2, 0xd4, 0x5d, 0x1a,
+	0xf7, 0xde, 0xe5, 0xe9, 0x0f, 0x50, 0xcf, 0x42, 0xec, 0x68, 0xb0, 0x67, 0xc5, 0x06, 0xdb, 0x35,
+	0x9e, 0x8b, 0x3d, 0xf7, 0x1b, 0x40, 0x3e, 0x4d, 0x77, 0x8c, 0x88, 0x3d, 0x6d, 0xb0, 0x5e, 0x43,
+	0x7d, 0xa3, 0x86, 0xe4, 0x19, 0x1c, 0x6e, 0x3c, 0x4f, 0x91, 0x53, 0xd3, 0x6e, 0xad, 0x3f, 0x3b,
+	0xeb, 0x17, 0x28, 0x8b, 0xc1, 0x9c, 0xe7, 0xae, 0xdd, 0x9b, 0x3b, 0xf9, 0xa4, 0xf0, 0x2d, 0x29,
+	0xed, 0xfb, 0x96, 0x64, 0x5f, 0x12, 0xeb, 0x25, 0x34, 0x8b, 0x63, 0x9c, 0x9c, 0x40, 0x6d, 0x94,
+	0x26, 0x13, 0x27, 0xcf, 0xb2, 0xca, 0xed, 0x01, 0xce, 0xc8, 0x23, 0xa8, 0xb0, 0xc4, 0xc9, 0x47,
+	0x68, 0x99, 0x25, 0x03, 0x9c, 0x59, 0x5f, 0x42, 0x6b, 0x7d, 0x8c, 0x93, 0x8f, 0xa0, 0xba, 0x1a,
+	0xf9, 0x5a, 0x5b, 0xdf, 0x79, 0xd8, 0x15, 0xa1, 0xf7, 0xd9, 0x9b, 0xe7, 0x41, 0xc4, 0xc2, 0xf9,
+	0xb0, 0xe3, 0x25, 0x93, 0x6e, 0xb8, 0x9c, 0x62, 0x3a, 0x46, 0x3f, 0xc0, 0xb4, 0x3b, 0x72, 0x87,
+	0x69, 0xe4, 0x75, 0x93, 0xd4, 0xc7, 0x14, 0xd3, 0x2e, 0x5d, 0xfb, 0x23, 0x35, 0xac, 0x88, 0x7f,
+	0x52, 0x9f, 0xfe, 0x33, 0x00, 0x57, 0x79, 0x0d, 0x4c, 0x66, 0x09, 0x00, 0x00,
 }
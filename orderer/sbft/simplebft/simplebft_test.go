@@ -1336,6 +1336,73 @@ func TestHelloMsg(t *testing.T) {
 	}
 }
 
+func TestStateTransfer(t *testing.T) {
+	skipInShortMode(t)
+	N := lowN
+	BS := uint64(1)
+	sys := newTestSystemWOTimersWithBatchSize(N, BS)
+	var repls []*SBFT
+	var adapters []*testSystemAdapter
+	for i := uint64(0); i < N; i++ {
+		a := sys.NewAdapter(i)
+		s, err := New(i, chainId, &Config{N: N, F: 1, BatchDurationNsec: 2000000000, BatchSizeBytes: BS, RequestTimeoutNsec: 20000000000}, a)
+		if err != nil {
+			t.Fatal(err)
+		}
+		repls = append(repls, s)
+		adapters = append(adapters, a)
+	}
+
+	disconnect := true
+
+	// replica 3 misses more batches than fit into a single fetch
+	sys.filterFn = func(e testElem) (testElem, bool) {
+		if msg, ok := e.ev.(*testMsgEvent); ok {
+			if disconnect && (msg.src == 3 || msg.dst == 3) {
+				return e, false
+			}
+		}
+		return e, true
+	}
+
+	connectAllForDefaultChain(sys)
+	for i := 0; i < 2*maxFetchBatches+3; i++ {
+		repls[0].Request([]byte{byte(i), 1, 2})
+		sys.Run()
+	}
+	if len(adapters[3].batches[chainId]) != 0 {
+		t.Fatalf("expected no batches on the disconnected replica, got %d", len(adapters[3].batches[chainId]))
+	}
+
+	disconnect = false
+	for _, a := range adapters {
+		if a.id != 3 {
+			a.receivers[chainId].Connection(3)
+			adapters[3].receivers[chainId].Connection(a.id)
+		}
+	}
+	sys.Run()
+
+	r := []byte{3, 5, 2}
+	repls[3].Request(r)
+	sys.Run()
+
+	for _, a := range adapters {
+		if len(a.batches[chainId]) != 2*maxFetchBatches+4 {
+			t.Fatalf("expected execution of %d batches on %d, got %d", 2*maxFetchBatches+4, a.id, len(a.batches[chainId]))
+		}
+		for i, b := range a.batches[chainId] {
+			ref := adapters[0].batches[chainId][i]
+			if !reflect.DeepEqual(ref.Header, b.Header) || !reflect.DeepEqual(ref.Payloads, b.Payloads) {
+				t.Errorf("batch %d of %d differs from that of 0", i, a.id)
+			}
+		}
+		if !reflect.DeepEqual([][]byte{r}, a.batches[chainId][len(a.batches[chainId])-1].Payloads) {
+			t.Errorf("wrong request executed on %d", a.id)
+		}
+	}
+}
+
 func TestViewChangeTimer(t *testing.T) {
 	skipInShortMode(t)
 	N := lowN
@@ -1513,3 +1580,166 @@ func TestTenReplicasBombedWithRequests(t *testing.T) {
 		}
 	}
 }
+
+func TestReconfiguration(t *testing.T) {
+	skipInShortMode(t)
+	N := lowN
+	BS := uint64(1)
+	sys := newTestSystemWOTimersWithBatchSize(N+1, BS)
+	config := &Config{N: N, F: 1, BatchDurationNsec: 2000000000, BatchSizeBytes: BS, RequestTimeoutNsec: 20000000000}
+	newConfig := *config
+	newConfig.N = N + 1
+
+	// replica 4 joins the set after batch 3
+	sys.membership = func(seq uint64) *Config {
+		if seq < 3 {
+			return config
+		}
+		return &newConfig
+	}
+	sys.filterFn = func(e testElem) (testElem, bool) {
+		if msg, ok := e.ev.(*testMsgEvent); ok {
+			if sys.adapters[msg.dst].receivers == nil {
+				return e, false
+			}
+		}
+		return e, true
+	}
+
+	var repls []*SBFT
+	var adapters []*testSystemAdapter
+	for i := uint64(0); i < N; i++ {
+		a := sys.NewAdapter(i)
+		s, err := New(i, chainId, config, a)
+		if err != nil {
+			t.Fatal(err)
+		}
+		repls = append(repls, s)
+		adapters = append(adapters, a)
+	}
+	connectAllForDefaultChain(sys)
+	joining := sys.NewAdapter(N)
+
+	for i := 0; i < 3; i++ {
+		repls[0].Request([]byte{byte(i), 1, 2})
+		sys.Run()
+	}
+	for _, r := range repls {
+		if r.config.N != N+1 {
+			t.Fatalf("expected replica %d to switch to %d replicas, got %d", r.id, N+1, r.config.N)
+		}
+	}
+
+	// the joining replica starts from a copy of the ledger
+	joining.batches = map[string][]*Batch{chainId: append([]*Batch(nil), adapters[0].batches[chainId]...)}
+	s, err := New(N, chainId, &newConfig, joining)
+	if err != nil {
+		t.Fatal(err)
+	}
+	repls = append(repls, s)
+	adapters = append(adapters, joining)
+	for _, a := range adapters[:N] {
+		a.receivers[chainId].Connection(N)
+		joining.receivers[chainId].Connection(a.id)
+	}
+	sys.Run()
+
+	r := []byte{3, 5, 2}
+	repls[N].Request(r)
+	sys.Run()
+
+	for _, a := range adapters {
+		if len(a.batches[chainId]) != 4 {
+			t.Fatalf("expected execution of 4 batches on %d, got %d", a.id, len(a.batches[chainId]))
+		}
+		if !reflect.DeepEqual([][]byte{r}, a.batches[chainId][3].Payloads) {
+			t.Error("wrong request executed (4)")
+		}
+	}
+}
+
+func TestBlockSignatures(t *testing.T) {
+	skipInShortMode(t)
+	N := lowN
+	sys := newTestSystem(N)
+	var repls []*SBFT
+	var adapters []*testSystemAdapter
+	for i := uint64(0); i < N; i++ {
+		a := sys.NewAdapter(i)
+		s, err := New(i, chainId, &Config{N: N, F: 1, BatchDurationNsec: 2000000000, BatchSizeBytes: 10, RequestTimeoutNsec: 20000000000}, a)
+		if err != nil {
+			t.Fatal(err)
+		}
+		repls = append(repls, s)
+		adapters = append(adapters, a)
+	}
+
+	// replica 3 signs checkpoints, but not blocks
+	sys.filterFn = func(e testElem) (testElem, bool) {
+		if msg, ok := e.ev.(*testMsgEvent); ok && msg.src == 3 {
+			if c, ok := msg.msg.Type.(*Msg_Checkpoint); ok {
+				c.Checkpoint.BlockSignature = []byte("garbage")
+			}
+		}
+		return e, true
+	}
+
+	connectAllForDefaultChain(sys)
+	repls[0].Request([]byte{1, 2, 3})
+	sys.Run()
+	for _, a := range adapters {
+		if len(a.batches[chainId]) != 1 {
+			t.Fatalf("expected execution of 1 batch on %d, got %d", a.id, len(a.batches[chainId]))
+		}
+		batch := a.batches[chainId][0]
+		if len(batch.BlockSignatures) < repls[0].commonCaseQuorum() {
+			t.Errorf("expected at least %d block signatures on %d, got %d", repls[0].commonCaseQuorum(), a.id, len(batch.BlockSignatures))
+		}
+		for src, sig := range batch.BlockSignatures {
+			if err := a.CheckBlockSig(chainId, batch, src, sig); err != nil {
+				t.Errorf("invalid block signature of %d on %d: %s", src, a.id, err)
+			}
+		}
+	}
+}
+
+func TestUnsignedCheckpoints(t *testing.T) {
+	skipInShortMode(t)
+	N := lowN
+	sys := newTestSystem(N)
+	var repls []*SBFT
+	var adapters []*testSystemAdapter
+	for i := uint64(0); i < N; i++ {
+		a := sys.NewAdapter(i)
+		s, err := New(i, chainId, &Config{N: N, F: 1, BatchDurationNsec: 2000000000, BatchSizeBytes: 10, RequestTimeoutNsec: 20000000000}, a)
+		if err != nil {
+			t.Fatal(err)
+		}
+		repls = append(repls, s)
+		adapters = append(adapters, a)
+	}
+
+	// replicas 2 and 3 leave their checkpoints unsigned, too few
+	// block signatures remain for the BlockValidation policy; the
+	// run ends before the request times out
+	sys.filterFn = func(e testElem) (testElem, bool) {
+		if e.at > 10*time.Second {
+			return e, false
+		}
+		if msg, ok := e.ev.(*testMsgEvent); ok && msg.src >= 2 {
+			if c, ok := msg.msg.Type.(*Msg_Checkpoint); ok {
+				c.Checkpoint.BlockSignature = nil
+			}
+		}
+		return e, true
+	}
+
+	connectAllForDefaultChain(sys)
+	repls[0].Request([]byte{1, 2, 3})
+	sys.Run()
+	for _, a := range adapters {
+		if len(a.batches[chainId]) != 0 {
+			t.Errorf("expected no batch executed on %d without a quorum of block signatures, got %d", a.id, len(a.batches[chainId]))
+		}
+	}
+}
@@ -0,0 +1,221 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simplebft
+
+import (
+	"bytes"
+	"sort"
+	"time"
+
+	"github.com/hyperledger/fabric/orderer/common/filter"
+)
+
+// maxFetchBatches bounds the number of batches sent in reply to a single
+// fetch request
+const maxFetchBatches = 10
+
+// stateTransfer tracks the batches a lagging replica fetches from the
+// replicas which are ahead of it
+type stateTransfer struct {
+	target  uint64   // sequence number to catch up with
+	sources []uint64 // replicas to fetch the batches from, in order of preference
+	timeout Canceller
+}
+
+// fetchBatches starts, or extends, the transfer of the batches up to
+// target from the given replicas
+func (s *SBFT) fetchBatches(target uint64, sources ...uint64) {
+	if target <= s.seq() {
+		return
+	}
+
+	// a request is in flight unless there was no replica to send it to
+	idle := s.transfer == nil || len(s.transfer.sources) == 0
+	if s.transfer == nil {
+		log.Noticef("replica %d: we are behind at batch %d, fetching batches up to %d", s.id, s.seq(), target)
+		s.transfer = &stateTransfer{timeout: dummyCanceller{}}
+	}
+	if target > s.transfer.target {
+		s.transfer.target = target
+	}
+	for _, src := range sources {
+		if src != s.id && !containsReplica(s.transfer.sources, src) {
+			s.transfer.sources = append(s.transfer.sources, src)
+		}
+	}
+
+	if idle {
+		s.requestBatches()
+	}
+}
+
+func (s *SBFT) requestBatches() {
+	t := s.transfer
+	t.timeout.Cancel()
+	if len(t.sources) == 0 {
+		return
+	}
+
+	src := t.sources[0]
+	log.Debugf("replica %d: fetching batches %d to %d from replica %d", s.id, s.seq()+1, t.target, src)
+	s.sys.Send(s.chainId, &Msg{&Msg_FetchBatches{&FetchBatches{FromSeq: s.seq() + 1, ToSeq: t.target}}}, src)
+	t.timeout = s.sys.Timer(time.Duration(s.config.RequestTimeoutNsec), func() {
+		log.Infof("replica %d: fetching batches from replica %d timed out", s.id, src)
+		s.rotateSources()
+		s.requestBatches()
+	})
+}
+
+// rotateSources moves the current source at the end of the list, the next
+// request goes to another replica
+func (s *SBFT) rotateSources() {
+	t := s.transfer
+	if len(t.sources) == 0 {
+		return
+	}
+	t.sources = append(t.sources[1:], t.sources[0])
+}
+
+func (s *SBFT) handleFetchBatches(fb *FetchBatches, src uint64) {
+	var batches []*Batch
+	for seq := fb.FromSeq; seq <= fb.ToSeq && len(batches) < maxFetchBatches; seq++ {
+		batch := s.sys.GetBatch(s.chainId, seq)
+		if batch == nil {
+			break
+		}
+		batches = append(batches, batch)
+	}
+	if len(batches) == 0 {
+		log.Debugf("replica %d: no batches %d to %d for replica %d", s.id, fb.FromSeq, fb.ToSeq, src)
+		return
+	}
+
+	log.Debugf("replica %d: sending %d batches from %d to replica %d", s.id, len(batches), fb.FromSeq, src)
+	s.sys.Send(s.chainId, &Msg{&Msg_FetchedBatches{&FetchedBatches{Batches: batches}}}, src)
+}
+
+func (s *SBFT) handleFetchedBatches(fb *FetchedBatches, src uint64) {
+	if s.transfer == nil {
+		log.Debugf("replica %d: ignoring batches from %d, we are not fetching any", s.id, src)
+		return
+	}
+
+	for _, batch := range fb.Batches {
+		if !s.deliverFetchedBatch(batch, src) {
+			// try another replica instead of waiting for the timeout
+			s.rotateSources()
+			break
+		}
+	}
+
+	if s.seq() < s.transfer.target {
+		s.requestBatches()
+		return
+	}
+	s.completeStateTransfer()
+}
+
+// deliverFetchedBatch checks that a fetched batch is certified by a weak
+// checkpoint and follows our last batch, and delivers it
+func (s *SBFT) deliverFetchedBatch(batch *Batch, src uint64) bool {
+	bh, err := s.checkBatch(batch, true, true)
+	if err != nil {
+		log.Warningf("replica %d: invalid batch fetched from %d: %s", s.id, src, err)
+		return false
+	}
+	if bh.Seq <= s.seq() {
+		// the batch was delivered meanwhile
+		return true
+	}
+	if bh.Seq != s.seq()+1 || !bytes.Equal(bh.PrevHash, s.sys.LastBatch(s.chainId).Hash()) {
+		log.Warningf("replica %d: batch %d fetched from %d does not follow our last batch %d", s.id, bh.Seq, src, s.seq())
+		return false
+	}
+
+	var committers []filter.Committer
+	if s.cur.preprep != nil && bh.Seq == s.cur.subject.Seq.Seq && bytes.Equal(batch.Hash(), s.cur.subject.Digest) {
+		// the batch went through the block cutter when we accepted its preprepare
+		committers = s.cur.committers
+	} else {
+		var blockOK bool
+		blockOK, committers = s.getCommittersFromBatch(batch)
+		if !blockOK {
+			log.Warningf("replica %d: batch %d fetched from %d is erroneous (block cutter)", s.id, bh.Seq, src)
+			return false
+		}
+	}
+
+	log.Infof("replica %d: delivering batch %d fetched from %d", s.id, bh.Seq, src)
+	s.deliverBatch(batch, committers)
+	return true
+}
+
+func (s *SBFT) completeStateTransfer() {
+	log.Noticef("replica %d: caught up at batch %d", s.id, s.seq())
+	s.transfer.timeout.Cancel()
+	s.transfer = nil
+
+	if s.seq() >= s.cur.subject.Seq.Seq {
+		// the request in flight was delivered, or overtaken, by the
+		// fetched batches
+		last := s.sys.LastBatch(s.chainId)
+		seq := &SeqView{Seq: s.seq(), View: s.view}
+		s.cur = reqInfo{
+			subject:        Subject{Seq: seq, Digest: last.Hash()},
+			timeout:        dummyCanceller{},
+			preprep:        &Preprepare{Seq: seq, Batch: last},
+			prepared:       true,
+			committed:      true,
+			checkpointDone: true,
+		}
+	}
+
+	s.maybeSendNextBatch()
+	s.processBacklog()
+}
+
+// checkpointHolders returns the replicas whose view change in the new view
+// shows that they delivered the batch with the given sequence number
+func (s *SBFT) checkpointHolders(nv *NewView, seq uint64) []uint64 {
+	var holders []uint64
+	for src, svc := range nv.Vset {
+		vc := &ViewChange{}
+		if err := s.checkSig(svc, src, vc); err != nil || vc.Checkpoint == nil {
+			continue
+		}
+		if vc.Checkpoint.DecodeHeader().Seq >= seq {
+			holders = append(holders, src)
+		}
+	}
+	sort.Sort(replicaSlice(holders))
+	return holders
+}
+
+func containsReplica(replicas []uint64, replica uint64) bool {
+	for _, r := range replicas {
+		if r == replica {
+			return true
+		}
+	}
+	return false
+}
+
+type replicaSlice []uint64
+
+func (r replicaSlice) Len() int           { return len(r) }
+func (r replicaSlice) Less(i, j int) bool { return r[i] < r[j] }
+func (r replicaSlice) Swap(i, j int)      { r[i], r[j] = r[j], r[i] }
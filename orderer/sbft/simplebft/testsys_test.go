@@ -158,6 +158,10 @@ func (t *testSystemAdapter) Timer(d time.Duration, tf func()) Canceller {
 	return tt
 }
 
+func (t *testSystemAdapter) BatchTimer(chainId string, tf func()) Canceller {
+	return t.Timer(time.Duration(t.receivers[chainId].(*SBFT).config.BatchDurationNsec), tf)
+}
+
 func (t *testSystemAdapter) Deliver(chainId string, batch *Batch, committer []filter.Committer) {
 	if t.batches == nil {
 		t.batches = make(map[string][]*Batch)
@@ -217,6 +221,15 @@ func (t *testSystemAdapter) LastBatch(chainId string) *Batch {
 	return t.batches[chainId][len(t.batches[chainId])-1]
 }
 
+func (t *testSystemAdapter) GetBatch(chainId string, seq uint64) *Batch {
+	for _, b := range t.batches[chainId] {
+		if b.DecodeHeader().Seq == seq {
+			return b
+		}
+	}
+	return nil
+}
+
 func (t *testSystemAdapter) Sign(data []byte) []byte {
 	hash := sha256.Sum256(data)
 	r, s, err := ecdsa.Sign(crand.Reader, t.key, hash[:])
@@ -230,7 +243,7 @@ func (t *testSystemAdapter) Sign(data []byte) []byte {
 	return sig
 }
 
-func (t *testSystemAdapter) CheckSig(data []byte, src uint64, sig []byte) error {
+func (t *testSystemAdapter) CheckSig(chainId string, data []byte, src uint64, sig []byte) error {
 	rs := struct{ R, S *big.Int }{}
 	rest, err := asn1.Unmarshal(sig, &rs)
 	if err != nil {
@@ -247,6 +260,26 @@ func (t *testSystemAdapter) CheckSig(data []byte, src uint64, sig []byte) error
 	return nil
 }
 
+func (t *testSystemAdapter) SignBlock(chainId string, batch *Batch) []byte {
+	return t.Sign(batch.Hash())
+}
+
+func (t *testSystemAdapter) CheckBlockSig(chainId string, batch *Batch, src uint64, sig []byte) error {
+	return t.CheckSig(chainId, batch.Hash(), src, sig)
+}
+
+func (t *testSystemAdapter) Membership(chainId string) (uint64, *Config, []string) {
+	if t.sys.membership == nil {
+		return t.id, nil, nil
+	}
+	config := t.sys.membership(t.LastBatch(chainId).DecodeHeader().Seq)
+	replicas := make([]string, config.N)
+	for i := range replicas {
+		replicas[i] = fmt.Sprintf("replica%d", i)
+	}
+	return t.id, config, replicas
+}
+
 func (t *testSystemAdapter) Reconnect(chainId string, replica uint64) {
 	testLog.Infof("dropping connection from %d to %d", replica, t.id)
 	t.sys.queue.filter(func(e testElem) bool {
@@ -280,6 +313,9 @@ type testSystem struct {
 	adapters      map[uint64]*testSystemAdapter
 	filterFn      func(testElem) (testElem, bool)
 	disableTimers bool
+	// membership returns the configuration in effect after the batch
+	// with the given sequence number
+	membership func(seq uint64) *Config
 }
 
 type testElem struct {
@@ -38,7 +38,7 @@ func (s *SBFT) sendViewChange() {
 		q = append(q, &s.cur.subject)
 	}
 
-	// TODO fix batches synchronization as we send no payload here
+	// the payload is not sent, replicas lacking the batch fetch it
 	checkpoint := *s.sys.LastBatch(s.chainId)
 	checkpoint.Payloads = nil // don't send the big payload
 
@@ -38,8 +38,6 @@ import (
 	"github.com/hyperledger/fabric/orderer/multichain"
 	"github.com/hyperledger/fabric/orderer/sbft"
 	"github.com/hyperledger/fabric/orderer/sbft/backend"
-	"github.com/hyperledger/fabric/orderer/sbft/crypto"
-	"github.com/hyperledger/fabric/orderer/sbft/simplebft"
 	cb "github.com/hyperledger/fabric/protos/common"
 	ab "github.com/hyperledger/fabric/protos/orderer"
 	"github.com/hyperledger/fabric/protos/utils"
@@ -61,6 +59,12 @@ func init() {
 
 	genConf := genesisconfig.Load(genesisconfig.SampleInsecureProfile)
 	genConf.Orderer.OrdererType = sbftName
+	genConf.Orderer.Sbft.Replicas = []*genesisconfig.SbftReplica{{
+		Address:  "127.0.0.1:6101",
+		Cert:     pwd + "/sbft/testdata/cert1.pem",
+		MSPID:    "DEFAULT",
+		SignCert: pwd + "/../msp/sampleconfig/signcerts/peer.pem",
+	}}
 	genesisBlock = provisional.New(genConf).GenesisBlock()
 
 	os.Chdir(pwd)
@@ -98,16 +102,11 @@ func TestSbftPeer(t *testing.T) {
 	defer func() {
 		os.RemoveAll(dataTmpDir)
 	}()
-	peers := make(map[string][]byte)
-	peers["6101"], err = crypto.ParseCertPEM("sbft/testdata/cert1.pem")
-	panicOnError(err)
 	listenAddr := ":6101"
 	certFile := "sbft/testdata/cert1.pem"
 	keyFile := "sbft/testdata/key.pem"
-	cons := &simplebft.Config{N: 1, F: 0, BatchDurationNsec: 1000, BatchSizeBytes: 1000000000, RequestTimeoutNsec: 1000000000}
-	c := &sbft.ConsensusConfig{Consensus: cons, Peers: peers}
 	sc := &backend.StackConfig{ListenAddr: listenAddr, CertFile: certFile, KeyFile: keyFile, DataDir: dataTmpDir}
-	sbftConsenter := sbft.New(c, sc)
+	sbftConsenter := sbft.New(sc)
 	<-time.After(5 * time.Second)
 	// End SBFT
 
@@ -125,9 +124,9 @@ func TestSbftPeer(t *testing.T) {
 		panic(fmt.Errorf("Failed initializing crypto [%s]", err))
 	}
 	signer := localmsp.NewSigner()
-	manager := multichain.NewManagerImpl(lf, consenters, signer)
+	manager := multichain.NewManagerImpl(lf, consenters, signer, int(conf.General.TxIDWindowSize))
 
-	server := NewServer(manager, signer)
+	server := NewServer(manager, signer, time.Second)
 	grpcServer := grpc.NewServer()
 	grpcAddr := fmt.Sprintf("%s:%d", conf.General.ListenAddress, conf.General.ListenPort)
 	lis, err := net.Listen("tcp", grpcAddr)
@@ -17,9 +17,13 @@ limitations under the License.
 package main
 
 import (
+	"time"
+
 	"github.com/hyperledger/fabric/common/crypto"
+	"github.com/hyperledger/fabric/orderer/common/admin"
 	"github.com/hyperledger/fabric/orderer/common/broadcast"
 	"github.com/hyperledger/fabric/orderer/common/deliver"
+	"github.com/hyperledger/fabric/orderer/common/operations"
 	"github.com/hyperledger/fabric/orderer/configupdate"
 	"github.com/hyperledger/fabric/orderer/multichain"
 	ab "github.com/hyperledger/fabric/protos/orderer"
@@ -50,13 +54,29 @@ func (bs deliverSupport) GetChain(chainID string) (deliver.Support, bool) {
 	return bs.Manager.GetChain(chainID)
 }
 
+type adminSupport struct {
+	multichain.Manager
+}
+
+func (as adminSupport) GetChain(chainID string) (admin.Support, bool) {
+	return as.Manager.GetChain(chainID)
+}
+
+type operationsSupport struct {
+	multichain.Manager
+}
+
+func (ops operationsSupport) GetChain(chainID string) (operations.Chain, bool) {
+	return ops.Manager.GetChain(chainID)
+}
+
 type server struct {
 	bh broadcast.Handler
 	dh deliver.Handler
 }
 
 // NewServer creates an ab.AtomicBroadcastServer based on the broadcast target and ledger Reader
-func NewServer(ml multichain.Manager, signer crypto.LocalSigner) ab.AtomicBroadcastServer {
+func NewServer(ml multichain.Manager, signer crypto.LocalSigner, commitTimeout time.Duration) ab.AtomicBroadcastServer {
 	logger.Infof("Starting orderer")
 
 	s := &server{
@@ -64,7 +84,7 @@ func NewServer(ml multichain.Manager, signer crypto.LocalSigner) ab.AtomicBroadc
 		bh: broadcast.NewHandlerImpl(broadcastSupport{
 			Manager:               ml,
 			ConfigUpdateProcessor: configupdate.New(ml.SystemChannelID(), configUpdateSupport{Manager: ml}, signer),
-		}),
+		}, commitTimeout),
 	}
 	return s
 }
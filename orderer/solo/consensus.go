@@ -17,8 +17,6 @@ limitations under the License.
 package solo
 
 import (
-	"time"
-
 	"github.com/hyperledger/fabric/orderer/multichain"
 	cb "github.com/hyperledger/fabric/protos/common"
 	"github.com/op/go-logging"
@@ -29,10 +27,9 @@ var logger = logging.MustGetLogger("orderer/solo")
 type consenter struct{}
 
 type chain struct {
-	support      multichain.ConsenterSupport
-	batchTimeout time.Duration
-	sendChan     chan *cb.Envelope
-	exitChan     chan struct{}
+	support  multichain.ConsenterSupport
+	sendChan chan *cb.Envelope
+	exitChan chan struct{}
 }
 
 // New creates a new consenter for the solo consensus scheme.
@@ -49,10 +46,9 @@ func (solo *consenter) HandleChain(support multichain.ConsenterSupport, metadata
 
 func newChain(support multichain.ConsenterSupport) *chain {
 	return &chain{
-		batchTimeout: support.SharedConfig().BatchTimeout(),
-		support:      support,
-		sendChan:     make(chan *cb.Envelope),
-		exitChan:     make(chan struct{}),
+		support:  support,
+		sendChan: make(chan *cb.Envelope),
+		exitChan: make(chan struct{}),
 	}
 }
 
@@ -80,27 +76,15 @@ func (ch *chain) Enqueue(env *cb.Envelope) bool {
 }
 
 func (ch *chain) main() {
-	var timer <-chan time.Time
-
 	for {
 		select {
 		case msg := <-ch.sendChan:
-			batches, committers, ok := ch.support.BlockCutter().Ordered(msg)
-			if ok && len(batches) == 0 && timer == nil {
-				timer = time.After(ch.batchTimeout)
-				continue
-			}
+			batches, committers, _ := ch.support.BlockCutter().Ordered(msg)
 			for i, batch := range batches {
 				block := ch.support.CreateNextBlock(batch)
 				ch.support.WriteBlock(block, committers[i], nil)
 			}
-			if len(batches) > 0 {
-				timer = nil
-			}
-		case <-timer:
-			//clear the timer
-			timer = nil
-
+		case <-ch.support.BlockCutter().Timer().C():
 			batch, committers := ch.support.BlockCutter().Cut()
 			if len(batch) == 0 {
 				logger.Warningf("Batch timer expired with no pending requests, this might indicate a bug")
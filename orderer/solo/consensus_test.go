@@ -61,6 +61,7 @@ func TestEmptyBatch(t *testing.T) {
 		BlockCutterVal:  mockblockcutter.NewReceiver(),
 		SharedConfigVal: &mockconfigvaluesorderer.SharedConfig{BatchTimeoutVal: batchTimeout},
 	}
+	support.BlockCutterVal.BatchTimeout = batchTimeout
 	defer close(support.BlockCutterVal.Block)
 	bs := newChain(support)
 	wg := goWithWait(bs.main)
@@ -82,6 +83,7 @@ func TestBatchTimer(t *testing.T) {
 		BlockCutterVal:  mockblockcutter.NewReceiver(),
 		SharedConfigVal: &mockconfigvaluesorderer.SharedConfig{BatchTimeoutVal: batchTimeout},
 	}
+	support.BlockCutterVal.BatchTimeout = batchTimeout
 	defer close(support.BlockCutterVal.Block)
 	bs := newChain(support)
 	wg := goWithWait(bs.main)
@@ -117,6 +119,7 @@ func TestBatchTimerHaltOnFilledBatch(t *testing.T) {
 		BlockCutterVal:  mockblockcutter.NewReceiver(),
 		SharedConfigVal: &mockconfigvaluesorderer.SharedConfig{BatchTimeoutVal: batchTimeout},
 	}
+	support.BlockCutterVal.BatchTimeout = batchTimeout
 	defer close(support.BlockCutterVal.Block)
 
 	bs := newChain(support)
@@ -134,7 +137,7 @@ func TestBatchTimerHaltOnFilledBatch(t *testing.T) {
 	}
 
 	// Change the batch timeout to be near instant, if the timer was not reset, it will still be waiting an hour
-	bs.batchTimeout = time.Millisecond
+	support.BlockCutterVal.BatchTimeout = time.Millisecond
 
 	support.BlockCutterVal.CutNext = false
 	syncQueueMessage(testMessage, bs, support.BlockCutterVal)
@@ -160,6 +163,7 @@ func TestConfigStyleMultiBatch(t *testing.T) {
 		BlockCutterVal:  mockblockcutter.NewReceiver(),
 		SharedConfigVal: &mockconfigvaluesorderer.SharedConfig{BatchTimeoutVal: batchTimeout},
 	}
+	support.BlockCutterVal.BatchTimeout = batchTimeout
 	defer close(support.BlockCutterVal.Block)
 	bs := newChain(support)
 	wg := goWithWait(bs.main)
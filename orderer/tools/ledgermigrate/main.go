@@ -0,0 +1,80 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+                 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/hyperledger/fabric/orderer/ledger"
+	fileledger "github.com/hyperledger/fabric/orderer/ledger/file"
+	jsonledger "github.com/hyperledger/fabric/orderer/ledger/json"
+	"github.com/hyperledger/fabric/orderer/ledger/migrate"
+
+	logging "github.com/op/go-logging"
+)
+
+var logger = logging.MustGetLogger("orderer/tools/ledgermigrate")
+
+func sourceFactory(sourceType, source string) (ledger.Factory, error) {
+	if _, err := os.Stat(source); err != nil {
+		return nil, err
+	}
+	switch sourceType {
+	case "json":
+		return jsonledger.New(source), nil
+	case "file":
+		return fileledger.New(source), nil
+	}
+	return nil, fmt.Errorf("Unknown source ledger type %s", sourceType)
+}
+
+// ledgermigrate copies the chains of a json or file ledger into a file ledger, which the orderer
+// then uses with LedgerType file and FileLedger.Location set to the target directory
+func main() {
+	var sourceType, source, target string
+
+	flag.StringVar(&sourceType, "sourceType", "json", "The type of the ledger to migrate, json or file")
+	flag.StringVar(&source, "source", "", "The directory of the ledger to migrate")
+	flag.StringVar(&target, "target", "", "The directory of the file ledger to migrate to")
+
+	flag.Parse()
+
+	logging.SetLevel(logging.INFO, "")
+
+	if source == "" || target == "" {
+		logger.Fatalf("Both the source and the target directories must be set")
+	}
+	if source == target {
+		logger.Fatalf("The source and the target directories must differ")
+	}
+
+	sf, err := sourceFactory(sourceType, source)
+	if err != nil {
+		logger.Fatalf("Cannot open the source ledger: %s", err)
+	}
+	defer sf.Close()
+
+	tf := fileledger.New(target)
+	defer tf.Close()
+
+	if err := migrate.Migrate(sf, tf); err != nil {
+		logger.Fatalf("Error on migration: %s", err)
+	}
+	logger.Info("Migration completed")
+}
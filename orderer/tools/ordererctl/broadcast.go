@@ -0,0 +1,98 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+                 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	cb "github.com/hyperledger/fabric/protos/common"
+	ab "github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric/protos/utils"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/spf13/cobra"
+	"golang.org/x/net/context"
+)
+
+func broadcastCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "broadcast <envelope file>...",
+		Short: "Broadcast envelopes read from files",
+		Long:  "Broadcast the marshaled envelopes read from the files, in order, stopping at the first one the orderer rejects.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				return fmt.Errorf("At least one envelope file must be given")
+			}
+			envs := make([]*cb.Envelope, len(args))
+			for i, file := range args {
+				env, err := readEnvelope(file)
+				if err != nil {
+					return err
+				}
+				envs[i] = env
+			}
+			return broadcast(envs...)
+		},
+	}
+}
+
+// readEnvelope reads a marshaled envelope from a file
+func readEnvelope(file string) (*cb.Envelope, error) {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("Could not read envelope file: %s", err)
+	}
+	env := &cb.Envelope{}
+	if err := proto.Unmarshal(data, env); err != nil {
+		return nil, fmt.Errorf("Could not unmarshal envelope from %s: %s", file, err)
+	}
+	if _, err := utils.UnmarshalPayload(env.Payload); err != nil {
+		return nil, fmt.Errorf("Could not unmarshal the payload of the envelope from %s: %s", file, err)
+	}
+	return env, nil
+}
+
+// broadcast sends the envelopes to the orderer, each one once the previous one was accepted
+func broadcast(envs ...*cb.Envelope) error {
+	conn, err := dial()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	client, err := ab.NewAtomicBroadcastClient(conn).Broadcast(context.TODO())
+	if err != nil {
+		return fmt.Errorf("Error opening the broadcast stream: %s", err)
+	}
+	defer client.CloseSend()
+
+	for i, env := range envs {
+		if err := client.Send(env); err != nil {
+			return fmt.Errorf("Error sending envelope %d: %s", i, err)
+		}
+		resp, err := client.Recv()
+		if err != nil {
+			return fmt.Errorf("Error receiving the response to envelope %d: %s", i, err)
+		}
+		if resp.Status != cb.Status_SUCCESS {
+			return fmt.Errorf("Envelope %d was rejected with status %s: %s", i, resp.Status, resp.Info)
+		}
+		logger.Infof("Envelope %d was accepted", i)
+	}
+	return nil
+}
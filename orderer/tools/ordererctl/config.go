@@ -0,0 +1,149 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+                 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/hyperledger/fabric/common/crypto"
+	"github.com/hyperledger/fabric/common/util"
+	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/utils"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/spf13/cobra"
+)
+
+func updateCmd() *cobra.Command {
+	var file string
+
+	cmd := &cobra.Command{
+		Use:   "update",
+		Short: "Sign and submit a config update",
+		Long:  "Add the signature of the local MSP to the config update read from the file and broadcast it to the orderer.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if file == "" {
+				return fmt.Errorf("The config update file must be set")
+			}
+			configUpdateEnv, channelID, err := readConfigUpdate(file)
+			if err != nil {
+				return err
+			}
+			signer := newSigner()
+			if err := signConfigUpdate(configUpdateEnv, signer); err != nil {
+				return err
+			}
+			env, err := utils.CreateSignedEnvelope(cb.HeaderType_CONFIG_UPDATE, channelID, signer, configUpdateEnv, 0, 0)
+			if err != nil {
+				return fmt.Errorf("Error creating the config update envelope: %s", err)
+			}
+			return broadcast(env)
+		},
+	}
+
+	cmd.Flags().StringVarP(&file, "file", "f", "", "The file of the config update envelope")
+	return cmd
+}
+
+func signCmd() *cobra.Command {
+	var file, output string
+
+	cmd := &cobra.Command{
+		Use:   "sign",
+		Short: "Sign a config update",
+		Long:  "Add the signature of the local MSP to the config update read from the file, so that it may be passed on to the next signer.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if file == "" {
+				return fmt.Errorf("The config update file must be set")
+			}
+			if output == "" {
+				output = file
+			}
+			configUpdateEnv, channelID, err := readConfigUpdate(file)
+			if err != nil {
+				return err
+			}
+			signer := newSigner()
+			if err := signConfigUpdate(configUpdateEnv, signer); err != nil {
+				return err
+			}
+			env, err := utils.CreateSignedEnvelope(cb.HeaderType_CONFIG_UPDATE, channelID, signer, configUpdateEnv, 0, 0)
+			if err != nil {
+				return fmt.Errorf("Error creating the config update envelope: %s", err)
+			}
+			if err := ioutil.WriteFile(output, utils.MarshalOrPanic(env), 0644); err != nil {
+				return fmt.Errorf("Error writing the signed config update: %s", err)
+			}
+			logger.Infof("Wrote the config update with %d signatures to %s", len(configUpdateEnv.Signatures), output)
+			return nil
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVarP(&file, "file", "f", "", "The file of the config update envelope")
+	flags.StringVar(&output, "output", "", "The file to write the signed config update envelope to, defaults to the input file")
+	return cmd
+}
+
+// readConfigUpdate reads an envelope of type CONFIG_UPDATE from a file and returns
+// the config update envelope it carries along with its channel ID
+func readConfigUpdate(file string) (*cb.ConfigUpdateEnvelope, string, error) {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, "", fmt.Errorf("Could not read config update file: %s", err)
+	}
+	env := &cb.Envelope{}
+	if err := proto.Unmarshal(data, env); err != nil {
+		return nil, "", fmt.Errorf("Could not unmarshal envelope from %s: %s", file, err)
+	}
+	configUpdateEnv := &cb.ConfigUpdateEnvelope{}
+	chdr, err := utils.UnmarshalEnvelopeOfType(env, cb.HeaderType_CONFIG_UPDATE, configUpdateEnv)
+	if err != nil {
+		return nil, "", fmt.Errorf("Could not read the config update from %s: %s", file, err)
+	}
+	return configUpdateEnv, chdr.ChannelId, nil
+}
+
+// signConfigUpdate adds the signature of the signer to the config update envelope,
+// unless the envelope already carries a signature by the same creator
+func signConfigUpdate(configUpdateEnv *cb.ConfigUpdateEnvelope, signer crypto.LocalSigner) error {
+	sigHeader, err := signer.NewSignatureHeader()
+	if err != nil {
+		return fmt.Errorf("Error creating the signature header: %s", err)
+	}
+
+	for i, configSig := range configUpdateEnv.Signatures {
+		existing := &cb.SignatureHeader{}
+		if err := proto.Unmarshal(configSig.SignatureHeader, existing); err != nil {
+			return fmt.Errorf("Could not unmarshal the header of signature %d: %s", i, err)
+		}
+		if bytes.Equal(existing.Creator, sigHeader.Creator) {
+			logger.Infof("The config update is already signed by this identity")
+			return nil
+		}
+	}
+
+	configSig := &cb.ConfigSignature{SignatureHeader: utils.MarshalOrPanic(sigHeader)}
+	configSig.Signature, err = signer.Sign(util.ConcatenateBytes(configSig.SignatureHeader, configUpdateEnv.ConfigUpdate))
+	if err != nil {
+		return fmt.Errorf("Error signing the config update: %s", err)
+	}
+	configUpdateEnv.Signatures = append(configUpdateEnv.Signatures, configSig)
+	return nil
+}
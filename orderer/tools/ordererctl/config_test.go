@@ -0,0 +1,94 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+                 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	mockcrypto "github.com/hyperledger/fabric/common/mocks/crypto"
+	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/utils"
+)
+
+func TestSignConfigUpdate(t *testing.T) {
+	configUpdateEnv := &cb.ConfigUpdateEnvelope{ConfigUpdate: []byte("ConfigUpdate")}
+
+	if err := signConfigUpdate(configUpdateEnv, mockcrypto.FakeLocalSigner); err != nil {
+		t.Fatalf("Error signing config update: %s", err)
+	}
+	if len(configUpdateEnv.Signatures) != 1 {
+		t.Fatalf("Expected 1 signature, got %d", len(configUpdateEnv.Signatures))
+	}
+
+	if err := signConfigUpdate(configUpdateEnv, mockcrypto.FakeLocalSigner); err != nil {
+		t.Fatalf("Error signing config update again: %s", err)
+	}
+	if len(configUpdateEnv.Signatures) != 1 {
+		t.Fatalf("Signing twice by the same identity should not add a signature, got %d", len(configUpdateEnv.Signatures))
+	}
+
+	other := &mockcrypto.LocalSigner{Identity: []byte("OtherIdentity")}
+	if err := signConfigUpdate(configUpdateEnv, other); err != nil {
+		t.Fatalf("Error signing config update by another identity: %s", err)
+	}
+	if len(configUpdateEnv.Signatures) != 2 {
+		t.Fatalf("Expected 2 signatures, got %d", len(configUpdateEnv.Signatures))
+	}
+}
+
+func TestReadConfigUpdate(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ordererctl")
+	if err != nil {
+		t.Fatalf("Error creating temp dir: %s", err)
+	}
+	defer os.RemoveAll(dir)
+
+	configUpdateEnv := &cb.ConfigUpdateEnvelope{ConfigUpdate: []byte("ConfigUpdate")}
+	env, err := utils.CreateSignedEnvelope(cb.HeaderType_CONFIG_UPDATE, "foo", mockcrypto.FakeLocalSigner, configUpdateEnv, 0, 0)
+	if err != nil {
+		t.Fatalf("Error creating envelope: %s", err)
+	}
+	file := filepath.Join(dir, "update.tx")
+	if err := ioutil.WriteFile(file, utils.MarshalOrPanic(env), 0644); err != nil {
+		t.Fatalf("Error writing envelope: %s", err)
+	}
+
+	read, channelID, err := readConfigUpdate(file)
+	if err != nil {
+		t.Fatalf("Error reading config update: %s", err)
+	}
+	if channelID != "foo" {
+		t.Fatalf("Expected channel foo, got %s", channelID)
+	}
+	if string(read.ConfigUpdate) != "ConfigUpdate" {
+		t.Fatalf("Config update was not read back")
+	}
+
+	env, err = utils.CreateSignedEnvelope(cb.HeaderType_MESSAGE, "foo", mockcrypto.FakeLocalSigner, configUpdateEnv, 0, 0)
+	if err != nil {
+		t.Fatalf("Error creating envelope: %s", err)
+	}
+	if err := ioutil.WriteFile(file, utils.MarshalOrPanic(env), 0644); err != nil {
+		t.Fatalf("Error writing envelope: %s", err)
+	}
+	if _, _, err := readConfigUpdate(file); err == nil {
+		t.Fatalf("Reading an envelope which is not a config update should fail")
+	}
+}
@@ -0,0 +1,223 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+                 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/hyperledger/fabric/common/crypto"
+	cb "github.com/hyperledger/fabric/protos/common"
+	ab "github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric/protos/utils"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	"github.com/spf13/cobra"
+	"golang.org/x/net/context"
+)
+
+const (
+	formatProto   = "proto"
+	formatJSON    = "json"
+	formatSummary = "summary"
+)
+
+func deliverCmd() *cobra.Command {
+	var channelID, start, stop, output, format string
+
+	cmd := &cobra.Command{
+		Use:   "deliver",
+		Short: "Deliver a range of blocks to disk",
+		Long:  "Deliver the blocks of a channel from start to stop, writing each one to a file of the output directory.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if channelID == "" {
+				return fmt.Errorf("The channel ID must be set")
+			}
+			if format != formatProto && format != formatJSON {
+				return fmt.Errorf("Unknown format %s, expected %s or %s", format, formatProto, formatJSON)
+			}
+			startPosition, err := parseSeekPosition(start)
+			if err != nil {
+				return err
+			}
+			stopPosition, err := parseSeekPosition(stop)
+			if err != nil {
+				return err
+			}
+			if err := os.MkdirAll(output, 0755); err != nil {
+				return fmt.Errorf("Could not create the output directory: %s", err)
+			}
+			return deliver(channelID, startPosition, stopPosition, func(block *cb.Block) error {
+				return writeBlock(output, channelID, format, block)
+			})
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVarP(&channelID, "channelID", "c", "", "The channel to deliver the blocks of")
+	flags.StringVar(&start, "start", "oldest", "The first block to deliver, a block number, oldest or newest")
+	flags.StringVar(&stop, "stop", "newest", "The last block to deliver, a block number, oldest or newest")
+	flags.StringVar(&output, "output", ".", "The directory to write the blocks to")
+	flags.StringVar(&format, "format", formatProto, "The format to write the blocks in, proto or json")
+	return cmd
+}
+
+func tailCmd() *cobra.Command {
+	var channelID, start, format string
+
+	cmd := &cobra.Command{
+		Use:   "tail",
+		Short: "Print the blocks of a channel as they are ordered",
+		Long:  "Print the blocks of a channel from start on, waiting for new blocks until interrupted.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if channelID == "" {
+				return fmt.Errorf("The channel ID must be set")
+			}
+			if format != formatSummary && format != formatJSON {
+				return fmt.Errorf("Unknown format %s, expected %s or %s", format, formatSummary, formatJSON)
+			}
+			startPosition, err := parseSeekPosition(start)
+			if err != nil {
+				return err
+			}
+			return deliver(channelID, startPosition, newSeekSpecified(math.MaxUint64), func(block *cb.Block) error {
+				return printBlock(format, block)
+			})
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVarP(&channelID, "channelID", "c", "", "The channel to print the blocks of")
+	flags.StringVar(&start, "start", "newest", "The first block to print, a block number, oldest or newest")
+	flags.StringVar(&format, "format", formatSummary, "The format to print the blocks in, summary or json")
+	return cmd
+}
+
+func newSeekSpecified(number uint64) *ab.SeekPosition {
+	return &ab.SeekPosition{Type: &ab.SeekPosition_Specified{Specified: &ab.SeekSpecified{Number: number}}}
+}
+
+// parseSeekPosition parses a block number, oldest or newest
+func parseSeekPosition(position string) (*ab.SeekPosition, error) {
+	switch position {
+	case "oldest":
+		return &ab.SeekPosition{Type: &ab.SeekPosition_Oldest{Oldest: &ab.SeekOldest{}}}, nil
+	case "newest":
+		return &ab.SeekPosition{Type: &ab.SeekPosition_Newest{Newest: &ab.SeekNewest{}}}, nil
+	}
+	number, err := strconv.ParseUint(position, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid position %s, expected a block number, oldest or newest", position)
+	}
+	return newSeekSpecified(number), nil
+}
+
+// newSeekEnvelope creates the signed request for the blocks of a channel from start to stop
+func newSeekEnvelope(channelID string, signer crypto.LocalSigner, start, stop *ab.SeekPosition) (*cb.Envelope, error) {
+	return utils.CreateSignedEnvelope(cb.HeaderType_DELIVER_SEEK_INFO, channelID, signer, &ab.SeekInfo{
+		Start:    start,
+		Stop:     stop,
+		Behavior: ab.SeekInfo_BLOCK_UNTIL_READY,
+	}, 0, 0)
+}
+
+// deliver requests the blocks of a channel from start to stop and passes them to the handler
+func deliver(channelID string, start, stop *ab.SeekPosition, handler func(*cb.Block) error) error {
+	env, err := newSeekEnvelope(channelID, newSigner(), start, stop)
+	if err != nil {
+		return fmt.Errorf("Error creating the deliver request: %s", err)
+	}
+
+	conn, err := dial()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	client, err := ab.NewAtomicBroadcastClient(conn).Deliver(context.TODO())
+	if err != nil {
+		return fmt.Errorf("Error opening the deliver stream: %s", err)
+	}
+	if err := client.Send(env); err != nil {
+		return fmt.Errorf("Error sending the deliver request: %s", err)
+	}
+	return receiveBlocks(client, handler)
+}
+
+// receiveBlocks passes the blocks received to the handler until the status of the request
+func receiveBlocks(client ab.AtomicBroadcast_DeliverClient, handler func(*cb.Block) error) error {
+	for {
+		msg, err := client.Recv()
+		if err != nil {
+			return fmt.Errorf("Error receiving: %s", err)
+		}
+		switch t := msg.Type.(type) {
+		case *ab.DeliverResponse_Status:
+			if t.Status != cb.Status_SUCCESS {
+				return fmt.Errorf("The deliver request failed with status %s", t.Status)
+			}
+			return nil
+		case *ab.DeliverResponse_Block:
+			if err := handler(t.Block); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// writeBlock writes a block to a file of the directory named after its channel and number
+func writeBlock(directory, channelID, format string, block *cb.Block) error {
+	var data []byte
+	var err error
+	var file string
+	switch format {
+	case formatJSON:
+		file = filepath.Join(directory, fmt.Sprintf("%s_%d.json", channelID, block.Header.Number))
+		var marshaled string
+		marshaled, err = (&jsonpb.Marshaler{Indent: "  "}).MarshalToString(block)
+		data = []byte(marshaled)
+	default:
+		file = filepath.Join(directory, fmt.Sprintf("%s_%d.block", channelID, block.Header.Number))
+		data, err = proto.Marshal(block)
+	}
+	if err != nil {
+		return fmt.Errorf("Error marshaling block %d: %s", block.Header.Number, err)
+	}
+	if err := ioutil.WriteFile(file, data, 0644); err != nil {
+		return fmt.Errorf("Error writing block %d: %s", block.Header.Number, err)
+	}
+	logger.Infof("Wrote block %d to %s", block.Header.Number, file)
+	return nil
+}
+
+// printBlock prints a block to the standard output
+func printBlock(format string, block *cb.Block) error {
+	if format == formatJSON {
+		return (&jsonpb.Marshaler{Indent: "  "}).Marshal(os.Stdout, block)
+	}
+	var transactions int
+	if block.Data != nil {
+		transactions = len(block.Data.Data)
+	}
+	_, err := fmt.Printf("Block %d: %d transactions, data hash %x\n", block.Header.Number, transactions, block.Header.DataHash)
+	return err
+}
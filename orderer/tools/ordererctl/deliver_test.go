@@ -0,0 +1,92 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+                 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	cb "github.com/hyperledger/fabric/protos/common"
+	ab "github.com/hyperledger/fabric/protos/orderer"
+
+	"github.com/golang/protobuf/proto"
+)
+
+func TestParseSeekPosition(t *testing.T) {
+	position, err := parseSeekPosition("oldest")
+	if err != nil {
+		t.Fatalf("Error parsing oldest: %s", err)
+	}
+	if _, ok := position.Type.(*ab.SeekPosition_Oldest); !ok {
+		t.Fatalf("Expected the oldest position, got %v", position)
+	}
+
+	position, err = parseSeekPosition("newest")
+	if err != nil {
+		t.Fatalf("Error parsing newest: %s", err)
+	}
+	if _, ok := position.Type.(*ab.SeekPosition_Newest); !ok {
+		t.Fatalf("Expected the newest position, got %v", position)
+	}
+
+	position, err = parseSeekPosition("7")
+	if err != nil {
+		t.Fatalf("Error parsing a block number: %s", err)
+	}
+	if specified, ok := position.Type.(*ab.SeekPosition_Specified); !ok || specified.Specified.Number != 7 {
+		t.Fatalf("Expected the position of block 7, got %v", position)
+	}
+
+	if _, err := parseSeekPosition("latest"); err == nil {
+		t.Fatalf("Parsing an unknown position should fail")
+	}
+}
+
+func TestWriteBlock(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ordererctl")
+	if err != nil {
+		t.Fatalf("Error creating temp dir: %s", err)
+	}
+	defer os.RemoveAll(dir)
+
+	block := cb.NewBlock(3, []byte("PreviousHash"))
+	block.Data.Data = [][]byte{[]byte("Data")}
+
+	if err := writeBlock(dir, "foo", formatProto, block); err != nil {
+		t.Fatalf("Error writing block: %s", err)
+	}
+	data, err := ioutil.ReadFile(filepath.Join(dir, "foo_3.block"))
+	if err != nil {
+		t.Fatalf("Error reading block: %s", err)
+	}
+	read := &cb.Block{}
+	if err := proto.Unmarshal(data, read); err != nil {
+		t.Fatalf("Error unmarshaling block: %s", err)
+	}
+	if !proto.Equal(block, read) {
+		t.Fatalf("Block read back differs from the block written")
+	}
+
+	if err := writeBlock(dir, "foo", formatJSON, block); err != nil {
+		t.Fatalf("Error writing block as JSON: %s", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "foo_3.json")); err != nil {
+		t.Fatalf("Block should have been written as JSON: %s", err)
+	}
+}
@@ -0,0 +1,140 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+                 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"time"
+
+	"github.com/hyperledger/fabric/common/crypto"
+	"github.com/hyperledger/fabric/common/localmsp"
+	mspmgmt "github.com/hyperledger/fabric/msp/mgmt"
+
+	logging "github.com/op/go-logging"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+var logger = logging.MustGetLogger("orderer/tools/ordererctl")
+
+// The flags shared by all commands
+var (
+	ordererAddress string
+	tlsEnabled     bool
+	caFile         string
+	certFile       string
+	keyFile        string
+	mspDir         string
+	mspID          string
+	dialTimeout    time.Duration
+)
+
+// ordererctl broadcasts envelopes to and delivers blocks from an orderer, the requests are
+// signed by the local MSP
+func main() {
+	logging.SetLevel(logging.INFO, "")
+	if err := rootCmd().Execute(); err != nil {
+		os.Exit(1)
+	}
+}
+
+func rootCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "ordererctl",
+		Short: "Broadcast to and deliver from an orderer",
+		Long:  "Broadcast envelopes and config updates to an orderer, and deliver blocks from it, signing with the local MSP.",
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			if mspDir == "" {
+				return fmt.Errorf("The local MSP directory must be set")
+			}
+			if err := mspmgmt.LoadLocalMsp(mspDir, nil, mspID); err != nil {
+				return fmt.Errorf("Could not load the local MSP from %s: %s", mspDir, err)
+			}
+			return nil
+		},
+	}
+	cmd.SilenceUsage = true
+
+	flags := cmd.PersistentFlags()
+	flags.StringVarP(&ordererAddress, "orderer", "o", "127.0.0.1:7050", "The address of the orderer")
+	flags.BoolVar(&tlsEnabled, "tls", false, "Use TLS when communicating with the orderer")
+	flags.StringVar(&caFile, "cafile", "", "The PEM encoded certificates trusted to authenticate the orderer, the system roots if unset")
+	flags.StringVar(&certFile, "certfile", "", "The PEM encoded certificate to authenticate to the orderer with as a TLS client")
+	flags.StringVar(&keyFile, "keyfile", "", "The PEM encoded private key of the TLS client certificate")
+	flags.StringVar(&mspDir, "mspDir", "", "The directory of the local MSP which signs the requests")
+	flags.StringVar(&mspID, "mspID", "DEFAULT", "The ID of the local MSP")
+	flags.DurationVar(&dialTimeout, "timeout", 3*time.Second, "The time to wait for the connection to the orderer")
+
+	cmd.AddCommand(broadcastCmd())
+	cmd.AddCommand(deliverCmd())
+	cmd.AddCommand(tailCmd())
+	cmd.AddCommand(updateCmd())
+	cmd.AddCommand(signCmd())
+	return cmd
+}
+
+// newSigner returns the signer of the local MSP
+func newSigner() crypto.LocalSigner {
+	return localmsp.NewSigner()
+}
+
+// dial connects to the orderer with the TLS settings of the flags
+func dial() (*grpc.ClientConn, error) {
+	opts := []grpc.DialOption{grpc.WithTimeout(dialTimeout), grpc.WithBlock()}
+	if tlsEnabled {
+		tlsConfig, err := clientTLSConfig()
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)))
+	} else {
+		opts = append(opts, grpc.WithInsecure())
+	}
+
+	conn, err := grpc.Dial(ordererAddress, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("Error connecting to %s: %s", ordererAddress, err)
+	}
+	return conn, nil
+}
+
+func clientTLSConfig() (*tls.Config, error) {
+	tlsConfig := &tls.Config{}
+	if caFile != "" {
+		caPEM, err := ioutil.ReadFile(caFile)
+		if err != nil {
+			return nil, fmt.Errorf("Could not read the trusted certificates: %s", err)
+		}
+		tlsConfig.RootCAs = x509.NewCertPool()
+		if !tlsConfig.RootCAs.AppendCertsFromPEM(caPEM) {
+			return nil, fmt.Errorf("No valid certificate in %s", caFile)
+		}
+	}
+	if certFile != "" || keyFile != "" {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("Could not load the TLS client certificate: %s", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	return tlsConfig, nil
+}
@@ -17,20 +17,29 @@ limitations under the License.
 package main
 
 import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
 	"io/ioutil"
 	"os"
 	"path/filepath"
 
+	"github.com/hyperledger/fabric/common/cauthdsl"
 	"github.com/hyperledger/fabric/common/ledger/blkstorage/fsblkstorage"
+	"github.com/hyperledger/fabric/common/policies"
+	"github.com/hyperledger/fabric/core/comm"
+	"github.com/hyperledger/fabric/orderer/common/operations"
 	"github.com/hyperledger/fabric/orderer/ledger"
 	fileledger "github.com/hyperledger/fabric/orderer/ledger/file"
 	jsonledger "github.com/hyperledger/fabric/orderer/ledger/json"
 	ramledger "github.com/hyperledger/fabric/orderer/ledger/ram"
 	config "github.com/hyperledger/fabric/orderer/localconfig"
-	"github.com/hyperledger/fabric/orderer/sbft"
 	"github.com/hyperledger/fabric/orderer/sbft/backend"
-	sbftcrypto "github.com/hyperledger/fabric/orderer/sbft/crypto"
-	"github.com/hyperledger/fabric/orderer/sbft/simplebft"
+	"github.com/hyperledger/fabric/protos/utils"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+
+	"github.com/hyperledger/fabric/msp"
 )
 
 func createLedgerFactory(conf *config.TopLevel) (ledger.Factory, string) {
@@ -88,20 +97,55 @@ func createSubDir(parentDirPath string, subDir string) (string, bool) {
 	return subDirPath, created
 }
 
-// XXX The functions below need to be moved to the SBFT package ASAP
+// makeRaftDialOptions returns the options used to connect to the other raft
+// consenters. With TLS enabled, the server certificate of the orderer is
+// presented as client certificate, which the consenters authenticate.
+func makeRaftDialOptions(secureConfig comm.SecureServerConfig) ([]grpc.DialOption, error) {
+	if !secureConfig.UseTLS {
+		return []grpc.DialOption{grpc.WithInsecure()}, nil
+	}
+
+	cert, err := tls.X509KeyPair(secureConfig.ServerCertificate, secureConfig.ServerKey)
+	if err != nil {
+		return nil, err
+	}
+	rootCAs := x509.NewCertPool()
+	for _, root := range append(secureConfig.ServerRootCAs, secureConfig.ClientRootCAs...) {
+		if !rootCAs.AppendCertsFromPEM(root) {
+			return nil, fmt.Errorf("Could not parse root CA certificate")
+		}
+	}
+	return []grpc.DialOption{grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{
+		Certificates: []tls.Certificate{cert},
+		RootCAs:      rootCAs,
+	}))}, nil
+}
+
+// makeAdminPolicy returns the policy which the requests of the admin service
+// must satisfy, they must be signed by an admin of the local MSP.
+func makeAdminPolicy(localMSP msp.MSP, mspID string) (policies.Policy, error) {
+	policy, _, err := cauthdsl.NewPolicyProvider(localMSP).NewPolicy(utils.MarshalOrPanic(cauthdsl.SignedByMspAdmin(mspID)))
+	return policy, err
+}
 
-func makeSbftConsensusConfig(conf *config.TopLevel) *sbft.ConsensusConfig {
-	cfg := simplebft.Config{N: conf.Genesis.SbftShared.N, F: conf.Genesis.SbftShared.F,
-		BatchDurationNsec:  uint64(conf.Genesis.DeprecatedBatchTimeout),
-		BatchSizeBytes:     uint64(conf.Genesis.DeprecatedBatchSize),
-		RequestTimeoutNsec: conf.Genesis.SbftShared.RequestTimeoutNsec}
-	peers := make(map[string][]byte)
-	for addr, cert := range conf.Genesis.SbftShared.Peers {
-		peers[addr], _ = sbftcrypto.ParseCertPEM(cert)
+// makeLedgerCheck returns the readiness check of the ledger, a file is created and
+// removed in the ledger dir. The RAM ledger, without a dir, is always writable.
+func makeLedgerCheck(ld string) operations.Check {
+	return func() error {
+		if ld == "" {
+			return nil
+		}
+		f, err := ioutil.TempFile(ld, ".ready")
+		if err != nil {
+			return fmt.Errorf("Ledger dir %s is not writable: %s", ld, err)
+		}
+		f.Close()
+		return os.Remove(f.Name())
 	}
-	return &sbft.ConsensusConfig{Consensus: &cfg, Peers: peers}
 }
 
+// XXX The functions below need to be moved to the SBFT package ASAP
+
 func makeSbftStackConfig(conf *config.TopLevel) *backend.StackConfig {
 	return &backend.StackConfig{ListenAddr: conf.SbftLocal.PeerCommAddr,
 		CertFile: conf.SbftLocal.CertFile,
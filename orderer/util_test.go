@@ -20,7 +20,12 @@ import (
 	"os"
 	"testing"
 
+	"github.com/hyperledger/fabric/common/localmsp"
 	config "github.com/hyperledger/fabric/orderer/localconfig"
+	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/utils"
+
+	mspmgmt "github.com/hyperledger/fabric/msp/mgmt"
 )
 
 func TestCreateLedgerFactory(t *testing.T) {
@@ -104,3 +109,50 @@ func TestCreateSubDir(t *testing.T) {
 		})
 	}
 }
+
+func TestMakeLedgerCheck(t *testing.T) {
+	if err := makeLedgerCheck("")(); err != nil {
+		t.Fatalf("RAM ledger should always be writable: %s", err)
+	}
+
+	ld := createTempDir("test-dir")
+	defer os.RemoveAll(ld)
+	if err := makeLedgerCheck(ld)(); err != nil {
+		t.Fatalf("Ledger dir should be writable: %s", err)
+	}
+	if err := makeLedgerCheck(ld + "/missing")(); err == nil {
+		t.Fatal("Missing ledger dir should not be writable")
+	}
+}
+
+func TestMakeAdminPolicy(t *testing.T) {
+	if err := mspmgmt.LoadLocalMsp("../msp/sampleconfig", nil, "DEFAULT"); err != nil {
+		t.Fatalf("Failed to load the local MSP: %s", err)
+	}
+
+	// The signing identity of the sample MSP is also its admin
+	env, err := utils.CreateSignedEnvelope(cb.HeaderType_MESSAGE, "", localmsp.NewSigner(), &cb.Payload{}, 0, 0)
+	if err != nil {
+		t.Fatalf("Failed to sign the envelope: %s", err)
+	}
+	signedData, err := env.AsSignedData()
+	if err != nil {
+		t.Fatalf("Failed to get the signed data: %s", err)
+	}
+
+	policy, err := makeAdminPolicy(mspmgmt.GetLocalMSP(), "DEFAULT")
+	if err != nil {
+		t.Fatalf("Failed to make the admin policy: %s", err)
+	}
+	if err := policy.Evaluate(signedData); err != nil {
+		t.Fatalf("The admin of the local MSP should satisfy the policy: %s", err)
+	}
+
+	policy, err = makeAdminPolicy(mspmgmt.GetLocalMSP(), "OTHER")
+	if err != nil {
+		t.Fatalf("Failed to make the admin policy: %s", err)
+	}
+	if err := policy.Evaluate(signedData); err == nil {
+		t.Fatal("The admin of another MSP should not satisfy the policy")
+	}
+}
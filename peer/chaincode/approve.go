@@ -0,0 +1,95 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chaincode
+
+import (
+	"fmt"
+
+	protcommon "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/spf13/cobra"
+)
+
+var chaincodeApproveCmd *cobra.Command
+
+const approve_cmdname = "approve"
+
+const approve_desc = "Approve a chaincode definition on the channel for the organization of the signer."
+
+// approveCmd returns the cobra command for Chaincode Approve
+func approveCmd(cf *ChaincodeCmdFactory) *cobra.Command {
+	chaincodeApproveCmd = &cobra.Command{
+		Use:       approve_cmdname,
+		Short:     fmt.Sprintf(approve_desc),
+		Long:      fmt.Sprintf(approve_desc),
+		ValidArgs: []string{"1"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return chaincodeApprove(cmd, args, cf)
+		},
+	}
+
+	return chaincodeApproveCmd
+}
+
+//approve the chaincode definition via Endorser
+func approve(cmd *cobra.Command, cf *ChaincodeCmdFactory) (*protcommon.Envelope, error) {
+	def, err := getChaincodeDefinition(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	creator, err := cf.Signer.Serialize()
+	if err != nil {
+		return nil, fmt.Errorf("Error serializing identity for %s: %s", cf.Signer.GetIdentifier(), err)
+	}
+
+	// the signature over the definition is kept by lccc and evaluated
+	// against the lifecycle policy of the channel on commit
+	signature, err := cf.Signer.Sign(def)
+	if err != nil {
+		return nil, fmt.Errorf("Error signing chaincode definition: %s", err)
+	}
+
+	prop, _, err := utils.CreateApproveProposal(chainID, def, signature, creator)
+	if err != nil {
+		return nil, fmt.Errorf("Error creating proposal  %s: %s", chainFuncName, err)
+	}
+
+	return endorseLifecycleProposal(prop, cf)
+}
+
+// chaincodeApprove approves the chaincode definition given on the command line
+func chaincodeApprove(cmd *cobra.Command, args []string, cf *ChaincodeCmdFactory) error {
+	var err error
+	if cf == nil {
+		cf, err = InitCmdFactory(true)
+		if err != nil {
+			return err
+		}
+	}
+	defer cf.BroadcastClient.Close()
+	env, err := approve(cmd, cf)
+	if err != nil {
+		return err
+	}
+
+	if env != nil {
+		err = cf.BroadcastClient.Send(env)
+	}
+
+	return err
+}
@@ -0,0 +1,89 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chaincode
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric/peer/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+)
+
+func getMockLifecycleCmdFactory(t *testing.T, status int32) *ChaincodeCmdFactory {
+	InitMSP()
+
+	signer, err := common.GetDefaultSigner()
+	if err != nil {
+		t.Fatalf("Get default signer error: %v", err)
+	}
+
+	mockResponse := &pb.ProposalResponse{
+		Response:    &pb.Response{Status: status},
+		Endorsement: &pb.Endorsement{},
+	}
+
+	return &ChaincodeCmdFactory{
+		EndorserClient:  common.GetMockEndorserClient(mockResponse, nil),
+		Signer:          signer,
+		BroadcastClient: common.GetMockBroadcastClient(nil),
+	}
+}
+
+// resetDefinitionFlags restores the escc and vscc defaulted by the lifecycle commands
+func resetDefinitionFlags() {
+	escc = common.UndefinedParamValue
+	vscc = common.UndefinedParamValue
+}
+
+func TestApproveCmd(t *testing.T) {
+	defer resetDefinitionFlags()
+	cmd := approveCmd(getMockLifecycleCmdFactory(t, 200))
+	AddFlags(cmd)
+
+	args := []string{"-n", "example02", "-v", "1", "--packagehash", "0a0b0c", "--initrequired"}
+	cmd.SetArgs(args)
+
+	if err := cmd.Execute(); err != nil {
+		t.Errorf("Run chaincode approve cmd error:%v", err)
+	}
+}
+
+func TestApproveCmdInvalidPackageHash(t *testing.T) {
+	defer resetDefinitionFlags()
+	cmd := approveCmd(getMockLifecycleCmdFactory(t, 200))
+	AddFlags(cmd)
+
+	args := []string{"-n", "example02", "-v", "1", "--packagehash", "not hex"}
+	cmd.SetArgs(args)
+
+	if err := cmd.Execute(); err == nil {
+		t.Error("Expected an error with an invalid package hash")
+	}
+}
+
+func TestCommitCmdEndorseFail(t *testing.T) {
+	defer resetDefinitionFlags()
+	cmd := commitCmd(getMockLifecycleCmdFactory(t, 500))
+	AddFlags(cmd)
+
+	args := []string{"-n", "example02", "-v", "1", "--packagehash", "0a0b0c"}
+	cmd.SetArgs(args)
+
+	if err := cmd.Execute(); err == nil {
+		t.Error("Expected an error when the commit is not endorsed")
+	}
+}
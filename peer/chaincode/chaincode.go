@@ -59,6 +59,18 @@ func AddFlags(cmd *cobra.Command) {
 	flags.StringVarP(&orderingEndpoint, "orderer", "o", "", "Ordering service endpoint")
 	flags.BoolVarP(&tls, "tls", "", false, "Use TLS when communicating with the orderer endpoint")
 	flags.StringVarP(&caFile, "cafile", "", "", "Path to file containing PEM-encoded trusted certificate(s) for the ordering endpoint")
+	flags.Int32VarP(&executeTimeout, "executetimeout", "", 0,
+		fmt.Sprintf("Maximum time in milliseconds a %s transaction may execute (0 uses the peer default)", chainFuncName))
+	flags.Int64VarP(&memoryLimit, "memory", "", 0,
+		fmt.Sprintf("Memory limit in bytes of the %s container (0 uses the peer default)", chainFuncName))
+	flags.Int64VarP(&cpuShares, "cpushares", "", 0,
+		fmt.Sprintf("CPU shares of the %s container (0 uses the peer default)", chainFuncName))
+	flags.Int32VarP(&maxConcurrency, "maxconcurrency", "", 0,
+		fmt.Sprintf("Maximum number of concurrent transactions executed by the %s (0 for no limit)", chainFuncName))
+	flags.StringVarP(&packageHash, "packagehash", "", common.UndefinedParamValue,
+		fmt.Sprint("Hex encoded hash of the installed chaincode package in approve/commit commands"))
+	flags.BoolVarP(&initRequired, "initrequired", "", false,
+		fmt.Sprint("Whether the chaincode must be initialized when its definition is committed"))
 }
 
 // Cmd returns the cobra command for Chaincode
@@ -70,7 +82,11 @@ func Cmd(cf *ChaincodeCmdFactory) *cobra.Command {
 	chaincodeCmd.AddCommand(queryCmd(cf))
 	chaincodeCmd.AddCommand(upgradeCmd(cf))
 	chaincodeCmd.AddCommand(packageCmd(cf))
+	chaincodeCmd.AddCommand(signpackageCmd(cf))
 	chaincodeCmd.AddCommand(installCmd(cf))
+	chaincodeCmd.AddCommand(approveCmd(cf))
+	chaincodeCmd.AddCommand(commitCmd(cf))
+	chaincodeCmd.AddCommand(querycommittedCmd(cf))
 
 	return chaincodeCmd
 }
@@ -94,6 +110,12 @@ var (
 	orderingEndpoint  string
 	tls               bool
 	caFile            string
+	executeTimeout    int32
+	memoryLimit       int64
+	cpuShares         int64
+	maxConcurrency    int32
+	packageHash       string
+	initRequired      bool
 )
 
 var chaincodeCmd = &cobra.Command{
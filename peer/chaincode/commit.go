@@ -0,0 +1,89 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chaincode
+
+import (
+	"fmt"
+
+	protcommon "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/spf13/cobra"
+)
+
+var chaincodeCommitCmd *cobra.Command
+
+const commit_cmdname = "commit"
+
+const commit_desc = "Commit a chaincode definition approved by the organizations of the channel."
+
+// commitCmd returns the cobra command for Chaincode Commit
+func commitCmd(cf *ChaincodeCmdFactory) *cobra.Command {
+	chaincodeCommitCmd = &cobra.Command{
+		Use:       commit_cmdname,
+		Short:     fmt.Sprintf(commit_desc),
+		Long:      fmt.Sprintf(commit_desc),
+		ValidArgs: []string{"1"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return chaincodeCommit(cmd, args, cf)
+		},
+	}
+
+	return chaincodeCommitCmd
+}
+
+//commit the chaincode definition via Endorser
+func commit(cmd *cobra.Command, cf *ChaincodeCmdFactory) (*protcommon.Envelope, error) {
+	def, err := getChaincodeDefinition(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	creator, err := cf.Signer.Serialize()
+	if err != nil {
+		return nil, fmt.Errorf("Error serializing identity for %s: %s", cf.Signer.GetIdentifier(), err)
+	}
+
+	prop, _, err := utils.CreateCommitProposal(chainID, def, creator)
+	if err != nil {
+		return nil, fmt.Errorf("Error creating proposal  %s: %s", chainFuncName, err)
+	}
+
+	return endorseLifecycleProposal(prop, cf)
+}
+
+// chaincodeCommit commits the chaincode definition given on the command line.
+// The definition must match the one approved by the organizations
+func chaincodeCommit(cmd *cobra.Command, args []string, cf *ChaincodeCmdFactory) error {
+	var err error
+	if cf == nil {
+		cf, err = InitCmdFactory(true)
+		if err != nil {
+			return err
+		}
+	}
+	defer cf.BroadcastClient.Close()
+	env, err := commit(cmd, cf)
+	if err != nil {
+		return err
+	}
+
+	if env != nil {
+		err = cf.BroadcastClient.Send(env)
+	}
+
+	return err
+}
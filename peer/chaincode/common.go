@@ -17,15 +17,19 @@ limitations under the License.
 package chaincode
 
 import (
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
 	"strings"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/cauthdsl"
+	"github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/core/chaincode"
 	"github.com/hyperledger/fabric/core/chaincode/platforms"
+	"github.com/hyperledger/fabric/core/common/ccprovider"
 	"github.com/hyperledger/fabric/core/container"
 	"github.com/hyperledger/fabric/msp"
 	"github.com/hyperledger/fabric/peer/common"
@@ -66,10 +70,24 @@ func getChaincodeBytes(spec *pb.ChaincodeSpec, crtPkg bool) (*pb.ChaincodeDeploy
 			return nil, err
 		}
 	}
-	chaincodeDeploymentSpec := &pb.ChaincodeDeploymentSpec{ChaincodeSpec: spec, CodePackage: codePackageBytes}
+	chaincodeDeploymentSpec := &pb.ChaincodeDeploymentSpec{ChaincodeSpec: spec, CodePackage: codePackageBytes, ResourceLimits: getResourceLimits()}
 	return chaincodeDeploymentSpec, nil
 }
 
+// getResourceLimits returns the resource limits given on the command line or
+// nil if none were specified
+func getResourceLimits() *pb.ChaincodeResourceLimits {
+	if executeTimeout == 0 && memoryLimit == 0 && cpuShares == 0 && maxConcurrency == 0 {
+		return nil
+	}
+	return &pb.ChaincodeResourceLimits{
+		ExecuteTimeout: executeTimeout,
+		Memory:         memoryLimit,
+		CpuShares:      cpuShares,
+		MaxConcurrency: maxConcurrency,
+	}
+}
+
 func getChaincodeSpecification(cmd *cobra.Command) (*pb.ChaincodeSpec, error) {
 	spec := &pb.ChaincodeSpec{}
 	if err := checkChaincodeCmdParams(cmd); err != nil {
@@ -133,14 +151,16 @@ func checkChaincodeCmdParams(cmd *cobra.Command) error {
 		return fmt.Errorf("Must supply value for %s name parameter.", chainFuncName)
 	}
 
-	if cmd.Name() == instantiate_cmdname || cmd.Name() == install_cmdname || cmd.Name() == upgrade_cmdname {
+	if cmd.Name() == instantiate_cmdname || cmd.Name() == install_cmdname || cmd.Name() == upgrade_cmdname ||
+		cmd.Name() == approve_cmdname || cmd.Name() == commit_cmdname {
 		if chaincodeVersion == common.UndefinedParamValue {
 			return fmt.Errorf("Chaincode version is not provided for %s", cmd.Name())
 		}
 	}
 
-	// if it's not a deploy or an upgrade we don't need policy, escc and vscc
-	if cmd.Name() != instantiate_cmdname && cmd.Name() != upgrade_cmdname {
+	// if it's not a deploy, an upgrade or a chaincode definition we don't need policy, escc and vscc
+	if cmd.Name() != instantiate_cmdname && cmd.Name() != upgrade_cmdname &&
+		cmd.Name() != approve_cmdname && cmd.Name() != commit_cmdname {
 		if escc != common.UndefinedParamValue {
 			return errors.New("escc should be supplied only to chaincode deploy requests")
 		}
@@ -198,7 +218,7 @@ func checkChaincodeCmdParams(cmd *cobra.Command) error {
 			return errors.New("Non-empty JSON chaincode parameters must contain the following keys: 'Args' or 'Function' and 'Args'")
 		}
 	} else {
-		if cmd == nil || cmd != chaincodeInstallCmd {
+		if cmd == nil || (cmd != chaincodeInstallCmd && !isDefinitionCmd(cmd)) {
 			return errors.New("Empty JSON chaincode parameters must contain the following keys: 'Args' or 'Function' and 'Args'")
 		}
 	}
@@ -206,6 +226,73 @@ func checkChaincodeCmdParams(cmd *cobra.Command) error {
 	return nil
 }
 
+// isDefinitionCmd returns true for the commands managing chaincode
+// definitions, they do not take a constructor message
+func isDefinitionCmd(cmd *cobra.Command) bool {
+	return cmd.Name() == approve_cmdname || cmd.Name() == commit_cmdname || cmd.Name() == querycommitted_cmdname
+}
+
+// getChaincodeDefinition returns the marshalled chaincode definition given on
+// the command line. If no package hash is given the hash of the package
+// installed in the local chaincode path is used
+func getChaincodeDefinition(cmd *cobra.Command) ([]byte, error) {
+	if err := checkChaincodeCmdParams(cmd); err != nil {
+		return nil, err
+	}
+
+	var hash []byte
+	if packageHash != common.UndefinedParamValue {
+		var err error
+		hash, err = hex.DecodeString(packageHash)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid package hash %s: %s", packageHash, err)
+		}
+	} else {
+		pkg, err := ccprovider.GetChaincodePackage(chaincodeName, chaincodeVersion)
+		if err != nil {
+			return nil, fmt.Errorf("Package hash not provided and chaincode %s:%s is not installed locally: %s", chaincodeName, chaincodeVersion, err)
+		}
+		hash = util.ComputeSHA256(pkg)
+	}
+
+	def := &pb.ChaincodeDefinition{
+		Name:              chaincodeName,
+		Version:           chaincodeVersion,
+		PackageHash:       hash,
+		EndorsementPolicy: policyMarhsalled,
+		InitRequired:      initRequired,
+		Escc:              escc,
+		Vscc:              vscc,
+	}
+	return proto.Marshal(def)
+}
+
+// endorseLifecycleProposal signs and endorses a proposal to lccc and
+// assembles the resulting transaction
+func endorseLifecycleProposal(prop *pb.Proposal, cf *ChaincodeCmdFactory) (*pcommon.Envelope, error) {
+	signedProp, err := putils.GetSignedProposal(prop, cf.Signer)
+	if err != nil {
+		return nil, fmt.Errorf("Error creating signed proposal  %s: %s", chainFuncName, err)
+	}
+
+	proposalResponse, err := cf.EndorserClient.ProcessProposal(context.Background(), signedProp)
+	if err != nil {
+		return nil, fmt.Errorf("Error endorsing %s: %s", chainFuncName, err)
+	}
+
+	if proposalResponse == nil {
+		return nil, nil
+	}
+
+	// assemble a signed transaction (it's an Envelope message)
+	env, err := putils.CreateSignedTx(prop, cf.Signer, proposalResponse)
+	if err != nil {
+		return nil, fmt.Errorf("Could not assemble transaction, err %s", err)
+	}
+
+	return env, nil
+}
+
 // ChaincodeCmdFactory holds the clients used by ChaincodeCmd
 type ChaincodeCmdFactory struct {
 	EndorserClient  pb.EndorserClient
@@ -267,7 +354,11 @@ func ChaincodeInvokeOrQuery(spec *pb.ChaincodeSpec, cID string, invoke bool, sig
 	}
 
 	var prop *pb.Proposal
-	prop, _, err = putils.CreateProposalFromCIS(pcommon.HeaderType_ENDORSER_TRANSACTION, cID, invocation, creator)
+	if invoke {
+		prop, _, err = putils.CreateProposalFromCIS(pcommon.HeaderType_ENDORSER_TRANSACTION, cID, invocation, creator)
+	} else {
+		prop, _, err = putils.CreateQueryProposalFromCIS(pcommon.HeaderType_ENDORSER_TRANSACTION, cID, invocation, creator)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("Error creating proposal  %s: %s", funcName, err)
 	}
@@ -284,6 +375,10 @@ func ChaincodeInvokeOrQuery(spec *pb.ChaincodeSpec, cID string, invoke bool, sig
 		return nil, fmt.Errorf("Error endorsing %s: %s", funcName, err)
 	}
 
+	if proposalResp != nil && proposalResp.Response != nil && proposalResp.Response.Status == int32(pcommon.Status_SERVICE_UNAVAILABLE) {
+		return proposalResp, fmt.Errorf("Endorser is busy, retry the %s later: %s", funcName, proposalResp.Response.Message)
+	}
+
 	if invoke {
 		if proposalResp != nil {
 			// assemble a signed transaction (it's an Envelope message)
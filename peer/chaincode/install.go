@@ -18,9 +18,11 @@ package chaincode
 
 import (
 	"fmt"
+	"io/ioutil"
 
 	"golang.org/x/net/context"
 
+	"github.com/hyperledger/fabric/core/common/ccpackage"
 	"github.com/hyperledger/fabric/core/common/ccprovider"
 	"github.com/hyperledger/fabric/peer/common"
 	pb "github.com/hyperledger/fabric/protos/peer"
@@ -33,7 +35,7 @@ var chaincodeInstallCmd *cobra.Command
 
 const install_cmdname = "install"
 
-const install_desc = "Package the specified chaincode into a deployment spec and save it on the peer's path."
+const install_desc = "Package the specified chaincode into a deployment spec, or read a package file, and save it on the peer's path."
 
 // installCmd returns the cobra command for Chaincode Deploy
 func installCmd(cf *ChaincodeCmdFactory) *cobra.Command {
@@ -62,6 +64,27 @@ func install(chaincodeName string, chaincodeVersion string, cds *pb.ChaincodeDep
 		return fmt.Errorf("Error creating proposal  %s: %s", chainFuncName, err)
 	}
 
+	return sendInstallProposal(prop, cf)
+}
+
+//install the package read from a file to "peer.address"
+func installPackage(pkg []byte, cf *ChaincodeCmdFactory) error {
+	creator, err := cf.Signer.Serialize()
+	if err != nil {
+		return fmt.Errorf("Error serializing identity for %s: %s", cf.Signer.GetIdentifier(), err)
+	}
+
+	prop, _, err := utils.CreateInstallProposalFromPackage(pkg, creator)
+	if err != nil {
+		return fmt.Errorf("Error creating proposal  %s: %s", chainFuncName, err)
+	}
+
+	return sendInstallProposal(prop, cf)
+}
+
+//sign and send an install proposal
+func sendInstallProposal(prop *pb.Proposal, cf *ChaincodeCmdFactory) error {
+	var err error
 	var signedProp *pb.SignedProposal
 	signedProp, err = utils.GetSignedProposal(prop, cf.Signer)
 	if err != nil {
@@ -80,8 +103,28 @@ func install(chaincodeName string, chaincodeVersion string, cds *pb.ChaincodeDep
 	return nil
 }
 
+// getPackageDeploymentSpec returns the deployment spec of a package file,
+// which is either a raw deployment spec or a signed package
+func getPackageDeploymentSpec(pkg []byte) (*pb.ChaincodeDeploymentSpec, error) {
+	env, ok := ccpackage.GetSignedPackage(pkg)
+	if !ok {
+		return utils.GetChaincodeDeploymentSpec(pkg)
+	}
+
+	scds, err := ccpackage.ExtractSignedCCDepSpec(env)
+	if err != nil {
+		return nil, err
+	}
+
+	return utils.GetChaincodeDeploymentSpec(scds.ChaincodeDeploymentSpec)
+}
+
 // chaincodeInstall installs the chaincode. If remoteinstall, does it via a lccc call
 func chaincodeInstall(cmd *cobra.Command, args []string, cf *ChaincodeCmdFactory) error {
+	if len(args) == 1 {
+		return chaincodeInstallPackage(args[0], cf)
+	}
+
 	if chaincodePath == common.UndefinedParamValue || chaincodeVersion == common.UndefinedParamValue {
 		return fmt.Errorf("Must supply value for %s path and version parameters.", chainFuncName)
 	}
@@ -113,3 +156,28 @@ func chaincodeInstall(cmd *cobra.Command, args []string, cf *ChaincodeCmdFactory
 
 	return err
 }
+
+// chaincodeInstallPackage installs the package read from the given file.
+// The signatures of a signed package are verified by the peer
+func chaincodeInstallPackage(file string, cf *ChaincodeCmdFactory) error {
+	pkg, err := ioutil.ReadFile(file)
+	if err != nil {
+		return err
+	}
+
+	cds, err := getPackageDeploymentSpec(pkg)
+	if err != nil {
+		return fmt.Errorf("Invalid chaincode package %s: %s", file, err)
+	}
+
+	if cf == nil {
+		cf, err = InitCmdFactory(false)
+		if err != nil {
+			return err
+		}
+	}
+
+	logger.Debugf("Installing package of chaincode %s:%s", cds.ChaincodeSpec.ChaincodeId.Name, cds.ChaincodeSpec.ChaincodeId.Version)
+
+	return installPackage(pkg, cf)
+}
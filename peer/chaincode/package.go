@@ -22,14 +22,30 @@ import (
 	"io/ioutil"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/cauthdsl"
+	"github.com/hyperledger/fabric/core/common/ccpackage"
+	"github.com/hyperledger/fabric/msp"
+	mspmgmt "github.com/hyperledger/fabric/msp/mgmt"
+	"github.com/hyperledger/fabric/peer/common"
+	pcommon "github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
 	"github.com/spf13/cobra"
 )
 
+var chaincodePackageCmd *cobra.Command
+
 const package_desc = "Package the specified chaincode into a deployment spec."
 
+// Package-related variables.
+var (
+	createSignedCCDepSpec bool
+	signCCDepSpec         bool
+	ownerPolicy           string
+)
+
 // deployCmd returns the cobra command for Chaincode Deploy
 func packageCmd(cf *ChaincodeCmdFactory) *cobra.Command {
-	chaincodeInstantiateCmd = &cobra.Command{
+	chaincodePackageCmd = &cobra.Command{
 		Use:       "package",
 		Short:     package_desc,
 		Long:      package_desc,
@@ -39,7 +55,33 @@ func packageCmd(cf *ChaincodeCmdFactory) *cobra.Command {
 		},
 	}
 
-	return chaincodeInstantiateCmd
+	flags := chaincodePackageCmd.Flags()
+	flags.BoolVarP(&createSignedCCDepSpec, "signed", "s", false,
+		fmt.Sprint("Create a package to be signed by the chaincode owners instead of a raw deployment spec"))
+	flags.BoolVarP(&signCCDepSpec, "sign", "S", false,
+		fmt.Sprint("Sign the package with the local identity, requires --signed"))
+	flags.StringVarP(&ownerPolicy, "ownerpolicy", "", common.UndefinedParamValue,
+		fmt.Sprint("The policy the owner signatures of the package must satisfy, defaults to an admin of the local MSP"))
+
+	return chaincodePackageCmd
+}
+
+// getOwnerPolicy returns the owner policy given on the command line or the
+// policy requiring a signature of an admin of the local MSP
+func getOwnerPolicy() (*pcommon.SignaturePolicyEnvelope, error) {
+	if ownerPolicy == common.UndefinedParamValue {
+		mspid, err := mspmgmt.GetLocalMSP().GetIdentifier()
+		if err != nil {
+			return nil, fmt.Errorf("Error getting local MSP identifier: %s", err)
+		}
+		return cauthdsl.SignedByMspAdmin(mspid), nil
+	}
+
+	p, err := cauthdsl.FromString(ownerPolicy)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid owner policy %s", ownerPolicy)
+	}
+	return p, nil
 }
 
 // chaincodeDeploy deploys the chaincode. On success, the chaincode name
@@ -57,7 +99,16 @@ func chaincodePackage(cmd *cobra.Command, args []string, cf *ChaincodeCmdFactory
 		return fmt.Errorf("Error getting chaincode code %s: %s", chainFuncName, err)
 	}
 
-	cdsBytes, err := proto.Marshal(cds)
+	if signCCDepSpec && !createSignedCCDepSpec {
+		return fmt.Errorf("Only signed packages (--signed) can be signed")
+	}
+
+	var cdsBytes []byte
+	if createSignedCCDepSpec {
+		cdsBytes, err = createSignedPackage(cds, cf)
+	} else {
+		cdsBytes, err = proto.Marshal(cds)
+	}
 	if err != nil {
 		return fmt.Errorf("Error marshalling chaincode deployment spec : %s", err)
 	}
@@ -71,3 +122,28 @@ func chaincodePackage(cmd *cobra.Command, args []string, cf *ChaincodeCmdFactory
 
 	return err
 }
+
+// createSignedPackage returns a package of the deployment spec for the owner
+// signatures, signed by the local identity if --sign was given
+func createSignedPackage(cds *pb.ChaincodeDeploymentSpec, cf *ChaincodeCmdFactory) ([]byte, error) {
+	policy, err := getOwnerPolicy()
+	if err != nil {
+		return nil, err
+	}
+
+	var owner msp.SigningIdentity
+	if signCCDepSpec {
+		if cf != nil {
+			owner = cf.Signer
+		} else if owner, err = common.GetDefaultSigner(); err != nil {
+			return nil, fmt.Errorf("Error getting default signer: %s", err)
+		}
+	}
+
+	env, err := ccpackage.OwnerCreateSignedCCDepSpec(cds, policy, owner)
+	if err != nil {
+		return nil, err
+	}
+
+	return proto.Marshal(env)
+}
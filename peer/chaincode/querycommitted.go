@@ -0,0 +1,94 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chaincode
+
+import (
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/peer/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/spf13/cobra"
+	"golang.org/x/net/context"
+)
+
+const querycommitted_cmdname = "querycommitted"
+
+const querycommitted_desc = "Query the committed definition of a chaincode on the channel."
+
+// querycommittedCmd returns the cobra command for Chaincode QueryCommitted
+func querycommittedCmd(cf *ChaincodeCmdFactory) *cobra.Command {
+	return &cobra.Command{
+		Use:       querycommitted_cmdname,
+		Short:     fmt.Sprintf(querycommitted_desc),
+		Long:      fmt.Sprintf(querycommitted_desc),
+		ValidArgs: []string{"1"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return chaincodeQueryCommitted(cmd, args, cf)
+		},
+	}
+}
+
+// chaincodeQueryCommitted prints the committed definition of the chaincode
+func chaincodeQueryCommitted(cmd *cobra.Command, args []string, cf *ChaincodeCmdFactory) error {
+	if chaincodeName == common.UndefinedParamValue {
+		return fmt.Errorf("Must supply value for %s name parameter.", chainFuncName)
+	}
+
+	var err error
+	if cf == nil {
+		cf, err = InitCmdFactory(false)
+		if err != nil {
+			return err
+		}
+	}
+
+	creator, err := cf.Signer.Serialize()
+	if err != nil {
+		return fmt.Errorf("Error serializing identity for %s: %s", cf.Signer.GetIdentifier(), err)
+	}
+
+	prop, _, err := utils.CreateQueryCommittedProposal(chainID, chaincodeName, creator)
+	if err != nil {
+		return fmt.Errorf("Error creating proposal  %s: %s", chainFuncName, err)
+	}
+
+	signedProp, err := utils.GetSignedProposal(prop, cf.Signer)
+	if err != nil {
+		return fmt.Errorf("Error creating signed proposal  %s: %s", chainFuncName, err)
+	}
+
+	proposalResponse, err := cf.EndorserClient.ProcessProposal(context.Background(), signedProp)
+	if err != nil {
+		return fmt.Errorf("Error endorsing %s: %s", chainFuncName, err)
+	}
+
+	if proposalResponse.Response == nil || proposalResponse.Response.Status != 200 {
+		return fmt.Errorf("Error querying committed definition of %s: %v", chaincodeName, proposalResponse.Response)
+	}
+
+	def := &pb.ChaincodeDefinition{}
+	if err = proto.Unmarshal(proposalResponse.Response.Payload, def); err != nil {
+		return fmt.Errorf("Error unmarshalling chaincode definition: %s", err)
+	}
+
+	fmt.Printf("Committed chaincode definition: name %s, version %s, package hash %x, init required %t, escc %s, vscc %s\n",
+		def.Name, def.Version, def.PackageHash, def.InitRequired, def.Escc, def.Vscc)
+
+	return nil
+}
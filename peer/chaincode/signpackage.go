@@ -0,0 +1,90 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chaincode
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/core/common/ccpackage"
+	"github.com/hyperledger/fabric/msp"
+	"github.com/hyperledger/fabric/peer/common"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/spf13/cobra"
+)
+
+const signpackage_desc = "Sign the specified chaincode package with the local identity."
+
+// signpackageCmd returns the cobra command for signing a package
+func signpackageCmd(cf *ChaincodeCmdFactory) *cobra.Command {
+	return &cobra.Command{
+		Use:       "signpackage",
+		Short:     signpackage_desc,
+		Long:      signpackage_desc,
+		ValidArgs: []string{"2"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return signpackage(cmd, args, cf)
+		},
+	}
+}
+
+// signpackage adds the signature of the local identity to the package read
+// from the first argument and writes the result to the second argument
+func signpackage(cmd *cobra.Command, args []string, cf *ChaincodeCmdFactory) error {
+	if len(args) != 2 {
+		return fmt.Errorf("Usage: signpackage <input package> <output package>")
+	}
+
+	var signer msp.SigningIdentity
+	if cf != nil {
+		signer = cf.Signer
+	} else {
+		var err error
+		if signer, err = common.GetDefaultSigner(); err != nil {
+			return fmt.Errorf("Error getting default signer: %s", err)
+		}
+	}
+
+	b, err := ioutil.ReadFile(args[0])
+	if err != nil {
+		return err
+	}
+
+	env, err := utils.UnmarshalEnvelope(b)
+	if err != nil {
+		return fmt.Errorf("%s is not a signed chaincode package: %s", args[0], err)
+	}
+
+	env, err = ccpackage.SignExistingPackage(env, signer)
+	if err != nil {
+		return err
+	}
+
+	b, err = proto.Marshal(env)
+	if err != nil {
+		return fmt.Errorf("Error marshalling signed package: %s", err)
+	}
+
+	if err = ioutil.WriteFile(args[1], b, 0700); err != nil {
+		logger.Errorf("Failed writing signed package to file [%s]: [%s]", args[1], err)
+		return err
+	}
+
+	logger.Infof("Wrote signed package to %s", args[1])
+	return nil
+}
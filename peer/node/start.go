@@ -30,7 +30,9 @@ import (
 
 	genesisconfig "github.com/hyperledger/fabric/common/configtx/tool/localconfig"
 	"github.com/hyperledger/fabric/common/configtx/tool/provisional"
+	commonledger "github.com/hyperledger/fabric/common/ledger"
 	"github.com/hyperledger/fabric/common/localmsp"
+	"github.com/hyperledger/fabric/common/policies"
 	"github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/core"
 	"github.com/hyperledger/fabric/core/chaincode"
@@ -309,9 +311,24 @@ func createEventHubServer(secureConfig comm.SecureServerConfig) (comm.GRPCServer
 		viper.GetInt("peer.events.timeout"))
 
 	pb.RegisterEventsServer(grpcServer.Server(), ehServer)
+	pb.RegisterChaincodeEventsServer(grpcServer.Server(), producer.NewReplayServer(&replaySupport{}))
 	return grpcServer, nil
 }
 
+//replaySupport gives the chaincode events replay server access to the
+//ledgers and policies of the channels joined by the peer
+type replaySupport struct{}
+
+func (*replaySupport) GetLedger(chainID string) (commonledger.Ledger, bool) {
+	lgr := peer.GetLedger(chainID)
+	return lgr, lgr != nil
+}
+
+func (*replaySupport) GetPolicyManager(chainID string) (policies.Manager, bool) {
+	policyManager := peer.GetPolicyManager(chainID)
+	return policyManager, policyManager != nil
+}
+
 func writePid(fileName string, pid int) error {
 	err := os.MkdirAll(filepath.Dir(fileName), 0755)
 	if err != nil {
@@ -76,6 +76,7 @@ const (
 	Status_BAD_REQUEST              Status = 400
 	Status_FORBIDDEN                Status = 403
 	Status_NOT_FOUND                Status = 404
+	Status_CONFLICT                 Status = 409
 	Status_REQUEST_ENTITY_TOO_LARGE Status = 413
 	Status_INTERNAL_SERVER_ERROR    Status = 500
 	Status_SERVICE_UNAVAILABLE      Status = 503
@@ -87,6 +88,7 @@ var Status_name = map[int32]string{
 	400: "BAD_REQUEST",
 	403: "FORBIDDEN",
 	404: "NOT_FOUND",
+	409: "CONFLICT",
 	413: "REQUEST_ENTITY_TOO_LARGE",
 	500: "INTERNAL_SERVER_ERROR",
 	503: "SERVICE_UNAVAILABLE",
@@ -97,6 +99,7 @@ var Status_value = map[string]int32{
 	"BAD_REQUEST":              400,
 	"FORBIDDEN":                403,
 	"NOT_FOUND":                404,
+	"CONFLICT":                 409,
 	"REQUEST_ENTITY_TOO_LARGE": 413,
 	"INTERNAL_SERVER_ERROR":    500,
 	"SERVICE_UNAVAILABLE":      503,
@@ -116,6 +119,7 @@ const (
 	HeaderType_ENDORSER_TRANSACTION HeaderType = 3
 	HeaderType_ORDERER_TRANSACTION  HeaderType = 4
 	HeaderType_DELIVER_SEEK_INFO    HeaderType = 5
+	HeaderType_CHAINCODE_PACKAGE    HeaderType = 6
 )
 
 var HeaderType_name = map[int32]string{
@@ -125,6 +129,7 @@ var HeaderType_name = map[int32]string{
 	3: "ENDORSER_TRANSACTION",
 	4: "ORDERER_TRANSACTION",
 	5: "DELIVER_SEEK_INFO",
+	6: "CHAINCODE_PACKAGE",
 }
 var HeaderType_value = map[string]int32{
 	"MESSAGE":              0,
@@ -133,6 +138,7 @@ var HeaderType_value = map[string]int32{
 	"ENDORSER_TRANSACTION": 3,
 	"ORDERER_TRANSACTION":  4,
 	"DELIVER_SEEK_INFO":    5,
+	"CHAINCODE_PACKAGE":    6,
 }
 
 func (x HeaderType) String() string {
@@ -391,60 +397,61 @@ func init() {
 func init() { proto.RegisterFile("common/common.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 870 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0x84, 0x55, 0xdd, 0x6e, 0xe3, 0x44,
-	0x14, 0xae, 0xe3, 0xfc, 0x34, 0xc7, 0x4d, 0x3b, 0x9d, 0x6c, 0x59, 0x53, 0x58, 0x6d, 0x64, 0xb4,
-	0xa8, 0xb4, 0x22, 0x11, 0xe5, 0x06, 0x2e, 0x9d, 0x78, 0xd2, 0xb5, 0x36, 0x6b, 0x2f, 0x63, 0x67,
-	0x11, 0xbb, 0x48, 0xd6, 0x24, 0x99, 0x26, 0x16, 0x89, 0x1d, 0xd9, 0x4e, 0xd5, 0xde, 0xf2, 0x00,
-	0x08, 0x09, 0x6e, 0x79, 0x01, 0x9e, 0x84, 0xb7, 0xe0, 0x25, 0x90, 0xb8, 0x45, 0xf6, 0xd8, 0xde,
-	0x24, 0xac, 0xb4, 0x57, 0x99, 0xef, 0x9c, 0xcf, 0xe7, 0x7c, 0xf3, 0x9d, 0x13, 0x1b, 0xda, 0xd3,
-	0x70, 0xb5, 0x0a, 0x83, 0x9e, 0xf8, 0xe9, 0xae, 0xa3, 0x30, 0x09, 0x71, 0x5d, 0xa0, 0xf3, 0xa7,
-	0xf3, 0x30, 0x9c, 0x2f, 0x79, 0x2f, 0x8b, 0x4e, 0x36, 0xb7, 0xbd, 0xc4, 0x5f, 0xf1, 0x38, 0x61,
-	0xab, 0xb5, 0x20, 0x6a, 0x1a, 0xc0, 0x88, 0xc5, 0xc9, 0x20, 0x0c, 0x6e, 0xfd, 0x39, 0x7e, 0x04,
-	0x35, 0x3f, 0x98, 0xf1, 0x7b, 0x55, 0xea, 0x48, 0x17, 0x55, 0x2a, 0x80, 0xf6, 0x16, 0x0e, 0x5f,
-	0xf2, 0x84, 0xcd, 0x58, 0xc2, 0x52, 0xc6, 0x1d, 0x5b, 0x6e, 0x78, 0xc6, 0x38, 0xa2, 0x02, 0xe0,
-	0x6f, 0x01, 0x62, 0x7f, 0x1e, 0xb0, 0x64, 0x13, 0xf1, 0x58, 0xad, 0x74, 0xe4, 0x0b, 0xe5, 0xfa,
-	0xe3, 0x6e, 0xae, 0xa8, 0x78, 0xd6, 0x29, 0x18, 0x74, 0x8b, 0xac, 0xfd, 0x08, 0xa7, 0xff, 0x23,
-	0xe0, 0x2f, 0x00, 0x95, 0x14, 0x6f, 0xc1, 0xd9, 0x8c, 0x47, 0x79, 0xc3, 0x93, 0x32, 0xfe, 0x3c,
-	0x0b, 0xe3, 0x4f, 0xa1, 0x59, 0x86, 0xd4, 0x4a, 0xc6, 0x79, 0x17, 0xd0, 0xde, 0x40, 0x3d, 0xe7,
-	0x3d, 0x83, 0xe3, 0xe9, 0x82, 0x05, 0x01, 0x5f, 0xee, 0x16, 0x6c, 0xe5, 0xd1, 0x9c, 0xf6, 0xbe,
-	0xce, 0x95, 0xf7, 0x76, 0xd6, 0xfe, 0x96, 0xa0, 0x35, 0xd8, 0x79, 0x18, 0x43, 0x35, 0x79, 0x58,
-	0x0b, 0x6f, 0x6a, 0x34, 0x3b, 0x63, 0x15, 0x1a, 0x77, 0x3c, 0x8a, 0xfd, 0x30, 0xc8, 0xea, 0xd4,
-	0x68, 0x01, 0xf1, 0x37, 0xd0, 0x2c, 0xa7, 0xa1, 0xca, 0x1d, 0xe9, 0x42, 0xb9, 0x3e, 0xef, 0x8a,
-	0x79, 0x75, 0x8b, 0x79, 0x75, 0xdd, 0x82, 0x41, 0xdf, 0x91, 0xf1, 0x13, 0x80, 0xe2, 0x2e, 0xfe,
-	0x4c, 0xad, 0x76, 0xa4, 0x8b, 0x26, 0x6d, 0xe6, 0x11, 0x73, 0x86, 0xdb, 0x50, 0x4b, 0xee, 0xd3,
-	0x4c, 0x2d, 0xcb, 0x54, 0x93, 0x7b, 0x73, 0x96, 0x0e, 0x8e, 0xaf, 0xc3, 0xe9, 0x42, 0xad, 0x8b,
-	0xd1, 0x66, 0x20, 0x75, 0x8f, 0xdf, 0x27, 0x3c, 0xc8, 0xf4, 0x35, 0x84, 0x7b, 0x65, 0x40, 0xd3,
-	0xe1, 0xc4, 0xd9, 0xb3, 0x5b, 0x85, 0xc6, 0x34, 0xe2, 0x2c, 0x09, 0x0b, 0xff, 0x0a, 0x98, 0x36,
-	0x08, 0xc2, 0x60, 0x5a, 0x0c, 0x41, 0x00, 0x8d, 0x40, 0xe3, 0x15, 0x7b, 0x58, 0x86, 0x6c, 0x86,
-	0x3f, 0x87, 0xfa, 0x96, 0xf3, 0xca, 0xf5, 0x71, 0xb1, 0x20, 0xa2, 0x34, 0xcd, 0xb3, 0xa9, 0x8b,
-	0xe9, 0x36, 0xe4, 0x75, 0xb2, 0xb3, 0xd6, 0x87, 0x43, 0x12, 0xdc, 0xf1, 0x65, 0x28, 0x1c, 0x5d,
-	0x8b, 0x92, 0x85, 0x84, 0x1c, 0x7e, 0x60, 0x17, 0x7e, 0x91, 0xa0, 0xd6, 0x5f, 0x86, 0xd3, 0x9f,
-	0xf0, 0xd5, 0x9e, 0x92, 0x76, 0xa1, 0x24, 0x4b, 0xef, 0xc9, 0x79, 0xb6, 0x25, 0x47, 0xb9, 0x3e,
-	0xdd, 0xa1, 0x1a, 0x2c, 0x61, 0x42, 0x21, 0xfe, 0x0a, 0x0e, 0x57, 0xf9, 0x1e, 0xe7, 0xc3, 0x3c,
-	0xdb, 0xa1, 0x16, 0x4b, 0x4e, 0x4b, 0x9a, 0x36, 0x07, 0x65, 0xab, 0x21, 0xfe, 0x08, 0xea, 0xc1,
-	0x66, 0x35, 0xc9, 0x55, 0x55, 0x69, 0x8e, 0xf0, 0x67, 0xd0, 0x5a, 0x47, 0xfc, 0xce, 0x0f, 0x37,
-	0xb1, 0xb7, 0x60, 0xf1, 0x22, 0xbf, 0xd9, 0x51, 0x11, 0x7c, 0xce, 0xe2, 0x05, 0xfe, 0x04, 0x9a,
-	0x69, 0x4d, 0x41, 0x90, 0x33, 0xc2, 0x61, 0x1a, 0x48, 0x93, 0xda, 0x53, 0x68, 0x96, 0x72, 0x4b,
-	0x7b, 0xa5, 0x8e, 0x5c, 0xda, 0x7b, 0x05, 0xad, 0x1d, 0x91, 0xf8, 0x7c, 0xeb, 0x36, 0x82, 0x58,
-	0xe2, 0xcb, 0x3f, 0x25, 0xa8, 0x3b, 0x09, 0x4b, 0x36, 0x31, 0x56, 0xa0, 0x31, 0xb6, 0x5e, 0x58,
-	0xf6, 0xf7, 0x16, 0x3a, 0xc0, 0x47, 0xd0, 0x70, 0xc6, 0x83, 0x01, 0x71, 0x1c, 0xf4, 0x97, 0x84,
-	0x11, 0x28, 0x7d, 0xdd, 0xf0, 0x28, 0xf9, 0x6e, 0x4c, 0x1c, 0x17, 0xfd, 0x2a, 0xe3, 0x63, 0x68,
-	0x0e, 0x6d, 0xda, 0x37, 0x0d, 0x83, 0x58, 0xe8, 0xb7, 0x0c, 0x5b, 0xb6, 0xeb, 0x0d, 0xed, 0xb1,
-	0x65, 0xa0, 0xdf, 0x65, 0xfc, 0x04, 0xd4, 0x9c, 0xed, 0x11, 0xcb, 0x35, 0xdd, 0x1f, 0x3c, 0xd7,
-	0xb6, 0xbd, 0x91, 0x4e, 0x6f, 0x08, 0xfa, 0x43, 0xc6, 0xe7, 0x70, 0x66, 0x5a, 0x2e, 0xa1, 0x96,
-	0x3e, 0xf2, 0x1c, 0x42, 0x5f, 0x13, 0xea, 0x11, 0x4a, 0x6d, 0x8a, 0xfe, 0x91, 0xb1, 0x0a, 0xed,
-	0x34, 0x64, 0x0e, 0x88, 0x37, 0xb6, 0xf4, 0xd7, 0xba, 0x39, 0xd2, 0xfb, 0x23, 0x82, 0xfe, 0x95,
-	0x2f, 0x7f, 0x96, 0x00, 0x84, 0xbf, 0x6e, 0xfa, 0x6f, 0x54, 0xa0, 0xf1, 0x92, 0x38, 0x8e, 0x7e,
-	0x43, 0xd0, 0x01, 0x06, 0xa8, 0x0f, 0x6c, 0x6b, 0x68, 0xde, 0x20, 0x09, 0x9f, 0x42, 0x4b, 0x9c,
-	0xbd, 0xf1, 0x2b, 0x43, 0x77, 0x09, 0xaa, 0x60, 0x15, 0x1e, 0x11, 0xcb, 0xb0, 0xa9, 0x43, 0xa8,
-	0xe7, 0x52, 0xdd, 0x72, 0xf4, 0x81, 0x6b, 0xda, 0x16, 0x92, 0xf1, 0x63, 0x68, 0xdb, 0xd4, 0x20,
-	0x74, 0x2f, 0x51, 0xc5, 0x67, 0x70, 0x6a, 0x90, 0x91, 0x99, 0x6a, 0x73, 0x08, 0x79, 0xe1, 0x99,
-	0xd6, 0xd0, 0x46, 0xb5, 0xcb, 0xb7, 0x80, 0x77, 0xec, 0x35, 0xd3, 0xd7, 0x2a, 0x3e, 0x06, 0x70,
-	0xcc, 0x1b, 0x4b, 0x77, 0xc7, 0x94, 0x38, 0xe8, 0x00, 0x9f, 0x80, 0x32, 0xd2, 0x1d, 0xd7, 0x2b,
-	0x35, 0x3d, 0x86, 0xf6, 0x56, 0x79, 0xc7, 0x1b, 0x9a, 0x23, 0x97, 0x50, 0x54, 0x49, 0x6f, 0x91,
-	0xf7, 0x47, 0x72, 0xff, 0xcb, 0x37, 0x57, 0x73, 0x3f, 0x59, 0x6c, 0x26, 0xe9, 0xba, 0xf5, 0x16,
-	0x0f, 0x6b, 0x1e, 0x2d, 0xf9, 0x6c, 0xce, 0xa3, 0xde, 0x2d, 0x9b, 0x44, 0xfe, 0x54, 0xbc, 0xfb,
-	0xe3, 0xfc, 0xfb, 0x30, 0xa9, 0x67, 0xf0, 0xeb, 0xff, 0x02, 0x00, 0x00, 0xff, 0xff, 0xa2, 0xa7,
-	0x76, 0x1e, 0x37, 0x06, 0x00, 0x00,
+	// 892 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0x84, 0x55, 0xd1, 0x6e, 0xe3, 0x44,
+	0x14, 0xad, 0xe3, 0xc4, 0x49, 0x6e, 0x9a, 0x76, 0x3a, 0xd9, 0xb2, 0xa6, 0xb0, 0xda, 0xc8, 0x68,
+	0x51, 0x69, 0x45, 0x22, 0xca, 0x0b, 0x3c, 0x3a, 0xf6, 0xa4, 0xb5, 0x9a, 0xb5, 0xcb, 0xd8, 0x59,
+	0xc4, 0x2e, 0x92, 0xe5, 0x24, 0xd3, 0x24, 0x22, 0xb1, 0xa3, 0xd8, 0xa9, 0xda, 0x9f, 0x40, 0x48,
+	0xf0, 0xb2, 0x0f, 0x7c, 0x08, 0x7f, 0xc0, 0x5f, 0xf0, 0x13, 0x48, 0xbc, 0xa2, 0xf1, 0xd8, 0xde,
+	0xa4, 0x54, 0xe2, 0xa9, 0x3e, 0x67, 0x8e, 0xef, 0x3d, 0x73, 0xee, 0x6d, 0x0c, 0xad, 0x71, 0xb4,
+	0x5c, 0x46, 0x61, 0x57, 0xfc, 0xe9, 0xac, 0xd6, 0x51, 0x12, 0x61, 0x45, 0xa0, 0x93, 0x97, 0xd3,
+	0x28, 0x9a, 0x2e, 0x58, 0x37, 0x65, 0x47, 0x9b, 0xdb, 0x6e, 0x32, 0x5f, 0xb2, 0x38, 0x09, 0x96,
+	0x2b, 0x21, 0xd4, 0x34, 0x80, 0x41, 0x10, 0x27, 0x46, 0x14, 0xde, 0xce, 0xa7, 0xf8, 0x19, 0x54,
+	0xe6, 0xe1, 0x84, 0xdd, 0xab, 0x52, 0x5b, 0x3a, 0x2d, 0x53, 0x01, 0xb4, 0x77, 0x50, 0x7b, 0xcd,
+	0x92, 0x60, 0x12, 0x24, 0x01, 0x57, 0xdc, 0x05, 0x8b, 0x0d, 0x4b, 0x15, 0xfb, 0x54, 0x00, 0xfc,
+	0x2d, 0x40, 0x3c, 0x9f, 0x86, 0x41, 0xb2, 0x59, 0xb3, 0x58, 0x2d, 0xb5, 0xe5, 0xd3, 0xc6, 0xc5,
+	0xc7, 0x9d, 0xcc, 0x51, 0xfe, 0xae, 0x9b, 0x2b, 0xe8, 0x96, 0x58, 0xfb, 0x11, 0x8e, 0xfe, 0x23,
+	0xc0, 0x5f, 0x00, 0x2a, 0x24, 0xfe, 0x8c, 0x05, 0x13, 0xb6, 0xce, 0x1a, 0x1e, 0x16, 0xfc, 0x55,
+	0x4a, 0xe3, 0x4f, 0xa1, 0x5e, 0x50, 0x6a, 0x29, 0xd5, 0x7c, 0x20, 0xb4, 0xb7, 0xa0, 0x64, 0xba,
+	0x57, 0x70, 0x30, 0x9e, 0x05, 0x61, 0xc8, 0x16, 0xbb, 0x05, 0x9b, 0x19, 0x9b, 0xc9, 0x9e, 0xea,
+	0x5c, 0x7a, 0xb2, 0xb3, 0xf6, 0x97, 0x04, 0x4d, 0x63, 0xe7, 0x65, 0x0c, 0xe5, 0xe4, 0x61, 0x25,
+	0xb2, 0xa9, 0xd0, 0xf4, 0x19, 0xab, 0x50, 0xbd, 0x63, 0xeb, 0x78, 0x1e, 0x85, 0x69, 0x9d, 0x0a,
+	0xcd, 0x21, 0xfe, 0x06, 0xea, 0xc5, 0x34, 0x54, 0xb9, 0x2d, 0x9d, 0x36, 0x2e, 0x4e, 0x3a, 0x62,
+	0x5e, 0x9d, 0x7c, 0x5e, 0x1d, 0x2f, 0x57, 0xd0, 0x0f, 0x62, 0xfc, 0x02, 0x20, 0xbf, 0xcb, 0x7c,
+	0xa2, 0x96, 0xdb, 0xd2, 0x69, 0x9d, 0xd6, 0x33, 0xc6, 0x9a, 0xe0, 0x16, 0x54, 0x92, 0x7b, 0x7e,
+	0x52, 0x49, 0x4f, 0xca, 0xc9, 0xbd, 0x35, 0xe1, 0x83, 0x63, 0xab, 0x68, 0x3c, 0x53, 0x15, 0x31,
+	0xda, 0x14, 0xf0, 0xf4, 0xd8, 0x7d, 0xc2, 0xc2, 0xd4, 0x5f, 0x55, 0xa4, 0x57, 0x10, 0x9a, 0x0e,
+	0x87, 0xee, 0xa3, 0xb8, 0x55, 0xa8, 0x8e, 0xd7, 0x2c, 0x48, 0xa2, 0x3c, 0xbf, 0x1c, 0xf2, 0x06,
+	0x61, 0x14, 0x8e, 0xf3, 0x21, 0x08, 0xa0, 0x11, 0xa8, 0xde, 0x04, 0x0f, 0x8b, 0x28, 0x98, 0xe0,
+	0xcf, 0x41, 0xd9, 0x4a, 0xbe, 0x71, 0x71, 0x90, 0x2f, 0x88, 0x28, 0x4d, 0x95, 0x59, 0x91, 0x22,
+	0xdf, 0x86, 0xac, 0x4e, 0xfa, 0xac, 0xf5, 0xa0, 0x46, 0xc2, 0x3b, 0xb6, 0x88, 0x44, 0xa2, 0x2b,
+	0x51, 0x32, 0xb7, 0x90, 0xc1, 0xff, 0xd9, 0x85, 0x9f, 0x25, 0xa8, 0xf4, 0x16, 0xd1, 0xf8, 0x27,
+	0x7c, 0xfe, 0xc8, 0x49, 0x2b, 0x77, 0x92, 0x1e, 0x3f, 0xb2, 0xf3, 0x6a, 0xcb, 0x4e, 0xe3, 0xe2,
+	0x68, 0x47, 0x6a, 0x06, 0x49, 0x20, 0x1c, 0xe2, 0xaf, 0xa0, 0xb6, 0xcc, 0xf6, 0x38, 0x1b, 0xe6,
+	0xf1, 0x8e, 0x34, 0x5f, 0x72, 0x5a, 0xc8, 0xb4, 0x29, 0x34, 0xb6, 0x1a, 0xe2, 0x8f, 0x40, 0x09,
+	0x37, 0xcb, 0x51, 0xe6, 0xaa, 0x4c, 0x33, 0x84, 0x3f, 0x83, 0xe6, 0x6a, 0xcd, 0xee, 0xe6, 0xd1,
+	0x26, 0xf6, 0x67, 0x41, 0x3c, 0xcb, 0x6e, 0xb6, 0x9f, 0x93, 0x57, 0x41, 0x3c, 0xc3, 0x9f, 0x40,
+	0x9d, 0xd7, 0x14, 0x02, 0x39, 0x15, 0xd4, 0x38, 0xc1, 0x0f, 0xb5, 0x97, 0x50, 0x2f, 0xec, 0x16,
+	0xf1, 0x4a, 0x6d, 0xb9, 0x88, 0xf7, 0x1c, 0x9a, 0x3b, 0x26, 0xf1, 0xc9, 0xd6, 0x6d, 0x84, 0xb0,
+	0xc0, 0x67, 0x7f, 0x48, 0xa0, 0xb8, 0x49, 0x90, 0x6c, 0x62, 0xdc, 0x80, 0xea, 0xd0, 0xbe, 0xb6,
+	0x9d, 0xef, 0x6d, 0xb4, 0x87, 0xf7, 0xa1, 0xea, 0x0e, 0x0d, 0x83, 0xb8, 0x2e, 0xfa, 0x53, 0xc2,
+	0x08, 0x1a, 0x3d, 0xdd, 0xf4, 0x29, 0xf9, 0x6e, 0x48, 0x5c, 0x0f, 0xfd, 0x22, 0xe3, 0x03, 0xa8,
+	0xf7, 0x1d, 0xda, 0xb3, 0x4c, 0x93, 0xd8, 0xe8, 0xd7, 0x14, 0xdb, 0x8e, 0xe7, 0xf7, 0x9d, 0xa1,
+	0x6d, 0xa2, 0xdf, 0x64, 0xdc, 0x84, 0x9a, 0xe1, 0xd8, 0xfd, 0x81, 0x65, 0x78, 0xe8, 0xbd, 0x8c,
+	0x5f, 0x80, 0x9a, 0xbd, 0xec, 0x13, 0xdb, 0xb3, 0xbc, 0x1f, 0x7c, 0xcf, 0x71, 0xfc, 0x81, 0x4e,
+	0x2f, 0x09, 0xfa, 0x5d, 0xc6, 0x27, 0x70, 0x6c, 0xd9, 0x1e, 0xa1, 0xb6, 0x3e, 0xf0, 0x5d, 0x42,
+	0xdf, 0x10, 0xea, 0x13, 0x4a, 0x1d, 0x8a, 0xfe, 0x96, 0xb1, 0x0a, 0x2d, 0x4e, 0x59, 0x06, 0xf1,
+	0x87, 0xb6, 0xfe, 0x46, 0xb7, 0x06, 0x7a, 0x6f, 0x40, 0xd0, 0x3f, 0xf2, 0xd9, 0x7b, 0x09, 0x40,
+	0xc4, 0xed, 0xf1, 0x7f, 0xce, 0x06, 0x54, 0x5f, 0x13, 0xd7, 0xd5, 0x2f, 0x09, 0xda, 0xc3, 0x00,
+	0x0a, 0xef, 0x6f, 0x5d, 0x22, 0x09, 0x1f, 0x41, 0x53, 0x3c, 0xfb, 0xc3, 0x1b, 0x53, 0xf7, 0x08,
+	0x2a, 0x61, 0x15, 0x9e, 0x11, 0xdb, 0x74, 0xa8, 0x4b, 0xa8, 0xef, 0x51, 0xdd, 0x76, 0x75, 0xc3,
+	0xb3, 0x1c, 0x1b, 0xc9, 0xf8, 0x39, 0xb4, 0x1c, 0x6a, 0x12, 0xfa, 0xe8, 0xa0, 0x8c, 0x8f, 0xe1,
+	0xc8, 0x24, 0x03, 0x8b, 0x7b, 0x73, 0x09, 0xb9, 0xf6, 0x2d, 0xbb, 0xef, 0xa0, 0x0a, 0xa7, 0x8d,
+	0x2b, 0xdd, 0xb2, 0x0d, 0xc7, 0x24, 0xfe, 0x8d, 0x6e, 0x5c, 0xf3, 0xfe, 0xca, 0xd9, 0x3b, 0xc0,
+	0x3b, 0x43, 0xb0, 0xf8, 0x8f, 0x2f, 0x3e, 0x00, 0x70, 0xad, 0x4b, 0x5b, 0xf7, 0x86, 0x94, 0xb8,
+	0x68, 0x0f, 0x1f, 0x42, 0x63, 0xa0, 0xbb, 0x9e, 0x5f, 0x58, 0x7d, 0x0e, 0xad, 0xad, 0xae, 0xae,
+	0xdf, 0xb7, 0x06, 0x1e, 0xa1, 0xa8, 0xc4, 0x2f, 0x97, 0xd9, 0x42, 0x72, 0xef, 0xcb, 0xb7, 0xe7,
+	0xd3, 0x79, 0x32, 0xdb, 0x8c, 0xf8, 0x52, 0x76, 0x67, 0x0f, 0x2b, 0xb6, 0x5e, 0xb0, 0xc9, 0x94,
+	0xad, 0xbb, 0xb7, 0xc1, 0x68, 0x3d, 0x1f, 0x8b, 0x2f, 0x44, 0x9c, 0x7d, 0x45, 0x46, 0x4a, 0x0a,
+	0xbf, 0xfe, 0x77, 0x00, 0x77, 0x3c, 0x78, 0x3f, 0x5d, 0x06, 0x00, 0x00,
 }
@@ -7,8 +7,10 @@ Package orderer is a generated protocol buffer package.
 
 It is generated from these files:
 	orderer/ab.proto
+	orderer/admin.proto
 	orderer/configuration.proto
 	orderer/kafka.proto
+	orderer/raft.proto
 
 It has these top-level messages:
 	BroadcastResponse
@@ -17,19 +19,53 @@ It has these top-level messages:
 	SeekSpecified
 	SeekPosition
 	SeekInfo
+	FilteredBlock
+	FilteredTransaction
 	DeliverResponse
+	AdminRequest
+	AdminListChannels
+	AdminJoinChannel
+	AdminRemoveChannel
+	AdminArchiveChannel
+	ChannelInfo
+	AdminResponse
 	ConsensusType
 	BatchSize
 	BatchTimeout
 	CreationPolicy
 	ChainCreationPolicyNames
 	KafkaBrokers
+	RaftConsenters
+	RaftConsenter
+	SbftMembership
+	SbftReplica
 	ChannelRestrictions
+	RateLimits
+	RateLimit
+	ChannelState
+	ConsensusTypeMigration
+	KafkaTopic
 	KafkaMessage
 	KafkaMessageRegular
 	KafkaMessageTimeToCut
 	KafkaMessageConnect
 	KafkaMetadata
+	RaftMessage
+	RaftMessageRegular
+	RaftMessageTimeToCut
+	RaftMetadata
+	RaftEntry
+	RaftHardState
+	RaftSnapshot
+	RaftWALRecord
+	RaftStep
+	RaftVoteRequest
+	RaftVoteResponse
+	RaftAppendRequest
+	RaftAppendResponse
+	RaftProposal
+	RaftStepResponse
+	RaftPullRequest
 */
 package orderer
 
@@ -75,8 +111,35 @@ func (x SeekInfo_SeekBehavior) String() string {
 }
 func (SeekInfo_SeekBehavior) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{5, 0} }
 
+type SeekInfo_SeekContentType int32
+
+const (
+	SeekInfo_BLOCK                SeekInfo_SeekContentType = 0
+	SeekInfo_HEADER_WITH_METADATA SeekInfo_SeekContentType = 1
+	SeekInfo_FILTERED             SeekInfo_SeekContentType = 2
+)
+
+var SeekInfo_SeekContentType_name = map[int32]string{
+	0: "BLOCK",
+	1: "HEADER_WITH_METADATA",
+	2: "FILTERED",
+}
+var SeekInfo_SeekContentType_value = map[string]int32{
+	"BLOCK":                0,
+	"HEADER_WITH_METADATA": 1,
+	"FILTERED":             2,
+}
+
+func (x SeekInfo_SeekContentType) String() string {
+	return proto.EnumName(SeekInfo_SeekContentType_name, int32(x))
+}
+func (SeekInfo_SeekContentType) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{5, 1} }
+
 type BroadcastResponse struct {
-	Status common.Status `protobuf:"varint,1,opt,name=status,enum=common.Status" json:"status,omitempty"`
+	Status      common.Status `protobuf:"varint,1,opt,name=status,enum=common.Status" json:"status,omitempty"`
+	Info        string        `protobuf:"bytes,2,opt,name=info" json:"info,omitempty"`
+	BlockNumber uint64        `protobuf:"varint,3,opt,name=block_number,json=blockNumber" json:"block_number,omitempty"`
+	TxIndex     uint64        `protobuf:"varint,4,opt,name=tx_index,json=txIndex" json:"tx_index,omitempty"`
 }
 
 func (m *BroadcastResponse) Reset()                    { *m = BroadcastResponse{} }
@@ -122,9 +185,7 @@ func (m *SeekPosition) String() string            { return proto.CompactTextStri
 func (*SeekPosition) ProtoMessage()               {}
 func (*SeekPosition) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
 
-type isSeekPosition_Type interface {
-	isSeekPosition_Type()
-}
+type isSeekPosition_Type interface{ isSeekPosition_Type() }
 
 type SeekPosition_Newest struct {
 	Newest *SeekNewest `protobuf:"bytes,1,opt,name=newest,oneof"`
@@ -269,10 +330,13 @@ func _SeekPosition_OneofSizer(msg proto.Message) (n int) {
 // error indicating that the block is not found.  To request that all blocks be returned indefinitely
 // as they are created, behavior should be set to BLOCK_UNTIL_READY and the stop should be set to
 // specified with a number of MAX_UINT64
+// The content type selects whether full blocks, blocks without their data, or summaries of the
+// transactions of the blocks are returned
 type SeekInfo struct {
-	Start    *SeekPosition         `protobuf:"bytes,1,opt,name=start" json:"start,omitempty"`
-	Stop     *SeekPosition         `protobuf:"bytes,2,opt,name=stop" json:"stop,omitempty"`
-	Behavior SeekInfo_SeekBehavior `protobuf:"varint,3,opt,name=behavior,enum=orderer.SeekInfo_SeekBehavior" json:"behavior,omitempty"`
+	Start       *SeekPosition            `protobuf:"bytes,1,opt,name=start" json:"start,omitempty"`
+	Stop        *SeekPosition            `protobuf:"bytes,2,opt,name=stop" json:"stop,omitempty"`
+	Behavior    SeekInfo_SeekBehavior    `protobuf:"varint,3,opt,name=behavior,enum=orderer.SeekInfo_SeekBehavior" json:"behavior,omitempty"`
+	ContentType SeekInfo_SeekContentType `protobuf:"varint,4,opt,name=content_type,json=contentType,enum=orderer.SeekInfo_SeekContentType" json:"content_type,omitempty"`
 }
 
 func (m *SeekInfo) Reset()                    { *m = SeekInfo{} }
@@ -294,21 +358,54 @@ func (m *SeekInfo) GetStop() *SeekPosition {
 	return nil
 }
 
+// FilteredBlock summarizes a block by the identifiers of its transactions
+type FilteredBlock struct {
+	ChannelId            string                 `protobuf:"bytes,1,opt,name=channel_id,json=channelId" json:"channel_id,omitempty"`
+	Number               uint64                 `protobuf:"varint,2,opt,name=number" json:"number,omitempty"`
+	FilteredTransactions []*FilteredTransaction `protobuf:"bytes,3,rep,name=filtered_transactions,json=filteredTransactions" json:"filtered_transactions,omitempty"`
+}
+
+func (m *FilteredBlock) Reset()                    { *m = FilteredBlock{} }
+func (m *FilteredBlock) String() string            { return proto.CompactTextString(m) }
+func (*FilteredBlock) ProtoMessage()               {}
+func (*FilteredBlock) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
+
+func (m *FilteredBlock) GetFilteredTransactions() []*FilteredTransaction {
+	if m != nil {
+		return m.FilteredTransactions
+	}
+	return nil
+}
+
+// FilteredTransaction summarizes a transaction of a block
+type FilteredTransaction struct {
+	Txid string            `protobuf:"bytes,1,opt,name=txid" json:"txid,omitempty"`
+	Type common.HeaderType `protobuf:"varint,2,opt,name=type,enum=common.HeaderType" json:"type,omitempty"`
+	// The validation code the transactions filter of the block records for the transaction,
+	// a peer TxValidationCode, if validated is set; the orderer does not validate transactions
+	TxValidationCode uint32 `protobuf:"varint,3,opt,name=tx_validation_code,json=txValidationCode" json:"tx_validation_code,omitempty"`
+	Validated        bool   `protobuf:"varint,4,opt,name=validated" json:"validated,omitempty"`
+}
+
+func (m *FilteredTransaction) Reset()                    { *m = FilteredTransaction{} }
+func (m *FilteredTransaction) String() string            { return proto.CompactTextString(m) }
+func (*FilteredTransaction) ProtoMessage()               {}
+func (*FilteredTransaction) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
+
 type DeliverResponse struct {
 	// Types that are valid to be assigned to Type:
 	//	*DeliverResponse_Status
 	//	*DeliverResponse_Block
+	//	*DeliverResponse_FilteredBlock
 	Type isDeliverResponse_Type `protobuf_oneof:"Type"`
 }
 
 func (m *DeliverResponse) Reset()                    { *m = DeliverResponse{} }
 func (m *DeliverResponse) String() string            { return proto.CompactTextString(m) }
 func (*DeliverResponse) ProtoMessage()               {}
-func (*DeliverResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
+func (*DeliverResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
 
-type isDeliverResponse_Type interface {
-	isDeliverResponse_Type()
-}
+type isDeliverResponse_Type interface{ isDeliverResponse_Type() }
 
 type DeliverResponse_Status struct {
 	Status common.Status `protobuf:"varint,1,opt,name=status,enum=common.Status,oneof"`
@@ -316,9 +413,13 @@ type DeliverResponse_Status struct {
 type DeliverResponse_Block struct {
 	Block *common.Block `protobuf:"bytes,2,opt,name=block,oneof"`
 }
+type DeliverResponse_FilteredBlock struct {
+	FilteredBlock *FilteredBlock `protobuf:"bytes,3,opt,name=filtered_block,json=filteredBlock,oneof"`
+}
 
-func (*DeliverResponse_Status) isDeliverResponse_Type() {}
-func (*DeliverResponse_Block) isDeliverResponse_Type()  {}
+func (*DeliverResponse_Status) isDeliverResponse_Type()        {}
+func (*DeliverResponse_Block) isDeliverResponse_Type()         {}
+func (*DeliverResponse_FilteredBlock) isDeliverResponse_Type() {}
 
 func (m *DeliverResponse) GetType() isDeliverResponse_Type {
 	if m != nil {
@@ -341,11 +442,19 @@ func (m *DeliverResponse) GetBlock() *common.Block {
 	return nil
 }
 
+func (m *DeliverResponse) GetFilteredBlock() *FilteredBlock {
+	if x, ok := m.GetType().(*DeliverResponse_FilteredBlock); ok {
+		return x.FilteredBlock
+	}
+	return nil
+}
+
 // XXX_OneofFuncs is for the internal use of the proto package.
 func (*DeliverResponse) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
 	return _DeliverResponse_OneofMarshaler, _DeliverResponse_OneofUnmarshaler, _DeliverResponse_OneofSizer, []interface{}{
 		(*DeliverResponse_Status)(nil),
 		(*DeliverResponse_Block)(nil),
+		(*DeliverResponse_FilteredBlock)(nil),
 	}
 }
 
@@ -361,6 +470,11 @@ func _DeliverResponse_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
 		if err := b.EncodeMessage(x.Block); err != nil {
 			return err
 		}
+	case *DeliverResponse_FilteredBlock:
+		b.EncodeVarint(3<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.FilteredBlock); err != nil {
+			return err
+		}
 	case nil:
 	default:
 		return fmt.Errorf("DeliverResponse.Type has unexpected type %T", x)
@@ -386,6 +500,14 @@ func _DeliverResponse_OneofUnmarshaler(msg proto.Message, tag, wire int, b *prot
 		err := b.DecodeMessage(msg)
 		m.Type = &DeliverResponse_Block{msg}
 		return true, err
+	case 3: // Type.filtered_block
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(FilteredBlock)
+		err := b.DecodeMessage(msg)
+		m.Type = &DeliverResponse_FilteredBlock{msg}
+		return true, err
 	default:
 		return false, nil
 	}
@@ -403,6 +525,11 @@ func _DeliverResponse_OneofSizer(msg proto.Message) (n int) {
 		n += proto.SizeVarint(2<<3 | proto.WireBytes)
 		n += proto.SizeVarint(uint64(s))
 		n += s
+	case *DeliverResponse_FilteredBlock:
+		s := proto.Size(x.FilteredBlock)
+		n += proto.SizeVarint(3<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
 	case nil:
 	default:
 		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
@@ -417,8 +544,11 @@ func init() {
 	proto.RegisterType((*SeekSpecified)(nil), "orderer.SeekSpecified")
 	proto.RegisterType((*SeekPosition)(nil), "orderer.SeekPosition")
 	proto.RegisterType((*SeekInfo)(nil), "orderer.SeekInfo")
+	proto.RegisterType((*FilteredBlock)(nil), "orderer.FilteredBlock")
+	proto.RegisterType((*FilteredTransaction)(nil), "orderer.FilteredTransaction")
 	proto.RegisterType((*DeliverResponse)(nil), "orderer.DeliverResponse")
 	proto.RegisterEnum("orderer.SeekInfo_SeekBehavior", SeekInfo_SeekBehavior_name, SeekInfo_SeekBehavior_value)
+	proto.RegisterEnum("orderer.SeekInfo_SeekContentType", SeekInfo_SeekContentType_name, SeekInfo_SeekContentType_value)
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -597,35 +727,54 @@ var _AtomicBroadcast_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("orderer/ab.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 480 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0x7c, 0x93, 0xcd, 0x6e, 0xd3, 0x40,
-	0x10, 0xc7, 0x63, 0x48, 0xdd, 0x76, 0x68, 0xd3, 0x74, 0xab, 0x56, 0x51, 0x0e, 0x08, 0x59, 0x02,
-	0x82, 0x00, 0x1b, 0x05, 0x89, 0x03, 0x45, 0x42, 0x31, 0x6d, 0x95, 0x88, 0x28, 0x41, 0x4e, 0x38,
-	0xc0, 0x25, 0xf2, 0xc7, 0xa4, 0x31, 0x75, 0xbc, 0xd6, 0xee, 0x26, 0xa8, 0x4f, 0xc1, 0x8b, 0xf0,
-	0x48, 0x3c, 0x0c, 0xda, 0x0f, 0x3b, 0x0a, 0x14, 0x4e, 0xc9, 0xcc, 0xfc, 0xfe, 0xb3, 0xff, 0x19,
-	0x8d, 0xa1, 0x49, 0x59, 0x82, 0x0c, 0x99, 0x17, 0x46, 0x6e, 0xc1, 0xa8, 0xa0, 0x64, 0xd7, 0x64,
-	0xda, 0x27, 0x31, 0x5d, 0x2e, 0x69, 0xee, 0xe9, 0x1f, 0x5d, 0x75, 0xce, 0xe1, 0xd8, 0x67, 0x34,
-	0x4c, 0xe2, 0x90, 0x8b, 0x00, 0x79, 0x41, 0x73, 0x8e, 0xe4, 0x09, 0xd8, 0x5c, 0x84, 0x62, 0xc5,
-	0x5b, 0xd6, 0x23, 0xab, 0xd3, 0xe8, 0x36, 0x5c, 0xa3, 0x99, 0xa8, 0x6c, 0x60, 0xaa, 0xce, 0x01,
-	0xc0, 0x04, 0xf1, 0x66, 0x84, 0xdf, 0x91, 0x8b, 0x32, 0x1a, 0x67, 0x89, 0x8c, 0x9e, 0xc2, 0xa1,
-	0x8c, 0x26, 0x05, 0xc6, 0xe9, 0x3c, 0xc5, 0x84, 0x9c, 0x81, 0x9d, 0xaf, 0x96, 0x11, 0x32, 0xd5,
-	0xb4, 0x1e, 0x98, 0xc8, 0xf9, 0x69, 0xc1, 0x81, 0x24, 0x3f, 0x51, 0x9e, 0x8a, 0x94, 0xe6, 0xe4,
-	0x25, 0xd8, 0xb9, 0xea, 0xa8, 0xc0, 0x07, 0xdd, 0x13, 0xd7, 0x4c, 0xe0, 0x6e, 0x1e, 0xeb, 0xd7,
-	0x02, 0x03, 0x49, 0x9c, 0xaa, 0x27, 0x5b, 0xf7, 0xee, 0xc0, 0xb5, 0x1b, 0x89, 0x6b, 0x88, 0xbc,
-	0x81, 0x7d, 0x5e, 0x7a, 0x6a, 0xdd, 0x57, 0x8a, 0xb3, 0x2d, 0x45, 0xe5, 0xb8, 0x5f, 0x0b, 0x36,
-	0xa8, 0x6f, 0x43, 0x7d, 0x7a, 0x5b, 0xa0, 0xf3, 0xcb, 0x82, 0x3d, 0x89, 0x0d, 0xf2, 0x39, 0x25,
-	0xcf, 0x61, 0x87, 0x8b, 0x90, 0x95, 0x4e, 0x4f, 0xb7, 0x1a, 0x95, 0x03, 0x05, 0x9a, 0x21, 0xcf,
-	0xa0, 0xce, 0x05, 0x2d, 0x8c, 0xcd, 0x7f, 0xb0, 0x0a, 0x21, 0x6f, 0x61, 0x2f, 0xc2, 0x45, 0xb8,
-	0x4e, 0x29, 0x53, 0x1e, 0x1b, 0xdd, 0x87, 0x5b, 0xb8, 0x7c, 0x5c, 0xfd, 0xf1, 0x0d, 0x15, 0x54,
-	0xbc, 0xf3, 0x4e, 0xaf, 0xb3, 0xac, 0x90, 0x53, 0x38, 0xf6, 0x87, 0xe3, 0x0f, 0x1f, 0x67, 0x9f,
-	0x47, 0xd3, 0xc1, 0x70, 0x16, 0x5c, 0xf6, 0x2e, 0xbe, 0x34, 0x6b, 0x32, 0x7d, 0xd5, 0x1b, 0x0c,
-	0x67, 0x83, 0xab, 0xd9, 0x68, 0x3c, 0x35, 0x69, 0xcb, 0xf9, 0x06, 0x47, 0x17, 0x98, 0xa5, 0x6b,
-	0x64, 0xd5, 0x35, 0x74, 0xfe, 0x7f, 0x0d, 0x72, 0xb7, 0xba, 0x4e, 0x1e, 0xc3, 0x4e, 0x94, 0xd1,
-	0xf8, 0xc6, 0x8c, 0x78, 0x58, 0x82, 0xbe, 0x4c, 0xf6, 0x6b, 0x81, 0xae, 0x96, 0xab, 0xec, 0xfe,
-	0xb0, 0xe0, 0xa8, 0x27, 0xe8, 0x32, 0x8d, 0xab, 0x13, 0x24, 0xef, 0x61, 0x7f, 0x13, 0x34, 0xcb,
-	0x06, 0x97, 0xf9, 0x1a, 0x33, 0x5a, 0x60, 0xbb, 0x5d, 0xad, 0xe1, 0xaf, 0xab, 0x75, 0x6a, 0x1d,
-	0xeb, 0x95, 0x45, 0xce, 0x61, 0xd7, 0x0c, 0x70, 0x87, 0xbc, 0x55, 0xc9, 0xff, 0x18, 0x52, 0x8b,
-	0x7d, 0xf7, 0xeb, 0x8b, 0xeb, 0x54, 0x2c, 0x56, 0x91, 0x54, 0x7a, 0x8b, 0xdb, 0x02, 0x59, 0x86,
-	0xc9, 0x35, 0x32, 0x6f, 0x1e, 0x46, 0x2c, 0x8d, 0x3d, 0xf5, 0xd1, 0x70, 0xcf, 0x74, 0x89, 0x6c,
-	0x15, 0xbf, 0xfe, 0x1d, 0x00, 0x00, 0xff, 0xff, 0xe2, 0x79, 0xcc, 0xb7, 0x76, 0x03, 0x00, 0x00,
+	// 769 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x94, 0x5f, 0x6f, 0xe2, 0x46,
+	0x10, 0xc0, 0x31, 0x21, 0x04, 0x86, 0x3f, 0x21, 0x9b, 0x3f, 0x72, 0xa3, 0xb4, 0x22, 0x96, 0x9a,
+	0x52, 0x35, 0x85, 0x8a, 0x4a, 0x7d, 0x68, 0x2b, 0x45, 0x10, 0x40, 0x58, 0xa5, 0xa4, 0xdd, 0xb8,
+	0x3d, 0xdd, 0xbd, 0x58, 0xc6, 0x5e, 0x82, 0x15, 0xe3, 0xb5, 0xec, 0x0d, 0x47, 0x3e, 0xc4, 0xe9,
+	0x9e, 0xef, 0xe1, 0x3e, 0xc1, 0xdd, 0x07, 0xbb, 0x8f, 0x71, 0xda, 0xf5, 0xda, 0x40, 0x82, 0xf2,
+	0x64, 0xcf, 0xcc, 0x6f, 0xfe, 0xec, 0xcc, 0xee, 0x40, 0x8d, 0x86, 0x0e, 0x09, 0x49, 0xd8, 0xb2,
+	0x26, 0xcd, 0x20, 0xa4, 0x8c, 0xa2, 0x3d, 0xa9, 0x39, 0x3d, 0xb4, 0xe9, 0x7c, 0x4e, 0xfd, 0x56,
+	0xfc, 0x89, 0xad, 0xda, 0x3b, 0x05, 0x0e, 0xba, 0x21, 0xb5, 0x1c, 0xdb, 0x8a, 0x18, 0x26, 0x51,
+	0x40, 0xfd, 0x88, 0xa0, 0x0b, 0xc8, 0x47, 0xcc, 0x62, 0x0f, 0x91, 0xaa, 0xd4, 0x95, 0x46, 0xb5,
+	0x5d, 0x6d, 0x4a, 0xa7, 0x5b, 0xa1, 0xc5, 0xd2, 0x8a, 0x10, 0xe4, 0x5c, 0x7f, 0x4a, 0xd5, 0x6c,
+	0x5d, 0x69, 0x14, 0xb1, 0xf8, 0x47, 0xe7, 0x50, 0x9e, 0x78, 0xd4, 0xbe, 0x37, 0xfd, 0x87, 0xf9,
+	0x84, 0x84, 0xea, 0x4e, 0x5d, 0x69, 0xe4, 0x70, 0x49, 0xe8, 0xc6, 0x42, 0x85, 0xbe, 0x81, 0x02,
+	0x5b, 0x9a, 0xae, 0xef, 0x90, 0xa5, 0x9a, 0x13, 0xe6, 0x3d, 0xb6, 0xd4, 0xb9, 0xa8, 0x95, 0x01,
+	0x6e, 0x09, 0xb9, 0x1f, 0x93, 0xb7, 0x24, 0x62, 0x89, 0x74, 0xe3, 0x39, 0x5c, 0xfa, 0x01, 0x2a,
+	0x5c, 0xba, 0x0d, 0x88, 0xed, 0x4e, 0x5d, 0xe2, 0xa0, 0x13, 0xc8, 0xcb, 0x24, 0x8a, 0x88, 0x22,
+	0x25, 0xed, 0xb3, 0x02, 0x65, 0x4e, 0xfe, 0x43, 0x23, 0x97, 0xb9, 0xd4, 0x47, 0x3f, 0x43, 0xde,
+	0x17, 0x11, 0x05, 0x58, 0x6a, 0x1f, 0x36, 0x65, 0x53, 0x9a, 0xab, 0x64, 0xc3, 0x0c, 0x96, 0x10,
+	0xc7, 0xa9, 0x48, 0xa9, 0x66, 0xb7, 0xe0, 0x71, 0x35, 0x1c, 0x8f, 0x21, 0xf4, 0x1b, 0x14, 0xa3,
+	0xa4, 0x26, 0x71, 0xdc, 0x52, 0xfb, 0x64, 0xc3, 0x23, 0xad, 0x78, 0x98, 0xc1, 0x2b, 0xb4, 0x9b,
+	0x87, 0x9c, 0xf1, 0x18, 0x10, 0xed, 0x4b, 0x16, 0x0a, 0x1c, 0xd3, 0x79, 0xfb, 0x7e, 0x82, 0xdd,
+	0x88, 0x59, 0x61, 0x52, 0xe9, 0xf1, 0x46, 0xa0, 0xe4, 0x40, 0x38, 0x66, 0xd0, 0x8f, 0x90, 0x8b,
+	0x18, 0x0d, 0xd4, 0xec, 0x4b, 0xac, 0x40, 0xd0, 0xef, 0x50, 0x98, 0x90, 0x99, 0xb5, 0x70, 0x69,
+	0x3c, 0x92, 0x6a, 0xfb, 0xbb, 0x0d, 0x9c, 0x27, 0x17, 0x3f, 0x5d, 0x49, 0xe1, 0x94, 0x47, 0x3d,
+	0x28, 0xdb, 0xd4, 0x67, 0xc4, 0x67, 0x26, 0x7b, 0x0c, 0x88, 0x98, 0x59, 0xb5, 0x7d, 0xbe, 0xdd,
+	0xff, 0x3a, 0x26, 0xf9, 0xc9, 0x70, 0xc9, 0x5e, 0x09, 0xda, 0x9f, 0x50, 0x5e, 0x8f, 0x8f, 0x8e,
+	0xe1, 0xa0, 0x3b, 0xba, 0xb9, 0xfe, 0xcb, 0xfc, 0x6f, 0x6c, 0xe8, 0x23, 0x13, 0xf7, 0x3b, 0xbd,
+	0xd7, 0xb5, 0x0c, 0x57, 0x0f, 0x3a, 0xfa, 0xc8, 0xd4, 0x07, 0xe6, 0xf8, 0xc6, 0x90, 0x6a, 0x45,
+	0xeb, 0xc1, 0xfe, 0x93, 0xe8, 0xa8, 0x08, 0xbb, 0x22, 0x40, 0x2d, 0x83, 0x54, 0x38, 0x1a, 0xf6,
+	0x3b, 0xbd, 0x3e, 0x36, 0x5f, 0xe9, 0xc6, 0xd0, 0xfc, 0xbb, 0x6f, 0x74, 0x7a, 0x1d, 0xa3, 0x53,
+	0x53, 0x50, 0x19, 0x0a, 0x03, 0x7d, 0x64, 0xf4, 0x71, 0xbf, 0x57, 0xcb, 0x6a, 0x1f, 0x14, 0xa8,
+	0x0c, 0x5c, 0x8f, 0x91, 0x90, 0x38, 0x5d, 0x7e, 0x23, 0xd1, 0xb7, 0x00, 0xf6, 0xcc, 0xf2, 0x7d,
+	0xe2, 0x99, 0xae, 0x23, 0x9a, 0x5e, 0xc4, 0x45, 0xa9, 0xd1, 0xd7, 0xaf, 0x58, 0x76, 0xfd, 0x8a,
+	0xa1, 0x7f, 0xe1, 0x78, 0x2a, 0xe3, 0x98, 0x2c, 0xb4, 0xfc, 0xc8, 0xb2, 0x79, 0xb3, 0x23, 0x75,
+	0xa7, 0xbe, 0xd3, 0x28, 0xb5, 0xcf, 0xd2, 0xde, 0x24, 0xd9, 0x8c, 0x15, 0x84, 0x8f, 0xa6, 0xcf,
+	0x95, 0x91, 0xf6, 0x51, 0x81, 0xc3, 0x2d, 0x34, 0x7f, 0x64, 0x6c, 0x99, 0xd6, 0x26, 0xfe, 0xd1,
+	0x05, 0xe4, 0xc4, 0x24, 0xb2, 0x62, 0x12, 0x28, 0x79, 0x9e, 0x43, 0x62, 0x39, 0x24, 0x14, 0xad,
+	0x17, 0x76, 0x74, 0x09, 0x88, 0x2d, 0xcd, 0x85, 0xe5, 0xb9, 0x8e, 0xc5, 0x83, 0x99, 0x36, 0x75,
+	0x88, 0x98, 0x7f, 0x05, 0xd7, 0xd8, 0xf2, 0xff, 0xd4, 0x70, 0x4d, 0x1d, 0x82, 0xce, 0xa0, 0x28,
+	0x51, 0xe2, 0x88, 0x21, 0x17, 0xf0, 0x4a, 0xa1, 0x7d, 0x52, 0x60, 0xbf, 0x47, 0x3c, 0x77, 0x41,
+	0xc2, 0x74, 0x51, 0x34, 0x5e, 0x5e, 0x14, 0xfc, 0x91, 0xc8, 0x55, 0xf1, 0x3d, 0xec, 0x8a, 0x15,
+	0x20, 0xef, 0x6a, 0x25, 0x01, 0xc5, 0x14, 0x86, 0x19, 0x1c, 0x5b, 0xd1, 0x15, 0x54, 0xd3, 0xbe,
+	0xc6, 0xfc, 0xd3, 0x07, 0xb5, 0x31, 0xbe, 0x61, 0x06, 0x57, 0xa6, 0xeb, 0x8a, 0xe4, 0x51, 0xb5,
+	0xdf, 0x2b, 0xb0, 0xdf, 0x61, 0x74, 0xee, 0xda, 0xe9, 0x7a, 0x43, 0x57, 0x50, 0x5c, 0x09, 0xb5,
+	0xa4, 0x82, 0xbe, 0xbf, 0x20, 0x1e, 0x0d, 0xc8, 0xe9, 0x69, 0x9a, 0xe3, 0xd9, 0x46, 0xd4, 0x32,
+	0x0d, 0xe5, 0x17, 0x05, 0xfd, 0x01, 0x7b, 0xb2, 0x03, 0x5b, 0xdc, 0xd5, 0xd4, 0xfd, 0x49, 0x97,
+	0x62, 0xe7, 0x6e, 0xf3, 0xcd, 0xe5, 0x9d, 0xcb, 0x66, 0x0f, 0x13, 0xee, 0xd9, 0x9a, 0x3d, 0x06,
+	0x24, 0xf4, 0x88, 0x73, 0x47, 0xc2, 0xd6, 0xd4, 0x9a, 0x84, 0xae, 0xdd, 0x12, 0x1b, 0x39, 0x6a,
+	0xc9, 0x28, 0x93, 0xbc, 0x90, 0x7f, 0xfd, 0x3a, 0x00, 0x5b, 0xaa, 0x8d, 0x4d, 0xd3, 0x05, 0x00,
+	0x00,
 }
@@ -0,0 +1,407 @@
+// Code generated by protoc-gen-go.
+// source: orderer/admin.proto
+// DO NOT EDIT!
+
+package orderer
+
+import proto "github.com/golang/protobuf/proto"
+import fmt "fmt"
+import math "math"
+import common "github.com/hyperledger/fabric/protos/common"
+
+import (
+	context "golang.org/x/net/context"
+	grpc "google.golang.org/grpc"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// AdminRequest is the data of the payload of the envelopes submitted to the
+// Admin service. The envelopes are of header type MESSAGE, and must be signed
+// by an admin of the local MSP of the orderer.
+type AdminRequest struct {
+	// Types that are valid to be assigned to Type:
+	//	*AdminRequest_ListChannels
+	//	*AdminRequest_JoinChannel
+	//	*AdminRequest_RemoveChannel
+	//	*AdminRequest_ArchiveChannel
+	Type    isAdminRequest_Type `protobuf_oneof:"Type"`
+	Orderer []byte              `protobuf:"bytes,5,opt,name=orderer,proto3" json:"orderer,omitempty"`
+}
+
+func (m *AdminRequest) Reset()                    { *m = AdminRequest{} }
+func (m *AdminRequest) String() string            { return proto.CompactTextString(m) }
+func (*AdminRequest) ProtoMessage()               {}
+func (*AdminRequest) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{0} }
+
+type isAdminRequest_Type interface{ isAdminRequest_Type() }
+
+type AdminRequest_ListChannels struct {
+	ListChannels *AdminListChannels `protobuf:"bytes,1,opt,name=list_channels,json=listChannels,oneof"`
+}
+type AdminRequest_JoinChannel struct {
+	JoinChannel *AdminJoinChannel `protobuf:"bytes,2,opt,name=join_channel,json=joinChannel,oneof"`
+}
+type AdminRequest_RemoveChannel struct {
+	RemoveChannel *AdminRemoveChannel `protobuf:"bytes,3,opt,name=remove_channel,json=removeChannel,oneof"`
+}
+type AdminRequest_ArchiveChannel struct {
+	ArchiveChannel *AdminArchiveChannel `protobuf:"bytes,4,opt,name=archive_channel,json=archiveChannel,oneof"`
+}
+
+func (*AdminRequest_ListChannels) isAdminRequest_Type()   {}
+func (*AdminRequest_JoinChannel) isAdminRequest_Type()    {}
+func (*AdminRequest_RemoveChannel) isAdminRequest_Type()  {}
+func (*AdminRequest_ArchiveChannel) isAdminRequest_Type() {}
+
+func (m *AdminRequest) GetType() isAdminRequest_Type {
+	if m != nil {
+		return m.Type
+	}
+	return nil
+}
+
+func (m *AdminRequest) GetListChannels() *AdminListChannels {
+	if x, ok := m.GetType().(*AdminRequest_ListChannels); ok {
+		return x.ListChannels
+	}
+	return nil
+}
+
+func (m *AdminRequest) GetJoinChannel() *AdminJoinChannel {
+	if x, ok := m.GetType().(*AdminRequest_JoinChannel); ok {
+		return x.JoinChannel
+	}
+	return nil
+}
+
+func (m *AdminRequest) GetRemoveChannel() *AdminRemoveChannel {
+	if x, ok := m.GetType().(*AdminRequest_RemoveChannel); ok {
+		return x.RemoveChannel
+	}
+	return nil
+}
+
+func (m *AdminRequest) GetArchiveChannel() *AdminArchiveChannel {
+	if x, ok := m.GetType().(*AdminRequest_ArchiveChannel); ok {
+		return x.ArchiveChannel
+	}
+	return nil
+}
+
+// XXX_OneofFuncs is for the internal use of the proto package.
+func (*AdminRequest) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
+	return _AdminRequest_OneofMarshaler, _AdminRequest_OneofUnmarshaler, _AdminRequest_OneofSizer, []interface{}{
+		(*AdminRequest_ListChannels)(nil),
+		(*AdminRequest_JoinChannel)(nil),
+		(*AdminRequest_RemoveChannel)(nil),
+		(*AdminRequest_ArchiveChannel)(nil),
+	}
+}
+
+func _AdminRequest_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
+	m := msg.(*AdminRequest)
+	// Type
+	switch x := m.Type.(type) {
+	case *AdminRequest_ListChannels:
+		b.EncodeVarint(1<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.ListChannels); err != nil {
+			return err
+		}
+	case *AdminRequest_JoinChannel:
+		b.EncodeVarint(2<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.JoinChannel); err != nil {
+			return err
+		}
+	case *AdminRequest_RemoveChannel:
+		b.EncodeVarint(3<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.RemoveChannel); err != nil {
+			return err
+		}
+	case *AdminRequest_ArchiveChannel:
+		b.EncodeVarint(4<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.ArchiveChannel); err != nil {
+			return err
+		}
+	case nil:
+	default:
+		return fmt.Errorf("AdminRequest.Type has unexpected type %T", x)
+	}
+	return nil
+}
+
+func _AdminRequest_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error) {
+	m := msg.(*AdminRequest)
+	switch tag {
+	case 1: // Type.list_channels
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(AdminListChannels)
+		err := b.DecodeMessage(msg)
+		m.Type = &AdminRequest_ListChannels{msg}
+		return true, err
+	case 2: // Type.join_channel
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(AdminJoinChannel)
+		err := b.DecodeMessage(msg)
+		m.Type = &AdminRequest_JoinChannel{msg}
+		return true, err
+	case 3: // Type.remove_channel
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(AdminRemoveChannel)
+		err := b.DecodeMessage(msg)
+		m.Type = &AdminRequest_RemoveChannel{msg}
+		return true, err
+	case 4: // Type.archive_channel
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(AdminArchiveChannel)
+		err := b.DecodeMessage(msg)
+		m.Type = &AdminRequest_ArchiveChannel{msg}
+		return true, err
+	default:
+		return false, nil
+	}
+}
+
+func _AdminRequest_OneofSizer(msg proto.Message) (n int) {
+	m := msg.(*AdminRequest)
+	// Type
+	switch x := m.Type.(type) {
+	case *AdminRequest_ListChannels:
+		s := proto.Size(x.ListChannels)
+		n += proto.SizeVarint(1<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *AdminRequest_JoinChannel:
+		s := proto.Size(x.JoinChannel)
+		n += proto.SizeVarint(2<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *AdminRequest_RemoveChannel:
+		s := proto.Size(x.RemoveChannel)
+		n += proto.SizeVarint(3<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *AdminRequest_ArchiveChannel:
+		s := proto.Size(x.ArchiveChannel)
+		n += proto.SizeVarint(4<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case nil:
+	default:
+		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
+	}
+	return n
+}
+
+// AdminListChannels lists the channels hosted by the orderer.
+type AdminListChannels struct {
+}
+
+func (m *AdminListChannels) Reset()                    { *m = AdminListChannels{} }
+func (m *AdminListChannels) String() string            { return proto.CompactTextString(m) }
+func (*AdminListChannels) ProtoMessage()               {}
+func (*AdminListChannels) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{1} }
+
+// AdminJoinChannel creates an application channel on the orderer from its
+// genesis block, without the channel being created through a system channel.
+type AdminJoinChannel struct {
+	GenesisBlock *common.Block `protobuf:"bytes,1,opt,name=genesis_block,json=genesisBlock" json:"genesis_block,omitempty"`
+}
+
+func (m *AdminJoinChannel) Reset()                    { *m = AdminJoinChannel{} }
+func (m *AdminJoinChannel) String() string            { return proto.CompactTextString(m) }
+func (*AdminJoinChannel) ProtoMessage()               {}
+func (*AdminJoinChannel) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{2} }
+
+func (m *AdminJoinChannel) GetGenesisBlock() *common.Block {
+	if m != nil {
+		return m.GenesisBlock
+	}
+	return nil
+}
+
+// AdminRemoveChannel halts an application channel and deletes its ledger.
+type AdminRemoveChannel struct {
+	ChannelId string `protobuf:"bytes,1,opt,name=channel_id,json=channelId" json:"channel_id,omitempty"`
+}
+
+func (m *AdminRemoveChannel) Reset()                    { *m = AdminRemoveChannel{} }
+func (m *AdminRemoveChannel) String() string            { return proto.CompactTextString(m) }
+func (*AdminRemoveChannel) ProtoMessage()               {}
+func (*AdminRemoveChannel) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{3} }
+
+// AdminArchiveChannel halts a sealed application channel and moves its ledger
+// aside, so that the channel is no longer served by the orderer.
+type AdminArchiveChannel struct {
+	ChannelId string `protobuf:"bytes,1,opt,name=channel_id,json=channelId" json:"channel_id,omitempty"`
+}
+
+func (m *AdminArchiveChannel) Reset()                    { *m = AdminArchiveChannel{} }
+func (m *AdminArchiveChannel) String() string            { return proto.CompactTextString(m) }
+func (*AdminArchiveChannel) ProtoMessage()               {}
+func (*AdminArchiveChannel) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{4} }
+
+// ChannelInfo describes a channel hosted by the orderer.
+type ChannelInfo struct {
+	ChannelId     string `protobuf:"bytes,1,opt,name=channel_id,json=channelId" json:"channel_id,omitempty"`
+	Height        uint64 `protobuf:"varint,2,opt,name=height" json:"height,omitempty"`
+	ConsensusType string `protobuf:"bytes,3,opt,name=consensus_type,json=consensusType" json:"consensus_type,omitempty"`
+	SystemChannel bool   `protobuf:"varint,4,opt,name=system_channel,json=systemChannel" json:"system_channel,omitempty"`
+	Sealed        bool   `protobuf:"varint,5,opt,name=sealed" json:"sealed,omitempty"`
+}
+
+func (m *ChannelInfo) Reset()                    { *m = ChannelInfo{} }
+func (m *ChannelInfo) String() string            { return proto.CompactTextString(m) }
+func (*ChannelInfo) ProtoMessage()               {}
+func (*ChannelInfo) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{5} }
+
+type AdminResponse struct {
+	Status   common.Status  `protobuf:"varint,1,opt,name=status,enum=common.Status" json:"status,omitempty"`
+	Info     string         `protobuf:"bytes,2,opt,name=info" json:"info,omitempty"`
+	Channels []*ChannelInfo `protobuf:"bytes,3,rep,name=channels" json:"channels,omitempty"`
+}
+
+func (m *AdminResponse) Reset()                    { *m = AdminResponse{} }
+func (m *AdminResponse) String() string            { return proto.CompactTextString(m) }
+func (*AdminResponse) ProtoMessage()               {}
+func (*AdminResponse) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{6} }
+
+func (m *AdminResponse) GetChannels() []*ChannelInfo {
+	if m != nil {
+		return m.Channels
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*AdminRequest)(nil), "orderer.AdminRequest")
+	proto.RegisterType((*AdminListChannels)(nil), "orderer.AdminListChannels")
+	proto.RegisterType((*AdminJoinChannel)(nil), "orderer.AdminJoinChannel")
+	proto.RegisterType((*AdminRemoveChannel)(nil), "orderer.AdminRemoveChannel")
+	proto.RegisterType((*AdminArchiveChannel)(nil), "orderer.AdminArchiveChannel")
+	proto.RegisterType((*ChannelInfo)(nil), "orderer.ChannelInfo")
+	proto.RegisterType((*AdminResponse)(nil), "orderer.AdminResponse")
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion3
+
+// Client API for Admin service
+
+type AdminClient interface {
+	// Process processes an envelope carrying an AdminRequest
+	Process(ctx context.Context, in *common.Envelope, opts ...grpc.CallOption) (*AdminResponse, error)
+}
+
+type adminClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewAdminClient(cc *grpc.ClientConn) AdminClient {
+	return &adminClient{cc}
+}
+
+func (c *adminClient) Process(ctx context.Context, in *common.Envelope, opts ...grpc.CallOption) (*AdminResponse, error) {
+	out := new(AdminResponse)
+	err := grpc.Invoke(ctx, "/orderer.Admin/Process", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for Admin service
+
+type AdminServer interface {
+	// Process processes an envelope carrying an AdminRequest
+	Process(context.Context, *common.Envelope) (*AdminResponse, error)
+}
+
+func RegisterAdminServer(s *grpc.Server, srv AdminServer) {
+	s.RegisterService(&_Admin_serviceDesc, srv)
+}
+
+func _Admin_Process_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(common.Envelope)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).Process(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/orderer.Admin/Process",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).Process(ctx, req.(*common.Envelope))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Admin_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "orderer.Admin",
+	HandlerType: (*AdminServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Process",
+			Handler:    _Admin_Process_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: fileDescriptor1,
+}
+
+func init() { proto.RegisterFile("orderer/admin.proto", fileDescriptor1) }
+
+var fileDescriptor1 = []byte{
+	// 500 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x53, 0x5f, 0x6f, 0xda, 0x3e,
+	0x14, 0x85, 0x42, 0x29, 0x5c, 0x08, 0xbf, 0xfe, 0xcc, 0x84, 0x18, 0xdb, 0x24, 0x14, 0x69, 0x13,
+	0x0f, 0x13, 0x99, 0xe8, 0xf6, 0x5a, 0x09, 0xf6, 0xaf, 0x9d, 0xf6, 0x30, 0x79, 0x7b, 0xda, 0x0b,
+	0x0a, 0xc9, 0x85, 0xb8, 0x4b, 0xec, 0xcc, 0x36, 0x95, 0x78, 0xd8, 0x17, 0xda, 0xc7, 0xd8, 0x27,
+	0x9b, 0xe2, 0x38, 0x81, 0xb4, 0x93, 0xfa, 0x04, 0xe7, 0xf8, 0xdc, 0x63, 0xdf, 0x73, 0x6f, 0x60,
+	0x20, 0x64, 0x88, 0x12, 0xa5, 0xe7, 0x87, 0x09, 0xe3, 0xb3, 0x54, 0x0a, 0x2d, 0xc8, 0x99, 0x25,
+	0xc7, 0x83, 0x40, 0x24, 0x89, 0xe0, 0x5e, 0xfe, 0x93, 0x9f, 0xba, 0x7f, 0x4e, 0xa0, 0xb7, 0xc8,
+	0xd4, 0x14, 0x7f, 0xee, 0x50, 0x69, 0xb2, 0x00, 0x27, 0x66, 0x4a, 0xaf, 0x82, 0xc8, 0xe7, 0x1c,
+	0x63, 0x35, 0xaa, 0x4f, 0xea, 0xd3, 0xee, 0x7c, 0x3c, 0xb3, 0x36, 0x33, 0xa3, 0xfe, 0xcc, 0x94,
+	0x7e, 0x6b, 0x15, 0x57, 0x35, 0xda, 0x8b, 0x8f, 0x30, 0xb9, 0x84, 0xde, 0x8d, 0x60, 0xbc, 0xb0,
+	0x18, 0x9d, 0x18, 0x87, 0xc7, 0x55, 0x87, 0x4f, 0x82, 0x71, 0x5b, 0x71, 0x55, 0xa3, 0xdd, 0x9b,
+	0x03, 0x24, 0xef, 0xa0, 0x2f, 0x31, 0x11, 0xb7, 0x58, 0x3a, 0x34, 0x8c, 0xc3, 0x93, 0xaa, 0x03,
+	0x35, 0x9a, 0x83, 0x87, 0x23, 0x8f, 0x09, 0xf2, 0x11, 0xfe, 0xf3, 0x65, 0x10, 0xb1, 0x23, 0x9b,
+	0xa6, 0xb1, 0x79, 0x5a, 0xb5, 0x59, 0xe4, 0xa2, 0x83, 0x4f, 0xdf, 0xaf, 0x30, 0x64, 0x04, 0x45,
+	0x84, 0xa3, 0xd3, 0x49, 0x7d, 0xda, 0xa3, 0x05, 0x5c, 0xb6, 0xa0, 0xf9, 0x6d, 0x9f, 0xa2, 0x3b,
+	0x80, 0xff, 0xef, 0xa5, 0xe2, 0x7e, 0x80, 0xf3, 0xbb, 0x8d, 0x92, 0x39, 0x38, 0x5b, 0xe4, 0xa8,
+	0x98, 0x5a, 0xad, 0x63, 0x11, 0xfc, 0xb0, 0xe1, 0x3a, 0x33, 0x3b, 0x93, 0x65, 0x46, 0xd2, 0x9e,
+	0xd5, 0x18, 0xe4, 0x5e, 0x00, 0xb9, 0xdf, 0x2e, 0x79, 0x06, 0x60, 0xbb, 0x5a, 0xb1, 0xd0, 0xd8,
+	0x74, 0x68, 0xc7, 0x32, 0xd7, 0xa1, 0xfb, 0x1a, 0x06, 0xff, 0x68, 0xee, 0xa1, 0xaa, 0xdf, 0x75,
+	0xe8, 0x5a, 0xe9, 0x35, 0xdf, 0x88, 0x07, 0xe4, 0x64, 0x08, 0xad, 0x08, 0xd9, 0x36, 0xd2, 0x66,
+	0xc2, 0x4d, 0x6a, 0x11, 0x79, 0x0e, 0xfd, 0x40, 0x70, 0x85, 0x5c, 0xed, 0xd4, 0x4a, 0xef, 0x53,
+	0x34, 0xf3, 0xeb, 0x50, 0xa7, 0x64, 0xb3, 0xd4, 0x32, 0x99, 0xda, 0x2b, 0x8d, 0x49, 0x65, 0x3e,
+	0x6d, 0xea, 0xe4, 0x6c, 0xf1, 0xe6, 0x21, 0xb4, 0x14, 0xfa, 0x31, 0x86, 0x26, 0xfd, 0x36, 0xb5,
+	0xc8, 0xfd, 0x05, 0x8e, 0xcd, 0x45, 0xa5, 0x99, 0x2f, 0x79, 0x01, 0x2d, 0xa5, 0x7d, 0xbd, 0xcb,
+	0x57, 0xb6, 0x3f, 0xef, 0x17, 0xa9, 0x7e, 0x35, 0x2c, 0xb5, 0xa7, 0x84, 0x40, 0x93, 0xf1, 0x8d,
+	0x30, 0x8f, 0xee, 0x50, 0xf3, 0x9f, 0xbc, 0x82, 0x76, 0xb9, 0xf0, 0x8d, 0x49, 0x63, 0xda, 0x9d,
+	0x3f, 0x2a, 0xb7, 0xe4, 0x28, 0x11, 0x5a, 0xaa, 0xe6, 0x97, 0x70, 0x6a, 0xae, 0x27, 0x6f, 0xe0,
+	0xec, 0x8b, 0x14, 0x01, 0x2a, 0x45, 0xce, 0x8b, 0x1b, 0xdf, 0xf3, 0x5b, 0x8c, 0x45, 0x8a, 0xe3,
+	0xe1, 0xdd, 0x95, 0xcd, 0xdf, 0xea, 0xd6, 0x96, 0xb3, 0xef, 0x2f, 0xb7, 0x4c, 0x47, 0xbb, 0x75,
+	0x56, 0xe3, 0x45, 0xfb, 0x14, 0x65, 0x8c, 0xe1, 0x16, 0xa5, 0xb7, 0xf1, 0xd7, 0x92, 0x05, 0x9e,
+	0xf9, 0x3e, 0x95, 0x67, 0xeb, 0xd7, 0x2d, 0x83, 0x2f, 0xfe, 0x0e, 0x00, 0x48, 0x30, 0x0c, 0x68,
+	0xe4, 0x03, 0x00, 0x00,
+}
@@ -13,14 +13,38 @@ var _ = proto.Marshal
 var _ = fmt.Errorf
 var _ = math.Inf
 
+// The consensus type of a channel may only be changed while the channel
+// is in maintenance mode, in which it only orders config transactions
+type ConsensusType_State int32
+
+const (
+	ConsensusType_STATE_NORMAL      ConsensusType_State = 0
+	ConsensusType_STATE_MAINTENANCE ConsensusType_State = 1
+)
+
+var ConsensusType_State_name = map[int32]string{
+	0: "STATE_NORMAL",
+	1: "STATE_MAINTENANCE",
+}
+var ConsensusType_State_value = map[string]int32{
+	"STATE_NORMAL":      0,
+	"STATE_MAINTENANCE": 1,
+}
+
+func (x ConsensusType_State) String() string {
+	return proto.EnumName(ConsensusType_State_name, int32(x))
+}
+func (ConsensusType_State) EnumDescriptor() ([]byte, []int) { return fileDescriptor2, []int{0, 0} }
+
 type ConsensusType struct {
-	Type string `protobuf:"bytes,1,opt,name=type" json:"type,omitempty"`
+	Type  string              `protobuf:"bytes,1,opt,name=type" json:"type,omitempty"`
+	State ConsensusType_State `protobuf:"varint,2,opt,name=state,enum=orderer.ConsensusType_State" json:"state,omitempty"`
 }
 
 func (m *ConsensusType) Reset()                    { *m = ConsensusType{} }
 func (m *ConsensusType) String() string            { return proto.CompactTextString(m) }
 func (*ConsensusType) ProtoMessage()               {}
-func (*ConsensusType) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{0} }
+func (*ConsensusType) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{0} }
 
 type BatchSize struct {
 	// Simply specified as number of messages for now, in the future
@@ -37,7 +61,7 @@ type BatchSize struct {
 func (m *BatchSize) Reset()                    { *m = BatchSize{} }
 func (m *BatchSize) String() string            { return proto.CompactTextString(m) }
 func (*BatchSize) ProtoMessage()               {}
-func (*BatchSize) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{1} }
+func (*BatchSize) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{1} }
 
 type BatchTimeout struct {
 	// Any duration string parseable by ParseDuration():
@@ -48,7 +72,7 @@ type BatchTimeout struct {
 func (m *BatchTimeout) Reset()                    { *m = BatchTimeout{} }
 func (m *BatchTimeout) String() string            { return proto.CompactTextString(m) }
 func (*BatchTimeout) ProtoMessage()               {}
-func (*BatchTimeout) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{2} }
+func (*BatchTimeout) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{2} }
 
 // When submitting a new chain configuration transaction to create a new chain,
 // the first configuration item must be of type Orderer with Key CreationPolicy
@@ -66,7 +90,7 @@ type CreationPolicy struct {
 func (m *CreationPolicy) Reset()                    { *m = CreationPolicy{} }
 func (m *CreationPolicy) String() string            { return proto.CompactTextString(m) }
 func (*CreationPolicy) ProtoMessage()               {}
-func (*CreationPolicy) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{3} }
+func (*CreationPolicy) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{3} }
 
 // ChainCreationPolicyNames is the set of policies which may be invoked for chain creation
 type ChainCreationPolicyNames struct {
@@ -78,7 +102,7 @@ type ChainCreationPolicyNames struct {
 func (m *ChainCreationPolicyNames) Reset()                    { *m = ChainCreationPolicyNames{} }
 func (m *ChainCreationPolicyNames) String() string            { return proto.CompactTextString(m) }
 func (*ChainCreationPolicyNames) ProtoMessage()               {}
-func (*ChainCreationPolicyNames) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{4} }
+func (*ChainCreationPolicyNames) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{4} }
 
 // Carries a list of bootstrap brokers, i.e. this is not the exclusive set of
 // brokers an ordering service
@@ -91,7 +115,81 @@ type KafkaBrokers struct {
 func (m *KafkaBrokers) Reset()                    { *m = KafkaBrokers{} }
 func (m *KafkaBrokers) String() string            { return proto.CompactTextString(m) }
 func (*KafkaBrokers) ProtoMessage()               {}
-func (*KafkaBrokers) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{5} }
+func (*KafkaBrokers) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{5} }
+
+// RaftConsenters is the set of orderers which replicate the raft log of a
+// channel when the consensus type is "raft"
+type RaftConsenters struct {
+	Consenters []*RaftConsenter `protobuf:"bytes,1,rep,name=consenters" json:"consenters,omitempty"`
+}
+
+func (m *RaftConsenters) Reset()                    { *m = RaftConsenters{} }
+func (m *RaftConsenters) String() string            { return proto.CompactTextString(m) }
+func (*RaftConsenters) ProtoMessage()               {}
+func (*RaftConsenters) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{6} }
+
+func (m *RaftConsenters) GetConsenters() []*RaftConsenter {
+	if m != nil {
+		return m.Consenters
+	}
+	return nil
+}
+
+type RaftConsenter struct {
+	// The consenter is identified by the (IP|host):port address of its
+	// orderer endpoint, e.g. orderer0.example.com:7050
+	Address string `protobuf:"bytes,1,opt,name=address" json:"address,omitempty"`
+	// PEM encoded certificate the consenter presents as a TLS client to the
+	// other consenters. Messages claiming to come from the consenter are
+	// not authenticated if it is empty.
+	ClientTlsCert []byte `protobuf:"bytes,2,opt,name=client_tls_cert,json=clientTlsCert,proto3" json:"client_tls_cert,omitempty"`
+}
+
+func (m *RaftConsenter) Reset()                    { *m = RaftConsenter{} }
+func (m *RaftConsenter) String() string            { return proto.CompactTextString(m) }
+func (*RaftConsenter) ProtoMessage()               {}
+func (*RaftConsenter) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{7} }
+
+// SbftMembership is the set of replicas which order the transactions of a
+// channel when the consensus type is "sbft". The replicas switch to a new
+// set at the block boundary following the config transaction changing it.
+type SbftMembership struct {
+	// The number of faulty replicas tolerated, at most (N-1)/3 where N is
+	// the number of replicas
+	F uint64 `protobuf:"varint,1,opt,name=f" json:"f,omitempty"`
+	// The time after which a replica suspects the primary of being faulty
+	RequestTimeoutNsec uint64 `protobuf:"varint,2,opt,name=request_timeout_nsec,json=requestTimeoutNsec" json:"request_timeout_nsec,omitempty"`
+	// The replicas, identified by their position in the list
+	Replicas []*SbftReplica `protobuf:"bytes,3,rep,name=replicas" json:"replicas,omitempty"`
+}
+
+func (m *SbftMembership) Reset()                    { *m = SbftMembership{} }
+func (m *SbftMembership) String() string            { return proto.CompactTextString(m) }
+func (*SbftMembership) ProtoMessage()               {}
+func (*SbftMembership) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{8} }
+
+func (m *SbftMembership) GetReplicas() []*SbftReplica {
+	if m != nil {
+		return m.Replicas
+	}
+	return nil
+}
+
+type SbftReplica struct {
+	// The (IP|host):port address of the SBFT endpoint of the replica, e.g.
+	// orderer0.example.com:6101
+	Address string `protobuf:"bytes,1,opt,name=address" json:"address,omitempty"`
+	// PEM encoded certificate the replica authenticates and signs with
+	Cert []byte `protobuf:"bytes,2,opt,name=cert,proto3" json:"cert,omitempty"`
+	// The serialized MSP identity the replica signs blocks with, see the
+	// BlockValidation policy
+	Identity []byte `protobuf:"bytes,3,opt,name=identity,proto3" json:"identity,omitempty"`
+}
+
+func (m *SbftReplica) Reset()                    { *m = SbftReplica{} }
+func (m *SbftReplica) String() string            { return proto.CompactTextString(m) }
+func (*SbftReplica) ProtoMessage()               {}
+func (*SbftReplica) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{9} }
 
 // ChannelRestrictions is the mssage which conveys restrictions on channel creation for an orderer
 type ChannelRestrictions struct {
@@ -101,7 +199,95 @@ type ChannelRestrictions struct {
 func (m *ChannelRestrictions) Reset()                    { *m = ChannelRestrictions{} }
 func (m *ChannelRestrictions) String() string            { return proto.CompactTextString(m) }
 func (*ChannelRestrictions) ProtoMessage()               {}
-func (*ChannelRestrictions) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{6} }
+func (*ChannelRestrictions) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{10} }
+
+// RateLimits bounds the rate at which the orderer admits broadcast messages into the channel
+type RateLimits struct {
+	Channel  *RateLimit `protobuf:"bytes,1,opt,name=channel" json:"channel,omitempty"`
+	Identity *RateLimit `protobuf:"bytes,2,opt,name=identity" json:"identity,omitempty"`
+	Msp      *RateLimit `protobuf:"bytes,3,opt,name=msp" json:"msp,omitempty"`
+}
+
+func (m *RateLimits) Reset()                    { *m = RateLimits{} }
+func (m *RateLimits) String() string            { return proto.CompactTextString(m) }
+func (*RateLimits) ProtoMessage()               {}
+func (*RateLimits) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{11} }
+
+func (m *RateLimits) GetChannel() *RateLimit {
+	if m != nil {
+		return m.Channel
+	}
+	return nil
+}
+
+func (m *RateLimits) GetIdentity() *RateLimit {
+	if m != nil {
+		return m.Identity
+	}
+	return nil
+}
+
+func (m *RateLimits) GetMsp() *RateLimit {
+	if m != nil {
+		return m.Msp
+	}
+	return nil
+}
+
+// RateLimit describes a token bucket, a rate of 0 indicates no limit
+type RateLimit struct {
+	MessagesPerSecond uint32 `protobuf:"varint,1,opt,name=messages_per_second,json=messagesPerSecond" json:"messages_per_second,omitempty"`
+	Burst             uint32 `protobuf:"varint,2,opt,name=burst" json:"burst,omitempty"`
+}
+
+func (m *RateLimit) Reset()                    { *m = RateLimit{} }
+func (m *RateLimit) String() string            { return proto.CompactTextString(m) }
+func (*RateLimit) ProtoMessage()               {}
+func (*RateLimit) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{12} }
+
+// ChannelState conveys whether the channel still orders transactions
+type ChannelState struct {
+	Sealed bool `protobuf:"varint,1,opt,name=sealed" json:"sealed,omitempty"`
+}
+
+func (m *ChannelState) Reset()                    { *m = ChannelState{} }
+func (m *ChannelState) String() string            { return proto.CompactTextString(m) }
+func (*ChannelState) ProtoMessage()               {}
+func (*ChannelState) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{13} }
+
+// ConsensusTypeMigration is stored as the orderer metadata of the config
+// block which changes the consensus type of a channel, the consenter of the
+// new type starts from that block with the metadata it last wrote, if any
+type ConsensusTypeMigration struct {
+	FromType string            `protobuf:"bytes,1,opt,name=from_type,json=fromType" json:"from_type,omitempty"`
+	ToType   string            `protobuf:"bytes,2,opt,name=to_type,json=toType" json:"to_type,omitempty"`
+	Metadata map[string][]byte `protobuf:"bytes,3,rep,name=metadata" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (m *ConsensusTypeMigration) Reset()                    { *m = ConsensusTypeMigration{} }
+func (m *ConsensusTypeMigration) String() string            { return proto.CompactTextString(m) }
+func (*ConsensusTypeMigration) ProtoMessage()               {}
+func (*ConsensusTypeMigration) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{14} }
+
+func (m *ConsensusTypeMigration) GetMetadata() map[string][]byte {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+// KafkaTopic conveys the checks the Kafka topic of the channel must pass when
+// the consensus type is "kafka", the orderer neither creates nor configures
+// the topic, which is created by the broker or by the Kafka operator
+type KafkaTopic struct {
+	MinReplicas     uint32 `protobuf:"varint,1,opt,name=min_replicas,json=minReplicas" json:"min_replicas,omitempty"`
+	RequireExisting bool   `protobuf:"varint,2,opt,name=require_existing,json=requireExisting" json:"require_existing,omitempty"`
+}
+
+func (m *KafkaTopic) Reset()                    { *m = KafkaTopic{} }
+func (m *KafkaTopic) String() string            { return proto.CompactTextString(m) }
+func (*KafkaTopic) ProtoMessage()               {}
+func (*KafkaTopic) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{15} }
 
 func init() {
 	proto.RegisterType((*ConsensusType)(nil), "orderer.ConsensusType")
@@ -110,32 +296,72 @@ func init() {
 	proto.RegisterType((*CreationPolicy)(nil), "orderer.CreationPolicy")
 	proto.RegisterType((*ChainCreationPolicyNames)(nil), "orderer.ChainCreationPolicyNames")
 	proto.RegisterType((*KafkaBrokers)(nil), "orderer.KafkaBrokers")
+	proto.RegisterType((*RaftConsenters)(nil), "orderer.RaftConsenters")
+	proto.RegisterType((*RaftConsenter)(nil), "orderer.RaftConsenter")
+	proto.RegisterType((*SbftMembership)(nil), "orderer.SbftMembership")
+	proto.RegisterType((*SbftReplica)(nil), "orderer.SbftReplica")
 	proto.RegisterType((*ChannelRestrictions)(nil), "orderer.ChannelRestrictions")
+	proto.RegisterType((*RateLimits)(nil), "orderer.RateLimits")
+	proto.RegisterType((*RateLimit)(nil), "orderer.RateLimit")
+	proto.RegisterType((*ChannelState)(nil), "orderer.ChannelState")
+	proto.RegisterType((*ConsensusTypeMigration)(nil), "orderer.ConsensusTypeMigration")
+	proto.RegisterType((*KafkaTopic)(nil), "orderer.KafkaTopic")
+	proto.RegisterEnum("orderer.ConsensusType_State", ConsensusType_State_name, ConsensusType_State_value)
 }
 
-func init() { proto.RegisterFile("orderer/configuration.proto", fileDescriptor1) }
-
-var fileDescriptor1 = []byte{
-	// 333 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0x64, 0x91, 0x5f, 0x6b, 0xa3, 0x40,
-	0x14, 0xc5, 0x71, 0x93, 0x4d, 0xd6, 0xcb, 0x66, 0xff, 0xcc, 0x2e, 0x45, 0xc8, 0x4b, 0xb0, 0x2f,
-	0x52, 0x42, 0x2c, 0xed, 0x37, 0xd0, 0xc7, 0x92, 0x52, 0x6c, 0x9e, 0xfa, 0x52, 0x46, 0x73, 0xd5,
-	0x21, 0x3a, 0x23, 0x77, 0x46, 0xd0, 0x7e, 0x89, 0x7e, 0xe5, 0xe2, 0xc4, 0x04, 0xda, 0xbc, 0x9d,
-	0x73, 0xef, 0x0f, 0xe6, 0xcc, 0x3d, 0xb0, 0x54, 0xb4, 0x47, 0x42, 0x0a, 0x33, 0x25, 0x73, 0x51,
-	0xb4, 0xc4, 0x8d, 0x50, 0x72, 0xd3, 0x90, 0x32, 0x8a, 0xcd, 0xc7, 0xa5, 0x7f, 0x0d, 0x8b, 0x58,
-	0x49, 0x8d, 0x52, 0xb7, 0x7a, 0xd7, 0x37, 0xc8, 0x18, 0x4c, 0x4d, 0xdf, 0xa0, 0xe7, 0xac, 0x9c,
-	0xc0, 0x4d, 0xac, 0xf6, 0xdf, 0x1d, 0x70, 0x23, 0x6e, 0xb2, 0xf2, 0x59, 0xbc, 0x21, 0x0b, 0xe0,
-	0x77, 0xcd, 0xbb, 0x2d, 0x6a, 0xcd, 0x0b, 0x8c, 0x55, 0x2b, 0x8d, 0x85, 0x17, 0xc9, 0xd7, 0x31,
-	0xbb, 0x81, 0x3f, 0x3c, 0xd5, 0xaa, 0x6a, 0x0d, 0x6e, 0x79, 0x17, 0xf5, 0x06, 0xb5, 0xf7, 0xcd,
-	0xa2, 0x17, 0x73, 0xb6, 0x86, 0xbf, 0x0d, 0x61, 0x8e, 0x44, 0xb8, 0x3f, 0xc3, 0x13, 0x0b, 0x5f,
-	0x2e, 0xfc, 0x00, 0x7e, 0xda, 0x40, 0x3b, 0x51, 0xa3, 0x6a, 0x0d, 0xf3, 0x60, 0x6e, 0x8e, 0x72,
-	0x0c, 0x7e, 0xb2, 0x7e, 0x00, 0xbf, 0x62, 0x42, 0xfb, 0xf7, 0x27, 0x55, 0x89, 0xac, 0x67, 0x57,
-	0x30, 0x6b, 0xac, 0x1a, 0xd1, 0xd1, 0xf9, 0xb7, 0xe0, 0xc5, 0x25, 0x17, 0xf2, 0x33, 0xfe, 0xc8,
-	0x6b, 0xd4, 0xec, 0x3f, 0x7c, 0x97, 0x83, 0xf0, 0x9c, 0xd5, 0x24, 0x70, 0x93, 0xa3, 0x19, 0x52,
-	0x3c, 0xf0, 0xfc, 0xc0, 0x23, 0x52, 0x07, 0x24, 0x3d, 0xa4, 0x48, 0x8f, 0x72, 0xe4, 0x4e, 0xd6,
-	0xbf, 0x83, 0x7f, 0x71, 0xc9, 0xa5, 0xc4, 0x2a, 0x41, 0x6d, 0x48, 0x64, 0xc3, 0x03, 0x9a, 0x2d,
-	0xc1, 0xad, 0x79, 0xf7, 0x9a, 0x9d, 0x8f, 0x38, 0x4d, 0x7e, 0xd4, 0xbc, 0xb3, 0xd7, 0x8b, 0x36,
-	0x2f, 0xeb, 0x42, 0x98, 0xb2, 0x4d, 0x37, 0x99, 0xaa, 0xc3, 0xb2, 0x6f, 0x90, 0x2a, 0xdc, 0x17,
-	0x48, 0x61, 0xce, 0x53, 0x12, 0x59, 0x68, 0xab, 0xd4, 0xe1, 0x58, 0x65, 0x3a, 0xb3, 0xfe, 0xfe,
-	0x23, 0x00, 0x00, 0xff, 0xff, 0x7c, 0x81, 0xdc, 0xdc, 0xf9, 0x01, 0x00, 0x00,
+func init() { proto.RegisterFile("orderer/configuration.proto", fileDescriptor2) }
+
+var fileDescriptor2 = []byte{
+	// 812 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x54, 0xcf, 0x6f, 0xe3, 0x44,
+	0x14, 0xc6, 0x4d, 0xb6, 0x4d, 0x5e, 0x93, 0x36, 0x9d, 0x2d, 0x25, 0xda, 0xe5, 0x50, 0x2c, 0xb4,
+	0x0a, 0x68, 0x71, 0xaa, 0x20, 0x21, 0x04, 0xa7, 0x36, 0x8a, 0xc4, 0x8a, 0x4d, 0xd8, 0x9d, 0x44,
+	0x42, 0xda, 0x8b, 0x35, 0x71, 0x9e, 0x93, 0x51, 0x6d, 0x8f, 0x99, 0x19, 0xa3, 0x84, 0x23, 0x37,
+	0x4e, 0xf0, 0xd7, 0xf1, 0xf7, 0xa0, 0xf9, 0x11, 0x2b, 0x61, 0x59, 0x6e, 0xef, 0x7b, 0xdf, 0xe7,
+	0xf1, 0xfb, 0x0d, 0xcf, 0x85, 0x5c, 0xa1, 0x44, 0x39, 0x4c, 0x44, 0x91, 0xf2, 0x75, 0x25, 0x99,
+	0xe6, 0xa2, 0x88, 0x4a, 0x29, 0xb4, 0x20, 0x67, 0x9e, 0x0c, 0xff, 0x08, 0xa0, 0x3b, 0x16, 0x85,
+	0xc2, 0x42, 0x55, 0x6a, 0xb1, 0x2b, 0x91, 0x10, 0x68, 0xea, 0x5d, 0x89, 0xfd, 0xe0, 0x36, 0x18,
+	0xb4, 0xa9, 0xb5, 0xc9, 0x08, 0x9e, 0x28, 0xcd, 0x34, 0xf6, 0x4f, 0x6e, 0x83, 0xc1, 0xc5, 0xe8,
+	0xd3, 0xc8, 0x7f, 0x1e, 0x1d, 0x7d, 0x1a, 0xcd, 0x8d, 0x86, 0x3a, 0x69, 0x78, 0x07, 0x4f, 0x2c,
+	0x26, 0x3d, 0xe8, 0xcc, 0x17, 0xf7, 0x8b, 0x49, 0x3c, 0xfb, 0x89, 0x4e, 0xef, 0x5f, 0xf7, 0x3e,
+	0x22, 0x1f, 0xc3, 0x95, 0xf3, 0x4c, 0xef, 0x5f, 0xcd, 0x16, 0x93, 0xd9, 0xfd, 0x6c, 0x3c, 0xe9,
+	0x05, 0xe1, 0x9f, 0x01, 0xb4, 0x1f, 0x98, 0x4e, 0x36, 0x73, 0xfe, 0x1b, 0x92, 0x01, 0x5c, 0xe6,
+	0x6c, 0x3b, 0x45, 0xa5, 0xd8, 0x1a, 0xc7, 0xa2, 0x2a, 0xb4, 0x0d, 0xa9, 0x4b, 0xff, 0xed, 0x26,
+	0x5f, 0x42, 0x8f, 0x2d, 0x95, 0xc8, 0x2a, 0x8d, 0x53, 0xb6, 0x7d, 0xd8, 0x69, 0x54, 0x36, 0xd0,
+	0x2e, 0x7d, 0xcf, 0x4f, 0x5e, 0xc2, 0x55, 0x29, 0x31, 0x45, 0x29, 0x71, 0x55, 0x8b, 0x1b, 0x56,
+	0xfc, 0x3e, 0x11, 0x0e, 0xa0, 0x63, 0x03, 0x5a, 0xf0, 0x1c, 0x45, 0xa5, 0x49, 0x1f, 0xce, 0xb4,
+	0x33, 0x7d, 0x79, 0xf6, 0x30, 0x1c, 0xc0, 0xc5, 0x58, 0xa2, 0x2d, 0xf1, 0x1b, 0x91, 0xf1, 0x64,
+	0x47, 0x6e, 0xe0, 0xb4, 0xb4, 0x96, 0x97, 0x7a, 0x14, 0xde, 0x41, 0x7f, 0xbc, 0x61, 0xbc, 0x38,
+	0x96, 0xcf, 0x58, 0x8e, 0x8a, 0x5c, 0xc3, 0x93, 0xc2, 0x18, 0xfd, 0xe0, 0xb6, 0x31, 0x68, 0x53,
+	0x07, 0x4c, 0x14, 0x3f, 0xb2, 0xf4, 0x91, 0x3d, 0x48, 0xf1, 0x88, 0x52, 0x99, 0x28, 0x96, 0xce,
+	0xf4, 0xba, 0x3d, 0x0c, 0x7f, 0x80, 0x0b, 0xca, 0x52, 0xed, 0xba, 0xa2, 0x8d, 0xf6, 0x1b, 0x80,
+	0xa4, 0x46, 0x56, 0x7e, 0x3e, 0xba, 0xa9, 0xdb, 0x77, 0x24, 0xa6, 0x07, 0xca, 0xf0, 0x2d, 0x74,
+	0x8f, 0x48, 0xf3, 0x53, 0xb6, 0x5a, 0x49, 0x54, 0x6a, 0x9f, 0xba, 0x87, 0xe4, 0x05, 0x5c, 0x26,
+	0x19, 0xc7, 0x42, 0xc7, 0x3a, 0x53, 0x71, 0x82, 0x52, 0xdb, 0xea, 0x77, 0x68, 0xd7, 0xb9, 0x17,
+	0x99, 0x1a, 0xa3, 0xd4, 0xe1, 0xef, 0x01, 0x5c, 0xcc, 0x97, 0xa9, 0x9e, 0x62, 0xbe, 0x44, 0xa9,
+	0x36, 0xbc, 0x24, 0x1d, 0x08, 0x52, 0xfb, 0x5c, 0x93, 0x06, 0x29, 0xb9, 0x83, 0x6b, 0x89, 0xbf,
+	0x54, 0xa8, 0x74, 0xec, 0xcb, 0x1a, 0x17, 0x0a, 0x13, 0xfb, 0x5a, 0x93, 0x12, 0xcf, 0xf9, 0x5e,
+	0xcc, 0x14, 0x26, 0xe4, 0x0e, 0x5a, 0x12, 0xcb, 0x8c, 0x27, 0xcc, 0x34, 0xd1, 0xe4, 0x76, 0x5d,
+	0xe7, 0x66, 0x7e, 0x45, 0x1d, 0x49, 0x6b, 0x55, 0xf8, 0x33, 0x9c, 0x1f, 0x10, 0xff, 0x93, 0x15,
+	0x81, 0xe6, 0x41, 0x2a, 0xd6, 0x26, 0xcf, 0xa0, 0xc5, 0x57, 0x58, 0x68, 0xae, 0x77, 0x76, 0x66,
+	0x3a, 0xb4, 0xc6, 0xe1, 0x08, 0x9e, 0x8e, 0x37, 0xac, 0x28, 0x30, 0xa3, 0xa8, 0xb4, 0xe4, 0x89,
+	0xe9, 0xad, 0x22, 0xcf, 0xa1, 0x9d, 0xb3, 0x6d, 0x9c, 0xd4, 0xf3, 0xdb, 0xa4, 0xad, 0x9c, 0x6d,
+	0xed, 0xe0, 0x86, 0x7f, 0x05, 0x00, 0x94, 0x69, 0x7c, 0xcd, 0x73, 0xae, 0xcd, 0x6c, 0x9e, 0x25,
+	0xee, 0x09, 0xab, 0x3c, 0x1f, 0x91, 0x83, 0x46, 0x79, 0x15, 0xdd, 0x4b, 0x48, 0x74, 0x10, 0xcc,
+	0xc9, 0x07, 0xe5, 0xb5, 0x86, 0x7c, 0x0e, 0x8d, 0x5c, 0x95, 0xfd, 0xc6, 0x07, 0xa5, 0x86, 0x0e,
+	0xdf, 0x42, 0xbb, 0xf6, 0x90, 0x08, 0x9e, 0xe6, 0x6e, 0xd1, 0x54, 0x5c, 0xa2, 0x8c, 0x15, 0x26,
+	0xa2, 0x58, 0xf9, 0x35, 0xbc, 0xda, 0x53, 0x6f, 0x50, 0xce, 0x2d, 0x61, 0xc6, 0x77, 0x59, 0x49,
+	0xa5, 0xfd, 0xf6, 0x39, 0x10, 0xbe, 0x80, 0x8e, 0xaf, 0x8c, 0xbb, 0x07, 0x37, 0x70, 0xaa, 0x90,
+	0x65, 0xe8, 0x1e, 0x6a, 0x51, 0x8f, 0xc2, 0xbf, 0x03, 0xb8, 0x39, 0xba, 0x27, 0x53, 0xbe, 0x76,
+	0x47, 0xcb, 0x54, 0x31, 0x95, 0x22, 0x8f, 0x0f, 0x0e, 0x53, 0xcb, 0x38, 0x8c, 0x8a, 0x7c, 0x02,
+	0x67, 0x5a, 0x38, 0xea, 0xc4, 0x6d, 0x9a, 0x16, 0x96, 0x78, 0x05, 0xad, 0x1c, 0x35, 0x5b, 0x31,
+	0xcd, 0xfc, 0x74, 0x7c, 0xf5, 0xdf, 0x87, 0xab, 0xfe, 0x51, 0x34, 0xf5, 0xfa, 0x49, 0xa1, 0xe5,
+	0x8e, 0xd6, 0x9f, 0x3f, 0xfb, 0x1e, 0xba, 0x47, 0x14, 0xe9, 0x41, 0xe3, 0x11, 0xf7, 0xab, 0x6d,
+	0x4c, 0x93, 0xfc, 0xaf, 0x2c, 0xab, 0xd0, 0x4f, 0x8c, 0x03, 0xdf, 0x9d, 0x7c, 0x1b, 0x84, 0xef,
+	0x00, 0xec, 0xfe, 0x2e, 0x44, 0xc9, 0x13, 0xf2, 0x19, 0x74, 0x72, 0x5e, 0xc4, 0xf5, 0xdc, 0xba,
+	0x6a, 0x9e, 0xe7, 0xbc, 0xf0, 0x43, 0xa9, 0xc8, 0x17, 0xd0, 0x33, 0xc3, 0xce, 0x25, 0xc6, 0xb8,
+	0xe5, 0x4a, 0xf3, 0x62, 0x6d, 0x5f, 0x6d, 0xd1, 0x4b, 0xef, 0x9f, 0x78, 0xf7, 0x43, 0xf4, 0xee,
+	0xe5, 0x9a, 0xeb, 0x4d, 0xb5, 0x8c, 0x12, 0x91, 0x0f, 0x37, 0xbb, 0x12, 0x65, 0x86, 0xab, 0x35,
+	0xca, 0x61, 0xca, 0x96, 0x92, 0x27, 0x43, 0x7b, 0xef, 0xd5, 0xd0, 0xe7, 0xbd, 0x3c, 0xb5, 0xf8,
+	0xeb, 0x7f, 0x06, 0x00, 0x8c, 0x2a, 0x2f, 0x61, 0x1e, 0x06, 0x00, 0x00,
 }
@@ -26,11 +26,9 @@ type KafkaMessage struct {
 func (m *KafkaMessage) Reset()                    { *m = KafkaMessage{} }
 func (m *KafkaMessage) String() string            { return proto.CompactTextString(m) }
 func (*KafkaMessage) ProtoMessage()               {}
-func (*KafkaMessage) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{0} }
+func (*KafkaMessage) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{0} }
 
-type isKafkaMessage_Type interface {
-	isKafkaMessage_Type()
-}
+type isKafkaMessage_Type interface{ isKafkaMessage_Type() }
 
 type KafkaMessage_Regular struct {
 	Regular *KafkaMessageRegular `protobuf:"bytes,1,opt,name=regular,oneof"`
@@ -169,13 +167,16 @@ func _KafkaMessage_OneofSizer(msg proto.Message) (n int) {
 
 // KafkaMessageRegular wraps a marshalled envelope.
 type KafkaMessageRegular struct {
-	Payload []byte `protobuf:"bytes,1,opt,name=payload,proto3" json:"payload,omitempty"`
+	Payload        []byte `protobuf:"bytes,1,opt,name=payload,proto3" json:"payload,omitempty"`
+	ConfigSeq      uint64 `protobuf:"varint,2,opt,name=config_seq,json=configSeq" json:"config_seq,omitempty"`
+	OriginalOffset int64  `protobuf:"varint,3,opt,name=original_offset,json=originalOffset" json:"original_offset,omitempty"`
+	Resubmitted    bool   `protobuf:"varint,4,opt,name=resubmitted" json:"resubmitted,omitempty"`
 }
 
 func (m *KafkaMessageRegular) Reset()                    { *m = KafkaMessageRegular{} }
 func (m *KafkaMessageRegular) String() string            { return proto.CompactTextString(m) }
 func (*KafkaMessageRegular) ProtoMessage()               {}
-func (*KafkaMessageRegular) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{1} }
+func (*KafkaMessageRegular) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{1} }
 
 // KafkaMessageTimeToCut is used to signal to the orderers
 // that it is time to cut block <block_number>.
@@ -186,7 +187,7 @@ type KafkaMessageTimeToCut struct {
 func (m *KafkaMessageTimeToCut) Reset()                    { *m = KafkaMessageTimeToCut{} }
 func (m *KafkaMessageTimeToCut) String() string            { return proto.CompactTextString(m) }
 func (*KafkaMessageTimeToCut) ProtoMessage()               {}
-func (*KafkaMessageTimeToCut) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{2} }
+func (*KafkaMessageTimeToCut) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{2} }
 
 // KafkaMessageConnect is posted by an orderer upon booting up.
 // It is used to prevent the panic that would be caused if we
@@ -199,19 +200,22 @@ type KafkaMessageConnect struct {
 func (m *KafkaMessageConnect) Reset()                    { *m = KafkaMessageConnect{} }
 func (m *KafkaMessageConnect) String() string            { return proto.CompactTextString(m) }
 func (*KafkaMessageConnect) ProtoMessage()               {}
-func (*KafkaMessageConnect) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{3} }
+func (*KafkaMessageConnect) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{3} }
 
 // LastOffsetPersisted is the encoded value for the Metadata message
 // which is encoded in the ORDERER block metadata index for the case
 // of the Kafka-based orderer.
 type KafkaMetadata struct {
 	LastOffsetPersisted int64 `protobuf:"varint,1,opt,name=last_offset_persisted,json=lastOffsetPersisted" json:"last_offset_persisted,omitempty"`
+	// The original offsets of the resubmitted messages which were ordered,
+	// further copies of them are dropped
+	OriginalOffsetsProcessed []int64 `protobuf:"varint,2,rep,packed,name=original_offsets_processed,json=originalOffsetsProcessed" json:"original_offsets_processed,omitempty"`
 }
 
 func (m *KafkaMetadata) Reset()                    { *m = KafkaMetadata{} }
 func (m *KafkaMetadata) String() string            { return proto.CompactTextString(m) }
 func (*KafkaMetadata) ProtoMessage()               {}
-func (*KafkaMetadata) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{4} }
+func (*KafkaMetadata) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{4} }
 
 func init() {
 	proto.RegisterType((*KafkaMessage)(nil), "orderer.KafkaMessage")
@@ -221,27 +225,33 @@ func init() {
 	proto.RegisterType((*KafkaMetadata)(nil), "orderer.KafkaMetadata")
 }
 
-func init() { proto.RegisterFile("orderer/kafka.proto", fileDescriptor2) }
-
-var fileDescriptor2 = []byte{
-	// 304 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0x7c, 0x91, 0x3d, 0x6b, 0xf3, 0x30,
-	0x14, 0x85, 0x93, 0x37, 0x21, 0xe1, 0x55, 0xd2, 0x45, 0x21, 0xe0, 0xa1, 0x94, 0x36, 0x53, 0x87,
-	0x62, 0x41, 0xba, 0x94, 0x4e, 0x25, 0x59, 0x02, 0xa5, 0x1f, 0x08, 0x4f, 0x5d, 0x8c, 0x2c, 0x5f,
-	0x3b, 0xc6, 0x1f, 0x32, 0xd2, 0xf5, 0xe0, 0xff, 0xd8, 0x1f, 0x55, 0x2c, 0xcb, 0x50, 0x8a, 0xc9,
-	0x78, 0xef, 0x79, 0x0e, 0xe7, 0xe8, 0x8a, 0x6c, 0x94, 0x8e, 0x41, 0x83, 0x66, 0xb9, 0x48, 0x72,
-	0xe1, 0xd7, 0x5a, 0xa1, 0xa2, 0x4b, 0xb7, 0xdc, 0x7d, 0x4f, 0xc9, 0xfa, 0xb5, 0x13, 0xde, 0xc0,
-	0x18, 0x91, 0x02, 0x7d, 0x22, 0x4b, 0x0d, 0x69, 0x53, 0x08, 0xed, 0x4d, 0x6f, 0xa7, 0xf7, 0xab,
-	0xfd, 0xb5, 0xef, 0x58, 0xff, 0x37, 0xc7, 0x7b, 0xe6, 0x34, 0xe1, 0x03, 0x4e, 0x5f, 0xc8, 0x0a,
-	0xb3, 0x12, 0x42, 0x54, 0xa1, 0x6c, 0xd0, 0xfb, 0x67, 0xdd, 0x37, 0xa3, 0xee, 0x20, 0x2b, 0x21,
-	0x50, 0xc7, 0x06, 0x4f, 0x13, 0xfe, 0x1f, 0x87, 0xa1, 0xcb, 0x96, 0xaa, 0xaa, 0x40, 0xa2, 0x37,
-	0xbb, 0x90, 0x7d, 0xec, 0x99, 0x2e, 0xdb, 0xe1, 0x87, 0x05, 0x99, 0x07, 0x6d, 0x0d, 0x3b, 0x46,
-	0x36, 0x23, 0x2d, 0xa9, 0x47, 0x96, 0xb5, 0x68, 0x0b, 0x25, 0x62, 0xfb, 0xa8, 0x35, 0x1f, 0xc6,
-	0xdd, 0x33, 0xd9, 0x8e, 0x16, 0xa3, 0x77, 0x64, 0x1d, 0x15, 0x4a, 0xe6, 0x61, 0xd5, 0x94, 0x11,
-	0xf4, 0xc7, 0x98, 0xf3, 0x95, 0xdd, 0xbd, 0xdb, 0xd5, 0xdf, 0x30, 0x57, 0xeb, 0x42, 0xd8, 0x91,
-	0x5c, 0x39, 0x03, 0x8a, 0x58, 0xa0, 0xa0, 0x7b, 0xb2, 0x2d, 0x84, 0xc1, 0x50, 0x25, 0x89, 0x01,
-	0x0c, 0x6b, 0xd0, 0x26, 0x33, 0x08, 0xbd, 0x71, 0xc6, 0x37, 0x9d, 0xf8, 0x61, 0xb5, 0xcf, 0x41,
-	0x3a, 0xf8, 0x5f, 0x0f, 0x69, 0x86, 0xe7, 0x26, 0xf2, 0xa5, 0x2a, 0xd9, 0xb9, 0xad, 0x41, 0x17,
-	0x10, 0xa7, 0xa0, 0x59, 0x22, 0x22, 0x9d, 0x49, 0x66, 0x7f, 0xd8, 0x30, 0x77, 0xb9, 0x68, 0x61,
-	0xe7, 0xc7, 0x9f, 0x00, 0x00, 0x00, 0xff, 0xff, 0x38, 0x89, 0x66, 0xa4, 0x08, 0x02, 0x00, 0x00,
+func init() { proto.RegisterFile("orderer/kafka.proto", fileDescriptor3) }
+
+var fileDescriptor3 = []byte{
+	// 391 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x52, 0x4f, 0x8b, 0xd4, 0x30,
+	0x14, 0xdf, 0x6e, 0xcb, 0x8e, 0xfb, 0x3a, 0x2a, 0xa4, 0x2c, 0x14, 0x51, 0xa9, 0xbd, 0x38, 0x07,
+	0x69, 0x61, 0xbd, 0x88, 0x78, 0x90, 0xdd, 0xcb, 0x82, 0xa8, 0x43, 0x9c, 0x93, 0x97, 0x92, 0xa6,
+	0xaf, 0x9d, 0x30, 0x6d, 0xd3, 0x49, 0xd2, 0xc3, 0x1c, 0xfd, 0x18, 0x7e, 0x27, 0x3f, 0x94, 0x34,
+	0x6d, 0x61, 0x94, 0xea, 0xf1, 0xfd, 0xfe, 0xf0, 0xde, 0xef, 0x97, 0x40, 0x20, 0x55, 0x81, 0x0a,
+	0x55, 0x7a, 0x60, 0xe5, 0x81, 0x25, 0x9d, 0x92, 0x46, 0x92, 0xd5, 0x04, 0xc6, 0xbf, 0x1c, 0x58,
+	0x7f, 0x1a, 0x88, 0xcf, 0xa8, 0x35, 0xab, 0x90, 0xbc, 0x83, 0x95, 0xc2, 0xaa, 0xaf, 0x99, 0x0a,
+	0x9d, 0xc8, 0xd9, 0xf8, 0xb7, 0xcf, 0x93, 0x49, 0x9b, 0x9c, 0xeb, 0xe8, 0xa8, 0x79, 0xb8, 0xa0,
+	0xb3, 0x9c, 0x7c, 0x04, 0xdf, 0x88, 0x06, 0x33, 0x23, 0x33, 0xde, 0x9b, 0xf0, 0xd2, 0xba, 0x5f,
+	0x2e, 0xba, 0x77, 0xa2, 0xc1, 0x9d, 0xbc, 0xef, 0xcd, 0xc3, 0x05, 0xbd, 0x36, 0xf3, 0x30, 0xec,
+	0xe6, 0xb2, 0x6d, 0x91, 0x9b, 0xd0, 0xfd, 0xcf, 0xee, 0xfb, 0x51, 0x33, 0xec, 0x9e, 0xe4, 0x77,
+	0x57, 0xe0, 0xed, 0x4e, 0x1d, 0xc6, 0x3f, 0x1d, 0x08, 0x16, 0xce, 0x24, 0x21, 0xac, 0x3a, 0x76,
+	0xaa, 0x25, 0x2b, 0x6c, 0xaa, 0x35, 0x9d, 0x47, 0xf2, 0x02, 0x80, 0xcb, 0xb6, 0x14, 0x55, 0xa6,
+	0xf1, 0x68, 0x8f, 0xf6, 0xe8, 0xf5, 0x88, 0x7c, 0xc3, 0x23, 0x79, 0x0d, 0x4f, 0xa5, 0x12, 0x95,
+	0x68, 0x59, 0x9d, 0xc9, 0xb2, 0xd4, 0x38, 0x9e, 0xe6, 0xd2, 0x27, 0x33, 0xfc, 0xd5, 0xa2, 0x24,
+	0x02, 0x5f, 0xa1, 0xee, 0xf3, 0x46, 0x18, 0x83, 0x45, 0xe8, 0x45, 0xce, 0xe6, 0x11, 0x3d, 0x87,
+	0xe2, 0xf7, 0x70, 0xb3, 0xd8, 0x01, 0x79, 0x05, 0xeb, 0xbc, 0x96, 0xfc, 0x90, 0xb5, 0x7d, 0x93,
+	0xe3, 0xd8, 0xbb, 0x47, 0x7d, 0x8b, 0x7d, 0xb1, 0x50, 0x9c, 0x42, 0xb0, 0xd0, 0xc0, 0xbf, 0x63,
+	0xc5, 0x3f, 0x1c, 0x78, 0x3c, 0x39, 0x0c, 0x2b, 0x98, 0x61, 0xe4, 0x16, 0x6e, 0x6a, 0xa6, 0xcd,
+	0x94, 0x22, 0xeb, 0x50, 0x69, 0xa1, 0x0d, 0x8e, 0x4e, 0x97, 0x06, 0x03, 0x39, 0x66, 0xd9, 0xce,
+	0x14, 0xf9, 0x00, 0xcf, 0xfe, 0x4a, 0xaf, 0xb3, 0x4e, 0x49, 0x8e, 0x5a, 0x63, 0x11, 0x5e, 0x46,
+	0xee, 0xc6, 0xa5, 0xe1, 0x9f, 0x45, 0xe8, 0xed, 0xcc, 0xdf, 0x25, 0xdf, 0xdf, 0x54, 0xc2, 0xec,
+	0xfb, 0x3c, 0xe1, 0xb2, 0x49, 0xf7, 0xa7, 0x0e, 0x55, 0x8d, 0x45, 0x85, 0x2a, 0x2d, 0x59, 0xae,
+	0x04, 0x4f, 0xed, 0x5f, 0xd4, 0xe9, 0xf4, 0xc6, 0xf9, 0x95, 0x9d, 0xdf, 0xfe, 0x1e, 0x00, 0x0c,
+	0x5f, 0x1f, 0xa8, 0xb2, 0x02, 0x00, 0x00,
 }
@@ -0,0 +1,878 @@
+// Code generated by protoc-gen-go.
+// source: orderer/raft.proto
+// DO NOT EDIT!
+
+package orderer
+
+import proto "github.com/golang/protobuf/proto"
+import fmt "fmt"
+import math "math"
+import common "github.com/hyperledger/fabric/protos/common"
+
+import (
+	context "golang.org/x/net/context"
+	grpc "google.golang.org/grpc"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// RaftMessage is the data of the entries of the raft log of a channel.
+// Like the messages of the Kafka-based orderer, the entries are run
+// through the block cutter of every orderer in the order of the log.
+type RaftMessage struct {
+	// Types that are valid to be assigned to Type:
+	//	*RaftMessage_Regular
+	//	*RaftMessage_TimeToCut
+	Type isRaftMessage_Type `protobuf_oneof:"Type"`
+}
+
+func (m *RaftMessage) Reset()                    { *m = RaftMessage{} }
+func (m *RaftMessage) String() string            { return proto.CompactTextString(m) }
+func (*RaftMessage) ProtoMessage()               {}
+func (*RaftMessage) Descriptor() ([]byte, []int) { return fileDescriptor4, []int{0} }
+
+type isRaftMessage_Type interface{ isRaftMessage_Type() }
+
+type RaftMessage_Regular struct {
+	Regular *RaftMessageRegular `protobuf:"bytes,1,opt,name=regular,oneof"`
+}
+type RaftMessage_TimeToCut struct {
+	TimeToCut *RaftMessageTimeToCut `protobuf:"bytes,2,opt,name=time_to_cut,json=timeToCut,oneof"`
+}
+
+func (*RaftMessage_Regular) isRaftMessage_Type()   {}
+func (*RaftMessage_TimeToCut) isRaftMessage_Type() {}
+
+func (m *RaftMessage) GetType() isRaftMessage_Type {
+	if m != nil {
+		return m.Type
+	}
+	return nil
+}
+
+func (m *RaftMessage) GetRegular() *RaftMessageRegular {
+	if x, ok := m.GetType().(*RaftMessage_Regular); ok {
+		return x.Regular
+	}
+	return nil
+}
+
+func (m *RaftMessage) GetTimeToCut() *RaftMessageTimeToCut {
+	if x, ok := m.GetType().(*RaftMessage_TimeToCut); ok {
+		return x.TimeToCut
+	}
+	return nil
+}
+
+// XXX_OneofFuncs is for the internal use of the proto package.
+func (*RaftMessage) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
+	return _RaftMessage_OneofMarshaler, _RaftMessage_OneofUnmarshaler, _RaftMessage_OneofSizer, []interface{}{
+		(*RaftMessage_Regular)(nil),
+		(*RaftMessage_TimeToCut)(nil),
+	}
+}
+
+func _RaftMessage_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
+	m := msg.(*RaftMessage)
+	// Type
+	switch x := m.Type.(type) {
+	case *RaftMessage_Regular:
+		b.EncodeVarint(1<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.Regular); err != nil {
+			return err
+		}
+	case *RaftMessage_TimeToCut:
+		b.EncodeVarint(2<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.TimeToCut); err != nil {
+			return err
+		}
+	case nil:
+	default:
+		return fmt.Errorf("RaftMessage.Type has unexpected type %T", x)
+	}
+	return nil
+}
+
+func _RaftMessage_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error) {
+	m := msg.(*RaftMessage)
+	switch tag {
+	case 1: // Type.regular
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(RaftMessageRegular)
+		err := b.DecodeMessage(msg)
+		m.Type = &RaftMessage_Regular{msg}
+		return true, err
+	case 2: // Type.time_to_cut
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(RaftMessageTimeToCut)
+		err := b.DecodeMessage(msg)
+		m.Type = &RaftMessage_TimeToCut{msg}
+		return true, err
+	default:
+		return false, nil
+	}
+}
+
+func _RaftMessage_OneofSizer(msg proto.Message) (n int) {
+	m := msg.(*RaftMessage)
+	// Type
+	switch x := m.Type.(type) {
+	case *RaftMessage_Regular:
+		s := proto.Size(x.Regular)
+		n += proto.SizeVarint(1<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *RaftMessage_TimeToCut:
+		s := proto.Size(x.TimeToCut)
+		n += proto.SizeVarint(2<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case nil:
+	default:
+		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
+	}
+	return n
+}
+
+// RaftMessageRegular wraps a marshalled envelope.
+type RaftMessageRegular struct {
+	Payload []byte `protobuf:"bytes,1,opt,name=payload,proto3" json:"payload,omitempty"`
+}
+
+func (m *RaftMessageRegular) Reset()                    { *m = RaftMessageRegular{} }
+func (m *RaftMessageRegular) String() string            { return proto.CompactTextString(m) }
+func (*RaftMessageRegular) ProtoMessage()               {}
+func (*RaftMessageRegular) Descriptor() ([]byte, []int) { return fileDescriptor4, []int{1} }
+
+// RaftMessageTimeToCut is used to signal to the orderers
+// that it is time to cut block <block_number>.
+type RaftMessageTimeToCut struct {
+	BlockNumber uint64 `protobuf:"varint,1,opt,name=block_number,json=blockNumber" json:"block_number,omitempty"`
+}
+
+func (m *RaftMessageTimeToCut) Reset()                    { *m = RaftMessageTimeToCut{} }
+func (m *RaftMessageTimeToCut) String() string            { return proto.CompactTextString(m) }
+func (*RaftMessageTimeToCut) ProtoMessage()               {}
+func (*RaftMessageTimeToCut) Descriptor() ([]byte, []int) { return fileDescriptor4, []int{2} }
+
+// RaftMetadata is the encoded value for the Metadata message which is
+// encoded in the ORDERER block metadata index for the case of the
+// Raft-based orderer.
+type RaftMetadata struct {
+	// Index of the last raft entry whose message is part of the ledger
+	RaftIndex uint64 `protobuf:"varint,1,opt,name=raft_index,json=raftIndex" json:"raft_index,omitempty"`
+}
+
+func (m *RaftMetadata) Reset()                    { *m = RaftMetadata{} }
+func (m *RaftMetadata) String() string            { return proto.CompactTextString(m) }
+func (*RaftMetadata) ProtoMessage()               {}
+func (*RaftMetadata) Descriptor() ([]byte, []int) { return fileDescriptor4, []int{3} }
+
+// RaftEntry is an entry of the raft log. The entry appended by a new
+// leader at the start of its term has no data.
+type RaftEntry struct {
+	Term  uint64 `protobuf:"varint,1,opt,name=term" json:"term,omitempty"`
+	Index uint64 `protobuf:"varint,2,opt,name=index" json:"index,omitempty"`
+	Data  []byte `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+}
+
+func (m *RaftEntry) Reset()                    { *m = RaftEntry{} }
+func (m *RaftEntry) String() string            { return proto.CompactTextString(m) }
+func (*RaftEntry) ProtoMessage()               {}
+func (*RaftEntry) Descriptor() ([]byte, []int) { return fileDescriptor4, []int{4} }
+
+// RaftHardState is the raft state which is persisted before any message
+// is sent.
+type RaftHardState struct {
+	Term   uint64 `protobuf:"varint,1,opt,name=term" json:"term,omitempty"`
+	Vote   string `protobuf:"bytes,2,opt,name=vote" json:"vote,omitempty"`
+	Commit uint64 `protobuf:"varint,3,opt,name=commit" json:"commit,omitempty"`
+}
+
+func (m *RaftHardState) Reset()                    { *m = RaftHardState{} }
+func (m *RaftHardState) String() string            { return proto.CompactTextString(m) }
+func (*RaftHardState) ProtoMessage()               {}
+func (*RaftHardState) Descriptor() ([]byte, []int) { return fileDescriptor4, []int{5} }
+
+// RaftSnapshot replaces the prefix of the raft log up to index. The
+// effects of the compacted entries are the blocks of the ledger up to
+// block_number.
+type RaftSnapshot struct {
+	Index       uint64 `protobuf:"varint,1,opt,name=index" json:"index,omitempty"`
+	Term        uint64 `protobuf:"varint,2,opt,name=term" json:"term,omitempty"`
+	BlockNumber uint64 `protobuf:"varint,3,opt,name=block_number,json=blockNumber" json:"block_number,omitempty"`
+}
+
+func (m *RaftSnapshot) Reset()                    { *m = RaftSnapshot{} }
+func (m *RaftSnapshot) String() string            { return proto.CompactTextString(m) }
+func (*RaftSnapshot) ProtoMessage()               {}
+func (*RaftSnapshot) Descriptor() ([]byte, []int) { return fileDescriptor4, []int{6} }
+
+// RaftWALRecord is a record of the write ahead log of a channel.
+type RaftWALRecord struct {
+	// Types that are valid to be assigned to Type:
+	//	*RaftWALRecord_Entry
+	//	*RaftWALRecord_HardState
+	Type isRaftWALRecord_Type `protobuf_oneof:"Type"`
+}
+
+func (m *RaftWALRecord) Reset()                    { *m = RaftWALRecord{} }
+func (m *RaftWALRecord) String() string            { return proto.CompactTextString(m) }
+func (*RaftWALRecord) ProtoMessage()               {}
+func (*RaftWALRecord) Descriptor() ([]byte, []int) { return fileDescriptor4, []int{7} }
+
+type isRaftWALRecord_Type interface{ isRaftWALRecord_Type() }
+
+type RaftWALRecord_Entry struct {
+	Entry *RaftEntry `protobuf:"bytes,1,opt,name=entry,oneof"`
+}
+type RaftWALRecord_HardState struct {
+	HardState *RaftHardState `protobuf:"bytes,2,opt,name=hard_state,json=hardState,oneof"`
+}
+
+func (*RaftWALRecord_Entry) isRaftWALRecord_Type()     {}
+func (*RaftWALRecord_HardState) isRaftWALRecord_Type() {}
+
+func (m *RaftWALRecord) GetType() isRaftWALRecord_Type {
+	if m != nil {
+		return m.Type
+	}
+	return nil
+}
+
+func (m *RaftWALRecord) GetEntry() *RaftEntry {
+	if x, ok := m.GetType().(*RaftWALRecord_Entry); ok {
+		return x.Entry
+	}
+	return nil
+}
+
+func (m *RaftWALRecord) GetHardState() *RaftHardState {
+	if x, ok := m.GetType().(*RaftWALRecord_HardState); ok {
+		return x.HardState
+	}
+	return nil
+}
+
+// XXX_OneofFuncs is for the internal use of the proto package.
+func (*RaftWALRecord) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
+	return _RaftWALRecord_OneofMarshaler, _RaftWALRecord_OneofUnmarshaler, _RaftWALRecord_OneofSizer, []interface{}{
+		(*RaftWALRecord_Entry)(nil),
+		(*RaftWALRecord_HardState)(nil),
+	}
+}
+
+func _RaftWALRecord_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
+	m := msg.(*RaftWALRecord)
+	// Type
+	switch x := m.Type.(type) {
+	case *RaftWALRecord_Entry:
+		b.EncodeVarint(1<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.Entry); err != nil {
+			return err
+		}
+	case *RaftWALRecord_HardState:
+		b.EncodeVarint(2<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.HardState); err != nil {
+			return err
+		}
+	case nil:
+	default:
+		return fmt.Errorf("RaftWALRecord.Type has unexpected type %T", x)
+	}
+	return nil
+}
+
+func _RaftWALRecord_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error) {
+	m := msg.(*RaftWALRecord)
+	switch tag {
+	case 1: // Type.entry
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(RaftEntry)
+		err := b.DecodeMessage(msg)
+		m.Type = &RaftWALRecord_Entry{msg}
+		return true, err
+	case 2: // Type.hard_state
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(RaftHardState)
+		err := b.DecodeMessage(msg)
+		m.Type = &RaftWALRecord_HardState{msg}
+		return true, err
+	default:
+		return false, nil
+	}
+}
+
+func _RaftWALRecord_OneofSizer(msg proto.Message) (n int) {
+	m := msg.(*RaftWALRecord)
+	// Type
+	switch x := m.Type.(type) {
+	case *RaftWALRecord_Entry:
+		s := proto.Size(x.Entry)
+		n += proto.SizeVarint(1<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *RaftWALRecord_HardState:
+		s := proto.Size(x.HardState)
+		n += proto.SizeVarint(2<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case nil:
+	default:
+		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
+	}
+	return n
+}
+
+// RaftStep is a message exchanged between the consenters of a channel.
+type RaftStep struct {
+	Channel string `protobuf:"bytes,1,opt,name=channel" json:"channel,omitempty"`
+	// Address of the sender as listed in the consenter set
+	From string `protobuf:"bytes,2,opt,name=from" json:"from,omitempty"`
+	Term uint64 `protobuf:"varint,3,opt,name=term" json:"term,omitempty"`
+	// Types that are valid to be assigned to Type:
+	//	*RaftStep_VoteRequest
+	//	*RaftStep_VoteResponse
+	//	*RaftStep_AppendRequest
+	//	*RaftStep_AppendResponse
+	//	*RaftStep_Snapshot
+	//	*RaftStep_Proposal
+	Type isRaftStep_Type `protobuf_oneof:"Type"`
+}
+
+func (m *RaftStep) Reset()                    { *m = RaftStep{} }
+func (m *RaftStep) String() string            { return proto.CompactTextString(m) }
+func (*RaftStep) ProtoMessage()               {}
+func (*RaftStep) Descriptor() ([]byte, []int) { return fileDescriptor4, []int{8} }
+
+type isRaftStep_Type interface{ isRaftStep_Type() }
+
+type RaftStep_VoteRequest struct {
+	VoteRequest *RaftVoteRequest `protobuf:"bytes,4,opt,name=vote_request,json=voteRequest,oneof"`
+}
+type RaftStep_VoteResponse struct {
+	VoteResponse *RaftVoteResponse `protobuf:"bytes,5,opt,name=vote_response,json=voteResponse,oneof"`
+}
+type RaftStep_AppendRequest struct {
+	AppendRequest *RaftAppendRequest `protobuf:"bytes,6,opt,name=append_request,json=appendRequest,oneof"`
+}
+type RaftStep_AppendResponse struct {
+	AppendResponse *RaftAppendResponse `protobuf:"bytes,7,opt,name=append_response,json=appendResponse,oneof"`
+}
+type RaftStep_Snapshot struct {
+	Snapshot *RaftSnapshot `protobuf:"bytes,8,opt,name=snapshot,oneof"`
+}
+type RaftStep_Proposal struct {
+	Proposal *RaftProposal `protobuf:"bytes,9,opt,name=proposal,oneof"`
+}
+
+func (*RaftStep_VoteRequest) isRaftStep_Type()    {}
+func (*RaftStep_VoteResponse) isRaftStep_Type()   {}
+func (*RaftStep_AppendRequest) isRaftStep_Type()  {}
+func (*RaftStep_AppendResponse) isRaftStep_Type() {}
+func (*RaftStep_Snapshot) isRaftStep_Type()       {}
+func (*RaftStep_Proposal) isRaftStep_Type()       {}
+
+func (m *RaftStep) GetType() isRaftStep_Type {
+	if m != nil {
+		return m.Type
+	}
+	return nil
+}
+
+func (m *RaftStep) GetVoteRequest() *RaftVoteRequest {
+	if x, ok := m.GetType().(*RaftStep_VoteRequest); ok {
+		return x.VoteRequest
+	}
+	return nil
+}
+
+func (m *RaftStep) GetVoteResponse() *RaftVoteResponse {
+	if x, ok := m.GetType().(*RaftStep_VoteResponse); ok {
+		return x.VoteResponse
+	}
+	return nil
+}
+
+func (m *RaftStep) GetAppendRequest() *RaftAppendRequest {
+	if x, ok := m.GetType().(*RaftStep_AppendRequest); ok {
+		return x.AppendRequest
+	}
+	return nil
+}
+
+func (m *RaftStep) GetAppendResponse() *RaftAppendResponse {
+	if x, ok := m.GetType().(*RaftStep_AppendResponse); ok {
+		return x.AppendResponse
+	}
+	return nil
+}
+
+func (m *RaftStep) GetSnapshot() *RaftSnapshot {
+	if x, ok := m.GetType().(*RaftStep_Snapshot); ok {
+		return x.Snapshot
+	}
+	return nil
+}
+
+func (m *RaftStep) GetProposal() *RaftProposal {
+	if x, ok := m.GetType().(*RaftStep_Proposal); ok {
+		return x.Proposal
+	}
+	return nil
+}
+
+// XXX_OneofFuncs is for the internal use of the proto package.
+func (*RaftStep) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
+	return _RaftStep_OneofMarshaler, _RaftStep_OneofUnmarshaler, _RaftStep_OneofSizer, []interface{}{
+		(*RaftStep_VoteRequest)(nil),
+		(*RaftStep_VoteResponse)(nil),
+		(*RaftStep_AppendRequest)(nil),
+		(*RaftStep_AppendResponse)(nil),
+		(*RaftStep_Snapshot)(nil),
+		(*RaftStep_Proposal)(nil),
+	}
+}
+
+func _RaftStep_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
+	m := msg.(*RaftStep)
+	// Type
+	switch x := m.Type.(type) {
+	case *RaftStep_VoteRequest:
+		b.EncodeVarint(4<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.VoteRequest); err != nil {
+			return err
+		}
+	case *RaftStep_VoteResponse:
+		b.EncodeVarint(5<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.VoteResponse); err != nil {
+			return err
+		}
+	case *RaftStep_AppendRequest:
+		b.EncodeVarint(6<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.AppendRequest); err != nil {
+			return err
+		}
+	case *RaftStep_AppendResponse:
+		b.EncodeVarint(7<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.AppendResponse); err != nil {
+			return err
+		}
+	case *RaftStep_Snapshot:
+		b.EncodeVarint(8<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.Snapshot); err != nil {
+			return err
+		}
+	case *RaftStep_Proposal:
+		b.EncodeVarint(9<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.Proposal); err != nil {
+			return err
+		}
+	case nil:
+	default:
+		return fmt.Errorf("RaftStep.Type has unexpected type %T", x)
+	}
+	return nil
+}
+
+func _RaftStep_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error) {
+	m := msg.(*RaftStep)
+	switch tag {
+	case 4: // Type.vote_request
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(RaftVoteRequest)
+		err := b.DecodeMessage(msg)
+		m.Type = &RaftStep_VoteRequest{msg}
+		return true, err
+	case 5: // Type.vote_response
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(RaftVoteResponse)
+		err := b.DecodeMessage(msg)
+		m.Type = &RaftStep_VoteResponse{msg}
+		return true, err
+	case 6: // Type.append_request
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(RaftAppendRequest)
+		err := b.DecodeMessage(msg)
+		m.Type = &RaftStep_AppendRequest{msg}
+		return true, err
+	case 7: // Type.append_response
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(RaftAppendResponse)
+		err := b.DecodeMessage(msg)
+		m.Type = &RaftStep_AppendResponse{msg}
+		return true, err
+	case 8: // Type.snapshot
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(RaftSnapshot)
+		err := b.DecodeMessage(msg)
+		m.Type = &RaftStep_Snapshot{msg}
+		return true, err
+	case 9: // Type.proposal
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(RaftProposal)
+		err := b.DecodeMessage(msg)
+		m.Type = &RaftStep_Proposal{msg}
+		return true, err
+	default:
+		return false, nil
+	}
+}
+
+func _RaftStep_OneofSizer(msg proto.Message) (n int) {
+	m := msg.(*RaftStep)
+	// Type
+	switch x := m.Type.(type) {
+	case *RaftStep_VoteRequest:
+		s := proto.Size(x.VoteRequest)
+		n += proto.SizeVarint(4<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *RaftStep_VoteResponse:
+		s := proto.Size(x.VoteResponse)
+		n += proto.SizeVarint(5<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *RaftStep_AppendRequest:
+		s := proto.Size(x.AppendRequest)
+		n += proto.SizeVarint(6<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *RaftStep_AppendResponse:
+		s := proto.Size(x.AppendResponse)
+		n += proto.SizeVarint(7<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *RaftStep_Snapshot:
+		s := proto.Size(x.Snapshot)
+		n += proto.SizeVarint(8<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *RaftStep_Proposal:
+		s := proto.Size(x.Proposal)
+		n += proto.SizeVarint(9<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case nil:
+	default:
+		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
+	}
+	return n
+}
+
+type RaftVoteRequest struct {
+	LastIndex uint64 `protobuf:"varint,1,opt,name=last_index,json=lastIndex" json:"last_index,omitempty"`
+	LastTerm  uint64 `protobuf:"varint,2,opt,name=last_term,json=lastTerm" json:"last_term,omitempty"`
+}
+
+func (m *RaftVoteRequest) Reset()                    { *m = RaftVoteRequest{} }
+func (m *RaftVoteRequest) String() string            { return proto.CompactTextString(m) }
+func (*RaftVoteRequest) ProtoMessage()               {}
+func (*RaftVoteRequest) Descriptor() ([]byte, []int) { return fileDescriptor4, []int{9} }
+
+type RaftVoteResponse struct {
+	Granted bool `protobuf:"varint,1,opt,name=granted" json:"granted,omitempty"`
+}
+
+func (m *RaftVoteResponse) Reset()                    { *m = RaftVoteResponse{} }
+func (m *RaftVoteResponse) String() string            { return proto.CompactTextString(m) }
+func (*RaftVoteResponse) ProtoMessage()               {}
+func (*RaftVoteResponse) Descriptor() ([]byte, []int) { return fileDescriptor4, []int{10} }
+
+type RaftAppendRequest struct {
+	PrevIndex uint64       `protobuf:"varint,1,opt,name=prev_index,json=prevIndex" json:"prev_index,omitempty"`
+	PrevTerm  uint64       `protobuf:"varint,2,opt,name=prev_term,json=prevTerm" json:"prev_term,omitempty"`
+	Entries   []*RaftEntry `protobuf:"bytes,3,rep,name=entries" json:"entries,omitempty"`
+	Commit    uint64       `protobuf:"varint,4,opt,name=commit" json:"commit,omitempty"`
+}
+
+func (m *RaftAppendRequest) Reset()                    { *m = RaftAppendRequest{} }
+func (m *RaftAppendRequest) String() string            { return proto.CompactTextString(m) }
+func (*RaftAppendRequest) ProtoMessage()               {}
+func (*RaftAppendRequest) Descriptor() ([]byte, []int) { return fileDescriptor4, []int{11} }
+
+func (m *RaftAppendRequest) GetEntries() []*RaftEntry {
+	if m != nil {
+		return m.Entries
+	}
+	return nil
+}
+
+// RaftAppendResponse acknowledges an append request or a snapshot. On
+// rejection match_index is a hint of the last index of the follower.
+type RaftAppendResponse struct {
+	Success    bool   `protobuf:"varint,1,opt,name=success" json:"success,omitempty"`
+	MatchIndex uint64 `protobuf:"varint,2,opt,name=match_index,json=matchIndex" json:"match_index,omitempty"`
+}
+
+func (m *RaftAppendResponse) Reset()                    { *m = RaftAppendResponse{} }
+func (m *RaftAppendResponse) String() string            { return proto.CompactTextString(m) }
+func (*RaftAppendResponse) ProtoMessage()               {}
+func (*RaftAppendResponse) Descriptor() ([]byte, []int) { return fileDescriptor4, []int{12} }
+
+// RaftProposal forwards the data of a new entry to the leader.
+type RaftProposal struct {
+	Data []byte `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+}
+
+func (m *RaftProposal) Reset()                    { *m = RaftProposal{} }
+func (m *RaftProposal) String() string            { return proto.CompactTextString(m) }
+func (*RaftProposal) ProtoMessage()               {}
+func (*RaftProposal) Descriptor() ([]byte, []int) { return fileDescriptor4, []int{13} }
+
+type RaftStepResponse struct {
+}
+
+func (m *RaftStepResponse) Reset()                    { *m = RaftStepResponse{} }
+func (m *RaftStepResponse) String() string            { return proto.CompactTextString(m) }
+func (*RaftStepResponse) ProtoMessage()               {}
+func (*RaftStepResponse) Descriptor() ([]byte, []int) { return fileDescriptor4, []int{14} }
+
+// RaftPullRequest requests the blocks from start to end (inclusive) of a
+// channel, it is used by a consenter which fell behind a snapshot.
+type RaftPullRequest struct {
+	Channel string `protobuf:"bytes,1,opt,name=channel" json:"channel,omitempty"`
+	Start   uint64 `protobuf:"varint,2,opt,name=start" json:"start,omitempty"`
+	End     uint64 `protobuf:"varint,3,opt,name=end" json:"end,omitempty"`
+}
+
+func (m *RaftPullRequest) Reset()                    { *m = RaftPullRequest{} }
+func (m *RaftPullRequest) String() string            { return proto.CompactTextString(m) }
+func (*RaftPullRequest) ProtoMessage()               {}
+func (*RaftPullRequest) Descriptor() ([]byte, []int) { return fileDescriptor4, []int{15} }
+
+func init() {
+	proto.RegisterType((*RaftMessage)(nil), "orderer.RaftMessage")
+	proto.RegisterType((*RaftMessageRegular)(nil), "orderer.RaftMessageRegular")
+	proto.RegisterType((*RaftMessageTimeToCut)(nil), "orderer.RaftMessageTimeToCut")
+	proto.RegisterType((*RaftMetadata)(nil), "orderer.RaftMetadata")
+	proto.RegisterType((*RaftEntry)(nil), "orderer.RaftEntry")
+	proto.RegisterType((*RaftHardState)(nil), "orderer.RaftHardState")
+	proto.RegisterType((*RaftSnapshot)(nil), "orderer.RaftSnapshot")
+	proto.RegisterType((*RaftWALRecord)(nil), "orderer.RaftWALRecord")
+	proto.RegisterType((*RaftStep)(nil), "orderer.RaftStep")
+	proto.RegisterType((*RaftVoteRequest)(nil), "orderer.RaftVoteRequest")
+	proto.RegisterType((*RaftVoteResponse)(nil), "orderer.RaftVoteResponse")
+	proto.RegisterType((*RaftAppendRequest)(nil), "orderer.RaftAppendRequest")
+	proto.RegisterType((*RaftAppendResponse)(nil), "orderer.RaftAppendResponse")
+	proto.RegisterType((*RaftProposal)(nil), "orderer.RaftProposal")
+	proto.RegisterType((*RaftStepResponse)(nil), "orderer.RaftStepResponse")
+	proto.RegisterType((*RaftPullRequest)(nil), "orderer.RaftPullRequest")
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion3
+
+// Client API for Cluster service
+
+type ClusterClient interface {
+	// Step delivers a raft message to the consenter of a channel
+	Step(ctx context.Context, in *RaftStep, opts ...grpc.CallOption) (*RaftStepResponse, error)
+	// Pull streams the blocks requested by a RaftPullRequest
+	Pull(ctx context.Context, in *RaftPullRequest, opts ...grpc.CallOption) (Cluster_PullClient, error)
+}
+
+type clusterClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewClusterClient(cc *grpc.ClientConn) ClusterClient {
+	return &clusterClient{cc}
+}
+
+func (c *clusterClient) Step(ctx context.Context, in *RaftStep, opts ...grpc.CallOption) (*RaftStepResponse, error) {
+	out := new(RaftStepResponse)
+	err := grpc.Invoke(ctx, "/orderer.Cluster/Step", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clusterClient) Pull(ctx context.Context, in *RaftPullRequest, opts ...grpc.CallOption) (Cluster_PullClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Cluster_serviceDesc.Streams[0], c.cc, "/orderer.Cluster/Pull", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &clusterPullClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Cluster_PullClient interface {
+	Recv() (*common.Block, error)
+	grpc.ClientStream
+}
+
+type clusterPullClient struct {
+	grpc.ClientStream
+}
+
+func (x *clusterPullClient) Recv() (*common.Block, error) {
+	m := new(common.Block)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Server API for Cluster service
+
+type ClusterServer interface {
+	// Step delivers a raft message to the consenter of a channel
+	Step(context.Context, *RaftStep) (*RaftStepResponse, error)
+	// Pull streams the blocks requested by a RaftPullRequest
+	Pull(*RaftPullRequest, Cluster_PullServer) error
+}
+
+func RegisterClusterServer(s *grpc.Server, srv ClusterServer) {
+	s.RegisterService(&_Cluster_serviceDesc, srv)
+}
+
+func _Cluster_Step_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RaftStep)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClusterServer).Step(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/orderer.Cluster/Step",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClusterServer).Step(ctx, req.(*RaftStep))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cluster_Pull_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(RaftPullRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ClusterServer).Pull(m, &clusterPullServer{stream})
+}
+
+type Cluster_PullServer interface {
+	Send(*common.Block) error
+	grpc.ServerStream
+}
+
+type clusterPullServer struct {
+	grpc.ServerStream
+}
+
+func (x *clusterPullServer) Send(m *common.Block) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+var _Cluster_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "orderer.Cluster",
+	HandlerType: (*ClusterServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Step",
+			Handler:    _Cluster_Step_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Pull",
+			Handler:       _Cluster_Pull_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: fileDescriptor4,
+}
+
+func init() { proto.RegisterFile("orderer/raft.proto", fileDescriptor4) }
+
+var fileDescriptor4 = []byte{
+	// 815 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0x74, 0x55, 0x5f, 0x8f, 0xdb, 0x44,
+	0x10, 0x77, 0x2e, 0xce, 0xbf, 0x49, 0xd2, 0x5e, 0x97, 0xa3, 0x72, 0x53, 0x55, 0x14, 0x3f, 0x21,
+	0x74, 0x24, 0xa8, 0x27, 0x51, 0xf1, 0x80, 0xa0, 0x77, 0x02, 0xa5, 0x12, 0xe5, 0x4e, 0x7b, 0x11,
+	0x48, 0xf0, 0x60, 0x6d, 0xec, 0xc9, 0x1f, 0x61, 0x7b, 0xcd, 0xee, 0x3a, 0x22, 0x12, 0xdf, 0x81,
+	0x27, 0xbe, 0x15, 0x1f, 0x0a, 0xed, 0xda, 0xeb, 0xd8, 0x49, 0x78, 0xf2, 0xce, 0x6f, 0xe7, 0xcf,
+	0x6f, 0x66, 0x67, 0xc6, 0x40, 0xb8, 0x88, 0x50, 0xa0, 0x98, 0x09, 0xb6, 0x52, 0xd3, 0x4c, 0x70,
+	0xc5, 0x49, 0xaf, 0xc4, 0x26, 0x1f, 0x85, 0x3c, 0x49, 0x78, 0x3a, 0x2b, 0x3e, 0xc5, 0xad, 0xff,
+	0x77, 0x0b, 0x86, 0x94, 0xad, 0xd4, 0x07, 0x94, 0x92, 0xad, 0x91, 0xbc, 0x85, 0x9e, 0xc0, 0x75,
+	0x1e, 0x33, 0xe1, 0xb5, 0x5e, 0xb7, 0x3e, 0x1b, 0xbe, 0x79, 0x39, 0x2d, 0xed, 0xa7, 0x35, 0x35,
+	0x5a, 0xa8, 0xcc, 0x1d, 0x6a, 0xb5, 0xc9, 0xb7, 0x30, 0x54, 0xdb, 0x04, 0x03, 0xc5, 0x83, 0x30,
+	0x57, 0xde, 0x85, 0x31, 0x7e, 0x75, 0xce, 0x78, 0xb1, 0x4d, 0x70, 0xc1, 0xef, 0x72, 0x35, 0x77,
+	0xe8, 0x40, 0x59, 0xe1, 0xb6, 0x0b, 0xee, 0x62, 0x9f, 0xa1, 0x3f, 0x05, 0x72, 0x1a, 0x89, 0x78,
+	0xd0, 0xcb, 0xd8, 0x3e, 0xe6, 0x2c, 0x32, 0xbc, 0x46, 0xd4, 0x8a, 0xfe, 0xd7, 0x70, 0x75, 0xce,
+	0x39, 0xf9, 0x14, 0x46, 0xcb, 0x98, 0x87, 0xbf, 0x07, 0x69, 0x9e, 0x2c, 0xb1, 0x48, 0xc7, 0xa5,
+	0x43, 0x83, 0xfd, 0x64, 0x20, 0xff, 0x0b, 0x18, 0x15, 0xa6, 0x8a, 0x45, 0x4c, 0x31, 0xf2, 0x0a,
+	0x40, 0x17, 0x2e, 0xd8, 0xa6, 0x11, 0xfe, 0x59, 0x1a, 0x0c, 0x34, 0xf2, 0x5e, 0x03, 0xfe, 0x7b,
+	0x18, 0x68, 0xf5, 0xef, 0x53, 0x25, 0xf6, 0x84, 0x80, 0xab, 0x50, 0x24, 0xa5, 0x96, 0x39, 0x93,
+	0x2b, 0xe8, 0x14, 0xa6, 0x17, 0x06, 0x2c, 0x04, 0xad, 0xa9, 0xbd, 0x7b, 0x6d, 0xc3, 0xdb, 0x9c,
+	0xfd, 0x7b, 0x18, 0x6b, 0x57, 0x73, 0x26, 0xa2, 0x47, 0xc5, 0x14, 0x9e, 0x75, 0x47, 0xc0, 0xdd,
+	0x71, 0x85, 0xc6, 0xdb, 0x80, 0x9a, 0x33, 0x79, 0x0e, 0x5d, 0xfd, 0x7e, 0x5b, 0x65, 0xdc, 0xb9,
+	0xb4, 0x94, 0xfc, 0xdf, 0x8a, 0x54, 0x1e, 0x53, 0x96, 0xc9, 0x0d, 0x57, 0x07, 0x2a, 0xad, 0x23,
+	0x2a, 0x26, 0xca, 0x45, 0x2d, 0xca, 0x71, 0x9d, 0xda, 0xa7, 0x75, 0xfa, 0xab, 0x60, 0xfb, 0xcb,
+	0xbb, 0x1f, 0x29, 0x86, 0x5c, 0x44, 0xe4, 0x73, 0xe8, 0xa0, 0xae, 0x42, 0xd9, 0x23, 0xa4, 0xf1,
+	0xcc, 0xa6, 0x3e, 0x73, 0x87, 0x16, 0x2a, 0xe4, 0x2d, 0xc0, 0x86, 0x89, 0x28, 0x90, 0x3a, 0xcf,
+	0xb2, 0x2f, 0x9e, 0x37, 0x0c, 0xaa, 0x2a, 0xe8, 0x86, 0xd8, 0x58, 0xa1, 0x6a, 0x88, 0x7f, 0xdb,
+	0xd0, 0x37, 0xb9, 0x29, 0xcc, 0x74, 0x1f, 0x84, 0x1b, 0x96, 0xa6, 0x18, 0x9b, 0xd8, 0x03, 0x6a,
+	0x45, 0x9d, 0xdb, 0x4a, 0xf0, 0xc4, 0x56, 0x4b, 0x9f, 0xab, 0x7c, 0xdb, 0xb5, 0x7c, 0xbf, 0x81,
+	0x91, 0xae, 0x64, 0x20, 0xf0, 0x8f, 0x1c, 0xa5, 0xf2, 0x5c, 0xc3, 0xc8, 0x6b, 0x30, 0xfa, 0x99,
+	0x2b, 0xa4, 0xc5, 0xfd, 0xdc, 0xa1, 0xc3, 0xdd, 0x41, 0x24, 0xdf, 0xc1, 0xb8, 0x34, 0x97, 0x19,
+	0x4f, 0x25, 0x7a, 0x1d, 0x63, 0xff, 0xe2, 0x8c, 0x7d, 0xa1, 0x30, 0x77, 0xe8, 0x68, 0x57, 0x93,
+	0xc9, 0x1d, 0x3c, 0x61, 0x59, 0x86, 0x69, 0x54, 0x51, 0xe8, 0x1a, 0x17, 0x93, 0x86, 0x8b, 0x77,
+	0x46, 0xe5, 0x40, 0x62, 0xcc, 0xea, 0x00, 0xf9, 0x01, 0x9e, 0x56, 0x4e, 0x4a, 0x22, 0xbd, 0x33,
+	0xf3, 0x6a, 0xbd, 0x54, 0x54, 0x9e, 0xb0, 0x06, 0x42, 0x6e, 0xa0, 0x2f, 0xcb, 0x9e, 0xf1, 0xfa,
+	0xc6, 0xc1, 0xc7, 0x0d, 0x07, 0xb6, 0xa1, 0xe6, 0x0e, 0xad, 0x14, 0xb5, 0x51, 0x26, 0x78, 0xc6,
+	0x25, 0x8b, 0xbd, 0xc1, 0x19, 0xa3, 0x87, 0xf2, 0x52, 0x1b, 0x59, 0xc5, 0xea, 0x39, 0x3f, 0xc0,
+	0xd3, 0xa3, 0x12, 0xeb, 0xb9, 0x8b, 0x99, 0x3c, 0x9a, 0x3b, 0x8d, 0x98, 0xb9, 0x23, 0x2f, 0xc1,
+	0x08, 0x41, 0xad, 0x75, 0xfb, 0x1a, 0x58, 0xa0, 0x48, 0xfc, 0x6b, 0xb8, 0x3c, 0xae, 0xb8, 0x6e,
+	0x92, 0xb5, 0x60, 0xa9, 0xc2, 0x62, 0x59, 0xf4, 0xa9, 0x15, 0xfd, 0x7f, 0x5a, 0xf0, 0xec, 0xa4,
+	0xba, 0x3a, 0x7e, 0x26, 0x70, 0xd7, 0x8c, 0xaf, 0x91, 0x2a, 0xbe, 0xb9, 0xae, 0xc7, 0xd7, 0x80,
+	0x8e, 0x4f, 0xae, 0xa1, 0xa7, 0xfb, 0x7c, 0x8b, 0xd2, 0x6b, 0xbf, 0x6e, 0x9f, 0x1f, 0x06, 0x6a,
+	0x55, 0x6a, 0xe3, 0xeb, 0x36, 0xc6, 0xf7, 0x1e, 0x48, 0x9d, 0xd6, 0x21, 0x0f, 0x99, 0x87, 0x21,
+	0x4a, 0x69, 0xf3, 0x28, 0x45, 0xf2, 0x09, 0x0c, 0x13, 0xa6, 0xc2, 0x4d, 0x50, 0xdf, 0x37, 0x60,
+	0xa0, 0x62, 0x57, 0xf9, 0x30, 0xaa, 0xbf, 0x44, 0xb5, 0x84, 0x5a, 0xb5, 0x25, 0x44, 0xe0, 0xd2,
+	0xce, 0x95, 0x0d, 0xe9, 0x3f, 0x16, 0xaf, 0xf3, 0x90, 0xc7, 0xb1, 0xad, 0xce, 0xff, 0x8f, 0xdc,
+	0x15, 0x74, 0xa4, 0x62, 0x42, 0xd9, 0x7d, 0x67, 0x04, 0x72, 0x09, 0x6d, 0x4c, 0xa3, 0x72, 0xe6,
+	0xf4, 0xf1, 0xcd, 0x0e, 0x7a, 0x77, 0x71, 0x2e, 0x15, 0x0a, 0xf2, 0x15, 0xb8, 0x66, 0x8e, 0x9f,
+	0x35, 0xbb, 0x4c, 0x61, 0x36, 0x79, 0x71, 0x02, 0x55, 0xac, 0x1c, 0x72, 0x03, 0xae, 0xe6, 0x44,
+	0x9a, 0x73, 0x5a, 0xa3, 0x39, 0x19, 0x4f, 0xcb, 0x1f, 0xdb, 0xad, 0xde, 0x5c, 0xbe, 0xf3, 0x65,
+	0xeb, 0x76, 0xfa, 0xeb, 0xf5, 0x7a, 0xab, 0x36, 0xf9, 0x52, 0x5f, 0xcd, 0x36, 0xfb, 0x0c, 0x45,
+	0x8c, 0xd1, 0x1a, 0xc5, 0x6c, 0xc5, 0x96, 0x62, 0x1b, 0xce, 0xcc, 0x3f, 0x50, 0xce, 0x4a, 0x97,
+	0xcb, 0xae, 0x91, 0x6f, 0xfe, 0x1b, 0x00, 0x8c, 0xa6, 0xf2, 0x0a, 0x47, 0x07, 0x00, 0x00,
+}
@@ -159,6 +159,9 @@ type ChaincodeDeploymentSpec struct {
 	EffectiveDate *google_protobuf1.Timestamp                  `protobuf:"bytes,2,opt,name=effective_date,json=effectiveDate" json:"effective_date,omitempty"`
 	CodePackage   []byte                                       `protobuf:"bytes,3,opt,name=code_package,json=codePackage,proto3" json:"code_package,omitempty"`
 	ExecEnv       ChaincodeDeploymentSpec_ExecutionEnvironment `protobuf:"varint,4,opt,name=exec_env,json=execEnv,enum=protos.ChaincodeDeploymentSpec_ExecutionEnvironment" json:"exec_env,omitempty"`
+	// Optional per-chaincode limits enforced by the peer and the container
+	// runtime. When unset the peer-wide defaults from core.yaml apply.
+	ResourceLimits *ChaincodeResourceLimits `protobuf:"bytes,5,opt,name=resource_limits,json=resourceLimits" json:"resource_limits,omitempty"`
 }
 
 func (m *ChaincodeDeploymentSpec) Reset()                    { *m = ChaincodeDeploymentSpec{} }
@@ -180,6 +183,31 @@ func (m *ChaincodeDeploymentSpec) GetEffectiveDate() *google_protobuf1.Timestamp
 	return nil
 }
 
+func (m *ChaincodeDeploymentSpec) GetResourceLimits() *ChaincodeResourceLimits {
+	if m != nil {
+		return m.ResourceLimits
+	}
+	return nil
+}
+
+// Resource limits applied to a single chaincode. A zero value for any
+// field means the peer-wide default is used.
+type ChaincodeResourceLimits struct {
+	// Maximum time in milliseconds a single transaction may execute
+	ExecuteTimeout int32 `protobuf:"varint,1,opt,name=execute_timeout,json=executeTimeout" json:"execute_timeout,omitempty"`
+	// Memory limit of the chaincode container in bytes
+	Memory int64 `protobuf:"varint,2,opt,name=memory" json:"memory,omitempty"`
+	// Relative CPU weight of the chaincode container
+	CpuShares int64 `protobuf:"varint,3,opt,name=cpu_shares,json=cpuShares" json:"cpu_shares,omitempty"`
+	// Maximum number of transactions executing concurrently in the chaincode
+	MaxConcurrency int32 `protobuf:"varint,4,opt,name=max_concurrency,json=maxConcurrency" json:"max_concurrency,omitempty"`
+}
+
+func (m *ChaincodeResourceLimits) Reset()                    { *m = ChaincodeResourceLimits{} }
+func (m *ChaincodeResourceLimits) String() string            { return proto.CompactTextString(m) }
+func (*ChaincodeResourceLimits) ProtoMessage()               {}
+func (*ChaincodeResourceLimits) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{4} }
+
 // Carries the chaincode function and its arguments.
 type ChaincodeInvocationSpec struct {
 	ChaincodeSpec *ChaincodeSpec `protobuf:"bytes,1,opt,name=chaincode_spec,json=chaincodeSpec" json:"chaincode_spec,omitempty"`
@@ -196,7 +224,7 @@ type ChaincodeInvocationSpec struct {
 func (m *ChaincodeInvocationSpec) Reset()                    { *m = ChaincodeInvocationSpec{} }
 func (m *ChaincodeInvocationSpec) String() string            { return proto.CompactTextString(m) }
 func (*ChaincodeInvocationSpec) ProtoMessage()               {}
-func (*ChaincodeInvocationSpec) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{4} }
+func (*ChaincodeInvocationSpec) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{5} }
 
 func (m *ChaincodeInvocationSpec) GetChaincodeSpec() *ChaincodeSpec {
 	if m != nil {
@@ -205,12 +233,78 @@ func (m *ChaincodeInvocationSpec) GetChaincodeSpec() *ChaincodeSpec {
 	return nil
 }
 
+// ChaincodeDefinition is the definition of a chaincode that the organizations
+// of a channel agree upon before it can be used on the channel.
+type ChaincodeDefinition struct {
+	Name    string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	Version string `protobuf:"bytes,2,opt,name=version" json:"version,omitempty"`
+	// Hash of the installed chaincode package (the marshalled
+	// ChaincodeDeploymentSpec stored on the peers)
+	PackageHash []byte `protobuf:"bytes,3,opt,name=package_hash,json=packageHash,proto3" json:"package_hash,omitempty"`
+	// Marshalled SignaturePolicyEnvelope, the channel default is used if empty
+	EndorsementPolicy []byte `protobuf:"bytes,4,opt,name=endorsement_policy,json=endorsementPolicy,proto3" json:"endorsement_policy,omitempty"`
+	// Whether Init must be invoked when the definition is committed
+	InitRequired bool   `protobuf:"varint,5,opt,name=init_required,json=initRequired" json:"init_required,omitempty"`
+	Escc         string `protobuf:"bytes,6,opt,name=escc" json:"escc,omitempty"`
+	Vscc         string `protobuf:"bytes,7,opt,name=vscc" json:"vscc,omitempty"`
+}
+
+func (m *ChaincodeDefinition) Reset()                    { *m = ChaincodeDefinition{} }
+func (m *ChaincodeDefinition) String() string            { return proto.CompactTextString(m) }
+func (*ChaincodeDefinition) ProtoMessage()               {}
+func (*ChaincodeDefinition) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{6} }
+
+// ChaincodeApproval records the approval of a chaincode definition by an
+// organization. The signature of the approver over the definition is kept
+// so that the channel lifecycle policy can be evaluated on commit.
+type ChaincodeApproval struct {
+	// Marshalled ChaincodeDefinition
+	Definition []byte `protobuf:"bytes,1,opt,name=definition,proto3" json:"definition,omitempty"`
+	// Serialized identity of the approver
+	Creator []byte `protobuf:"bytes,2,opt,name=creator,proto3" json:"creator,omitempty"`
+	// Signature of the approver over the definition
+	Signature []byte `protobuf:"bytes,3,opt,name=signature,proto3" json:"signature,omitempty"`
+}
+
+func (m *ChaincodeApproval) Reset()                    { *m = ChaincodeApproval{} }
+func (m *ChaincodeApproval) String() string            { return proto.CompactTextString(m) }
+func (*ChaincodeApproval) ProtoMessage()               {}
+func (*ChaincodeApproval) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{7} }
+
+// SignedChaincodeDeploymentSpec is a chaincode package co-signed by its
+// owners. It is carried as the data of an Envelope of type CHAINCODE_PACKAGE.
+type SignedChaincodeDeploymentSpec struct {
+	// Marshalled ChaincodeDeploymentSpec
+	ChaincodeDeploymentSpec []byte `protobuf:"bytes,1,opt,name=chaincode_deployment_spec,json=chaincodeDeploymentSpec,proto3" json:"chaincode_deployment_spec,omitempty"`
+	// Marshalled SignaturePolicyEnvelope the owner endorsements must satisfy
+	OwnerPolicy []byte `protobuf:"bytes,2,opt,name=owner_policy,json=ownerPolicy,proto3" json:"owner_policy,omitempty"`
+	// Signatures of the owners over the concatenation of the deployment
+	// spec, the owner policy and the identity of the owner
+	OwnerEndorsements []*Endorsement `protobuf:"bytes,3,rep,name=owner_endorsements,json=ownerEndorsements" json:"owner_endorsements,omitempty"`
+}
+
+func (m *SignedChaincodeDeploymentSpec) Reset()                    { *m = SignedChaincodeDeploymentSpec{} }
+func (m *SignedChaincodeDeploymentSpec) String() string            { return proto.CompactTextString(m) }
+func (*SignedChaincodeDeploymentSpec) ProtoMessage()               {}
+func (*SignedChaincodeDeploymentSpec) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{8} }
+
+func (m *SignedChaincodeDeploymentSpec) GetOwnerEndorsements() []*Endorsement {
+	if m != nil {
+		return m.OwnerEndorsements
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*ChaincodeID)(nil), "protos.ChaincodeID")
 	proto.RegisterType((*ChaincodeInput)(nil), "protos.ChaincodeInput")
 	proto.RegisterType((*ChaincodeSpec)(nil), "protos.ChaincodeSpec")
 	proto.RegisterType((*ChaincodeDeploymentSpec)(nil), "protos.ChaincodeDeploymentSpec")
+	proto.RegisterType((*ChaincodeResourceLimits)(nil), "protos.ChaincodeResourceLimits")
 	proto.RegisterType((*ChaincodeInvocationSpec)(nil), "protos.ChaincodeInvocationSpec")
+	proto.RegisterType((*ChaincodeDefinition)(nil), "protos.ChaincodeDefinition")
+	proto.RegisterType((*ChaincodeApproval)(nil), "protos.ChaincodeApproval")
+	proto.RegisterType((*SignedChaincodeDeploymentSpec)(nil), "protos.SignedChaincodeDeploymentSpec")
 	proto.RegisterEnum("protos.ConfidentialityLevel", ConfidentialityLevel_name, ConfidentialityLevel_value)
 	proto.RegisterEnum("protos.ChaincodeSpec_Type", ChaincodeSpec_Type_name, ChaincodeSpec_Type_value)
 	proto.RegisterEnum("protos.ChaincodeDeploymentSpec_ExecutionEnvironment", ChaincodeDeploymentSpec_ExecutionEnvironment_name, ChaincodeDeploymentSpec_ExecutionEnvironment_value)
@@ -219,42 +313,63 @@ func init() {
 func init() { proto.RegisterFile("peer/chaincode.proto", fileDescriptor1) }
 
 var fileDescriptor1 = []byte{
-	// 585 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0xac, 0x53, 0x5d, 0x6f, 0xd3, 0x30,
-	0x14, 0x5d, 0xd6, 0xee, 0xeb, 0xf6, 0x83, 0x60, 0xc6, 0xa8, 0xf6, 0xc2, 0x88, 0x78, 0x18, 0x13,
-	0x4a, 0xa5, 0x32, 0xf1, 0x84, 0x90, 0xb2, 0x24, 0x9b, 0x02, 0xa5, 0x9d, 0xb2, 0x0e, 0x09, 0x5e,
-	0x2a, 0x37, 0xb9, 0x4d, 0x2d, 0x52, 0x3b, 0x4a, 0xdc, 0x68, 0x7d, 0xe6, 0x7f, 0xf1, 0xd7, 0x40,
-	0x76, 0xd6, 0x6e, 0xd3, 0xf6, 0xc8, 0x93, 0xed, 0xe3, 0x73, 0xec, 0x73, 0x8f, 0xee, 0x85, 0xfd,
-	0x0c, 0x31, 0xef, 0x46, 0x33, 0xca, 0x78, 0x24, 0x62, 0xb4, 0xb3, 0x5c, 0x48, 0x41, 0xb6, 0xf5,
-	0x52, 0x1c, 0xbe, 0x4e, 0x84, 0x48, 0x52, 0xec, 0xea, 0xe3, 0x64, 0x31, 0xed, 0x4a, 0x36, 0xc7,
-	0x42, 0xd2, 0x79, 0x56, 0x11, 0xad, 0x21, 0x34, 0xdc, 0x95, 0x36, 0xf0, 0x08, 0x81, 0x7a, 0x46,
-	0xe5, 0xac, 0x63, 0x1c, 0x19, 0xc7, 0x7b, 0xa1, 0xde, 0x2b, 0x8c, 0xd3, 0x39, 0x76, 0x36, 0x2b,
-	0x4c, 0xed, 0x49, 0x07, 0x76, 0x4a, 0xcc, 0x0b, 0x26, 0x78, 0xa7, 0xa6, 0xe1, 0xd5, 0xd1, 0x7a,
-	0x0b, 0xed, 0xbb, 0x07, 0x79, 0xb6, 0x90, 0x4a, 0x4f, 0xf3, 0xa4, 0xe8, 0x18, 0x47, 0xb5, 0xe3,
-	0x66, 0xa8, 0xf7, 0xd6, 0x5f, 0x03, 0x5a, 0x6b, 0xda, 0x55, 0x86, 0x11, 0xb1, 0xa1, 0x2e, 0x97,
-	0x19, 0xea, 0x9f, 0xdb, 0xbd, 0xc3, 0xca, 0x5e, 0x61, 0x3f, 0x20, 0xd9, 0xa3, 0x65, 0x86, 0xa1,
-	0xe6, 0x91, 0x8f, 0xd0, 0x5c, 0x17, 0x3d, 0x66, 0xb1, 0x76, 0xd7, 0xe8, 0xbd, 0x78, 0xa4, 0x0b,
-	0xbc, 0xb0, 0xb1, 0x26, 0x06, 0x31, 0x79, 0x0f, 0x5b, 0x4c, 0xd9, 0xd2, 0xbe, 0x1b, 0xbd, 0x83,
-	0xc7, 0x02, 0x75, 0x1b, 0x56, 0x24, 0x55, 0xa7, 0x4a, 0x4c, 0x2c, 0x64, 0xa7, 0x7e, 0x64, 0x1c,
-	0x6f, 0x85, 0xab, 0xa3, 0xf5, 0x19, 0xea, 0xca, 0x0d, 0x69, 0xc1, 0xde, 0xf5, 0xc0, 0xf3, 0xcf,
-	0x83, 0x81, 0xef, 0x99, 0x1b, 0x04, 0x60, 0xfb, 0x62, 0xd8, 0x77, 0x06, 0x17, 0xa6, 0x41, 0x76,
-	0xa1, 0x3e, 0x18, 0x7a, 0xbe, 0xb9, 0x49, 0x76, 0xa0, 0xe6, 0x3a, 0xa1, 0x59, 0x53, 0xd0, 0x17,
-	0xe7, 0xbb, 0x63, 0xd6, 0xad, 0x3f, 0x9b, 0xf0, 0x6a, 0xfd, 0xa7, 0x87, 0x59, 0x2a, 0x96, 0x73,
-	0xe4, 0x52, 0x67, 0xf1, 0x09, 0xda, 0x77, 0xb5, 0x15, 0x19, 0x46, 0x3a, 0x95, 0x46, 0xef, 0xe5,
-	0x93, 0xa9, 0x84, 0xad, 0xe8, 0x41, 0x92, 0x0e, 0xb4, 0x71, 0x3a, 0xc5, 0x48, 0xb2, 0x12, 0xc7,
-	0x31, 0x95, 0x78, 0x9b, 0xcd, 0xa1, 0x5d, 0x35, 0x83, 0xbd, 0x6a, 0x06, 0x7b, 0xb4, 0x6a, 0x86,
-	0xb0, 0xb5, 0x56, 0x78, 0x54, 0x22, 0x79, 0x03, 0x4d, 0xfd, 0x77, 0x46, 0xa3, 0x5f, 0x34, 0x41,
-	0x9d, 0x55, 0x33, 0x6c, 0x28, 0xec, 0xb2, 0x82, 0xc8, 0x10, 0x76, 0xf1, 0x06, 0xa3, 0x31, 0xf2,
-	0x52, 0x47, 0xd3, 0xee, 0x9d, 0x3e, 0x72, 0xf7, 0xb0, 0x2c, 0xdb, 0xbf, 0xc1, 0x68, 0x21, 0x99,
-	0xe0, 0x3e, 0x2f, 0x59, 0x2e, 0xb8, 0xba, 0x08, 0x77, 0xd4, 0x2b, 0x3e, 0x2f, 0x2d, 0x1b, 0xf6,
-	0x9f, 0x22, 0xa8, 0x44, 0xbd, 0xa1, 0xfb, 0xd5, 0x0f, 0xab, 0x74, 0xaf, 0x7e, 0x5c, 0x8d, 0xfc,
-	0x6f, 0xa6, 0x61, 0xfd, 0x36, 0xee, 0x05, 0x18, 0xf0, 0x52, 0x44, 0x54, 0x49, 0xff, 0x43, 0x80,
-	0x27, 0xf0, 0x9c, 0xc5, 0xe3, 0x04, 0x39, 0xe6, 0xfa, 0xc9, 0x31, 0x4d, 0x93, 0xdb, 0xee, 0x7f,
-	0xc6, 0xe2, 0x8b, 0x35, 0xee, 0xa4, 0xc9, 0xc9, 0x29, 0xec, 0xbb, 0x82, 0x4f, 0x59, 0x8c, 0x5c,
-	0x32, 0x9a, 0x32, 0xb9, 0xec, 0x63, 0x89, 0xa9, 0x72, 0x7a, 0x79, 0x7d, 0xd6, 0x0f, 0x5c, 0x73,
-	0x83, 0x98, 0xd0, 0x74, 0x87, 0x83, 0xf3, 0xc0, 0xf3, 0x07, 0xa3, 0xc0, 0xe9, 0x9b, 0xc6, 0x99,
-	0x0b, 0x07, 0x22, 0x4f, 0xec, 0xd9, 0x32, 0xc3, 0x3c, 0xc5, 0x38, 0xc1, 0xfc, 0xd6, 0xd8, 0xcf,
-	0x77, 0x09, 0x93, 0xb3, 0xc5, 0xc4, 0x8e, 0xc4, 0xbc, 0x7b, 0xef, 0xba, 0x3b, 0xa5, 0x93, 0x9c,
-	0x45, 0xd5, 0x1c, 0x17, 0x5d, 0x35, 0xf3, 0x93, 0x6a, 0xc6, 0x3f, 0xfc, 0x0b, 0x00, 0x00, 0xff,
-	0xff, 0xd7, 0xce, 0x97, 0x9a, 0x02, 0x04, 0x00, 0x00,
+	// 927 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0xac, 0x55, 0x5f, 0x6f, 0xe3, 0x44,
+	0x10, 0x3f, 0x5f, 0xd2, 0x7f, 0x13, 0x37, 0x4d, 0xf7, 0x4a, 0x1b, 0xaa, 0x3b, 0xae, 0x67, 0x90,
+	0x28, 0x27, 0x70, 0xa4, 0x72, 0xe2, 0x01, 0x21, 0xa4, 0x34, 0xc9, 0xf5, 0x02, 0x25, 0xa9, 0xb6,
+	0x39, 0x24, 0x78, 0xb1, 0xb6, 0xeb, 0x89, 0xb3, 0x3a, 0x67, 0xd7, 0xac, 0xed, 0xd0, 0x3c, 0xf3,
+	0xc2, 0xe7, 0xe0, 0xa3, 0xf0, 0x51, 0xf8, 0x22, 0xa0, 0x5d, 0x3b, 0x7f, 0xaa, 0xb6, 0x6f, 0x3c,
+	0x79, 0xe7, 0x37, 0xb3, 0xb3, 0x33, 0xbf, 0xf9, 0x79, 0x17, 0x0e, 0x12, 0x44, 0xdd, 0xe2, 0x13,
+	0x26, 0x24, 0x57, 0x21, 0xfa, 0x89, 0x56, 0x99, 0x22, 0x9b, 0xf6, 0x93, 0x1e, 0xbf, 0x8c, 0x94,
+	0x8a, 0x62, 0x6c, 0x59, 0xf3, 0x26, 0x1f, 0xb7, 0x32, 0x31, 0xc5, 0x34, 0x63, 0xd3, 0xa4, 0x08,
+	0x3c, 0x7e, 0x6e, 0xb7, 0x27, 0x5a, 0x25, 0x2a, 0x65, 0x71, 0xa0, 0x31, 0x4d, 0x94, 0x4c, 0xcb,
+	0x34, 0xde, 0x10, 0x6a, 0x9d, 0x45, 0xe6, 0x7e, 0x97, 0x10, 0xa8, 0x26, 0x2c, 0x9b, 0x34, 0x9d,
+	0x13, 0xe7, 0x74, 0x87, 0xda, 0xb5, 0xc1, 0x24, 0x9b, 0x62, 0xf3, 0x69, 0x81, 0x99, 0x35, 0x69,
+	0xc2, 0xd6, 0x0c, 0x75, 0x2a, 0x94, 0x6c, 0x56, 0x2c, 0xbc, 0x30, 0xbd, 0xcf, 0xa0, 0xbe, 0x4a,
+	0x28, 0x93, 0x3c, 0x33, 0xfb, 0x99, 0x8e, 0xd2, 0xa6, 0x73, 0x52, 0x39, 0x75, 0xa9, 0x5d, 0x7b,
+	0xff, 0x3a, 0xb0, 0xbb, 0x0c, 0xbb, 0x4e, 0x90, 0x13, 0x1f, 0xaa, 0xd9, 0x3c, 0x41, 0x7b, 0x72,
+	0xfd, 0xec, 0xb8, 0x28, 0x2f, 0xf5, 0xef, 0x04, 0xf9, 0xa3, 0x79, 0x82, 0xd4, 0xc6, 0x91, 0x6f,
+	0xc0, 0x5d, 0x52, 0x12, 0x88, 0xd0, 0x56, 0x57, 0x3b, 0x7b, 0x76, 0x6f, 0x5f, 0xbf, 0x4b, 0x6b,
+	0xcb, 0xc0, 0x7e, 0x48, 0xbe, 0x84, 0x0d, 0x61, 0xca, 0xb2, 0x75, 0xd7, 0xce, 0x0e, 0xef, 0x6f,
+	0x30, 0x5e, 0x5a, 0x04, 0x99, 0x3e, 0x0d, 0x9f, 0x2a, 0xcf, 0x9a, 0xd5, 0x13, 0xe7, 0x74, 0x83,
+	0x2e, 0x4c, 0xef, 0x7b, 0xa8, 0x9a, 0x6a, 0xc8, 0x2e, 0xec, 0xbc, 0x1f, 0x74, 0x7b, 0x6f, 0xfb,
+	0x83, 0x5e, 0xb7, 0xf1, 0x84, 0x00, 0x6c, 0x5e, 0x0c, 0x2f, 0xdb, 0x83, 0x8b, 0x86, 0x43, 0xb6,
+	0xa1, 0x3a, 0x18, 0x76, 0x7b, 0x8d, 0xa7, 0x64, 0x0b, 0x2a, 0x9d, 0x36, 0x6d, 0x54, 0x0c, 0xf4,
+	0x43, 0xfb, 0xe7, 0x76, 0xa3, 0xea, 0xfd, 0x59, 0x81, 0xa3, 0xe5, 0x99, 0x5d, 0x4c, 0x62, 0x35,
+	0x9f, 0xa2, 0xcc, 0x2c, 0x17, 0xdf, 0x41, 0x7d, 0xd5, 0x5b, 0x9a, 0x20, 0xb7, 0xac, 0xd4, 0xce,
+	0x3e, 0x7a, 0x90, 0x15, 0xba, 0xcb, 0xd7, 0x4d, 0xd2, 0x86, 0x3a, 0x8e, 0xc7, 0xc8, 0x33, 0x31,
+	0xc3, 0x20, 0x64, 0x19, 0x96, 0xdc, 0x1c, 0xfb, 0x85, 0x54, 0xfc, 0x85, 0x54, 0xfc, 0xd1, 0x42,
+	0x2a, 0x74, 0x77, 0xb9, 0xa3, 0xcb, 0x32, 0x24, 0xaf, 0xc0, 0xb5, 0x67, 0x27, 0x8c, 0x7f, 0x60,
+	0x11, 0x5a, 0xae, 0x5c, 0x5a, 0x33, 0xd8, 0x55, 0x01, 0x91, 0x21, 0x6c, 0xe3, 0x2d, 0xf2, 0x00,
+	0xe5, 0xcc, 0x52, 0x53, 0x3f, 0x7b, 0x73, 0xaf, 0xba, 0xbb, 0x6d, 0xf9, 0xbd, 0x5b, 0xe4, 0x79,
+	0x26, 0x94, 0xec, 0xc9, 0x99, 0xd0, 0x4a, 0x1a, 0x07, 0xdd, 0x32, 0x59, 0x7a, 0x72, 0x46, 0xde,
+	0xc1, 0x9e, 0xc6, 0x54, 0xe5, 0x9a, 0x63, 0x10, 0x8b, 0xa9, 0xc8, 0xd2, 0xe6, 0x86, 0xad, 0xfb,
+	0xe5, 0xbd, 0xbc, 0xb4, 0x8c, 0xbb, 0xb4, 0x61, 0xb4, 0xae, 0xef, 0xd8, 0x9e, 0x0f, 0x07, 0x0f,
+	0x1d, 0x65, 0x66, 0xd3, 0x1d, 0x76, 0x7e, 0xec, 0xd1, 0x62, 0x4e, 0xd7, 0xbf, 0x5c, 0x8f, 0x7a,
+	0x3f, 0x35, 0x1c, 0xef, 0x2f, 0x07, 0x8e, 0x1e, 0xc9, 0x4d, 0x3e, 0x87, 0x3d, 0xb4, 0xb9, 0x30,
+	0x58, 0x08, 0xc1, 0xb1, 0x42, 0xa8, 0x97, 0xf0, 0xa8, 0x40, 0xc9, 0x21, 0x6c, 0x4e, 0x71, 0xaa,
+	0xf4, 0xdc, 0xb2, 0x5d, 0xa1, 0xa5, 0x45, 0x5e, 0x00, 0xf0, 0x24, 0x0f, 0xd2, 0x09, 0xd3, 0x98,
+	0x5a, 0x22, 0x2b, 0x74, 0x87, 0x27, 0xf9, 0xb5, 0x05, 0x4c, 0xfe, 0x29, 0xbb, 0x0d, 0xb8, 0x92,
+	0x3c, 0xd7, 0x1a, 0x25, 0x9f, 0x97, 0x42, 0xab, 0x4f, 0xd9, 0x6d, 0x67, 0x85, 0x7a, 0x7f, 0xac,
+	0x17, 0xd9, 0x97, 0x33, 0xc5, 0x99, 0xe9, 0xef, 0x7f, 0xd0, 0xcb, 0x6b, 0xd8, 0x17, 0x61, 0x10,
+	0xa1, 0x44, 0x6d, 0x53, 0x06, 0x2c, 0x8e, 0xca, 0x9f, 0x7d, 0x4f, 0x84, 0x17, 0x4b, 0xbc, 0x1d,
+	0x47, 0xde, 0x3f, 0x0e, 0x3c, 0x5b, 0x1b, 0xef, 0x58, 0x48, 0x61, 0x5c, 0xcb, 0x3b, 0xc2, 0x79,
+	0xf8, 0x8e, 0x78, 0x7a, 0xe7, 0x8e, 0x30, 0xf2, 0x2a, 0x95, 0x15, 0x4c, 0x58, 0x3a, 0x59, 0xc8,
+	0xab, 0xc4, 0xde, 0xb1, 0x74, 0x42, 0xbe, 0x02, 0x82, 0x32, 0x54, 0x3a, 0x45, 0x33, 0xba, 0x20,
+	0x51, 0xb1, 0x28, 0xa9, 0x71, 0xe9, 0xfe, 0x9a, 0xe7, 0xca, 0x3a, 0xc8, 0xa7, 0xb0, 0x6b, 0x4a,
+	0x09, 0x34, 0xfe, 0x96, 0x0b, 0x8d, 0xa1, 0x95, 0xce, 0x36, 0x75, 0x0d, 0x48, 0x4b, 0xcc, 0x14,
+	0x89, 0x29, 0xe7, 0xcd, 0xcd, 0xa2, 0x48, 0xb3, 0x36, 0xd8, 0xcc, 0x60, 0x5b, 0x05, 0x66, 0xd6,
+	0xde, 0x07, 0xd8, 0x5f, 0xf6, 0xd8, 0x4e, 0x12, 0xad, 0x66, 0x2c, 0x26, 0x9f, 0x00, 0x84, 0xcb,
+	0x7e, 0x6d, 0x9f, 0x2e, 0x5d, 0x43, 0x4c, 0xb7, 0x5c, 0x23, 0xcb, 0x94, 0xb6, 0xdd, 0xba, 0x74,
+	0x61, 0x92, 0xe7, 0xb0, 0x93, 0x8a, 0x48, 0xb2, 0x2c, 0xd7, 0x8b, 0x3f, 0x69, 0x05, 0x78, 0x7f,
+	0x3b, 0xf0, 0xe2, 0x5a, 0x44, 0x12, 0xc3, 0xc7, 0x6e, 0x83, 0x6f, 0xe1, 0xe3, 0xd5, 0x74, 0xc3,
+	0xa5, 0x6f, 0x35, 0x68, 0x97, 0x1e, 0xf1, 0x47, 0xf6, 0xbe, 0x02, 0x57, 0xfd, 0x2e, 0x51, 0x2f,
+	0x08, 0x2c, 0x4a, 0xab, 0x59, 0xac, 0xa4, 0xee, 0x1c, 0x48, 0x11, 0xb2, 0xc6, 0xaa, 0x11, 0x6a,
+	0x65, 0xfd, 0x3a, 0xed, 0xad, 0x7c, 0x74, 0xdf, 0x86, 0xaf, 0x21, 0xe9, 0xeb, 0x37, 0x70, 0xd0,
+	0x51, 0x72, 0x2c, 0x42, 0x94, 0x99, 0x60, 0xb1, 0xc8, 0xe6, 0x97, 0x38, 0xc3, 0xd8, 0xfc, 0x65,
+	0x57, 0xef, 0xcf, 0x2f, 0xfb, 0x9d, 0xc6, 0x13, 0xd2, 0x00, 0xb7, 0x33, 0x1c, 0xbc, 0xed, 0x77,
+	0x7b, 0x83, 0x51, 0xbf, 0x7d, 0xd9, 0x70, 0xce, 0x3b, 0x70, 0xa8, 0x74, 0xe4, 0x4f, 0xe6, 0x09,
+	0xea, 0x18, 0xc3, 0x08, 0x75, 0x79, 0xdc, 0xaf, 0x5f, 0x44, 0x22, 0x9b, 0xe4, 0x37, 0x3e, 0x57,
+	0xd3, 0xd6, 0x9a, 0xbb, 0x35, 0x66, 0x37, 0x5a, 0xf0, 0xe2, 0xad, 0x4b, 0x5b, 0xe6, 0x61, 0xbb,
+	0x29, 0xde, 0xc1, 0xaf, 0xff, 0x1b, 0x00, 0x81, 0x77, 0xdd, 0x6b, 0x26, 0x07, 0x00, 0x00,
 }
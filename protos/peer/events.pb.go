@@ -76,9 +76,7 @@ func (m *Interest) String() string            { return proto.CompactTextString(m
 func (*Interest) ProtoMessage()               {}
 func (*Interest) Descriptor() ([]byte, []int) { return fileDescriptor5, []int{1} }
 
-type isInterest_RegInfo interface {
-	isInterest_RegInfo()
-}
+type isInterest_RegInfo interface{ isInterest_RegInfo() }
 
 type Interest_ChaincodeRegInfo struct {
 	ChaincodeRegInfo *ChaincodeReg `protobuf:"bytes,2,opt,name=chaincode_reg_info,json=chaincodeRegInfo,oneof"`
@@ -243,9 +241,7 @@ func (m *Event) String() string            { return proto.CompactTextString(m) }
 func (*Event) ProtoMessage()               {}
 func (*Event) Descriptor() ([]byte, []int) { return fileDescriptor5, []int{6} }
 
-type isEvent_Event interface {
-	isEvent_Event()
-}
+type isEvent_Event interface{ isEvent_Event() }
 
 type Event_Register struct {
 	Register *Register `protobuf:"bytes,1,opt,name=register,oneof"`
@@ -442,6 +438,159 @@ func _Event_OneofSizer(msg proto.Message) (n int) {
 	return n
 }
 
+// ChaincodeEventsRequest is the data of the signed Envelope sent to
+// ChaincodeEvents.Deliver. Events are read from the valid transactions
+// of the committed blocks of the channel in the envelope header
+type ChaincodeEventsRequest struct {
+	ChaincodeId string `protobuf:"bytes,1,opt,name=chaincode_id,json=chaincodeId" json:"chaincode_id,omitempty"`
+	// regular expression the event names must match, all events of the
+	// chaincode are delivered if empty
+	EventNameFilter string `protobuf:"bytes,2,opt,name=event_name_filter,json=eventNameFilter" json:"event_name_filter,omitempty"`
+	// number of the first block to read events from
+	StartBlock uint64 `protobuf:"varint,3,opt,name=start_block,json=startBlock" json:"start_block,omitempty"`
+	// if set, delivery stops at the current height of the ledger,
+	// otherwise it waits for new blocks to be committed
+	StopAtHeight bool `protobuf:"varint,4,opt,name=stop_at_height,json=stopAtHeight" json:"stop_at_height,omitempty"`
+}
+
+func (m *ChaincodeEventsRequest) Reset()                    { *m = ChaincodeEventsRequest{} }
+func (m *ChaincodeEventsRequest) String() string            { return proto.CompactTextString(m) }
+func (*ChaincodeEventsRequest) ProtoMessage()               {}
+func (*ChaincodeEventsRequest) Descriptor() ([]byte, []int) { return fileDescriptor5, []int{7} }
+
+// ChaincodeBlockEvents carries the matching events of the transactions of
+// a block. A client can resume delivery from block_number + 1
+type ChaincodeBlockEvents struct {
+	BlockNumber     uint64            `protobuf:"varint,1,opt,name=block_number,json=blockNumber" json:"block_number,omitempty"`
+	ChaincodeEvents []*ChaincodeEvent `protobuf:"bytes,2,rep,name=chaincode_events,json=chaincodeEvents" json:"chaincode_events,omitempty"`
+}
+
+func (m *ChaincodeBlockEvents) Reset()                    { *m = ChaincodeBlockEvents{} }
+func (m *ChaincodeBlockEvents) String() string            { return proto.CompactTextString(m) }
+func (*ChaincodeBlockEvents) ProtoMessage()               {}
+func (*ChaincodeBlockEvents) Descriptor() ([]byte, []int) { return fileDescriptor5, []int{8} }
+
+func (m *ChaincodeBlockEvents) GetChaincodeEvents() []*ChaincodeEvent {
+	if m != nil {
+		return m.ChaincodeEvents
+	}
+	return nil
+}
+
+type ChaincodeEventsResponse struct {
+	// Types that are valid to be assigned to Type:
+	//	*ChaincodeEventsResponse_Status
+	//	*ChaincodeEventsResponse_Events
+	Type isChaincodeEventsResponse_Type `protobuf_oneof:"Type"`
+}
+
+func (m *ChaincodeEventsResponse) Reset()                    { *m = ChaincodeEventsResponse{} }
+func (m *ChaincodeEventsResponse) String() string            { return proto.CompactTextString(m) }
+func (*ChaincodeEventsResponse) ProtoMessage()               {}
+func (*ChaincodeEventsResponse) Descriptor() ([]byte, []int) { return fileDescriptor5, []int{9} }
+
+type isChaincodeEventsResponse_Type interface{ isChaincodeEventsResponse_Type() }
+
+type ChaincodeEventsResponse_Status struct {
+	Status common.Status `protobuf:"varint,1,opt,name=status,enum=common.Status,oneof"`
+}
+type ChaincodeEventsResponse_Events struct {
+	Events *ChaincodeBlockEvents `protobuf:"bytes,2,opt,name=events,oneof"`
+}
+
+func (*ChaincodeEventsResponse_Status) isChaincodeEventsResponse_Type() {}
+func (*ChaincodeEventsResponse_Events) isChaincodeEventsResponse_Type() {}
+
+func (m *ChaincodeEventsResponse) GetType() isChaincodeEventsResponse_Type {
+	if m != nil {
+		return m.Type
+	}
+	return nil
+}
+
+func (m *ChaincodeEventsResponse) GetStatus() common.Status {
+	if x, ok := m.GetType().(*ChaincodeEventsResponse_Status); ok {
+		return x.Status
+	}
+	return common.Status_UNKNOWN
+}
+
+func (m *ChaincodeEventsResponse) GetEvents() *ChaincodeBlockEvents {
+	if x, ok := m.GetType().(*ChaincodeEventsResponse_Events); ok {
+		return x.Events
+	}
+	return nil
+}
+
+// XXX_OneofFuncs is for the internal use of the proto package.
+func (*ChaincodeEventsResponse) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
+	return _ChaincodeEventsResponse_OneofMarshaler, _ChaincodeEventsResponse_OneofUnmarshaler, _ChaincodeEventsResponse_OneofSizer, []interface{}{
+		(*ChaincodeEventsResponse_Status)(nil),
+		(*ChaincodeEventsResponse_Events)(nil),
+	}
+}
+
+func _ChaincodeEventsResponse_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
+	m := msg.(*ChaincodeEventsResponse)
+	// Type
+	switch x := m.Type.(type) {
+	case *ChaincodeEventsResponse_Status:
+		b.EncodeVarint(1<<3 | proto.WireVarint)
+		b.EncodeVarint(uint64(x.Status))
+	case *ChaincodeEventsResponse_Events:
+		b.EncodeVarint(2<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.Events); err != nil {
+			return err
+		}
+	case nil:
+	default:
+		return fmt.Errorf("ChaincodeEventsResponse.Type has unexpected type %T", x)
+	}
+	return nil
+}
+
+func _ChaincodeEventsResponse_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error) {
+	m := msg.(*ChaincodeEventsResponse)
+	switch tag {
+	case 1: // Type.status
+		if wire != proto.WireVarint {
+			return true, proto.ErrInternalBadWireType
+		}
+		x, err := b.DecodeVarint()
+		m.Type = &ChaincodeEventsResponse_Status{common.Status(x)}
+		return true, err
+	case 2: // Type.events
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(ChaincodeBlockEvents)
+		err := b.DecodeMessage(msg)
+		m.Type = &ChaincodeEventsResponse_Events{msg}
+		return true, err
+	default:
+		return false, nil
+	}
+}
+
+func _ChaincodeEventsResponse_OneofSizer(msg proto.Message) (n int) {
+	m := msg.(*ChaincodeEventsResponse)
+	// Type
+	switch x := m.Type.(type) {
+	case *ChaincodeEventsResponse_Status:
+		n += proto.SizeVarint(1<<3 | proto.WireVarint)
+		n += proto.SizeVarint(uint64(x.Status))
+	case *ChaincodeEventsResponse_Events:
+		s := proto.Size(x.Events)
+		n += proto.SizeVarint(2<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case nil:
+	default:
+		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
+	}
+	return n
+}
+
 func init() {
 	proto.RegisterType((*ChaincodeReg)(nil), "protos.ChaincodeReg")
 	proto.RegisterType((*Interest)(nil), "protos.Interest")
@@ -450,6 +599,9 @@ func init() {
 	proto.RegisterType((*Unregister)(nil), "protos.Unregister")
 	proto.RegisterType((*SignedEvent)(nil), "protos.SignedEvent")
 	proto.RegisterType((*Event)(nil), "protos.Event")
+	proto.RegisterType((*ChaincodeEventsRequest)(nil), "protos.ChaincodeEventsRequest")
+	proto.RegisterType((*ChaincodeBlockEvents)(nil), "protos.ChaincodeBlockEvents")
+	proto.RegisterType((*ChaincodeEventsResponse)(nil), "protos.ChaincodeEventsResponse")
 	proto.RegisterEnum("protos.EventType", EventType_name, EventType_value)
 }
 
@@ -559,45 +711,158 @@ var _Events_serviceDesc = grpc.ServiceDesc{
 	Metadata: fileDescriptor5,
 }
 
+// Client API for ChaincodeEvents service
+
+type ChaincodeEventsClient interface {
+	// Deliver first requires an Envelope of type DELIVER_SEEK_INFO with
+	// Payload data as a marshaled ChaincodeEventsRequest
+	Deliver(ctx context.Context, opts ...grpc.CallOption) (ChaincodeEvents_DeliverClient, error)
+}
+
+type chaincodeEventsClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewChaincodeEventsClient(cc *grpc.ClientConn) ChaincodeEventsClient {
+	return &chaincodeEventsClient{cc}
+}
+
+func (c *chaincodeEventsClient) Deliver(ctx context.Context, opts ...grpc.CallOption) (ChaincodeEvents_DeliverClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_ChaincodeEvents_serviceDesc.Streams[0], c.cc, "/protos.ChaincodeEvents/Deliver", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &chaincodeEventsDeliverClient{stream}
+	return x, nil
+}
+
+type ChaincodeEvents_DeliverClient interface {
+	Send(*common.Envelope) error
+	Recv() (*ChaincodeEventsResponse, error)
+	grpc.ClientStream
+}
+
+type chaincodeEventsDeliverClient struct {
+	grpc.ClientStream
+}
+
+func (x *chaincodeEventsDeliverClient) Send(m *common.Envelope) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *chaincodeEventsDeliverClient) Recv() (*ChaincodeEventsResponse, error) {
+	m := new(ChaincodeEventsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Server API for ChaincodeEvents service
+
+type ChaincodeEventsServer interface {
+	// Deliver first requires an Envelope of type DELIVER_SEEK_INFO with
+	// Payload data as a marshaled ChaincodeEventsRequest
+	Deliver(ChaincodeEvents_DeliverServer) error
+}
+
+func RegisterChaincodeEventsServer(s *grpc.Server, srv ChaincodeEventsServer) {
+	s.RegisterService(&_ChaincodeEvents_serviceDesc, srv)
+}
+
+func _ChaincodeEvents_Deliver_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ChaincodeEventsServer).Deliver(&chaincodeEventsDeliverServer{stream})
+}
+
+type ChaincodeEvents_DeliverServer interface {
+	Send(*ChaincodeEventsResponse) error
+	Recv() (*common.Envelope, error)
+	grpc.ServerStream
+}
+
+type chaincodeEventsDeliverServer struct {
+	grpc.ServerStream
+}
+
+func (x *chaincodeEventsDeliverServer) Send(m *ChaincodeEventsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *chaincodeEventsDeliverServer) Recv() (*common.Envelope, error) {
+	m := new(common.Envelope)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+var _ChaincodeEvents_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "protos.ChaincodeEvents",
+	HandlerType: (*ChaincodeEventsServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Deliver",
+			Handler:       _ChaincodeEvents_Deliver_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: fileDescriptor5,
+}
+
 func init() { proto.RegisterFile("peer/events.proto", fileDescriptor5) }
 
 var fileDescriptor5 = []byte{
-	// 587 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0x94, 0x54, 0x4f, 0x6f, 0xd3, 0x4e,
-	0x10, 0xb5, 0xd3, 0x26, 0x8d, 0x27, 0x69, 0x7f, 0xe9, 0xf6, 0xa7, 0xca, 0x94, 0x3f, 0x2a, 0x46,
-	0x48, 0xa1, 0x48, 0x49, 0x09, 0x15, 0xf7, 0xda, 0xb5, 0xb0, 0x29, 0x6d, 0xd1, 0x36, 0x5c, 0xb8,
-	0x44, 0x8e, 0x33, 0x75, 0x0c, 0x8d, 0x1d, 0xad, 0xb7, 0xa8, 0xf9, 0x44, 0x9c, 0xf8, 0x8e, 0xc8,
-	0x63, 0xaf, 0x9d, 0x88, 0x13, 0x27, 0x67, 0xde, 0xbc, 0xb7, 0xfb, 0xf6, 0xcd, 0x6e, 0x60, 0x7f,
-	0x89, 0x28, 0x86, 0xf8, 0x13, 0x13, 0x99, 0x0d, 0x96, 0x22, 0x95, 0x29, 0x6b, 0xd1, 0x27, 0x3b,
-	0x3a, 0x08, 0xd3, 0xc5, 0x22, 0x4d, 0x86, 0xc5, 0xa7, 0x68, 0x1e, 0x3d, 0x21, 0x7e, 0x38, 0x0f,
-	0xe2, 0x24, 0x4c, 0x67, 0x48, 0xc2, 0xb2, 0x75, 0x48, 0x2d, 0x29, 0x82, 0x24, 0x0b, 0x42, 0x19,
-	0x2b, 0x89, 0xf5, 0x05, 0xba, 0x8e, 0xe2, 0x73, 0x8c, 0xd8, 0x4b, 0xe8, 0x56, 0xfa, 0x49, 0x3c,
-	0x33, 0xf5, 0x63, 0xbd, 0x6f, 0xf0, 0x4e, 0x85, 0xf9, 0x33, 0xf6, 0x1c, 0x80, 0x56, 0x9e, 0x24,
-	0xc1, 0x02, 0xcd, 0x06, 0x11, 0x0c, 0x42, 0xae, 0x83, 0x05, 0x5a, 0xbf, 0x74, 0x68, 0xfb, 0x89,
-	0x44, 0x81, 0x99, 0x64, 0xa7, 0x8a, 0x2b, 0x57, 0x4b, 0xa4, 0xc5, 0xf6, 0x46, 0xfb, 0xc5, 0xd6,
-	0xd9, 0xc0, 0xcd, 0x3b, 0xe3, 0xd5, 0x12, 0x4b, 0x79, 0xfe, 0x93, 0x5d, 0x00, 0xab, 0x0d, 0x08,
-	0x8c, 0x26, 0x71, 0x72, 0x97, 0xd2, 0x2e, 0x9d, 0xd1, 0xff, 0x4a, 0xb9, 0x6e, 0xd9, 0xd3, 0x78,
-	0x2f, 0x5c, 0xab, 0xfd, 0xe4, 0x2e, 0x65, 0x26, 0xec, 0x10, 0xe6, 0x5f, 0x98, 0x5b, 0x64, 0x50,
-	0x95, 0xb6, 0x01, 0x3b, 0x25, 0xc9, 0x3a, 0x83, 0x36, 0xc7, 0x28, 0xce, 0x24, 0x0a, 0xd6, 0x87,
-	0x56, 0x91, 0xb3, 0xa9, 0x1f, 0x6f, 0xf5, 0x3b, 0xa3, 0x9e, 0xda, 0x4a, 0x1d, 0x85, 0x97, 0x7d,
-	0xeb, 0x0a, 0x0c, 0x8e, 0xdf, 0x91, 0x42, 0x64, 0xaf, 0xa0, 0x21, 0x1f, 0xe9, 0x5c, 0x9d, 0xd1,
-	0x81, 0x92, 0x8c, 0xeb, 0x94, 0x79, 0x43, 0x3e, 0xb2, 0xa7, 0x60, 0xa0, 0x10, 0xa9, 0x98, 0x2c,
-	0xb2, 0xa8, 0xcc, 0xab, 0x4d, 0xc0, 0x55, 0x16, 0x59, 0x1f, 0x00, 0xbe, 0x26, 0xe2, 0xdf, 0x6d,
-	0x5c, 0x42, 0xe7, 0x36, 0x8e, 0x12, 0x9c, 0x51, 0x8a, 0xec, 0x19, 0x18, 0x59, 0x1c, 0x25, 0x81,
-	0x7c, 0x10, 0x45, 0xce, 0x5d, 0x5e, 0x03, 0xec, 0x45, 0x39, 0x06, 0x7b, 0x25, 0x31, 0x23, 0x0b,
-	0x5d, 0xbe, 0x86, 0x58, 0xbf, 0x1b, 0xd0, 0x2c, 0xd6, 0x19, 0x40, 0x5b, 0x99, 0x29, 0x8f, 0x55,
-	0x59, 0x50, 0x59, 0x79, 0x1a, 0xaf, 0x38, 0xec, 0x35, 0x34, 0xa7, 0xf7, 0x69, 0xf8, 0xa3, 0x9c,
-	0xd0, 0xee, 0xa0, 0xbc, 0x90, 0x76, 0x0e, 0x7a, 0x1a, 0x2f, 0xba, 0xec, 0x1c, 0xfe, 0xab, 0xa7,
-	0x4a, 0x1b, 0xd3, 0x5c, 0x3a, 0xa3, 0xc3, 0xbf, 0x46, 0x4a, 0x3e, 0x3c, 0x8d, 0xef, 0x85, 0x1b,
-	0x08, 0x7b, 0x07, 0x86, 0x50, 0xb9, 0x9b, 0xdb, 0x24, 0xde, 0xaf, 0xad, 0x95, 0x0d, 0x4f, 0xe3,
-	0x35, 0x8b, 0x9d, 0x01, 0x3c, 0x54, 0xd9, 0x9a, 0x4d, 0xd2, 0x30, 0xa5, 0xa9, 0x53, 0xf7, 0x34,
-	0xbe, 0xc6, 0xa3, 0xbb, 0x23, 0x30, 0x90, 0xa9, 0x30, 0x5b, 0x94, 0x94, 0x2a, 0xed, 0x9d, 0x32,
-	0xa5, 0x13, 0x1b, 0x8c, 0xea, 0xf2, 0xb2, 0x2e, 0xb4, 0xb9, 0xfb, 0xd1, 0xbf, 0x1d, 0xbb, 0xbc,
-	0xa7, 0x31, 0x03, 0x9a, 0xf6, 0xe7, 0x1b, 0xe7, 0xb2, 0xa7, 0xb3, 0x5d, 0x30, 0x1c, 0xef, 0xdc,
-	0xbf, 0x76, 0x6e, 0x2e, 0xdc, 0x5e, 0x23, 0x2f, 0xb9, 0xfb, 0xc9, 0x75, 0xc6, 0xfe, 0xcd, 0x75,
-	0x6f, 0x6b, 0x74, 0x06, 0x2d, 0x5a, 0x23, 0x63, 0x27, 0xb0, 0xed, 0xcc, 0x03, 0xc9, 0x76, 0x37,
-	0x1e, 0xc6, 0xd1, 0x66, 0x69, 0x69, 0x7d, 0xfd, 0x54, 0xb7, 0xdf, 0x7e, 0x7b, 0x13, 0xc5, 0x72,
-	0xfe, 0x30, 0xcd, 0x83, 0x1e, 0xce, 0x57, 0x4b, 0x14, 0xf7, 0x38, 0x8b, 0x50, 0x0c, 0xef, 0x82,
-	0xa9, 0x88, 0xc3, 0x61, 0xa1, 0x19, 0xe6, 0xcf, 0x7d, 0x5a, 0xfc, 0x59, 0xbc, 0xff, 0x13, 0x00,
-	0x00, 0xff, 0xff, 0x82, 0xc7, 0xec, 0x4d, 0x48, 0x04, 0x00, 0x00,
+	// 788 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0x94, 0x55, 0xdf, 0x93, 0xe2, 0x44,
+	0x10, 0x4e, 0x58, 0x96, 0x25, 0x1d, 0x76, 0x09, 0x73, 0x57, 0x6b, 0xc4, 0xd3, 0x3b, 0xa3, 0x56,
+	0xe1, 0x5a, 0x05, 0x27, 0x6e, 0xdd, 0x3b, 0x01, 0x34, 0x78, 0x1e, 0x6b, 0xcd, 0x72, 0x2f, 0xbe,
+	0xa4, 0x42, 0x18, 0x42, 0x14, 0x92, 0x38, 0x33, 0x6c, 0x1d, 0x55, 0xbe, 0xf9, 0xc7, 0xf8, 0xa4,
+	0x7f, 0xa3, 0x95, 0xce, 0x2f, 0xb8, 0xd5, 0x2a, 0x7d, 0x0a, 0xf3, 0x75, 0x7f, 0x3d, 0xdf, 0x7c,
+	0xdd, 0x33, 0x40, 0x27, 0x61, 0x8c, 0x0f, 0xd8, 0x03, 0x8b, 0xa4, 0xe8, 0x27, 0x3c, 0x96, 0x31,
+	0x69, 0xe0, 0x47, 0x74, 0x9f, 0xf8, 0xf1, 0x6e, 0x17, 0x47, 0x83, 0xec, 0x93, 0x05, 0xbb, 0x1f,
+	0x62, 0xbe, 0xbf, 0xf1, 0xc2, 0xc8, 0x8f, 0x57, 0x0c, 0x89, 0x79, 0xe8, 0x1a, 0x43, 0x92, 0x7b,
+	0x91, 0xf0, 0x7c, 0x19, 0x16, 0x14, 0xeb, 0x47, 0x68, 0x8d, 0x8b, 0x7c, 0xca, 0x02, 0xf2, 0x29,
+	0xb4, 0x4a, 0xbe, 0x1b, 0xae, 0x4c, 0xf5, 0x85, 0xda, 0xd3, 0xa8, 0x5e, 0x62, 0xb3, 0x15, 0xf9,
+	0x18, 0x00, 0x2b, 0xbb, 0x91, 0xb7, 0x63, 0x66, 0x0d, 0x13, 0x34, 0x44, 0xe6, 0xde, 0x8e, 0x59,
+	0x7f, 0xa8, 0xd0, 0x9c, 0x45, 0x92, 0x71, 0x26, 0x24, 0x79, 0x59, 0xe4, 0xca, 0x43, 0xc2, 0xb0,
+	0xd8, 0xd5, 0xb0, 0x93, 0x6d, 0x2d, 0xfa, 0xd3, 0x34, 0xb2, 0x38, 0x24, 0x2c, 0xa7, 0xa7, 0x3f,
+	0xc9, 0x04, 0x48, 0x25, 0x80, 0xb3, 0xc0, 0x0d, 0xa3, 0x75, 0x8c, 0xbb, 0xe8, 0xc3, 0xa7, 0x05,
+	0xf3, 0x58, 0xb2, 0xa3, 0x50, 0xc3, 0x3f, 0x5a, 0xcf, 0xa2, 0x75, 0x4c, 0x4c, 0xb8, 0x40, 0x6c,
+	0x36, 0x31, 0xcf, 0x50, 0x60, 0xb1, 0xb4, 0x35, 0xb8, 0xc8, 0x93, 0xac, 0x5b, 0x68, 0x52, 0x16,
+	0x84, 0x42, 0x32, 0x4e, 0x7a, 0xd0, 0xc8, 0x7c, 0x36, 0xd5, 0x17, 0x67, 0x3d, 0x7d, 0x68, 0x14,
+	0x5b, 0x15, 0x47, 0xa1, 0x79, 0xdc, 0x7a, 0x03, 0x1a, 0x65, 0x3f, 0x33, 0x34, 0x91, 0x7c, 0x06,
+	0x35, 0xf9, 0x0e, 0xcf, 0xa5, 0x0f, 0x9f, 0x14, 0x94, 0x45, 0xe5, 0x32, 0xad, 0xc9, 0x77, 0xe4,
+	0x23, 0xd0, 0x18, 0xe7, 0x31, 0x77, 0x77, 0x22, 0xc8, 0xfd, 0x6a, 0x22, 0xf0, 0x46, 0x04, 0xd6,
+	0x2b, 0x80, 0xb7, 0x11, 0xff, 0xff, 0x32, 0x5e, 0x83, 0x7e, 0x1f, 0x06, 0x11, 0x5b, 0xa1, 0x8b,
+	0xe4, 0x19, 0x68, 0x22, 0x0c, 0x22, 0x4f, 0xee, 0x79, 0xe6, 0x73, 0x8b, 0x56, 0x00, 0xf9, 0x24,
+	0x6f, 0x83, 0x7d, 0x90, 0x4c, 0xa0, 0x84, 0x16, 0x3d, 0x42, 0xac, 0x3f, 0x6b, 0x70, 0x9e, 0xd5,
+	0xe9, 0x43, 0xb3, 0x10, 0x93, 0x1f, 0xab, 0x94, 0x50, 0x78, 0xe5, 0x28, 0xb4, 0xcc, 0x21, 0x5f,
+	0xc0, 0xf9, 0x72, 0x1b, 0xfb, 0xbf, 0xe4, 0x1d, 0xba, 0xec, 0xe7, 0x03, 0x69, 0xa7, 0xa0, 0xa3,
+	0xd0, 0x2c, 0x4a, 0x46, 0xd0, 0xae, 0xba, 0x8a, 0x1b, 0x63, 0x5f, 0xf4, 0xe1, 0xf5, 0xa3, 0x96,
+	0xa2, 0x0e, 0x47, 0xa1, 0x57, 0xfe, 0x09, 0x42, 0xbe, 0x06, 0x8d, 0x17, 0xbe, 0x9b, 0x75, 0x24,
+	0x77, 0x2a, 0x69, 0x79, 0xc0, 0x51, 0x68, 0x95, 0x45, 0x6e, 0x01, 0xf6, 0xa5, 0xb7, 0xe6, 0x39,
+	0x72, 0x48, 0xc1, 0xa9, 0x5c, 0x77, 0x14, 0x7a, 0x94, 0x87, 0xb3, 0xc3, 0x99, 0x27, 0x63, 0x6e,
+	0x36, 0xd0, 0xa9, 0x62, 0x69, 0x5f, 0xe4, 0x2e, 0x59, 0x7f, 0xa9, 0x70, 0x7d, 0x2a, 0x58, 0x50,
+	0xf6, 0xeb, 0x3e, 0x9d, 0xf8, 0xff, 0x70, 0x81, 0x6e, 0xa0, 0x53, 0x5d, 0x20, 0x77, 0x1d, 0x6e,
+	0x53, 0x75, 0xd9, 0x5c, 0xb4, 0xcb, 0x7b, 0xf4, 0x2d, 0xc2, 0xe4, 0x39, 0xe8, 0x42, 0x7a, 0x5c,
+	0xba, 0x99, 0xcb, 0xa9, 0x69, 0x75, 0x0a, 0x08, 0xa1, 0xc5, 0xe4, 0x73, 0xb8, 0x12, 0x32, 0x4e,
+	0x5c, 0x4f, 0xba, 0x1b, 0x16, 0x06, 0x1b, 0x89, 0xde, 0x34, 0x69, 0x2b, 0x45, 0x47, 0xd2, 0x41,
+	0xcc, 0xfa, 0x0d, 0x9e, 0x96, 0x7a, 0x91, 0x97, 0x89, 0x4e, 0xd5, 0x62, 0x61, 0x37, 0xda, 0xef,
+	0x96, 0x79, 0xcb, 0xeb, 0x54, 0x47, 0x6c, 0x8e, 0x10, 0x19, 0x81, 0xf1, 0x5e, 0xeb, 0xd2, 0x09,
+	0x3a, 0xfb, 0xf7, 0xde, 0xd1, 0xf6, 0x69, 0xe7, 0x84, 0xf5, 0xbb, 0x0a, 0x1f, 0x3c, 0xb2, 0x4b,
+	0x24, 0x71, 0x24, 0x58, 0x3a, 0xf1, 0x42, 0x7a, 0x72, 0x2f, 0xf2, 0xd7, 0xe1, 0xaa, 0x98, 0xa0,
+	0x7b, 0x44, 0x1d, 0x85, 0xe6, 0x71, 0xf2, 0xaa, 0xbc, 0x1b, 0xd9, 0xac, 0x3d, 0x7b, 0xb4, 0xfd,
+	0xd1, 0xc9, 0x52, 0x5e, 0x96, 0x6d, 0x37, 0xa0, 0x9e, 0xbe, 0x2c, 0x37, 0x36, 0x68, 0xe5, 0x8b,
+	0x43, 0x5a, 0xd0, 0xa4, 0xd3, 0xef, 0x66, 0xf7, 0x8b, 0x29, 0x35, 0x14, 0xa2, 0xc1, 0xb9, 0xfd,
+	0xc3, 0xdd, 0xf8, 0xb5, 0xa1, 0x92, 0x4b, 0xd0, 0xc6, 0xce, 0x68, 0x36, 0x1f, 0xdf, 0x4d, 0xa6,
+	0x46, 0x2d, 0x5d, 0xd2, 0xe9, 0xf7, 0xd3, 0xf1, 0x62, 0x76, 0x37, 0x37, 0xce, 0x86, 0xb7, 0xd0,
+	0xc8, 0x9d, 0xbb, 0x81, 0xfa, 0x78, 0xe3, 0x49, 0x72, 0x79, 0xf2, 0x9a, 0x75, 0x4f, 0x97, 0x96,
+	0xd2, 0x53, 0x5f, 0xaa, 0xc3, 0xb7, 0xd0, 0x7e, 0xef, 0xf8, 0xc4, 0x86, 0x8b, 0x09, 0xdb, 0x86,
+	0x0f, 0x8c, 0x13, 0xa3, 0x38, 0xf1, 0x34, 0x7a, 0x60, 0xdb, 0x38, 0x61, 0xdd, 0xe7, 0xff, 0x6c,
+	0x6c, 0x69, 0x5a, 0x56, 0xd6, 0xfe, 0xea, 0xa7, 0x2f, 0x83, 0x50, 0x6e, 0xf6, 0xcb, 0xb4, 0xc0,
+	0x60, 0x73, 0x48, 0x18, 0xdf, 0xb2, 0x55, 0xc0, 0xf8, 0x60, 0xed, 0x2d, 0x79, 0xe8, 0x0f, 0xb2,
+	0x2a, 0x83, 0xf4, 0xe9, 0x5f, 0x66, 0x7f, 0x1c, 0xdf, 0xfc, 0x3d, 0x00, 0x95, 0xa0, 0x1a, 0xbf,
+	0x54, 0x06, 0x00, 0x00,
 }
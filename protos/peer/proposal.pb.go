@@ -105,6 +105,11 @@ type ChaincodeHeaderExtension struct {
 	PayloadVisibility []byte `protobuf:"bytes,1,opt,name=payload_visibility,json=payloadVisibility,proto3" json:"payload_visibility,omitempty"`
 	// The ID of the chaincode to target.
 	ChaincodeId *ChaincodeID `protobuf:"bytes,2,opt,name=chaincode_id,json=chaincodeId" json:"chaincode_id,omitempty"`
+	// ReadOnly is set by clients that only query the chaincode and do not
+	// intend to submit the endorsement for ordering. Peers use it to
+	// schedule the execution of the proposal and reject it if its simulation
+	// writes to the state.
+	ReadOnly bool `protobuf:"varint,3,opt,name=read_only,json=readOnly" json:"read_only,omitempty"`
 }
 
 func (m *ChaincodeHeaderExtension) Reset()                    { *m = ChaincodeHeaderExtension{} }
@@ -181,32 +186,33 @@ func init() {
 func init() { proto.RegisterFile("peer/proposal.proto", fileDescriptor7) }
 
 var fileDescriptor7 = []byte{
-	// 419 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0x64, 0x52, 0xdf, 0x6b, 0xd4, 0x40,
-	0x10, 0xe6, 0xee, 0xb0, 0x3f, 0x26, 0x67, 0x6d, 0xb7, 0x45, 0xc2, 0xd1, 0x87, 0x12, 0x10, 0x2a,
-	0x6a, 0x02, 0x11, 0x44, 0x7c, 0x11, 0xab, 0x05, 0xfb, 0x20, 0x94, 0xa8, 0x7d, 0xe8, 0xcb, 0xb1,
-	0x49, 0xc6, 0x64, 0x31, 0xee, 0xae, 0xbb, 0x9b, 0xc3, 0x3c, 0xfa, 0xe7, 0xf9, 0x5f, 0x49, 0xb2,
-	0x3f, 0x6c, 0xbd, 0xa7, 0xe4, 0x9b, 0xef, 0x9b, 0x6f, 0x66, 0x76, 0x06, 0x8e, 0x25, 0xa2, 0xca,
-	0xa4, 0x12, 0x52, 0x68, 0xda, 0xa5, 0x52, 0x09, 0x23, 0xc8, 0xce, 0xf4, 0xd1, 0xab, 0x93, 0x89,
-	0xac, 0x5a, 0xca, 0x78, 0x25, 0x6a, 0xb4, 0xec, 0xea, 0xf4, 0x5e, 0xca, 0x5a, 0xa1, 0x96, 0x82,
-	0x6b, 0xc7, 0x26, 0x5f, 0xe1, 0xe0, 0x33, 0x6b, 0x38, 0xd6, 0xd7, 0x4e, 0x40, 0x9e, 0xc0, 0x41,
-	0x10, 0x97, 0x83, 0x41, 0x1d, 0xcf, 0xce, 0x66, 0xe7, 0xcb, 0xe2, 0xa1, 0x8f, 0x5e, 0x8c, 0x41,
-	0x72, 0x0a, 0xfb, 0x9a, 0x35, 0x9c, 0x9a, 0x5e, 0x61, 0x3c, 0x9f, 0x14, 0xff, 0x02, 0xc9, 0x2d,
-	0xec, 0x05, 0xc3, 0xc7, 0xb0, 0xd3, 0x22, 0xad, 0x51, 0x39, 0x23, 0x87, 0x48, 0x0c, 0xbb, 0x92,
-	0x0e, 0x9d, 0xa0, 0xb5, 0xcb, 0xf7, 0x70, 0xf4, 0xc6, 0x5f, 0x06, 0xb9, 0x66, 0x82, 0xc7, 0x0b,
-	0xeb, 0x1d, 0x02, 0xc9, 0xef, 0x19, 0xc4, 0xef, 0xfd, 0x90, 0x1f, 0x27, 0xaf, 0x4b, 0x4f, 0x92,
-	0x17, 0x40, 0x9c, 0xcb, 0x7a, 0xc3, 0x34, 0x2b, 0x59, 0xc7, 0xcc, 0xe0, 0x0a, 0x1f, 0x39, 0xe6,
-	0x26, 0x10, 0xe4, 0x15, 0x2c, 0xc3, 0x7b, 0xad, 0x99, 0x6d, 0x24, 0xca, 0x8f, 0xed, 0xe3, 0xe8,
-	0x34, 0x94, 0xb9, 0xfa, 0x50, 0x44, 0x41, 0x78, 0x55, 0x27, 0x7f, 0xee, 0xf6, 0xe0, 0x27, 0xbd,
-	0x76, 0xed, 0x9f, 0xc0, 0x03, 0xc6, 0x65, 0x6f, 0x5c, 0x59, 0x0b, 0xc8, 0x0d, 0x2c, 0xbf, 0x28,
-	0xca, 0x35, 0x43, 0x6e, 0x3e, 0x51, 0x19, 0xcf, 0xcf, 0x16, 0xe7, 0x51, 0x9e, 0x6f, 0x95, 0xfa,
-	0xcf, 0x2d, 0xbd, 0x9b, 0x74, 0xc9, 0x8d, 0x1a, 0x8a, 0x7b, 0x3e, 0xab, 0xb7, 0x70, 0xb4, 0x25,
-	0x21, 0x87, 0xb0, 0xf8, 0x8e, 0x76, 0xee, 0xfd, 0x62, 0xfc, 0x1d, 0x9b, 0xda, 0xd0, 0xae, 0xf7,
-	0xbb, 0xb2, 0xe0, 0xcd, 0xfc, 0xf5, 0x2c, 0xf9, 0x09, 0x8f, 0x42, 0xf1, 0x77, 0x95, 0x19, 0x5f,
-	0x31, 0x86, 0x5d, 0x85, 0xba, 0xef, 0x8c, 0x5f, 0xbe, 0x87, 0xe3, 0x32, 0x71, 0x83, 0xdc, 0x68,
-	0xe7, 0xe3, 0x10, 0x79, 0x0e, 0x7b, 0xfe, 0xb2, 0xa6, 0x8d, 0x45, 0xf9, 0xa1, 0x9f, 0xac, 0x70,
-	0xf1, 0x22, 0x28, 0x2e, 0x9e, 0xdd, 0x3e, 0x6d, 0x98, 0x69, 0xfb, 0x32, 0xad, 0xc4, 0x8f, 0xac,
-	0x1d, 0x24, 0xaa, 0x0e, 0xeb, 0x06, 0x55, 0xf6, 0x8d, 0x96, 0x8a, 0x55, 0x99, 0x4d, 0xcd, 0xc6,
-	0xd3, 0x2d, 0xed, 0x79, 0xbf, 0xfc, 0x1b, 0x00, 0x00, 0xff, 0xff, 0x28, 0x14, 0xac, 0x8e, 0xfc,
-	0x02, 0x00, 0x00,
+	// 437 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0x64, 0x52, 0x5d, 0x6b, 0x14, 0x31,
+	0x14, 0x65, 0x77, 0xb1, 0xdd, 0xbd, 0xbb, 0xd6, 0x36, 0x2d, 0x32, 0xac, 0x7d, 0x28, 0x03, 0x42,
+	0x45, 0xdd, 0x81, 0x15, 0x44, 0x7c, 0x11, 0xab, 0x05, 0xfb, 0x20, 0x96, 0x51, 0xfb, 0xd0, 0x97,
+	0x25, 0x33, 0x73, 0xdd, 0x0d, 0xc6, 0x24, 0x26, 0x99, 0xc5, 0xf9, 0x33, 0xfe, 0x1f, 0xff, 0x95,
+	0x64, 0xf2, 0x61, 0x6b, 0x9f, 0x66, 0xee, 0x3d, 0xe7, 0x9e, 0x9c, 0x9c, 0x5c, 0x38, 0x54, 0x88,
+	0xba, 0x50, 0x5a, 0x2a, 0x69, 0x28, 0x5f, 0x28, 0x2d, 0xad, 0x24, 0x3b, 0xfd, 0xc7, 0xcc, 0x8f,
+	0x7a, 0xb0, 0xde, 0x50, 0x26, 0x6a, 0xd9, 0xa0, 0x47, 0xe7, 0xc7, 0xb7, 0x46, 0x56, 0x1a, 0x8d,
+	0x92, 0xc2, 0x04, 0x34, 0xff, 0x0a, 0x7b, 0x9f, 0xd9, 0x5a, 0x60, 0x73, 0x19, 0x08, 0xe4, 0x31,
+	0xec, 0x25, 0x72, 0xd5, 0x59, 0x34, 0xd9, 0xe0, 0x64, 0x70, 0x3a, 0x2b, 0xef, 0xc7, 0xee, 0x99,
+	0x6b, 0x92, 0x63, 0x98, 0x18, 0xb6, 0x16, 0xd4, 0xb6, 0x1a, 0xb3, 0x61, 0xcf, 0xf8, 0xd7, 0xc8,
+	0xaf, 0x61, 0x9c, 0x04, 0x1f, 0xc2, 0xce, 0x06, 0x69, 0x83, 0x3a, 0x08, 0x85, 0x8a, 0x64, 0xb0,
+	0xab, 0x68, 0xc7, 0x25, 0x6d, 0xc2, 0x7c, 0x2c, 0x9d, 0x36, 0xfe, 0xb2, 0x28, 0x0c, 0x93, 0x22,
+	0x1b, 0x79, 0xed, 0xd4, 0xc8, 0x7f, 0x0f, 0x20, 0x7b, 0x17, 0x2f, 0xf9, 0xa1, 0xd7, 0x3a, 0x8f,
+	0x20, 0x79, 0x0e, 0x24, 0xa8, 0xac, 0xb6, 0xcc, 0xb0, 0x8a, 0x71, 0x66, 0xbb, 0x70, 0xf0, 0x41,
+	0x40, 0xae, 0x12, 0x40, 0x5e, 0xc2, 0x2c, 0xe5, 0xb5, 0x62, 0xde, 0xc8, 0x74, 0x79, 0xe8, 0xc3,
+	0x31, 0x8b, 0x74, 0xcc, 0xc5, 0xfb, 0x72, 0x9a, 0x88, 0x17, 0x0d, 0x79, 0x04, 0x13, 0x8d, 0xb4,
+	0x59, 0x49, 0xc1, 0xbb, 0xde, 0xe1, 0xb8, 0x1c, 0xbb, 0xc6, 0x27, 0xc1, 0xbb, 0xfc, 0xcf, 0x4d,
+	0x83, 0x31, 0x86, 0xcb, 0x70, 0xb7, 0x23, 0xb8, 0xc7, 0x84, 0x6a, 0x6d, 0xf0, 0xe4, 0x0b, 0x72,
+	0x05, 0xb3, 0x2f, 0x9a, 0x0a, 0xc3, 0x50, 0xd8, 0x8f, 0x54, 0x65, 0xc3, 0x93, 0xd1, 0xe9, 0x74,
+	0xb9, 0xbc, 0xe3, 0xe3, 0x3f, 0xb5, 0xc5, 0xcd, 0xa1, 0x73, 0x61, 0x75, 0x57, 0xde, 0xd2, 0x99,
+	0xbf, 0x81, 0x83, 0x3b, 0x14, 0xb2, 0x0f, 0xa3, 0xef, 0xe8, 0x43, 0x99, 0x94, 0xee, 0xd7, 0x99,
+	0xda, 0x52, 0xde, 0xc6, 0x87, 0xf4, 0xc5, 0xeb, 0xe1, 0xab, 0x41, 0xfe, 0x13, 0x1e, 0xa4, 0xc3,
+	0xdf, 0xd6, 0xd6, 0x45, 0x9c, 0xc1, 0xae, 0x46, 0xd3, 0x72, 0x1b, 0x37, 0x23, 0x96, 0xee, 0xa5,
+	0x71, 0x8b, 0xc2, 0x9a, 0xa0, 0x13, 0x2a, 0xf2, 0x0c, 0xc6, 0x71, 0xed, 0xfa, 0xb0, 0xa6, 0xcb,
+	0xfd, 0x78, 0xb3, 0x32, 0xf4, 0xcb, 0xc4, 0x38, 0x7b, 0x7a, 0xfd, 0x64, 0xcd, 0xec, 0xa6, 0xad,
+	0x16, 0xb5, 0xfc, 0x51, 0x6c, 0x3a, 0x85, 0x9a, 0x63, 0xb3, 0x46, 0x5d, 0x7c, 0xa3, 0x95, 0x66,
+	0x75, 0xe1, 0x47, 0x0b, 0xb7, 0xd7, 0x95, 0xdf, 0xfd, 0x17, 0x7f, 0x07, 0x00, 0x84, 0x0c, 0x41,
+	0x6e, 0x19, 0x03, 0x00, 0x00,
 }
@@ -160,6 +160,23 @@ func GetChaincodeHeaderExtension(hdr *common.Header) (*peer.ChaincodeHeaderExten
 	return chaincodeHdrExt, nil
 }
 
+// IsReadOnlyProposal returns whether the client marked the given chaincode
+// proposal as a query. The endorser rejects such proposals if their simulation
+// writes to the state
+func IsReadOnlyProposal(prop *peer.Proposal) (bool, error) {
+	hdr, err := GetHeader(prop.Header)
+	if err != nil {
+		return false, err
+	}
+
+	chaincodeHdrExt, err := GetChaincodeHeaderExtension(hdr)
+	if err != nil {
+		return false, err
+	}
+
+	return chaincodeHdrExt.ReadOnly, nil
+}
+
 // GetProposalResponse given proposal in bytes
 func GetProposalResponse(prBytes []byte) (*peer.ProposalResponse, error) {
 	proposalResponse := &peer.ProposalResponse{}
@@ -340,7 +357,30 @@ func CreateChaincodeProposalWithTransient(typ common.HeaderType, chainID string,
 
 // CreateChaincodeProposalWithTxIDNonceAndTransient creates a proposal from given input
 func CreateChaincodeProposalWithTxIDNonceAndTransient(txid string, typ common.HeaderType, chainID string, cis *peer.ChaincodeInvocationSpec, nonce, creator []byte, transientMap map[string][]byte) (*peer.Proposal, string, error) {
-	ccHdrExt := &peer.ChaincodeHeaderExtension{ChaincodeId: cis.ChaincodeSpec.ChaincodeId}
+	return createChaincodeProposal(txid, typ, chainID, cis, nonce, creator, transientMap, false)
+}
+
+// CreateQueryProposalFromCIS returns a proposal given a serialized identity and a
+// ChaincodeInvocationSpec. The proposal is marked as read only so that peers can
+// schedule it as a query
+func CreateQueryProposalFromCIS(typ common.HeaderType, chainID string, cis *peer.ChaincodeInvocationSpec, creator []byte) (*peer.Proposal, string, error) {
+	// generate a random nonce
+	nonce, err := primitives.GetRandomNonce()
+	if err != nil {
+		return nil, "", err
+	}
+
+	// compute txid
+	txid, err := ComputeProposalTxID(nonce, creator)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return createChaincodeProposal(txid, typ, chainID, cis, nonce, creator, nil, true)
+}
+
+func createChaincodeProposal(txid string, typ common.HeaderType, chainID string, cis *peer.ChaincodeInvocationSpec, nonce, creator []byte, transientMap map[string][]byte, readOnly bool) (*peer.Proposal, string, error) {
+	ccHdrExt := &peer.ChaincodeHeaderExtension{ChaincodeId: cis.ChaincodeSpec.ChaincodeId, ReadOnly: readOnly}
 	ccHdrExtBytes, err := proto.Marshal(ccHdrExt)
 	if err != nil {
 		return nil, "", err
@@ -539,6 +579,12 @@ func CreateInstallProposalFromCDS(cds *peer.ChaincodeDeploymentSpec, creator []b
 	return createProposalFromCDS("", cds, creator, nil, nil, nil, "install")
 }
 
+// CreateInstallProposalFromPackage returns a install proposal given a serialized identity and a chaincode
+// package, either a marshalled ChaincodeDeploymentSpec or a signed package Envelope
+func CreateInstallProposalFromPackage(pkg []byte, creator []byte) (*peer.Proposal, string, error) {
+	return createLifecycleProposal("", [][]byte{[]byte("install"), pkg}, creator, false)
+}
+
 // CreateDeployProposalFromCDS returns a deploy proposal given a serialized identity and a ChaincodeDeploymentSpec
 func CreateDeployProposalFromCDS(chainID string, cds *peer.ChaincodeDeploymentSpec, creator []byte, policy []byte, escc []byte, vscc []byte) (*peer.Proposal, string, error) {
 	return createProposalFromCDS(chainID, cds, creator, policy, escc, vscc, "deploy")
@@ -581,6 +627,35 @@ func createProposalFromCDS(chainID string, cds *peer.ChaincodeDeploymentSpec, cr
 	return CreateProposalFromCIS(common.HeaderType_ENDORSER_TRANSACTION, chainID, lcccSpec, creator)
 }
 
+// CreateApproveProposal returns a proposal approving a marshalled ChaincodeDefinition for the organization of the creator
+func CreateApproveProposal(chainID string, def []byte, signature []byte, creator []byte) (*peer.Proposal, string, error) {
+	return createLifecycleProposal(chainID, [][]byte{[]byte("approve"), []byte(chainID), def, signature}, creator, false)
+}
+
+// CreateCommitProposal returns a proposal committing a marshalled ChaincodeDefinition on the chain
+func CreateCommitProposal(chainID string, def []byte, creator []byte) (*peer.Proposal, string, error) {
+	return createLifecycleProposal(chainID, [][]byte{[]byte("commit"), []byte(chainID), def}, creator, false)
+}
+
+// CreateQueryCommittedProposal returns a proposal querying the committed ChaincodeDefinition of a chaincode
+func CreateQueryCommittedProposal(chainID string, ccname string, creator []byte) (*peer.Proposal, string, error) {
+	return createLifecycleProposal(chainID, [][]byte{[]byte("querycommitted"), []byte(chainID), []byte(ccname)}, creator, true)
+}
+
+// createLifecycleProposal returns a proposal invoking lccc with the given arguments
+func createLifecycleProposal(chainID string, args [][]byte, creator []byte, readOnly bool) (*peer.Proposal, string, error) {
+	lcccSpec := &peer.ChaincodeInvocationSpec{
+		ChaincodeSpec: &peer.ChaincodeSpec{
+			Type:        peer.ChaincodeSpec_GOLANG,
+			ChaincodeId: &peer.ChaincodeID{Name: "lccc"},
+			Input:       &peer.ChaincodeInput{Args: args}}}
+
+	if readOnly {
+		return CreateQueryProposalFromCIS(common.HeaderType_ENDORSER_TRANSACTION, chainID, lcccSpec, creator)
+	}
+	return CreateProposalFromCIS(common.HeaderType_ENDORSER_TRANSACTION, chainID, lcccSpec, creator)
+}
+
 // ComputeProposalTxID computes TxID as the Hash computed
 // over the concatenation of nonce and creator.
 func ComputeProposalTxID(nonce, creator []byte) (string, error) {
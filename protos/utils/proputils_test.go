@@ -386,6 +386,26 @@ func TestComputeProposalTxID(t *testing.T) {
 	assert.Equal(t, txid, txid2)
 }
 
+func TestQueryProposal(t *testing.T) {
+	prop, txid, err := utils.CreateQueryProposalFromCIS(common.HeaderType_ENDORSER_TRANSACTION, util.GetTestChainID(), createCIS(), []byte("creator"))
+	assert.NoError(t, err, "Failed creating query proposal")
+	assert.NotEmpty(t, txid, "TxID cannot be empty.")
+
+	readOnly, err := utils.IsReadOnlyProposal(prop)
+	assert.NoError(t, err)
+	assert.True(t, readOnly, "Query proposal should be read only")
+
+	prop, _, err = utils.CreateProposalFromCIS(common.HeaderType_ENDORSER_TRANSACTION, util.GetTestChainID(), createCIS(), []byte("creator"))
+	assert.NoError(t, err, "Failed creating proposal")
+
+	readOnly, err = utils.IsReadOnlyProposal(prop)
+	assert.NoError(t, err)
+	assert.False(t, readOnly, "Invoke proposal should not be read only")
+
+	_, err = utils.IsReadOnlyProposal(&pb.Proposal{Header: []byte("garbage")})
+	assert.Error(t, err)
+}
+
 var signer msp.SigningIdentity
 var signerSerialized []byte
 